@@ -0,0 +1,194 @@
+/*
+ * Copyright 2018 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package access controls which clients reach Alphas and Zeros, over HTTP and gRPC alike: the
+// clients must be in the allowlist of the endpoints they call, one for the admin endpoints and one
+// for the others, and every client is limited to a rate of requests.
+//
+// Clients are told apart by their IP address, as Dgraph has no users.
+package access
+
+import (
+	"fmt"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/spf13/pflag"
+	"github.com/spf13/viper"
+
+	"github.com/dgraph-io/dgraph/x"
+)
+
+// RegisterFlags registers the flags setting up the access control, for Alphas and Zeros alike.
+func RegisterFlags(flag *pflag.FlagSet) {
+	flag.String("admin_allowlist", "", "Comma separated IP addresses and CIDR ranges of the"+
+		" clients allowed to call the admin endpoints, like 10.0.0.0/8,192.168.1.7. Empty allows all.")
+	flag.String("data_allowlist", "", "Comma separated IP addresses and CIDR ranges of the"+
+		" clients allowed to call the endpoints other than the admin ones. Empty allows all.")
+	flag.Float64("rate_limit", 0, "Requests per second allowed from every client IP address."+
+		" Zero means unlimited.")
+	flag.Int("rate_burst", 0, "Requests a client can make at once, above --rate_limit."+
+		" Defaults to --rate_limit rounded up.")
+}
+
+// allowlist is a list of networks. An empty allowlist allows all.
+type allowlist []*net.IPNet
+
+func parseAllowlist(s string) (allowlist, error) {
+	var l allowlist
+	for _, entry := range strings.Split(s, ",") {
+		entry = strings.TrimSpace(entry)
+		if len(entry) == 0 {
+			continue
+		}
+		if !strings.Contains(entry, "/") {
+			ip := net.ParseIP(entry)
+			if ip == nil {
+				return nil, x.Errorf("Invalid IP address %q in allowlist", entry)
+			}
+			bits := 8 * net.IPv6len
+			if ip.To4() != nil {
+				ip, bits = ip.To4(), 8*net.IPv4len
+			}
+			l = append(l, &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)})
+			continue
+		}
+		_, network, err := net.ParseCIDR(entry)
+		if err != nil {
+			return nil, x.Errorf("Invalid CIDR range %q in allowlist", entry)
+		}
+		l = append(l, network)
+	}
+	return l, nil
+}
+
+func (l allowlist) allows(ip net.IP) bool {
+	if len(l) == 0 {
+		return true
+	}
+	for _, network := range l {
+		if network.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// List is a list of IP addresses and CIDR ranges, telling clients apart for other purposes than
+// access. Unlike an allowlist, an empty List holds no client.
+type List struct {
+	networks allowlist
+}
+
+// ParseList parses a List of comma separated IP addresses and CIDR ranges.
+func ParseList(s string) (*List, error) {
+	networks, err := parseAllowlist(s)
+	if err != nil {
+		return nil, err
+	}
+	return &List{networks: networks}, nil
+}
+
+// Contains tells whether the client at host is in l. A nil List holds no client.
+func (l *List) Contains(host string) bool {
+	if l == nil || len(l.networks) == 0 {
+		return false
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && l.networks.allows(ip)
+}
+
+// ErrNotAllowed is returned for the clients not in the allowlist of the endpoint they call.
+var ErrNotAllowed = x.Errorf("Client not in the allowlist of the endpoint")
+
+// RateLimitError is returned for the clients over their rate of requests.
+type RateLimitError struct {
+	RetryAfter time.Duration
+}
+
+func (e *RateLimitError) Error() string {
+	return fmt.Sprintf("Too many requests. Retry after %s.", e.RetryAfter)
+}
+
+// retryAfterSeconds returns the time to retry after in whole seconds, as used by the Retry-After
+// HTTP header. It's at least a second.
+func (e *RateLimitError) retryAfterSeconds() int64 {
+	secs := int64((e.RetryAfter + time.Second - 1) / time.Second)
+	if secs < 1 {
+		secs = 1
+	}
+	return secs
+}
+
+// Controller checks the requests of the clients against the allowlists and rate limits. A nil
+// Controller lets all requests in.
+type Controller struct {
+	admin, data allowlist
+	limiter     *limiter
+}
+
+// New returns the Controller with the given allowlists, as comma separated IP addresses and CIDR
+// ranges, and rate limit of every client. It returns nil if they allow everything.
+func New(admin, data string, rate float64, burst int) (*Controller, error) {
+	c := &Controller{}
+	var err error
+	if c.admin, err = parseAllowlist(admin); err != nil {
+		return nil, err
+	}
+	if c.data, err = parseAllowlist(data); err != nil {
+		return nil, err
+	}
+	if rate < 0 || burst < 0 {
+		return nil, x.Errorf("Rate limit and burst can't be negative")
+	}
+	if rate > 0 {
+		c.limiter = newLimiter(rate, burst)
+	}
+	if len(c.admin) == 0 && len(c.data) == 0 && c.limiter == nil {
+		return nil, nil
+	}
+	return c, nil
+}
+
+// FromConfig returns the Controller set up by the flags registered with RegisterFlags.
+func FromConfig(v *viper.Viper) (*Controller, error) {
+	return New(v.GetString("admin_allowlist"), v.GetString("data_allowlist"),
+		v.GetFloat64("rate_limit"), v.GetInt("rate_burst"))
+}
+
+// Check returns ErrNotAllowed if the client at host can't call the admin or other endpoints, or a
+// RateLimitError if it's over its rate.
+func (c *Controller) Check(host string, admin bool) error {
+	if c == nil {
+		return nil
+	}
+	ip := net.ParseIP(host)
+	list := c.data
+	if admin {
+		list = c.admin
+	}
+	if len(list) > 0 && (ip == nil || !list.allows(ip)) {
+		return ErrNotAllowed
+	}
+	if c.limiter == nil {
+		return nil
+	}
+	if wait := c.limiter.take(host, time.Now()); wait > 0 {
+		return &RateLimitError{RetryAfter: wait}
+	}
+	return nil
+}
@@ -0,0 +1,127 @@
+/*
+ * Copyright 2018 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package access
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAllowlist(t *testing.T) {
+	c, err := New("10.0.0.0/8, 192.168.1.7", "10.0.0.0/8,172.16.0.0/12,::1", 0, 0)
+	require.NoError(t, err)
+	tests := []struct {
+		host  string
+		admin bool
+		err   error
+	}{
+		{"10.1.2.3", true, nil},
+		{"192.168.1.7", true, nil},
+		{"192.168.1.8", true, ErrNotAllowed},
+		{"172.16.0.9", true, ErrNotAllowed},
+		{"172.16.0.9", false, nil},
+		{"192.168.1.7", false, ErrNotAllowed},
+		{"::1", false, nil},
+		{"not an ip", false, ErrNotAllowed},
+	}
+	for _, tc := range tests {
+		require.Equal(t, tc.err, c.Check(tc.host, tc.admin), "%+v", tc)
+	}
+
+	for _, list := range []string{"10.0.0.0/33", "10.0.0", "localhost"} {
+		_, err := New(list, "", 0, 0)
+		require.Error(t, err, list)
+	}
+
+	// Nothing to control.
+	c, err = New("", "", 0, 0)
+	require.NoError(t, err)
+	require.Nil(t, c)
+	require.NoError(t, c.Check("10.1.2.3", true))
+}
+
+func TestList(t *testing.T) {
+	l, err := ParseList("10.0.0.0/8, 192.168.1.7")
+	require.NoError(t, err)
+	require.True(t, l.Contains("10.1.2.3"))
+	require.True(t, l.Contains("192.168.1.7"))
+	require.False(t, l.Contains("192.168.1.8"))
+	require.False(t, l.Contains("not an ip"))
+
+	// Empty lists hold no client, unlike allowlists.
+	l, err = ParseList("")
+	require.NoError(t, err)
+	require.False(t, l.Contains("10.1.2.3"))
+	l = nil
+	require.False(t, l.Contains("10.1.2.3"))
+
+	_, err = ParseList("10.0.0")
+	require.Error(t, err)
+}
+
+func TestLimiter(t *testing.T) {
+	l := newLimiter(2, 3)
+	now := time.Now()
+	for i := 0; i < 3; i++ {
+		require.Zero(t, l.take("a", now))
+	}
+	require.Equal(t, 500*time.Millisecond, l.take("a", now))
+	// Other clients have their own buckets.
+	require.Zero(t, l.take("b", now))
+
+	now = now.Add(250 * time.Millisecond)
+	require.Equal(t, 250*time.Millisecond, l.take("a", now))
+	now = now.Add(250 * time.Millisecond)
+	require.Zero(t, l.take("a", now))
+
+	// The buckets refill up to the burst, and are then dropped.
+	now = now.Add(2 * sweepInterval)
+	require.Zero(t, l.take("c", now))
+	require.Len(t, l.buckets, 1)
+	for i := 0; i < 2; i++ {
+		require.Zero(t, l.take("a", now))
+	}
+}
+
+func TestHandler(t *testing.T) {
+	c, err := New("127.0.0.1", "", 1, 1)
+	require.NoError(t, err)
+	h := Handler(c, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("OK"))
+	}), func(path string) bool { return strings.HasPrefix(path, "/admin/") })
+
+	serve := func(path, remote string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest("GET", path, nil)
+		req.RemoteAddr = remote
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+		return rec
+	}
+	require.Equal(t, http.StatusOK, serve("/query", "10.0.0.1:1234").Code)
+	rec := serve("/query", "10.0.0.1:1235")
+	require.Equal(t, http.StatusTooManyRequests, rec.Code)
+	require.Equal(t, "1", rec.Header().Get("Retry-After"))
+
+	rec = serve("/admin/export", "10.0.0.2:1234")
+	require.Equal(t, http.StatusForbidden, rec.Code)
+	require.Equal(t, http.StatusOK, serve("/admin/export", "127.0.0.1:1234").Code)
+}
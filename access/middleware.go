@@ -0,0 +1,116 @@
+/*
+ * Copyright 2018 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package access
+
+import (
+	"net"
+	"net/http"
+	"strconv"
+
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+
+	"github.com/dgraph-io/dgraph/x"
+)
+
+// Handler returns h, only passing it the requests c lets in. isAdmin tells whether the endpoint
+// at a path is an admin one. If c is nil, h is returned as is.
+func Handler(c *Controller, h http.Handler, isAdmin func(path string) bool) http.Handler {
+	if c == nil {
+		return h
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		host, _, err := net.SplitHostPort(r.RemoteAddr)
+		if err != nil {
+			host = r.RemoteAddr
+		}
+		switch err := c.Check(host, isAdmin(r.URL.Path)).(type) {
+		case nil:
+			h.ServeHTTP(w, r)
+		case *RateLimitError:
+			w.Header().Set("Content-Type", "application/json")
+			w.Header().Set("Retry-After", strconv.FormatInt(err.retryAfterSeconds(), 10))
+			w.WriteHeader(http.StatusTooManyRequests)
+			x.SetStatus(w, x.ErrorTooManyRequests, err.Error())
+		default:
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusForbidden)
+			x.SetStatus(w, x.ErrorNoPermission, err.Error())
+		}
+	})
+}
+
+// peerHost returns the IP address of the gRPC client of ctx.
+func peerHost(ctx context.Context) string {
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.Addr == nil {
+		return ""
+	}
+	if tcp, ok := p.Addr.(*net.TCPAddr); ok {
+		return tcp.IP.String()
+	}
+	host, _, err := net.SplitHostPort(p.Addr.String())
+	if err != nil {
+		return p.Addr.String()
+	}
+	return host
+}
+
+// grpcError turns the error of Check into a gRPC status. Rate limited clients are told when to
+// retry in the retry-after header, in seconds.
+func grpcError(err error, setHeader func(metadata.MD) error) error {
+	switch err := err.(type) {
+	case nil:
+		return nil
+	case *RateLimitError:
+		setHeader(metadata.Pairs("retry-after", strconv.FormatInt(err.retryAfterSeconds(), 10)))
+		return status.Error(codes.ResourceExhausted, err.Error())
+	default:
+		return status.Error(codes.PermissionDenied, err.Error())
+	}
+}
+
+// ServerOptions returns the interceptors of a gRPC server only serving the requests c lets in.
+// isAdmin tells whether a method, like /api.Dgraph/Alter, is an admin one. Streams are checked
+// once, when they start.
+func ServerOptions(c *Controller, isAdmin func(method string) bool) []grpc.ServerOption {
+	if c == nil {
+		return nil
+	}
+	unary := func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler) (interface{}, error) {
+		err := c.Check(peerHost(ctx), isAdmin(info.FullMethod))
+		if err != nil {
+			return nil, grpcError(err, func(md metadata.MD) error {
+				return grpc.SetHeader(ctx, md)
+			})
+		}
+		return handler(ctx, req)
+	}
+	stream := func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo,
+		handler grpc.StreamHandler) error {
+		if err := c.Check(peerHost(ss.Context()), isAdmin(info.FullMethod)); err != nil {
+			return grpcError(err, ss.SetHeader)
+		}
+		return handler(srv, ss)
+	}
+	return []grpc.ServerOption{grpc.UnaryInterceptor(unary), grpc.StreamInterceptor(stream)}
+}
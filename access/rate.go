@@ -0,0 +1,88 @@
+/*
+ * Copyright 2018 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package access
+
+import (
+	"math"
+	"sync"
+	"time"
+)
+
+// sweepInterval is how often the buckets of the clients gone quiet are dropped.
+const sweepInterval = time.Minute
+
+// bucket is the token bucket of a client.
+type bucket struct {
+	tokens float64
+	at     time.Time
+}
+
+// limiter keeps a token bucket per client, refilled at rate tokens per second up to burst tokens.
+type limiter struct {
+	sync.Mutex
+	rate      float64
+	burst     float64
+	buckets   map[string]*bucket
+	lastSweep time.Time
+}
+
+func newLimiter(rate float64, burst int) *limiter {
+	if burst == 0 {
+		burst = int(math.Ceil(rate))
+	}
+	return &limiter{rate: rate, burst: float64(burst), buckets: make(map[string]*bucket)}
+}
+
+// fill refills b for the time since it was last taken from.
+func (l *limiter) fill(b *bucket, now time.Time) {
+	if elapsed := now.Sub(b.at).Seconds(); elapsed > 0 {
+		b.tokens = math.Min(l.burst, b.tokens+elapsed*l.rate)
+		b.at = now
+	}
+}
+
+// take takes a token from the bucket of key. If there's none, it returns how long until there's
+// one.
+func (l *limiter) take(key string, now time.Time) time.Duration {
+	l.Lock()
+	defer l.Unlock()
+	if now.Sub(l.lastSweep) > sweepInterval {
+		l.sweep(now)
+	}
+	b, ok := l.buckets[key]
+	if !ok {
+		b = &bucket{tokens: l.burst, at: now}
+		l.buckets[key] = b
+	}
+	l.fill(b, now)
+	if b.tokens >= 1 {
+		b.tokens--
+		return 0
+	}
+	return time.Duration((1 - b.tokens) / l.rate * float64(time.Second))
+}
+
+// sweep drops the full buckets, which are the same as new ones.
+func (l *limiter) sweep(now time.Time) {
+	for key, b := range l.buckets {
+		l.fill(b, now)
+		if b.tokens >= l.burst {
+			delete(l.buckets, key)
+		}
+	}
+	l.lastSweep = now
+}
@@ -0,0 +1,185 @@
+/*
+ * Copyright 2018 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package audit records who changed what in the cluster: every mutation, schema change, drop and
+// admin call, along with the client it came from and its outcome.
+//
+// The entries are JSON lines. Every entry has the hash of the previous one, and its own hash is
+// that of its JSON encoding without it, so that changing, removing or reordering entries breaks
+// the chain. Verify checks the chain of a log.
+package audit
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/dgraph-io/dgraph/x"
+)
+
+// maxDetails bounds the details of an entry, like the schema of an alter.
+const maxDetails = 4096
+
+// Entry is an entry of the audit log.
+type Entry struct {
+	Seq          uint64    `json:"seq"`
+	Time         time.Time `json:"time"`
+	Client       string    `json:"client,omitempty"`
+	ForwardedFor string    `json:"forwarded_for,omitempty"`
+	Namespace    string    `json:"namespace,omitempty"`
+	Op           string    `json:"op"`
+	Details      string    `json:"details,omitempty"`
+	Error        string    `json:"error,omitempty"`
+	PrevHash     string    `json:"prev_hash"`
+	Hash         string    `json:"hash"`
+}
+
+// hash returns the hash of e, which is that of its encoding without it.
+func (e Entry) hash() (string, error) {
+	e.Hash = ""
+	js, err := json.Marshal(e)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(js)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// sink is where the entries are written to, one line each.
+type sink interface {
+	write(line []byte) error
+	// last returns the last entry written by an earlier process, if any, to continue its chain.
+	last() (*Entry, error)
+	close() error
+}
+
+// Logger writes the entries of the audit log to its sink. A nil Logger logs nothing.
+type Logger struct {
+	sync.Mutex
+	sink sink
+	seq  uint64
+	prev string
+}
+
+// Open opens the audit log at target, either file=<path> for a file rotated once it's rotateMB
+// large, or syslog, syslog=<network>://<address> for the local or a remote syslog.
+func Open(target string, rotateMB int64) (*Logger, error) {
+	kv := strings.SplitN(target, "=", 2)
+	var s sink
+	var err error
+	switch {
+	case kv[0] == "file" && len(kv) == 2 && len(kv[1]) > 0:
+		s, err = openFile(kv[1], rotateMB<<20)
+	case kv[0] == "syslog" && len(kv) == 1:
+		s, err = openSyslog("", "")
+	case kv[0] == "syslog" && len(kv) == 2:
+		addr := strings.SplitN(kv[1], "://", 2)
+		if len(addr) != 2 {
+			return nil, x.Errorf("Invalid syslog address %q, like udp://host:514", kv[1])
+		}
+		s, err = openSyslog(addr[0], addr[1])
+	default:
+		return nil, x.Errorf("Invalid audit log %q. Use file=<path> or syslog[=<network>://<address>].",
+			target)
+	}
+	if err != nil {
+		return nil, err
+	}
+	l := &Logger{sink: s}
+	last, err := s.last()
+	if err != nil {
+		s.close()
+		return nil, x.Wrapf(err, "While reading the last audit log entry")
+	}
+	if last != nil {
+		l.seq, l.prev = last.Seq, last.Hash
+	}
+	return l, nil
+}
+
+// Log fills in the sequence number, time and hashes of e, and writes it.
+func (l *Logger) Log(e *Entry) error {
+	if l == nil {
+		return nil
+	}
+	if len(e.Details) > maxDetails {
+		e.Details = e.Details[:maxDetails] + "..."
+	}
+	l.Lock()
+	defer l.Unlock()
+	e.Seq = l.seq + 1
+	if e.Time.IsZero() {
+		e.Time = time.Now().UTC()
+	}
+	e.PrevHash = l.prev
+	var err error
+	if e.Hash, err = e.hash(); err != nil {
+		return err
+	}
+	js, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+	if err := l.sink.write(js); err != nil {
+		return err
+	}
+	l.seq, l.prev = e.Seq, e.Hash
+	return nil
+}
+
+// Close closes the sink of l.
+func (l *Logger) Close() error {
+	if l == nil {
+		return nil
+	}
+	l.Lock()
+	defer l.Unlock()
+	return l.sink.close()
+}
+
+// Verify checks the chain of the entries read from r, which must follow the entry with the hash
+// prev, if not empty. It returns the last entry, to verify the next file of a rotated log.
+func Verify(r io.Reader, prev string) (*Entry, error) {
+	var last *Entry
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64<<10), 1<<20)
+	for line := 1; scanner.Scan(); line++ {
+		var e Entry
+		if err := json.Unmarshal(scanner.Bytes(), &e); err != nil {
+			return last, x.Errorf("Line %d: %v", line, err)
+		}
+		hash, err := e.hash()
+		if err != nil {
+			return last, err
+		}
+		switch {
+		case hash != e.Hash:
+			return last, x.Errorf("Line %d: entry %d was changed", line, e.Seq)
+		case last == nil && len(prev) == 0:
+			// The start of the log.
+		case e.PrevHash != prev || (last != nil && e.Seq != last.Seq+1):
+			return last, x.Errorf("Line %d: entries before entry %d are missing or were changed",
+				line, e.Seq)
+		}
+		last, prev = &e, e.Hash
+	}
+	return last, scanner.Err()
+}
@@ -0,0 +1,165 @@
+/*
+ * Copyright 2018 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package audit
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func logEntries(t *testing.T, l *Logger, ops ...string) {
+	for _, op := range ops {
+		require.NoError(t, l.Log(&Entry{Client: "127.0.0.1", Op: op}))
+	}
+}
+
+func TestAuditChain(t *testing.T) {
+	dir, err := ioutil.TempDir("", "audit")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "audit.log")
+
+	l, err := Open("file="+path, 0)
+	require.NoError(t, err)
+	logEntries(t, l, "mutate", "alter")
+	require.NoError(t, l.Close())
+
+	// The chain continues after a restart.
+	l, err = Open("file="+path, 0)
+	require.NoError(t, err)
+	logEntries(t, l, "commit")
+	require.NoError(t, l.Close())
+
+	data, err := ioutil.ReadFile(path)
+	require.NoError(t, err)
+	last, err := Verify(bytes.NewReader(data), "")
+	require.NoError(t, err)
+	require.Equal(t, uint64(3), last.Seq)
+
+	lines := strings.SplitAfter(string(data), "\n")
+	changed := strings.Replace(string(data), `"op":"alter"`, `"op":"query"`, 1)
+	_, err = Verify(strings.NewReader(changed), "")
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "entry 2 was changed")
+
+	removed := lines[0] + lines[2]
+	_, err = Verify(strings.NewReader(removed), "")
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "before entry 3")
+
+	reordered := lines[1] + lines[0] + lines[2]
+	_, err = Verify(strings.NewReader(reordered), "")
+	require.Error(t, err)
+}
+
+func TestAuditRotation(t *testing.T) {
+	dir, err := ioutil.TempDir("", "audit")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "audit.log")
+
+	l, err := Open("file="+path, 0)
+	require.NoError(t, err)
+	// Rotate after every entry.
+	l.sink.(*fileSink).max = 1
+	logEntries(t, l, "mutate", "alter", "commit")
+	require.NoError(t, l.Close())
+
+	rotated, err := filepath.Glob(path + ".*")
+	require.NoError(t, err)
+	sort.Strings(rotated)
+	files := append(rotated, path)
+	require.Len(t, files, 3)
+
+	var prev string
+	for i, file := range files {
+		data, err := ioutil.ReadFile(file)
+		require.NoError(t, err)
+		last, err := Verify(bytes.NewReader(data), prev)
+		require.NoError(t, err)
+		require.Equal(t, uint64(i+1), last.Seq)
+		prev = last.Hash
+	}
+
+	// The last file doesn't follow the first one.
+	data, err := ioutil.ReadFile(files[0])
+	require.NoError(t, err)
+	first, err := Verify(bytes.NewReader(data), "")
+	require.NoError(t, err)
+	data, err = ioutil.ReadFile(files[2])
+	require.NoError(t, err)
+	_, err = Verify(bytes.NewReader(data), first.Hash)
+	require.Error(t, err)
+}
+
+func TestAuditOpen(t *testing.T) {
+	for _, target := range []string{"", "file", "file=", "syslog=localhost", "kafka=x"} {
+		_, err := Open(target, 0)
+		require.Error(t, err, target)
+	}
+	var l *Logger
+	require.NoError(t, l.Log(&Entry{Op: "mutate"}))
+	require.NoError(t, l.Close())
+}
+
+func TestAuditHandler(t *testing.T) {
+	dir, err := ioutil.TempDir("", "audit")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "audit.log")
+	l, err := Open("file="+path, 0)
+	require.NoError(t, err)
+
+	h := Handler(l, "admin", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("fail") == "1" {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"errors":[{"code":"ErrorInvalidRequest","message":"Bad tablet"}]}`))
+			return
+		}
+		w.Write([]byte("OK"))
+	})
+	for _, uri := range []string{"/moveTablet?tablet=name", "/moveTablet?fail=1"} {
+		req := httptest.NewRequest("GET", uri, nil)
+		req.RemoteAddr = "10.0.0.1:4242"
+		req.Header.Set("X-Forwarded-For", "192.168.0.1")
+		h(httptest.NewRecorder(), req)
+	}
+	require.NoError(t, l.Close())
+
+	data, err := ioutil.ReadFile(path)
+	require.NoError(t, err)
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	require.Len(t, lines, 2)
+	var ok, failed Entry
+	require.NoError(t, json.Unmarshal([]byte(lines[0]), &ok))
+	require.NoError(t, json.Unmarshal([]byte(lines[1]), &failed))
+	require.Equal(t, "10.0.0.1", ok.Client)
+	require.Equal(t, "192.168.0.1", ok.ForwardedFor)
+	require.Equal(t, "GET /moveTablet?tablet=name", ok.Details)
+	require.Empty(t, ok.Error)
+	require.Equal(t, "ErrorInvalidRequest: Bad tablet", failed.Error)
+}
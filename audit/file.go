@@ -0,0 +1,109 @@
+/*
+ * Copyright 2018 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package audit
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"os"
+	"time"
+)
+
+// fileSink appends the entries to a file, which is renamed after the time of its rotation once it
+// grows over max bytes. The chain continues in the new file.
+type fileSink struct {
+	path string
+	max  int64
+	f    *os.File
+	size int64
+}
+
+func openFile(path string, max int64) (*fileSink, error) {
+	s := &fileSink{path: path, max: max}
+	return s, s.open()
+}
+
+func (s *fileSink) open() error {
+	f, err := os.OpenFile(s.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+	fi, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return err
+	}
+	s.f, s.size = f, fi.Size()
+	return nil
+}
+
+func (s *fileSink) rotate() error {
+	if err := s.f.Close(); err != nil {
+		return err
+	}
+	rotated := s.path + "." + time.Now().UTC().Format("20060102T150405.000000000")
+	if err := os.Rename(s.path, rotated); err != nil {
+		return err
+	}
+	return s.open()
+}
+
+func (s *fileSink) write(line []byte) error {
+	if s.max > 0 && s.size > 0 && s.size+int64(len(line))+1 > s.max {
+		if err := s.rotate(); err != nil {
+			return err
+		}
+	}
+	n, err := s.f.Write(append(line, '\n'))
+	s.size += int64(n)
+	return err
+}
+
+// last reads the last line of the file, which entries keep small.
+func (s *fileSink) last() (*Entry, error) {
+	f, err := os.Open(s.path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	const tail = 2 * maxDetails
+	start := s.size - tail
+	if start < 0 {
+		start = 0
+	}
+	buf := make([]byte, s.size-start)
+	if _, err := f.ReadAt(buf, start); err != nil && err != io.EOF {
+		return nil, err
+	}
+	buf = bytes.TrimRight(buf, "\n")
+	if len(buf) == 0 {
+		return nil, nil
+	}
+	if i := bytes.LastIndexByte(buf, '\n'); i >= 0 {
+		buf = buf[i+1:]
+	}
+	var e Entry
+	if err := json.Unmarshal(buf, &e); err != nil {
+		return nil, err
+	}
+	return &e, nil
+}
+
+func (s *fileSink) close() error {
+	return s.f.Close()
+}
@@ -0,0 +1,105 @@
+/*
+ * Copyright 2018 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package audit
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+
+	"github.com/golang/glog"
+)
+
+// maxResponseHead is how much of the response is kept to find its error, if any.
+const maxResponseHead = 4096
+
+// recorder keeps the status and the start of a response.
+type recorder struct {
+	http.ResponseWriter
+	status int
+	head   []byte
+}
+
+func (r *recorder) WriteHeader(status int) {
+	if r.status == 0 {
+		r.status = status
+	}
+	r.ResponseWriter.WriteHeader(status)
+}
+
+func (r *recorder) Write(b []byte) (int, error) {
+	if r.status == 0 {
+		r.status = http.StatusOK
+	}
+	if n := maxResponseHead - len(r.head); n > 0 {
+		if n > len(b) {
+			n = len(b)
+		}
+		r.head = append(r.head, b[:n]...)
+	}
+	return r.ResponseWriter.Write(b)
+}
+
+// responseError returns the error of the response, from its status or from the errors the
+// handlers reply with.
+func (r *recorder) responseError() string {
+	var res struct {
+		Errors []struct {
+			Code    string `json:"code"`
+			Message string `json:"message"`
+		} `json:"errors"`
+	}
+	if err := json.Unmarshal(r.head, &res); err == nil && len(res.Errors) > 0 {
+		return fmt.Sprintf("%s: %s", res.Errors[0].Code, res.Errors[0].Message)
+	}
+	if r.status >= 400 {
+		return http.StatusText(r.status)
+	}
+	return ""
+}
+
+// Client returns the address of the client of r, without its port.
+func Client(r *http.Request) string {
+	if host, _, err := net.SplitHostPort(r.RemoteAddr); err == nil {
+		return host
+	}
+	return r.RemoteAddr
+}
+
+// Handler returns h, logging every request to it in l as the operation op. If l is nil, h is
+// returned as is.
+func Handler(l *Logger, op string, h http.HandlerFunc) http.HandlerFunc {
+	if l == nil {
+		return h
+	}
+	return func(w http.ResponseWriter, r *http.Request) {
+		rec := &recorder{ResponseWriter: w}
+		h(rec, r)
+		e := &Entry{
+			Client:       Client(r),
+			ForwardedFor: r.Header.Get("X-Forwarded-For"),
+			Namespace:    r.Header.Get("X-Dgraph-Namespace"),
+			Op:           op,
+			Details:      r.Method + " " + r.URL.RequestURI(),
+			Error:        rec.responseError(),
+		}
+		if err := l.Log(e); err != nil {
+			glog.Errorf("While writing to the audit log: %v", err)
+		}
+	}
+}
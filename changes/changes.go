@@ -0,0 +1,368 @@
+/*
+ * Copyright 2018 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package changes publishes the changes committed to the data to message brokers, so that other
+// systems can react to them without polling.
+//
+// Every edge set or deleted by a committed transaction is an event. The leader of each group
+// publishes the events of its own predicates once they're committed, in the order of the
+// commits. Events are routed to topics by their predicate, with a comma separated list of rules
+// of the form <predicate>=<sink>:<topic>, like
+//
+//   name=nats:people.names    The changes to name go to the NATS subject people.names.
+//   friend*=pubsub:friends    The changes to the predicates starting with friend go to the Pub/Sub
+//                             topic friends.
+//   *=nats:dgraph.changes     All other changes go to the subject dgraph.changes.
+//
+// The first rule matching a predicate applies, and the changes no rule matches aren't published.
+// The sinks are given as a comma separated list of kind=address pairs:
+//
+//   nats=nats://[user:password@]host:4222   NATS JetStream. The subjects must belong to a stream.
+//   pubsub=<project>                        Google Pub/Sub topics of the project.
+//
+// Events are published at least once while the leader stays the same: batches are retried until
+// the broker stores them, and the NATS sink sets an id on every message so that JetStream can
+// drop the duplicates. The events waiting to be published are held in memory, so those of a
+// leader which goes away, or which can't be published for too long, are lost. They're counted
+// in the dgraph_change_events_total metric, as dropped.
+package changes
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang/glog"
+
+	"github.com/dgraph-io/dgraph/x"
+)
+
+const (
+	// Event ops.
+	OpSet    = "set"
+	OpDelete = "delete"
+
+	publishTimeout  = 10 * time.Second
+	publishAttempts = 5
+	queueSize       = 1000 // Commits waiting to be published.
+)
+
+// Event is a change to an edge, committed at CommitTs.
+type Event struct {
+	CommitTs  uint64 `json:"commit_ts"`
+	Namespace string `json:"namespace,omitempty"`
+	Predicate string `json:"predicate"`
+	Uid       string `json:"uid"`
+	Op        string `json:"op"`
+	// Object is the uid the edge points to, for uid predicates. Otherwise, Value is the value of
+	// the edge, or * if all the values of the predicate were deleted.
+	Object string `json:"object,omitempty"`
+	Value  string `json:"value,omitempty"`
+	Lang   string `json:"lang,omitempty"`
+}
+
+// id is unique to an event, for the brokers to drop the duplicates of retried publishes.
+func (e *Event) id() string {
+	return fmt.Sprintf("%d/%s/%s/%s/%s/%s/%s", e.CommitTs, e.Namespace, e.Predicate, e.Uid, e.Op,
+		e.Object+e.Value, e.Lang)
+}
+
+// Message is what's published to a topic for an event.
+type Message struct {
+	ID    string
+	Data  []byte
+	Attrs map[string]string
+}
+
+// Sink is a message broker the events can be published to.
+type Sink interface {
+	// Publish publishes msgs to topic, returning once the broker has stored all of them.
+	Publish(ctx context.Context, topic string, msgs []*Message) error
+	Close() error
+}
+
+// Route sends the changes to the predicates matching Predicate to Topic of Sink. Predicate
+// can end with *, to match all the predicates starting with what comes before it.
+type Route struct {
+	Predicate string
+	Sink      string
+	Topic     string
+}
+
+func (r Route) matches(pred string) bool {
+	if strings.HasSuffix(r.Predicate, "*") {
+		return strings.HasPrefix(pred, strings.TrimSuffix(r.Predicate, "*"))
+	}
+	return r.Predicate == pred
+}
+
+func (r Route) String() string {
+	return fmt.Sprintf("%s=%s:%s", r.Predicate, r.Sink, r.Topic)
+}
+
+// ParseRoutes parses a comma separated list of routing rules.
+func ParseRoutes(rules string) ([]Route, error) {
+	var routes []Route
+	for _, rule := range strings.Split(rules, ",") {
+		rule = strings.TrimSpace(rule)
+		if len(rule) == 0 {
+			continue
+		}
+		kv := strings.SplitN(rule, "=", 2)
+		if len(kv) != 2 {
+			return nil, x.Errorf("Invalid change stream route %q. Use <predicate>=<sink>:<topic>.",
+				rule)
+		}
+		dest := strings.SplitN(kv[1], ":", 2)
+		if len(kv[0]) == 0 || len(dest) != 2 || len(dest[0]) == 0 || len(dest[1]) == 0 {
+			return nil, x.Errorf("Invalid change stream route %q. Use <predicate>=<sink>:<topic>.",
+				rule)
+		}
+		if strings.Contains(strings.TrimSuffix(kv[0], "*"), "*") {
+			return nil, x.Errorf("Invalid change stream route %q. Only a trailing * is allowed.",
+				rule)
+		}
+		routes = append(routes, Route{Predicate: kv[0], Sink: dest[0], Topic: dest[1]})
+	}
+	return routes, nil
+}
+
+// ParseSinks parses a comma separated list of kind=address pairs into the sinks by kind.
+func ParseSinks(targets string) (map[string]Sink, error) {
+	sinks := make(map[string]Sink)
+	for _, target := range strings.Split(targets, ",") {
+		target = strings.TrimSpace(target)
+		if len(target) == 0 {
+			continue
+		}
+		kv := strings.SplitN(target, "=", 2)
+		if len(kv) != 2 || len(kv[1]) == 0 {
+			return nil, x.Errorf("Invalid change stream sink %q. Use kind=address.", target)
+		}
+		if _, ok := sinks[kv[0]]; ok {
+			return nil, x.Errorf("Change stream sink %s given more than once", kv[0])
+		}
+		var sink Sink
+		var err error
+		switch kv[0] {
+		case "nats":
+			sink, err = newNatsSink(kv[1])
+		case "pubsub":
+			sink, err = newPubsubSink(kv[1])
+		default:
+			err = x.Errorf("Unknown change stream sink %q. Must be nats or pubsub.", kv[0])
+		}
+		if err != nil {
+			return nil, err
+		}
+		sinks[kv[0]] = sink
+	}
+	return sinks, nil
+}
+
+// Stream routes the events of the commits it's given to its sinks, publishing them in the
+// background.
+type Stream struct {
+	sinks map[string]Sink
+
+	sync.RWMutex
+	routes []Route
+
+	pending chan []*Event
+	done    chan struct{}
+	stopped chan struct{}
+}
+
+// New returns a stream publishing to the sinks in targets, as routed by the rules in routes. If
+// there are no targets, the stream is disabled and nil is returned.
+func New(targets, routes string) (*Stream, error) {
+	sinks, err := ParseSinks(targets)
+	if err != nil || len(sinks) == 0 {
+		return nil, err
+	}
+	s := newStream(sinks)
+	if err := s.SetRoutes(routes); err != nil {
+		return nil, err
+	}
+	go s.run()
+	return s, nil
+}
+
+func newStream(sinks map[string]Sink) *Stream {
+	return &Stream{
+		sinks:   sinks,
+		pending: make(chan []*Event, queueSize),
+		done:    make(chan struct{}),
+		stopped: make(chan struct{}),
+	}
+}
+
+// CheckRoutes parses rules, and checks that their sinks are those of the stream.
+func (s *Stream) CheckRoutes(rules string) ([]Route, error) {
+	routes, err := ParseRoutes(rules)
+	if err != nil {
+		return nil, err
+	}
+	for _, r := range routes {
+		if _, ok := s.sinks[r.Sink]; !ok {
+			return nil, x.Errorf("Change stream route %s is to sink %s, which isn't set up."+
+				" Set it with --change_stream.", r, r.Sink)
+		}
+	}
+	return routes, nil
+}
+
+// SetRoutes replaces the routing rules by those in rules.
+func (s *Stream) SetRoutes(rules string) error {
+	routes, err := s.CheckRoutes(rules)
+	if err != nil {
+		return err
+	}
+	s.Lock()
+	defer s.Unlock()
+	s.routes = routes
+	return nil
+}
+
+// Routes returns the routing rules, in the same form as they're set.
+func (s *Stream) Routes() string {
+	s.RLock()
+	defer s.RUnlock()
+	var rules []string
+	for _, r := range s.routes {
+		rules = append(rules, r.String())
+	}
+	return strings.Join(rules, ",")
+}
+
+func (s *Stream) route(pred string) (Route, bool) {
+	s.RLock()
+	defer s.RUnlock()
+	for _, r := range s.routes {
+		if r.matches(pred) {
+			return r, true
+		}
+	}
+	return Route{}, false
+}
+
+// Publish queues the events of a commit to be published. It doesn't block: if too many commits
+// are waiting already, the events are dropped.
+func (s *Stream) Publish(events []*Event) {
+	if len(events) == 0 {
+		return
+	}
+	select {
+	case s.pending <- events:
+	default:
+		x.ChangeEvents.Add("dropped", int64(len(events)))
+		glog.Warningf("Dropped %d changes committed at %d, as too many are waiting to be"+
+			" published.", len(events), events[0].CommitTs)
+	}
+}
+
+// Close stops publishing, and closes the sinks. The events not yet published are dropped.
+func (s *Stream) Close() {
+	close(s.done)
+	<-s.stopped
+	for kind, sink := range s.sinks {
+		if err := sink.Close(); err != nil {
+			glog.Warningf("While closing the change stream sink %s: %v", kind, err)
+		}
+	}
+}
+
+func (s *Stream) run() {
+	defer close(s.stopped)
+	for {
+		select {
+		case events := <-s.pending:
+			s.publish(events)
+		case <-s.done:
+			return
+		}
+	}
+}
+
+type destination struct {
+	sink, topic string
+}
+
+// publish publishes the events of a commit, one batch per topic.
+func (s *Stream) publish(events []*Event) {
+	batches := make(map[destination][]*Message)
+	for _, e := range events {
+		r, ok := s.route(e.Predicate)
+		if !ok {
+			continue
+		}
+		data, err := json.Marshal(e)
+		x.Check(err)
+		d := destination{sink: r.Sink, topic: r.Topic}
+		batches[d] = append(batches[d], &Message{
+			ID:    e.id(),
+			Data:  data,
+			Attrs: map[string]string{"predicate": e.Predicate, "op": e.Op},
+		})
+	}
+	dests := make([]destination, 0, len(batches))
+	for d := range batches {
+		dests = append(dests, d)
+	}
+	sort.Slice(dests, func(i, j int) bool {
+		return dests[i].sink+dests[i].topic < dests[j].sink+dests[j].topic
+	})
+
+	for _, d := range dests {
+		msgs := batches[d]
+		if err := s.publishBatch(s.sinks[d.sink], d.topic, msgs); err != nil {
+			x.ChangeEvents.Add("dropped", int64(len(msgs)))
+			glog.Errorf("Dropped %d changes committed at %d, unable to publish them to %s:%s: %v",
+				len(msgs), events[0].CommitTs, d.sink, d.topic, err)
+			continue
+		}
+		x.ChangeEvents.Add("published", int64(len(msgs)))
+	}
+}
+
+// publishBatch publishes msgs to the topic of sink, retrying with a backoff until the stream is
+// closed.
+func (s *Stream) publishBatch(sink Sink, topic string, msgs []*Message) error {
+	backoff := 100 * time.Millisecond
+	var err error
+	for attempt := 0; attempt < publishAttempts; attempt++ {
+		if attempt > 0 {
+			select {
+			case <-time.After(backoff):
+				backoff *= 2
+			case <-s.done:
+				return err
+			}
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), publishTimeout)
+		err = sink.Publish(ctx, topic, msgs)
+		cancel()
+		if err == nil {
+			return nil
+		}
+		glog.Warningf("While publishing %d changes to %s: %v", len(msgs), topic, err)
+	}
+	return err
+}
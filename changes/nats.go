@@ -0,0 +1,287 @@
+/*
+ * Copyright 2018 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package changes
+
+import (
+	"bufio"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/dgraph-io/dgraph/x"
+)
+
+// The NATS sink speaks the text protocol of NATS over a single connection. Messages are published
+// to JetStream with a reply subject, which the server answers once the message is stored in the
+// stream of the subject. The id of every message is set in its Nats-Msg-Id header, for JetStream
+// to drop the duplicates within its deduplication window.
+
+const natsDialTimeout = 5 * time.Second
+
+type natsSink struct {
+	addr                  string // host:port
+	user, password, token string
+
+	sync.Mutex
+	conn  net.Conn
+	r     *bufio.Reader
+	inbox string
+}
+
+func newNatsSink(addr string) (*natsSink, error) {
+	if !strings.Contains(addr, "://") {
+		addr = "nats://" + addr
+	}
+	u, err := url.Parse(addr)
+	if err != nil || u.Scheme != "nats" || len(u.Host) == 0 {
+		return nil, x.Errorf("Invalid NATS address %q. Use nats://[user:password@]host:port.", addr)
+	}
+	s := &natsSink{addr: u.Host}
+	if u.Port() == "" {
+		s.addr = net.JoinHostPort(u.Hostname(), "4222")
+	}
+	if u.User != nil {
+		if pass, ok := u.User.Password(); ok {
+			s.user, s.password = u.User.Username(), pass
+		} else {
+			s.token = u.User.Username()
+		}
+	}
+	return s, nil
+}
+
+type natsInfo struct {
+	Headers      bool `json:"headers"`
+	AuthRequired bool `json:"auth_required"`
+}
+
+type natsConnect struct {
+	Verbose  bool   `json:"verbose"`
+	Pedantic bool   `json:"pedantic"`
+	Headers  bool   `json:"headers"`
+	Name     string `json:"name"`
+	Lang     string `json:"lang"`
+	Version  string `json:"version"`
+	Protocol int    `json:"protocol"`
+	User     string `json:"user,omitempty"`
+	Pass     string `json:"pass,omitempty"`
+	Token    string `json:"auth_token,omitempty"`
+}
+
+// connect connects to the server, and subscribes to the inbox the acks are sent to.
+func (s *natsSink) connect(ctx context.Context) error {
+	d := net.Dialer{Timeout: natsDialTimeout}
+	conn, err := d.DialContext(ctx, "tcp", s.addr)
+	if err != nil {
+		return err
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		conn.SetDeadline(deadline)
+	}
+	r := bufio.NewReader(conn)
+	line, err := readNatsLine(r)
+	if err != nil {
+		conn.Close()
+		return err
+	}
+	var info natsInfo
+	if !strings.HasPrefix(line, "INFO ") ||
+		json.Unmarshal([]byte(strings.TrimPrefix(line, "INFO ")), &info) != nil {
+		conn.Close()
+		return x.Errorf("Unexpected greeting from NATS server %s: %q", s.addr, line)
+	}
+	if !info.Headers {
+		conn.Close()
+		return x.Errorf("NATS server %s doesn't support headers, which JetStream needs", s.addr)
+	}
+
+	connect, err := json.Marshal(natsConnect{Headers: true, Name: "dgraph", Lang: "go",
+		Version: x.Version(), Protocol: 1, User: s.user, Pass: s.password, Token: s.token})
+	x.Check(err)
+	var id [8]byte
+	if _, err := rand.Read(id[:]); err != nil {
+		conn.Close()
+		return err
+	}
+	inbox := "_INBOX." + hex.EncodeToString(id[:])
+	if _, err := fmt.Fprintf(conn, "CONNECT %s\r\nSUB %s.* 1\r\nPING\r\n", connect,
+		inbox); err != nil {
+		conn.Close()
+		return err
+	}
+	for {
+		line, err := readNatsLine(r)
+		if err != nil {
+			conn.Close()
+			return err
+		}
+		switch {
+		case line == "PONG":
+			s.conn, s.r, s.inbox = conn, r, inbox
+			return nil
+		case strings.HasPrefix(line, "-ERR"):
+			conn.Close()
+			return x.Errorf("NATS server %s refused the connection: %s", s.addr, line)
+		}
+	}
+}
+
+func readNatsLine(r *bufio.Reader) (string, error) {
+	line, err := r.ReadString('\n')
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimRight(line, "\r\n"), nil
+}
+
+type natsAck struct {
+	Stream string `json:"stream"`
+	Seq    uint64 `json:"seq"`
+	Error  *struct {
+		Code        int    `json:"code"`
+		Description string `json:"description"`
+	} `json:"error"`
+}
+
+func (s *natsSink) Publish(ctx context.Context, subject string, msgs []*Message) error {
+	s.Lock()
+	defer s.Unlock()
+	if s.conn == nil {
+		if err := s.connect(ctx); err != nil {
+			return err
+		}
+	}
+	err := s.publish(ctx, subject, msgs)
+	if err != nil {
+		// The state of the connection isn't known anymore, so the next publish starts anew.
+		s.conn.Close()
+		s.conn = nil
+	}
+	return err
+}
+
+func (s *natsSink) publish(ctx context.Context, subject string, msgs []*Message) error {
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		deadline = time.Now().Add(publishTimeout)
+	}
+	if err := s.conn.SetDeadline(deadline); err != nil {
+		return err
+	}
+
+	w := bufio.NewWriter(s.conn)
+	for i, msg := range msgs {
+		hdr := fmt.Sprintf("NATS/1.0\r\nNats-Msg-Id: %s\r\n\r\n", msg.ID)
+		fmt.Fprintf(w, "HPUB %s %s.%d %d %d\r\n%s", subject, s.inbox, i, len(hdr),
+			len(hdr)+len(msg.Data), hdr)
+		w.Write(msg.Data)
+		w.WriteString("\r\n")
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	acked := make([]bool, len(msgs))
+	for left := len(msgs); left > 0; {
+		line, err := readNatsLine(s.r)
+		if err != nil {
+			return err
+		}
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+		switch fields[0] {
+		case "PING":
+			if _, err := io.WriteString(s.conn, "PONG\r\n"); err != nil {
+				return err
+			}
+			continue
+		case "+OK", "PONG", "INFO":
+			continue
+		case "-ERR":
+			return x.Errorf("NATS server %s: %s", s.addr, line)
+		case "MSG", "HMSG":
+		default:
+			return x.Errorf("Unexpected message from NATS server %s: %q", s.addr, line)
+		}
+
+		// MSG <subject> <sid> <size> or HMSG <subject> <sid> <header size> <size>.
+		if (fields[0] == "MSG" && len(fields) != 4) || (fields[0] == "HMSG" && len(fields) != 5) {
+			return x.Errorf("Malformed message from NATS server %s: %q", s.addr, line)
+		}
+		size, err := strconv.Atoi(fields[len(fields)-1])
+		if err != nil {
+			return x.Errorf("Malformed message from NATS server %s: %q", s.addr, line)
+		}
+		body := make([]byte, size+2)
+		if _, err := io.ReadFull(s.r, body); err != nil {
+			return err
+		}
+		body = body[:size]
+		if fields[0] == "HMSG" {
+			hsize, err := strconv.Atoi(fields[3])
+			if err != nil || hsize > size {
+				return x.Errorf("Malformed message from NATS server %s: %q", s.addr, line)
+			}
+			// A status in the headers, like 503 if no stream has the subject.
+			status := strings.Fields(strings.SplitN(string(body[:hsize]), "\r\n", 2)[0])
+			if len(status) > 1 {
+				return x.Errorf("Unable to publish to %s: NATS status %s", subject,
+					strings.Join(status[1:], " "))
+			}
+			body = body[hsize:]
+		}
+
+		idx, err := strconv.Atoi(strings.TrimPrefix(fields[1], s.inbox+"."))
+		if err != nil || idx < 0 || idx >= len(msgs) || acked[idx] {
+			// Not an ack of this publish.
+			continue
+		}
+		var ack natsAck
+		if err := json.Unmarshal(body, &ack); err != nil {
+			return x.Errorf("Invalid ack from NATS server %s: %q", s.addr, body)
+		}
+		if ack.Error != nil {
+			return x.Errorf("Unable to publish to %s: %s (%d)", subject, ack.Error.Description,
+				ack.Error.Code)
+		}
+		acked[idx] = true
+		left--
+	}
+	return nil
+}
+
+func (s *natsSink) Close() error {
+	s.Lock()
+	defer s.Unlock()
+	if s.conn == nil {
+		return nil
+	}
+	err := s.conn.Close()
+	s.conn = nil
+	return err
+}
@@ -0,0 +1,131 @@
+/*
+ * Copyright 2018 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package changes
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// fakeJetStream serves the part of the NATS protocol the sink uses. The subjects starting with
+// "missing" belong to no stream.
+type fakeJetStream struct {
+	ln net.Listener
+
+	sync.Mutex
+	connect string
+	ids     []string
+	data    []string
+}
+
+func newFakeJetStream(t *testing.T) *fakeJetStream {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	js := &fakeJetStream{ln: ln}
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			go js.serve(conn)
+		}
+	}()
+	return js
+}
+
+func (js *fakeJetStream) serve(conn net.Conn) {
+	defer conn.Close()
+	r := bufio.NewReader(conn)
+	fmt.Fprintf(conn, "INFO {\"headers\":true}\r\n")
+	var seq int
+	for {
+		line, err := readNatsLine(r)
+		if err != nil {
+			return
+		}
+		fields := strings.Fields(line)
+		switch fields[0] {
+		case "CONNECT":
+			js.Lock()
+			js.connect = strings.TrimPrefix(line, "CONNECT ")
+			js.Unlock()
+		case "PING":
+			fmt.Fprintf(conn, "PONG\r\n")
+		case "HPUB":
+			// HPUB <subject> <reply> <header size> <size>
+			hsize, _ := strconv.Atoi(fields[3])
+			size, _ := strconv.Atoi(fields[4])
+			body := make([]byte, size+2)
+			if _, err := io.ReadFull(r, body); err != nil {
+				return
+			}
+			if strings.HasPrefix(fields[1], "missing") {
+				status := "NATS/1.0 503\r\n\r\n"
+				fmt.Fprintf(conn, "HMSG %s 1 %d %d\r\n%s\r\n", fields[2], len(status),
+					len(status), status)
+				continue
+			}
+			hdr := string(body[:hsize])
+			js.Lock()
+			js.ids = append(js.ids, strings.TrimSpace(strings.Split(hdr, "Nats-Msg-Id:")[1]))
+			js.data = append(js.data, string(body[hsize:size]))
+			js.Unlock()
+			seq++
+			ack := fmt.Sprintf(`{"stream":"changes","seq":%d}`, seq)
+			fmt.Fprintf(conn, "MSG %s 1 %d\r\n%s\r\n", fields[2], len(ack), ack)
+		}
+	}
+}
+
+func TestNatsSink(t *testing.T) {
+	js := newFakeJetStream(t)
+	defer js.ln.Close()
+
+	sink, err := newNatsSink("nats://secret@" + js.ln.Addr().String())
+	require.NoError(t, err)
+	defer sink.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	msgs := []*Message{{ID: "1", Data: []byte(`{"uid":"0x1"}`)}, {ID: "2", Data: []byte("2")}}
+	require.NoError(t, sink.Publish(ctx, "changes.name", msgs))
+	require.NoError(t, sink.Publish(ctx, "changes.name", msgs[1:]))
+
+	js.Lock()
+	require.Contains(t, js.connect, `"auth_token":"secret"`)
+	require.Contains(t, js.connect, `"headers":true`)
+	require.Equal(t, []string{"1", "2", "2"}, js.ids)
+	require.Equal(t, []string{`{"uid":"0x1"}`, "2", "2"}, js.data)
+	js.Unlock()
+
+	err = sink.Publish(ctx, "missing.name", msgs)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "NATS status 503")
+	// The sink reconnects after the failure.
+	require.NoError(t, sink.Publish(ctx, "changes.name", msgs[:1]))
+}
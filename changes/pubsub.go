@@ -0,0 +1,185 @@
+/*
+ * Copyright 2018 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package changes
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/dgraph-io/dgraph/x"
+)
+
+// The Pub/Sub sink publishes with the REST API of Google Pub/Sub. It authenticates with the
+// access tokens of the service account of the instance, as served by the metadata server of
+// Google Cloud. If PUBSUB_EMULATOR_HOST is set, as for the Google client libraries, it publishes
+// to the emulator at that address instead, without authenticating.
+
+const (
+	pubsubEndpoint     = "https://pubsub.googleapis.com"
+	pubsubTokenURL     = "http://metadata.google.internal/computeMetadata/v1/instance/service-accounts/default/token"
+	pubsubMaxBatch     = 1000 // Messages per publish request.
+	pubsubTokenLeeway  = time.Minute
+	pubsubMaxErrorBody = 1024
+)
+
+type pubsubSink struct {
+	project  string
+	endpoint string
+	tokenURL string // Empty if the requests aren't authenticated.
+	client   *http.Client
+
+	sync.Mutex
+	token   string
+	expires time.Time
+}
+
+func newPubsubSink(project string) (*pubsubSink, error) {
+	project = strings.TrimPrefix(project, "projects/")
+	if len(project) == 0 || strings.ContainsAny(project, "/:") {
+		return nil, x.Errorf("Invalid Pub/Sub project %q", project)
+	}
+	s := &pubsubSink{
+		project:  project,
+		endpoint: pubsubEndpoint,
+		tokenURL: pubsubTokenURL,
+		client:   &http.Client{Timeout: publishTimeout},
+	}
+	if host := os.Getenv("PUBSUB_EMULATOR_HOST"); len(host) > 0 {
+		s.endpoint, s.tokenURL = "http://"+host, ""
+	}
+	return s, nil
+}
+
+// accessToken returns a token of the service account, fetching a new one once it expires.
+func (s *pubsubSink) accessToken(ctx context.Context) (string, error) {
+	s.Lock()
+	defer s.Unlock()
+	if len(s.token) > 0 && time.Now().Before(s.expires) {
+		return s.token, nil
+	}
+	req, err := http.NewRequest("GET", s.tokenURL, nil)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Metadata-Flavor", "Google")
+	resp, err := s.client.Do(req.WithContext(ctx))
+	if err != nil {
+		return "", x.Wrapf(err, "While getting an access token for Pub/Sub")
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", x.Errorf("While getting an access token for Pub/Sub: %s", resp.Status)
+	}
+	var token struct {
+		AccessToken string `json:"access_token"`
+		ExpiresIn   int64  `json:"expires_in"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&token); err != nil {
+		return "", x.Wrapf(err, "While getting an access token for Pub/Sub")
+	}
+	s.token = token.AccessToken
+	s.expires = time.Now().Add(time.Duration(token.ExpiresIn)*time.Second - pubsubTokenLeeway)
+	return s.token, nil
+}
+
+type pubsubMessage struct {
+	Data       []byte            `json:"data"` // Base64 encoded, as the API wants.
+	Attributes map[string]string `json:"attributes,omitempty"`
+}
+
+func (s *pubsubSink) Publish(ctx context.Context, topic string, msgs []*Message) error {
+	for len(msgs) > 0 {
+		n := len(msgs)
+		if n > pubsubMaxBatch {
+			n = pubsubMaxBatch
+		}
+		if err := s.publish(ctx, topic, msgs[:n]); err != nil {
+			return err
+		}
+		msgs = msgs[n:]
+	}
+	return nil
+}
+
+func (s *pubsubSink) publish(ctx context.Context, topic string, msgs []*Message) error {
+	var body struct {
+		Messages []pubsubMessage `json:"messages"`
+	}
+	for _, msg := range msgs {
+		attrs := map[string]string{"id": msg.ID}
+		for k, v := range msg.Attrs {
+			attrs[k] = v
+		}
+		body.Messages = append(body.Messages, pubsubMessage{Data: msg.Data, Attributes: attrs})
+	}
+	data, err := json.Marshal(body)
+	x.Check(err)
+
+	u := s.endpoint + "/v1/projects/" + url.PathEscape(s.project) + "/topics/" +
+		url.PathEscape(topic) + ":publish"
+	req, err := http.NewRequest("POST", u, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if len(s.tokenURL) > 0 {
+		token, err := s.accessToken(ctx)
+		if err != nil {
+			return err
+		}
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	resp, err := s.client.Do(req.WithContext(ctx))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		msg, _ := ioutil.ReadAll(&io.LimitedReader{R: resp.Body, N: pubsubMaxErrorBody})
+		if resp.StatusCode == http.StatusUnauthorized {
+			// The token might have been revoked, so a new one is fetched by the retry.
+			s.Lock()
+			s.token = ""
+			s.Unlock()
+		}
+		return x.Errorf("Unable to publish to Pub/Sub topic %s: %s %s", topic, resp.Status,
+			bytes.TrimSpace(msg))
+	}
+	var res struct {
+		MessageIds []string `json:"messageIds"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&res); err != nil {
+		return x.Wrapf(err, "While reading the response of Pub/Sub")
+	}
+	if len(res.MessageIds) != len(msgs) {
+		return x.Errorf("Pub/Sub published %d messages out of %d", len(res.MessageIds), len(msgs))
+	}
+	return nil
+}
+
+func (s *pubsubSink) Close() error {
+	return nil
+}
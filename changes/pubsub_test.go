@@ -0,0 +1,76 @@
+/*
+ * Copyright 2018 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package changes
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPubsubSink(t *testing.T) {
+	var tokens int
+	var got []pubsubMessage
+	mux := http.NewServeMux()
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "Google", r.Header.Get("Metadata-Flavor"))
+		tokens++
+		fmt.Fprintf(w, `{"access_token":"token%d","expires_in":3600}`, tokens)
+	})
+	mux.HandleFunc("/v1/projects/my-project/topics/changes:publish",
+		func(w http.ResponseWriter, r *http.Request) {
+			require.Equal(t, "POST", r.Method)
+			require.Equal(t, "Bearer token1", r.Header.Get("Authorization"))
+			var body struct {
+				Messages []pubsubMessage `json:"messages"`
+			}
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			got = append(got, body.Messages...)
+			var ids []string
+			for range body.Messages {
+				ids = append(ids, fmt.Sprint(len(ids)))
+			}
+			json.NewEncoder(w).Encode(map[string][]string{"messageIds": ids})
+		})
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	sink, err := newPubsubSink("my-project")
+	require.NoError(t, err)
+	sink.endpoint, sink.tokenURL = srv.URL, srv.URL+"/token"
+
+	msgs := []*Message{
+		{ID: "1", Data: []byte(`{"uid":"0x1"}`), Attrs: map[string]string{"predicate": "name"}},
+		{ID: "2", Data: []byte("2")},
+	}
+	require.NoError(t, sink.Publish(context.Background(), "changes", msgs))
+	require.NoError(t, sink.Publish(context.Background(), "changes", msgs[1:]))
+	// The token is reused until it expires.
+	require.Equal(t, 1, tokens)
+	require.Len(t, got, 3)
+	require.Equal(t, []byte(`{"uid":"0x1"}`), got[0].Data)
+	require.Equal(t, map[string]string{"id": "1", "predicate": "name"}, got[0].Attributes)
+
+	err = sink.Publish(context.Background(), "missing", msgs)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "404")
+}
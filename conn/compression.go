@@ -0,0 +1,71 @@
+/*
+ * Copyright 2018 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package conn
+
+import (
+	"bytes"
+	"io"
+
+	"github.com/dgraph-io/dgraph/x"
+	"google.golang.org/grpc/encoding"
+)
+
+// The compressors are registered with gRPC by every node, so that they can all decompress the
+// messages they get. Servers compress their responses with the compressor of the request, so
+// whether the messages of a connection are compressed is up to the client, see Config.Compression.
+func init() {
+	encoding.RegisterCompressor(compressor{name: x.GzipEncoding})
+	encoding.RegisterCompressor(compressor{name: x.SnappyEncoding})
+}
+
+type compressor struct {
+	name string
+}
+
+func (c compressor) Name() string {
+	return c.name
+}
+
+func (c compressor) Compress(w io.Writer) (io.WriteCloser, error) {
+	return &compressWriter{w: w, name: c.name}, nil
+}
+
+func (c compressor) Decompress(r io.Reader) (io.Reader, error) {
+	return x.Decompress(c.name, r)
+}
+
+// compressWriter holds a message until it's closed, to compress it all at once and record how
+// much compressing it saved.
+type compressWriter struct {
+	w    io.Writer
+	name string
+	buf  bytes.Buffer
+}
+
+func (cw *compressWriter) Write(p []byte) (int, error) {
+	return cw.buf.Write(p)
+}
+
+func (cw *compressWriter) Close() error {
+	out, err := x.Compress(cw.name, cw.buf.Bytes())
+	if err != nil {
+		return err
+	}
+	x.RecordCompression("grpc", cw.name, cw.buf.Len(), len(out))
+	_, err = cw.w.Write(out)
+	return err
+}
@@ -0,0 +1,218 @@
+/*
+ * Copyright 2017-2018 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package conn
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dgraph-io/dgraph/x"
+)
+
+// The Zeros an Alpha or Zero connects to can be found through DNS or the Kubernetes API, instead of
+// being given by address, so that the nodes of a StatefulSet find each other as it scales, without
+// changing flags. The addresses are resolved again whenever connecting to them fails.
+
+const (
+	dnsSRVScheme = "dns+srv://"
+	k8sScheme    = "k8s://"
+
+	k8sAccountDir = "/var/run/secrets/kubernetes.io/serviceaccount"
+)
+
+// Discovery is where to find peers: either their addresses, comma separated, like
+// zero1:5080,zero2:5080, or the targets of the SRV records of a name, like
+// dns+srv://_grpc._tcp.zero.dgraph.svc.cluster.local, or the ready endpoints of a Kubernetes
+// service, like k8s://dgraph/zero:grpc for the service zero in namespace dgraph, at its port named
+// grpc. The port can be left out if the service has only one.
+type Discovery struct {
+	spec  string
+	addrs []string // Given by address.
+	name  string   // Of the SRV records.
+
+	namespace, service, port string
+}
+
+// lookupSRV and k8sEndpoints are variables for the tests.
+var (
+	lookupSRV    = net.DefaultResolver.LookupSRV
+	k8sEndpoints = getK8sEndpoints
+)
+
+// ParseDiscovery parses where to find peers.
+func ParseDiscovery(spec string) (*Discovery, error) {
+	d := &Discovery{spec: spec}
+	switch {
+	case strings.HasPrefix(spec, dnsSRVScheme):
+		d.name = strings.TrimPrefix(spec, dnsSRVScheme)
+		if len(d.name) == 0 {
+			return nil, x.Errorf("No name in %q", spec)
+		}
+	case strings.HasPrefix(spec, k8sScheme):
+		parts := strings.Split(strings.TrimPrefix(spec, k8sScheme), "/")
+		if len(parts) != 2 || len(parts[0]) == 0 || len(parts[1]) == 0 {
+			return nil, x.Errorf("Invalid %q, must be like k8s://namespace/service:port", spec)
+		}
+		d.namespace, d.service = parts[0], parts[1]
+		if idx := strings.IndexByte(d.service, ':'); idx >= 0 {
+			d.service, d.port = d.service[:idx], d.service[idx+1:]
+		}
+	default:
+		for _, addr := range strings.Split(spec, ",") {
+			if addr = strings.TrimSpace(addr); len(addr) > 0 {
+				d.addrs = append(d.addrs, addr)
+			}
+		}
+		if len(d.addrs) == 0 {
+			return nil, x.Errorf("No address in %q", spec)
+		}
+	}
+	return d, nil
+}
+
+func (d *Discovery) String() string {
+	return d.spec
+}
+
+// Dynamic tells whether the peers are found through DNS or Kubernetes, rather than given.
+func (d *Discovery) Dynamic() bool {
+	return len(d.addrs) == 0
+}
+
+// Resolve returns the addresses of the peers, sorted.
+func (d *Discovery) Resolve(ctx context.Context) ([]string, error) {
+	var addrs []string
+	switch {
+	case len(d.name) > 0:
+		_, srvs, err := lookupSRV(ctx, "", "", d.name)
+		if err != nil {
+			return nil, err
+		}
+		for _, srv := range srvs {
+			host := strings.TrimSuffix(srv.Target, ".")
+			addrs = append(addrs, net.JoinHostPort(host, strconv.Itoa(int(srv.Port))))
+		}
+	case len(d.service) > 0:
+		eps, err := k8sEndpoints(ctx, d.namespace, d.service)
+		if err != nil {
+			return nil, err
+		}
+		if addrs, err = eps.addrs(d.port); err != nil {
+			return nil, x.Wrapf(err, "While resolving %s", d.spec)
+		}
+	default:
+		addrs = append(addrs, d.addrs...)
+	}
+	return x.RemoveDuplicates(addrs), nil
+}
+
+// endpoints are the endpoints of a Kubernetes service, as returned by its API.
+type endpoints struct {
+	Subsets []struct {
+		Addresses []struct {
+			IP string `json:"ip"`
+		} `json:"addresses"`
+		Ports []struct {
+			Name string `json:"name"`
+			Port int    `json:"port"`
+		} `json:"ports"`
+	} `json:"subsets"`
+}
+
+// addrs returns the addresses of the endpoints at the port of the given name, or at their only
+// port if the name is empty.
+func (eps *endpoints) addrs(name string) ([]string, error) {
+	var addrs []string
+	for _, sub := range eps.Subsets {
+		port := -1
+		for _, p := range sub.Ports {
+			if p.Name == name || (len(name) == 0 && len(sub.Ports) == 1) {
+				port = p.Port
+			}
+		}
+		if port < 0 {
+			if len(name) == 0 {
+				return nil, x.Errorf("The service has %d ports, one must be named", len(sub.Ports))
+			}
+			return nil, x.Errorf("The service has no port named %s", name)
+		}
+		for _, a := range sub.Addresses {
+			addrs = append(addrs, net.JoinHostPort(a.IP, strconv.Itoa(port)))
+		}
+	}
+	return addrs, nil
+}
+
+// getK8sEndpoints gets the endpoints of a service from the Kubernetes API, with the service account
+// of the pod it runs in.
+func getK8sEndpoints(ctx context.Context, namespace, service string) (*endpoints, error) {
+	host, port := os.Getenv("KUBERNETES_SERVICE_HOST"), os.Getenv("KUBERNETES_SERVICE_PORT")
+	if len(host) == 0 || len(port) == 0 {
+		return nil, x.Errorf("Not running in a Kubernetes pod, KUBERNETES_SERVICE_HOST isn't set")
+	}
+	token, err := ioutil.ReadFile(k8sAccountDir + "/token")
+	if err != nil {
+		return nil, err
+	}
+	ca, err := ioutil.ReadFile(k8sAccountDir + "/ca.crt")
+	if err != nil {
+		return nil, err
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(ca) {
+		return nil, x.Errorf("No certificate in %s/ca.crt", k8sAccountDir)
+	}
+	client := &http.Client{
+		Timeout:   10 * time.Second,
+		Transport: &http.Transport{TLSClientConfig: &tls.Config{RootCAs: pool}},
+	}
+
+	url := fmt.Sprintf("https://%s/api/v1/namespaces/%s/endpoints/%s",
+		net.JoinHostPort(host, port), namespace, service)
+	req, err := http.NewRequest("GET", url, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Authorization", "Bearer "+strings.TrimSpace(string(token)))
+	resp, err := client.Do(req.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, x.Errorf("The Kubernetes API replied with %s: %s", resp.Status, body)
+	}
+	var eps endpoints
+	if err := json.Unmarshal(body, &eps); err != nil {
+		return nil, err
+	}
+	return &eps, nil
+}
@@ -0,0 +1,108 @@
+/*
+ * Copyright 2017-2018 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package conn
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseDiscovery(t *testing.T) {
+	for _, spec := range []string{"", " , ", "dns+srv://", "k8s://", "k8s://ns", "k8s:///zero",
+		"k8s://ns/", "k8s://ns/zero/grpc"} {
+		_, err := ParseDiscovery(spec)
+		require.Error(t, err, spec)
+	}
+
+	d, err := ParseDiscovery("zero2:5080, zero1:5080,zero2:5080")
+	require.NoError(t, err)
+	require.False(t, d.Dynamic())
+	addrs, err := d.Resolve(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, []string{"zero1:5080", "zero2:5080"}, addrs)
+
+	d, err = ParseDiscovery("k8s://dgraph/zero:grpc")
+	require.NoError(t, err)
+	require.True(t, d.Dynamic())
+	require.Equal(t, "dgraph", d.namespace)
+	require.Equal(t, "zero", d.service)
+	require.Equal(t, "grpc", d.port)
+}
+
+func TestResolveSRV(t *testing.T) {
+	defer func(f func(context.Context, string, string, string) (string, []*net.SRV, error)) {
+		lookupSRV = f
+	}(lookupSRV)
+	lookupSRV = func(_ context.Context, service, proto, name string) (string, []*net.SRV, error) {
+		require.Equal(t, "_grpc._tcp.zero.dgraph.svc", name)
+		return name, []*net.SRV{
+			{Target: "zero-1.zero.dgraph.svc.", Port: 5080},
+			{Target: "zero-0.zero.dgraph.svc.", Port: 5080},
+		}, nil
+	}
+
+	d, err := ParseDiscovery("dns+srv://_grpc._tcp.zero.dgraph.svc")
+	require.NoError(t, err)
+	require.True(t, d.Dynamic())
+	addrs, err := d.Resolve(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, []string{"zero-0.zero.dgraph.svc:5080", "zero-1.zero.dgraph.svc:5080"}, addrs)
+}
+
+func TestResolveK8s(t *testing.T) {
+	defer func(f func(context.Context, string, string) (*endpoints, error)) {
+		k8sEndpoints = f
+	}(k8sEndpoints)
+	var eps endpoints
+	require.NoError(t, json.Unmarshal([]byte(`{"subsets": [
+		{"addresses": [{"ip": "10.0.0.2"}, {"ip": "10.0.0.1"}],
+		 "notReadyAddresses": [{"ip": "10.0.0.3"}],
+		 "ports": [{"name": "http", "port": 6080}, {"name": "grpc", "port": 5080}]}
+	]}`), &eps))
+	k8sEndpoints = func(_ context.Context, namespace, service string) (*endpoints, error) {
+		require.Equal(t, "dgraph", namespace)
+		require.Equal(t, "zero", service)
+		return &eps, nil
+	}
+
+	d, err := ParseDiscovery("k8s://dgraph/zero:grpc")
+	require.NoError(t, err)
+	addrs, err := d.Resolve(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, []string{"10.0.0.1:5080", "10.0.0.2:5080"}, addrs)
+
+	// The port must be named if there are many.
+	d, err = ParseDiscovery("k8s://dgraph/zero")
+	require.NoError(t, err)
+	_, err = d.Resolve(context.Background())
+	require.Error(t, err)
+	d, err = ParseDiscovery("k8s://dgraph/zero:raft")
+	require.NoError(t, err)
+	_, err = d.Resolve(context.Background())
+	require.Error(t, err)
+
+	eps.Subsets[0].Ports = eps.Subsets[0].Ports[1:]
+	d, err = ParseDiscovery("k8s://dgraph/zero")
+	require.NoError(t, err)
+	addrs, err = d.Resolve(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, []string{"10.0.0.1:5080", "10.0.0.2:5080"}, addrs)
+}
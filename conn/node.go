@@ -453,13 +453,15 @@ func (n *Node) proposeConfChange(ctx context.Context, pb raftpb.ConfChange) erro
 	}
 }
 
-func (n *Node) AddToCluster(ctx context.Context, pid uint64) error {
+// AddToCluster adds the peer to the Raft group. Learners replicate the group, but don't vote.
+func (n *Node) AddToCluster(ctx context.Context, pid uint64, learner bool) error {
 	addr, ok := n.Peer(pid)
 	x.AssertTruef(ok, "Unable to find conn pool for peer: %d", pid)
 	rc := &pb.RaftContext{
-		Addr:  addr,
-		Group: n.RaftContext.Group,
-		Id:    pid,
+		Addr:      addr,
+		Group:     n.RaftContext.Group,
+		Id:        pid,
+		IsLearner: learner,
 	}
 	rcBytes, err := rc.Marshal()
 	x.Check(err)
@@ -469,9 +471,12 @@ func (n *Node) AddToCluster(ctx context.Context, pid uint64) error {
 		NodeID:  pid,
 		Context: rcBytes,
 	}
+	if learner {
+		cc.Type = raftpb.ConfChangeAddLearnerNode
+	}
 	err = errInternalRetry
 	for err == errInternalRetry {
-		glog.Infof("Trying to add %d to cluster. Addr: %v\n", pid, addr)
+		glog.Infof("Trying to add %d to cluster. Addr: %v. Learner: %v\n", pid, addr, learner)
 		glog.Infof("Current confstate at %d: %+v\n", n.Id, n.ConfState())
 		err = n.proposeConfChange(ctx, cc)
 	}
@@ -25,6 +25,7 @@ import (
 	"time"
 
 	"github.com/dgraph-io/dgo/protos/api"
+	"github.com/dgraph-io/dgraph/fault"
 	"github.com/dgraph-io/dgraph/protos/pb"
 	"github.com/dgraph-io/dgraph/x"
 	"github.com/golang/glog"
@@ -124,13 +125,20 @@ func (p *Pools) Connect(addr string) *Pool {
 
 // NewPool creates a new "pool" with one gRPC connection, refcount 0.
 func NewPool(addr string) (*Pool, error) {
-	conn, err := grpc.Dial(addr,
+	callOpts := []grpc.CallOption{
+		grpc.MaxCallRecvMsgSize(x.GrpcMaxSize),
+		grpc.MaxCallSendMsgSize(x.GrpcMaxSize),
+	}
+	if x.Config.Compression != "" {
+		callOpts = append(callOpts, grpc.UseCompressor(x.Config.Compression))
+	}
+	dialOpts := []grpc.DialOption{
 		grpc.WithStatsHandler(&ocgrpc.ClientHandler{}),
-		grpc.WithDefaultCallOptions(
-			grpc.MaxCallRecvMsgSize(x.GrpcMaxSize),
-			grpc.MaxCallSendMsgSize(x.GrpcMaxSize)),
+		grpc.WithDefaultCallOptions(callOpts...),
 		grpc.WithBackoffMaxDelay(time.Second),
-		grpc.WithInsecure())
+		DialOption(),
+	}
+	conn, err := grpc.Dial(addr, append(dialOpts, fault.DialOptions()...)...)
 	if err != nil {
 		return nil, err
 	}
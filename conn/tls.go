@@ -0,0 +1,238 @@
+/*
+ * Copyright 2018 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package conn
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/golang/glog"
+	"github.com/spf13/pflag"
+	"github.com/spf13/viper"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+
+	"github.com/dgraph-io/dgraph/x"
+)
+
+// The internal traffic of the cluster, between Zeros and Alphas, including the Raft messages,
+// can use mutual TLS. Every node then has its own certificate, signed by the CA of the cluster,
+// which it presents both when accepting and when making connections, and only accepts peers with
+// a certificate signed by the same CA. The certificates are read again whenever their files
+// change, so that they can be rotated without restarting the nodes. Existing connections keep
+// going, new ones use the new certificates.
+
+const (
+	internalRootCert = "ca.crt"
+	internalNodeCert = "node.crt"
+	internalNodeKey  = "node.key"
+
+	internalTLSCheckInterval = 30 * time.Second
+)
+
+type internalTLS struct {
+	sync.RWMutex
+	dir        string
+	serverName string
+	cert       *tls.Certificate
+	pool       *x509.CertPool
+	modTimes   []time.Time // Of the files the certificates were last read from.
+}
+
+var itls *internalTLS
+
+// RegisterInternalTLSFlags registers the flags to set up mutual TLS between the nodes.
+func RegisterInternalTLSFlags(flag *pflag.FlagSet) {
+	flag.String("tls_internal_dir", "", "Directory with the ca.crt, node.crt and node.key used"+
+		" for mutual TLS between Zeros and Alphas. Internal traffic isn't encrypted if not set.")
+	flag.String("tls_internal_server_name", "", "Name the certificates of the other nodes are"+
+		" verified against. Defaults to the host name of the address they're reached at.")
+}
+
+// SetupInternalTLSFromConfig sets up mutual TLS as configured by the flags registered with
+// RegisterInternalTLSFlags. It must be called before any internal connection is made.
+func SetupInternalTLSFromConfig(v *viper.Viper) error {
+	return SetupInternalTLS(v.GetString("tls_internal_dir"), v.GetString("tls_internal_server_name"))
+}
+
+// SetupInternalTLS sets up mutual TLS with the certificates in dir, and keeps checking them for
+// changes. An empty dir leaves the internal traffic in plaintext.
+func SetupInternalTLS(dir, serverName string) error {
+	if len(dir) == 0 {
+		return nil
+	}
+	t := &internalTLS{dir: dir, serverName: serverName}
+	if err := t.load(); err != nil {
+		return err
+	}
+	itls = t
+	go func() {
+		for range time.Tick(internalTLSCheckInterval) {
+			if !t.changed() {
+				continue
+			}
+			if err := t.load(); err != nil {
+				glog.Errorf("While reloading internal TLS certificates: %v. Using current ones.",
+					err)
+			} else {
+				glog.Infof("Reloaded internal TLS certificates from %s", t.dir)
+			}
+		}
+	}()
+	glog.Infof("Using mutual TLS for internal traffic, with certificates from %s", dir)
+	return nil
+}
+
+func (t *internalTLS) files() []string {
+	return []string{
+		filepath.Join(t.dir, internalRootCert),
+		filepath.Join(t.dir, internalNodeCert),
+		filepath.Join(t.dir, internalNodeKey),
+	}
+}
+
+func modTimes(files []string) ([]time.Time, error) {
+	var res []time.Time
+	for _, f := range files {
+		fi, err := os.Stat(f)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, fi.ModTime())
+	}
+	return res, nil
+}
+
+// changed tells whether any of the files changed since they were last read.
+func (t *internalTLS) changed() bool {
+	cur, err := modTimes(t.files())
+	if err != nil {
+		// Likely in the middle of being replaced.
+		return false
+	}
+	t.RLock()
+	defer t.RUnlock()
+	for i := range cur {
+		if !cur[i].Equal(t.modTimes[i]) {
+			return true
+		}
+	}
+	return false
+}
+
+func (t *internalTLS) load() error {
+	files := t.files()
+	times, err := modTimes(files)
+	if err != nil {
+		return err
+	}
+	ca, err := ioutil.ReadFile(files[0])
+	if err != nil {
+		return err
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(ca) {
+		return x.Errorf("No certificates found in %s", files[0])
+	}
+	cert, err := tls.LoadX509KeyPair(files[1], files[2])
+	if err != nil {
+		return err
+	}
+
+	t.Lock()
+	defer t.Unlock()
+	t.cert, t.pool, t.modTimes = &cert, pool, times
+	return nil
+}
+
+// config returns the TLS config to accept connections with, or to make them with, using the
+// current certificates.
+func (t *internalTLS) config(server bool) *tls.Config {
+	t.RLock()
+	defer t.RUnlock()
+	cfg := &tls.Config{
+		Certificates: []tls.Certificate{*t.cert},
+		MinVersion:   tls.VersionTLS12,
+	}
+	if server {
+		cfg.ClientCAs = t.pool
+		cfg.ClientAuth = tls.RequireAndVerifyClientCert
+	} else {
+		cfg.RootCAs = t.pool
+		cfg.ServerName = t.serverName
+	}
+	return cfg
+}
+
+// internalCreds are the gRPC credentials of the internal connections. Every handshake uses the
+// certificates read last.
+type internalCreds struct {
+	t *internalTLS
+}
+
+func (c internalCreds) ClientHandshake(ctx context.Context, authority string,
+	rawConn net.Conn) (net.Conn, credentials.AuthInfo, error) {
+	return credentials.NewTLS(c.t.config(false)).ClientHandshake(ctx, authority, rawConn)
+}
+
+func (c internalCreds) ServerHandshake(rawConn net.Conn) (net.Conn, credentials.AuthInfo, error) {
+	return credentials.NewTLS(c.t.config(true)).ServerHandshake(rawConn)
+}
+
+func (c internalCreds) Info() credentials.ProtocolInfo {
+	c.t.RLock()
+	defer c.t.RUnlock()
+	return credentials.ProtocolInfo{
+		SecurityProtocol: "tls",
+		SecurityVersion:  "1.2",
+		ServerName:       c.t.serverName,
+	}
+}
+
+func (c internalCreds) Clone() credentials.TransportCredentials {
+	return c
+}
+
+func (c internalCreds) OverrideServerName(name string) error {
+	c.t.Lock()
+	defer c.t.Unlock()
+	c.t.serverName = name
+	return nil
+}
+
+// DialOption returns the option to make internal connections with, with mutual TLS if set up.
+func DialOption() grpc.DialOption {
+	if itls == nil {
+		return grpc.WithInsecure()
+	}
+	return grpc.WithTransportCredentials(internalCreds{t: itls})
+}
+
+// ServerOptions returns the options of the servers accepting internal connections.
+func ServerOptions() []grpc.ServerOption {
+	if itls == nil {
+		return nil
+	}
+	return []grpc.ServerOption{grpc.Creds(internalCreds{t: itls})}
+}
@@ -0,0 +1,153 @@
+/*
+ * Copyright 2018 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package conn
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io/ioutil"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+type testCert struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+}
+
+// newCert returns a certificate signed by parent, or a CA if parent is nil.
+func newCert(t *testing.T, name string, parent *testCert) *testCert {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		Subject:      pkix.Name{CommonName: name},
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature | x509.KeyUsageKeyEncipherment,
+	}
+	signer, signerKey := template, key
+	if parent == nil {
+		template.IsCA = true
+		template.BasicConstraintsValid = true
+		template.KeyUsage |= x509.KeyUsageCertSign
+	} else {
+		template.DNSNames = []string{name}
+		template.ExtKeyUsage = []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth,
+			x509.ExtKeyUsageClientAuth}
+		signer, signerKey = parent.cert, parent.key
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, signer, &key.PublicKey, signerKey)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	return &testCert{cert: cert, key: key}
+}
+
+// writeCerts writes the files of a node with the given certificate, signed by ca.
+func writeCerts(t *testing.T, dir string, ca, node *testCert) {
+	write := func(file, typ string, der []byte) {
+		data := pem.EncodeToMemory(&pem.Block{Type: typ, Bytes: der})
+		require.NoError(t, ioutil.WriteFile(filepath.Join(dir, file), data, 0600))
+	}
+	write(internalRootCert, "CERTIFICATE", ca.cert.Raw)
+	write(internalNodeCert, "CERTIFICATE", node.cert.Raw)
+	key, err := x509.MarshalECPrivateKey(node.key)
+	require.NoError(t, err)
+	write(internalNodeKey, "EC PRIVATE KEY", key)
+}
+
+func newInternalTLS(t *testing.T, ca, node *testCert, serverName string) *internalTLS {
+	dir, err := ioutil.TempDir("", "tls")
+	require.NoError(t, err)
+	writeCerts(t, dir, ca, node)
+	it := &internalTLS{dir: dir, serverName: serverName}
+	require.NoError(t, it.load())
+	return it
+}
+
+// handshake connects client to server, returning the names in the certificates they saw.
+func handshake(client, server *internalTLS) (string, string, error) {
+	c, s := net.Pipe()
+	defer c.Close()
+	defer s.Close()
+	tc, ts := tls.Client(c, client.config(false)), tls.Server(s, server.config(true))
+	errCh := make(chan error, 1)
+	go func() { errCh <- ts.Handshake() }()
+	if err := tc.Handshake(); err != nil {
+		return "", "", err
+	}
+	if err := <-errCh; err != nil {
+		return "", "", err
+	}
+	return tc.ConnectionState().PeerCertificates[0].Subject.CommonName,
+		ts.ConnectionState().PeerCertificates[0].Subject.CommonName, nil
+}
+
+func TestInternalTLS(t *testing.T) {
+	ca := newCert(t, "ca", nil)
+	alpha := newInternalTLS(t, ca, newCert(t, "alpha", ca), "zero")
+	defer os.RemoveAll(alpha.dir)
+	zero := newInternalTLS(t, ca, newCert(t, "zero", ca), "alpha")
+	defer os.RemoveAll(zero.dir)
+
+	server, client, err := handshake(alpha, zero)
+	require.NoError(t, err)
+	require.Equal(t, "zero", server)
+	require.Equal(t, "alpha", client)
+
+	// The name of the server must match.
+	alpha.serverName = "other"
+	_, _, err = handshake(alpha, zero)
+	require.Error(t, err)
+	alpha.serverName = "zero"
+
+	// Nodes with certificates from another CA aren't let in.
+	other := newCert(t, "other", nil)
+	stranger := newInternalTLS(t, other, newCert(t, "zero", other), "zero")
+	defer os.RemoveAll(stranger.dir)
+	_, _, err = handshake(stranger, zero)
+	require.Error(t, err)
+	_, _, err = handshake(alpha, stranger)
+	require.Error(t, err)
+
+	// Rotated certificates are picked up once their files change.
+	require.False(t, zero.changed())
+	writeCerts(t, zero.dir, ca, newCert(t, "zero", ca))
+	later := time.Now().Add(time.Minute)
+	for _, f := range zero.files() {
+		require.NoError(t, os.Chtimes(f, later, later))
+	}
+	require.True(t, zero.changed())
+	old := zero.cert
+	require.NoError(t, zero.load())
+	require.False(t, zero.changed())
+	require.NotEqual(t, old.Certificate[0], zero.cert.Certificate[0])
+	_, _, err = handshake(alpha, zero)
+	require.NoError(t, err)
+}
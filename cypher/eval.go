@@ -0,0 +1,977 @@
+/*
+ * Copyright 2018 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cypher
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/dgraph-io/dgraph/x"
+)
+
+// Result is the result of a query, with a value for every column in each row.
+type Result struct {
+	Columns []string        `json:"columns"`
+	Rows    [][]interface{} `json:"rows"`
+}
+
+// node is a node matched, as returned by Dgraph.
+type node map[string]interface{}
+
+// rel is a relationship matched.
+type rel struct {
+	typ string
+}
+
+// row binds the variables to the nodes and relationships matched.
+type row map[string]interface{}
+
+func (n node) uid() string {
+	uid, _ := n["uid"].(string)
+	return uid
+}
+
+func (n node) labels() []interface{} {
+	switch l := n[LabelPredicate].(type) {
+	case []interface{}:
+		return l
+	case nil:
+		return []interface{}{}
+	default:
+		return []interface{}{l}
+	}
+}
+
+// Rows returns the result of the query, given the JSON Dgraph returned for the GraphQuery.
+func (q *Query) Rows(js []byte) (*Result, error) {
+	var data map[string]interface{}
+	dec := json.NewDecoder(bytes.NewReader(js))
+	dec.UseNumber()
+	if err := dec.Decode(&data); err != nil {
+		return nil, err
+	}
+	data = normalize(data).(map[string]interface{})
+
+	var rows []row
+	for k, pat := range q.q.patterns {
+		prows, err := q.patternRows(pat, q.roots[k], data[blockName(k)])
+		if err != nil {
+			return nil, err
+		}
+		if k == 0 {
+			rows = prows
+		} else {
+			rows = join(rows, prows)
+		}
+	}
+
+	if q.q.where != nil {
+		var matching []row
+		for _, r := range rows {
+			v, err := q.eval(q.q.where, r, nil)
+			if err != nil {
+				return nil, err
+			}
+			b, err := boolean(v)
+			if err != nil {
+				return nil, err
+			}
+			if b != nil && *b {
+				matching = append(matching, r)
+			}
+		}
+		rows = matching
+	}
+	return q.project(rows)
+}
+
+// patternRows returns the rows of the paths matching the pattern, in the nodes of its block.
+func (q *Query) patternRows(pat *pattern, root int, nodes interface{}) ([]row, error) {
+	var left, right []int
+	for i := root - 1; i >= 0; i-- {
+		left = append(left, i)
+	}
+	for i := root + 1; i < len(pat.nodes); i++ {
+		right = append(right, i)
+	}
+
+	var rows []row
+	for _, n := range list0(nodes) {
+		m, ok := n.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		path := make([]node, len(pat.nodes))
+		path[root] = node(m)
+		for _, l := range paths(m, leftEdge, len(left)) {
+			for i, n := range l {
+				path[left[i]] = n
+			}
+			for _, r := range paths(m, rightEdge, len(right)) {
+				for i, n := range r {
+					path[right[i]] = n
+				}
+				res, ok, err := q.bind(pat, path)
+				if err != nil {
+					return nil, err
+				}
+				if ok {
+					rows = append(rows, res)
+				}
+			}
+		}
+	}
+	return rows, nil
+}
+
+// paths returns the paths of length n following the edge from m.
+func paths(m map[string]interface{}, edge string, n int) [][]node {
+	if n == 0 {
+		return [][]node{nil}
+	}
+	var res [][]node
+	for _, next := range list0(m[edge]) {
+		nm, ok := next.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		for _, tail := range paths(nm, edge, n-1) {
+			res = append(res, append([]node{node(nm)}, tail...))
+		}
+	}
+	return res
+}
+
+func list0(v interface{}) []interface{} {
+	switch v := v.(type) {
+	case []interface{}:
+		return v
+	case nil:
+		return nil
+	}
+	return []interface{}{v}
+}
+
+// bind returns the row of the path, if it matches the pattern. Paths can't, if they have
+// different nodes for the same variable, or nodes without the labels and properties of the
+// pattern.
+func (q *Query) bind(pat *pattern, path []node) (row, bool, error) {
+	r := make(row, len(path)+len(pat.rels))
+	for i, n := range path {
+		np := pat.nodes[i]
+		if prev, ok := r[np.v].(node); ok && prev.uid() != n.uid() {
+			return nil, false, nil
+		}
+		r[np.v] = n
+		for _, label := range np.labels {
+			if !contains(n.labels(), label) {
+				return nil, false, nil
+			}
+		}
+		for _, p := range np.props {
+			want, err := q.eval(p.val, nil, nil)
+			if err != nil {
+				return nil, false, err
+			}
+			if equal(n[p.key], want) != true {
+				return nil, false, nil
+			}
+		}
+	}
+	for _, rp := range pat.rels {
+		if len(rp.v) > 0 {
+			r[rp.v] = rel{typ: rp.typ}
+		}
+	}
+	return r, true, nil
+}
+
+// join returns the rows combining a row of a with one of b, for the ones with the same nodes
+// for the variables they share. Only node variables can be shared, as relationship variables
+// are used once.
+func join(a, b []row) []row {
+	var res []row
+	for _, ra := range a {
+	Rows:
+		for _, rb := range b {
+			merged := make(row, len(ra)+len(rb))
+			for k, v := range ra {
+				merged[k] = v
+			}
+			for k, v := range rb {
+				prev, ok := merged[k]
+				switch {
+				case !ok:
+					merged[k] = v
+				case prev.(node).uid() != v.(node).uid():
+					continue Rows
+				}
+			}
+			res = append(res, merged)
+		}
+	}
+	return res
+}
+
+// record is a row of the result, with the row it's returned for. Rows with aggregations are
+// returned for a group of rows.
+type record struct {
+	vals  []interface{}
+	row   row
+	group []row
+}
+
+func (q *Query) project(rows []row) (*Result, error) {
+	items := q.q.items
+	aggregated := make([]bool, len(items))
+	anyAggregated := false
+	for i, item := range items {
+		aggregated[i] = hasAggregation(item.e)
+		anyAggregated = anyAggregated || aggregated[i]
+	}
+
+	var records []*record
+	if !anyAggregated {
+		for _, r := range rows {
+			rec := &record{row: r, group: []row{r}}
+			for _, item := range items {
+				v, err := q.eval(item.e, r, nil)
+				if err != nil {
+					return nil, err
+				}
+				rec.vals = append(rec.vals, v)
+			}
+			records = append(records, rec)
+		}
+	} else {
+		// Rows are grouped by the values of the columns without aggregations.
+		groups := make(map[string]*record)
+		for _, r := range rows {
+			var keys []interface{}
+			for i, item := range items {
+				if aggregated[i] {
+					continue
+				}
+				v, err := q.eval(item.e, r, nil)
+				if err != nil {
+					return nil, err
+				}
+				keys = append(keys, v)
+			}
+			key := valueKey(keys)
+			rec, ok := groups[key]
+			if !ok {
+				rec = &record{row: r}
+				groups[key] = rec
+				records = append(records, rec)
+			}
+			rec.group = append(rec.group, r)
+		}
+		onlyAggregated := true
+		for _, a := range aggregated {
+			onlyAggregated = onlyAggregated && a
+		}
+		if len(records) == 0 && onlyAggregated {
+			// Aggregations over no rows still have a result, like a count of 0.
+			records = append(records, &record{row: row{}})
+		}
+		for _, rec := range records {
+			for _, item := range items {
+				v, err := q.eval(item.e, rec.row, rec.group)
+				if err != nil {
+					return nil, err
+				}
+				rec.vals = append(rec.vals, v)
+			}
+		}
+	}
+
+	if q.q.distinct {
+		seen := make(map[string]bool)
+		var distinct []*record
+		for _, rec := range records {
+			key := valueKey(rec.vals)
+			if !seen[key] {
+				seen[key] = true
+				distinct = append(distinct, rec)
+			}
+		}
+		records = distinct
+	}
+
+	if len(q.q.order) > 0 {
+		keys := make([][]interface{}, len(records))
+		for i, rec := range records {
+			env := make(row, len(rec.row)+len(items))
+			for k, v := range rec.row {
+				env[k] = v
+			}
+			for j, item := range items {
+				env[item.name] = rec.vals[j]
+			}
+			for _, o := range q.q.order {
+				v, err := q.eval(o.e, env, rec.group)
+				if err != nil {
+					return nil, err
+				}
+				keys[i] = append(keys[i], v)
+			}
+		}
+		idx := make([]int, len(records))
+		for i := range idx {
+			idx[i] = i
+		}
+		sort.SliceStable(idx, func(a, b int) bool {
+			for j, o := range q.q.order {
+				c := orderCompare(keys[idx[a]][j], keys[idx[b]][j])
+				if o.desc {
+					c = -c
+				}
+				if c != 0 {
+					return c < 0
+				}
+			}
+			return false
+		})
+		sorted := make([]*record, len(records))
+		for i, j := range idx {
+			sorted[i] = records[j]
+		}
+		records = sorted
+	}
+
+	if q.q.skip != nil {
+		n, err := q.count(q.q.skip)
+		if err != nil {
+			return nil, err
+		}
+		if n > len(records) {
+			n = len(records)
+		}
+		records = records[n:]
+	}
+	if q.q.limit != nil {
+		n, err := q.count(q.q.limit)
+		if err != nil {
+			return nil, err
+		}
+		if n < len(records) {
+			records = records[:n]
+		}
+	}
+
+	res := &Result{Rows: make([][]interface{}, 0, len(records))}
+	for _, item := range items {
+		res.Columns = append(res.Columns, item.name)
+	}
+	for _, rec := range records {
+		vals := make([]interface{}, len(rec.vals))
+		for i, v := range rec.vals {
+			vals[i] = output(v)
+		}
+		res.Rows = append(res.Rows, vals)
+	}
+	return res, nil
+}
+
+func hasAggregation(e expr) bool {
+	switch e := e.(type) {
+	case *call:
+		if _, ok := aggregationFuncs[e.name]; ok {
+			return true
+		}
+		for _, arg := range e.args {
+			if hasAggregation(arg) {
+				return true
+			}
+		}
+	case *binary:
+		return hasAggregation(e.l) || hasAggregation(e.r)
+	case *unary:
+		return hasAggregation(e.e)
+	case *property:
+		return hasAggregation(e.e)
+	case *list:
+		for _, el := range e.elems {
+			if hasAggregation(el) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// output returns the value as it's returned: nodes with their properties and uid, and
+// relationships with their type.
+func output(v interface{}) interface{} {
+	switch v := v.(type) {
+	case node:
+		m := make(map[string]interface{}, len(v))
+		for k, val := range v {
+			if k != leftEdge && k != rightEdge {
+				m[k] = val
+			}
+		}
+		return m
+	case rel:
+		return map[string]interface{}{"type": v.typ}
+	case []interface{}:
+		res := make([]interface{}, len(v))
+		for i, el := range v {
+			res[i] = output(el)
+		}
+		return res
+	}
+	return v
+}
+
+// valueKey returns a key of the values, equal for equal values.
+func valueKey(vals []interface{}) string {
+	var b strings.Builder
+	for _, v := range vals {
+		switch v := v.(type) {
+		case node:
+			b.WriteString("node:" + v.uid())
+		case rel:
+			b.WriteString("rel:" + v.typ)
+		case int64:
+			// Equal integers and floats are the same value.
+			fmt.Fprintf(&b, "num:%v", float64(v))
+		case float64:
+			fmt.Fprintf(&b, "num:%v", v)
+		default:
+			js, _ := json.Marshal(output(v))
+			fmt.Fprintf(&b, "%T:%s", v, js)
+		}
+		b.WriteByte(0)
+	}
+	return b.String()
+}
+
+// eval returns the value of the expression for the row, and for the group of rows if it has
+// aggregations.
+func (q *Query) eval(e expr, r row, group []row) (interface{}, error) {
+	switch e := e.(type) {
+	case *literal:
+		return e.v, nil
+	case *param:
+		return q.params[e.name], nil
+	case *variable:
+		return r[e.name], nil
+	case *property:
+		v, err := q.eval(e.e, r, group)
+		if err != nil {
+			return nil, err
+		}
+		switch v := v.(type) {
+		case node:
+			return v[e.key], nil
+		case nil:
+			return nil, nil
+		case rel:
+			// Relationships don't have properties.
+			return nil, nil
+		}
+		return nil, x.Errorf("Property %s of a value which isn't a node", e.key)
+	case *list:
+		res := make([]interface{}, 0, len(e.elems))
+		for _, el := range e.elems {
+			v, err := q.eval(el, r, group)
+			if err != nil {
+				return nil, err
+			}
+			res = append(res, v)
+		}
+		return res, nil
+	case *unary:
+		v, err := q.eval(e.e, r, group)
+		if err != nil {
+			return nil, err
+		}
+		switch e.op {
+		case "isnull":
+			return v == nil, nil
+		case "isnotnull":
+			return v != nil, nil
+		case "not":
+			b, err := boolean(v)
+			if err != nil || b == nil {
+				return nil, err
+			}
+			return !*b, nil
+		}
+		switch v := v.(type) {
+		case int64:
+			return -v, nil
+		case float64:
+			return -v, nil
+		case nil:
+			return nil, nil
+		}
+		return nil, x.Errorf("Only numbers can be negated")
+	case *binary:
+		return q.evalBinary(e, r, group)
+	case *call:
+		if f, ok := aggregationFuncs[e.name]; ok {
+			return q.aggregate(f, e, group)
+		}
+		var args []interface{}
+		for _, arg := range e.args {
+			v, err := q.eval(arg, r, group)
+			if err != nil {
+				return nil, err
+			}
+			args = append(args, v)
+		}
+		return applyScalar(e.name, args)
+	}
+	return nil, x.Errorf("Invalid expression %T", e)
+}
+
+// boolean returns the truth value of v, nil for null.
+func boolean(v interface{}) (*bool, error) {
+	switch v := v.(type) {
+	case bool:
+		return &v, nil
+	case nil:
+		return nil, nil
+	}
+	return nil, x.Errorf("Expected a boolean, got %v", output(v))
+}
+
+func (q *Query) evalBinary(e *binary, r row, group []row) (interface{}, error) {
+	l, err := q.eval(e.l, r, group)
+	if err != nil {
+		return nil, err
+	}
+	rv, err := q.eval(e.r, r, group)
+	if err != nil {
+		return nil, err
+	}
+
+	switch e.op {
+	case "and", "or", "xor":
+		lb, err := boolean(l)
+		if err != nil {
+			return nil, err
+		}
+		rb, err := boolean(rv)
+		if err != nil {
+			return nil, err
+		}
+		switch {
+		case e.op == "and" && ((lb != nil && !*lb) || (rb != nil && !*rb)):
+			return false, nil
+		case e.op == "or" && ((lb != nil && *lb) || (rb != nil && *rb)):
+			return true, nil
+		case lb == nil || rb == nil:
+			return nil, nil
+		case e.op == "xor":
+			return *lb != *rb, nil
+		}
+		return *lb, nil
+	case "=":
+		return equal(l, rv), nil
+	case "<>":
+		if eq := equal(l, rv); eq != nil {
+			return !eq.(bool), nil
+		}
+		return nil, nil
+	case "<", "<=", ">", ">=":
+		c, ok := compare(l, rv)
+		if !ok {
+			return nil, nil
+		}
+		switch e.op {
+		case "<":
+			return c < 0, nil
+		case "<=":
+			return c <= 0, nil
+		case ">":
+			return c > 0, nil
+		}
+		return c >= 0, nil
+	case "=~":
+		s, ok1 := l.(string)
+		re, ok2 := rv.(string)
+		if !ok1 || !ok2 {
+			return nil, nil
+		}
+		m, err := regexp.MatchString("^(?:"+re+")$", s)
+		if err != nil {
+			return nil, x.Errorf("Invalid regular expression %q: %v", re, err)
+		}
+		return m, nil
+	case "starts", "ends", "contains":
+		s, ok1 := l.(string)
+		sub, ok2 := rv.(string)
+		if !ok1 || !ok2 {
+			return nil, nil
+		}
+		switch e.op {
+		case "starts":
+			return strings.HasPrefix(s, sub), nil
+		case "ends":
+			return strings.HasSuffix(s, sub), nil
+		}
+		return strings.Contains(s, sub), nil
+	case "in":
+		elems, ok := rv.([]interface{})
+		if !ok {
+			if rv == nil {
+				return nil, nil
+			}
+			return nil, x.Errorf("IN takes a list")
+		}
+		var res interface{} = false
+		for _, el := range elems {
+			switch equal(l, el) {
+			case true:
+				return true, nil
+			case nil:
+				res = nil
+			}
+		}
+		return res, nil
+	}
+	return arithmetic(e.op, l, rv)
+}
+
+func arithmetic(op string, l, r interface{}) (interface{}, error) {
+	if l == nil || r == nil {
+		return nil, nil
+	}
+	if op == "+" {
+		if ll, ok := l.([]interface{}); ok {
+			if rl, ok := r.([]interface{}); ok {
+				return append(append([]interface{}{}, ll...), rl...), nil
+			}
+			return append(append([]interface{}{}, ll...), r), nil
+		}
+		ls, lok := l.(string)
+		rs, rok := r.(string)
+		switch {
+		case lok && rok:
+			return ls + rs, nil
+		case lok:
+			return ls + fmt.Sprint(r), nil
+		case rok:
+			return fmt.Sprint(l) + rs, nil
+		}
+	}
+
+	li, lint := l.(int64)
+	ri, rint := r.(int64)
+	if lint && rint {
+		switch op {
+		case "+":
+			return li + ri, nil
+		case "-":
+			return li - ri, nil
+		case "*":
+			return li * ri, nil
+		}
+		if ri == 0 {
+			return nil, x.Errorf("Division by zero")
+		}
+		if op == "/" {
+			return li / ri, nil
+		}
+		return li % ri, nil
+	}
+	lf, lok := number(l)
+	rf, rok := number(r)
+	if !lok || !rok {
+		return nil, x.Errorf("Operator %s takes numbers, got %v and %v", op, output(l),
+			output(r))
+	}
+	switch op {
+	case "+":
+		return lf + rf, nil
+	case "-":
+		return lf - rf, nil
+	case "*":
+		return lf * rf, nil
+	case "/":
+		return lf / rf, nil
+	}
+	return math.Mod(lf, rf), nil
+}
+
+func number(v interface{}) (float64, bool) {
+	switch v := v.(type) {
+	case int64:
+		return float64(v), true
+	case float64:
+		return v, true
+	}
+	return 0, false
+}
+
+// compare compares comparable values, which are numbers, strings and booleans of the same kind.
+func compare(a, b interface{}) (int, bool) {
+	if af, ok := number(a); ok {
+		bf, ok := number(b)
+		switch {
+		case !ok:
+			return 0, false
+		case af < bf:
+			return -1, true
+		case af > bf:
+			return 1, true
+		}
+		return 0, true
+	}
+	switch a := a.(type) {
+	case string:
+		b, ok := b.(string)
+		return strings.Compare(a, b), ok
+	case bool:
+		b, ok := b.(bool)
+		switch {
+		case !ok:
+			return 0, false
+		case a == b:
+			return 0, true
+		case !a:
+			return -1, true
+		}
+		return 1, true
+	}
+	return 0, false
+}
+
+// equal returns whether the values are equal, or nil if that's unknown because of nulls.
+func equal(a, b interface{}) interface{} {
+	if a == nil || b == nil {
+		return nil
+	}
+	switch a := a.(type) {
+	case node:
+		b, ok := b.(node)
+		return ok && a.uid() == b.uid()
+	case rel:
+		b, ok := b.(rel)
+		return ok && a == b
+	case []interface{}:
+		b, ok := b.([]interface{})
+		if !ok || len(a) != len(b) {
+			return false
+		}
+		var res interface{} = true
+		for i := range a {
+			switch equal(a[i], b[i]) {
+			case false:
+				return false
+			case nil:
+				res = nil
+			}
+		}
+		return res
+	}
+	c, ok := compare(a, b)
+	return ok && c == 0
+}
+
+func contains(vals []interface{}, v interface{}) bool {
+	for _, val := range vals {
+		if equal(val, v) == true {
+			return true
+		}
+	}
+	return false
+}
+
+// orderCompare orders all values: by kind, then as compare does, with nulls last.
+func orderCompare(a, b interface{}) int {
+	rank := func(v interface{}) int {
+		switch v.(type) {
+		case node:
+			return 0
+		case rel:
+			return 1
+		case []interface{}:
+			return 2
+		case string:
+			return 3
+		case bool:
+			return 4
+		case int64, float64:
+			return 5
+		case nil:
+			return 7
+		}
+		return 6
+	}
+	if ra, rb := rank(a), rank(b); ra != rb {
+		return ra - rb
+	}
+	if c, ok := compare(a, b); ok {
+		return c
+	}
+	return strings.Compare(valueKey([]interface{}{a}), valueKey([]interface{}{b}))
+}
+
+// scalarFuncs are the functions of values, with the number of arguments they take, or -1 if
+// they take any.
+var scalarFuncs = map[string]int{
+	"id":       1,
+	"labels":   1,
+	"type":     1,
+	"tolower":  1,
+	"toupper":  1,
+	"size":     1,
+	"tostring": 1,
+	"coalesce": -1,
+}
+
+// applyScalar returns the value of the scalar function for the values of its arguments.
+func applyScalar(name string, args []interface{}) (interface{}, error) {
+	if name == "coalesce" {
+		for _, arg := range args {
+			if arg != nil {
+				return arg, nil
+			}
+		}
+		return nil, nil
+	}
+
+	switch v := args[0].(type) {
+	case nil:
+		return nil, nil
+	case node:
+		switch name {
+		case "id":
+			return v.uid(), nil
+		case "labels":
+			return v.labels(), nil
+		}
+	case rel:
+		if name == "type" {
+			return v.typ, nil
+		}
+	case string:
+		switch name {
+		case "tolower":
+			return strings.ToLower(v), nil
+		case "toupper":
+			return strings.ToUpper(v), nil
+		case "size":
+			return int64(utf8.RuneCountInString(v)), nil
+		case "tostring":
+			return v, nil
+		}
+	case []interface{}:
+		if name == "size" {
+			return int64(len(v)), nil
+		}
+	case int64, float64, bool:
+		if name == "tostring" {
+			return fmt.Sprint(v), nil
+		}
+	}
+	return nil, x.Errorf("Function %s doesn't take %v", name, output(args[0]))
+}
+
+// aggregation returns the aggregate of the values, which aren't null.
+type aggregation func(vals []interface{}) (interface{}, error)
+
+var aggregationFuncs = map[string]aggregation{
+	"count": func(vals []interface{}) (interface{}, error) {
+		return int64(len(vals)), nil
+	},
+	"collect": func(vals []interface{}) (interface{}, error) {
+		return append([]interface{}{}, vals...), nil
+	},
+	"sum": func(vals []interface{}) (interface{}, error) {
+		var sum interface{} = int64(0)
+		for _, v := range vals {
+			if _, ok := number(v); !ok {
+				return nil, x.Errorf("sum takes numbers, got %v", output(v))
+			}
+			var err error
+			if sum, err = arithmetic("+", sum, v); err != nil {
+				return nil, err
+			}
+		}
+		return sum, nil
+	},
+	"avg": func(vals []interface{}) (interface{}, error) {
+		if len(vals) == 0 {
+			return nil, nil
+		}
+		var sum float64
+		for _, v := range vals {
+			f, ok := number(v)
+			if !ok {
+				return nil, x.Errorf("avg takes numbers, got %v", output(v))
+			}
+			sum += f
+		}
+		return sum / float64(len(vals)), nil
+	},
+	"min": func(vals []interface{}) (interface{}, error) {
+		return extreme(vals, -1), nil
+	},
+	"max": func(vals []interface{}) (interface{}, error) {
+		return extreme(vals, 1), nil
+	},
+}
+
+// extreme returns the smallest of the values if sign is -1, or the largest if it's 1.
+func extreme(vals []interface{}, sign int) interface{} {
+	var res interface{}
+	for _, v := range vals {
+		if res == nil || orderCompare(v, res)*sign > 0 {
+			res = v
+		}
+	}
+	return res
+}
+
+// aggregate returns the aggregation of the values of the call's argument for the group.
+func (q *Query) aggregate(f aggregation, e *call, group []row) (interface{}, error) {
+	if e.star {
+		return int64(len(group)), nil
+	}
+	var vals []interface{}
+	seen := make(map[string]bool)
+	for _, r := range group {
+		v, err := q.eval(e.args[0], r, nil)
+		if err != nil {
+			return nil, err
+		}
+		if v == nil {
+			continue
+		}
+		if e.distinct {
+			key := valueKey([]interface{}{v})
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+		}
+		vals = append(vals, v)
+	}
+	return f(vals)
+}
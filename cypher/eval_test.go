@@ -0,0 +1,147 @@
+/*
+ * Copyright 2018 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cypher
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// The people of the tests: Alice knows Bob and Carol, and Bob knows Carol.
+const knows = `{"pattern0": [
+	{"uid": "0x1", "dgraph.label": "Person", "name": "Alice", "age": 30, "_right": [
+		{"uid": "0x2", "name": "Bob", "age": 25},
+		{"uid": "0x3", "name": "Carol", "age": 35.5}
+	]},
+	{"uid": "0x2", "dgraph.label": ["Person", "Admin"], "name": "Bob", "age": 25, "_right": [
+		{"uid": "0x3", "name": "Carol", "age": 35.5}
+	]},
+	{"uid": "0x3", "dgraph.label": "Person", "name": "Carol", "age": 35.5}
+]}`
+
+func rows(t *testing.T, src string, params map[string]interface{}, js string) *Result {
+	q, err := Parse(src, params)
+	require.NoError(t, err)
+	res, err := q.Rows([]byte(js))
+	require.NoError(t, err)
+	return res
+}
+
+func rowsJSON(t *testing.T, res *Result) string {
+	js, err := json.Marshal(res)
+	require.NoError(t, err)
+	return string(js)
+}
+
+func TestRows(t *testing.T) {
+	res := rows(t, `MATCH (a:Person)-[r:KNOWS]->(b) RETURN a.name, b.name AS friend, type(r)`,
+		nil, knows)
+	require.Equal(t, []string{"a.name", "friend", "type(r)"}, res.Columns)
+	require.Equal(t, [][]interface{}{
+		{"Alice", "Bob", "KNOWS"},
+		{"Alice", "Carol", "KNOWS"},
+		{"Bob", "Carol", "KNOWS"},
+	}, res.Rows)
+}
+
+func TestRowsNodes(t *testing.T) {
+	res := rows(t, `MATCH (a:Admin)-[:KNOWS]->(b) RETURN b`, nil, knows)
+	require.JSONEq(t, `{"columns": ["b"], "rows": [[{"uid": "0x3", "name": "Carol", "age": 35.5}]]}`,
+		rowsJSON(t, res))
+
+	res = rows(t, `MATCH (a:Person {name: 'Alice'})-[:KNOWS]->(b) RETURN labels(a), id(b)`, nil,
+		knows)
+	require.Equal(t, [][]interface{}{
+		{[]interface{}{"Person"}, "0x2"},
+		{[]interface{}{"Person"}, "0x3"},
+	}, res.Rows)
+}
+
+func TestRowsWhere(t *testing.T) {
+	for src, want := range map[string]string{
+		`MATCH (a:Person)-[:KNOWS]->(b) WHERE b.age > 30 RETURN a.name`:                     `[["Alice"],["Bob"]]`,
+		`MATCH (a:Person)-[:KNOWS]->(b) WHERE b.name STARTS WITH 'B' RETURN a.name`:         `[["Alice"]]`,
+		`MATCH (a:Person)-[:KNOWS]->(b) WHERE a.name =~ 'A.*' XOR b.age < 30 RETURN b.name`: `[["Carol"]]`,
+		`MATCH (a:Person)-[:KNOWS]->(b) WHERE b.missing = 1 OR a.age IN [25] RETURN b.name`: `[["Carol"]]`,
+		`MATCH (a:Person)-[:KNOWS]->(b) WHERE NOT b.missing IS NULL RETURN b.name`:          `[]`,
+	} {
+		res := rows(t, src, nil, knows)
+		js, err := json.Marshal(res.Rows)
+		require.NoError(t, err)
+		require.JSONEq(t, want, string(js), src)
+	}
+}
+
+func TestRowsAggregations(t *testing.T) {
+	res := rows(t, `MATCH (a:Person)-[:KNOWS]->(b) RETURN a.name, count(*) AS n, collect(b.name),
+		max(b.age), avg(b.age) ORDER BY n DESC`, nil, knows)
+	require.Equal(t, [][]interface{}{
+		{"Alice", int64(2), []interface{}{"Bob", "Carol"}, 35.5, 30.25},
+		{"Bob", int64(1), []interface{}{"Carol"}, 35.5, 35.5},
+	}, res.Rows)
+
+	res = rows(t, `MATCH (a:Person)-[:KNOWS]->(b) RETURN count(DISTINCT b), sum(a.age)`, nil,
+		knows)
+	require.Equal(t, [][]interface{}{{int64(2), int64(85)}}, res.Rows)
+
+	res = rows(t, `MATCH (a:Person)-[:KNOWS]->(b) RETURN count(*), collect(a.name)`, nil,
+		`{"pattern0": []}`)
+	require.Equal(t, [][]interface{}{{int64(0), []interface{}{}}}, res.Rows)
+}
+
+func TestRowsOrder(t *testing.T) {
+	res := rows(t, `MATCH (a:Person) RETURN DISTINCT a.age AS age ORDER BY age DESC SKIP 1
+		LIMIT $n`, map[string]interface{}{"n": 5}, knows)
+	require.Equal(t, [][]interface{}{{int64(30)}, {int64(25)}}, res.Rows)
+
+	res = rows(t, `MATCH (a:Person) RETURN a.name ORDER BY a.nickname, a.name DESC`, nil, knows)
+	require.Equal(t, [][]interface{}{{"Carol"}, {"Bob"}, {"Alice"}}, res.Rows)
+}
+
+func TestRowsJoin(t *testing.T) {
+	// The node matched twice in a pattern must be the same.
+	res := rows(t, `MATCH (a:Person)-[:KNOWS]->(b)-[:KNOWS]->(a) RETURN a`, nil, `{"pattern0": [
+		{"uid": "0x1", "dgraph.label": "Person", "_right": [
+			{"uid": "0x2", "_right": [{"uid": "0x1"}, {"uid": "0x3"}]}
+		]}
+	]}`)
+	require.Len(t, res.Rows, 1)
+
+	res = rows(t, `MATCH (a:Person {name: 'Alice'}), (a)-[:OWNS]->(d:Dog) RETURN a.name, d.name`,
+		nil, `{
+			"pattern0": [{"uid": "0x1", "name": "Alice", "dgraph.label": "Person"}],
+			"pattern1": [
+				{"uid": "0x4", "name": "Rex", "dgraph.label": "Dog", "_left": [{"uid": "0x1"}]},
+				{"uid": "0x5", "name": "Fido", "dgraph.label": "Dog", "_left": [{"uid": "0x2"}]}
+			]
+		}`)
+	require.Equal(t, [][]interface{}{{"Alice", "Rex"}}, res.Rows)
+}
+
+func TestRowsErrors(t *testing.T) {
+	q, err := Parse(`MATCH (a:Person) RETURN a.age / 0`, nil)
+	require.NoError(t, err)
+	_, err = q.Rows([]byte(knows))
+	require.Contains(t, err.Error(), "Division by zero")
+
+	q, err = Parse(`MATCH (a:Person) WHERE a.name RETURN a`, nil)
+	require.NoError(t, err)
+	_, err = q.Rows([]byte(knows))
+	require.Contains(t, err.Error(), "Expected a boolean")
+}
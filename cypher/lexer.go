@@ -0,0 +1,194 @@
+/*
+ * Copyright 2018 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cypher
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/dgraph-io/dgraph/x"
+)
+
+type tokenKind int
+
+const (
+	tokEOF tokenKind = iota
+	tokName
+	tokQuotedName // A name in backticks, which is never a keyword.
+	tokInt
+	tokFloat
+	tokString
+	tokParam
+	tokPunct
+)
+
+type token struct {
+	kind tokenKind
+	val  string // Of strings, the value after unescaping.
+	pos  int    // Offset in the query.
+}
+
+// is tells whether t is the keyword kw, which is case insensitive as per openCypher.
+func (t token) is(kw string) bool {
+	return t.kind == tokName && strings.EqualFold(t.val, kw)
+}
+
+func (t token) isPunct(p string) bool {
+	return t.kind == tokPunct && t.val == p
+}
+
+// Punctuation of two characters, which is matched before that of one.
+var punct2 = []string{"<>", "<=", ">=", "=~"}
+
+const punct1 = "()[]{},:;.-<>=+*/%|"
+
+func lex(src string) ([]token, error) {
+	var toks []token
+	for i := 0; i < len(src); {
+		r, size := utf8.DecodeRuneInString(src[i:])
+		switch {
+		case unicode.IsSpace(r):
+			i += size
+			continue
+		case strings.HasPrefix(src[i:], "//"):
+			for i < len(src) && src[i] != '\n' {
+				i++
+			}
+			continue
+		case r == '_' || unicode.IsLetter(r):
+			start := i
+			for i < len(src) {
+				r, size := utf8.DecodeRuneInString(src[i:])
+				if r != '_' && !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+					break
+				}
+				i += size
+			}
+			toks = append(toks, token{kind: tokName, val: src[start:i], pos: start})
+			continue
+		case r == '`':
+			end := strings.IndexByte(src[i+1:], '`')
+			if end < 0 {
+				return nil, x.Errorf("Unterminated name at offset %d", i)
+			}
+			toks = append(toks, token{kind: tokQuotedName, val: src[i+1 : i+1+end], pos: i})
+			i += end + 2
+			continue
+		case r == '$':
+			start := i
+			i++
+			for i < len(src) {
+				r, size := utf8.DecodeRuneInString(src[i:])
+				if r != '_' && !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+					break
+				}
+				i += size
+			}
+			if i == start+1 {
+				return nil, x.Errorf("Parameter without a name at offset %d", start)
+			}
+			toks = append(toks, token{kind: tokParam, val: src[start+1 : i], pos: start})
+			continue
+		case r >= '0' && r <= '9':
+			start, kind := i, tokInt
+			for i < len(src) && src[i] >= '0' && src[i] <= '9' {
+				i++
+			}
+			if i+1 < len(src) && src[i] == '.' && src[i+1] >= '0' && src[i+1] <= '9' {
+				kind = tokFloat
+				for i++; i < len(src) && src[i] >= '0' && src[i] <= '9'; i++ {
+				}
+			}
+			if i < len(src) && (src[i] == 'e' || src[i] == 'E') {
+				kind = tokFloat
+				i++
+				if i < len(src) && (src[i] == '+' || src[i] == '-') {
+					i++
+				}
+				for ; i < len(src) && src[i] >= '0' && src[i] <= '9'; i++ {
+				}
+			}
+			toks = append(toks, token{kind: kind, val: src[start:i], pos: start})
+			continue
+		case r == '\'' || r == '"':
+			s, n, err := lexString(src[i:])
+			if err != nil {
+				return nil, x.Errorf("%v at offset %d", err, i)
+			}
+			toks = append(toks, token{kind: tokString, val: s, pos: i})
+			i += n
+			continue
+		}
+
+		matched := false
+		for _, p := range punct2 {
+			if strings.HasPrefix(src[i:], p) {
+				toks = append(toks, token{kind: tokPunct, val: p, pos: i})
+				i += len(p)
+				matched = true
+				break
+			}
+		}
+		if matched {
+			continue
+		}
+		if strings.IndexByte(punct1, src[i]) < 0 {
+			return nil, x.Errorf("Unexpected character %q at offset %d", r, i)
+		}
+		toks = append(toks, token{kind: tokPunct, val: src[i : i+1], pos: i})
+		i++
+	}
+	return append(toks, token{kind: tokEOF, pos: len(src)}), nil
+}
+
+// lexString returns the value of the string literal src starts with, and its length in src.
+func lexString(src string) (string, int, error) {
+	quote := src[0]
+	var b strings.Builder
+	for i := 1; i < len(src); i++ {
+		c := src[i]
+		switch {
+		case c == quote:
+			return b.String(), i + 1, nil
+		case c != '\\':
+			b.WriteByte(c)
+			continue
+		}
+		i++
+		if i == len(src) {
+			break
+		}
+		switch src[i] {
+		case 'n':
+			b.WriteByte('\n')
+		case 't':
+			b.WriteByte('\t')
+		case 'r':
+			b.WriteByte('\r')
+		case 'b':
+			b.WriteByte('\b')
+		case 'f':
+			b.WriteByte('\f')
+		case '\\', '\'', '"':
+			b.WriteByte(src[i])
+		default:
+			return "", 0, x.Errorf("Invalid escape \\%c", src[i])
+		}
+	}
+	return "", 0, x.Errorf("Unterminated string")
+}
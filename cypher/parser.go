@@ -0,0 +1,691 @@
+/*
+ * Copyright 2018 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cypher
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/dgraph-io/dgraph/x"
+)
+
+// The subset of openCypher supported is a single query part: MATCH clauses with patterns of fixed
+// length, an optional WHERE, and a RETURN with the optional ORDER BY, SKIP and LIMIT.
+
+type query struct {
+	patterns []*pattern
+	where    expr
+	distinct bool
+	star     bool // RETURN *
+	items    []*returnItem
+	order    []*sortItem
+	skip     expr
+	limit    expr
+}
+
+// A pattern is a path of nodes, with a relationship between every two of them.
+type pattern struct {
+	nodes []*nodePattern
+	rels  []*relPattern
+}
+
+type nodePattern struct {
+	v      string // Generated for nodes without a variable.
+	labels []string
+	props  []*propPattern
+}
+
+type propPattern struct {
+	key string
+	val expr
+}
+
+type relPattern struct {
+	v   string
+	typ string
+	in  bool // Of relationships <-[:T]-, which are from the node on the right.
+}
+
+type returnItem struct {
+	e    expr
+	name string // The alias, or the text of the expression.
+}
+
+type sortItem struct {
+	e    expr
+	desc bool
+}
+
+// Expressions.
+type (
+	expr interface{}
+
+	literal struct {
+		v interface{} // nil, int64, float64, string or bool.
+	}
+	param struct {
+		name string
+	}
+	variable struct {
+		name string
+	}
+	property struct {
+		e   expr
+		key string
+	}
+	// Ops are or, xor, and, the comparisons, the arithmetic operators, in, starts, ends and
+	// contains.
+	binary struct {
+		op   string
+		l, r expr
+	}
+	// Ops are not, -, isnull and isnotnull.
+	unary struct {
+		op string
+		e  expr
+	}
+	call struct {
+		name     string // In lower case.
+		distinct bool
+		star     bool // count(*)
+		args     []expr
+	}
+	list struct {
+		elems []expr
+	}
+)
+
+type parser struct {
+	src  string
+	toks []token
+	i    int
+	anon int // Number of nodes without a variable so far.
+}
+
+func (p *parser) peek() token { return p.toks[p.i] }
+
+func (p *parser) next() token {
+	t := p.toks[p.i]
+	if t.kind != tokEOF {
+		p.i++
+	}
+	return t
+}
+
+func (p *parser) errorf(format string, args ...interface{}) error {
+	t := p.peek()
+	at := "the end of the query"
+	if t.kind != tokEOF {
+		at = "offset " + strconv.Itoa(t.pos)
+	}
+	return x.Errorf(format+" at %s", append(args, at)...)
+}
+
+func (p *parser) unexpected() error {
+	t := p.peek()
+	if t.kind == tokEOF {
+		return x.Errorf("Unexpected end of the query")
+	}
+	return p.errorf("Unexpected %q", strings.TrimSpace(p.src[t.pos:p.toks[p.i+1].pos]))
+}
+
+func (p *parser) skipPunct(s string) bool {
+	if p.peek().isPunct(s) {
+		p.i++
+		return true
+	}
+	return false
+}
+
+func (p *parser) expectPunct(s string) error {
+	if !p.skipPunct(s) {
+		return p.unexpected()
+	}
+	return nil
+}
+
+func (p *parser) skipKeyword(kw string) bool {
+	if p.peek().is(kw) {
+		p.i++
+		return true
+	}
+	return false
+}
+
+func (p *parser) expectKeyword(kw string) error {
+	if !p.skipKeyword(kw) {
+		return p.errorf("Expected %s", kw)
+	}
+	return nil
+}
+
+func (p *parser) name() (string, error) {
+	t := p.peek()
+	if t.kind != tokName && t.kind != tokQuotedName {
+		return "", p.unexpected()
+	}
+	p.i++
+	return t.val, nil
+}
+
+// unsupported are the clauses of openCypher which aren't supported, so that they're rejected
+// with a clear error rather than a syntax error.
+var unsupported = []string{"OPTIONAL", "WITH", "UNWIND", "CREATE", "MERGE", "DELETE", "DETACH",
+	"SET", "REMOVE", "CALL", "UNION", "FOREACH", "LOAD", "START"}
+
+func (p *parser) checkSupported() error {
+	for _, kw := range unsupported {
+		if p.peek().is(kw) {
+			return p.errorf("%s isn't supported", kw)
+		}
+	}
+	return nil
+}
+
+func parse(src string) (*query, error) {
+	toks, err := lex(src)
+	if err != nil {
+		return nil, err
+	}
+	p := &parser{src: src, toks: toks}
+	q := &query{}
+
+	if err := p.checkSupported(); err != nil {
+		return nil, err
+	}
+	if err := p.expectKeyword("MATCH"); err != nil {
+		return nil, err
+	}
+	for {
+		pat, err := p.pattern()
+		if err != nil {
+			return nil, err
+		}
+		q.patterns = append(q.patterns, pat)
+		if p.skipPunct(",") || p.skipKeyword("MATCH") {
+			continue
+		}
+		if err := p.checkSupported(); err != nil {
+			return nil, err
+		}
+		break
+	}
+
+	if p.skipKeyword("WHERE") {
+		if q.where, err = p.expr(); err != nil {
+			return nil, err
+		}
+	}
+	if err := p.checkSupported(); err != nil {
+		return nil, err
+	}
+	if err := p.expectKeyword("RETURN"); err != nil {
+		return nil, err
+	}
+	q.distinct = p.skipKeyword("DISTINCT")
+	if p.skipPunct("*") {
+		q.star = true
+	} else {
+		for {
+			item, err := p.returnItem()
+			if err != nil {
+				return nil, err
+			}
+			q.items = append(q.items, item)
+			if !p.skipPunct(",") {
+				break
+			}
+		}
+	}
+
+	if p.skipKeyword("ORDER") {
+		if err := p.expectKeyword("BY"); err != nil {
+			return nil, err
+		}
+		for {
+			e, err := p.expr()
+			if err != nil {
+				return nil, err
+			}
+			item := &sortItem{e: e}
+			if p.skipKeyword("DESC") || p.skipKeyword("DESCENDING") {
+				item.desc = true
+			} else if !p.skipKeyword("ASC") {
+				p.skipKeyword("ASCENDING")
+			}
+			q.order = append(q.order, item)
+			if !p.skipPunct(",") {
+				break
+			}
+		}
+	}
+	if p.skipKeyword("SKIP") {
+		if q.skip, err = p.expr(); err != nil {
+			return nil, err
+		}
+	}
+	if p.skipKeyword("LIMIT") {
+		if q.limit, err = p.expr(); err != nil {
+			return nil, err
+		}
+	}
+	if err := p.checkSupported(); err != nil {
+		return nil, err
+	}
+	if p.peek().isPunct(";") {
+		p.next()
+	}
+	if p.peek().kind != tokEOF {
+		return nil, p.unexpected()
+	}
+	return q, nil
+}
+
+func (p *parser) returnItem() (*returnItem, error) {
+	start := p.peek().pos
+	e, err := p.expr()
+	if err != nil {
+		return nil, err
+	}
+	item := &returnItem{e: e, name: strings.TrimSpace(p.src[start:p.peek().pos])}
+	if p.skipKeyword("AS") {
+		if item.name, err = p.name(); err != nil {
+			return nil, err
+		}
+	}
+	return item, nil
+}
+
+func (p *parser) pattern() (*pattern, error) {
+	if t := p.peek(); (t.kind == tokName || t.kind == tokQuotedName) &&
+		p.toks[p.i+1].isPunct("=") {
+		return nil, p.errorf("Paths can't be assigned to variables")
+	}
+	pat := &pattern{}
+	n, err := p.nodePattern()
+	if err != nil {
+		return nil, err
+	}
+	pat.nodes = append(pat.nodes, n)
+	for p.peek().isPunct("-") || p.peek().isPunct("<") {
+		r, err := p.relPattern()
+		if err != nil {
+			return nil, err
+		}
+		n, err := p.nodePattern()
+		if err != nil {
+			return nil, err
+		}
+		pat.rels = append(pat.rels, r)
+		pat.nodes = append(pat.nodes, n)
+	}
+	return pat, nil
+}
+
+func (p *parser) nodePattern() (*nodePattern, error) {
+	if err := p.expectPunct("("); err != nil {
+		return nil, err
+	}
+	n := &nodePattern{}
+	if t := p.peek(); t.kind == tokName || t.kind == tokQuotedName {
+		n.v = t.val
+		p.i++
+	} else {
+		// Spaces keep the names generated from being those of any variable.
+		n.v = " node" + strconv.Itoa(p.anon)
+		p.anon++
+	}
+	for p.skipPunct(":") {
+		label, err := p.name()
+		if err != nil {
+			return nil, err
+		}
+		n.labels = append(n.labels, label)
+	}
+	if p.peek().isPunct("{") {
+		props, err := p.props()
+		if err != nil {
+			return nil, err
+		}
+		n.props = props
+	}
+	if err := p.expectPunct(")"); err != nil {
+		return nil, err
+	}
+	return n, nil
+}
+
+func (p *parser) props() ([]*propPattern, error) {
+	if err := p.expectPunct("{"); err != nil {
+		return nil, err
+	}
+	var props []*propPattern
+	for !p.skipPunct("}") {
+		if len(props) > 0 {
+			if err := p.expectPunct(","); err != nil {
+				return nil, err
+			}
+		}
+		key, err := p.name()
+		if err != nil {
+			return nil, err
+		}
+		if err := p.expectPunct(":"); err != nil {
+			return nil, err
+		}
+		val, err := p.expr()
+		if err != nil {
+			return nil, err
+		}
+		props = append(props, &propPattern{key: key, val: val})
+	}
+	return props, nil
+}
+
+func (p *parser) relPattern() (*relPattern, error) {
+	r := &relPattern{in: p.skipPunct("<")}
+	if err := p.expectPunct("-"); err != nil {
+		return nil, err
+	}
+	if p.skipPunct("[") {
+		if t := p.peek(); t.kind == tokName || t.kind == tokQuotedName {
+			r.v = t.val
+			p.i++
+		}
+		if p.skipPunct(":") {
+			typ, err := p.name()
+			if err != nil {
+				return nil, err
+			}
+			r.typ = typ
+		}
+		switch {
+		case p.peek().isPunct("|"):
+			return nil, p.errorf("Relationships can only have a single type")
+		case p.peek().isPunct("*"):
+			return nil, p.errorf("Only patterns of fixed length are supported")
+		case p.peek().isPunct("{"):
+			return nil, p.errorf("Relationships can't have properties")
+		}
+		if err := p.expectPunct("]"); err != nil {
+			return nil, err
+		}
+	}
+	if err := p.expectPunct("-"); err != nil {
+		return nil, err
+	}
+	out := p.skipPunct(">")
+	switch {
+	case out && r.in:
+		return nil, p.errorf("Relationships can't have both directions")
+	case !out && !r.in:
+		return nil, p.errorf("Relationships must have a direction")
+	case len(r.typ) == 0:
+		return nil, p.errorf("Relationships must have a type")
+	}
+	return r, nil
+}
+
+func (p *parser) expr() (expr, error) {
+	return p.binaryExpr(0)
+}
+
+// levels are the binary operators by increasing precedence, with the keywords in upper case.
+var levels = [][]string{{"OR"}, {"XOR"}, {"AND"}}
+
+func (p *parser) binaryExpr(level int) (expr, error) {
+	if level == len(levels) {
+		return p.notExpr()
+	}
+	l, err := p.binaryExpr(level + 1)
+	if err != nil {
+		return nil, err
+	}
+	for {
+		op := ""
+		for _, kw := range levels[level] {
+			if p.skipKeyword(kw) {
+				op = strings.ToLower(kw)
+			}
+		}
+		if len(op) == 0 {
+			return l, nil
+		}
+		r, err := p.binaryExpr(level + 1)
+		if err != nil {
+			return nil, err
+		}
+		l = &binary{op: op, l: l, r: r}
+	}
+}
+
+func (p *parser) notExpr() (expr, error) {
+	if p.skipKeyword("NOT") {
+		e, err := p.notExpr()
+		if err != nil {
+			return nil, err
+		}
+		return &unary{op: "not", e: e}, nil
+	}
+	return p.comparison()
+}
+
+var comparisons = []string{"=", "<>", "<", "<=", ">", ">=", "=~"}
+
+// comparison parses chains of comparisons, so that a < b < c is a < b AND b < c.
+func (p *parser) comparison() (expr, error) {
+	l, err := p.additive()
+	if err != nil {
+		return nil, err
+	}
+	var res expr
+	and := func(e expr) {
+		if res == nil {
+			res = e
+		} else {
+			res = &binary{op: "and", l: res, r: e}
+		}
+	}
+	for {
+		var op string
+		t := p.peek()
+		for _, c := range comparisons {
+			if t.isPunct(c) {
+				op = c
+			}
+		}
+		switch {
+		case len(op) > 0:
+			p.next()
+		case t.is("IS"):
+			p.next()
+			op = "isnull"
+			if p.skipKeyword("NOT") {
+				op = "isnotnull"
+			}
+			if err := p.expectKeyword("NULL"); err != nil {
+				return nil, err
+			}
+			and(&unary{op: op, e: l})
+			continue
+		case t.is("STARTS") || t.is("ENDS"):
+			p.next()
+			op = strings.ToLower(t.val)
+			if err := p.expectKeyword("WITH"); err != nil {
+				return nil, err
+			}
+		case t.is("CONTAINS") || t.is("IN"):
+			p.next()
+			op = strings.ToLower(t.val)
+		}
+		if len(op) == 0 {
+			break
+		}
+		r, err := p.additive()
+		if err != nil {
+			return nil, err
+		}
+		and(&binary{op: op, l: l, r: r})
+		l = r
+	}
+	if res == nil {
+		return l, nil
+	}
+	return res, nil
+}
+
+func (p *parser) additive() (expr, error) {
+	l, err := p.multiplicative()
+	if err != nil {
+		return nil, err
+	}
+	for p.peek().isPunct("+") || p.peek().isPunct("-") {
+		op := p.next().val
+		r, err := p.multiplicative()
+		if err != nil {
+			return nil, err
+		}
+		l = &binary{op: op, l: l, r: r}
+	}
+	return l, nil
+}
+
+func (p *parser) multiplicative() (expr, error) {
+	l, err := p.unaryExpr()
+	if err != nil {
+		return nil, err
+	}
+	for p.peek().isPunct("*") || p.peek().isPunct("/") || p.peek().isPunct("%") {
+		op := p.next().val
+		r, err := p.unaryExpr()
+		if err != nil {
+			return nil, err
+		}
+		l = &binary{op: op, l: l, r: r}
+	}
+	return l, nil
+}
+
+func (p *parser) unaryExpr() (expr, error) {
+	switch {
+	case p.skipPunct("-"):
+		e, err := p.unaryExpr()
+		if err != nil {
+			return nil, err
+		}
+		return &unary{op: "-", e: e}, nil
+	case p.skipPunct("+"):
+		return p.unaryExpr()
+	}
+	e, err := p.primary()
+	if err != nil {
+		return nil, err
+	}
+	for p.skipPunct(".") {
+		key, err := p.name()
+		if err != nil {
+			return nil, err
+		}
+		e = &property{e: e, key: key}
+	}
+	return e, nil
+}
+
+func (p *parser) primary() (expr, error) {
+	t := p.peek()
+	switch {
+	case t.kind == tokInt:
+		p.next()
+		v, err := strconv.ParseInt(t.val, 10, 64)
+		if err != nil {
+			return nil, x.Errorf("Invalid integer %s at offset %d", t.val, t.pos)
+		}
+		return &literal{v: v}, nil
+	case t.kind == tokFloat:
+		p.next()
+		v, err := strconv.ParseFloat(t.val, 64)
+		if err != nil {
+			return nil, x.Errorf("Invalid float %s at offset %d", t.val, t.pos)
+		}
+		return &literal{v: v}, nil
+	case t.kind == tokString:
+		p.next()
+		return &literal{v: t.val}, nil
+	case t.kind == tokParam:
+		p.next()
+		return &param{name: t.val}, nil
+	case t.is("TRUE"):
+		p.next()
+		return &literal{v: true}, nil
+	case t.is("FALSE"):
+		p.next()
+		return &literal{v: false}, nil
+	case t.is("NULL"):
+		p.next()
+		return &literal{}, nil
+	case t.isPunct("("):
+		p.next()
+		e, err := p.expr()
+		if err != nil {
+			return nil, err
+		}
+		return e, p.expectPunct(")")
+	case t.isPunct("["):
+		p.next()
+		l := &list{}
+		for !p.skipPunct("]") {
+			if len(l.elems) > 0 {
+				if err := p.expectPunct(","); err != nil {
+					return nil, err
+				}
+			}
+			e, err := p.expr()
+			if err != nil {
+				return nil, err
+			}
+			l.elems = append(l.elems, e)
+		}
+		return l, nil
+	case t.kind == tokName && p.toks[p.i+1].isPunct("("):
+		p.i += 2
+		c := &call{name: strings.ToLower(t.val)}
+		if p.skipPunct("*") {
+			c.star = true
+			return c, p.expectPunct(")")
+		}
+		c.distinct = p.skipKeyword("DISTINCT")
+		for !p.skipPunct(")") {
+			if len(c.args) > 0 {
+				if err := p.expectPunct(","); err != nil {
+					return nil, err
+				}
+			}
+			e, err := p.expr()
+			if err != nil {
+				return nil, err
+			}
+			c.args = append(c.args, e)
+		}
+		return c, nil
+	case t.kind == tokName || t.kind == tokQuotedName:
+		p.next()
+		return &variable{name: t.val}, nil
+	case t.isPunct("{"):
+		return nil, p.errorf("Map literals aren't supported")
+	}
+	return nil, p.unexpected()
+}
@@ -0,0 +1,133 @@
+/*
+ * Copyright 2018 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cypher
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLex(t *testing.T) {
+	toks, err := lex("MATCH (`a b`)<-[:KNOWS]-() // comment\nWHERE a.x =~ 'it\\'s' <> $p, 1.5e3")
+	require.NoError(t, err)
+	var vals []string
+	for _, tok := range toks[:len(toks)-1] {
+		vals = append(vals, tok.val)
+	}
+	require.Equal(t, []string{"MATCH", "(", "a b", ")", "<", "-", "[", ":", "KNOWS", "]", "-",
+		"(", ")", "WHERE", "a", ".", "x", "=~", "it's", "<>", "p", ",", "1.5e3"}, vals)
+	require.Equal(t, tokQuotedName, toks[2].kind)
+	require.Equal(t, tokParam, toks[20].kind)
+	require.Equal(t, tokFloat, toks[22].kind)
+	require.Equal(t, tokEOF, toks[len(toks)-1].kind)
+
+	_, err = lex("'unterminated")
+	require.Error(t, err)
+	_, err = lex("a # b")
+	require.Contains(t, err.Error(), "offset 2")
+}
+
+func TestParsePatterns(t *testing.T) {
+	q, err := parse(`match (a:Person {name: 'Alice'})-[r:KNOWS]->(b), (b)<-[:OWNS]-(:Dog)
+		return a, b.name AS name`)
+	require.NoError(t, err)
+	require.Len(t, q.patterns, 2)
+
+	pat := q.patterns[0]
+	require.Len(t, pat.nodes, 2)
+	require.Equal(t, "a", pat.nodes[0].v)
+	require.Equal(t, []string{"Person"}, pat.nodes[0].labels)
+	require.Equal(t, "name", pat.nodes[0].props[0].key)
+	require.Equal(t, &literal{v: "Alice"}, pat.nodes[0].props[0].val)
+	require.Equal(t, &relPattern{v: "r", typ: "KNOWS"}, pat.rels[0])
+
+	pat = q.patterns[1]
+	require.Equal(t, &relPattern{typ: "OWNS", in: true}, pat.rels[0])
+	require.Equal(t, " node0", pat.nodes[1].v)
+	require.Equal(t, []string{"Dog"}, pat.nodes[1].labels)
+
+	require.Equal(t, "a", q.items[0].name)
+	require.Equal(t, "name", q.items[1].name)
+	require.Equal(t, &property{e: &variable{name: "b"}, key: "name"}, q.items[1].e)
+}
+
+func TestParseClauses(t *testing.T) {
+	q, err := parse(`MATCH (n) MATCH (m) WHERE n.age >= 18 RETURN DISTINCT n.name, count(*)
+		ORDER BY n.name DESC, count(*) SKIP 1 LIMIT $limit;`)
+	require.NoError(t, err)
+	require.Len(t, q.patterns, 2)
+	require.True(t, q.distinct)
+	require.Equal(t, "count(*)", q.items[1].name)
+	require.Equal(t, &call{name: "count", star: true}, q.items[1].e)
+	require.Len(t, q.order, 2)
+	require.True(t, q.order[0].desc)
+	require.False(t, q.order[1].desc)
+	require.Equal(t, &literal{v: int64(1)}, q.skip)
+	require.Equal(t, &param{name: "limit"}, q.limit)
+
+	q, err = parse(`MATCH (n) RETURN *`)
+	require.NoError(t, err)
+	require.True(t, q.star)
+}
+
+func TestParseExpressions(t *testing.T) {
+	q, err := parse(`MATCH (n) WHERE NOT n.a = 1 OR n.b < n.c <= 3 AND n.d IS NOT NULL
+		RETURN -n.a + 2 * 3, n.s STARTS WITH 'x', [1, 'a'], toLower(n.s)`)
+	require.NoError(t, err)
+
+	a := &property{e: &variable{name: "n"}, key: "a"}
+	b := &property{e: &variable{name: "n"}, key: "b"}
+	c := &property{e: &variable{name: "n"}, key: "c"}
+	d := &property{e: &variable{name: "n"}, key: "d"}
+	require.Equal(t, &binary{op: "or",
+		l: &unary{op: "not", e: &binary{op: "=", l: a, r: &literal{v: int64(1)}}},
+		r: &binary{op: "and",
+			l: &binary{op: "and",
+				l: &binary{op: "<", l: b, r: c},
+				r: &binary{op: "<=", l: c, r: &literal{v: int64(3)}}},
+			r: &unary{op: "isnotnull", e: d}},
+	}, q.where)
+
+	require.Equal(t, &binary{op: "+", l: &unary{op: "-", e: a},
+		r: &binary{op: "*", l: &literal{v: int64(2)}, r: &literal{v: int64(3)}}}, q.items[0].e)
+	require.Equal(t, "starts", q.items[1].e.(*binary).op)
+	require.Equal(t, &list{elems: []expr{&literal{v: int64(1)}, &literal{v: "a"}}}, q.items[2].e)
+	require.Equal(t, "tolower", q.items[3].e.(*call).name)
+}
+
+func TestParseErrors(t *testing.T) {
+	for src, msg := range map[string]string{
+		`MATCH (a) CREATE (b)`:                   "CREATE isn't supported",
+		`OPTIONAL MATCH (a) RETURN a`:            "OPTIONAL isn't supported",
+		`MATCH (a)-[:A*2]->(b) RETURN a`:         "Only patterns of fixed length",
+		`MATCH (a)-[:A|B]->(b) RETURN a`:         "a single type",
+		`MATCH (a)-[:A]-(b) RETURN a`:            "must have a direction",
+		`MATCH (a)-[]->(b) RETURN a`:             "must have a type",
+		`MATCH (a)<-[:A]->(b) RETURN a`:          "both directions",
+		`MATCH p = (a)-[:A]->(b) RETURN p`:       "Paths can't be assigned",
+		`MATCH (a) RETURN {x: 1}`:                "Map literals",
+		`MATCH (a) RETURN a extra`:               "at offset 19",
+		`MATCH (a) WHERE`:                        "Unexpected end of the query",
+		`MATCH (a)-[:A {w: 1}]->(b) RETURN a, b`: "can't have properties",
+		`MATCH (a RETURN a`:                      `Unexpected "RETURN" at offset 9`,
+	} {
+		_, err := parse(src)
+		require.Error(t, err, src)
+		require.Contains(t, err.Error(), msg, src)
+	}
+}
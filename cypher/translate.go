@@ -0,0 +1,475 @@
+/*
+ * Copyright 2018 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package cypher translates a subset of openCypher to queries of Dgraph.
+//
+// Every pattern of a query is run as a block, which starts from one of its nodes and follows its
+// relationships, as the edges of their type, in both directions. The tree of nodes the block
+// returns is then turned into the rows of the pattern, one for every path matching it. WHERE and
+// RETURN are evaluated over the rows, once joined.
+package cypher
+
+import (
+	"encoding/json"
+	"sort"
+	"strconv"
+
+	"github.com/dgraph-io/dgraph/gql"
+	"github.com/dgraph-io/dgraph/x"
+)
+
+// LabelPredicate holds the labels of nodes, one value for each. It must have an exact or hash
+// index for nodes to be found by label.
+const LabelPredicate = "dgraph.label"
+
+// Aliases of the edges leading to the nodes on the left and right of a node in its pattern.
+const (
+	leftEdge  = "_left"
+	rightEdge = "_right"
+)
+
+// Query is a query translated to a query of Dgraph.
+type Query struct {
+	q      *query
+	params map[string]interface{}
+	roots  []int // Of every pattern, the index of the node its block starts from.
+
+	vars  []string // Those named in the patterns, in order of appearance.
+	rels  map[string]bool
+	needs map[string]*needs
+}
+
+// needs are the values fetched for the nodes of a variable.
+type needs struct {
+	props  map[string]bool
+	labels bool
+	all    bool // For nodes returned, or passed to functions needing all their properties.
+}
+
+// Parse parses the openCypher query, with the values of its parameters.
+func Parse(src string, params map[string]interface{}) (*Query, error) {
+	q, err := parse(src)
+	if err != nil {
+		return nil, err
+	}
+	res := &Query{
+		q:      q,
+		params: make(map[string]interface{}, len(params)),
+		rels:   make(map[string]bool),
+		needs:  make(map[string]*needs),
+	}
+	for k, v := range params {
+		res.params[k] = normalize(v)
+	}
+	if err := res.check(); err != nil {
+		return nil, err
+	}
+	for _, pat := range q.patterns {
+		root, err := res.root(pat)
+		if err != nil {
+			return nil, err
+		}
+		res.roots = append(res.roots, root)
+	}
+	return res, nil
+}
+
+// check checks the use of the variables, parameters and aggregations, and collects the values
+// the nodes of every variable need.
+func (q *Query) check() error {
+	nodes := make(map[string]bool)
+	for _, pat := range q.q.patterns {
+		for _, n := range pat.nodes {
+			if q.rels[n.v] {
+				return x.Errorf("Variable %s is used for both nodes and relationships", n.v)
+			}
+			if !nodes[n.v] {
+				nodes[n.v] = true
+				q.needs[n.v] = &needs{props: make(map[string]bool)}
+				if n.v[0] != ' ' {
+					q.vars = append(q.vars, n.v)
+				}
+			}
+			nd := q.needs[n.v]
+			nd.labels = nd.labels || len(n.labels) > 0
+			for _, p := range n.props {
+				nd.props[p.key] = true
+				if err := q.checkExpr(p.val, nil, false); err != nil {
+					return err
+				}
+			}
+		}
+		for _, r := range pat.rels {
+			if len(r.v) == 0 {
+				continue
+			}
+			switch {
+			case nodes[r.v]:
+				return x.Errorf("Variable %s is used for both nodes and relationships", r.v)
+			case q.rels[r.v]:
+				return x.Errorf("Relationship variable %s is used more than once", r.v)
+			}
+			q.rels[r.v] = true
+			q.vars = append(q.vars, r.v)
+		}
+	}
+
+	if q.q.where != nil {
+		if err := q.checkExpr(q.q.where, nil, false); err != nil {
+			return err
+		}
+	}
+	if q.q.star {
+		if len(q.vars) == 0 {
+			return x.Errorf("RETURN * needs variables to return")
+		}
+		for _, v := range q.vars {
+			q.q.items = append(q.q.items, &returnItem{e: &variable{name: v}, name: v})
+		}
+	}
+	names := make(map[string]bool)
+	for _, item := range q.q.items {
+		if names[item.name] {
+			return x.Errorf("Column %s is returned more than once", item.name)
+		}
+		names[item.name] = true
+		if err := q.checkExpr(item.e, nil, true); err != nil {
+			return err
+		}
+	}
+	aggregated := false
+	for _, item := range q.q.items {
+		aggregated = aggregated || hasAggregation(item.e)
+	}
+	for _, item := range q.q.order {
+		// Rows are only grouped for aggregations in RETURN.
+		if err := q.checkExpr(item.e, names, aggregated); err != nil {
+			return err
+		}
+	}
+	for _, e := range []expr{q.q.skip, q.q.limit} {
+		if e == nil {
+			continue
+		}
+		if _, err := q.count(e); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkExpr checks the expression, which can use the names of columns if given, and aggregations
+// if allowed.
+func (q *Query) checkExpr(e expr, columns map[string]bool, aggregations bool) error {
+	switch e := e.(type) {
+	case *variable:
+		switch {
+		case q.needs[e.name] != nil:
+			q.needs[e.name].all = true
+		case q.rels[e.name] || columns[e.name]:
+		default:
+			return x.Errorf("Variable %s isn't defined", e.name)
+		}
+	case *param:
+		if _, ok := q.params[e.name]; !ok {
+			return x.Errorf("Parameter $%s isn't given", e.name)
+		}
+	case *property:
+		if v, ok := e.e.(*variable); ok && q.needs[v.name] != nil {
+			q.needs[v.name].props[e.key] = true
+			return nil
+		}
+		return q.checkExpr(e.e, columns, aggregations)
+	case *binary:
+		if err := q.checkExpr(e.l, columns, aggregations); err != nil {
+			return err
+		}
+		return q.checkExpr(e.r, columns, aggregations)
+	case *unary:
+		return q.checkExpr(e.e, columns, aggregations)
+	case *list:
+		for _, el := range e.elems {
+			if err := q.checkExpr(el, columns, aggregations); err != nil {
+				return err
+			}
+		}
+	case *call:
+		_, agg := aggregationFuncs[e.name]
+		arity, scalar := scalarFuncs[e.name]
+		switch {
+		case agg && !aggregations:
+			return x.Errorf("Aggregations can only be used in RETURN, and in ORDER BY with RETURN " +
+				"aggregating")
+		case agg && e.star && e.name != "count":
+			return x.Errorf("Only count takes *")
+		case agg && !e.star && len(e.args) != 1:
+			return x.Errorf("Aggregation %s takes one argument", e.name)
+		case agg:
+		case !scalar:
+			return x.Errorf("Unknown function %s", e.name)
+		case e.star || e.distinct:
+			return x.Errorf("Function %s doesn't take * or DISTINCT", e.name)
+		case arity >= 0 && len(e.args) != arity:
+			return x.Errorf("Function %s takes %d arguments", e.name, arity)
+		}
+		for _, arg := range e.args {
+			// Nodes passed to id and labels only need their uid and labels.
+			if v, ok := arg.(*variable); ok && q.needs[v.name] != nil &&
+				(e.name == "id" || e.name == "labels") {
+				q.needs[v.name].labels = q.needs[v.name].labels || e.name == "labels"
+				continue
+			}
+			// Aggregations can't be nested.
+			if err := q.checkExpr(arg, columns, aggregations && !agg); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// count returns the value of the SKIP or LIMIT expression e.
+func (q *Query) count(e expr) (int, error) {
+	v, err := q.eval(e, nil, nil)
+	if err != nil {
+		return 0, err
+	}
+	n, ok := v.(int64)
+	if !ok || n < 0 {
+		return 0, x.Errorf("SKIP and LIMIT must be given non-negative integers")
+	}
+	return int(n), nil
+}
+
+// root returns the index of the node of the pattern its block starts from. The nodes it can
+// start from are, by preference, the ones with a label, with properties, with a property equal
+// to a value in WHERE, or with a relationship from them.
+func (q *Query) root(pat *pattern) (int, error) {
+	for i, n := range pat.nodes {
+		if len(n.labels) > 0 {
+			return i, nil
+		}
+	}
+	for i, n := range pat.nodes {
+		if len(n.props) > 0 {
+			return i, nil
+		}
+	}
+	for i, n := range pat.nodes {
+		if _, _, ok := q.whereEq(n.v); ok {
+			return i, nil
+		}
+	}
+	for i := range pat.nodes {
+		if len(q.hasEdge(pat, i)) > 0 {
+			return i, nil
+		}
+	}
+	return 0, x.Errorf("Patterns must have a node with a label, with properties, or with a " +
+		"relationship from it")
+}
+
+// whereEq finds a condition of WHERE that a property of the nodes of v is equal to a value, which
+// all rows must meet.
+func (q *Query) whereEq(v string) (string, expr, bool) {
+	var find func(e expr) (string, expr, bool)
+	find = func(e expr) (string, expr, bool) {
+		b, ok := e.(*binary)
+		if !ok {
+			return "", nil, false
+		}
+		if b.op == "and" {
+			if key, val, ok := find(b.l); ok {
+				return key, val, true
+			}
+			return find(b.r)
+		}
+		if b.op != "=" {
+			return "", nil, false
+		}
+		for _, sides := range [][2]expr{{b.l, b.r}, {b.r, b.l}} {
+			p, ok := sides[0].(*property)
+			if !ok {
+				continue
+			}
+			if pv, ok := p.e.(*variable); !ok || pv.name != v {
+				continue
+			}
+			switch sides[1].(type) {
+			case *literal, *param:
+				return p.key, sides[1], true
+			}
+		}
+		return "", nil, false
+	}
+	if q.q.where == nil {
+		return "", nil, false
+	}
+	return find(q.q.where)
+}
+
+// hasEdge returns the type of a relationship from node i of the pattern, if any.
+func (q *Query) hasEdge(pat *pattern, i int) string {
+	if i < len(pat.rels) && !pat.rels[i].in {
+		return pat.rels[i].typ
+	}
+	if i > 0 && pat.rels[i-1].in {
+		return pat.rels[i-1].typ
+	}
+	return ""
+}
+
+// GraphQuery returns the query of Dgraph, with a block for every pattern.
+func (q *Query) GraphQuery() (*gql.Result, error) {
+	res := &gql.Result{}
+	for k, pat := range q.q.patterns {
+		root := q.roots[k]
+		fn, err := q.rootFunc(pat, root)
+		if err != nil {
+			return nil, err
+		}
+		block := &gql.GraphQuery{
+			Alias:    blockName(k),
+			Args:     make(map[string]string),
+			Func:     fn,
+			Children: q.children(pat, root, 0),
+		}
+		res.Query = append(res.Query, block)
+		res.QueryVars = append(res.QueryVars, &gql.Vars{})
+	}
+	return res, nil
+}
+
+func blockName(k int) string {
+	return "pattern" + strconv.Itoa(k)
+}
+
+func (q *Query) rootFunc(pat *pattern, root int) (*gql.Function, error) {
+	n := pat.nodes[root]
+	eq := func(attr string, e expr) (*gql.Function, error) {
+		v, err := q.eval(e, nil, nil)
+		if err != nil {
+			return nil, err
+		}
+		s, err := valueString(v)
+		if err != nil {
+			return nil, err
+		}
+		return &gql.Function{Name: "eq", Attr: attr, Args: []gql.Arg{{Value: s}}}, nil
+	}
+	if len(n.labels) > 0 {
+		return eq(LabelPredicate, &literal{v: n.labels[0]})
+	}
+	if len(n.props) > 0 {
+		return eq(n.props[0].key, n.props[0].val)
+	}
+	if key, val, ok := q.whereEq(n.v); ok {
+		return eq(key, val)
+	}
+	return &gql.Function{Name: "has", Attr: q.hasEdge(pat, root)}, nil
+}
+
+// valueString returns the value as an argument of a function of Dgraph.
+func valueString(v interface{}) (string, error) {
+	switch v := v.(type) {
+	case string:
+		return v, nil
+	case int64:
+		return strconv.FormatInt(v, 10), nil
+	case float64:
+		return strconv.FormatFloat(v, 'g', -1, 64), nil
+	case bool:
+		return strconv.FormatBool(v), nil
+	}
+	return "", x.Errorf("Nodes can't be found by the value %v", v)
+}
+
+// children returns the children of the block of node i of the pattern, which continue towards
+// dir, or both ways if 0.
+func (q *Query) children(pat *pattern, i, dir int) []*gql.GraphQuery {
+	child := func(attr string) *gql.GraphQuery {
+		return &gql.GraphQuery{Attr: attr, Args: make(map[string]string)}
+	}
+	nd := q.needs[pat.nodes[i].v]
+	res := []*gql.GraphQuery{child("uid")}
+	if nd.all {
+		expand := child("expand")
+		expand.Expand, expand.IsInternal = "_all_", true
+		res = append(res, expand)
+	} else {
+		if nd.labels {
+			res = append(res, child(LabelPredicate))
+		}
+		var props []string
+		for p := range nd.props {
+			props = append(props, p)
+		}
+		sort.Strings(props)
+		for _, p := range props {
+			res = append(res, child(p))
+		}
+	}
+
+	if dir <= 0 && i > 0 {
+		// Going left, the edges are those of the relationships in reverse.
+		r := pat.rels[i-1]
+		edge := child(reverse(r.typ, !r.in))
+		edge.Alias, edge.Children = leftEdge, q.children(pat, i-1, -1)
+		res = append(res, edge)
+	}
+	if dir >= 0 && i < len(pat.rels) {
+		r := pat.rels[i]
+		edge := child(reverse(r.typ, r.in))
+		edge.Alias, edge.Children = rightEdge, q.children(pat, i+1, 1)
+		res = append(res, edge)
+	}
+	return res
+}
+
+// reverse returns the reverse edge of typ if rev is set.
+func reverse(typ string, rev bool) string {
+	if rev {
+		return "~" + typ
+	}
+	return typ
+}
+
+// normalize turns the numbers of JSON into int64 or float64.
+func normalize(v interface{}) interface{} {
+	switch v := v.(type) {
+	case json.Number:
+		if i, err := v.Int64(); err == nil {
+			return i
+		}
+		f, _ := v.Float64()
+		return f
+	case int:
+		return int64(v)
+	case []interface{}:
+		res := make([]interface{}, len(v))
+		for i, el := range v {
+			res[i] = normalize(el)
+		}
+		return res
+	case map[string]interface{}:
+		res := make(map[string]interface{}, len(v))
+		for k, el := range v {
+			res[k] = normalize(el)
+		}
+		return res
+	}
+	return v
+}
@@ -0,0 +1,117 @@
+/*
+ * Copyright 2018 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cypher
+
+import (
+	"testing"
+
+	"github.com/dgraph-io/dgraph/gql"
+	"github.com/stretchr/testify/require"
+)
+
+func child(attr string, children ...*gql.GraphQuery) *gql.GraphQuery {
+	return &gql.GraphQuery{Attr: attr, Args: make(map[string]string), Children: children}
+}
+
+func edge(attr, alias string, children ...*gql.GraphQuery) *gql.GraphQuery {
+	gq := child(attr, children...)
+	gq.Alias = alias
+	return gq
+}
+
+func translate(t *testing.T, src string, params map[string]interface{}) *gql.Result {
+	q, err := Parse(src, params)
+	require.NoError(t, err)
+	res, err := q.GraphQuery()
+	require.NoError(t, err)
+	return res
+}
+
+func TestGraphQueryByLabel(t *testing.T) {
+	res := translate(t, `MATCH (a)-[:KNOWS]->(b:Person)<-[:OWNS]-(c) RETURN a.name, id(c)`, nil)
+	require.Len(t, res.Query, 1)
+	require.Len(t, res.QueryVars, 1)
+
+	block := res.Query[0]
+	require.Equal(t, "pattern0", block.Alias)
+	require.Equal(t, &gql.Function{Name: "eq", Attr: LabelPredicate,
+		Args: []gql.Arg{{Value: "Person"}}}, block.Func)
+	require.Equal(t, []*gql.GraphQuery{
+		child("uid"),
+		child(LabelPredicate),
+		edge("~KNOWS", leftEdge, child("uid"), child("name")),
+		edge("~OWNS", rightEdge, child("uid")),
+	}, block.Children)
+}
+
+func TestGraphQueryByProperty(t *testing.T) {
+	res := translate(t, `MATCH (a)-[:KNOWS]->(b {name: $name}) RETURN a`,
+		map[string]interface{}{"name": "Bob"})
+	block := res.Query[0]
+	require.Equal(t, &gql.Function{Name: "eq", Attr: "name", Args: []gql.Arg{{Value: "Bob"}}},
+		block.Func)
+
+	all := child("expand")
+	all.Expand, all.IsInternal = "_all_", true
+	require.Equal(t, []*gql.GraphQuery{
+		child("uid"),
+		child("name"),
+		edge("~KNOWS", leftEdge, child("uid"), all),
+	}, block.Children)
+}
+
+func TestGraphQueryByWhere(t *testing.T) {
+	res := translate(t, `MATCH (a)-[:KNOWS]->(b) WHERE a.age > 3 AND b.age = 30 RETURN b.name`,
+		nil)
+	require.Equal(t, &gql.Function{Name: "eq", Attr: "age", Args: []gql.Arg{{Value: "30"}}},
+		res.Query[0].Func)
+	require.Equal(t, "~KNOWS", res.Query[0].Children[3].Attr)
+}
+
+func TestGraphQueryByEdge(t *testing.T) {
+	res := translate(t, `MATCH (a)<-[:KNOWS]-(b), (c)-[:OWNS]->(d) RETURN count(*)`, nil)
+	require.Len(t, res.Query, 2)
+	require.Equal(t, &gql.Function{Name: "has", Attr: "KNOWS"}, res.Query[0].Func)
+	require.Equal(t, []*gql.GraphQuery{
+		child("uid"),
+		edge("KNOWS", leftEdge, child("uid")),
+	}, res.Query[0].Children)
+	require.Equal(t, "pattern1", res.Query[1].Alias)
+	require.Equal(t, &gql.Function{Name: "has", Attr: "OWNS"}, res.Query[1].Func)
+}
+
+func TestParseChecks(t *testing.T) {
+	for src, msg := range map[string]string{
+		`MATCH (a) RETURN a`:                         "Patterns must have a node",
+		`MATCH (a:A) RETURN b`:                       "Variable b isn't defined",
+		`MATCH (a:A) RETURN a.x AS y, a.z AS y`:      "Column y",
+		`MATCH (a:A)-[a:R]->(b) RETURN a`:            "both nodes and relationships",
+		`MATCH (a:A)-[r:R]->(b)-[r:R]->(c) RETURN a`: "more than once",
+		`MATCH (a:A) WHERE count(a) > 1 RETURN a`:    "Aggregations can only be used",
+		`MATCH (a:A) RETURN count(count(a))`:         "Aggregations can only be used",
+		`MATCH (a:A) RETURN foo(a)`:                  "Unknown function foo",
+		`MATCH (a:A) RETURN toLower(a.x, a.y)`:       "takes 1 arguments",
+		`MATCH (a:A) RETURN a LIMIT -1`:              "LIMIT",
+		`MATCH (a:A {x: $x}) RETURN a`:               "Parameter $x isn't given",
+		`MATCH (a:A) RETURN sum(*)`:                  "Only count takes *",
+		`MATCH (a:A) RETURN a.x ORDER BY count(*)`:   "ORDER BY with RETURN aggregating",
+	} {
+		_, err := Parse(src, nil)
+		require.Error(t, err, src)
+		require.Contains(t, err.Error(), msg, src)
+	}
+}
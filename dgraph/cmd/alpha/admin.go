@@ -29,6 +29,7 @@ import (
 	"github.com/dgraph-io/dgraph/posting"
 	"github.com/dgraph-io/dgraph/worker"
 	"github.com/dgraph-io/dgraph/x"
+	"github.com/golang/glog"
 )
 
 // handlerInit does some standard checks. Returns false if something is wrong.
@@ -60,8 +61,13 @@ func exportHandler(w http.ResponseWriter, r *http.Request) {
 	if !handlerInit(w, r, http.MethodGet) {
 		return
 	}
+	format := r.URL.Query().Get("format")
+	if err := worker.ValidateExportFormat(format); err != nil {
+		x.SetStatus(w, x.ErrorInvalidRequest, err.Error())
+		return
+	}
 	// Export logic can be moved to dgraphzero.
-	if err := worker.ExportOverNetwork(context.Background()); err != nil {
+	if err := worker.ExportOverNetwork(context.Background(), format); err != nil {
 		x.SetStatus(w, err.Error(), "Export failed.")
 		return
 	}
@@ -146,3 +152,79 @@ func ipInIPWhitelistRanges(ipString string) bool {
 	}
 	return false
 }
+
+// rollupsHandler reports the status of the rollups of this Alpha (GET), and triggers, pauses or
+// resumes them via the action query parameter: run, pause or resume (POST).
+func rollupsHandler(w http.ResponseWriter, r *http.Request) {
+	if !handlerInit(w, r, r.Method) {
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+
+	switch r.Method {
+	case http.MethodGet:
+		x.Reply(w, worker.Rollups())
+
+	case http.MethodPost:
+		action := r.URL.Query().Get("action")
+		glog.Infof("Got request to %s rollups from %s\n", action, r.RemoteAddr)
+		switch action {
+		case "run":
+			worker.TriggerRollup()
+			x.Check2(w.Write([]byte(`{"code": "Success", "message": "Rollup triggered."}`)))
+		case "pause":
+			worker.PauseRollups(true)
+			x.Check2(w.Write([]byte(`{"code": "Success", "message": "Rollups paused."}`)))
+		case "resume":
+			worker.PauseRollups(false)
+			x.Check2(w.Write([]byte(`{"code": "Success", "message": "Rollups resumed."}`)))
+		default:
+			x.SetStatus(w, x.ErrorInvalidRequest,
+				fmt.Sprintf("Invalid action %q, must be run, pause or resume", action))
+		}
+
+	default:
+		w.WriteHeader(http.StatusBadRequest)
+		x.SetStatus(w, x.ErrorInvalidMethod, "Invalid method")
+	}
+}
+
+// indexingHandler reports the progress of the index builds on this Alpha (GET), limits their rate
+// in keys per second via the rate query parameter (PUT), and cancels the build of the predicate
+// passed via the attr query parameter (DELETE).
+func indexingHandler(w http.ResponseWriter, r *http.Request) {
+	if !handlerInit(w, r, r.Method) {
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+
+	switch r.Method {
+	case http.MethodGet:
+		x.Reply(w, worker.IndexBuilds())
+
+	case http.MethodPost, http.MethodPut:
+		rate, err := strconv.ParseInt(r.URL.Query().Get("rate"), 10, 64)
+		if err != nil {
+			x.SetStatus(w, x.ErrorInvalidRequest, err.Error())
+			return
+		}
+		if err := worker.SetIndexBuildRate(rate); err != nil {
+			x.SetStatus(w, x.ErrorInvalidRequest, err.Error())
+			return
+		}
+		x.Check2(w.Write([]byte(`{"code": "Success", "message": "Rate of index builds set."}`)))
+
+	case http.MethodDelete:
+		attr := r.URL.Query().Get("attr")
+		glog.Infof("Got request to cancel index build of %q from %s\n", attr, r.RemoteAddr)
+		if err := worker.CancelIndexBuild(context.Background(), attr); err != nil {
+			x.SetStatus(w, x.Error, err.Error())
+			return
+		}
+		x.Check2(w.Write([]byte(`{"code": "Success", "message": "Index build cancelled."}`)))
+
+	default:
+		w.WriteHeader(http.StatusBadRequest)
+		x.SetStatus(w, x.ErrorInvalidMethod, "Invalid method")
+	}
+}
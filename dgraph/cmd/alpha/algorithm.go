@@ -0,0 +1,89 @@
+/*
+ * Copyright 2018 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package alpha
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+
+	"github.com/dgraph-io/dgraph/edgraph"
+	"github.com/dgraph-io/dgraph/x"
+	"github.com/golang/glog"
+	"google.golang.org/grpc/metadata"
+)
+
+// algorithmsHandler lists (GET), starts (POST) and cancels (DELETE) the graph algorithm jobs run
+// by this Alpha. The id of the job to cancel is passed via the id query parameter.
+func algorithmsHandler(w http.ResponseWriter, r *http.Request) {
+	if !handlerInit(w, r, r.Method) {
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+
+	switch r.Method {
+	case http.MethodGet:
+		x.Reply(w, edgraph.AlgorithmJobs())
+
+	case http.MethodPost, http.MethodPut:
+		defer r.Body.Close()
+		b, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			x.SetStatus(w, x.ErrorInvalidRequest, err.Error())
+			return
+		}
+		var job edgraph.AlgorithmJob
+		if err := json.Unmarshal(b, &job); err != nil {
+			x.SetStatus(w, x.ErrorInvalidRequest, err.Error())
+			return
+		}
+		// Jobs write to their output predicate, so they're protected in the same way as Alter.
+		md := namespaceMD(r)
+		md.Append("auth-token", r.Header.Get("X-Dgraph-AuthToken"))
+		ctx := metadata.NewIncomingContext(context.Background(), md)
+
+		glog.Infof("Got request to run %q over %q from %s\n", job.Algorithm, job.Predicate,
+			r.RemoteAddr)
+		id, err := (&edgraph.Server{}).StartAlgorithm(ctx, &job)
+		if err != nil {
+			x.SetStatus(w, x.Error, err.Error())
+			return
+		}
+		x.Check2(w.Write([]byte(fmt.Sprintf(
+			`{"code": "Success", "message": "Algorithm job started.", "id": %d}`, id))))
+
+	case http.MethodDelete:
+		id, err := strconv.ParseUint(r.URL.Query().Get("id"), 0, 64)
+		if err != nil {
+			x.SetStatus(w, x.ErrorInvalidRequest, err.Error())
+			return
+		}
+		glog.Infof("Got request to cancel algorithm job %d from %s\n", id, r.RemoteAddr)
+		if err := edgraph.CancelAlgorithm(id); err != nil {
+			x.SetStatus(w, x.Error, err.Error())
+			return
+		}
+		x.Check2(w.Write([]byte(`{"code": "Success", "message": "Algorithm job cancelled."}`)))
+
+	default:
+		w.WriteHeader(http.StatusBadRequest)
+		x.SetStatus(w, x.ErrorInvalidMethod, "Invalid method")
+	}
+}
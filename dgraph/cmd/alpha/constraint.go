@@ -0,0 +1,92 @@
+/*
+ * Copyright 2018 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package alpha
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/dgraph-io/dgraph/edgraph"
+	"github.com/dgraph-io/dgraph/x"
+	"github.com/golang/glog"
+	"google.golang.org/grpc/metadata"
+)
+
+// constraintsHandler lists (GET), registers (POST) and removes (DELETE) constraints. Passing
+// check=true to GET checks all constraints before listing them. The name of the constraint to
+// remove is passed via the name query parameter.
+func constraintsHandler(w http.ResponseWriter, r *http.Request) {
+	if !handlerInit(w, r, r.Method) {
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+
+	md := namespaceMD(r)
+	md.Append("auth-token", r.Header.Get("X-Dgraph-AuthToken"))
+	ctx := metadata.NewIncomingContext(context.Background(), md)
+
+	s := &edgraph.Server{}
+	switch r.Method {
+	case http.MethodGet:
+		if r.URL.Query().Get("check") == "true" {
+			if err := s.CheckConstraints(ctx); err != nil {
+				x.SetStatus(w, x.Error, err.Error())
+				return
+			}
+		}
+		list, err := s.ListConstraints(ctx)
+		if err != nil {
+			x.SetStatus(w, x.Error, err.Error())
+			return
+		}
+		x.Reply(w, list)
+
+	case http.MethodPost, http.MethodPut:
+		defer r.Body.Close()
+		b, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			x.SetStatus(w, x.ErrorInvalidRequest, err.Error())
+			return
+		}
+		var c edgraph.Constraint
+		if err := json.Unmarshal(b, &c); err != nil {
+			x.SetStatus(w, x.ErrorInvalidRequest, err.Error())
+			return
+		}
+		glog.Infof("Got request to register constraint %q from %s\n", c.Name, r.RemoteAddr)
+		if err := s.RegisterConstraint(ctx, &c); err != nil {
+			x.SetStatus(w, x.Error, err.Error())
+			return
+		}
+		x.Check2(w.Write([]byte(`{"code": "Success", "message": "Constraint registered."}`)))
+
+	case http.MethodDelete:
+		name := r.URL.Query().Get("name")
+		glog.Infof("Got request to remove constraint %q from %s\n", name, r.RemoteAddr)
+		if err := s.RemoveConstraint(ctx, name); err != nil {
+			x.SetStatus(w, x.Error, err.Error())
+			return
+		}
+		x.Check2(w.Write([]byte(`{"code": "Success", "message": "Constraint removed."}`)))
+
+	default:
+		w.WriteHeader(http.StatusBadRequest)
+		x.SetStatus(w, x.ErrorInvalidMethod, "Invalid method")
+	}
+}
@@ -0,0 +1,80 @@
+/*
+ * Copyright 2018 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package alpha
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"mime"
+	"net/http"
+
+	"github.com/dgraph-io/dgraph/edgraph"
+	"github.com/dgraph-io/dgraph/x"
+)
+
+// cypherHandler runs openCypher queries, sent with POST as JSON with the query and its parameters,
+// or as the query itself.
+func cypherHandler(w http.ResponseWriter, r *http.Request) {
+	x.AddCorsHeaders(w)
+	w.Header().Set("Content-Type", "application/json")
+
+	if r.Method == "OPTIONS" {
+		return
+	}
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusBadRequest)
+		x.SetStatus(w, x.ErrorInvalidMethod, "Invalid method")
+		return
+	}
+
+	defer r.Body.Close()
+	b, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		x.SetStatus(w, x.ErrorInvalidRequest, err.Error())
+		return
+	}
+	var req struct {
+		Query      string                 `json:"query"`
+		Parameters map[string]interface{} `json:"parameters"`
+	}
+	typ, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if typ == "application/json" {
+		// Numbers are kept as given, so that integers stay integers.
+		dec := json.NewDecoder(bytes.NewReader(b))
+		dec.UseNumber()
+		if err := dec.Decode(&req); err != nil {
+			x.SetStatus(w, x.ErrorInvalidRequest, err.Error())
+			return
+		}
+	} else {
+		req.Query = string(b)
+	}
+
+	res, err := (&edgraph.Server{}).Cypher(requestContext(r, namespaceMD(r)), req.Query,
+		req.Parameters)
+	if err != nil {
+		x.SetStatus(w, x.ErrorInvalidRequest, err.Error())
+		return
+	}
+	js, err := json.Marshal(map[string]interface{}{"data": res})
+	if err != nil {
+		x.SetStatus(w, x.Error, err.Error())
+		return
+	}
+	x.Check2(w.Write(js))
+}
@@ -0,0 +1,84 @@
+/*
+ * Copyright 2018 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package alpha
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/dgraph-io/dgraph/edgraph"
+	"github.com/dgraph-io/dgraph/x"
+)
+
+// deleteHandler starts deletes by query with POST, returns their progress with GET, of all of them
+// or of the one with ?id=, and cancels the one with ?id= with DELETE.
+func deleteHandler(w http.ResponseWriter, r *http.Request) {
+	x.AddCorsHeaders(w)
+	w.Header().Set("Content-Type", "application/json")
+
+	if r.Method == "OPTIONS" {
+		return
+	}
+
+	var id uint64
+	if s := r.URL.Query().Get("id"); len(s) > 0 {
+		var err error
+		if id, err = strconv.ParseUint(s, 10, 64); err != nil {
+			x.SetStatus(w, x.ErrorInvalidRequest, err.Error())
+			return
+		}
+	}
+	ctx := requestContext(r, namespaceMD(r))
+
+	switch r.Method {
+	case http.MethodGet:
+		res, err := (&edgraph.Server{}).DeleteStatuses(ctx, id)
+		if err != nil {
+			x.SetStatus(w, x.ErrorInvalidRequest, err.Error())
+			return
+		}
+		x.Reply(w, map[string]interface{}{"data": res})
+
+	case http.MethodPost:
+		defer r.Body.Close()
+		var req edgraph.DeleteQuery
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			x.SetStatus(w, x.ErrorInvalidRequest, err.Error())
+			return
+		}
+		st, err := (&edgraph.Server{}).DeleteByQuery(ctx, &req)
+		if err != nil {
+			x.SetStatusWithData(w, x.ErrorInvalidRequest, err.Error())
+			return
+		}
+		x.Reply(w, map[string]interface{}{"data": st})
+
+	case http.MethodDelete:
+		if err := (&edgraph.Server{}).CancelDelete(ctx, id); err != nil {
+			x.SetStatus(w, x.ErrorInvalidRequest, err.Error())
+			return
+		}
+		x.Check2(w.Write([]byte(fmt.Sprintf(
+			`{"code": "Success", "message": "Delete %d cancelled."}`, id))))
+
+	default:
+		w.WriteHeader(http.StatusBadRequest)
+		x.SetStatus(w, x.ErrorInvalidMethod, "Invalid method")
+	}
+}
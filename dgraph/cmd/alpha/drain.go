@@ -0,0 +1,107 @@
+/*
+ * Copyright 2018 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package alpha
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/dgraph-io/dgraph/edgraph"
+	"github.com/dgraph-io/dgraph/worker"
+	"github.com/dgraph-io/dgraph/x"
+)
+
+const (
+	// defaultDrainTimeout is how long a POST to /admin/drain waits for the Alpha to be drained.
+	defaultDrainTimeout = time.Minute
+	drainPollInterval   = 100 * time.Millisecond
+)
+
+// drainListener closes the connections it accepts while the Alpha is draining, so that new
+// clients go to the other Alphas while those connected already finish their transactions.
+type drainListener struct {
+	net.Listener
+}
+
+func (l drainListener) Accept() (net.Conn, error) {
+	for {
+		c, err := l.Listener.Accept()
+		if err != nil || !worker.IsDraining() {
+			return c, err
+		}
+		c.Close()
+	}
+}
+
+// closeWhileDraining closes the HTTP connections of the clients after their requests while the
+// Alpha is draining, instead of keeping them alive. New ones are still taken, for /health, the
+// admin endpoints and the commits of the open transactions.
+func closeWhileDraining(h http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if worker.IsDraining() {
+			w.Header().Set("Connection", "close")
+		}
+		h.ServeHTTP(w, r)
+	})
+}
+
+// drainHandler returns the state of the drain of this Alpha with GET, puts it in drain mode
+// with POST, waiting for it to be drained for ?timeout= at most, and takes it out of it with
+// DELETE.
+func drainHandler(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+	case http.MethodPost:
+		timeout := defaultDrainTimeout
+		if s := r.URL.Query().Get("timeout"); len(s) > 0 {
+			var err error
+			if timeout, err = time.ParseDuration(s); err != nil {
+				x.SetStatus(w, x.ErrorInvalidRequest, err.Error())
+				return
+			}
+		}
+		if err := worker.StartDrain(); err != nil {
+			x.SetStatus(w, x.Error, err.Error())
+			return
+		}
+		waitDrained(r.Context(), timeout)
+	case http.MethodDelete:
+		worker.StopDrain()
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		x.SetStatus(w, x.ErrorInvalidMethod, "Invalid method")
+		return
+	}
+	x.Reply(w, map[string]interface{}{"data": worker.GetDrainState(edgraph.OpenTxns())})
+}
+
+// waitDrained returns once this Alpha is drained, or after timeout.
+func waitDrained(ctx context.Context, timeout time.Duration) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	ticker := time.NewTicker(drainPollInterval)
+	defer ticker.Stop()
+	for !worker.GetDrainState(edgraph.OpenTxns()).Drained {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
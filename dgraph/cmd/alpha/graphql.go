@@ -0,0 +1,133 @@
+/*
+ * Copyright 2018 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package alpha
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"mime"
+	"net/http"
+
+	"github.com/dgraph-io/dgraph/edgraph"
+	"github.com/dgraph-io/dgraph/graphql"
+	"github.com/dgraph-io/dgraph/x"
+	"github.com/golang/glog"
+	"google.golang.org/grpc/metadata"
+)
+
+// graphQLHandler serves the GraphQL API, the way GraphQL clients like GraphiQL and Apollo expect:
+// queries can be sent with GET, passing the query, variables and operationName parameters, and
+// queries and mutations with POST, as JSON or as the query itself with type application/graphql.
+func graphQLHandler(w http.ResponseWriter, r *http.Request) {
+	x.AddCorsHeaders(w)
+	w.Header().Set("Content-Type", "application/json")
+
+	if r.Method == "OPTIONS" {
+		return
+	}
+
+	var req graphql.Request
+	switch r.Method {
+	case http.MethodGet:
+		params := r.URL.Query()
+		req.Query = params.Get("query")
+		req.OperationName = params.Get("operationName")
+		if vars := params.Get("variables"); len(vars) > 0 {
+			if err := json.Unmarshal([]byte(vars), &req.Variables); err != nil {
+				x.SetStatus(w, x.ErrorInvalidRequest, "Error while unmarshalling variables")
+				return
+			}
+		}
+
+	case http.MethodPost:
+		defer r.Body.Close()
+		b, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			x.SetStatus(w, x.ErrorInvalidRequest, err.Error())
+			return
+		}
+		typ, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+		if typ == "application/graphql" {
+			req.Query = string(b)
+		} else if err := json.Unmarshal(b, &req); err != nil {
+			x.SetStatus(w, x.ErrorInvalidRequest, err.Error())
+			return
+		}
+
+	default:
+		w.WriteHeader(http.StatusBadRequest)
+		x.SetStatus(w, x.ErrorInvalidMethod, "Invalid method")
+		return
+	}
+
+	resp := (&edgraph.Server{}).GraphQL(requestContext(r, namespaceMD(r)), &req)
+	js, err := json.Marshal(resp)
+	if err != nil {
+		x.SetStatus(w, x.Error, err.Error())
+		return
+	}
+	w.Write(js)
+}
+
+// graphQLSchemaHandler returns (GET) and sets (POST) the GraphQL schema, in the SDL. Setting an
+// empty schema goes back to generating the GraphQL schema from the Dgraph schema.
+func graphQLSchemaHandler(w http.ResponseWriter, r *http.Request) {
+	x.AddCorsHeaders(w)
+
+	if r.Method == "OPTIONS" {
+		return
+	}
+
+	// The GraphQL schema alters the Dgraph schema, so it's protected in the same way as Alter.
+	md := namespaceMD(r)
+	md.Append("auth-token", r.Header.Get("X-Dgraph-AuthToken"))
+	ctx := metadata.NewIncomingContext(context.Background(), md)
+
+	s := &edgraph.Server{}
+	switch r.Method {
+	case http.MethodPost, http.MethodPut:
+		w.Header().Set("Content-Type", "application/json")
+		defer r.Body.Close()
+		b, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			x.SetStatus(w, x.ErrorInvalidRequest, err.Error())
+			return
+		}
+		glog.Infof("Got request to set the GraphQL schema from %s\n", r.RemoteAddr)
+		if err := s.SetGraphQLSchema(ctx, string(b)); err != nil {
+			x.SetStatus(w, x.Error, err.Error())
+			return
+		}
+		x.Check2(w.Write([]byte(`{"code": "Success", "message": "GraphQL schema set."}`)))
+
+	case http.MethodGet:
+		gs, err := s.GetGraphQLSchema(ctx)
+		if err != nil {
+			w.Header().Set("Content-Type", "application/json")
+			x.SetStatus(w, x.Error, err.Error())
+			return
+		}
+		w.Header().Set("Content-Type", "application/graphql")
+		x.Check2(w.Write([]byte(gs.SDL())))
+
+	default:
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		x.SetStatus(w, x.ErrorInvalidMethod, "Invalid method")
+	}
+}
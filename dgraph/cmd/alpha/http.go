@@ -21,6 +21,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
+	"net"
 	"net/http"
 	"sort"
 	"strconv"
@@ -34,13 +35,40 @@ import (
 	"github.com/dgraph-io/dgraph/worker"
 	"github.com/dgraph-io/dgraph/x"
 	"github.com/golang/glog"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
 )
 
 func allowed(method string) bool {
 	return method == http.MethodPost || method == http.MethodPut
 }
 
+// namespaceMD passes the namespace of the request and its token, set in the X-Dgraph-Namespace and
+// X-Dgraph-NamespaceToken headers, the same way as gRPC clients do.
+func namespaceMD(r *http.Request) metadata.MD {
+	md := metadata.New(nil)
+	if ns := r.Header.Get("X-Dgraph-Namespace"); len(ns) > 0 {
+		md.Append("namespace", ns)
+		md.Append("namespace-token", r.Header.Get("X-Dgraph-NamespaceToken"))
+	}
+	return md
+}
+
+// requestContext is the context of the calls to edgraph.Server made for r, with the metadata md.
+// The client of r is its peer, so that it's audited the same way as the clients of gRPC requests.
+func requestContext(r *http.Request, md metadata.MD) context.Context {
+	if fwd := r.Header.Get("X-Forwarded-For"); len(fwd) > 0 {
+		md.Append("forwarded-for", fwd)
+	}
+	ctx := metadata.NewIncomingContext(r.Context(), md)
+	if addr, err := net.ResolveTCPAddr("tcp", r.RemoteAddr); err == nil {
+		ctx = peer.NewContext(ctx, &peer.Peer{Addr: addr})
+	}
+	return ctx
+}
+
 func extractStartTs(urlPath string) (uint64, error) {
 	params := strings.Split(strings.TrimPrefix(urlPath, "/"), "/")
 
@@ -102,9 +130,35 @@ func queryHandler(w http.ResponseWriter, r *http.Request) {
 
 	d := r.URL.Query().Get("debug")
 	ctx := context.WithValue(context.Background(), "debug", d)
+	md := namespaceMD(r)
+	bestEffort := r.URL.Query().Get("be") == "true"
+	if bestEffort {
+		md.Set("best-effort", "true")
+	}
+	var asOf uint64
+	if ts := r.URL.Query().Get("asOfTs"); len(ts) > 0 {
+		md.Set("as-of-ts", ts)
+		// Validated by the query.
+		asOf, _ = strconv.ParseUint(ts, 10, 64)
+	}
+	ctx = metadata.NewIncomingContext(ctx, md)
 
+	e := query.Extensions{
+		BestEffort: bestEffort,
+		AsOfTs:     asOf,
+	}
 	// Core processing happens here.
-	resp, err := (&edgraph.Server{}).Query(ctx, &req)
+	var resp *api.Response
+	if r.URL.Query().Get("stream") == "true" {
+		s := &queryStream{w: w}
+		resp, err = (&edgraph.Server{}).StreamQuery(ctx, &req, s.write)
+		if s.started {
+			s.end(resp, err, e)
+			return
+		}
+	} else {
+		resp, err = (&edgraph.Server{}).Query(ctx, &req)
+	}
 	if err != nil {
 		x.SetStatusWithData(w, x.ErrorInvalidRequest, err.Error())
 		return
@@ -112,10 +166,8 @@ func queryHandler(w http.ResponseWriter, r *http.Request) {
 
 	response := map[string]interface{}{}
 
-	e := query.Extensions{
-		Txn:     resp.Txn,
-		Latency: resp.Latency,
-	}
+	e.Txn = resp.Txn
+	e.Latency = resp.Latency
 	response["extensions"] = e
 
 	// User can either ask for schema or have a query.
@@ -211,7 +263,23 @@ func mutationHandler(w http.ResponseWriter, r *http.Request) {
 	}
 	mu.StartTs = ts
 
-	resp, err := (&edgraph.Server{}).Mutate(context.Background(), mu)
+	ctx := requestContext(r, namespaceMD(r))
+	resp, err := (&edgraph.Server{}).Mutate(ctx, mu)
+	if merr, ok := err.(*worker.TabletMovingError); ok {
+		if d := merr.RetryAfter(); d > 0 {
+			w.Header().Set("Retry-After", strconv.Itoa(int(d.Seconds())))
+		}
+		w.WriteHeader(http.StatusServiceUnavailable)
+		x.SetStatusWithData(w, x.ErrorServiceUnavailable, err.Error())
+		return
+	}
+	if status.Code(err) == codes.ResourceExhausted {
+		// Too many mutations are pending.
+		w.Header().Set("Retry-After", "1")
+		w.WriteHeader(http.StatusTooManyRequests)
+		x.SetStatusWithData(w, x.ErrorTooManyRequests, err.Error())
+		return
+	}
 	if err != nil {
 		x.SetStatusWithData(w, x.ErrorInvalidRequest, err.Error())
 		return
@@ -292,12 +360,14 @@ func commitHandler(w http.ResponseWriter, r *http.Request) {
 
 	tc.Keys = encodedKeys
 
-	cts, err := worker.CommitOverNetwork(context.Background(), tc)
+	// Commit via the server, so that the transaction is checked against the constraints.
+	ctx := requestContext(r, namespaceMD(r))
+	tctx, err := (&edgraph.Server{}).CommitOrAbort(ctx, tc)
 	if err != nil {
 		x.SetStatus(w, x.Error, err.Error())
 		return
 	}
-	resp.Context.CommitTs = cts
+	resp.Context.CommitTs = tctx.CommitTs
 
 	e := query.Extensions{
 		Txn: resp.Context,
@@ -351,6 +421,7 @@ func abortHandler(w http.ResponseWriter, r *http.Request) {
 	tc.Aborted = true
 
 	_, aerr := worker.CommitOverNetwork(context.Background(), tc)
+	edgraph.TxnDone(ts)
 	if aerr != nil {
 		x.SetStatus(w, x.Error, aerr.Error())
 		return
@@ -402,10 +473,13 @@ func alterHandler(w http.ResponseWriter, r *http.Request) {
 		glog.Infof("The alter request is forwarded by %s\n", fwd)
 	}
 
-	md := metadata.New(nil)
+	md := namespaceMD(r)
 	// Pass in an auth token, if present.
 	md.Append("auth-token", r.Header.Get("X-Dgraph-AuthToken"))
-	ctx := metadata.NewIncomingContext(context.Background(), md)
+	if r.URL.Query().Get("background") == "true" {
+		md.Set("background-index", "true")
+	}
+	ctx := requestContext(r, md)
 	if _, err = (&edgraph.Server{}).Alter(ctx, op); err != nil {
 		x.SetStatus(w, x.Error, err.Error())
 		return
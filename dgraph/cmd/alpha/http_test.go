@@ -26,6 +26,7 @@ import (
 	"net/http/httptest"
 	"strconv"
 	"testing"
+	"time"
 
 	"github.com/dgraph-io/dgraph/query"
 	"github.com/dgraph-io/dgraph/x"
@@ -185,6 +186,150 @@ func TestTransactionBasic(t *testing.T) {
 	require.Equal(t, `{"data":{"balances":[{"name":"Bob","balance":"110"}]}}`, data)
 }
 
+func TestStreamQuery(t *testing.T) {
+	require.NoError(t, dropAll())
+	require.NoError(t, alterSchema(`name: string @index(exact) .`))
+	var m bytes.Buffer
+	m.WriteString("{ set {\n")
+	for i := 0; i < 2500; i++ {
+		fmt.Fprintf(&m, "_:n%d <name> \"n%d\" .\n", i, i)
+	}
+	m.WriteString("} }")
+	_, _, err := mutationWithTs(m.String(), false, true, true, 0)
+	require.NoError(t, err)
+
+	q := `{ q(func: has(name), orderasc: name) { name } r(func: eq(name, "n7")) { name } }`
+	want, ts, err := queryWithTs(q, 0)
+	require.NoError(t, err)
+
+	req, err := http.NewRequest("POST", addr+"/query/"+strconv.FormatUint(ts, 10)+"?stream=true",
+		bytes.NewBufferString(q))
+	require.NoError(t, err)
+	_, body, err := runRequest(req)
+	require.NoError(t, err)
+	var r res
+	require.NoError(t, json.Unmarshal(body, &r))
+	require.Equal(t, ts, r.Extensions.Txn.StartTs)
+	got, err := json.Marshal(res{Data: r.Data})
+	require.NoError(t, err)
+	require.Equal(t, want, string(got))
+}
+
+func TestUpsertTemplate(t *testing.T) {
+	require.NoError(t, dropAll())
+	require.NoError(t, alterSchema(`email: string @index(exact) @upsert .`))
+
+	tmpl, err := json.Marshal(map[string]string{
+		"name":  "user",
+		"query": `query q($email: string) { u as var(func: eq(email, $email)) }`,
+		"set": `uid(u) <email> "$email" .
+			uid(u) <name> "$name" .`,
+	})
+	require.NoError(t, err)
+	req, err := http.NewRequest("POST", addr+"/template", bytes.NewReader(tmpl))
+	require.NoError(t, err)
+	_, _, err = runRequest(req)
+	require.NoError(t, err)
+
+	// The second run finds the node the first one created. $name is only used by the mutation.
+	for _, name := range []string{"Alice", "Alicia"} {
+		req, err := http.NewRequest("POST", addr+"/upsert/user",
+			bytes.NewBufferString(`{"$email": "alice@example.com", "$name": "`+name+`"}`))
+		require.NoError(t, err)
+		_, _, err = runRequest(req)
+		require.NoError(t, err)
+	}
+	output, err := runQuery(`{ q(func: eq(email, "alice@example.com")) { name } }`)
+	require.NoError(t, err)
+	require.JSONEq(t, `{"data": {"q": [{"name": "Alicia"}]}}`, output)
+}
+
+func TestConstraintOnCommit(t *testing.T) {
+	require.NoError(t, dropAll())
+	require.NoError(t, alterSchema(`order.id: string @index(exact) .`))
+
+	c := `{"name": "orderHasCustomer", "mode": "commit",
+		"query": "{ orphans(func: has(order.id)) @filter(not has(order.customer)) { uid } }"}`
+	req, err := http.NewRequest("POST", addr+"/admin/constraints", bytes.NewBufferString(c))
+	require.NoError(t, err)
+	_, _, err = runRequest(req)
+	require.NoError(t, err)
+	defer func() {
+		req, err := http.NewRequest("DELETE", addr+"/admin/constraints?name=orderHasCustomer",
+			nil)
+		require.NoError(t, err)
+		_, _, err = runRequest(req)
+		require.NoError(t, err)
+	}()
+
+	err = runMutation(`{ set { _:o <order.id> "1" . } }`)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), `violates constraint "orderHasCustomer"`)
+	require.NoError(t, runMutation(`{ set {
+		_:o <order.id> "2" .
+		_:o <order.customer> _:c .
+	} }`))
+
+	output, err := runQuery(`{ q(func: has(order.id)) { order.id } }`)
+	require.NoError(t, err)
+	require.JSONEq(t, `{"data": {"q": [{"order.id": "2"}]}}`, output)
+}
+
+func TestDeleteByQuery(t *testing.T) {
+	require.NoError(t, dropAll())
+	require.NoError(t, alterSchema(`event.ts: string @index(exact) .`))
+	require.NoError(t, runMutation(`{ set {
+		_:a <event.ts> "2017-06-01" .
+		_:a <event.payload> "a" .
+		_:b <event.ts> "2018-06-01" .
+		_:b <event.payload> "b" .
+	} }`))
+
+	// The example of the wiki.
+	body, err := json.Marshal(map[string]interface{}{
+		"query": "query q($before: string) { v as var(func: has(event.ts)) " +
+			"@filter(lt(event.ts, $before)) }",
+		"delete":     "uid(v) <event.ts> * .\nuid(v) <event.payload> * .",
+		"variables":  map[string]string{"$before": "2018-01-01"},
+		"batch_size": 5000,
+	})
+	require.NoError(t, err)
+	req, err := http.NewRequest("POST", addr+"/delete", bytes.NewReader(body))
+	require.NoError(t, err)
+	_, out, err := runRequest(req)
+	require.NoError(t, err)
+	var st struct {
+		Data struct {
+			ID     uint64 `json:"id"`
+			Total  int64  `json:"total"`
+			Status string `json:"status"`
+		} `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(out, &st))
+	require.Equal(t, int64(1), st.Data.Total)
+
+	for i := 0; st.Data.Status == "running" && i < 100; i++ {
+		time.Sleep(100 * time.Millisecond)
+		req, err := http.NewRequest("GET", addr+"/delete?id="+
+			strconv.FormatUint(st.Data.ID, 10), nil)
+		require.NoError(t, err)
+		_, out, err := runRequest(req)
+		require.NoError(t, err)
+		var res struct {
+			Data []json.RawMessage `json:"data"`
+		}
+		require.NoError(t, json.Unmarshal(out, &res))
+		require.Len(t, res.Data, 1)
+		require.NoError(t, json.Unmarshal(res.Data[0], &st.Data))
+	}
+	require.Equal(t, "done", st.Data.Status)
+
+	output, err := runQuery(`{ q(func: has(event.ts)) { event.ts event.payload } }`)
+	require.NoError(t, err)
+	require.JSONEq(t, `{"data": {"q": [{"event.ts": "2018-06-01", "event.payload": "b"}]}}`,
+		output)
+}
+
 func TestAlterAllFieldsShouldBeSet(t *testing.T) {
 	req, err := http.NewRequest("PUT", "/alter", bytes.NewBufferString(
 		`{"dropall":true}`, // "dropall" is spelt incorrect - should be "drop_all"
@@ -200,3 +345,30 @@ func TestAlterAllFieldsShouldBeSet(t *testing.T) {
 	require.Len(t, qr.Errors, 1)
 	require.Equal(t, qr.Errors[0].Code, "Error")
 }
+
+func TestHealth(t *testing.T) {
+	req, err := http.NewRequest("GET", addr+"/health", nil)
+	require.NoError(t, err)
+	_, out, err := runRequest(req)
+	require.NoError(t, err)
+	require.Equal(t, "OK", string(out))
+
+	var report struct {
+		Status string `json:"status"`
+		Live   bool   `json:"live"`
+	}
+	req, err = http.NewRequest("GET", addr+"/health?format=json", nil)
+	require.NoError(t, err)
+	_, out, err = runRequest(req)
+	require.NoError(t, err)
+	require.NoError(t, json.Unmarshal(out, &report))
+	require.True(t, report.Live)
+
+	req, err = http.NewRequest("GET", addr+"/health", nil)
+	require.NoError(t, err)
+	req.Header.Set("Accept", "application/json")
+	_, out, err = runRequest(req)
+	require.NoError(t, err)
+	require.NoError(t, json.Unmarshal(out, &report))
+	require.NotEmpty(t, report.Status)
+}
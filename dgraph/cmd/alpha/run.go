@@ -19,6 +19,7 @@ package alpha
 import (
 	"bytes"
 	"crypto/tls"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"log"
@@ -27,14 +28,21 @@ import (
 	_ "net/http/pprof"
 	"os"
 	"os/signal"
+	"path/filepath"
 	"strings"
 	"sync"
 	"syscall"
 	"time"
 
 	"github.com/dgraph-io/dgo/protos/api"
+	"github.com/dgraph-io/dgraph/access"
+	"github.com/dgraph-io/dgraph/audit"
+	"github.com/dgraph-io/dgraph/changes"
+	"github.com/dgraph-io/dgraph/conn"
 	"github.com/dgraph-io/dgraph/edgraph"
+	"github.com/dgraph-io/dgraph/fault"
 	"github.com/dgraph-io/dgraph/posting"
+	"github.com/dgraph-io/dgraph/protos/pb"
 	"github.com/dgraph-io/dgraph/schema"
 	"github.com/dgraph-io/dgraph/tok"
 	"github.com/dgraph-io/dgraph/worker"
@@ -42,9 +50,7 @@ import (
 	"github.com/golang/glog"
 	"github.com/spf13/cast"
 	"github.com/spf13/cobra"
-	"go.opencensus.io/exporter/jaeger"
 	"go.opencensus.io/plugin/ocgrpc"
-	otrace "go.opencensus.io/trace"
 	"go.opencensus.io/zpages"
 	"golang.org/x/net/context"
 	"golang.org/x/net/trace"
@@ -52,11 +58,14 @@ import (
 	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/health"
 	hapi "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
 )
 
 var (
-	bindall bool
-	tlsConf x.TLSHelperConfig
+	bindall    bool
+	tlsConf    x.TLSHelperConfig
+	adminAuth  *x.AdminAuth
+	accessCtrl *access.Controller
 )
 
 var Alpha x.SubCommand
@@ -72,7 +81,7 @@ they form a Raft group and provide synchronous replication.
 `,
 		Run: func(cmd *cobra.Command, args []string) {
 			defer x.StartProfile(Alpha.Conf).Stop()
-			run()
+			run(nil)
 		},
 	}
 	Alpha.EnvPrefix = "DGRAPH_ALPHA"
@@ -91,10 +100,15 @@ they form a Raft group and provide synchronous replication.
 	flag.String("badger.vlog", "mmap",
 		"[mmap, disk] Specifies how Badger Value log is stored."+
 			" mmap consumes more RAM, but provides better performance.")
+	flag.Bool("badger.inmemory", false, "NOT DURABLE. Keep all the data in memory, without"+
+		" syncing writes, in a temporary directory removed when Alpha stops, instead of --postings"+
+		" and --wal. For tests and caches, as the data is lost when Alpha stops.")
 
 	// OpenCensus flags.
-	flag.Float64("trace", 1.0, "The ratio of queries to trace.")
-	flag.String("jaeger.collector", "", "Send opencensus traces to Jaeger.")
+	x.RegisterTracingFlags(flag)
+	x.RegisterMetricsPushFlags(flag)
+	x.RegisterAdminFlags(flag)
+	access.RegisterFlags(flag)
 
 	flag.StringP("wal", "w", "w", "Directory to store raft write-ahead logs.")
 	flag.Bool("nomutations", false, "Don't allow mutations on this server.")
@@ -104,10 +118,36 @@ they form a Raft group and provide synchronous replication.
 	flag.String("export", "export", "Folder in which to store exports.")
 	flag.Int("pending_proposals", 256,
 		"Number of pending mutation proposals. Useful for rate limiting.")
+	flag.Int("mutation_queue", 1024,
+		"Number of mutation proposals waiting for room among the pending ones. Those beyond are"+
+			" turned away as overloaded. Zero means unbounded.")
+	flag.Duration("mutation_queue_timeout", 10*time.Second,
+		"How long a mutation proposal waits for room among the pending ones, before being turned"+
+			" away as overloaded. Zero means no limit.")
+	flag.String("rollup_window", "",
+		"Comma separated daily windows, in local time, to roll up posting lists and run the value"+
+			" log GC in, like 01:00-05:00,22:00-23:30. Empty means any time.")
+	flag.String("rollup_priority", "",
+		"Comma separated priorities of predicates in rollups, like name:high,logs:low. Predicates"+
+			" of high priority are rolled up first, and even outside of --rollup_window.")
+	flag.Duration("txn_ttl", 5*time.Minute,
+		"Transactions with no mutation for this long are aborted, so that they don't hold back"+
+			" the cleanup of old versions. Zero keeps them forever.")
+	flag.String("txn_ttl_exempt", "",
+		"Comma separated IP addresses and CIDR ranges of the clients whose transactions are"+
+			" never aborted by --txn_ttl, like long running loaders.")
+	flag.Duration("history_retention", 0,
+		"How long the past versions of the data are kept for, to be queried via asOfTs. Zero"+
+			" keeps them only as long as the transactions need them.")
+	flag.Int("mutation_batch", 1,
+		"Maximum number of small mutations batched into a single Raft proposal under load."+
+			" One disables batching. All the Alphas of a group must support batching.")
 	flag.String("my", "",
 		"IP_ADDRESS:PORT of this Dgraph Alpha, so other Dgraph Alphas can talk to this.")
 	flag.StringP("zero", "z", fmt.Sprintf("localhost:%d", x.PortZeroGrpc),
-		"IP_ADDRESS:PORT of a Dgraph Zero.")
+		"IP_ADDRESS:PORT of a Dgraph Zero, or comma separated ones. Or the Zeros to find through"+
+			" the SRV records of a name, like dns+srv://_grpc._tcp.zero.ns.svc.cluster.local, or"+
+			" the endpoints of a Kubernetes service, like k8s://namespace/service:port_name.")
 	flag.Uint64("idx", 0,
 		"Optional Raft ID that this Dgraph Alpha will use to join RAFT groups.")
 	flag.Bool("expand_edge", true,
@@ -119,12 +159,92 @@ they form a Raft group and provide synchronous replication.
 	flag.String("auth_token", "",
 		"If set, all Alter requests to Dgraph would need to have this token."+
 			" The token can be passed as follows: For HTTP requests, in X-Dgraph-AuthToken header."+
-			" For Grpc, in auth-token key in the context.")
+			" For Grpc, in auth-token key in the context. Defaults to the admin token of /alter.")
 	flag.Float64P("lru_mb", "l", -1,
 		"Estimated memory the LRU cache can take. "+
 			"Actual usage by the process would be more than specified here.")
+	flag.Float64("posting_cache_mb", 0,
+		"Memory the data, reverse and count posting lists can take in their cache."+
+			" Zero sizes the cache according to --lru_mb.")
+	flag.Float64("index_cache_mb", 0,
+		"Memory the index posting lists can take in their cache."+
+			" Zero sizes the cache according to --lru_mb.")
+	flag.Float64("block_cache_mb", 0,
+		"Memory, outside of the Go heap, the encoded posting lists evicted from the posting list"+
+			" and index caches can take in the block cache. Zero disables the cache.")
+	flag.Float64("query_cache_mb", 0,
+		"Memory the results of queries run outside of transactions can take in the query cache."+
+			" Zero disables the cache.")
+	flag.Duration("query_timeout", 0,
+		"Cancel the queries running for longer than this. Zero means no timeout.")
+	flag.Duration("slow_query", 0,
+		"Log the queries taking longer than this as JSON, to --slow_query_log."+
+			" Zero disables the slow query log.")
+	flag.Float64("slow_query_sample", 1.0, "The ratio of slow queries logged.")
+	flag.Int("slow_query_rate", 10,
+		"Maximum number of slow queries logged per second. Zero means unlimited.")
+	flag.String("slow_query_log", "",
+		"File the slow queries are appended to. If empty, they're logged along with the other logs.")
+	flag.String("audit", "",
+		"Audit log of the mutations, alters, drops and admin calls, either file=<path> or"+
+			" syslog[=<network>://<address>]. Empty disables the audit log.")
+	flag.Int64("audit_rotate_mb", 100,
+		"Size at which the audit log file is rotated. Zero disables rotation.")
+	flag.String("change_stream", "",
+		"Comma separated list of sinks to publish the committed changes to, like"+
+			" nats=nats://<host:port> for NATS JetStream or pubsub=<project> for Google Pub/Sub."+
+			" Empty disables the change stream.")
+	flag.String("change_stream_routes", "",
+		"Comma separated list of rules routing the changes to topics, like"+
+			" <predicate>=<sink>:<topic>. The predicate can end with *. The routes set for the"+
+			" whole cluster through /admin/config of Zero take precedence.")
+	flag.Duration("constraint_check_interval", 0,
+		"Interval at which this Alpha checks all constraints and records violations."+
+			" Zero disables the periodic checks.")
+	flag.Int64("index_build_rate", 0,
+		"Maximum number of keys processed per second by every background index build."+
+			" Zero means unlimited. Can be changed at runtime via /admin/indexing.")
+	flag.Duration("drop_grace", time.Hour,
+		"Dropped predicates are kept for this long, and can be restored meanwhile by renaming"+
+			" them back via Zero. Zero deletes them right away.")
+	flag.Duration("gossip_interval", 0,
+		"Interval at which the Alphas of a group gossip their health among themselves, so that only"+
+			" the group leader reports to Zero frequently. Useful for large clusters."+
+			" Zero disables gossip, and every Alpha reports to Zero.")
+	flag.Bool("learner", false,
+		"Replicate the group this Alpha is assigned to without voting in it, to serve reads"+
+			" without slowing down commits, e.g. in another datacenter. Learners don't count"+
+			" towards the replicas of the group, and never become its leader.")
+	flag.Bool("spare", false,
+		"Wait until a group is missing a replica, e.g. after Zero removed a dead member with"+
+			" --dead_after, and join it. A spare Alpha never forms a new group.")
+	flag.Int("group_replicas", 0,
+		"Number of replicas of the group this Alpha forms or joins, to hold the predicates wanting"+
+			" as many, e.g. 1 for low-value predicates. Zero means the --replicas of Zero.")
+	flag.Bool("zero_follower_reads", false,
+		"Stream the membership state from any Zero, spreading the Alphas over them, instead of"+
+			" from the leader of Zero. Best with --read_staleness set on the Zeros.")
+	flag.Int64("snapshot_log_mb", 64,
+		"The leader of a group takes a snapshot once the Raft log entries it can discard reach"+
+			" this many MB, or fewer when the disk holding the log runs low on space."+
+			" Zero takes snapshots as often as possible.")
+	flag.Duration("snapshot_max_interval", 10*time.Minute,
+		"The leader of a group takes a snapshot of all the Raft log entries it can discard at"+
+			" least this often, however few. Zero disables it.")
+	flag.Int64("snapshot_rate_mb", 0,
+		"Maximum MB per second this Alpha streams snapshots at, to the replicas joining its group"+
+			" or falling behind, and to the clones and replicas of the cluster. Zero means unlimited.")
+	flag.String("compression", "",
+		"[gzip, snappy] Compress the RPCs this Alpha makes to other nodes, like the results of"+
+			" sorts and aggregations fetched from other groups. Empty disables compression.")
+	flag.Int("http_compression_min", 1024,
+		"HTTP query and mutation responses of at least this many bytes are compressed, if the"+
+			" client accepts gzip or snappy. Negative disables compression.")
 	flag.Bool("debugmode", false,
 		"Enable debug mode for more debug information.")
+	flag.Bool("debug_conflicts", false,
+		"Tell the clients of the transactions aborted because of a conflict which key they"+
+			" conflicted on, and with which commit.")
 
 	// Useful for running multiple servers on the same machine.
 	flag.IntP("port_offset", "o", 0,
@@ -138,6 +258,7 @@ they form a Raft group and provide synchronous replication.
 	x.RegisterTLSFlags(flag)
 	flag.String("tls_client_auth", "VERIFYIFGIVEN", "Enable TLS client authentication")
 	tlsConf.ConfigType = x.TLSServerConfig
+	conn.RegisterInternalTLSFlags(flag)
 
 	//Custom plugins.
 	flag.String("custom_tokenizers", "",
@@ -208,12 +329,27 @@ func grpcPort() int {
 
 func healthCheck(w http.ResponseWriter, r *http.Request) {
 	x.AddCorsHeaders(w)
-	if err := x.HealthCheck(); err == nil {
-		w.WriteHeader(http.StatusOK)
-		w.Write([]byte("OK"))
-	} else {
-		w.WriteHeader(http.StatusServiceUnavailable)
+	report := worker.Health(edgraph.Config.PostingDir)
+	// The status code tells the liveness of the Alpha, or with ?ready, its readiness.
+	_, ready := r.URL.Query()["ready"]
+	code := report.HTTPStatus(ready)
+	// The body is the plain OK of old, unless the report is asked for in JSON.
+	if r.URL.Query().Get("format") != "json" &&
+		!strings.Contains(r.Header.Get("Accept"), "application/json") {
+		w.WriteHeader(code)
+		if code == http.StatusOK {
+			w.Write([]byte("OK"))
+		}
+		return
 	}
+	js, err := json.Marshal(report)
+	if err != nil {
+		x.SetStatus(w, x.Error, err.Error())
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	w.Write(js)
 }
 
 // storeStatsHandler outputs some basic stats for data store.
@@ -240,21 +376,21 @@ func setupListener(addr string, port int, reload func()) (net.Listener, error) {
 	return net.Listen("tcp", fmt.Sprintf("%s:%d", addr, port))
 }
 
+// isAdminMethod tells whether the gRPC method is an admin one, for the admin allowlist.
+func isAdminMethod(method string) bool {
+	return method == "/api.Dgraph/Alter"
+}
+
+// isAdminPath tells whether the HTTP endpoint at path is an admin one, for the admin allowlist.
+func isAdminPath(path string) bool {
+	return path == "/alter" || strings.HasPrefix(path, "/admin/")
+}
+
 func serveGRPC(l net.Listener, tlsCfg *tls.Config, wg *sync.WaitGroup) {
 	defer wg.Done()
 
-	if collector := Alpha.Conf.GetString("jaeger.collector"); len(collector) > 0 {
-		// Port details: https://www.jaegertracing.io/docs/getting-started/
-		// Default collectorEndpointURI := "http://localhost:14268"
-		je, err := jaeger.NewExporter(jaeger.Options{
-			Endpoint:    collector,
-			ServiceName: "dgraph.alpha",
-		})
-		if err != nil {
-			log.Fatalf("Failed to create the Jaeger exporter: %v", err)
-		}
-		// And now finally register it as a Trace Exporter
-		otrace.RegisterExporter(je)
+	if err := x.RegisterTraceExportersFromConfig(Alpha.Conf, "dgraph.alpha"); err != nil {
+		log.Fatalf("Failed to set up tracing: %v", err)
 	}
 	// Exclusively for stats, metrics, etc. Not for tracing.
 	// var views = append(ocgrpc.DefaultServerViews, ocgrpc.DefaultClientViews...)
@@ -268,21 +404,29 @@ func serveGRPC(l net.Listener, tlsCfg *tls.Config, wg *sync.WaitGroup) {
 		grpc.MaxConcurrentStreams(1000),
 		grpc.StatsHandler(&ocgrpc.ServerHandler{}),
 	}
+	opt = append(opt, access.ServerOptions(accessCtrl, isAdminMethod)...)
 	if tlsCfg != nil {
 		opt = append(opt, grpc.Creds(credentials.NewTLS(tlsCfg)))
 	}
 
 	s := grpc.NewServer(opt...)
 	api.RegisterDgraphServer(s, &edgraph.Server{})
+	pb.RegisterBatchServer(s, &edgraph.Server{})
+	pb.RegisterNegotiationServer(s, &edgraph.Server{})
+	pb.RegisterStreamServer(s, &edgraph.Streamer{})
 	hapi.RegisterHealthServer(s, health.NewServer())
+	// Reflection lets tools like grpcurl and the drivers of other languages discover the API.
+	reflection.Register(s)
 	err := s.Serve(l)
 	glog.Errorf("GRPC listener canceled: %v\n", err)
 	s.Stop()
 }
 
-func serveHTTP(l net.Listener, tlsCfg *tls.Config, wg *sync.WaitGroup) {
+// serveHTTP serves handler on l, or http.DefaultServeMux if it's nil.
+func serveHTTP(l net.Listener, handler http.Handler, tlsCfg *tls.Config, wg *sync.WaitGroup) {
 	defer wg.Done()
 	srv := &http.Server{
+		Handler:      handler,
 		ReadTimeout:  10 * time.Second,
 		WriteTimeout: 600 * time.Second,
 		IdleTimeout:  2 * time.Minute,
@@ -333,23 +477,52 @@ func setupServer() {
 		log.Fatal(err)
 	}
 
-	http.HandleFunc("/query", queryHandler)
-	http.HandleFunc("/query/", queryHandler)
-	http.HandleFunc("/mutate", mutationHandler)
-	http.HandleFunc("/mutate/", mutationHandler)
+	minSize := Alpha.Conf.GetInt("http_compression_min")
+	http.HandleFunc("/query", x.CompressHandler(minSize, queryHandler))
+	http.HandleFunc("/query/", x.CompressHandler(minSize, queryHandler))
+	http.HandleFunc("/mutate", x.CompressHandler(minSize, mutationHandler))
+	http.HandleFunc("/mutate/", x.CompressHandler(minSize, mutationHandler))
 	http.HandleFunc("/commit/", commitHandler)
 	http.HandleFunc("/abort/", abortHandler)
 	http.HandleFunc("/alter", alterHandler)
+	http.HandleFunc("/template", templateHandler)
+	http.HandleFunc("/upsert/", upsertHandler)
+	http.HandleFunc("/delete", deleteHandler)
+	http.HandleFunc("/graphql", x.CompressHandler(minSize, graphQLHandler))
+	http.HandleFunc("/graphql/schema", graphQLSchemaHandler)
+	http.HandleFunc("/cypher", x.CompressHandler(minSize, cypherHandler))
 	http.HandleFunc("/health", healthCheck)
 	http.HandleFunc("/share", shareHandler)
 
 	// TODO: Figure out what this is for?
 	http.HandleFunc("/debug/store", storeStatsHandler)
 
-	http.HandleFunc("/admin/shutdown", shutDownHandler)
-	http.HandleFunc("/admin/backup", backupHandler)
-	http.HandleFunc("/admin/export", exportHandler)
-	http.HandleFunc("/admin/config/lru_mb", memoryLimitHandler)
+	// Admin calls are audited, mutations and alters are by edgraph. They're served on their own
+	// listener with --admin_addr.
+	adminMux := http.DefaultServeMux
+	var adminListener net.Listener
+	if addr := Alpha.Conf.GetString("admin_addr"); len(addr) > 0 {
+		adminMux = http.NewServeMux()
+		if adminListener, err = net.Listen("tcp", addr); err != nil {
+			log.Fatal(err)
+		}
+	}
+	admin := func(h http.HandlerFunc) http.HandlerFunc {
+		return audit.Handler(edgraph.State.Audit, "admin", adminAuth.Handler(h))
+	}
+	adminMux.HandleFunc("/admin/shutdown", admin(shutDownHandler))
+	adminMux.HandleFunc("/admin/drain", admin(drainHandler))
+	adminMux.HandleFunc("/admin/backup", admin(backupHandler))
+	adminMux.HandleFunc("/admin/export", admin(exportHandler))
+	adminMux.HandleFunc("/admin/constraints", admin(constraintsHandler))
+	adminMux.HandleFunc("/admin/algorithms", admin(algorithmsHandler))
+	adminMux.HandleFunc("/admin/indexing", admin(indexingHandler))
+	adminMux.HandleFunc("/admin/rollups", admin(rollupsHandler))
+	adminMux.HandleFunc("/admin/config/lru_mb", admin(memoryLimitHandler))
+	tunables := x.NewTunables(append(append(edgraph.Tunables(), worker.Tunables()...),
+		x.TraceTunable())...)
+	adminMux.HandleFunc("/admin/config", admin(tunables.Handler))
+	adminMux.HandleFunc("/admin/faults", admin(fault.Handler))
 
 	// Add OpenCensus z-pages.
 	zpages.Handle(http.DefaultServeMux, "/z")
@@ -360,8 +533,14 @@ func setupServer() {
 	// Initilize the servers.
 	var wg sync.WaitGroup
 	wg.Add(3)
-	go serveGRPC(grpcListener, tlsCfg, &wg)
-	go serveHTTP(httpListener, tlsCfg, &wg)
+	go serveGRPC(drainListener{grpcListener}, tlsCfg, &wg)
+	go serveHTTP(httpListener, access.Handler(accessCtrl,
+		closeWhileDraining(http.DefaultServeMux), isAdminPath), tlsCfg, &wg)
+	if adminListener != nil {
+		wg.Add(1)
+		go serveHTTP(adminListener, access.Handler(accessCtrl, adminMux, isAdminPath), tlsCfg, &wg)
+		glog.Infoln("Admin endpoints served at", adminListener.Addr())
+	}
 
 	go func() {
 		defer wg.Done()
@@ -369,6 +548,9 @@ func setupServer() {
 		// Stops grpc/http servers; Already accepted connections are not closed.
 		grpcListener.Close()
 		httpListener.Close()
+		if adminListener != nil {
+			adminListener.Close()
+		}
 	}()
 
 	glog.Infoln("gRPC server started.  Listening on port", grpcPort())
@@ -378,19 +560,107 @@ func setupServer() {
 
 var shutdownCh chan struct{}
 
-func run() {
+// handleSignals closes shutdownCh on the first signal to terminate, and exits on the third. It
+// returns the func to stop handling them.
+func handleSignals() func() {
+	sdCh := make(chan os.Signal, 3)
+	var numShutDownSig int
+	// sigint : Ctrl-C, sigterm : kill command.
+	signal.Notify(sdCh, os.Interrupt, syscall.SIGINT, syscall.SIGTERM)
+	go func() {
+		for {
+			select {
+			case _, ok := <-sdCh:
+				if !ok {
+					return
+				}
+				select {
+				case <-shutdownCh:
+				default:
+					close(shutdownCh)
+				}
+				numShutDownSig++
+				glog.Infoln("Caught Ctrl-C. Terminating now (this may take a few seconds)...")
+				if numShutDownSig == 3 {
+					glog.Infoln("Signaled thrice. Aborting!")
+					os.Exit(1)
+				}
+			}
+		}
+	}()
+	return func() {
+		signal.Stop(sdCh)
+		close(sdCh)
+	}
+}
+
+// Run runs Alpha with the configuration of Alpha.Conf until stop is closed. It's used by package
+// embed, which runs Alpha inside another process, so it doesn't handle the signals.
+func Run(stop <-chan struct{}) {
+	run(stop)
+}
+
+// run runs Alpha until stop is closed, or /admin/shutdown is called. If stop is nil, it's until
+// Alpha gets a signal to terminate instead.
+func run(stop <-chan struct{}) {
 	bindall = Alpha.Conf.GetBool("bindall")
 
+	var err error
+	adminAuth, err = x.AdminAuthFromConfig(Alpha.Conf)
+	x.Checkf(err, "While setting up the admin tokens")
+	accessCtrl, err = access.FromConfig(Alpha.Conf)
+	x.Checkf(err, "While setting up the access control")
+	txnTTLExempt, err := access.ParseList(Alpha.Conf.GetString("txn_ttl_exempt"))
+	x.Checkf(err, "While parsing --txn_ttl_exempt")
+	authToken := Alpha.Conf.GetString("auth_token")
+	if len(authToken) == 0 {
+		authToken = adminAuth.Token("/alter")
+	}
+
+	postingDir, walDir := Alpha.Conf.GetString("postings"), Alpha.Conf.GetString("wal")
+	inMemory := Alpha.Conf.GetBool("badger.inmemory")
+	if inMemory {
+		dir, shm, err := x.MemoryDir("dgraph")
+		x.Checkf(err, "While creating the directory of the data in memory")
+		// Removed once the stores are closed, as the defers run in reverse.
+		defer os.RemoveAll(dir)
+		postingDir, walDir = filepath.Join(dir, "p"), filepath.Join(dir, "w")
+		if shm {
+			glog.Warningf("The data isn't durable: it's kept in memory, in %s, and lost when"+
+				" Alpha stops.", dir)
+		} else {
+			glog.Warningf("There's no /dev/shm to keep the data in memory. It isn't durable:"+
+				" it's kept on disk, in %s, without syncing it, and lost when Alpha stops.", dir)
+		}
+	}
+
 	edgraph.SetConfiguration(edgraph.Options{
-		BadgerTables: Alpha.Conf.GetString("badger.tables"),
-		BadgerVlog:   Alpha.Conf.GetString("badger.vlog"),
+		BadgerTables:   Alpha.Conf.GetString("badger.tables"),
+		BadgerVlog:     Alpha.Conf.GetString("badger.vlog"),
+		BadgerInMemory: inMemory,
 
-		PostingDir: Alpha.Conf.GetString("postings"),
-		WALDir:     Alpha.Conf.GetString("wal"),
+		PostingDir: postingDir,
+		WALDir:     walDir,
 
 		Nomutations:    Alpha.Conf.GetBool("nomutations"),
-		AuthToken:      Alpha.Conf.GetString("auth_token"),
+		AuthToken:      authToken,
 		AllottedMemory: Alpha.Conf.GetFloat64("lru_mb"),
+		PostingCacheMB: Alpha.Conf.GetFloat64("posting_cache_mb"),
+		IndexCacheMB:   Alpha.Conf.GetFloat64("index_cache_mb"),
+		BlockCacheMB:   Alpha.Conf.GetFloat64("block_cache_mb"),
+		QueryCacheMB:   Alpha.Conf.GetFloat64("query_cache_mb"),
+
+		QueryTimeout: Alpha.Conf.GetDuration("query_timeout"),
+
+		SlowQuery:       Alpha.Conf.GetDuration("slow_query"),
+		SlowQuerySample: Alpha.Conf.GetFloat64("slow_query_sample"),
+		SlowQueryRate:   Alpha.Conf.GetInt("slow_query_rate"),
+		SlowQueryLog:    Alpha.Conf.GetString("slow_query_log"),
+
+		Audit:         Alpha.Conf.GetString("audit"),
+		AuditRotateMB: Alpha.Conf.GetInt64("audit_rotate_mb"),
+
+		TxnTTLExempt: txnTTLExempt,
 	})
 
 	ips, err := parseIPsFromString(Alpha.Conf.GetString("whitelist"))
@@ -405,8 +675,36 @@ func run() {
 		ExpandEdge:          Alpha.Conf.GetBool("expand_edge"),
 		WhiteListedIPRanges: ips,
 		MaxRetries:          Alpha.Conf.GetInt("max_retries"),
+		DropGrace:           Alpha.Conf.GetDuration("drop_grace"),
+		GossipInterval:      Alpha.Conf.GetDuration("gossip_interval"),
+		Learner:             Alpha.Conf.GetBool("learner"),
+		Spare:               Alpha.Conf.GetBool("spare"),
+		GroupReplicas:       Alpha.Conf.GetInt("group_replicas"),
+		ZeroFollowerReads:   Alpha.Conf.GetBool("zero_follower_reads"),
+		PostingDir:          postingDir,
+		WALDir:              walDir,
+		SnapshotLogBytes:    uint64(Alpha.Conf.GetInt64("snapshot_log_mb")) << 20,
+		SnapshotMaxInterval: Alpha.Conf.GetDuration("snapshot_max_interval"),
+		SnapshotRate:        Alpha.Conf.GetInt64("snapshot_rate_mb") << 20,
+
+		MutationQueue:        Alpha.Conf.GetInt("mutation_queue"),
+		MutationQueueTimeout: Alpha.Conf.GetDuration("mutation_queue_timeout"),
+		MutationBatch:        Alpha.Conf.GetInt("mutation_batch"),
+		DebugConflicts:       Alpha.Conf.GetBool("debug_conflicts"),
+		TxnTTL:               Alpha.Conf.GetDuration("txn_ttl"),
+		HistoryRetention:     Alpha.Conf.GetDuration("history_retention"),
 	}
 
+	x.Checkf(worker.SetRollupSchedule(Alpha.Conf.GetString("rollup_window"),
+		Alpha.Conf.GetString("rollup_priority")), "While setting up the rollup schedule")
+	changeStream, err := changes.New(Alpha.Conf.GetString("change_stream"),
+		Alpha.Conf.GetString("change_stream_routes"))
+	x.Checkf(err, "While setting up the change stream")
+	if changeStream != nil {
+		defer changeStream.Close()
+	}
+	worker.SetChangeStream(changeStream)
+
 	x.LoadTLSConfig(&tlsConf, Alpha.Conf)
 	tlsConf.ClientAuth = Alpha.Conf.GetString("tls_client_auth")
 
@@ -415,6 +713,19 @@ func run() {
 	x.Config.DebugMode = Alpha.Conf.GetBool("debugmode")
 	x.Config.PortOffset = Alpha.Conf.GetInt("port_offset")
 	x.Config.QueryEdgeLimit = cast.ToUint64(Alpha.Conf.GetString("query_edge_limit"))
+	x.Config.Compression = Alpha.Conf.GetString("compression")
+	if !x.ValidEncoding(x.Config.Compression) {
+		log.Fatalf("Invalid --compression: %q. Must be gzip or snappy.", x.Config.Compression)
+	}
+	if worker.Config.Spare && worker.Config.Learner {
+		log.Fatalf("An Alpha can't be both a --spare and a --learner.")
+	}
+	if worker.Config.GroupReplicas < 0 {
+		log.Fatalf("Invalid --group_replicas: %d. Must be positive, or zero.",
+			worker.Config.GroupReplicas)
+	}
+
+	x.Checkf(conn.SetupInternalTLSFromConfig(Alpha.Conf), "While setting up internal TLS")
 
 	x.PrintVersion()
 	edgraph.InitServerState()
@@ -428,8 +739,7 @@ func run() {
 			return true, true
 		}
 	}
-	otrace.ApplyConfig(otrace.Config{
-		DefaultSampler: otrace.ProbabilitySampler(worker.Config.Tracing)})
+	x.Checkf(x.SetTraceRatio(worker.Config.Tracing), "While setting --trace")
 
 	// Posting will initialize index which requires schema. Hence, initialize
 	// schema before calling posting.Init().
@@ -438,42 +748,27 @@ func run() {
 	defer posting.Cleanup()
 	worker.Init(edgraph.State.Pstore)
 
-	// setup shutdown os signal handler
-	sdCh := make(chan os.Signal, 3)
 	shutdownCh = make(chan struct{})
-
-	var numShutDownSig int
-	defer func() {
-		signal.Stop(sdCh)
-		close(sdCh)
-	}()
-	// sigint : Ctrl-C, sigterm : kill command.
-	signal.Notify(sdCh, os.Interrupt, syscall.SIGINT, syscall.SIGTERM)
-	go func() {
-		for {
+	if stop != nil {
+		go func() {
 			select {
-			case _, ok := <-sdCh:
-				if !ok {
-					return
-				}
-				select {
-				case <-shutdownCh:
-				default:
-					close(shutdownCh)
-				}
-				numShutDownSig++
-				glog.Infoln("Caught Ctrl-C. Terminating now (this may take a few seconds)...")
-				if numShutDownSig == 3 {
-					glog.Infoln("Signaled thrice. Aborting!")
-					os.Exit(1)
-				}
+			case <-stop:
+				close(shutdownCh)
+			case <-shutdownCh:
 			}
-		}
-	}()
-	_ = numShutDownSig
+		}()
+	} else {
+		defer handleSignals()()
+	}
 
 	// Setup external communication.
+	x.Check(worker.SetIndexBuildRate(Alpha.Conf.GetInt64("index_build_rate")))
 	go worker.StartRaftNodes(edgraph.State.WALstore, bindall)
+	x.Checkf(x.StartMetricsPushFromConfig(Alpha.Conf, worker.Config.MyAddr, shutdownCh),
+		"While setting up the push of metrics")
+	if d := Alpha.Conf.GetDuration("constraint_check_interval"); d > 0 {
+		go (&edgraph.Server{}).RunConstraintChecks(d, shutdownCh)
+	}
 	setupServer()
 	glog.Infoln("GRPC and HTTP stopped.")
 	worker.BlockingStop()
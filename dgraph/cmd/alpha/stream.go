@@ -0,0 +1,69 @@
+/*
+ * Copyright 2018 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package alpha
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/dgraph-io/dgo/protos/api"
+	"github.com/dgraph-io/dgraph/query"
+	"github.com/golang/glog"
+)
+
+// queryStream writes the response to a query asked for with ?stream=true as it's encoded, flushing
+// it a chunk at a time with chunked transfer encoding. The response is the same as without
+// streaming, with the data before the extensions.
+type queryStream struct {
+	w       http.ResponseWriter
+	started bool
+}
+
+func (s *queryStream) write(data []byte) error {
+	if !s.started {
+		s.started = true
+		if _, err := s.w.Write([]byte(`{"data":`)); err != nil {
+			return err
+		}
+	}
+	if _, err := s.w.Write(data); err != nil {
+		return err
+	}
+	if f, ok := s.w.(http.Flusher); ok {
+		f.Flush()
+	}
+	return nil
+}
+
+// end writes the extensions after the data. The status of the response is sent along with its
+// first chunk, so errors after it abort the response instead, which clients see as it being cut
+// short.
+func (s *queryStream) end(resp *api.Response, err error, e query.Extensions) {
+	if err != nil {
+		glog.Errorf("Error while streaming the response to a query: %v", err)
+		panic(http.ErrAbortHandler)
+	}
+	e.Txn = resp.Txn
+	e.Latency = resp.Latency
+	js, err := json.Marshal(e)
+	if err != nil {
+		panic(http.ErrAbortHandler)
+	}
+	s.w.Write([]byte(`,"extensions":`))
+	s.w.Write(js)
+	s.w.Write([]byte(`}`))
+}
@@ -0,0 +1,163 @@
+/*
+ * Copyright 2018 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package alpha
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"strings"
+
+	"github.com/dgraph-io/dgraph/edgraph"
+	"github.com/dgraph-io/dgraph/query"
+	"github.com/dgraph-io/dgraph/x"
+	"github.com/golang/glog"
+	"google.golang.org/grpc/metadata"
+)
+
+// templateHandler registers (POST), fetches (GET) and removes (DELETE) upsert templates. The name
+// of the template is passed via the name query parameter for GET and DELETE requests.
+func templateHandler(w http.ResponseWriter, r *http.Request) {
+	x.AddCorsHeaders(w)
+	w.Header().Set("Content-Type", "application/json")
+
+	if r.Method == "OPTIONS" {
+		return
+	}
+
+	// Templates define how data is written, so they're protected in the same way as Alter.
+	md := namespaceMD(r)
+	md.Append("auth-token", r.Header.Get("X-Dgraph-AuthToken"))
+	ctx := metadata.NewIncomingContext(r.Context(), md)
+
+	s := &edgraph.Server{}
+	switch r.Method {
+	case http.MethodPost, http.MethodPut:
+		defer r.Body.Close()
+		b, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			x.SetStatus(w, x.ErrorInvalidRequest, err.Error())
+			return
+		}
+		var t edgraph.UpsertTemplate
+		if err := json.Unmarshal(b, &t); err != nil {
+			x.SetStatus(w, x.ErrorInvalidRequest, err.Error())
+			return
+		}
+		glog.Infof("Got request to register template %q from %s\n", t.Name, r.RemoteAddr)
+		if err := s.RegisterTemplate(ctx, &t); err != nil {
+			x.SetStatus(w, x.Error, err.Error())
+			return
+		}
+		x.Check2(w.Write([]byte(`{"code": "Success", "message": "Template registered."}`)))
+
+	case http.MethodGet:
+		name := r.URL.Query().Get("name")
+		t, err := s.GetTemplate(ctx, name)
+		if err != nil {
+			x.SetStatus(w, x.Error, err.Error())
+			return
+		}
+		if t == nil {
+			w.WriteHeader(http.StatusNotFound)
+			x.SetStatus(w, x.ErrorNoData, "No template registered with name: "+name)
+			return
+		}
+		x.Reply(w, t)
+
+	case http.MethodDelete:
+		name := r.URL.Query().Get("name")
+		glog.Infof("Got request to remove template %q from %s\n", name, r.RemoteAddr)
+		if err := s.RemoveTemplate(ctx, name); err != nil {
+			x.SetStatus(w, x.Error, err.Error())
+			return
+		}
+		x.Check2(w.Write([]byte(`{"code": "Success", "message": "Template removed."}`)))
+
+	default:
+		w.WriteHeader(http.StatusBadRequest)
+		x.SetStatus(w, x.ErrorInvalidMethod, "Invalid method")
+	}
+}
+
+// upsertHandler invokes the template named in the path, i.e. /upsert/<name>. The body holds the
+// variables as a JSON map, in the same format as the X-Dgraph-Vars header of queries.
+func upsertHandler(w http.ResponseWriter, r *http.Request) {
+	x.AddCorsHeaders(w)
+	w.Header().Set("Content-Type", "application/json")
+
+	if r.Method == "OPTIONS" {
+		return
+	}
+
+	if !allowed(r.Method) {
+		w.WriteHeader(http.StatusBadRequest)
+		x.SetStatus(w, x.ErrorInvalidMethod, "Invalid method")
+		return
+	}
+
+	name := strings.TrimPrefix(r.URL.Path, "/upsert/")
+	if len(name) == 0 || strings.Contains(name, "/") {
+		x.SetStatus(w, x.ErrorInvalidRequest, "Template name must be passed as /upsert/<name>")
+		return
+	}
+
+	defer r.Body.Close()
+	b, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		x.SetStatus(w, x.ErrorInvalidRequest, err.Error())
+		return
+	}
+	vars := map[string]string{}
+	if len(b) > 0 {
+		if err := json.Unmarshal(b, &vars); err != nil {
+			x.SetStatus(w, x.ErrorInvalidRequest, "Error while unmarshalling variables")
+			return
+		}
+	}
+
+	ctx := requestContext(r, namespaceMD(r))
+	resp, err := (&edgraph.Server{}).Upsert(ctx, name, vars)
+	if err != nil {
+		x.SetStatusWithData(w, x.ErrorInvalidRequest, err.Error())
+		return
+	}
+
+	e := query.Extensions{
+		Txn:     resp.Context,
+		Latency: resp.Latency,
+	}
+	// Templates always commit immediately, so don't send the keys.
+	if e.Txn != nil {
+		e.Txn.Keys = e.Txn.Keys[:0]
+	}
+
+	response := map[string]interface{}{}
+	response["extensions"] = e
+	mp := map[string]interface{}{}
+	mp["code"] = x.Success
+	mp["message"] = "Done"
+	mp["uids"] = resp.Uids
+	response["data"] = mp
+
+	js, err := json.Marshal(response)
+	if err != nil {
+		x.SetStatusWithData(w, x.Error, err.Error())
+		return
+	}
+	w.Write(js)
+}
@@ -0,0 +1,69 @@
+/*
+ * Copyright 2018 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package audit
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	alog "github.com/dgraph-io/dgraph/audit"
+	"github.com/dgraph-io/dgraph/x"
+)
+
+var Audit x.SubCommand
+
+func init() {
+	Audit.Cmd = &cobra.Command{
+		Use:   "audit <file>...",
+		Short: "Verify the hash chain of an audit log",
+		Long: `
+Audit checks that no entry of an audit log written to files by Alpha or Zero was changed, removed
+or reordered. The files of a rotated log are given from the oldest to the most recent, the file
+still written to last, and the chain is checked across them.`,
+		Args: cobra.MinimumNArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			if err := run(args); err != nil {
+				fmt.Println(err)
+				os.Exit(1)
+			}
+		},
+	}
+	Audit.EnvPrefix = "DGRAPH_AUDIT"
+}
+
+func run(files []string) error {
+	var prev string
+	var seq uint64
+	for _, file := range files {
+		f, err := os.Open(file)
+		if err != nil {
+			return err
+		}
+		last, err := alog.Verify(f, prev)
+		f.Close()
+		if err != nil {
+			return x.Wrapf(err, "While verifying %s", file)
+		}
+		if last != nil {
+			seq, prev = last.Seq, last.Hash
+		}
+	}
+	fmt.Printf("The chain is intact, up to entry %d.\n", seq)
+	return nil
+}
@@ -33,6 +33,7 @@ import (
 
 	"github.com/dgraph-io/badger"
 	bo "github.com/dgraph-io/badger/options"
+	"github.com/dgraph-io/dgraph/conn"
 	"github.com/dgraph-io/dgraph/protos/pb"
 	"github.com/dgraph-io/dgraph/schema"
 	"github.com/dgraph-io/dgraph/x"
@@ -86,7 +87,7 @@ func newLoader(opt options) *loader {
 	fmt.Printf("Connecting to zero at %s\n", opt.ZeroAddr)
 	zero, err := grpc.Dial(opt.ZeroAddr,
 		grpc.WithBlock(),
-		grpc.WithInsecure(),
+		conn.DialOption(),
 		grpc.WithTimeout(time.Minute))
 	x.Checkf(err, "Unable to connect to zero, Is it running at %s?", opt.ZeroAddr)
 	st := &state{
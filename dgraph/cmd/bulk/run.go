@@ -28,6 +28,7 @@ import (
 	"runtime"
 	"strconv"
 
+	"github.com/dgraph-io/dgraph/conn"
 	"github.com/dgraph-io/dgraph/x"
 	"github.com/spf13/cobra"
 )
@@ -73,6 +74,7 @@ func init() {
 	flag.Bool("version", false, "Prints the version of Dgraph Bulk Loader.")
 	flag.BoolP("store_xids", "x", false, "Generate an xid edge for each node.")
 	flag.StringP("zero", "z", "localhost:5080", "gRPC address for Dgraph zero")
+	conn.RegisterInternalTLSFlags(flag)
 	// TODO: Potentially move http server to main.
 	flag.String("http", "localhost:8080",
 		"Address to serve http (pprof).")
@@ -117,6 +119,7 @@ func run() {
 		fmt.Fprint(os.Stderr, "RDF and schema file(s) must be specified.\n")
 		os.Exit(1)
 	}
+	x.Checkf(conn.SetupInternalTLSFromConfig(Bulk.Conf), "While setting up internal TLS")
 	if opt.ReduceShards > opt.MapShards {
 		fmt.Fprintf(os.Stderr, "Invalid flags: reduce_shards(%d) should be <= map_shards(%d)\n",
 			opt.ReduceShards, opt.MapShards)
@@ -71,6 +71,7 @@ func (s *shuffler) createBadger(i int) *badger.DB {
 	opt.ValueDir = opt.Dir
 	db, err := badger.OpenManaged(opt)
 	x.Check(err)
+	x.Check(x.WriteFormat(opt.Dir, x.PostingFormat))
 	s.dbs = append(s.dbs, db)
 	return db
 }
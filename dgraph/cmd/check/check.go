@@ -0,0 +1,413 @@
+/*
+ * Copyright 2018 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package check
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/dgraph-io/badger"
+
+	"github.com/dgraph-io/dgraph/posting"
+	"github.com/dgraph-io/dgraph/protos/pb"
+	"github.com/dgraph-io/dgraph/schema"
+	"github.com/dgraph-io/dgraph/tok"
+	"github.com/dgraph-io/dgraph/types"
+	"github.com/dgraph-io/dgraph/worker"
+	"github.com/dgraph-io/dgraph/x"
+)
+
+// The kinds of issues found. Those of orphaned index entries and dangling reverse edges are in
+// lists derived from the data, so they can be repaired by taking the postings out of the lists.
+const (
+	orphanedIndex   = "orphaned_index"   // An index entry of a uid whose value doesn't have the term.
+	danglingReverse = "dangling_reverse" // A reverse edge without the edge it's the reverse of.
+	typeViolation   = "type_violation"   // Data which doesn't fit the schema of its predicate.
+	tabletMismatch  = "tablet_mismatch"  // Data of a tablet the group doesn't hold.
+)
+
+// issue is an issue found in a key, or in all the keys of a predicate if Key is empty.
+type issue struct {
+	Kind      string `json:"kind"`
+	Predicate string `json:"predicate"`
+	Key       string `json:"key,omitempty"` // In hex.
+	Uid       string `json:"uid,omitempty"`
+	Detail    string `json:"detail"`
+	Count     int    `json:"count,omitempty"` // Of the postings or keys with the issue.
+	Repaired  bool   `json:"repaired,omitempty"`
+}
+
+// report is the result of a check, printed as JSON.
+type report struct {
+	ReadTs    uint64            `json:"read_ts"`
+	Keys      int               `json:"keys"`
+	Counts    map[string]int    `json:"counts"`              // Of the issues, by kind.
+	Repaired  int               `json:"repaired"`            // Issues repaired.
+	Unchecked map[string]string `json:"unchecked,omitempty"` // Indexes which weren't, and why.
+	Issues    []*issue          `json:"issues"`
+	// Truncated is set if only the first of the issues of a kind are listed.
+	Truncated bool `json:"truncated,omitempty"`
+}
+
+// unrepaired returns the number of issues left.
+func (r *report) unrepaired() int {
+	var n int
+	for _, c := range r.Counts {
+		n += c
+	}
+	return n - r.Repaired
+}
+
+// checker checks the keys of a p directory, one at a time, reading the keys they refer to as it
+// goes instead of holding them.
+type checker struct {
+	db        *badger.DB
+	readTs    uint64
+	maxIssues int // Listed per kind.
+	repair    bool
+
+	// The tablets of the cluster, and the group of the p directory. The tablets aren't checked if
+	// nil.
+	tablets map[string]*pb.Tablet
+	gid     uint32
+
+	look   *badger.Iterator // For reading the keys referred to.
+	writer *x.TxnWriter
+	res    *report
+	listed map[string]int // Issues listed, by kind.
+	// The postings of the current predicate in keys of uids other groups hold.
+	misplaced int
+}
+
+func newChecker(db *badger.DB, readTs uint64) *checker {
+	return &checker{
+		db:        db,
+		readTs:    readTs,
+		maxIssues: math.MaxInt32,
+		res: &report{
+			ReadTs:    readTs,
+			Counts:    make(map[string]int),
+			Unchecked: make(map[string]string),
+		},
+		listed: make(map[string]int),
+	}
+}
+
+func (c *checker) add(is *issue) {
+	c.res.Counts[is.Kind]++
+	if is.Repaired {
+		c.res.Repaired++
+	}
+	if c.listed[is.Kind] >= c.maxIssues {
+		c.res.Truncated = true
+		return
+	}
+	c.listed[is.Kind]++
+	c.res.Issues = append(c.res.Issues, is)
+}
+
+// run checks all the keys of the p directory as of readTs. With repair, the repairable issues are
+// repaired in the latest version of the lists, which readTs has to be.
+func (c *checker) run() (*report, error) {
+	if c.repair {
+		c.writer = x.NewTxnWriter(c.db)
+		// Like rollups, the lists are written over at their own version.
+		c.writer.BlindWrite = true
+	}
+	txn := c.db.NewTransactionAt(c.readTs, false)
+	defer txn.Discard()
+	iopts := badger.DefaultIteratorOptions
+	iopts.AllVersions = true
+	itr := txn.NewIterator(iopts)
+	defer itr.Close()
+	c.look = txn.NewIterator(iopts)
+	defer c.look.Close()
+
+	var pred string
+	var tab *pb.Tablet
+	for itr.Rewind(); itr.Valid(); {
+		item := itr.Item()
+		key := item.KeyCopy(nil)
+		pk := x.Parse(key)
+		if pk == nil || pk.IsSchema() {
+			itr.Next()
+			continue
+		}
+		if pk.Attr != pred {
+			// The keys of a predicate are next to each other.
+			c.endPredicate(pred)
+			pred = pk.Attr
+			var skip bool
+			if tab, skip = c.startPredicate(pred); skip {
+				itr.Seek(pk.SkipPredicate())
+				continue
+			}
+		}
+		l, err := posting.ReadPostingList(key, itr)
+		if err != nil {
+			return nil, x.Wrapf(err, "while reading key %x", key)
+		}
+		for itr.Valid() && bytes.Equal(itr.Item().Key(), key) {
+			itr.Next()
+		}
+		c.res.Keys++
+
+		switch {
+		case pk.IsData():
+			err = c.checkData(pk, key, l, tab)
+		case pk.IsIndex():
+			err = c.checkIndex(pk, key, l, tab)
+		case pk.IsReverse():
+			err = c.checkReverse(pk, key, l, tab)
+		}
+		if err != nil {
+			return nil, x.Wrapf(err, "while checking key %x", key)
+		}
+	}
+	c.endPredicate(pred)
+	if c.writer != nil {
+		if err := c.writer.Flush(); err != nil {
+			return nil, err
+		}
+	}
+	sort.SliceStable(c.res.Issues, func(i, j int) bool {
+		return c.res.Issues[i].Kind < c.res.Issues[j].Kind
+	})
+	return c.res, nil
+}
+
+// startPredicate returns the tablet of pred. skip is set if the group doesn't hold any of it,
+// which is reported once for all its keys.
+func (c *checker) startPredicate(pred string) (tab *pb.Tablet, skip bool) {
+	c.misplaced = 0
+	if c.tablets == nil {
+		return nil, false
+	}
+	tab, ok := c.tablets[pred]
+	switch {
+	case !ok:
+		c.add(&issue{Kind: tabletMismatch, Predicate: pred,
+			Detail: "The predicate has no tablet in the state of Zero"})
+		return nil, true
+	case !holdsTablet(tab, c.gid):
+		c.add(&issue{Kind: tabletMismatch, Predicate: pred,
+			Detail: fmt.Sprintf("The tablet is served by group %d, not by group %d",
+				tab.GroupId, c.gid)})
+		return nil, true
+	}
+	return tab, false
+}
+
+func (c *checker) endPredicate(pred string) {
+	if c.misplaced > 0 {
+		c.add(&issue{Kind: tabletMismatch, Predicate: pred, Count: c.misplaced,
+			Detail: fmt.Sprintf("The tablet is split, and group %d has data of uids other"+
+				" groups hold", c.gid)})
+	}
+	c.misplaced = 0
+}
+
+func holdsTablet(tab *pb.Tablet, gid uint32) bool {
+	_, ok := worker.TabletUids(tab, gid)
+	return ok
+}
+
+// holdsUid tells whether the group holds the data of uid in tab, which it all does if tab isn't
+// split.
+func (c *checker) holdsUid(tab *pb.Tablet, uid uint64) bool {
+	if tab == nil {
+		return true
+	}
+	holds, _ := worker.TabletUids(tab, c.gid)
+	return holds(uid)
+}
+
+// checkData checks the postings of the data key of a uid against the schema of its predicate.
+func (c *checker) checkData(pk *x.ParsedKey, key []byte, l *posting.List, tab *pb.Tablet) error {
+	if !c.holdsUid(tab, pk.Uid) {
+		c.misplaced++
+		return nil
+	}
+	violation := func(format string, args ...interface{}) {
+		c.add(&issue{Kind: typeViolation, Predicate: pk.Attr, Key: fmt.Sprintf("%x", key),
+			Uid: fmt.Sprintf("%#x", pk.Uid), Detail: fmt.Sprintf(format, args...)})
+	}
+	su, ok := schema.State().Get(pk.Attr)
+	if !ok {
+		violation("The predicate has no schema")
+		return nil
+	}
+	typ := types.TypeID(su.ValueType)
+	var edges, values, lists int
+	var bad error
+	err := l.Iterate(c.readTs, 0, func(p *pb.Posting) error {
+		if p.PostingType == pb.Posting_REF {
+			edges++
+			return nil
+		}
+		values++
+		if p.PostingType == pb.Posting_VALUE && p.Uid != math.MaxUint64 {
+			lists++
+		}
+		if typ != types.UidID && typ != types.DefaultID && bad == nil {
+			_, bad = types.Convert(types.Val{Tid: types.TypeID(p.ValType), Value: p.Value}, typ)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	switch {
+	case typ == types.UidID && values > 0:
+		violation("%d values for a predicate of type uid", values)
+	case typ != types.UidID && edges > 0:
+		violation("%d uid edges for a predicate of type %s", edges, typ.Name())
+	case bad != nil:
+		violation("A value can't be converted to %s: %v", typ.Name(), bad)
+	case !su.List && lists > 0:
+		violation("%d values of a list for a predicate which isn't a list", lists)
+	}
+	return nil
+}
+
+// checkIndex checks that the uids of an index entry have a value which has its term.
+func (c *checker) checkIndex(pk *x.ParsedKey, key []byte, l *posting.List, tab *pb.Tablet) error {
+	su, ok := schema.State().Get(pk.Attr)
+	if !ok || len(su.Tokenizer) == 0 {
+		return c.removeAll(orphanedIndex, pk, key, l, tab, "The predicate isn't indexed")
+	}
+	for _, name := range su.Tokenizer {
+		if _, ok := tok.GetTokenizer(name); !ok {
+			c.res.Unchecked[pk.Attr] = fmt.Sprintf("Unknown tokenizer %s. Pass it with"+
+				" --custom_tokenizers", name)
+			return nil
+		}
+	}
+	term := string(pk.Term)
+	return c.removeUids(orphanedIndex, pk, key, l, tab, func(uid uint64) (bool, error) {
+		data, err := c.readList(x.DataKey(pk.Attr, uid))
+		if err != nil {
+			return false, err
+		}
+		var found bool
+		err = data.Iterate(c.readTs, 0, func(p *pb.Posting) error {
+			if p.PostingType == pb.Posting_REF {
+				return nil
+			}
+			val := types.Val{Tid: types.TypeID(p.ValType), Value: p.Value}
+			tokens, err := posting.IndexTokens(pk.Attr, string(p.LangTag), val)
+			if err != nil {
+				// Such values aren't indexed.
+				return nil
+			}
+			for _, t := range tokens {
+				if t == term {
+					found = true
+					return posting.ErrStopIteration
+				}
+			}
+			return nil
+		})
+		if err != nil && err != posting.ErrStopIteration {
+			return false, err
+		}
+		return found, nil
+	}, fmt.Sprintf("uids without a value with the term %q", term))
+}
+
+// checkReverse checks that the uids of the reverse edges to a uid have an edge to it.
+func (c *checker) checkReverse(pk *x.ParsedKey, key []byte, l *posting.List,
+	tab *pb.Tablet) error {
+	su, ok := schema.State().Get(pk.Attr)
+	if !ok || su.Directive != pb.SchemaUpdate_REVERSE {
+		return c.removeAll(danglingReverse, pk, key, l, tab, "The predicate isn't reversed")
+	}
+	dst := pk.Uid
+	return c.removeUids(danglingReverse, pk, key, l, tab, func(src uint64) (bool, error) {
+		data, err := c.readList(x.DataKey(pk.Attr, src))
+		if err != nil {
+			return false, err
+		}
+		var found bool
+		err = data.Iterate(c.readTs, dst-1, func(p *pb.Posting) error {
+			found = p.Uid == dst && p.PostingType == pb.Posting_REF
+			return posting.ErrStopIteration
+		})
+		if err != nil && err != posting.ErrStopIteration {
+			return false, err
+		}
+		return found, nil
+	}, fmt.Sprintf("uids without an edge to %#x", dst))
+}
+
+// readList reads the list of key as of readTs, which is empty if there's none.
+func (c *checker) readList(key []byte) (*posting.List, error) {
+	c.look.Seek(key)
+	return posting.ReadPostingList(key, c.look)
+}
+
+// removeAll reports all the postings of the list of key, which shouldn't be there at all, and
+// takes them out of it with repair.
+func (c *checker) removeAll(kind string, pk *x.ParsedKey, key []byte, l *posting.List,
+	tab *pb.Tablet, detail string) error {
+	return c.removeUids(kind, pk, key, l, tab, func(uint64) (bool, error) {
+		return false, nil
+	}, detail)
+}
+
+// removeUids reports the postings of the list of key whose uids ok returns false for, and takes
+// them out of it with repair. Those of the uids other groups hold are left to startPredicate.
+func (c *checker) removeUids(kind string, pk *x.ParsedKey, key []byte, l *posting.List,
+	tab *pb.Tablet, ok func(uid uint64) (bool, error), detail string) error {
+	bad := make(map[uint64]bool)
+	err := l.Iterate(c.readTs, 0, func(p *pb.Posting) error {
+		if !c.holdsUid(tab, p.Uid) {
+			c.misplaced++
+			return nil
+		}
+		good, err := ok(p.Uid)
+		if err != nil {
+			return err
+		}
+		if !good {
+			bad[p.Uid] = true
+		}
+		return nil
+	})
+	if err != nil || len(bad) == 0 {
+		return err
+	}
+	is := &issue{Kind: kind, Predicate: pk.Attr, Key: fmt.Sprintf("%x", key), Count: len(bad),
+		Detail: fmt.Sprintf("%d %s", len(bad), detail)}
+	if pk.IsReverse() {
+		is.Uid = fmt.Sprintf("%#x", pk.Uid)
+	}
+	if c.writer != nil {
+		kv, err := l.MarshalToKvFiltered(func(uid uint64) bool { return !bad[uid] })
+		if err != nil {
+			return err
+		}
+		if err := c.writer.SetAt(kv.Key, kv.Val, kv.UserMeta[0], kv.Version); err != nil {
+			return err
+		}
+		is.Repaired = kv.Version > 0
+	}
+	c.add(is)
+	return nil
+}
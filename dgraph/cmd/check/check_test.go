@@ -0,0 +1,229 @@
+/*
+ * Copyright 2018 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package check
+
+import (
+	"io/ioutil"
+	"math"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/dgraph-io/badger"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dgraph-io/dgraph/codec"
+	"github.com/dgraph-io/dgraph/posting"
+	"github.com/dgraph-io/dgraph/protos/pb"
+	"github.com/dgraph-io/dgraph/schema"
+	"github.com/dgraph-io/dgraph/x"
+)
+
+func value(v string, typ pb.Posting_ValType) *pb.Posting {
+	return &pb.Posting{Uid: math.MaxUint64, Value: []byte(v), ValType: typ,
+		PostingType: pb.Posting_VALUE}
+}
+
+// setList writes the complete list of key at ts, with the postings of values, and edges to the
+// uids.
+func setList(t *testing.T, db *badger.DB, ts uint64, key []byte, uids []uint64,
+	values ...*pb.Posting) {
+	enc := codec.Encoder{BlockSize: 256}
+	for _, p := range values {
+		enc.Add(p.Uid)
+	}
+	for _, uid := range uids {
+		enc.Add(uid)
+	}
+	pl := &pb.PostingList{Pack: enc.Done(), Postings: values}
+	val, err := pl.Marshal()
+	require.NoError(t, err)
+	txn := db.NewTransactionAt(ts, true)
+	defer txn.Discard()
+	require.NoError(t, txn.SetWithMeta(key, val, posting.BitCompletePosting))
+	require.NoError(t, txn.CommitAt(ts, nil))
+}
+
+func setSchema(t *testing.T, db *badger.DB, su pb.SchemaUpdate) {
+	val, err := su.Marshal()
+	require.NoError(t, err)
+	txn := db.NewTransactionAt(1, true)
+	defer txn.Discard()
+	require.NoError(t, txn.Set(x.SchemaKey(su.Predicate), val))
+	require.NoError(t, txn.CommitAt(1, nil))
+}
+
+func openDB(t *testing.T) (*badger.DB, string) {
+	dir, err := ioutil.TempDir("", "check")
+	require.NoError(t, err)
+	opt := badger.DefaultOptions
+	opt.Dir = dir
+	opt.ValueDir = dir
+	db, err := badger.OpenManaged(opt)
+	require.NoError(t, err)
+
+	setSchema(t, db, pb.SchemaUpdate{Predicate: "name", ValueType: pb.Posting_STRING,
+		Tokenizer: []string{"exact"}})
+	setSchema(t, db, pb.SchemaUpdate{Predicate: "friend", ValueType: pb.Posting_UID,
+		Directive: pb.SchemaUpdate_REVERSE, List: true})
+	setSchema(t, db, pb.SchemaUpdate{Predicate: "age", ValueType: pb.Posting_INT})
+	schema.Init(db)
+	require.NoError(t, schema.LoadFromDb())
+
+	setList(t, db, 10, x.DataKey("name", 1), nil, value("alice", pb.Posting_STRING))
+	// The entry of 2 is orphaned, as is the one of bob.
+	setList(t, db, 10, x.IndexKey("name", "\x02alice"), []uint64{1, 2})
+	setList(t, db, 10, x.IndexKey("name", "\x02bob"), []uint64{1})
+	// The reverse edge of 3 is dangling.
+	setList(t, db, 10, x.DataKey("friend", 1), []uint64{2})
+	setList(t, db, 10, x.ReverseKey("friend", 2), []uint64{1, 3})
+	setList(t, db, 10, x.DataKey("age", 1), nil, value("abc", pb.Posting_STRING))
+	setList(t, db, 10, x.DataKey("age", 2), []uint64{5})
+	setList(t, db, 10, x.DataKey("age", 150), nil, value("30", pb.Posting_DEFAULT))
+	setList(t, db, 10, x.DataKey("nick", 1), nil, value("al", pb.Posting_STRING))
+	return db, dir
+}
+
+func issuesOf(res *report, kind string) []*issue {
+	var out []*issue
+	for _, is := range res.Issues {
+		if is.Kind == kind {
+			out = append(out, is)
+		}
+	}
+	return out
+}
+
+func TestCheck(t *testing.T) {
+	db, dir := openDB(t)
+	defer os.RemoveAll(dir)
+	defer db.Close()
+
+	res, err := newChecker(db, math.MaxUint64).run()
+	require.NoError(t, err)
+	require.Equal(t, 9, res.Keys)
+	require.Equal(t, map[string]int{orphanedIndex: 2, danglingReverse: 1, typeViolation: 3},
+		res.Counts)
+	require.Equal(t, 6, res.unrepaired())
+
+	orphans := issuesOf(res, orphanedIndex)
+	require.Len(t, orphans, 2)
+	for _, is := range orphans {
+		require.Equal(t, "name", is.Predicate)
+		require.Equal(t, 1, is.Count)
+		require.False(t, is.Repaired)
+	}
+	dangling := issuesOf(res, danglingReverse)
+	require.Len(t, dangling, 1)
+	require.Equal(t, "0x2", dangling[0].Uid)
+
+	var details []string
+	for _, is := range issuesOf(res, typeViolation) {
+		details = append(details, is.Predicate+": "+is.Detail)
+	}
+	require.Len(t, details, 3)
+	require.True(t, strings.HasPrefix(details[0], "age: A value can't be converted to int"))
+	require.Equal(t, "age: 1 uid edges for a predicate of type int", details[1])
+	require.Equal(t, "nick: The predicate has no schema", details[2])
+
+	// The issues listed are capped, not the counts.
+	c := newChecker(db, math.MaxUint64)
+	c.maxIssues = 1
+	res, err = c.run()
+	require.NoError(t, err)
+	require.True(t, res.Truncated)
+	require.Len(t, res.Issues, 3)
+	require.Equal(t, 3, res.Counts[typeViolation])
+}
+
+func TestCheckRepair(t *testing.T) {
+	db, dir := openDB(t)
+	defer os.RemoveAll(dir)
+	defer db.Close()
+
+	c := newChecker(db, math.MaxUint64)
+	c.repair = true
+	res, err := c.run()
+	require.NoError(t, err)
+	require.Equal(t, 3, res.Repaired)
+	for _, is := range res.Issues {
+		require.Equal(t, is.Kind != typeViolation, is.Repaired)
+	}
+	// Only the type violations are left.
+	require.Equal(t, 3, res.unrepaired())
+
+	res, err = newChecker(db, math.MaxUint64).run()
+	require.NoError(t, err)
+	require.Equal(t, map[string]int{typeViolation: 3}, res.Counts)
+
+	uids := func(key []byte) []uint64 {
+		txn := db.NewTransactionAt(math.MaxUint64, false)
+		defer txn.Discard()
+		iopts := badger.DefaultIteratorOptions
+		iopts.AllVersions = true
+		itr := txn.NewIterator(iopts)
+		defer itr.Close()
+		itr.Seek(key)
+		l, err := posting.ReadPostingList(key, itr)
+		require.NoError(t, err)
+		var out []uint64
+		require.NoError(t, l.Iterate(math.MaxUint64, 0, func(p *pb.Posting) error {
+			out = append(out, p.Uid)
+			return nil
+		}))
+		return out
+	}
+	require.Equal(t, []uint64{1}, uids(x.IndexKey("name", "\x02alice")))
+	require.Empty(t, uids(x.IndexKey("name", "\x02bob")))
+	require.Equal(t, []uint64{1}, uids(x.ReverseKey("friend", 2)))
+}
+
+func TestCheckTablets(t *testing.T) {
+	db, dir := openDB(t)
+	defer os.RemoveAll(dir)
+	defer db.Close()
+
+	state := `{"groups": {
+		"1": {"tablets": {
+			"name": {"groupId": 1, "predicate": "name"},
+			"age": {"groupId": 1, "predicate": "age",
+				"shards": [{"startUid": "100", "groupId": 2}]}}},
+		"2": {"tablets": {
+			"friend": {"groupId": 2, "predicate": "friend"}}}}}`
+	tablets, err := readTablets(strings.NewReader(state))
+	require.NoError(t, err)
+	require.Len(t, tablets, 3)
+
+	c := newChecker(db, math.MaxUint64)
+	c.tablets, c.gid = tablets, 1
+	res, err := c.run()
+	require.NoError(t, err)
+	// The keys of friend and nick aren't checked, as the group doesn't hold them.
+	require.Equal(t, 6, res.Keys)
+	require.Equal(t, 3, res.Counts[tabletMismatch])
+	require.Equal(t, 2, res.Counts[orphanedIndex])
+	require.Equal(t, 0, res.Counts[danglingReverse])
+
+	mismatches := make(map[string]*issue)
+	for _, is := range issuesOf(res, tabletMismatch) {
+		mismatches[is.Predicate] = is
+	}
+	require.Equal(t, "The tablet is served by group 2, not by group 1",
+		mismatches["friend"].Detail)
+	require.Equal(t, "The predicate has no tablet in the state of Zero", mismatches["nick"].Detail)
+	require.Equal(t, 1, mismatches["age"].Count)
+}
@@ -0,0 +1,170 @@
+/*
+ * Copyright 2018 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package check
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"strings"
+
+	"github.com/dgraph-io/badger"
+	"github.com/dgraph-io/badger/options"
+	"github.com/gogo/protobuf/jsonpb"
+	"github.com/spf13/cobra"
+
+	"github.com/dgraph-io/dgraph/protos/pb"
+	"github.com/dgraph-io/dgraph/schema"
+	"github.com/dgraph-io/dgraph/tok"
+	"github.com/dgraph-io/dgraph/x"
+)
+
+var Check x.SubCommand
+
+var opt struct {
+	postings         string
+	zeroState        string
+	group            uint32
+	readTs           uint64
+	repair           bool
+	maxIssues        int
+	customTokenizers string
+}
+
+func init() {
+	Check.Cmd = &cobra.Command{
+		Use:   "check",
+		Short: "Check the p directory of an Alpha against its schema and the state of Zero",
+		Long: `
+Check scans the p directory of an Alpha, offline with the Alpha stopped, for index entries of uids
+whose values don't have their term, reverse edges without the edge they're the reverse of, data
+which doesn't fit the schema of its predicate and, given the state of Zero, data of tablets the
+group of the Alpha doesn't hold. The issues found are reported as JSON on stdout, and the command
+exits with 1 if any is left. With --repair, the orphaned index entries and dangling reverse edges
+are taken out of their lists, as they're derived from the data. The other issues are left for the
+operator to fix.`,
+		Args: cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			defer x.StartProfile(Check.Conf).Stop()
+			if err := run(); err != nil {
+				fmt.Println(err)
+				os.Exit(1)
+			}
+		},
+	}
+	Check.EnvPrefix = "DGRAPH_CHECK"
+
+	flag := Check.Cmd.Flags()
+	flag.StringVarP(&opt.postings, "postings", "p", "", "Directory of the posting lists of an Alpha")
+	flag.StringVar(&opt.zeroState, "zero_state", "",
+		"File with the JSON of the /state of Zero, to check the tablets of the group against")
+	flag.Uint32Var(&opt.group, "group", 0, "Group of the Alpha, for --zero_state")
+	flag.Uint64Var(&opt.readTs, "read_ts", 0,
+		"Timestamp to check the data as of. Zero for the latest data")
+	flag.BoolVar(&opt.repair, "repair", false,
+		"Take the orphaned index entries and dangling reverse edges out of their lists")
+	flag.IntVar(&opt.maxIssues, "max_issues", 1000,
+		"Issues of each kind to list in the report. All of them are counted")
+	flag.StringVar(&opt.customTokenizers, "custom_tokenizers", "",
+		"Comma separated list of the tokenizer plugins of the Alpha")
+}
+
+func run() error {
+	if len(opt.postings) == 0 {
+		return x.Errorf("No posting directory to check. Pass --postings")
+	}
+	if opt.repair && opt.readTs > 0 {
+		return x.Errorf("The lists are only repaired as of the latest data. Leave out --read_ts")
+	}
+	var tablets map[string]*pb.Tablet
+	if len(opt.zeroState) > 0 {
+		if opt.group == 0 {
+			return x.Errorf("The tablets can't be checked without the group. Pass --group")
+		}
+		f, err := os.Open(opt.zeroState)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		if tablets, err = readTablets(f); err != nil {
+			return x.Wrapf(err, "While reading the state of Zero in %s", opt.zeroState)
+		}
+	}
+	if opt.customTokenizers != "" {
+		for _, soFile := range strings.Split(opt.customTokenizers, ",") {
+			tok.LoadCustomTokenizer(soFile)
+		}
+	}
+
+	bopts := badger.DefaultOptions
+	bopts.Dir = opt.postings
+	bopts.ValueDir = opt.postings
+	bopts.TableLoadingMode = options.MemoryMap
+	bopts.ReadOnly = !opt.repair
+	db, err := badger.OpenManaged(bopts)
+	if err != nil {
+		return x.Wrapf(err, "While opening %s", opt.postings)
+	}
+	defer db.Close()
+
+	schema.Init(db)
+	if err := schema.LoadFromDb(); err != nil {
+		return x.Wrapf(err, "While loading the schema")
+	}
+
+	readTs := opt.readTs
+	if readTs == 0 {
+		readTs = math.MaxUint64
+	}
+	c := newChecker(db, readTs)
+	c.maxIssues = opt.maxIssues
+	c.repair = opt.repair
+	c.tablets, c.gid = tablets, opt.group
+	res, err := c.run()
+	if err != nil {
+		return err
+	}
+	res.ReadTs = opt.readTs
+
+	js, err := json.MarshalIndent(res, "", "  ")
+	if err != nil {
+		return err
+	}
+	fmt.Println(string(js))
+	if res.unrepaired() > 0 {
+		os.Exit(1)
+	}
+	return nil
+}
+
+// readTablets returns the tablets of all the groups in the membership state of Zero, as served
+// on its /state endpoint.
+func readTablets(r io.Reader) (map[string]*pb.Tablet, error) {
+	var state pb.MembershipState
+	if err := jsonpb.Unmarshal(r, &state); err != nil {
+		return nil, err
+	}
+	tablets := make(map[string]*pb.Tablet)
+	for _, g := range state.Groups {
+		for pred, tab := range g.Tablets {
+			tablets[pred] = tab
+		}
+	}
+	return tablets, nil
+}
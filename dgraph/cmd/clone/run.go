@@ -0,0 +1,287 @@
+/*
+ * Copyright 2018 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package clone
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/dgraph-io/badger"
+	"github.com/spf13/cobra"
+	"google.golang.org/grpc"
+
+	"github.com/dgraph-io/dgraph/conn"
+	"github.com/dgraph-io/dgraph/protos/pb"
+	"github.com/dgraph-io/dgraph/x"
+)
+
+var Clone x.SubCommand
+
+var opt struct {
+	zero     string
+	out      string
+	alphas   []string
+	zeroAddr string
+	hold     time.Duration
+	token    string
+}
+
+func init() {
+	Clone.Cmd = &cobra.Command{
+		Use:   "clone",
+		Short: "Clone a running cluster into the directories of a new one",
+		Long: `
+Clone copies the data of every group of a running cluster, as of a single timestamp picked by its
+Zero, into the p directories of a new cluster, along with the WAL of the Zero of the new cluster.
+The new cluster gets its own id and Raft IDs, and one Alpha per group at the given addresses. More
+replicas can join it once it runs. Tablets don't move in the running cluster while it's cloned.`,
+		Args: cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			defer x.StartProfile(Clone.Conf).Stop()
+			if err := run(); err != nil {
+				fmt.Println(err)
+				os.Exit(1)
+			}
+		},
+	}
+	Clone.EnvPrefix = "DGRAPH_CLONE"
+
+	flag := Clone.Cmd.Flags()
+	flag.StringVarP(&opt.zero, "zero", "z", fmt.Sprintf("localhost:%d", x.PortZeroHTTP),
+		"HTTP address of the Zero leader of the cluster to clone")
+	flag.StringVarP(&opt.out, "out", "o", "clone", "Directory to write the new cluster to")
+	flag.StringSliceVar(&opt.alphas, "alphas", nil, "Comma separated addresses of the Alphas"+
+		" of the new cluster, one per group, in the order of the ids of the groups cloned")
+	flag.StringVar(&opt.zeroAddr, "zero_addr", fmt.Sprintf("localhost:%d", x.PortZeroGrpc),
+		"Address of the Zero of the new cluster")
+	flag.DurationVar(&opt.hold, "hold", time.Hour,
+		"How long tablets are kept from moving at the most, if the clone doesn't finish")
+	flag.StringVarP(&opt.token, "auth_token", "a", "",
+		"Admin token of the Zero, if it has one, for /clone and /cloneDone")
+	conn.RegisterInternalTLSFlags(flag)
+	Clone.Cmd.MarkFlagRequired("alphas")
+}
+
+// plan is the plan of the clone returned by Zero.
+type plan struct {
+	ReadTs uint64              `json:"read_ts"`
+	State  *pb.MembershipState `json:"state"`
+}
+
+func zeroRequest(path string, params string, out interface{}) error {
+	req, err := http.NewRequest("GET", fmt.Sprintf("http://%s/%s?%s", opt.zero, path, params), nil)
+	if err != nil {
+		return err
+	}
+	if len(opt.token) > 0 {
+		req.Header.Set(x.AuthTokenHeader, opt.token)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return x.Errorf("Zero replied to %s with %s: %s", path, resp.Status, body)
+	}
+	if out == nil {
+		return nil
+	}
+	return json.Unmarshal(body, out)
+}
+
+func run() error {
+	if err := conn.SetupInternalTLSFromConfig(Clone.Conf); err != nil {
+		return err
+	}
+	if _, err := os.Stat(opt.out); err == nil {
+		return x.Errorf("Output directory %s already exists", opt.out)
+	}
+
+	var p plan
+	if err := zeroRequest("clone", "hold="+opt.hold.String(), &p); err != nil {
+		return x.Wrapf(err, "while starting the clone")
+	}
+	defer func() {
+		if err := zeroRequest("cloneDone", fmt.Sprintf("readTs=%d", p.ReadTs), nil); err != nil {
+			fmt.Printf("While letting the tablets move again: %v\n", err)
+		}
+	}()
+	for i := range opt.alphas {
+		opt.alphas[i] = strings.TrimSpace(opt.alphas[i])
+	}
+	state, err := newState(p.State, p.ReadTs, opt.zeroAddr, opt.alphas)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Cloning %d groups at ts %d\n", len(state.Groups), p.ReadTs)
+
+	gids := sortedGroups(p.State)
+	errCh := make(chan error, len(gids))
+	var wg sync.WaitGroup
+	for i, gid := range gids {
+		wg.Add(1)
+		go func(i int, gid uint32) {
+			defer wg.Done()
+			dir := filepath.Join(opt.out, fmt.Sprint(i), "p")
+			if err := cloneGroup(p.State.Groups[gid], gid, p.ReadTs, dir); err != nil {
+				errCh <- x.Wrapf(err, "while cloning group %d", gid)
+			}
+		}(i, gid)
+	}
+	wg.Wait()
+	close(errCh)
+	err = <-errCh
+	if err == nil {
+		err = writeZeroState(filepath.Join(opt.out, "zero", "zw"), state)
+	}
+	if err != nil {
+		// Don't leave a partial clone behind.
+		os.RemoveAll(opt.out)
+		return err
+	}
+	fmt.Printf("\nCloned the cluster into %s. Start its Zero with:\n", opt.out)
+	fmt.Printf("  dgraph zero --idx %d --my %s -w %s\n", zeroId, opt.zeroAddr,
+		filepath.Join(opt.out, "zero", "zw"))
+	fmt.Printf("Then its Alphas with:\n")
+	for i := range gids {
+		id := uint64(i + 1)
+		fmt.Printf("  dgraph alpha --idx %d --my %s --zero %s -p %s\n", id,
+			state.Groups[uint32(i+1)].Members[id].Addr, opt.zeroAddr,
+			filepath.Join(opt.out, fmt.Sprint(i), "p"))
+	}
+	return nil
+}
+
+// cloneGroup streams the data of the group at readTs from its leader into a new p directory.
+// Only the leader streams its data, so the other members are tried in case the leader changed.
+func cloneGroup(group *pb.Group, gid uint32, readTs uint64, dir string) error {
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return err
+	}
+	bopt := badger.DefaultOptions
+	bopt.SyncWrites = false
+	bopt.Dir = dir
+	bopt.ValueDir = dir
+	db, err := badger.OpenManaged(bopt)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+	if err := x.WriteFormat(dir, x.PostingFormat); err != nil {
+		return err
+	}
+
+	var addrs []string
+	for _, m := range group.Members {
+		if m.Leader {
+			addrs = append([]string{m.Addr}, addrs...)
+		} else if !m.Learner {
+			addrs = append(addrs, m.Addr)
+		}
+	}
+	if len(addrs) == 0 {
+		return x.Errorf("No members found")
+	}
+	for _, addr := range addrs {
+		var count int
+		count, err = streamGroup(db, group.Tablets, gid, readTs, addr)
+		if err == nil {
+			fmt.Printf("Cloned %d keys of group %d from %s\n", count, gid, addr)
+			return nil
+		}
+		fmt.Printf("While cloning group %d from %s: %v\n", gid, addr, err)
+		if err := db.DropAll(); err != nil {
+			return err
+		}
+	}
+	return err
+}
+
+func streamGroup(db *badger.DB, tablets map[string]*pb.Tablet, gid uint32, readTs uint64,
+	addr string) (int, error) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	c, err := grpc.Dial(addr,
+		grpc.WithDefaultCallOptions(grpc.MaxCallRecvMsgSize(x.GrpcMaxSize)),
+		conn.DialOption())
+	if err != nil {
+		return 0, err
+	}
+	defer c.Close()
+
+	stream, err := pb.NewWorkerClient(c).StreamSnapshot(ctx)
+	if err != nil {
+		return 0, err
+	}
+	// The snapshot isn't for a member of the group, so it has no Raft ID.
+	req := &pb.Snapshot{Context: &pb.RaftContext{Group: gid}, ReadTs: readTs}
+	if err := stream.Send(req); err != nil {
+		return 0, err
+	}
+
+	count := 0
+	writer := x.NewTxnWriter(db)
+	writer.BlindWrite = true
+	for {
+		kvs, err := stream.Recv()
+		if err != nil {
+			return count, err
+		}
+		if kvs.Done {
+			break
+		}
+		keep := kvs.Kv[:0]
+		for _, kv := range kvs.Kv {
+			if keepKey(kv.Key, tablets) {
+				keep = append(keep, kv)
+			}
+		}
+		kvs.Kv = keep
+		if err := writer.Send(kvs); err != nil {
+			return count, err
+		}
+		count += len(kvs.Kv)
+	}
+	if err := writer.Flush(); err != nil {
+		return count, err
+	}
+	if err := stream.Send(&pb.Snapshot{Done: true}); err != nil {
+		return count, err
+	}
+	if err := stream.CloseSend(); err != nil {
+		return count, err
+	}
+	// Wait for the leader to get the acknowledgement, before cancelling the stream.
+	if _, err := stream.Recv(); err != io.EOF {
+		return count, err
+	}
+	return count, nil
+}
@@ -0,0 +1,132 @@
+/*
+ * Copyright 2018 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package clone
+
+import (
+	"sort"
+
+	"github.com/coreos/etcd/raft/raftpb"
+	"github.com/dgraph-io/badger"
+	"github.com/google/uuid"
+
+	"github.com/dgraph-io/dgraph/protos/pb"
+	"github.com/dgraph-io/dgraph/raftwal"
+	"github.com/dgraph-io/dgraph/x"
+)
+
+// zeroId is the Raft ID of the Zero of the new cluster.
+const zeroId = 1
+
+// sortedGroups returns the ids of the groups of state, in increasing order. The new cluster
+// gets the same number of groups, numbered from 1 in this order.
+func sortedGroups(state *pb.MembershipState) []uint32 {
+	var gids []uint32
+	for gid := range state.Groups {
+		gids = append(gids, gid)
+	}
+	sort.Slice(gids, func(i, j int) bool { return gids[i] < gids[j] })
+	return gids
+}
+
+// newState returns the state of the new cluster, cloned at readTs from state. Every group gets a
+// single Alpha, with the address at the same position in alphas, and Raft IDs counting from 1.
+// The new cluster starts leasing uids and timestamps after the ones already used.
+func newState(state *pb.MembershipState, readTs uint64, zeroAddr string,
+	alphas []string) (*pb.MembershipState, error) {
+	gids := sortedGroups(state)
+	if len(gids) != len(alphas) {
+		return nil, x.Errorf("The cluster has %d groups, but %d Alpha addresses were given",
+			len(gids), len(alphas))
+	}
+
+	ns := &pb.MembershipState{
+		Counter:    1,
+		Groups:     make(map[uint32]*pb.Group),
+		Zeros:      map[uint64]*pb.Member{zeroId: {Id: zeroId, Addr: zeroAddr, Leader: true}},
+		MaxLeaseId: state.MaxLeaseId,
+		MaxTxnTs:   state.MaxTxnTs,
+		MaxRaftId:  uint64(len(alphas)),
+		Cid:        uuid.New().String(),
+
+		StrictSchema: state.StrictSchema,
+		Namespaces:   state.Namespaces,
+	}
+	if ns.MaxTxnTs < readTs {
+		ns.MaxTxnTs = readTs
+	}
+	for i, gid := range gids {
+		newGid := uint32(i + 1)
+		id := uint64(i + 1)
+		group := &pb.Group{
+			Members: map[uint64]*pb.Member{id: {Id: id, GroupId: newGid, Addr: alphas[i]}},
+			Tablets: make(map[string]*pb.Tablet),
+		}
+		for pred, tablet := range state.Groups[gid].Tablets {
+			group.Tablets[pred] = &pb.Tablet{
+				GroupId:   newGid,
+				Predicate: tablet.Predicate,
+				Space:     tablet.Space,
+			}
+		}
+		ns.Groups[newGid] = group
+	}
+	return ns, nil
+}
+
+// keepKey tells whether a key streamed from a group belongs to one of the tablets it serves.
+// The Alphas can still hold data of tablets which moved away from their group.
+func keepKey(key []byte, tablets map[string]*pb.Tablet) bool {
+	pk := x.Parse(key)
+	if pk == nil {
+		return false
+	}
+	_, ok := tablets[pk.Attr]
+	return ok
+}
+
+// writeZeroState writes state to the WAL of the new Zero in dir, as the snapshot of a Raft group
+// made of that Zero only. The Zero then starts off with the state, as if it were restarted.
+func writeZeroState(dir string, state *pb.MembershipState) error {
+	data, err := state.Marshal()
+	if err != nil {
+		return err
+	}
+	opt := badger.LSMOnlyOptions
+	opt.SyncWrites = true
+	opt.Dir = dir
+	opt.ValueDir = dir
+	opt.ValueLogFileSize = 64 << 20
+	kv, err := badger.Open(opt)
+	if err != nil {
+		return x.Wrapf(err, "while opening the WAL of Zero at %s", dir)
+	}
+	defer kv.Close()
+	if err := x.WriteFormat(dir, x.WALFormat); err != nil {
+		return err
+	}
+
+	store := raftwal.Init(kv, zeroId, 0)
+	snap := raftpb.Snapshot{
+		Data: data,
+		Metadata: raftpb.SnapshotMetadata{
+			Index:     1,
+			Term:      1,
+			ConfState: raftpb.ConfState{Nodes: []uint64{zeroId}},
+		},
+	}
+	return store.Save(raftpb.HardState{Term: 1, Commit: 1}, nil, snap)
+}
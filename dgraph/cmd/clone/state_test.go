@@ -0,0 +1,79 @@
+/*
+ * Copyright 2018 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package clone
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dgraph-io/dgraph/protos/pb"
+	"github.com/dgraph-io/dgraph/x"
+)
+
+func TestNewState(t *testing.T) {
+	state := &pb.MembershipState{
+		Groups: map[uint32]*pb.Group{
+			5: {
+				Members: map[uint64]*pb.Member{7: {Id: 7, GroupId: 5, Addr: "a:7080"}},
+				Tablets: map[string]*pb.Tablet{"name": {GroupId: 5, Predicate: "name", Space: 10}},
+			},
+			2: {
+				Members: map[uint64]*pb.Member{
+					3: {Id: 3, GroupId: 2, Addr: "b:7080", Leader: true},
+					4: {Id: 4, GroupId: 2, Addr: "c:7080"},
+				},
+				Tablets: map[string]*pb.Tablet{
+					"age": {GroupId: 2, Predicate: "age", ReadOnly: true, MovingTo: 5},
+				},
+			},
+		},
+		Zeros:      map[uint64]*pb.Member{2: {Id: 2, Addr: "zero:5080"}},
+		MaxLeaseId: 1000,
+		MaxTxnTs:   50,
+		MaxRaftId:  7,
+		Cid:        "source",
+	}
+
+	_, err := newState(state, 40, "newzero:5080", []string{"x:7080"})
+	require.Error(t, err)
+
+	ns, err := newState(state, 60, "newzero:5080", []string{"x:7080", "y:7080"})
+	require.NoError(t, err)
+	require.Equal(t, uint64(1000), ns.MaxLeaseId)
+	require.Equal(t, uint64(60), ns.MaxTxnTs)
+	require.Equal(t, uint64(2), ns.MaxRaftId)
+	require.NotEqual(t, "source", ns.Cid)
+	require.Equal(t, "newzero:5080", ns.Zeros[zeroId].Addr)
+	require.Len(t, ns.Groups, 2)
+
+	// Groups are renumbered in the order of their ids.
+	g1 := ns.Groups[1]
+	require.Len(t, g1.Members, 1)
+	require.Equal(t, &pb.Member{Id: 1, GroupId: 1, Addr: "x:7080"}, g1.Members[1])
+	require.Equal(t, &pb.Tablet{GroupId: 1, Predicate: "age"}, g1.Tablets["age"])
+	g2 := ns.Groups[2]
+	require.Equal(t, &pb.Member{Id: 2, GroupId: 2, Addr: "y:7080"}, g2.Members[2])
+	require.Equal(t, &pb.Tablet{GroupId: 2, Predicate: "name", Space: 10}, g2.Tablets["name"])
+}
+
+func TestKeepKey(t *testing.T) {
+	tablets := map[string]*pb.Tablet{"name": {Predicate: "name"}}
+	require.True(t, keepKey(x.DataKey("name", 1), tablets))
+	require.True(t, keepKey(x.SchemaKey("name"), tablets))
+	require.False(t, keepKey(x.DataKey("age", 1), tablets))
+}
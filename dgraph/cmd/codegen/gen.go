@@ -0,0 +1,460 @@
+/*
+ * Copyright 2018 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package codegen
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/dgraph-io/dgraph/gql"
+	"github.com/dgraph-io/dgraph/protos/pb"
+	"github.com/dgraph-io/dgraph/types"
+	"github.com/dgraph-io/dgraph/x"
+)
+
+const header = "// Code generated by dgraph codegen. DO NOT EDIT.\n"
+
+// anyType is the type of the fields whose type can't be known from the schema, like the ones of
+// predicates missing from it, or of value variables.
+const anyType = types.TypeID(255)
+
+// node is the shape of the JSON objects returned for a block of a query, or for the nodes reached
+// by a uid predicate.
+type node struct {
+	name   string
+	fields []*field
+}
+
+type field struct {
+	name string // Name of the field of the generated type.
+	json string // Key of the field in the JSON result.
+	typ  types.TypeID
+	list bool
+	// child is set for the uid predicates the query has a block for.
+	child *node
+}
+
+// generator turns the blocks of a query into the types of their results, with the types of the
+// scalar predicates taken from the schema.
+type generator struct {
+	schema map[string]*pb.SchemaUpdate
+	// The types generated, in the order they're first used.
+	nodes []*node
+	names map[string]bool
+}
+
+func newGenerator(updates []*pb.SchemaUpdate) *generator {
+	g := &generator{
+		schema: make(map[string]*pb.SchemaUpdate, len(updates)),
+		names:  make(map[string]bool),
+	}
+	for _, su := range updates {
+		g.schema[su.Predicate] = su
+	}
+	return g
+}
+
+// generate returns the code of the types of the results of the query in lang, or of a type for
+// the nodes of the schema if there's no query.
+func generate(updates []*pb.SchemaUpdate, query, lang, pkg, name string) ([]byte, error) {
+	if lang != "go" && lang != "ts" {
+		return nil, x.Errorf("Invalid language: %q. Must be go or ts.", lang)
+	}
+	g := newGenerator(updates)
+	var root *node
+	if query == "" {
+		g.fromSchema()
+	} else {
+		var err error
+		if root, err = g.fromQuery(name, query); err != nil {
+			return nil, err
+		}
+	}
+	if lang == "ts" {
+		return tsCode(root, g.nodes), nil
+	}
+	return goCode(pkg, root, g.nodes)
+}
+
+// newNode returns an empty node whose name is based on name, and different from all the others.
+func (g *generator) newNode(name string) *node {
+	name = uniqueName(exportName(name), g.names)
+	n := &node{name: name}
+	g.nodes = append(g.nodes, n)
+	return n
+}
+
+// fromQuery returns the node of the whole result of the query, with a field per block.
+func (g *generator) fromQuery(name, query string) (*node, error) {
+	res, err := gql.Parse(gql.Request{Str: query})
+	if err != nil {
+		return nil, err
+	}
+	if len(res.Query) == 0 {
+		return nil, x.Errorf("Query must have at least one block")
+	}
+	g.names[exportName(name)+"Response"] = true
+	root := &node{name: exportName(name) + "Response"}
+	fields := make(map[string]bool)
+	for _, gq := range res.Query {
+		// Blocks only defining variables aren't part of the result.
+		if gq.Alias == "var" {
+			continue
+		}
+		if gq.Alias == "shortest" {
+			return nil, x.Errorf("Shortest path queries aren't supported")
+		}
+		n, err := g.fromBlock(gq.Alias, gq)
+		if err != nil {
+			return nil, x.Wrapf(err, "while generating block %q", gq.Alias)
+		}
+		root.fields = append(root.fields, &field{
+			name:  uniqueName(exportName(gq.Alias), fields),
+			json:  gq.Alias,
+			list:  true,
+			child: n,
+		})
+	}
+	return root, nil
+}
+
+func (g *generator) fromBlock(name string, gq *gql.GraphQuery) (*node, error) {
+	switch {
+	case gq.Recurse:
+		return nil, x.Errorf("@recurse isn't supported")
+	case gq.Normalize:
+		return nil, x.Errorf("@normalize isn't supported")
+	case gq.IsGroupby:
+		return nil, x.Errorf("@groupby isn't supported")
+	case gq.Facets != nil:
+		return nil, x.Errorf("@facets isn't supported")
+	}
+
+	n := g.newNode(name)
+	fields := make(map[string]bool)
+	add := func(f *field) {
+		// Selecting the same key twice only returns it once.
+		for _, prev := range n.fields {
+			if prev.json == f.json {
+				return
+			}
+		}
+		f.name = uniqueName(exportName(f.json), fields)
+		n.fields = append(n.fields, f)
+	}
+
+	for _, child := range gq.Children {
+		f, err := g.fromChild(n.name, child)
+		if err != nil {
+			return nil, err
+		}
+		if f != nil {
+			add(f)
+		}
+	}
+	if gq.UidCount {
+		alias := gq.UidCountAlias
+		if alias == "" {
+			alias = "count"
+		}
+		add(&field{json: alias, typ: types.IntID})
+	}
+	return n, nil
+}
+
+func (g *generator) fromChild(parent string, gq *gql.GraphQuery) (*field, error) {
+	if gq.Attr == "expand" {
+		return nil, x.Errorf("expand() isn't supported, list the predicates to get instead")
+	}
+	if gq.Facets != nil {
+		return nil, x.Errorf("@facets isn't supported")
+	}
+	if gq.IsInternal {
+		return &field{json: internalFieldName(gq), typ: anyType}, nil
+	}
+	if gq.Attr == "uid" {
+		key := gq.Attr
+		if gq.Alias != "" {
+			key = gq.Alias
+		}
+		return &field{json: key, typ: types.StringID}, nil
+	}
+
+	key := gq.Attr
+	if gq.Alias != "" {
+		key = gq.Alias
+	}
+	if gq.IsCount {
+		if gq.Alias == "" {
+			key = fmt.Sprintf("count(%s)", gq.Attr)
+		}
+		return &field{json: key, typ: types.IntID}, nil
+	}
+
+	su := g.schema[gq.Attr]
+	if len(gq.Children) > 0 || gq.UidCount {
+		if su != nil && types.TypeID(su.ValueType) != types.UidID {
+			return nil, x.Errorf("Predicate %q isn't of type uid, it can't have children",
+				gq.Attr)
+		}
+		n, err := g.fromBlock(parent+" "+key, gq)
+		if err != nil {
+			return nil, err
+		}
+		return &field{json: key, list: true, child: n}, nil
+	}
+	if su == nil {
+		return &field{json: key, typ: anyType}, nil
+	}
+
+	typ := types.TypeID(su.ValueType)
+	if typ == types.UidID {
+		return nil, x.Errorf("Predicate %q is of type uid, it needs children to be returned",
+			gq.Attr)
+	}
+	if gq.Alias == "" && len(gq.Langs) > 0 {
+		key += "@" + strings.Join(gq.Langs, ":")
+	}
+	// Values with a language are returned on their own, even for lists.
+	return &field{json: key, typ: typ, list: su.List && len(gq.Langs) == 0}, nil
+}
+
+// internalFieldName returns the key of the values of variables, aggregations and math in the
+// results, as given to them by the query package.
+func internalFieldName(gq *gql.GraphQuery) string {
+	if gq.Alias != "" {
+		return gq.Alias
+	}
+	name := fmt.Sprintf("val(%s)", gq.Var)
+	if len(gq.NeedsVar) > 0 {
+		name = fmt.Sprintf("val(%s)", gq.NeedsVar[0].Name)
+		switch gq.Attr {
+		case "min", "max", "sum", "avg":
+			name = fmt.Sprintf("%s(%s)", gq.Attr, name)
+		}
+	}
+	return name
+}
+
+// fromSchema returns a single node with a field for every predicate of the schema, which can hold
+// the results of any query going over them.
+func (g *generator) fromSchema() *node {
+	n := g.newNode("Node")
+	fields := map[string]bool{"Uid": true}
+	n.fields = append(n.fields, &field{name: "Uid", json: "uid", typ: types.StringID})
+
+	preds := make([]string, 0, len(g.schema))
+	for pred := range g.schema {
+		preds = append(preds, pred)
+	}
+	sort.Strings(preds)
+	for _, pred := range preds {
+		su := g.schema[pred]
+		f := &field{name: uniqueName(exportName(pred), fields), json: pred,
+			typ: types.TypeID(su.ValueType), list: su.List}
+		if f.typ == types.UidID {
+			f.child, f.list = n, true
+		}
+		n.fields = append(n.fields, f)
+	}
+	return n
+}
+
+// exportName turns s into an exported identifier, by capitalizing every word of it.
+func exportName(s string) string {
+	var b strings.Builder
+	upper := true
+	for _, r := range s {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			upper = true
+			continue
+		}
+		if b.Len() == 0 && unicode.IsDigit(r) {
+			b.WriteByte('X')
+		}
+		if upper {
+			r = unicode.ToUpper(r)
+			upper = false
+		}
+		b.WriteRune(r)
+	}
+	if b.Len() == 0 {
+		return "X"
+	}
+	return b.String()
+}
+
+// uniqueName returns name, with a number appended to it if it's already in names, and adds it to
+// names.
+func uniqueName(name string, names map[string]bool) string {
+	unique := name
+	for i := 2; names[unique]; i++ {
+		unique = fmt.Sprintf("%s%d", name, i)
+	}
+	names[unique] = true
+	return unique
+}
+
+// isIdent returns whether s can be used as is as the name of a field in TypeScript.
+func isIdent(s string) bool {
+	for i, r := range s {
+		if !unicode.IsLetter(r) && r != '_' && r != '$' && (i == 0 || !unicode.IsDigit(r)) {
+			return false
+		}
+	}
+	return len(s) > 0
+}
+
+func goType(f *field) string {
+	var t string
+	switch {
+	case f.child != nil:
+		t = f.child.name
+	case f.typ == types.IntID:
+		t = "int64"
+	case f.typ == types.FloatID:
+		t = "float64"
+	case f.typ == types.BoolID:
+		t = "bool"
+	case f.typ == types.DateTimeID:
+		t = "time.Time"
+	case f.typ == types.GeoID:
+		t = "json.RawMessage"
+	case f.typ == anyType:
+		t = "interface{}"
+	default:
+		t = "string"
+	}
+	if f.list {
+		return "[]" + t
+	}
+	return t
+}
+
+func tsType(f *field) string {
+	var t string
+	switch {
+	case f.child != nil:
+		t = f.child.name
+	case f.typ == types.IntID, f.typ == types.FloatID:
+		t = "number"
+	case f.typ == types.BoolID:
+		t = "boolean"
+	case f.typ == types.GeoID, f.typ == anyType:
+		t = "any"
+	default:
+		// Dates are returned as RFC 3339 strings.
+		t = "string"
+	}
+	if f.list {
+		return t + "[]"
+	}
+	return t
+}
+
+// goCode returns the Go declarations of the types of the nodes, the root first, along with a
+// function to unmarshal the root.
+func goCode(pkg string, root *node, nodes []*node) ([]byte, error) {
+	var body bytes.Buffer
+	var usesTime bool
+	write := func(n *node) {
+		fmt.Fprintf(&body, "type %s struct {\n", n.name)
+		for _, f := range n.fields {
+			t := goType(f)
+			usesTime = usesTime || strings.HasSuffix(t, "time.Time")
+			fmt.Fprintf(&body, "\t%s %s `json:\"%s,omitempty\"`\n", f.name, t, f.json)
+		}
+		body.WriteString("}\n\n")
+	}
+
+	if root != nil {
+		fmt.Fprintf(&body, "// %s is the result of the query.\n", root.name)
+		write(root)
+		name := strings.TrimSuffix(root.name, "Response")
+		fmt.Fprintf(&body, "// Unmarshal%s unmarshals the JSON result of the query.\n", name)
+		fmt.Fprintf(&body, "func Unmarshal%s(b []byte) (*%s, error) {\n", name, root.name)
+		fmt.Fprintf(&body, "\tvar res %s\n", root.name)
+		body.WriteString("\tif err := json.Unmarshal(b, &res); err != nil {\n" +
+			"\t\treturn nil, err\n\t}\n\treturn &res, nil\n}\n\n")
+	}
+	for _, n := range nodes {
+		write(n)
+	}
+	if root == nil {
+		for _, n := range nodes {
+			fmt.Fprintf(&body, "// Unmarshal%s unmarshals the nodes of the given block of the "+
+				"JSON result of a query.\n", n.name)
+			fmt.Fprintf(&body, "func Unmarshal%s(b []byte, block string) ([]%s, error) {\n",
+				n.name, n.name)
+			body.WriteString("\tvar res map[string]json.RawMessage\n" +
+				"\tif err := json.Unmarshal(b, &res); err != nil {\n\t\treturn nil, err\n\t}\n")
+			fmt.Fprintf(&body, "\tvar nodes []%s\n", n.name)
+			body.WriteString("\tif raw, ok := res[block]; ok {\n" +
+				"\t\tif err := json.Unmarshal(raw, &nodes); err != nil {\n" +
+				"\t\t\treturn nil, err\n\t\t}\n\t}\n\treturn nodes, nil\n}\n\n")
+		}
+	}
+
+	var out bytes.Buffer
+	fmt.Fprintf(&out, "%s\npackage %s\n\nimport (\n\t\"encoding/json\"\n", header, pkg)
+	if usesTime {
+		out.WriteString("\t\"time\"\n")
+	}
+	out.WriteString(")\n\n")
+	out.Write(body.Bytes())
+	return format.Source(out.Bytes())
+}
+
+// tsCode returns the TypeScript interfaces of the nodes, the root first, along with a function to
+// parse the root.
+func tsCode(root *node, nodes []*node) []byte {
+	var out bytes.Buffer
+	out.WriteString(header)
+	write := func(n *node) {
+		fmt.Fprintf(&out, "\nexport interface %s {\n", n.name)
+		for _, f := range n.fields {
+			key := f.json
+			if !isIdent(key) {
+				key = fmt.Sprintf("%q", key)
+			}
+			fmt.Fprintf(&out, "  %s?: %s;\n", key, tsType(f))
+		}
+		out.WriteString("}\n")
+	}
+
+	if root != nil {
+		write(root)
+		name := strings.TrimSuffix(root.name, "Response")
+		fmt.Fprintf(&out, "\nexport function parse%s(json: string): %s {\n", name, root.name)
+		fmt.Fprintf(&out, "  return JSON.parse(json) as %s;\n}\n", root.name)
+	}
+	for _, n := range nodes {
+		write(n)
+	}
+	if root == nil {
+		for _, n := range nodes {
+			fmt.Fprintf(&out, "\nexport function parse%s(json: string, block: string): %s[] {\n",
+				n.name, n.name)
+			fmt.Fprintf(&out, "  return JSON.parse(json)[block] || [];\n}\n")
+		}
+	}
+	return out.Bytes()
+}
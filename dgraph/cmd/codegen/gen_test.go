@@ -0,0 +1,168 @@
+/*
+ * Copyright 2018 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package codegen
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dgraph-io/dgraph/schema"
+)
+
+const testSchema = `
+name: string @index(term) @lang .
+age: int .
+score: float .
+alive: bool .
+dob: datetime .
+friend: uid @count .
+nickname: [string] .
+`
+
+const testQuery = `{
+	me(func: uid(1)) {
+		uid
+		name
+		name@fr
+		years: age
+		dob
+		nickname
+		count(friend)
+		friend {
+			name
+			alive
+			count(uid)
+		}
+	}
+	var(func: uid(1)) {
+		s as score
+	}
+	scores(func: uid(s)) {
+		val(s)
+	}
+}`
+
+func gen(t *testing.T, query, lang string) string {
+	updates, err := schema.Parse(testSchema)
+	require.NoError(t, err)
+	code, err := generate(updates, query, lang, "model", "Friends")
+	require.NoError(t, err)
+	return string(code)
+}
+
+func fields(code, typ string) []string {
+	start := strings.Index(code, typ+" {\n")
+	if start < 0 {
+		start = strings.Index(code, typ+" struct {\n")
+	}
+	if start < 0 {
+		return nil
+	}
+	body := code[start:]
+	body = body[strings.Index(body, "\n")+1 : strings.Index(body, "}")]
+	var res []string
+	for _, line := range strings.Split(strings.TrimSpace(body), "\n") {
+		res = append(res, strings.Join(strings.Fields(line), " "))
+	}
+	return res
+}
+
+func TestGenerateGo(t *testing.T) {
+	code := gen(t, testQuery, "go")
+	require.True(t, strings.HasPrefix(code, header))
+	require.Contains(t, code, "package model\n")
+	require.Contains(t, code, `"time"`)
+	require.Contains(t, code, "func UnmarshalFriends(b []byte) (*FriendsResponse, error) {")
+
+	require.Equal(t, []string{
+		"Me []Me `json:\"me,omitempty\"`",
+		"Scores []Scores `json:\"scores,omitempty\"`",
+	}, fields(code, "type FriendsResponse"))
+	require.Equal(t, []string{
+		"Uid string `json:\"uid,omitempty\"`",
+		"Name string `json:\"name,omitempty\"`",
+		"NameFr string `json:\"name@fr,omitempty\"`",
+		"Years int64 `json:\"years,omitempty\"`",
+		"Dob time.Time `json:\"dob,omitempty\"`",
+		"Nickname []string `json:\"nickname,omitempty\"`",
+		"CountFriend int64 `json:\"count(friend),omitempty\"`",
+		"Friend []MeFriend `json:\"friend,omitempty\"`",
+	}, fields(code, "type Me"))
+	require.Equal(t, []string{
+		"Name string `json:\"name,omitempty\"`",
+		"Alive bool `json:\"alive,omitempty\"`",
+		"Count int64 `json:\"count,omitempty\"`",
+	}, fields(code, "type MeFriend"))
+	// Values of variables can be of any type.
+	require.Contains(t, code, "ValS interface{} `json:\"val(s),omitempty\"`")
+}
+
+func TestGenerateTS(t *testing.T) {
+	code := gen(t, testQuery, "ts")
+	require.Contains(t, code, "export function parseFriends(json: string): FriendsResponse {")
+	require.Equal(t, []string{
+		"name?: string;",
+		"alive?: boolean;",
+		"count?: number;",
+	}, fields(code, "export interface MeFriend"))
+	require.Contains(t, fields(code, "export interface Me"), `"name@fr"?: string;`)
+}
+
+func TestGenerateSchema(t *testing.T) {
+	code := gen(t, "", "go")
+	require.Contains(t, code,
+		"func UnmarshalNode(b []byte, block string) ([]Node, error) {")
+	require.Equal(t, []string{
+		"Uid string `json:\"uid,omitempty\"`",
+		"Age int64 `json:\"age,omitempty\"`",
+		"Alive bool `json:\"alive,omitempty\"`",
+		"Dob time.Time `json:\"dob,omitempty\"`",
+		"Friend []Node `json:\"friend,omitempty\"`",
+		"Name string `json:\"name,omitempty\"`",
+		"Nickname []string `json:\"nickname,omitempty\"`",
+		"Score float64 `json:\"score,omitempty\"`",
+	}, fields(code, "type Node"))
+}
+
+func TestGenerateErrors(t *testing.T) {
+	updates, err := schema.Parse(testSchema)
+	require.NoError(t, err)
+	for _, q := range []string{
+		`{ me(func: uid(1)) { friend } }`,
+		`{ me(func: uid(1)) { name { age } } }`,
+		`{ me(func: uid(1)) { expand(_all_) } }`,
+		`{ me(func: uid(1)) @recurse { friend } }`,
+		`{ me(func: uid(1)) { friend @facets { name } } }`,
+	} {
+		_, err := generate(updates, q, "go", "model", "Query")
+		require.Error(t, err, q)
+	}
+	_, err = generate(updates, "", "java", "model", "Query")
+	require.Error(t, err)
+}
+
+func TestExportName(t *testing.T) {
+	require.Equal(t, "DgraphType", exportName("dgraph.type"))
+	require.Equal(t, "FriendCount", exportName("friendCount"))
+	require.Equal(t, "MinValX", exportName("min(val(x))"))
+	require.Equal(t, "X2nd", exportName("2nd"))
+	names := map[string]bool{}
+	require.Equal(t, "Name", uniqueName("Name", names))
+	require.Equal(t, "Name2", uniqueName("Name", names))
+}
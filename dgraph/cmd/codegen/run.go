@@ -0,0 +1,93 @@
+/*
+ * Copyright 2018 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package codegen
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+
+	"github.com/dgraph-io/dgraph/schema"
+	"github.com/dgraph-io/dgraph/x"
+	"github.com/spf13/cobra"
+)
+
+var Codegen x.SubCommand
+
+func init() {
+	Codegen.Cmd = &cobra.Command{
+		Use:   "codegen",
+		Short: "Generate types for the results of queries",
+		Long: `
+Codegen generates Go structs, or TypeScript interfaces, matching the JSON results of a query,
+with the types of the predicates taken from the schema, along with a function to unmarshal them.
+Without a query, it generates a single type with a field for every predicate of the schema.`,
+		Args: cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			defer x.StartProfile(Codegen.Conf).Stop()
+			if err := run(); err != nil {
+				fmt.Println(err)
+				os.Exit(1)
+			}
+		},
+	}
+
+	Codegen.EnvPrefix = "DGRAPH_CODEGEN"
+
+	flag := Codegen.Cmd.Flags()
+	flag.StringP("schema", "s", "", "Location of the schema file")
+	flag.StringP("query", "q", "", "Location of the file of the query to generate types for")
+	flag.StringP("lang", "l", "go", "Language to generate, one of [go, ts]")
+	flag.String("package", "dgraph", "Package of the generated Go code")
+	flag.String("name", "Query",
+		"Name of the query, from which the names of the generated code derive")
+	flag.StringP("out", "o", "", "Location of the output file. Defaults to stdout.")
+}
+
+func run() error {
+	conf := Codegen.Conf
+	if conf.GetString("schema") == "" {
+		return x.Errorf("No schema file. Pass --schema")
+	}
+	b, err := ioutil.ReadFile(conf.GetString("schema"))
+	if err != nil {
+		return err
+	}
+	updates, err := schema.Parse(string(b))
+	if err != nil {
+		return x.Wrapf(err, "while parsing schema")
+	}
+	var query string
+	if file := conf.GetString("query"); file != "" {
+		q, err := ioutil.ReadFile(file)
+		if err != nil {
+			return err
+		}
+		query = string(q)
+	}
+	code, err := generate(updates, query, conf.GetString("lang"), conf.GetString("package"),
+		conf.GetString("name"))
+	if err != nil {
+		return err
+	}
+	out := conf.GetString("out")
+	if out == "" {
+		_, err = os.Stdout.Write(code)
+		return err
+	}
+	return ioutil.WriteFile(out, code, 0644)
+}
@@ -0,0 +1,204 @@
+/*
+ * Copyright 2018 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package debug
+
+import (
+	"bufio"
+	"encoding/binary"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/dgraph-io/badger"
+
+	"github.com/dgraph-io/dgraph/posting"
+	"github.com/dgraph-io/dgraph/raftwal"
+	"github.com/dgraph-io/dgraph/worker"
+	"github.com/dgraph-io/dgraph/x"
+)
+
+// Every entry of the value log of Badger has a header, its key and value, and the CRC32
+// (Castagnoli) of all three. Badger only checks the CRCs when it replays the log at startup, so
+// the entries corrupted after that only show up as bad values, if at all.
+const vlogHeaderSize = 18
+
+var castagnoli = crc32.MakeTable(crc32.Castagnoli)
+
+// vlogCheck is the result of checking the CRCs of a value log file.
+type vlogCheck struct {
+	entries int
+	// corrupt is the offset of the first entry whose CRC doesn't match, or -1 if none. The
+	// entries after it can't be told apart.
+	corrupt int64
+	// truncated is the offset of the last entry if it's incomplete, or -1 if it's not. That's
+	// expected of the last file after a crash, which Badger truncates on startup.
+	truncated int64
+}
+
+// checkVlog checks the CRC of every entry of the value log r.
+func checkVlog(r io.Reader) (vlogCheck, error) {
+	res := vlogCheck{corrupt: -1, truncated: -1}
+	br := bufio.NewReader(r)
+	var offset int64
+	var hbuf [vlogHeaderSize]byte
+	var buf []byte
+	for {
+		n, err := io.ReadFull(br, hbuf[:])
+		switch {
+		case err == io.EOF:
+			return res, nil
+		case err == io.ErrUnexpectedEOF:
+			res.truncated = offset
+			return res, nil
+		case err != nil:
+			return res, err
+		}
+		klen := binary.BigEndian.Uint32(hbuf[0:4])
+		vlen := binary.BigEndian.Uint32(hbuf[4:8])
+		if klen == 0 && vlen == 0 && allZero(hbuf[8:]) {
+			// The rest of a file preallocated by Badger.
+			return res, nil
+		}
+		if klen > 1<<16 {
+			res.corrupt = offset
+			return res, nil
+		}
+		size := int(klen) + int(vlen) + crc32.Size
+		if cap(buf) < size {
+			buf = make([]byte, size)
+		}
+		buf = buf[:size]
+		m, err := io.ReadFull(br, buf)
+		switch {
+		case err == io.EOF || err == io.ErrUnexpectedEOF:
+			res.truncated = offset
+			return res, nil
+		case err != nil:
+			return res, err
+		}
+		crc := crc32.Checksum(hbuf[:], castagnoli)
+		crc = crc32.Update(crc, castagnoli, buf[:size-crc32.Size])
+		if crc != binary.BigEndian.Uint32(buf[size-crc32.Size:]) {
+			res.corrupt = offset
+			return res, nil
+		}
+		res.entries++
+		offset += int64(n + m)
+	}
+}
+
+func allZero(b []byte) bool {
+	for _, c := range b {
+		if c != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// checkVlogs checks the CRCs of the value log files in dir. It returns false if any is corrupt.
+func checkVlogs(dir string) bool {
+	files, err := filepath.Glob(filepath.Join(dir, "*.vlog"))
+	x.Check(err)
+	sort.Strings(files)
+	ok := true
+	for i, file := range files {
+		f, err := os.Open(file)
+		x.Check(err)
+		res, err := checkVlog(f)
+		f.Close()
+		x.Checkf(err, "While reading %s", file)
+		switch {
+		case res.corrupt >= 0 && i == len(files)-1:
+			// Badger truncates the last file there on startup, as if the write was torn.
+			fmt.Printf("%s: CORRUPT entry at offset %d, after %d valid entries. Unless the"+
+				" write was torn by a crash, the entries after it will be lost.\n", file,
+				res.corrupt, res.entries)
+			ok = false
+		case res.corrupt >= 0:
+			fmt.Printf("%s: CORRUPT entry at offset %d, after %d valid entries\n", file,
+				res.corrupt, res.entries)
+			ok = false
+		case res.truncated >= 0 && i < len(files)-1:
+			fmt.Printf("%s: CORRUPT, truncated at offset %d, after %d valid entries\n", file,
+				res.truncated, res.entries)
+			ok = false
+		case res.truncated >= 0:
+			fmt.Printf("%s: %d valid entries, incomplete entry at offset %d\n", file,
+				res.entries, res.truncated)
+		default:
+			fmt.Printf("%s: %d valid entries\n", file, res.entries)
+		}
+	}
+	return ok
+}
+
+// checkPostings decodes every version of every key in db, printing those which can't be. It
+// returns false if there's any.
+func checkPostings(db *badger.DB) bool {
+	var bad int
+	keys := posting.CheckPostings(db, func(key []byte, version uint64, err error) bool {
+		fmt.Printf("CORRUPT key %x at version %d: %v\n", key, version, err)
+		bad++
+		return true
+	})
+	fmt.Printf("Checked %d keys, %d versions could not be decoded\n", keys, bad)
+	return bad == 0
+}
+
+// printChecksums prints the checksums of the tablets as of readTs, which can be compared with the
+// ones Alphas report to Zero in /state.
+func printChecksums(db *badger.DB, readTs uint64) {
+	sums, err := worker.TabletChecksums(db, readTs)
+	x.Check(err)
+	if readTs == math.MaxUint64 {
+		fmt.Println("Checksums of the tablets, at the latest ts:")
+	} else {
+		fmt.Printf("Checksums of the tablets at ts %d:\n", readTs)
+	}
+	for _, s := range sums {
+		fmt.Printf("  %s: digest %d keys %d\n", s.Predicate, s.Digest, s.Keys)
+	}
+}
+
+// checkDir checks the value log of dir, and that the keys of db can be decoded: postings, or a
+// WAL with wal.
+func checkDir(dir string, db *badger.DB, wal bool) {
+	ok := checkVlogs(dir)
+	if wal {
+		if err := raftwal.Check(db); err != nil {
+			fmt.Printf("CORRUPT WAL: %v\n", err)
+			ok = false
+		}
+	} else {
+		ok = checkPostings(db) && ok
+		readTs := opt.readTs
+		if readTs == 0 {
+			readTs = math.MaxUint64
+		}
+		printChecksums(db, readTs)
+	}
+	if !ok {
+		fmt.Println("Found corruption.")
+		os.Exit(1)
+	}
+	fmt.Println("No corruption found.")
+}
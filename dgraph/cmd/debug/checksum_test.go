@@ -0,0 +1,71 @@
+/*
+ * Copyright 2018 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package debug
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/dgraph-io/badger"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckVlog(t *testing.T) {
+	dir, err := ioutil.TempDir("", "vlog")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	bopts := badger.DefaultOptions
+	bopts.Dir = dir
+	bopts.ValueDir = dir
+	db, err := badger.Open(bopts)
+	require.NoError(t, err)
+	for i := 0; i < 10; i++ {
+		require.NoError(t, db.Update(func(txn *badger.Txn) error {
+			return txn.Set([]byte(fmt.Sprintf("key%d", i)), bytes.Repeat([]byte("v"), 100))
+		}))
+	}
+	require.NoError(t, db.Close())
+
+	files, err := filepath.Glob(filepath.Join(dir, "*.vlog"))
+	require.NoError(t, err)
+	require.Len(t, files, 1)
+	data, err := ioutil.ReadFile(files[0])
+	require.NoError(t, err)
+
+	res, err := checkVlog(bytes.NewReader(data))
+	require.NoError(t, err)
+	// Every transaction has an entry marking its end.
+	require.Equal(t, 20, res.entries)
+	require.Equal(t, int64(-1), res.corrupt)
+	require.Equal(t, int64(-1), res.truncated)
+
+	res, err = checkVlog(bytes.NewReader(data[:len(data)-10]))
+	require.NoError(t, err)
+	require.Equal(t, 19, res.entries)
+	require.True(t, res.truncated > 0)
+
+	data[len(data)/2] ^= 0xff
+	res, err = checkVlog(bytes.NewReader(data))
+	require.NoError(t, err)
+	require.True(t, res.corrupt > 0)
+	require.True(t, res.entries < 20)
+}
@@ -46,6 +46,11 @@ type flagOptions struct {
 	predicate  string
 	readOnly   bool
 	pdir       string
+	wdir       string
+	zwdir      string
+	uid        string
+	checksum   bool
+	readTs     uint64
 	itemMeta   bool
 	jepsen     bool
 }
@@ -68,6 +73,19 @@ func init() {
 	flag.StringVarP(&opt.keyLookup, "lookup", "l", "", "Hex of key to lookup.")
 	flag.BoolVarP(&opt.keyHistory, "history", "y", false, "Show all versions of a key.")
 	flag.StringVarP(&opt.pdir, "postings", "p", "", "Directory where posting lists are stored.")
+	flag.StringVarP(&opt.wdir, "wal", "w", "",
+		"Directory of the Raft WAL of an Alpha, to print its entries after the snapshot.")
+	flag.StringVar(&opt.zwdir, "zero_wal", "",
+		"Directory of the Raft WAL of a Zero, to print its entries after the snapshot.")
+	flag.StringVar(&opt.uid, "uid", "",
+		"Uid, like 0x1f, to print the posting lists of in --pred, along with the index keys"+
+			" which reference it.")
+	flag.BoolVar(&opt.checksum, "checksum", false,
+		"Check the CRCs of the value log, and that every key can be decoded, in --postings,"+
+			" --wal or --zero_wal. For --postings, print the checksums of the tablets too, like"+
+			" the ones in /state of Zero.")
+	flag.Uint64Var(&opt.readTs, "read_ts", 0,
+		"Timestamp to compute the checksums of the tablets at. Zero means the latest.")
 }
 
 func toInt(o *pb.Posting) int {
@@ -468,7 +486,117 @@ func printKeys(db *badger.DB) {
 	fmt.Printf("Found %d keys\n", loop)
 }
 
+// printList prints the postings of the list at key, if there are any, along with title.
+func printList(txn *badger.Txn, title string, key []byte) {
+	iopts := badger.DefaultIteratorOptions
+	iopts.AllVersions = true
+	itr := txn.NewIterator(iopts)
+	defer itr.Close()
+
+	itr.Seek(key)
+	if !itr.Valid() || !bytes.Equal(itr.Item().Key(), key) {
+		fmt.Printf("%s: none\n", title)
+		return
+	}
+	pl, err := posting.ReadPostingList(itr.Item().KeyCopy(nil), itr)
+	if err != nil {
+		log.Fatal(err)
+	}
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "%s: key %x\n", title, key)
+	fmt.Fprintf(&buf, " Length: %d\n", pl.Length(math.MaxUint64, 0))
+	err = pl.Iterate(math.MaxUint64, 0, func(o *pb.Posting) error {
+		appendPosting(&buf, o)
+		return nil
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+	fmt.Println(buf.String())
+}
+
+// showUid prints the value or edges of the uid in the predicate, the edges pointing to it, and
+// the index, count and reverse keys whose lists have it.
+func showUid(db *badger.DB) {
+	x.AssertTruef(len(opt.predicate) > 0, "--uid needs --pred.")
+	uid, err := strconv.ParseUint(opt.uid, 0, 64)
+	if err == nil && uid == 0 {
+		err = x.Errorf("uids start at 1")
+	}
+	if err != nil {
+		log.Fatalf("Invalid uid %q: %v", opt.uid, err)
+	}
+	txn := db.NewTransactionAt(math.MaxUint64, false)
+	defer txn.Discard()
+
+	printList(txn, fmt.Sprintf("Data of %#x in %s", uid, opt.predicate),
+		x.DataKey(opt.predicate, uid))
+	printList(txn, fmt.Sprintf("Reverse edges to %#x in %s", uid, opt.predicate),
+		x.ReverseKey(opt.predicate, uid))
+
+	iopts := badger.DefaultIteratorOptions
+	iopts.AllVersions = true
+	itr := txn.NewIterator(iopts)
+	defer itr.Close()
+
+	fmt.Printf("Keys of %s referencing %#x:\n", opt.predicate, uid)
+	prefix := x.PredicatePrefix(opt.predicate)
+	var found int
+	for itr.Seek(prefix); itr.ValidForPrefix(prefix); {
+		key := itr.Item().KeyCopy(nil)
+		pk := x.Parse(key)
+		if pk == nil || pk.IsData() || pk.IsSchema() {
+			itr.Next()
+			continue
+		}
+		pl, err := posting.ReadPostingList(key, itr)
+		if err != nil {
+			log.Fatal(err)
+		}
+		// Skip the older versions of the key.
+		for itr.Valid() && bytes.Equal(itr.Item().Key(), key) {
+			itr.Next()
+		}
+		var has bool
+		err = pl.Iterate(math.MaxUint64, uid-1, func(p *pb.Posting) error {
+			has = p.Uid == uid
+			return posting.ErrStopIteration
+		})
+		if err != nil {
+			log.Fatal(err)
+		}
+		if !has {
+			continue
+		}
+		switch {
+		case pk.IsIndex():
+			fmt.Printf("  {i} term: [%d] %q key: %x\n", pk.Term[0], pk.Term[1:], key)
+		case pk.IsCount():
+			fmt.Printf("  {c} count: %d key: %x\n", pk.Count, key)
+		case pk.IsReverse():
+			fmt.Printf("  {r} uid: %#x key: %x\n", pk.Uid, key)
+		}
+		found++
+	}
+	fmt.Printf("Found %d keys\n", found)
+}
+
 func run() {
+	if dir := opt.wdir + opt.zwdir; len(dir) > 0 {
+		x.AssertTruef(len(opt.wdir) == 0 || len(opt.zwdir) == 0,
+			"Only one of --wal and --zero_wal can be specified.")
+		fmt.Printf("Opening WAL: %s\n", dir)
+		db, err := openWAL(dir)
+		x.Check(err)
+		defer db.Close()
+		if opt.checksum {
+			checkDir(dir, db, true)
+		} else {
+			printWAL(db, len(opt.zwdir) > 0)
+		}
+		return
+	}
+
 	bopts := badger.DefaultOptions
 	bopts.Dir = opt.pdir
 	bopts.ValueDir = opt.pdir
@@ -483,6 +611,10 @@ func run() {
 	defer db.Close()
 
 	switch {
+	case opt.checksum:
+		checkDir(opt.pdir, db, false)
+	case len(opt.uid) > 0:
+		showUid(db)
 	case len(opt.keyLookup) > 0:
 		lookup(db)
 	case opt.jepsen:
@@ -0,0 +1,281 @@
+/*
+ * Copyright 2018 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package debug
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/coreos/etcd/raft"
+	"github.com/coreos/etcd/raft/raftpb"
+	"github.com/dgraph-io/badger"
+
+	"github.com/dgraph-io/dgraph/protos/pb"
+	"github.com/dgraph-io/dgraph/raftwal"
+	"github.com/dgraph-io/dgraph/x"
+)
+
+func openWAL(dir string) (*badger.DB, error) {
+	bopts := badger.LSMOnlyOptions
+	bopts.Dir = dir
+	bopts.ValueDir = dir
+	bopts.ReadOnly = opt.readOnly
+	return badger.Open(bopts)
+}
+
+// printWAL prints the Raft logs of the WAL of an Alpha (w), or of a Zero (zw) with zero, along
+// with the entries which aren't part of their snapshot yet.
+func printWAL(db *badger.DB, zero bool) {
+	id, err := raftwal.RaftId(db)
+	x.Check(err)
+	fmt.Printf("Raft id: %#x\n", id)
+
+	logs, err := raftwal.Stored(db)
+	x.Check(err)
+	for _, w := range logs {
+		printLog(w, zero)
+	}
+}
+
+func printLog(w *raftwal.DiskStorage, zero bool) {
+	fmt.Printf("\nNode %#x of group %d\n", w.ID(), w.Group())
+	snap, err := w.Snapshot()
+	x.Check(err)
+	if raft.IsEmptySnap(snap) {
+		fmt.Println("Snapshot: none")
+	} else {
+		fmt.Printf("Snapshot: index %d term %d voters %x learners %x, %s\n",
+			snap.Metadata.Index, snap.Metadata.Term, snap.Metadata.ConfState.Nodes,
+			snap.Metadata.ConfState.Learners, describeSnapshot(snap.Data, zero))
+	}
+	hs, err := w.HardState()
+	x.Check(err)
+	fmt.Printf("HardState: term %d vote %#x commit %d\n", hs.Term, hs.Vote, hs.Commit)
+
+	first, err := w.FirstIndex()
+	if err != nil {
+		fmt.Println("No entries")
+		return
+	}
+	last, err := w.LastIndex()
+	x.Check(err)
+	if last < first {
+		fmt.Println("No entries after the snapshot")
+		return
+	}
+	fmt.Printf("Entries after the snapshot: %d to %d\n", first, last)
+	es, err := w.Entries(first, last+1, math.MaxUint64)
+	x.Check(err)
+	for _, e := range es {
+		state := "committed"
+		if e.Index > hs.Commit {
+			state = "uncommitted"
+		}
+		fmt.Printf("%d . %d [%s] %s\n", e.Index, e.Term, state, describeEntry(e, zero))
+	}
+}
+
+func describeSnapshot(data []byte, zero bool) string {
+	if zero {
+		var state pb.MembershipState
+		if err := state.Unmarshal(data); err != nil {
+			return fmt.Sprintf("undecodable membership state: %v", err)
+		}
+		return fmt.Sprintf("membership state at counter %d, max txn ts %d", state.Counter,
+			state.MaxTxnTs)
+	}
+	var snap pb.Snapshot
+	if err := snap.Unmarshal(data); err != nil {
+		return fmt.Sprintf("undecodable snapshot: %v", err)
+	}
+	return fmt.Sprintf("read ts %d", snap.ReadTs)
+}
+
+// describeEntry tells what a Raft entry proposed.
+func describeEntry(e raftpb.Entry, zero bool) string {
+	switch {
+	case e.Type == raftpb.EntryConfChange:
+		var cc raftpb.ConfChange
+		if err := cc.Unmarshal(e.Data); err != nil {
+			return fmt.Sprintf("Undecodable conf change: %v", err)
+		}
+		var rc pb.RaftContext
+		if err := rc.Unmarshal(cc.Context); err != nil || len(rc.Addr) == 0 {
+			return fmt.Sprintf("%s of node %#x", cc.Type, cc.NodeID)
+		}
+		return fmt.Sprintf("%s of node %#x at %s", cc.Type, cc.NodeID, rc.Addr)
+	case len(e.Data) == 0:
+		// Leaders append an empty entry when elected.
+		return "Empty"
+	case zero:
+		var p pb.ZeroProposal
+		if err := p.Unmarshal(e.Data); err != nil {
+			return fmt.Sprintf("Undecodable proposal: %v", err)
+		}
+		return describeZeroProposal(&p)
+	default:
+		var p pb.Proposal
+		if err := p.Unmarshal(e.Data); err != nil {
+			return fmt.Sprintf("Undecodable proposal: %v", err)
+		}
+		return describeProposal(&p)
+	}
+}
+
+func describeProposal(p *pb.Proposal) string {
+	var desc string
+	switch {
+	case len(p.Batch) > 0:
+		var parts []string
+		for _, b := range p.Batch {
+			parts = append(parts, describeProposal(b))
+		}
+		return fmt.Sprintf("Batch of %d: [%s]", len(p.Batch), strings.Join(parts, "; "))
+	case p.Mutations != nil:
+		desc = describeMutations(p.Mutations)
+	case len(p.Kv) > 0:
+		attrs := make(map[string]bool)
+		for _, kv := range p.Kv {
+			if pk := x.Parse(kv.Key); pk != nil {
+				attrs[pk.Attr] = true
+			}
+		}
+		desc = fmt.Sprintf("KVs: %d of predicates %s", len(p.Kv), joinSet(attrs))
+	case p.State != nil:
+		desc = fmt.Sprintf("Membership state at counter %d", p.State.Counter)
+	case len(p.CleanPredicate) > 0:
+		desc = fmt.Sprintf("Clean predicate %s", p.CleanPredicate)
+	case p.Delta != nil:
+		desc = fmt.Sprintf("Oracle delta: %d txns, max assigned %d", len(p.Delta.Txns),
+			p.Delta.MaxAssigned)
+	case p.Snapshot != nil:
+		desc = fmt.Sprintf("Snapshot at index %d, read ts %d", p.Snapshot.Index,
+			p.Snapshot.ReadTs)
+	case p.IndexBuilt != nil:
+		b := p.IndexBuilt
+		desc = fmt.Sprintf("Index built for %s, started at %d", b.Schema.GetPredicate(),
+			b.StartTs)
+		if b.Cancelled {
+			desc = fmt.Sprintf("Index build cancelled for %s: %s", b.Schema.GetPredicate(),
+				b.Reason)
+		}
+	case p.Rename != nil:
+		desc = fmt.Sprintf("Rename predicate %s to %s", p.Rename.From, p.Rename.To)
+	default:
+		desc = "Unknown"
+	}
+	if len(p.Key) > 0 {
+		desc += fmt.Sprintf(" (key %s)", p.Key)
+	}
+	return desc
+}
+
+func describeMutations(m *pb.Mutations) string {
+	switch {
+	case m.DropAll:
+		return "Drop all"
+	case len(m.DropNamespace) > 0:
+		return fmt.Sprintf("Drop namespace %s", m.DropNamespace)
+	case len(m.Schema) > 0:
+		attrs := make(map[string]bool)
+		for _, s := range m.Schema {
+			attrs[s.Predicate] = true
+		}
+		return fmt.Sprintf("Schema of %s", joinSet(attrs))
+	}
+	attrs := make(map[string]bool)
+	for _, e := range m.Edges {
+		attrs[e.Attr] = true
+	}
+	return fmt.Sprintf("Mutation at start ts %d: %d edges of %s", m.StartTs, len(m.Edges),
+		joinSet(attrs))
+}
+
+func describeZeroProposal(p *pb.ZeroProposal) string {
+	var parts []string
+	if len(p.SnapshotTs) > 0 {
+		var gids []int
+		for gid := range p.SnapshotTs {
+			gids = append(gids, int(gid))
+		}
+		sort.Ints(gids)
+		var tss []string
+		for _, gid := range gids {
+			tss = append(tss, fmt.Sprintf("%d:%d", gid, p.SnapshotTs[uint32(gid)]))
+		}
+		parts = append(parts, "Snapshot ts of groups "+strings.Join(tss, ","))
+	}
+	if m := p.Member; m != nil {
+		parts = append(parts, fmt.Sprintf("Member %#x of group %d at %s", m.Id, m.GroupId,
+			m.Addr))
+	}
+	if t := p.Tablet; t != nil {
+		parts = append(parts, fmt.Sprintf("Tablet %s of group %d", t.Predicate, t.GroupId))
+	}
+	if p.MaxLeaseId > 0 {
+		parts = append(parts, fmt.Sprintf("Max lease id %d", p.MaxLeaseId))
+	}
+	if p.MaxTxnTs > 0 {
+		parts = append(parts, fmt.Sprintf("Max txn ts %d", p.MaxTxnTs))
+	}
+	if p.MaxRaftId > 0 {
+		parts = append(parts, fmt.Sprintf("Max raft id %d", p.MaxRaftId))
+	}
+	if t := p.Txn; t != nil {
+		parts = append(parts, fmt.Sprintf("Txn started at %d, committed at %d", t.StartTs,
+			t.CommitTs))
+	}
+	if len(p.Cid) > 0 {
+		parts = append(parts, "Cluster id "+p.Cid)
+	}
+	if len(p.SchemaMode) > 0 {
+		parts = append(parts, "Schema mode "+p.SchemaMode)
+	}
+	if ns := p.Namespace; ns != nil {
+		parts = append(parts, "Namespace "+ns.Name)
+	}
+	if len(p.DropNamespace) > 0 {
+		parts = append(parts, "Drop namespace "+p.DropNamespace)
+	}
+	if r := p.Replication; r != nil {
+		parts = append(parts, fmt.Sprintf("Replication of %s applied at %d", r.Source,
+			r.AppliedTs))
+	}
+	if e := p.Event; e != nil {
+		parts = append(parts, fmt.Sprintf("Event %s: %s", e.Type, e.Message))
+	}
+	if len(parts) == 0 {
+		parts = append(parts, "Unknown")
+	}
+	desc := strings.Join(parts, ", ")
+	if len(p.Key) > 0 {
+		desc += fmt.Sprintf(" (key %s)", p.Key)
+	}
+	return desc
+}
+
+// joinSet returns the sorted elements of set, comma separated.
+func joinSet(set map[string]bool) string {
+	var elems []string
+	for e := range set {
+		elems = append(elems, e)
+	}
+	sort.Strings(elems)
+	return strings.Join(elems, ",")
+}
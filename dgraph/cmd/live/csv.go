@@ -0,0 +1,339 @@
+/*
+ * Copyright 2018 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package live
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/dgraph-io/dgo/protos/api"
+	"github.com/dgraph-io/dgraph/rdf"
+	"github.com/dgraph-io/dgraph/x"
+	"github.com/spf13/cobra"
+)
+
+var Import x.SubCommand
+var ImportCSV x.SubCommand
+
+func init() {
+	Import.Cmd = &cobra.Command{
+		Use:   "import",
+		Short: "Import data in formats other than RDF into a running Dgraph cluster",
+	}
+	Import.EnvPrefix = "DGRAPH_IMPORT"
+
+	ImportCSV.Cmd = &cobra.Command{
+		Use:   "csv",
+		Short: "Import CSV files, mapping columns to predicates",
+		Long: `
+Import one or more CSV files into a running Dgraph cluster. The mapping file
+describes, per CSV file, which column holds the external id of the row and which
+predicate and type each column maps to. Rows are converted into RDF N-Quads and
+sent via the live loader.
+`,
+		Run: func(cmd *cobra.Command, args []string) {
+			defer x.StartProfile(ImportCSV.Conf).Stop()
+			if err := runCSV(); err != nil {
+				os.Exit(1)
+			}
+		},
+	}
+	ImportCSV.EnvPrefix = "DGRAPH_IMPORT_CSV"
+	Import.Cmd.AddCommand(ImportCSV.Cmd)
+
+	flag := ImportCSV.Cmd.Flags()
+	flag.StringP("files", "f", "", "Comma separated list of CSV files to load")
+	flag.StringP("mapping", "m", "", "Location of the JSON file mapping CSV columns to predicates")
+	flag.String("delimiter", ",", "Field delimiter used in the CSV files")
+	flag.StringP("schema", "s", "", "Location of schema file")
+	flag.StringP("dgraph", "d", "127.0.0.1:9080", "Dgraph gRPC server address")
+	flag.StringP("zero", "z", "127.0.0.1:5080", "Dgraphzero gRPC server address")
+	flag.IntP("conc", "c", 10,
+		"Number of concurrent requests to make to Dgraph")
+	flag.IntP("batch", "b", 1000,
+		"Number of RDF N-Quads to send as part of a mutation.")
+	flag.StringP("xidmap", "x", "", "Directory to store xid to uid mapping")
+	flag.BoolP("ignore_index_conflict", "i", true,
+		"Ignores conflicts on index keys during transaction")
+	flag.StringP("auth_token", "a", "",
+		"The auth token passed to the server for Alter operation of the schema file")
+
+	// TLS configuration
+	x.RegisterTLSFlags(flag)
+	flag.String("tls_server_name", "", "Used to verify the server hostname.")
+}
+
+// csvMapping describes how the rows of one CSV file are converted into N-Quads. The first line
+// of the file must be a header naming the columns.
+type csvMapping struct {
+	// Xid is the column holding the external id of the row. Rows sharing the same xid (within
+	// the same namespace) end up on the same node. If empty, every row creates a new node.
+	Xid string `json:"xid"`
+	// Namespace scopes the xids of this file, so that ids of different tables don't collide.
+	// It defaults to the file name without extension.
+	Namespace string      `json:"namespace"`
+	Columns   []csvColumn `json:"columns"`
+}
+
+type csvColumn struct {
+	Column    string `json:"column"`
+	Predicate string `json:"predicate"`
+	// Type is one of string, int, float, bool, datetime, geo or uid. Values of uid columns are
+	// treated as xids in the Link namespace, creating an edge to that node. If empty, the value
+	// is stored with the default type.
+	Type string `json:"type"`
+	Link string `json:"link"`
+	Lang string `json:"lang"`
+}
+
+var csvTypes = map[string]string{
+	"string":   "xs:string",
+	"int":      "xs:int",
+	"float":    "xs:float",
+	"bool":     "xs:boolean",
+	"datetime": "xs:dateTime",
+	"geo":      "geo:geojson",
+}
+
+// readCSVMappings reads the mapping file, which is a JSON object keyed by CSV file name.
+func readCSVMappings(file string) (map[string]*csvMapping, error) {
+	b, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	var mappings map[string]*csvMapping
+	if err := json.Unmarshal(b, &mappings); err != nil {
+		return nil, x.Wrapf(err, "while parsing mapping file: %s", file)
+	}
+	for name, m := range mappings {
+		if len(m.Namespace) == 0 {
+			ext := filepath.Ext(strings.TrimSuffix(name, ".gz"))
+			m.Namespace = strings.TrimSuffix(strings.TrimSuffix(name, ".gz"), ext)
+		}
+		if len(m.Columns) == 0 {
+			return nil, x.Errorf("No columns mapped for file: %s", name)
+		}
+		for _, c := range m.Columns {
+			if len(c.Column) == 0 || len(c.Predicate) == 0 {
+				return nil, x.Errorf("Column and predicate are required in mapping for %s: %+v",
+					name, c)
+			}
+			if _, ok := csvTypes[c.Type]; !ok && c.Type != "" && c.Type != "uid" {
+				return nil, x.Errorf("Unknown type %q for column %q in mapping for %s",
+					c.Type, c.Column, name)
+			}
+			if c.Type == "uid" && len(c.Link) == 0 {
+				return nil, x.Errorf("Column %q of %s is of type uid but has no link namespace",
+					c.Column, name)
+			}
+		}
+	}
+	return mappings, nil
+}
+
+// mappingFor returns the mapping for the given CSV file, looking it up by base name.
+func mappingFor(mappings map[string]*csvMapping, file string) (*csvMapping, error) {
+	base := filepath.Base(file)
+	m, ok := mappings[base]
+	if !ok {
+		return nil, x.Errorf("No mapping found for CSV file: %s", base)
+	}
+	return m, nil
+}
+
+// blankNode returns a blank node label for the xid in the given namespace. Letters and digits
+// are kept as they are, every other byte is escaped as _XX, so any xid yields a valid and
+// unique label.
+func blankNode(namespace, xid string) string {
+	var b strings.Builder
+	b.WriteString("_:")
+	escape := func(s string) {
+		for i := 0; i < len(s); i++ {
+			c := s[i]
+			if c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' {
+				b.WriteByte(c)
+				continue
+			}
+			fmt.Fprintf(&b, "_%02x", c)
+		}
+	}
+	escape(namespace)
+	b.WriteByte('.')
+	escape(xid)
+	return b.String()
+}
+
+// nquadsForRow generates the RDF for a single CSV row and parses it into N-Quads.
+func (m *csvMapping) nquadsForRow(index map[string]int, row []string,
+	rowNum uint64) ([]*api.NQuad, error) {
+	subject := blankNode(m.Namespace, "row"+strconv.FormatUint(rowNum, 10))
+	if len(m.Xid) > 0 {
+		xid := strings.TrimSpace(row[index[m.Xid]])
+		if len(xid) == 0 {
+			return nil, x.Errorf("Empty xid column %q", m.Xid)
+		}
+		subject = blankNode(m.Namespace, xid)
+	}
+
+	var nqs []*api.NQuad
+	for _, c := range m.Columns {
+		val := row[index[c.Column]]
+		if len(strings.TrimSpace(val)) == 0 {
+			// Empty cells are treated as NULL.
+			continue
+		}
+		var object string
+		switch {
+		case c.Type == "uid":
+			object = blankNode(c.Link, strings.TrimSpace(val))
+		case len(c.Lang) > 0:
+			object = strconv.Quote(val) + "@" + c.Lang
+		case c.Type == "":
+			object = strconv.Quote(val)
+		default:
+			object = fmt.Sprintf("%s^^<%s>", strconv.Quote(val), csvTypes[c.Type])
+		}
+		nq, err := rdf.Parse(fmt.Sprintf("%s <%s> %s .", subject, c.Predicate, object))
+		if err != nil {
+			return nil, x.Wrapf(err, "while converting column %q", c.Column)
+		}
+		nqs = append(nqs, &nq)
+	}
+	return nqs, nil
+}
+
+// processCSVFile converts a CSV file into N-Quads using the mapping and sends them as mutations.
+func (l *loader) processCSVFile(ctx context.Context, file string, m *csvMapping,
+	delimiter rune) error {
+	fmt.Printf("\nProcessing %s\n", file)
+	gr, f := fileReader(file)
+	defer f.Close()
+
+	r := csv.NewReader(gr)
+	r.Comma = delimiter
+	r.ReuseRecord = true
+	header, err := r.Read()
+	if err != nil {
+		return x.Wrapf(err, "while reading CSV header")
+	}
+	index := make(map[string]int, len(header))
+	for i, name := range header {
+		index[strings.TrimSpace(name)] = i
+	}
+	if _, ok := index[m.Xid]; len(m.Xid) > 0 && !ok {
+		return x.Errorf("Xid column %q not found in header of %s", m.Xid, file)
+	}
+	for _, c := range m.Columns {
+		if _, ok := index[c.Column]; !ok {
+			return x.Errorf("Column %q not found in header of %s", c.Column, file)
+		}
+	}
+
+	var line uint64 = 1
+	mu := api.Mutation{}
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+		}
+		row, err := r.Read()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return err
+		}
+		line++
+
+		nqs, err := m.nquadsForRow(index, row, line)
+		if err != nil {
+			return fmt.Errorf("Error while converting CSV: %v, on line: %v", err, line)
+		}
+		for _, nq := range nqs {
+			nq.Subject = l.uid(nq.Subject)
+			if len(nq.ObjectId) > 0 {
+				nq.ObjectId = l.uid(nq.ObjectId)
+			}
+		}
+		mu.Set = append(mu.Set, nqs...)
+
+		if len(mu.Set) >= opt.numRdf {
+			l.reqs <- mu
+			mu = api.Mutation{}
+		}
+	}
+	if len(mu.Set) > 0 {
+		l.reqs <- mu
+	}
+	return nil
+}
+
+func runCSV() error {
+	x.PrintVersion()
+	opt = options{
+		files:               ImportCSV.Conf.GetString("files"),
+		schemaFile:          ImportCSV.Conf.GetString("schema"),
+		dgraph:              ImportCSV.Conf.GetString("dgraph"),
+		zero:                ImportCSV.Conf.GetString("zero"),
+		concurrent:          ImportCSV.Conf.GetInt("conc"),
+		numRdf:              ImportCSV.Conf.GetInt("batch"),
+		clientDir:           ImportCSV.Conf.GetString("xidmap"),
+		ignoreIndexConflict: ImportCSV.Conf.GetBool("ignore_index_conflict"),
+		authToken:           ImportCSV.Conf.GetString("auth_token"),
+	}
+	x.LoadTLSConfig(&tlsConf, ImportCSV.Conf)
+	tlsConf.ServerName = ImportCSV.Conf.GetString("tls_server_name")
+
+	delim := []rune(ImportCSV.Conf.GetString("delimiter"))
+	if len(delim) != 1 {
+		fmt.Printf("The delimiter must be a single character. Got: %q\n", string(delim))
+		return x.Errorf("Invalid delimiter")
+	}
+	mappingFile := ImportCSV.Conf.GetString("mapping")
+	if len(mappingFile) == 0 {
+		fmt.Println("The mapping file must be specified via --mapping.")
+		return x.Errorf("No mapping file")
+	}
+	mappings, err := readCSVMappings(mappingFile)
+	if err != nil {
+		fmt.Printf("Error while reading mapping file %q: %v\n", mappingFile, err)
+		return err
+	}
+	// Validate the mappings upfront, so we don't fail after having loaded half the files.
+	for _, file := range fileList(opt.files) {
+		if _, err := mappingFor(mappings, strings.Trim(file, " \t")); err != nil {
+			fmt.Println(err)
+			return err
+		}
+	}
+
+	return load(func(l *loader, ctx context.Context, file string) error {
+		m, err := mappingFor(mappings, file)
+		if err != nil {
+			return err
+		}
+		return l.processCSVFile(ctx, file, m, delim[0])
+	})
+}
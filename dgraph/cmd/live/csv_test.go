@@ -0,0 +1,92 @@
+/*
+ * Copyright 2018 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package live
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/dgraph-io/dgo/protos/api"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBlankNode(t *testing.T) {
+	require.Equal(t, "_:person.42", blankNode("person", "42"))
+	require.Equal(t, "_:person.adam_20smith", blankNode("person", "adam smith"))
+	require.Equal(t, "_:my_5fns.a_5fb", blankNode("my_ns", "a_b"))
+	require.NotEqual(t, blankNode("person", "a b"), blankNode("person", "a_20b"))
+}
+
+func TestReadCSVMappings(t *testing.T) {
+	f, err := ioutil.TempFile("", "mapping")
+	require.NoError(t, err)
+	defer os.Remove(f.Name())
+
+	_, err = f.WriteString(`{
+		"person.csv.gz": {
+			"xid": "id",
+			"columns": [{"column": "friend", "predicate": "friend", "type": "uid", "link": "person"}]
+		}
+	}`)
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+
+	mappings, err := readCSVMappings(f.Name())
+	require.NoError(t, err)
+	m, err := mappingFor(mappings, "/data/person.csv.gz")
+	require.NoError(t, err)
+	require.Equal(t, "person", m.Namespace)
+
+	_, err = mappingFor(mappings, "company.csv")
+	require.Error(t, err)
+}
+
+func TestNquadsForRow(t *testing.T) {
+	m := &csvMapping{
+		Xid:       "id",
+		Namespace: "person",
+		Columns: []csvColumn{
+			{Column: "name", Predicate: "name", Lang: "en"},
+			{Column: "age", Predicate: "age", Type: "int"},
+			{Column: "company", Predicate: "works_for", Type: "uid", Link: "company"},
+			{Column: "bio", Predicate: "bio"},
+		},
+	}
+	index := map[string]int{"id": 0, "name": 1, "age": 2, "company": 3, "bio": 4}
+
+	nqs, err := m.nquadsForRow(index, []string{"7", "Alice", "30", "c1", ""}, 2)
+	require.NoError(t, err)
+	require.Len(t, nqs, 3)
+
+	require.Equal(t, "_:person.7", nqs[0].Subject)
+	require.Equal(t, "name", nqs[0].Predicate)
+	require.Equal(t, "en", nqs[0].Lang)
+	require.Equal(t, &api.Value{Val: &api.Value_DefaultVal{DefaultVal: "Alice"}},
+		nqs[0].ObjectValue)
+
+	require.Equal(t, &api.Value{Val: &api.Value_IntVal{IntVal: 30}}, nqs[1].ObjectValue)
+
+	require.Equal(t, "works_for", nqs[2].Predicate)
+	require.Equal(t, "_:company.c1", nqs[2].ObjectId)
+
+	_, err = m.nquadsForRow(index, []string{"8", "Bob", "thirty", "", ""}, 3)
+	require.Error(t, err)
+
+	_, err = m.nquadsForRow(index, []string{"", "Bob", "30", "", ""}, 4)
+	require.Error(t, err)
+}
@@ -42,6 +42,7 @@ import (
 	bopt "github.com/dgraph-io/badger/options"
 	"github.com/dgraph-io/dgo"
 	"github.com/dgraph-io/dgo/protos/api"
+	"github.com/dgraph-io/dgraph/conn"
 	"github.com/dgraph-io/dgraph/rdf"
 	"github.com/dgraph-io/dgraph/x"
 	"github.com/dgraph-io/dgraph/xidmap"
@@ -63,6 +64,11 @@ type options struct {
 	clientDir           string
 	ignoreIndexConflict bool
 	authToken           string
+
+	// dropAll drops all data before loading, and schema is applied before loading if there's
+	// no schema file.
+	dropAll bool
+	schema  string
 }
 
 var opt options
@@ -100,6 +106,7 @@ func init() {
 
 	// TLS configuration
 	x.RegisterTLSFlags(flag)
+	conn.RegisterInternalTLSFlags(flag)
 	flag.String("tls_server_name", "", "Used to verify the server hostname.")
 }
 
@@ -123,15 +130,19 @@ func readLine(r *bufio.Reader, buf *bytes.Buffer) error {
 	return err
 }
 
-// processSchemaFile process schema for a given gz file.
-func processSchemaFile(ctx context.Context, file string, dgraphClient *dgo.Dgraph) error {
-	fmt.Printf("\nProcessing %s\n", file)
+// alter runs the operation, passing along the auth token.
+func alter(ctx context.Context, op *api.Operation, dgraphClient *dgo.Dgraph) error {
 	if len(opt.authToken) > 0 {
 		md := metadata.New(nil)
 		md.Append("auth-token", opt.authToken)
 		ctx = metadata.NewOutgoingContext(ctx, md)
 	}
+	return dgraphClient.Alter(ctx, op)
+}
 
+// processSchemaFile process schema for a given gz file.
+func processSchemaFile(ctx context.Context, file string, dgraphClient *dgo.Dgraph) error {
+	fmt.Printf("\nProcessing %s\n", file)
 	f, err := os.Open(file)
 	x.Check(err)
 	defer f.Close()
@@ -151,7 +162,7 @@ func processSchemaFile(ctx context.Context, file string, dgraphClient *dgo.Dgrap
 
 	op := &api.Operation{}
 	op.Schema = string(b)
-	return dgraphClient.Alter(ctx, op)
+	return alter(ctx, op, dgraphClient)
 }
 
 func (l *loader) uid(val string) string {
@@ -188,9 +199,14 @@ func fileReader(file string) (io.Reader, *os.File) {
 func (l *loader) processFile(ctx context.Context, file string) error {
 	fmt.Printf("\nProcessing %s\n", file)
 	gr, f := fileReader(file)
-	var buf bytes.Buffer
-	bufReader := bufio.NewReader(gr)
 	defer f.Close()
+	return l.processRDF(ctx, gr)
+}
+
+// processRDF sends mutations for the RDF N-Quads read from r.
+func (l *loader) processRDF(ctx context.Context, r io.Reader) error {
+	var buf bytes.Buffer
+	bufReader := bufio.NewReader(r)
 
 	var line uint64
 	mu := api.Mutation{}
@@ -267,6 +283,18 @@ func setupConnection(host string, insecure bool) (*grpc.ClientConn, error) {
 		grpc.WithTimeout(10*time.Second))
 }
 
+// setupZeroConnection connects to Zero, which uses the certificates of the internal traffic if
+// mutual TLS is set up between the nodes.
+func setupZeroConnection(host string) (*grpc.ClientConn, error) {
+	return grpc.Dial(host,
+		grpc.WithDefaultCallOptions(
+			grpc.MaxCallRecvMsgSize(x.GrpcMaxSize),
+			grpc.MaxCallSendMsgSize(x.GrpcMaxSize)),
+		conn.DialOption(),
+		grpc.WithBlock(),
+		grpc.WithTimeout(10*time.Second))
+}
+
 func fileList(files string) []string {
 	if len(files) == 0 {
 		return []string{}
@@ -285,7 +313,7 @@ func setup(opts batchMutationOptions, dc *dgo.Dgraph) *loader {
 	kv, err := badger.Open(o)
 	x.Checkf(err, "Error while creating badger KV posting store")
 
-	connzero, err := setupConnection(opt.zero, true)
+	connzero, err := setupZeroConnection(opt.zero)
 	x.Checkf(err, "Unable to connect to zero, Is it running at %s?", opt.zero)
 
 	alloc := xidmap.New(
@@ -330,8 +358,15 @@ func run() error {
 		authToken:           Live.Conf.GetString("auth_token"),
 	}
 	x.LoadTLSConfig(&tlsConf, Live.Conf)
+	x.Checkf(conn.SetupInternalTLSFromConfig(Live.Conf), "While setting up internal TLS")
 	tlsConf.ServerName = Live.Conf.GetString("tls_server_name")
 
+	return load((*loader).processFile)
+}
+
+// load sets up the connections to Dgraph and runs process over every file in opt.files. The
+// options should be populated before calling load.
+func load(process func(l *loader, ctx context.Context, file string) error) error {
 	go http.ListenAndServe("localhost:6060", nil)
 	ctx := context.Background()
 	bmOpts := batchMutationOptions{
@@ -366,6 +401,20 @@ func run() error {
 	defer l.kv.Close()
 	defer l.alloc.EvictAll()
 
+	if opt.dropAll {
+		if err := alter(ctx, &api.Operation{DropAll: true}, dgraphClient); err != nil {
+			fmt.Printf("Error while dropping all data: %s\n", err)
+			return err
+		}
+		fmt.Println("Dropped all data")
+	}
+	if len(opt.schemaFile) == 0 && len(opt.schema) > 0 {
+		if err := alter(ctx, &api.Operation{Schema: opt.schema}, dgraphClient); err != nil {
+			fmt.Printf("Error while applying schema: %s\n", err)
+			return err
+		}
+		fmt.Println("Applied schema")
+	}
 	if len(opt.schemaFile) > 0 {
 		if err := processSchemaFile(ctx, opt.schemaFile, dgraphClient); err != nil {
 			if err == context.Canceled {
@@ -389,7 +438,7 @@ func run() error {
 	for _, file := range filesList {
 		file = strings.Trim(file, " \t")
 		go func(file string) {
-			errCh <- l.processFile(ctx, file)
+			errCh <- process(l, ctx, file)
 		}(file)
 	}
 
@@ -0,0 +1,121 @@
+/*
+ * Copyright 2018 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package live
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/dgraph-io/dgraph/x"
+	"github.com/spf13/cobra"
+)
+
+var LoadSample x.SubCommand
+
+func init() {
+	LoadSample.Cmd = &cobra.Command{
+		Use:   "load-sample",
+		Short: "Load a sample dataset into a running Dgraph cluster",
+		Long: `
+Load one of the sample datasets bundled with Dgraph, along with its schema, into a
+running Dgraph cluster via the live loader. Datasets: ` + strings.Join(sampleNames(), ", ") + `.
+`,
+		Run: func(cmd *cobra.Command, args []string) {
+			defer x.StartProfile(LoadSample.Conf).Stop()
+			if err := runSample(); err != nil {
+				os.Exit(1)
+			}
+		},
+	}
+	LoadSample.EnvPrefix = "DGRAPH_LOAD_SAMPLE"
+
+	flag := LoadSample.Cmd.Flags()
+	flag.String("dataset", "", "Dataset to load, one of: "+strings.Join(sampleNames(), ", "))
+	flag.Bool("drop_before", false,
+		"Drop all data in the cluster before loading. Asks for confirmation, unless --yes is set.")
+	flag.BoolP("yes", "y", false, "Don't ask for confirmation before dropping all data")
+	flag.StringP("dgraph", "d", "127.0.0.1:9080", "Dgraph gRPC server address")
+	flag.StringP("zero", "z", "127.0.0.1:5080", "Dgraphzero gRPC server address")
+	flag.StringP("auth_token", "a", "",
+		"The auth token passed to the server for Alter operations")
+
+	// TLS configuration
+	x.RegisterTLSFlags(flag)
+	flag.String("tls_server_name", "", "Used to verify the server hostname.")
+}
+
+func sampleNames() []string {
+	var names []string
+	for name := range sampleDatasets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// confirmDrop asks the user to confirm dropping all data in the cluster.
+func confirmDrop() bool {
+	fmt.Printf("This drops ALL data in the cluster at %s. Type 'yes' to continue: ", opt.dgraph)
+	answer, err := bufio.NewReader(os.Stdin).ReadString('\n')
+	if err != nil {
+		return false
+	}
+	return strings.TrimSpace(answer) == "yes"
+}
+
+func runSample() error {
+	x.PrintVersion()
+	name := LoadSample.Conf.GetString("dataset")
+	ds, ok := sampleDatasets[name]
+	if !ok {
+		fmt.Printf("Unknown dataset %q. Use --dataset with one of: %s\n", name,
+			strings.Join(sampleNames(), ", "))
+		return x.Errorf("Unknown dataset")
+	}
+	opt = options{
+		files:               name,
+		dgraph:              LoadSample.Conf.GetString("dgraph"),
+		zero:                LoadSample.Conf.GetString("zero"),
+		concurrent:          1,
+		numRdf:              1000,
+		ignoreIndexConflict: true,
+		authToken:           LoadSample.Conf.GetString("auth_token"),
+		dropAll:             LoadSample.Conf.GetBool("drop_before"),
+		schema:              ds.schema,
+	}
+	x.LoadTLSConfig(&tlsConf, LoadSample.Conf)
+	tlsConf.ServerName = LoadSample.Conf.GetString("tls_server_name")
+
+	if opt.dropAll && !LoadSample.Conf.GetBool("yes") && !confirmDrop() {
+		fmt.Println("Not dropping any data. Aborting.")
+		return x.Errorf("Drop not confirmed")
+	}
+
+	fmt.Printf("Loading dataset %s: %s\n", name, ds.about)
+	err := load(func(l *loader, ctx context.Context, file string) error {
+		return l.processRDF(ctx, strings.NewReader(ds.rdf))
+	})
+	if err != nil {
+		return err
+	}
+	fmt.Printf("\nDone. Try this query in Ratel, or via HTTP on /query:\n\n%s\n", ds.query)
+	return nil
+}
@@ -0,0 +1,50 @@
+/*
+ * Copyright 2018 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package live
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/dgraph-io/dgraph/gql"
+	"github.com/dgraph-io/dgraph/rdf"
+	"github.com/dgraph-io/dgraph/schema"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSampleDatasets(t *testing.T) {
+	for name, ds := range sampleDatasets {
+		updates, err := schema.Parse(ds.schema)
+		require.NoError(t, err, name)
+		preds := make(map[string]bool)
+		for _, su := range updates {
+			preds[su.Predicate] = true
+		}
+
+		for i, line := range strings.Split(ds.rdf, "\n") {
+			nq, err := rdf.Parse(line)
+			if err == rdf.ErrEmpty {
+				continue
+			}
+			require.NoError(t, err, "%s, line %d", name, i)
+			require.True(t, preds[nq.Predicate], "%s: no schema for %s", name, nq.Predicate)
+		}
+
+		_, err = gql.Parse(gql.Request{Str: ds.query})
+		require.NoError(t, err, name)
+	}
+}
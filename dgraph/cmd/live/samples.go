@@ -0,0 +1,323 @@
+/*
+ * Copyright 2018 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package live
+
+// sampleDataset is a small dataset loaded by load-sample, along with its schema and a query to
+// get started with.
+type sampleDataset struct {
+	about  string
+	schema string
+	rdf    string
+	query  string
+}
+
+var sampleDatasets = map[string]*sampleDataset{
+	"movies": {
+		about:  "Films along with their directors, actors and genres",
+		schema: moviesSchema,
+		rdf:    moviesRDF,
+		query: `{
+  films(func: has(director.film), orderasc: name) {
+    name
+    director: ~director.film { name }
+    starring { name }
+  }
+}`,
+	},
+	"social": {
+		about:  "People who are friends with and follow each other",
+		schema: socialSchema,
+		rdf:    socialRDF,
+		query: `{
+  alice(func: eq(name, "Alice")) {
+    name
+    friend @facets(close) {
+      name
+      friend { name }
+    }
+    followers: count(~follows)
+  }
+}`,
+	},
+	"geo": {
+		about:  "European cities, with their locations, populations and countries",
+		schema: geoSchema,
+		rdf:    geoRDF,
+		query: `{
+  near_paris(func: near(location, [2.3522, 48.8566], 500000)) {
+    name
+    population
+    country { name }
+  }
+}`,
+	},
+}
+
+const moviesSchema = `
+name: string @index(term, exact) @lang .
+initial_release_date: datetime @index(year) .
+director.film: uid @reverse @count .
+starring: uid @reverse @count .
+genre: uid @reverse @count .
+`
+
+const moviesRDF = `
+_:lucas <name> "George Lucas" .
+_:kershner <name> "Irvin Kershner" .
+_:spielberg <name> "Steven Spielberg" .
+_:scott <name> "Ridley Scott" .
+_:cameron <name> "James Cameron" .
+
+_:scifi <name> "Science Fiction" .
+_:adventure <name> "Adventure" .
+_:action <name> "Action" .
+_:horror <name> "Horror" .
+_:family <name> "Family" .
+
+_:hamill <name> "Mark Hamill" .
+_:ford <name> "Harrison Ford" .
+_:fisher <name> "Carrie Fisher" .
+_:allen <name> "Karen Allen" .
+_:neill <name> "Sam Neill" .
+_:dern <name> "Laura Dern" .
+_:goldblum <name> "Jeff Goldblum" .
+_:thomas <name> "Henry Thomas" .
+_:barrymore <name> "Drew Barrymore" .
+_:weaver <name> "Sigourney Weaver" .
+_:skerritt <name> "Tom Skerritt" .
+_:hauer <name> "Rutger Hauer" .
+_:young <name> "Sean Young" .
+_:schwarzenegger <name> "Arnold Schwarzenegger" .
+_:hamilton <name> "Linda Hamilton" .
+_:biehn <name> "Michael Biehn" .
+
+_:starwars <name> "Star Wars: Episode IV - A New Hope" .
+_:starwars <name> "Krieg der Sterne"@de .
+_:starwars <initial_release_date> "1977-05-25" .
+_:starwars <genre> _:scifi .
+_:starwars <genre> _:adventure .
+_:starwars <starring> _:hamill .
+_:starwars <starring> _:ford .
+_:starwars <starring> _:fisher .
+_:lucas <director.film> _:starwars .
+
+_:empire <name> "Star Wars: Episode V - The Empire Strikes Back" .
+_:empire <name> "Das Imperium schlägt zurück"@de .
+_:empire <initial_release_date> "1980-05-21" .
+_:empire <genre> _:scifi .
+_:empire <genre> _:adventure .
+_:empire <starring> _:hamill .
+_:empire <starring> _:ford .
+_:empire <starring> _:fisher .
+_:kershner <director.film> _:empire .
+
+_:raiders <name> "Raiders of the Lost Ark" .
+_:raiders <initial_release_date> "1981-06-12" .
+_:raiders <genre> _:adventure .
+_:raiders <genre> _:action .
+_:raiders <starring> _:ford .
+_:raiders <starring> _:allen .
+_:spielberg <director.film> _:raiders .
+
+_:et <name> "E.T. the Extra-Terrestrial" .
+_:et <initial_release_date> "1982-06-11" .
+_:et <genre> _:scifi .
+_:et <genre> _:family .
+_:et <starring> _:thomas .
+_:et <starring> _:barrymore .
+_:spielberg <director.film> _:et .
+
+_:jurassic <name> "Jurassic Park" .
+_:jurassic <initial_release_date> "1993-06-11" .
+_:jurassic <genre> _:scifi .
+_:jurassic <genre> _:adventure .
+_:jurassic <starring> _:neill .
+_:jurassic <starring> _:dern .
+_:jurassic <starring> _:goldblum .
+_:spielberg <director.film> _:jurassic .
+
+_:alien <name> "Alien" .
+_:alien <initial_release_date> "1979-05-25" .
+_:alien <genre> _:scifi .
+_:alien <genre> _:horror .
+_:alien <starring> _:weaver .
+_:alien <starring> _:skerritt .
+_:scott <director.film> _:alien .
+
+_:bladerunner <name> "Blade Runner" .
+_:bladerunner <initial_release_date> "1982-06-25" .
+_:bladerunner <genre> _:scifi .
+_:bladerunner <starring> _:ford .
+_:bladerunner <starring> _:hauer .
+_:bladerunner <starring> _:young .
+_:scott <director.film> _:bladerunner .
+
+_:terminator <name> "The Terminator" .
+_:terminator <initial_release_date> "1984-10-26" .
+_:terminator <genre> _:scifi .
+_:terminator <genre> _:action .
+_:terminator <starring> _:schwarzenegger .
+_:terminator <starring> _:hamilton .
+_:terminator <starring> _:biehn .
+_:cameron <director.film> _:terminator .
+
+_:aliens <name> "Aliens" .
+_:aliens <initial_release_date> "1986-07-18" .
+_:aliens <genre> _:scifi .
+_:aliens <genre> _:action .
+_:aliens <starring> _:weaver .
+_:aliens <starring> _:biehn .
+_:cameron <director.film> _:aliens .
+`
+
+const socialSchema = `
+name: string @index(exact, term) .
+age: int @index(int) .
+joined: datetime @index(year) .
+friend: uid @reverse @count .
+follows: uid @reverse @count .
+`
+
+const socialRDF = `
+_:alice <name> "Alice" .
+_:alice <age> "29"^^<xs:int> .
+_:alice <joined> "2012-03-01" .
+_:bob <name> "Bob" .
+_:bob <age> "34"^^<xs:int> .
+_:bob <joined> "2011-07-15" .
+_:carol <name> "Carol" .
+_:carol <age> "25"^^<xs:int> .
+_:carol <joined> "2015-01-20" .
+_:dave <name> "Dave" .
+_:dave <age> "41"^^<xs:int> .
+_:dave <joined> "2010-11-02" .
+_:eve <name> "Eve" .
+_:eve <age> "22"^^<xs:int> .
+_:eve <joined> "2017-09-09" .
+_:frank <name> "Frank" .
+_:frank <age> "37"^^<xs:int> .
+_:frank <joined> "2013-05-30" .
+_:grace <name> "Grace" .
+_:grace <age> "30"^^<xs:int> .
+_:grace <joined> "2014-02-14" .
+_:heidi <name> "Heidi" .
+_:heidi <age> "27"^^<xs:int> .
+_:heidi <joined> "2016-08-01" .
+
+_:alice <friend> _:bob (close=true, since=2012) .
+_:alice <friend> _:carol (close=false, since=2015) .
+_:bob <friend> _:alice (close=true, since=2012) .
+_:bob <friend> _:dave (close=true, since=2011) .
+_:carol <friend> _:alice (close=false, since=2015) .
+_:carol <friend> _:eve (close=true, since=2017) .
+_:dave <friend> _:bob (close=true, since=2011) .
+_:dave <friend> _:frank (close=false, since=2013) .
+_:eve <friend> _:carol (close=true, since=2017) .
+_:frank <friend> _:dave (close=false, since=2013) .
+_:frank <friend> _:grace (close=true, since=2014) .
+_:grace <friend> _:frank (close=true, since=2014) .
+_:grace <friend> _:heidi (close=false, since=2016) .
+_:heidi <friend> _:grace (close=false, since=2016) .
+
+_:alice <follows> _:dave .
+_:bob <follows> _:dave .
+_:carol <follows> _:dave .
+_:eve <follows> _:alice .
+_:eve <follows> _:dave .
+_:frank <follows> _:alice .
+_:grace <follows> _:alice .
+_:heidi <follows> _:eve .
+_:dave <follows> _:grace .
+`
+
+const geoSchema = `
+name: string @index(exact, term) .
+population: int @index(int) .
+location: geo @index(geo) .
+country: uid @reverse .
+capital: uid .
+`
+
+const geoRDF = `
+_:france <name> "France" .
+_:france <capital> _:paris .
+_:germany <name> "Germany" .
+_:germany <capital> _:berlin .
+_:spain <name> "Spain" .
+_:spain <capital> _:madrid .
+_:uk <name> "United Kingdom" .
+_:uk <capital> _:london .
+_:italy <name> "Italy" .
+_:italy <capital> _:rome .
+
+_:paris <name> "Paris" .
+_:paris <population> "2150000"^^<xs:int> .
+_:paris <location> "{'type':'Point','coordinates':[2.3522,48.8566]}"^^<geo:geojson> .
+_:paris <country> _:france .
+_:lyon <name> "Lyon" .
+_:lyon <population> "516000"^^<xs:int> .
+_:lyon <location> "{'type':'Point','coordinates':[4.8357,45.764]}"^^<geo:geojson> .
+_:lyon <country> _:france .
+_:marseille <name> "Marseille" .
+_:marseille <population> "862000"^^<xs:int> .
+_:marseille <location> "{'type':'Point','coordinates':[5.3698,43.2965]}"^^<geo:geojson> .
+_:marseille <country> _:france .
+
+_:berlin <name> "Berlin" .
+_:berlin <population> "3600000"^^<xs:int> .
+_:berlin <location> "{'type':'Point','coordinates':[13.405,52.52]}"^^<geo:geojson> .
+_:berlin <country> _:germany .
+_:hamburg <name> "Hamburg" .
+_:hamburg <population> "1800000"^^<xs:int> .
+_:hamburg <location> "{'type':'Point','coordinates':[9.9937,53.5511]}"^^<geo:geojson> .
+_:hamburg <country> _:germany .
+_:munich <name> "Munich" .
+_:munich <population> "1470000"^^<xs:int> .
+_:munich <location> "{'type':'Point','coordinates':[11.582,48.1351]}"^^<geo:geojson> .
+_:munich <country> _:germany .
+
+_:madrid <name> "Madrid" .
+_:madrid <population> "3200000"^^<xs:int> .
+_:madrid <location> "{'type':'Point','coordinates':[-3.7038,40.4168]}"^^<geo:geojson> .
+_:madrid <country> _:spain .
+_:barcelona <name> "Barcelona" .
+_:barcelona <population> "1600000"^^<xs:int> .
+_:barcelona <location> "{'type':'Point','coordinates':[2.1734,41.3851]}"^^<geo:geojson> .
+_:barcelona <country> _:spain .
+
+_:london <name> "London" .
+_:london <population> "8900000"^^<xs:int> .
+_:london <location> "{'type':'Point','coordinates':[-0.1276,51.5072]}"^^<geo:geojson> .
+_:london <country> _:uk .
+_:manchester <name> "Manchester" .
+_:manchester <population> "550000"^^<xs:int> .
+_:manchester <location> "{'type':'Point','coordinates':[-2.2426,53.4808]}"^^<geo:geojson> .
+_:manchester <country> _:uk .
+_:edinburgh <name> "Edinburgh" .
+_:edinburgh <population> "490000"^^<xs:int> .
+_:edinburgh <location> "{'type':'Point','coordinates':[-3.1883,55.9533]}"^^<geo:geojson> .
+_:edinburgh <country> _:uk .
+
+_:rome <name> "Rome" .
+_:rome <population> "2800000"^^<xs:int> .
+_:rome <location> "{'type':'Point','coordinates':[12.4964,41.9028]}"^^<geo:geojson> .
+_:rome <country> _:italy .
+_:milan <name> "Milan" .
+_:milan <population> "1400000"^^<xs:int> .
+_:milan <location> "{'type':'Point','coordinates':[9.19,45.4642]}"^^<geo:geojson> .
+_:milan <country> _:italy .
+`
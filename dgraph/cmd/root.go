@@ -22,8 +22,12 @@ import (
 	"os"
 
 	"github.com/dgraph-io/dgraph/dgraph/cmd/alpha"
+	"github.com/dgraph-io/dgraph/dgraph/cmd/audit"
 	"github.com/dgraph-io/dgraph/dgraph/cmd/bulk"
 	"github.com/dgraph-io/dgraph/dgraph/cmd/cert"
+	"github.com/dgraph-io/dgraph/dgraph/cmd/check"
+	"github.com/dgraph-io/dgraph/dgraph/cmd/clone"
+	"github.com/dgraph-io/dgraph/dgraph/cmd/codegen"
 	"github.com/dgraph-io/dgraph/dgraph/cmd/conv"
 	"github.com/dgraph-io/dgraph/dgraph/cmd/debug"
 	"github.com/dgraph-io/dgraph/dgraph/cmd/live"
@@ -83,11 +87,15 @@ func init() {
 		"Dgraph always sets this flag to 0. It can't be overwritten."))
 
 	var subcommands = []*x.SubCommand{
-		&bulk.Bulk, &cert.Cert, &conv.Conv, &live.Live, &alpha.Alpha, &zero.Zero,
-		&version.Version, &debug.Debug,
+		&bulk.Bulk, &cert.Cert, &clone.Clone, &codegen.Codegen, &conv.Conv, &live.Live,
+		&alpha.Alpha, &zero.Zero, &version.Version, &debug.Debug, &live.Import, &live.ImportCSV,
+		&live.LoadSample, &audit.Audit, &check.Check,
 	}
 	for _, sc := range subcommands {
-		RootCmd.AddCommand(sc.Cmd)
+		// Nested commands have already been added to their parent command.
+		if !sc.Cmd.HasParent() {
+			RootCmd.AddCommand(sc.Cmd)
+		}
 		sc.Conf = viper.New()
 		sc.Conf.BindPFlags(sc.Cmd.Flags())
 		sc.Conf.BindPFlags(RootCmd.PersistentFlags())
@@ -148,10 +148,16 @@ func (s *Server) lease(ctx context.Context, num *pb.Num, txn bool) (*pb.Assigned
 			out.ReadOnly = s.readOnlyTs
 		}
 		s.orc.doneUntil.Begin(x.Max(out.EndId, out.ReadOnly))
+		if num.ReadOnly {
+			x.ZeroLeased.Add("timestamps", int64(num.Val)+1)
+		} else {
+			x.ZeroLeased.Add("timestamps", int64(num.Val))
+		}
 	} else {
 		out.StartId = s.nextLeaseId
 		out.EndId = out.StartId + num.Val - 1
 		s.nextLeaseId = out.EndId + 1
+		x.ZeroLeased.Add("uids", int64(num.Val))
 	}
 	return out, nil
 }
@@ -0,0 +1,124 @@
+/*
+ * Copyright 2018 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package zero
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/golang/glog"
+
+	"github.com/dgraph-io/dgraph/protos/pb"
+)
+
+// checksums keeps the checksums of the tablets last reported by every Alpha. The replicas of a
+// group compute them at the same snapshot, so two replicas reporting different digests at the
+// same read ts hold different data. Like liveness, they're kept in memory only.
+type checksums struct {
+	sync.Mutex
+	byMember map[uint64]*memberChecksums
+}
+
+type memberChecksums struct {
+	groupId uint32
+	tablets map[string]*pb.TabletChecksum
+}
+
+// record keeps the checksums sent along with the members of a membership update. They're taken
+// out of the members, so that they don't get proposed along with them.
+func (c *checksums) record(group *pb.Group) {
+	c.Lock()
+	defer c.Unlock()
+	if c.byMember == nil {
+		c.byMember = make(map[uint64]*memberChecksums)
+	}
+	for id, m := range group.Members {
+		if len(m.Checksums) == 0 {
+			continue
+		}
+		prev := c.byMember[id]
+		cur := &memberChecksums{groupId: m.GroupId, tablets: make(map[string]*pb.TabletChecksum)}
+		for _, sum := range m.Checksums {
+			cur.tablets[sum.Predicate] = sum
+			if prev != nil && prev.groupId == m.GroupId {
+				if old, ok := prev.tablets[sum.Predicate]; ok && !changed(old, sum) {
+					// Already compared.
+					continue
+				}
+			}
+			for other, oc := range c.byMember {
+				if other == id || oc.groupId != m.GroupId {
+					continue
+				}
+				if theirs, ok := oc.tablets[sum.Predicate]; ok && diverged(theirs, sum) {
+					glog.Warningf("Tablet %q diverged in group %d at ts %d: Alpha %#x has "+
+						"digest %#x over %d keys, Alpha %#x has %#x over %d keys.",
+						sum.Predicate, m.GroupId, sum.ReadTs, id, sum.Digest, sum.Keys,
+						other, theirs.Digest, theirs.Keys)
+				}
+			}
+		}
+		c.byMember[id] = cur
+		m.Checksums = nil
+	}
+}
+
+func changed(a, b *pb.TabletChecksum) bool {
+	return a.ReadTs != b.ReadTs || a.Digest != b.Digest || a.Keys != b.Keys
+}
+
+func diverged(a, b *pb.TabletChecksum) bool {
+	return a.ReadTs == b.ReadTs && changed(a, b)
+}
+
+// fill sets the checksums last reported by every Alpha in ms, and flags the tablets for which
+// replicas reported different digests at the same read ts as diverged.
+func (c *checksums) fill(ms *pb.MembershipState) {
+	c.Lock()
+	defer c.Unlock()
+	for gid, group := range ms.Groups {
+		var reports []*memberChecksums
+		for id, m := range group.Members {
+			mc, ok := c.byMember[id]
+			if !ok || mc.groupId != gid {
+				continue
+			}
+			reports = append(reports, mc)
+			m.Checksums = m.Checksums[:0]
+			for _, sum := range mc.tablets {
+				m.Checksums = append(m.Checksums, sum)
+			}
+			sort.Slice(m.Checksums, func(i, j int) bool {
+				return m.Checksums[i].Predicate < m.Checksums[j].Predicate
+			})
+		}
+		for pred, tablet := range group.Tablets {
+			seen := make(map[uint64]*pb.TabletChecksum) // Read ts -> checksum.
+			for _, mc := range reports {
+				sum, ok := mc.tablets[pred]
+				if !ok {
+					continue
+				}
+				if s, ok := seen[sum.ReadTs]; ok && diverged(s, sum) {
+					tablet.Diverged = true
+					break
+				}
+				seen[sum.ReadTs] = sum
+			}
+		}
+	}
+}
@@ -0,0 +1,73 @@
+/*
+ * Copyright 2018 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package zero
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dgraph-io/dgraph/protos/pb"
+)
+
+func report(id uint64, sums ...*pb.TabletChecksum) *pb.Group {
+	return &pb.Group{Members: map[uint64]*pb.Member{
+		id: {Id: id, GroupId: 1, Checksums: sums},
+	}}
+}
+
+func TestChecksums(t *testing.T) {
+	var c checksums
+	g := report(1,
+		&pb.TabletChecksum{Predicate: "name", ReadTs: 10, Digest: 0xab, Keys: 2},
+		&pb.TabletChecksum{Predicate: "friend", ReadTs: 10, Digest: 0xcd, Keys: 3})
+	c.record(g)
+	// The checksums aren't proposed along with the member.
+	require.Empty(t, g.Members[1].Checksums)
+
+	c.record(report(2,
+		&pb.TabletChecksum{Predicate: "name", ReadTs: 10, Digest: 0xab, Keys: 2},
+		&pb.TabletChecksum{Predicate: "friend", ReadTs: 10, Digest: 0xce, Keys: 3}))
+	// Alpha 3 hasn't caught up with the snapshot yet.
+	c.record(report(3,
+		&pb.TabletChecksum{Predicate: "name", ReadTs: 5, Digest: 0x12, Keys: 1}))
+
+	ms := &pb.MembershipState{Groups: map[uint32]*pb.Group{1: {
+		Members: map[uint64]*pb.Member{1: {Id: 1}, 2: {Id: 2}, 3: {Id: 3}, 4: {Id: 4}},
+		Tablets: map[string]*pb.Tablet{
+			"name":   {GroupId: 1, Predicate: "name"},
+			"friend": {GroupId: 1, Predicate: "friend"},
+		},
+	}}}
+	c.fill(ms)
+	group := ms.Groups[1]
+	require.False(t, group.Tablets["name"].Diverged)
+	require.True(t, group.Tablets["friend"].Diverged)
+
+	require.Len(t, group.Members[1].Checksums, 2)
+	require.Equal(t, "friend", group.Members[1].Checksums[0].Predicate)
+	require.Len(t, group.Members[3].Checksums, 1)
+	require.Empty(t, group.Members[4].Checksums)
+
+	// Alpha 2 caught up.
+	c.record(report(2,
+		&pb.TabletChecksum{Predicate: "name", ReadTs: 10, Digest: 0xab, Keys: 2},
+		&pb.TabletChecksum{Predicate: "friend", ReadTs: 10, Digest: 0xcd, Keys: 3}))
+	ms.Groups[1].Tablets["friend"].Diverged = false
+	c.fill(ms)
+	require.False(t, ms.Groups[1].Tablets["friend"].Diverged)
+}
@@ -0,0 +1,127 @@
+/*
+ * Copyright 2018 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package zero
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/golang/glog"
+
+	"github.com/dgraph-io/dgraph/protos/pb"
+	"github.com/dgraph-io/dgraph/x"
+)
+
+// A clone copies the data of every group as of a single read timestamp, picked by the leader.
+// A tablet moving while the groups are being copied would end up in neither copy, or in both, so
+// the leader doesn't move any tablets until the clone is done, or its hold expires. Like exports,
+// clones are only tracked in memory by the leader.
+const (
+	defaultCloneHold = time.Hour
+	maxCloneHold     = 24 * time.Hour
+)
+
+// clonePlan is what dgraph clone needs to copy the cluster: the read timestamp to copy the
+// groups at, and the state of the cluster, to find their leaders and tablets.
+type clonePlan struct {
+	ReadTs    uint64              `json:"read_ts"`
+	HoldUntil time.Time           `json:"hold_until"`
+	State     *pb.MembershipState `json:"state"`
+}
+
+type cloneHolds struct {
+	sync.Mutex
+	holds map[uint64]time.Time // Read ts of the clone -> when its hold expires.
+}
+
+func (c *cloneHolds) hold(readTs uint64, until time.Time) {
+	c.Lock()
+	defer c.Unlock()
+	if c.holds == nil {
+		c.holds = make(map[uint64]time.Time)
+	}
+	c.holds[readTs] = until
+}
+
+// release ends the hold of the clone at readTs, returning whether there was one.
+func (c *cloneHolds) release(readTs uint64) bool {
+	c.Lock()
+	defer c.Unlock()
+	_, ok := c.holds[readTs]
+	delete(c.holds, readTs)
+	return ok
+}
+
+// held returns the read ts of a clone holding the tablets at now, if any.
+func (c *cloneHolds) held(now time.Time) (uint64, bool) {
+	c.Lock()
+	defer c.Unlock()
+	for ts, until := range c.holds {
+		if now.Before(until) {
+			return ts, true
+		}
+		glog.Warningf("Hold of clone at ts %d expired, without the clone being done.", ts)
+		delete(c.holds, ts)
+	}
+	return 0, false
+}
+
+// startClone picks the read timestamp to clone the cluster at, and holds the tablets in place
+// for the given duration, or until the clone is done.
+func (s *Server) startClone(ctx context.Context, hold time.Duration) (*clonePlan, error) {
+	if !s.Node.AmLeader() {
+		return nil, x.Errorf("Clones can only be coordinated by the leader of Zero")
+	}
+	if hold <= 0 {
+		hold = defaultCloneHold
+	}
+	if hold > maxCloneHold {
+		return nil, x.Errorf("Hold of clone can't be longer than %s", maxCloneHold)
+	}
+
+	// The tablets are held before checking that none of them is moving, so that no move can
+	// start after the check. Moves started before carry on, so they have to be waited for.
+	until := time.Now().Add(hold)
+	ts, err := s.Timestamps(ctx, &pb.Num{ReadOnly: true})
+	if err != nil {
+		return nil, x.Wrapf(err, "while getting the read timestamp for clone")
+	}
+	s.clones.hold(ts.ReadOnly, until)
+	state := s.membershipState()
+	for _, group := range state.Groups {
+		for _, tablet := range group.Tablets {
+			if tablet.ReadOnly || tablet.MovingTo != 0 {
+				s.clones.release(ts.ReadOnly)
+				return nil, x.Errorf("Tablet %q is being moved. Try again once it's done.",
+					tablet.Predicate)
+			}
+		}
+	}
+	glog.Infof("Starting clone at readTs %d, holding tablets until %s", ts.ReadOnly,
+		until.Format(time.RFC3339))
+	return &clonePlan{ReadTs: ts.ReadOnly, HoldUntil: until, State: state}, nil
+}
+
+// endClone lets the tablets move again once the clone at readTs is done.
+func (s *Server) endClone(readTs uint64) error {
+	if !s.clones.release(readTs) {
+		return x.Errorf("No clone found at readTs %d", readTs)
+	}
+	glog.Infof("Clone at readTs %d is done", readTs)
+	return nil
+}
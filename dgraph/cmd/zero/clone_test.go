@@ -0,0 +1,47 @@
+/*
+ * Copyright 2018 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package zero
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCloneHolds(t *testing.T) {
+	var c cloneHolds
+	now := time.Now()
+	_, held := c.held(now)
+	require.False(t, held)
+
+	c.hold(10, now.Add(time.Minute))
+	ts, held := c.held(now)
+	require.True(t, held)
+	require.Equal(t, uint64(10), ts)
+
+	// Expired holds are dropped.
+	_, held = c.held(now.Add(2 * time.Minute))
+	require.False(t, held)
+	require.False(t, c.release(10))
+
+	c.hold(20, now.Add(time.Minute))
+	require.False(t, c.release(10))
+	require.True(t, c.release(20))
+	_, held = c.held(now)
+	require.False(t, held)
+}
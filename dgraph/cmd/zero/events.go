@@ -0,0 +1,305 @@
+/*
+ * Copyright 2018 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package zero
+
+import (
+	"encoding/binary"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dgraph-io/badger"
+	"github.com/dgraph-io/dgo/protos/api"
+	"github.com/golang/glog"
+	"golang.org/x/net/context"
+
+	"github.com/dgraph-io/dgraph/protos/pb"
+	"github.com/dgraph-io/dgraph/x"
+)
+
+// Types of the events recorded by Zero. The Alphas record others, like schema changes and backups.
+const (
+	eventLeaderElected      = "leader_elected"
+	eventMemberJoined       = "member_joined"
+	eventMemberRemoved      = "member_removed"
+	eventMemberDraining     = "member_draining"
+	eventTabletMoveStarted  = "tablet_move_started"
+	eventTabletMoved        = "tablet_moved"
+	eventTabletMoveFailed   = "tablet_move_failed"
+	eventTabletSplitStarted = "tablet_split_started"
+	eventTabletSplit        = "tablet_split"
+	eventTabletSplitFailed  = "tablet_split_failed"
+	eventTabletCopyStarted  = "tablet_copy_started"
+	eventTabletCopied       = "tablet_copied"
+	eventTabletCopyFailed   = "tablet_copy_failed"
+	eventTabletCopyDropped  = "tablet_copy_dropped"
+	eventExport             = "export"
+)
+
+const (
+	// eventTrimInterval is how often the event log is trimmed to --max_events.
+	eventTrimInterval = time.Minute
+	// defaultEventLimit is how many events /events returns at most, unless told otherwise.
+	defaultEventLimit = 1000
+)
+
+// eventPrefix starts the keys of the events in the store of Zero. It can't clash with the keys of
+// the Raft log, which start with the Raft id of the node.
+var eventPrefix = []byte("zero-events/")
+
+// eventLog keeps the events of the cluster in the store of Zero, by the Raft index they were
+// recorded at. Events are proposed, so that every Zero applies the same ones, and replaying the
+// Raft log on restart finds the events already there. The log isn't part of the Raft snapshots
+// though: a Zero which is sent a snapshot, on joining or when it's too far behind, only gets the
+// events after it. So the log of the leader is the authoritative one, although it too lacks the
+// events before the snapshot it was sent, if it ever was one.
+type eventLog struct {
+	db  *badger.DB
+	max int
+}
+
+func eventKey(index uint64) []byte {
+	key := make([]byte, len(eventPrefix)+8)
+	copy(key, eventPrefix)
+	binary.BigEndian.PutUint64(key[len(eventPrefix):], index)
+	return key
+}
+
+func newEvent(typ string, group uint32, node uint64, format string,
+	args ...interface{}) *pb.ClusterEvent {
+	return &pb.ClusterEvent{
+		At:      time.Now().UnixNano(),
+		Type:    typ,
+		Group:   group,
+		Node:    node,
+		Message: fmt.Sprintf(format, args...),
+	}
+}
+
+// add stores ev, unless an event was already stored at its index.
+func (l *eventLog) add(ev *pb.ClusterEvent) error {
+	if l.db == nil {
+		return nil
+	}
+	key := eventKey(ev.Index)
+	return l.db.Update(func(txn *badger.Txn) error {
+		if _, err := txn.Get(key); err != badger.ErrKeyNotFound {
+			return err
+		}
+		data, err := ev.Marshal()
+		if err != nil {
+			return err
+		}
+		return txn.Set(key, data)
+	})
+}
+
+// trim deletes the oldest events, keeping at most max of them.
+func (l *eventLog) trim() error {
+	if l.db == nil || l.max <= 0 {
+		return nil
+	}
+	var keys [][]byte
+	err := l.db.View(func(txn *badger.Txn) error {
+		opt := badger.DefaultIteratorOptions
+		opt.PrefetchValues = false
+		it := txn.NewIterator(opt)
+		defer it.Close()
+		for it.Seek(eventPrefix); it.ValidForPrefix(eventPrefix); it.Next() {
+			keys = append(keys, it.Item().KeyCopy(nil))
+		}
+		return nil
+	})
+	if err != nil || len(keys) <= l.max {
+		return err
+	}
+	batch := l.db.NewWriteBatch()
+	defer batch.Cancel()
+	for _, key := range keys[:len(keys)-l.max] {
+		if err := batch.Delete(key); err != nil {
+			return err
+		}
+	}
+	return batch.Flush()
+}
+
+func (l *eventLog) trimPeriodically(closer <-chan struct{}) {
+	ticker := time.NewTicker(eventTrimInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-closer:
+			return
+		case <-ticker.C:
+			if err := l.trim(); err != nil {
+				glog.Errorf("While trimming the event log: %v", err)
+			}
+		}
+	}
+}
+
+// eventFilter selects the events returned by /events.
+type eventFilter struct {
+	since, until time.Time // Either can be zero, for no bound.
+	types        map[string]bool
+	limit        int // The most recent events are kept.
+}
+
+func (f *eventFilter) match(ev *pb.ClusterEvent) bool {
+	at := time.Unix(0, ev.At)
+	switch {
+	case !f.since.IsZero() && at.Before(f.since):
+		return false
+	case !f.until.IsZero() && !at.Before(f.until):
+		return false
+	case len(f.types) > 0 && !f.types[ev.Type]:
+		return false
+	}
+	return true
+}
+
+// list returns the events matching f, from the oldest to the most recent.
+func (l *eventLog) list(f *eventFilter) ([]*pb.ClusterEvent, error) {
+	var events []*pb.ClusterEvent
+	if l.db == nil {
+		return events, nil
+	}
+	err := l.db.View(func(txn *badger.Txn) error {
+		it := txn.NewIterator(badger.DefaultIteratorOptions)
+		defer it.Close()
+		for it.Seek(eventPrefix); it.ValidForPrefix(eventPrefix); it.Next() {
+			ev := new(pb.ClusterEvent)
+			if err := it.Item().Value(ev.Unmarshal); err != nil {
+				return err
+			}
+			if !f.match(ev) {
+				continue
+			}
+			events = append(events, ev)
+			if f.limit > 0 && len(events) > f.limit {
+				events = events[1:]
+			}
+		}
+		return nil
+	})
+	return events, err
+}
+
+// proposeEvent records ev in the event log, if this node is the leader. Failures are only logged,
+// as events are informational.
+func (s *Server) proposeEvent(ev *pb.ClusterEvent) {
+	if !s.Node.AmLeader() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	if err := s.Node.proposeAndWait(ctx, &pb.ZeroProposal{Event: ev}); err != nil {
+		glog.Errorf("While recording event %+v: %v", ev, err)
+	}
+}
+
+// RecordEvent records an event reported by an Alpha in the event log.
+func (s *Server) RecordEvent(ctx context.Context, ev *pb.ClusterEvent) (*api.Payload, error) {
+	if ctx.Err() != nil {
+		return &api.Payload{}, ctx.Err()
+	}
+	if len(ev.Type) == 0 {
+		return &api.Payload{}, x.Errorf("Event has no type: %+v", ev)
+	}
+	if ev.At == 0 {
+		ev.At = time.Now().UnixNano()
+	}
+	if err := s.Node.proposeAndWait(ctx, &pb.ZeroProposal{Event: ev}); err != nil {
+		return &api.Payload{}, err
+	}
+	return &api.Payload{Data: []byte("OK")}, nil
+}
+
+// eventsFilter parses the parameters of /events: since and until as RFC 3339 times, type as a
+// comma separated list of types, and limit.
+func eventsFilter(r *http.Request) (*eventFilter, error) {
+	q := r.URL.Query()
+	f := &eventFilter{limit: defaultEventLimit}
+	for name, t := range map[string]*time.Time{"since": &f.since, "until": &f.until} {
+		if s := q.Get(name); len(s) > 0 {
+			var err error
+			if *t, err = time.Parse(time.RFC3339, s); err != nil {
+				return nil, x.Errorf("Invalid %s: %v", name, err)
+			}
+		}
+	}
+	if s := q.Get("type"); len(s) > 0 {
+		f.types = make(map[string]bool)
+		for _, typ := range strings.Split(s, ",") {
+			f.types[strings.TrimSpace(typ)] = true
+		}
+	}
+	if s := q.Get("limit"); len(s) > 0 {
+		limit, err := strconv.Atoi(s)
+		if err != nil || limit < 0 {
+			return nil, x.Errorf("Invalid limit: %q", s)
+		}
+		f.limit = limit
+	}
+	return f, nil
+}
+
+// eventJSON is how events are shown by /events.
+type eventJSON struct {
+	Index     uint64    `json:"index"`
+	At        time.Time `json:"at"`
+	Type      string    `json:"type"`
+	Group     uint32    `json:"group,omitempty"`
+	Node      uint64    `json:"node,omitempty"`
+	Predicate string    `json:"predicate,omitempty"`
+	Message   string    `json:"message,omitempty"`
+}
+
+// getEvents serves /events, the event log of the cluster, from the oldest to the most recent
+// event.
+func (st *state) getEvents(w http.ResponseWriter, r *http.Request) {
+	x.AddCorsHeaders(w)
+	w.Header().Set("Content-Type", "application/json")
+
+	f, err := eventsFilter(r)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		x.SetStatus(w, x.ErrorInvalidRequest, err.Error())
+		return
+	}
+	events, err := st.zero.events.list(f)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		x.SetStatus(w, x.Error, err.Error())
+		return
+	}
+	out := make([]eventJSON, 0, len(events))
+	for _, ev := range events {
+		out = append(out, eventJSON{
+			Index:     ev.Index,
+			At:        time.Unix(0, ev.At).UTC(),
+			Type:      ev.Type,
+			Group:     ev.Group,
+			Node:      ev.Node,
+			Predicate: ev.Predicate,
+			Message:   ev.Message,
+		})
+	}
+	x.Reply(w, out)
+}
@@ -0,0 +1,107 @@
+/*
+ * Copyright 2018 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package zero
+
+import (
+	"io/ioutil"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/dgraph-io/badger"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dgraph-io/dgraph/protos/pb"
+)
+
+func types(events []*pb.ClusterEvent) []string {
+	var out []string
+	for _, ev := range events {
+		out = append(out, ev.Type)
+	}
+	return out
+}
+
+func TestEventLog(t *testing.T) {
+	dir, err := ioutil.TempDir("", "events")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	opt := badger.DefaultOptions
+	opt.Dir = dir
+	opt.ValueDir = dir
+	db, err := badger.Open(opt)
+	require.NoError(t, err)
+	defer db.Close()
+
+	l := eventLog{db: db, max: 3}
+	start := time.Now()
+	for i, typ := range []string{eventMemberJoined, eventLeaderElected, eventTabletMoveStarted,
+		eventTabletMoved, eventExport} {
+		ev := &pb.ClusterEvent{
+			Index: uint64(i + 1),
+			At:    start.Add(time.Duration(i) * time.Minute).UnixNano(),
+			Type:  typ,
+		}
+		require.NoError(t, l.add(ev))
+	}
+	// Replaying the Raft log doesn't change what's recorded.
+	require.NoError(t, l.add(&pb.ClusterEvent{Index: 1, Type: eventMemberRemoved}))
+
+	all, err := l.list(&eventFilter{})
+	require.NoError(t, err)
+	require.Equal(t, []string{eventMemberJoined, eventLeaderElected, eventTabletMoveStarted,
+		eventTabletMoved, eventExport}, types(all))
+
+	events, err := l.list(&eventFilter{limit: 2})
+	require.NoError(t, err)
+	require.Equal(t, []string{eventTabletMoved, eventExport}, types(events))
+
+	events, err = l.list(&eventFilter{
+		since: start.Add(time.Minute),
+		until: start.Add(4 * time.Minute),
+		types: map[string]bool{eventLeaderElected: true, eventTabletMoved: true},
+	})
+	require.NoError(t, err)
+	require.Equal(t, []string{eventLeaderElected, eventTabletMoved}, types(events))
+
+	require.NoError(t, l.trim())
+	events, err = l.list(&eventFilter{})
+	require.NoError(t, err)
+	require.Equal(t, []string{eventTabletMoveStarted, eventTabletMoved, eventExport},
+		types(events))
+}
+
+func TestEventsFilter(t *testing.T) {
+	f, err := eventsFilter(httptest.NewRequest("GET", "/events", nil))
+	require.NoError(t, err)
+	require.Equal(t, defaultEventLimit, f.limit)
+	require.True(t, f.since.IsZero())
+
+	f, err = eventsFilter(httptest.NewRequest("GET",
+		"/events?since=2018-11-01T10:00:00Z&type=export,tablet_moved&limit=0", nil))
+	require.NoError(t, err)
+	require.Equal(t, 0, f.limit)
+	require.Equal(t, time.Date(2018, 11, 1, 10, 0, 0, 0, time.UTC), f.since.UTC())
+	require.Equal(t, map[string]bool{eventExport: true, eventTabletMoved: true}, f.types)
+
+	_, err = eventsFilter(httptest.NewRequest("GET", "/events?until=yesterday", nil))
+	require.Error(t, err)
+	_, err = eventsFilter(httptest.NewRequest("GET", "/events?limit=-1", nil))
+	require.Error(t, err)
+}
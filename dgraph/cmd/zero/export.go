@@ -0,0 +1,218 @@
+/*
+ * Copyright 2018 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package zero
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/dgraph-io/dgraph/protos/pb"
+	"github.com/dgraph-io/dgraph/worker"
+	"github.com/dgraph-io/dgraph/x"
+	"github.com/golang/glog"
+)
+
+const (
+	exportRunning = "running"
+	exportDone    = "done"
+	exportFailed  = "failed"
+
+	// Number of attempts to export a group, picking its leader again every time.
+	exportAttempts = 3
+	// Number of finished exports whose status is kept around.
+	exportHistory = 10
+)
+
+// groupExport is the status of the export of a single group.
+type groupExport struct {
+	GroupId    uint32     `json:"group_id"`
+	Status     string     `json:"status"`
+	Addr       string     `json:"addr,omitempty"`
+	Dir        string     `json:"dir,omitempty"`
+	Error      string     `json:"error,omitempty"`
+	Attempts   int        `json:"attempts"`
+	FinishedAt *time.Time `json:"finished_at,omitempty"`
+}
+
+// exportJob is a cluster-wide export, for which all groups export their data at the same read
+// timestamp. The read timestamp identifies the export. Once done, the job doubles as the
+// manifest of the export, listing where the data of each group has been written.
+type exportJob struct {
+	ReadTs     uint64         `json:"read_ts"`
+	UnixTs     int64          `json:"unix_ts"`
+	Format     string         `json:"format"`
+	Status     string         `json:"status"`
+	StartedAt  time.Time      `json:"started_at"`
+	FinishedAt *time.Time     `json:"finished_at,omitempty"`
+	Groups     []*groupExport `json:"groups"`
+}
+
+// Export jobs are only tracked in memory on the leader which coordinated them.
+type exportJobs struct {
+	sync.Mutex
+	jobs []*exportJob
+}
+
+// startExport picks a read timestamp and asks every group to export its data at it. It returns
+// right away, the progress of the export can be followed via exportStatus.
+func (s *Server) startExport(format string) (*exportJob, error) {
+	// Rejected before any group is asked, rather than by every group.
+	if err := worker.ValidateExportFormat(format); err != nil {
+		return nil, err
+	}
+	if !s.Node.AmLeader() {
+		return nil, x.Errorf("Exports can only be coordinated by the leader of Zero")
+	}
+	gids := s.KnownGroups()
+	if len(gids) == 0 {
+		return nil, x.Errorf("No groups to export")
+	}
+	sort.Slice(gids, func(i, j int) bool { return gids[i] < gids[j] })
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	ts, err := s.Timestamps(ctx, &pb.Num{ReadOnly: true})
+	if err != nil {
+		return nil, x.Wrapf(err, "while getting the read timestamp for export")
+	}
+
+	now := time.Now()
+	job := &exportJob{
+		ReadTs:    ts.ReadOnly,
+		UnixTs:    now.Unix(),
+		Format:    format,
+		Status:    exportRunning,
+		StartedAt: now,
+	}
+	for _, gid := range gids {
+		job.Groups = append(job.Groups, &groupExport{GroupId: gid, Status: exportRunning})
+	}
+
+	s.exports.Lock()
+	s.exports.jobs = append(s.exports.jobs, job)
+	if n := len(s.exports.jobs); n > exportHistory {
+		s.exports.jobs = s.exports.jobs[n-exportHistory:]
+	}
+	cp := job.copy()
+	s.exports.Unlock()
+
+	glog.Infof("Starting export of groups %v at readTs %d", gids, job.ReadTs)
+	go s.runExport(job)
+	return cp, nil
+}
+
+func (s *Server) runExport(job *exportJob) {
+	var wg sync.WaitGroup
+	for _, g := range job.Groups {
+		wg.Add(1)
+		go func(g *groupExport) {
+			defer wg.Done()
+			s.exportGroup(job, g)
+		}(g)
+	}
+	wg.Wait()
+
+	s.exports.Lock()
+	defer s.exports.Unlock()
+	job.Status = exportDone
+	for _, g := range job.Groups {
+		if g.Status != exportDone {
+			job.Status = exportFailed
+		}
+	}
+	now := time.Now()
+	job.FinishedAt = &now
+	glog.Infof("Export at readTs %d finished with status: %s", job.ReadTs, job.Status)
+	go s.proposeEvent(newEvent(eventExport, 0, 0, "%s export at readTs %d finished with status %s",
+		job.Format, job.ReadTs, job.Status))
+}
+
+// exportGroup asks the leader of the group to export it. Exports are idempotent, as the output
+// only depends on the request, so failed attempts can simply be retried.
+func (s *Server) exportGroup(job *exportJob, g *groupExport) {
+	req := &pb.ExportRequest{
+		GroupId: g.GroupId,
+		ReadTs:  job.ReadTs,
+		UnixTs:  job.UnixTs,
+		Format:  job.Format,
+	}
+	var dir, addr string
+	var err error
+	for i := 1; i <= exportAttempts; i++ {
+		if i > 1 {
+			time.Sleep(time.Duration(i) * time.Second)
+		}
+		s.exports.Lock()
+		g.Attempts = i
+		s.exports.Unlock()
+
+		pl := s.Leader(g.GroupId)
+		if pl == nil {
+			err = x.Errorf("No healthy connection found to leader of group %d", g.GroupId)
+			continue
+		}
+		addr = pl.Addr
+		var st *pb.Status
+		st, err = pb.NewWorkerClient(pl.Get()).Export(context.Background(), req)
+		if err == nil {
+			dir = st.GetMsg()
+			break
+		}
+		glog.Warningf("Attempt %d to export group %d at readTs %d via %s failed: %v",
+			i, g.GroupId, job.ReadTs, addr, err)
+	}
+
+	s.exports.Lock()
+	defer s.exports.Unlock()
+	g.Addr = addr
+	now := time.Now()
+	g.FinishedAt = &now
+	if err != nil {
+		g.Status = exportFailed
+		g.Error = err.Error()
+		return
+	}
+	g.Status = exportDone
+	g.Dir = dir
+}
+
+// exportStatus returns the export with the given read timestamp, or all tracked exports if the
+// timestamp is zero.
+func (s *Server) exportStatus(readTs uint64) []*exportJob {
+	s.exports.Lock()
+	defer s.exports.Unlock()
+	var res []*exportJob
+	for _, job := range s.exports.jobs {
+		if readTs == 0 || job.ReadTs == readTs {
+			res = append(res, job.copy())
+		}
+	}
+	return res
+}
+
+// copy returns a deep copy of the job. It must be called with the lock of the jobs held.
+func (job *exportJob) copy() *exportJob {
+	cp := *job
+	cp.Groups = make([]*groupExport, 0, len(job.Groups))
+	for _, g := range job.Groups {
+		gc := *g
+		cp.Groups = append(cp.Groups, &gc)
+	}
+	return &cp
+}
@@ -0,0 +1,40 @@
+/*
+ * Copyright 2016-2018 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package zero
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestStartExportFormat(t *testing.T) {
+	// Unknown formats are rejected before anything else, even off the leader.
+	s := &Server{}
+	_, err := s.startExport("json")
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "Invalid export format")
+	require.Empty(t, s.exportStatus(0))
+
+	st := &state{zero: s}
+	w := httptest.NewRecorder()
+	st.export(w, httptest.NewRequest(http.MethodPost, "/export?format=xml", nil))
+	require.Equal(t, http.StatusBadRequest, w.Code)
+	require.Contains(t, w.Body.String(), "Invalid export format")
+}
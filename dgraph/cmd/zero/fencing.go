@@ -0,0 +1,180 @@
+/*
+ * Copyright 2018 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package zero
+
+import (
+	"sync"
+	"time"
+
+	"github.com/dgraph-io/dgraph/x"
+)
+
+// Fencing leases let external processes, like backup agents, elect a single active worker. A
+// lease is held by one holder at a time and comes with a fencing token. Tokens only ever increase:
+// the Raft term of the Zero leader makes up the upper 32 bits, and a counter of the leases granted
+// during the term the lower ones. Resources acted upon by the workers should reject requests
+// carrying a lower token than the highest one they've seen, which keeps a worker which lost its
+// lease (e.g. due to a long GC pause) from doing any harm.
+//
+// Leases are only kept in memory by the leader. To make sure the leases granted by a previous
+// leader have expired, a new leader doesn't grant any leases for maxFencingTTL.
+const (
+	defaultFencingTTL = 10 * time.Second
+	maxFencingTTL     = time.Minute
+)
+
+var errFencingHeld = x.Errorf("Lease is held by another holder")
+
+type fencingLease struct {
+	Name      string    `json:"name"`
+	Holder    string    `json:"holder"`
+	Token     uint64    `json:"token"`
+	Term      uint64    `json:"term"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+type fencingLeases struct {
+	sync.Mutex
+	term        uint64    // Raft term in which the leases were granted.
+	leaderSince time.Time // When this node was first seen as leader for the term.
+	seq         uint32
+	leases      map[string]*fencingLease
+}
+
+// resetIfNewTerm drops all the state kept for a previous term.
+func (f *fencingLeases) resetIfNewTerm(term uint64, now time.Time) {
+	if f.term == term {
+		return
+	}
+	f.term = term
+	f.leaderSince = now
+	f.seq = 0
+	f.leases = make(map[string]*fencingLease)
+}
+
+// acquire grants the lease to holder, or renews it if holder already holds it. Renewing keeps the
+// token unchanged.
+func (f *fencingLeases) acquire(term uint64, name, holder string, ttl time.Duration,
+	now time.Time) (*fencingLease, error) {
+	f.Lock()
+	defer f.Unlock()
+	f.resetIfNewTerm(term, now)
+
+	if l, ok := f.leases[name]; ok && now.Before(l.ExpiresAt) {
+		if l.Holder != holder {
+			return nil, errFencingHeld
+		}
+		l.ExpiresAt = now.Add(ttl)
+		cp := *l
+		return &cp, nil
+	}
+	if wait := maxFencingTTL - now.Sub(f.leaderSince); wait > 0 {
+		return nil, x.Errorf("Zero leader was elected recently. Retry in %v.",
+			wait.Round(time.Second))
+	}
+	if f.seq == 1<<32-1 {
+		return nil, x.Errorf("Out of fencing tokens for term %d", term)
+	}
+	f.seq++
+	l := &fencingLease{
+		Name:      name,
+		Holder:    holder,
+		Token:     term<<32 | uint64(f.seq),
+		Term:      term,
+		ExpiresAt: now.Add(ttl),
+	}
+	f.leases[name] = l
+	cp := *l
+	return &cp, nil
+}
+
+// release gives up the lease, if it's held by holder.
+func (f *fencingLeases) release(term uint64, name, holder string, now time.Time) error {
+	f.Lock()
+	defer f.Unlock()
+	f.resetIfNewTerm(term, now)
+
+	l, ok := f.leases[name]
+	if !ok || !now.Before(l.ExpiresAt) {
+		return nil
+	}
+	if l.Holder != holder {
+		return errFencingHeld
+	}
+	delete(f.leases, name)
+	return nil
+}
+
+// current returns the unexpired lease with the given name, if any.
+func (f *fencingLeases) current(term uint64, name string, now time.Time) *fencingLease {
+	f.Lock()
+	defer f.Unlock()
+	f.resetIfNewTerm(term, now)
+
+	l, ok := f.leases[name]
+	if !ok || !now.Before(l.ExpiresAt) {
+		return nil
+	}
+	cp := *l
+	return &cp
+}
+
+// leaderTerm returns the current Raft term, if this node is the leader.
+func (s *Server) leaderTerm() (uint64, error) {
+	if s.Node == nil || s.Node.Raft() == nil {
+		return 0, x.Errorf("Zero is not ready yet")
+	}
+	st := s.Node.Raft().Status()
+	if st.Lead != st.ID {
+		return 0, x.Errorf("Fencing leases are only served by the leader of Zero")
+	}
+	return st.Term, nil
+}
+
+func (s *Server) acquireFencingLease(name, holder string,
+	ttl time.Duration) (*fencingLease, error) {
+	if len(name) == 0 || len(holder) == 0 {
+		return nil, x.Errorf("Both the name of the lease and its holder are required")
+	}
+	if ttl == 0 {
+		ttl = defaultFencingTTL
+	}
+	if ttl < 0 || ttl > maxFencingTTL {
+		return nil, x.Errorf("TTL of a lease must be between 0 and %v", maxFencingTTL)
+	}
+	term, err := s.leaderTerm()
+	if err != nil {
+		return nil, err
+	}
+	return s.fencing.acquire(term, name, holder, ttl, time.Now())
+}
+
+func (s *Server) releaseFencingLease(name, holder string) error {
+	term, err := s.leaderTerm()
+	if err != nil {
+		return err
+	}
+	return s.fencing.release(term, name, holder, time.Now())
+}
+
+func (s *Server) currentFencingLease(name string) (*fencingLease, error) {
+	term, err := s.leaderTerm()
+	if err != nil {
+		return nil, err
+	}
+	return s.fencing.current(term, name, time.Now()), nil
+}
@@ -0,0 +1,65 @@
+/*
+ * Copyright 2018 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package zero
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFencingLeases(t *testing.T) {
+	var f fencingLeases
+	now := time.Now()
+
+	// No leases right after becoming leader.
+	_, err := f.acquire(2, "backup", "a", time.Second, now)
+	require.Error(t, err)
+
+	now = now.Add(maxFencingTTL)
+	l, err := f.acquire(2, "backup", "a", time.Second, now)
+	require.NoError(t, err)
+	require.Equal(t, uint64(2<<32|1), l.Token)
+
+	_, err = f.acquire(2, "backup", "b", time.Second, now)
+	require.Equal(t, errFencingHeld, err)
+
+	// Renewing keeps the token.
+	renewed, err := f.acquire(2, "backup", "a", time.Second, now.Add(time.Second/2))
+	require.NoError(t, err)
+	require.Equal(t, l.Token, renewed.Token)
+
+	// Once expired, the lease goes to the next holder, with a higher token.
+	now = now.Add(2 * time.Second)
+	require.Nil(t, f.current(2, "backup", now))
+	next, err := f.acquire(2, "backup", "b", time.Second, now)
+	require.NoError(t, err)
+	require.True(t, next.Token > l.Token)
+
+	require.Equal(t, errFencingHeld, f.release(2, "backup", "a", now))
+	require.NoError(t, f.release(2, "backup", "b", now))
+	require.Nil(t, f.current(2, "backup", now))
+
+	// Tokens of a later term are higher than all tokens of earlier terms.
+	now = now.Add(maxFencingTTL)
+	require.Nil(t, f.current(3, "backup", now))
+	now = now.Add(maxFencingTTL)
+	later, err := f.acquire(3, "backup", "c", time.Second, now)
+	require.NoError(t, err)
+	require.True(t, later.Token > next.Token)
+}
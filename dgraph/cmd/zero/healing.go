@@ -0,0 +1,128 @@
+/*
+ * Copyright 2018 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package zero
+
+import (
+	"sort"
+	"time"
+
+	"github.com/golang/glog"
+	"golang.org/x/net/context"
+
+	"github.com/dgraph-io/dgraph/protos/pb"
+)
+
+// healInterval is how often the leader looks for dead members, if --dead_after is set.
+const healInterval = 10 * time.Second
+
+// seen returns when the Alpha with the given id was last heard of, if ever.
+func (l *liveness) seen(id uint64) (time.Time, bool) {
+	l.Lock()
+	defer l.Unlock()
+	at, ok := l.lastSeen[id]
+	return at, ok
+}
+
+// deadMembers returns the members of the groups in state last seen before cutoff, which can be
+// removed while a quorum of the voters of their groups is alive. At most one member is
+// returned per group, the one unseen for the longest, so that a group doesn't lose all its
+// members at once to a network partition.
+func deadMembers(state *pb.MembershipState, lastSeen func(id uint64) time.Time,
+	cutoff time.Time) []*pb.Member {
+	var dead []*pb.Member
+	for _, group := range state.GetGroups() {
+		var alive int
+		var oldest *pb.Member
+		for id, m := range group.Members {
+			if !lastSeen(id).Before(cutoff) {
+				if !m.Learner {
+					alive++
+				}
+				continue
+			}
+			if oldest == nil || lastSeen(id).Before(lastSeen(oldest.Id)) ||
+				(lastSeen(id).Equal(lastSeen(oldest.Id)) && id < oldest.Id) {
+				oldest = m
+			}
+		}
+		// The removal is a Raft conf change of the group, which a quorum of its current voters
+		// must commit.
+		if oldest != nil && alive > voters(group)/2 {
+			dead = append(dead, oldest)
+		}
+	}
+	sort.Slice(dead, func(i, j int) bool { return dead[i].Id < dead[j].Id })
+	return dead
+}
+
+// healPeriodically removes the members which weren't heard of for deadAfter from their groups,
+// while this node is the leader. The groups missing replicas then get them from the spare
+// Alphas, or from the next Alphas to connect.
+func (s *Server) healPeriodically(deadAfter time.Duration) {
+	glog.Infof("Removing the Alphas unseen for %s from their groups", deadAfter)
+	ticker := time.NewTicker(healInterval)
+	defer ticker.Stop()
+
+	// Liveness is only kept in memory, so a new leader gives every member deadAfter to be heard.
+	var leaderSince time.Time
+	for {
+		select {
+		case <-s.shutDownCh:
+			return
+		case <-ticker.C:
+		}
+		if !s.Node.AmLeader() {
+			leaderSince = time.Time{}
+			continue
+		}
+		if leaderSince.IsZero() {
+			leaderSince = time.Now()
+		}
+		lastSeen := func(id uint64) time.Time {
+			if at, ok := s.alive.seen(id); ok && at.After(leaderSince) {
+				return at
+			}
+			return leaderSince
+		}
+		for _, m := range deadMembers(s.membershipState(), lastSeen, time.Now().Add(-deadAfter)) {
+			glog.Warningf("Removing member %d of group %d at %s, unseen since %s",
+				m.Id, m.GroupId, m.Addr, lastSeen(m.Id).Format(time.RFC3339))
+			ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+			if err := s.removeNode(ctx, m.Id, m.GroupId); err != nil {
+				glog.Errorf("While removing dead member %d of group %d: %v", m.Id, m.GroupId, err)
+			}
+			cancel()
+		}
+	}
+}
+
+// groupMissingReplica returns the group with the fewest voters among those with less than
+// their replicas, if any. Spare Alphas only join such groups.
+func (s *Server) groupMissingReplica() (uint32, bool) {
+	var gid uint32
+	var best *pb.Group
+	for id, group := range s.state.Groups {
+		n := voters(group)
+		if n == 0 || n >= s.groupReplicas(group) {
+			continue
+		}
+		if best == nil || n < voters(best) || (n == voters(best) && id < gid) {
+			gid, best = id, group
+		}
+	}
+	return gid, best != nil
+}
@@ -0,0 +1,89 @@
+/*
+ * Copyright 2018 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package zero
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dgraph-io/dgraph/protos/pb"
+)
+
+func TestDeadMembers(t *testing.T) {
+	now := time.Now()
+	seen := map[uint64]time.Time{
+		1: now, 2: now, 3: now.Add(-2 * time.Hour), 11: now, 12: now.Add(-time.Hour), // Group 1.
+		4: now.Add(-time.Hour), 5: now, // Group 2.
+		6: now, 7: now.Add(-time.Hour), 8: now.Add(-time.Hour), // Group 3.
+		9: now.Add(-time.Hour), // Group 4.
+	}
+	members := func(ids ...uint64) map[uint64]*pb.Member {
+		ms := make(map[uint64]*pb.Member)
+		for _, id := range ids {
+			ms[id] = &pb.Member{Id: id}
+		}
+		return ms
+	}
+	state := &pb.MembershipState{Groups: map[uint32]*pb.Group{
+		1: {Members: members(1, 2, 3, 10, 11, 12)},
+		2: {Members: members(4, 5)},
+		3: {Members: members(6, 7, 8)},
+		4: {Members: members(9)},
+	}}
+	state.Groups[1].Members[10].Learner = true
+	seen[10] = now
+	lastSeen := func(id uint64) time.Time { return seen[id] }
+
+	dead := deadMembers(state, lastSeen, now.Add(-time.Minute))
+	// Group 1 only loses the member unseen for the longest. Groups 2 and 3 would need their dead
+	// members to commit the removal, and group 4 has no member left to do it.
+	require.Len(t, dead, 1)
+	require.Equal(t, uint64(3), dead[0].Id)
+
+	// Dead learners can go as long as a quorum of voters is alive.
+	seen[10] = now.Add(-time.Hour)
+	state.Groups[1].Members = members(1, 10)
+	state.Groups[1].Members[10].Learner = true
+	dead = deadMembers(state, lastSeen, now.Add(-time.Minute))
+	require.Len(t, dead, 1)
+	require.Equal(t, uint64(10), dead[0].Id)
+
+	require.Empty(t, deadMembers(state, lastSeen, now.Add(-2*time.Hour)))
+}
+
+func TestGroupMissingReplica(t *testing.T) {
+	s := &Server{NumReplicas: 3, state: &pb.MembershipState{Groups: map[uint32]*pb.Group{
+		1: {Members: map[uint64]*pb.Member{1: {Id: 1}, 2: {Id: 2}, 3: {Id: 3}}},
+		2: {Members: map[uint64]*pb.Member{4: {Id: 4}, 5: {Id: 5}}},
+		3: {Members: map[uint64]*pb.Member{6: {Id: 6}, 7: {Id: 7, Learner: true}}},
+		4: {},
+	}}}
+	gid, ok := s.groupMissingReplica()
+	require.True(t, ok)
+	require.Equal(t, uint32(3), gid)
+
+	delete(s.state.Groups, 3)
+	gid, ok = s.groupMissingReplica()
+	require.True(t, ok)
+	require.Equal(t, uint32(2), gid)
+
+	s.NumReplicas = 1
+	_, ok = s.groupMissingReplica()
+	require.False(t, ok)
+}
@@ -0,0 +1,244 @@
+/*
+ * Copyright 2018 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package zero
+
+import (
+	"encoding/json"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/dgraph-io/dgo/protos/api"
+	"golang.org/x/net/context"
+
+	"github.com/dgraph-io/dgraph/conn"
+	"github.com/dgraph-io/dgraph/protos/pb"
+	"github.com/dgraph-io/dgraph/x"
+)
+
+// Statuses of the nodes, the same as in the health reports of the Alphas.
+const (
+	healthy   = "healthy"
+	degraded  = "degraded"
+	unhealthy = "unhealthy"
+)
+
+const (
+	// healthTimeout bounds the probe of every node by /health/cluster.
+	healthTimeout = 5 * time.Second
+	// maxAppliedLag is how far behind the applied index of a Zero can be before it's degraded.
+	maxAppliedLag = 1000
+)
+
+// nodeHealth is the health of a node of the cluster, as reported by the node itself. Alphas report
+// more, which is left out.
+type nodeHealth struct {
+	Id            uint64 `json:"id"`
+	Group         uint32 `json:"group"`
+	Addr          string `json:"addr"`
+	Zero          bool   `json:"zero"`
+	Status        string `json:"status"`
+	Error         string `json:"error,omitempty"`
+	Version       string `json:"version,omitempty"`
+	Leader        bool   `json:"leader"`
+	Learner       bool   `json:"learner"`
+	LastHeartbeat int64  `json:"last_heartbeat,omitempty"`
+	AppliedIndex  uint64 `json:"applied_index"`
+	AppliedLag    uint64 `json:"applied_lag"`
+	DiskFree      uint64 `json:"disk_free_bytes,omitempty"`
+	DiskTotal     uint64 `json:"disk_total_bytes,omitempty"`
+}
+
+// clusterHealth is the document served by /health/cluster.
+type clusterHealth struct {
+	Status string       `json:"status"`
+	Nodes  []nodeHealth `json:"nodes"`
+}
+
+// health checks this Zero: whether it knows of a leader, how far behind its applied index is and
+// how much of the disk holding its WAL is free.
+func (s *Server) health() *nodeHealth {
+	h := &nodeHealth{
+		Id:      s.Node.Id,
+		Addr:    opts.myAddr,
+		Zero:    true,
+		Status:  healthy,
+		Version: x.Version(),
+		Learner: opts.learner,
+	}
+	if s.Node.Raft() == nil {
+		h.Status, h.Error = unhealthy, "Not started yet"
+		return h
+	}
+	st := s.Node.Raft().Status()
+	h.Leader = st.Lead == st.ID
+	h.AppliedIndex = s.Node.Applied.DoneUntil()
+	if st.Commit > h.AppliedIndex {
+		h.AppliedLag = st.Commit - h.AppliedIndex
+	}
+	if free, total, err := x.DiskUsage(opts.w); err == nil {
+		h.DiskFree, h.DiskTotal = free, total
+	}
+	switch {
+	case st.Lead == 0:
+		h.Status, h.Error = unhealthy, "No leader"
+	case h.AppliedLag > maxAppliedLag:
+		h.Status = degraded
+	}
+	return h
+}
+
+// Health returns the health of this Zero as JSON, for the Zero serving /health/cluster.
+func (s *Server) Health(ctx context.Context, _ *api.Payload) (*api.Payload, error) {
+	if ctx.Err() != nil {
+		return &api.Payload{}, ctx.Err()
+	}
+	js, err := json.Marshal(s.health())
+	if err != nil {
+		return &api.Payload{}, err
+	}
+	return &api.Payload{Data: js}, nil
+}
+
+// probe asks the node at h.Addr for its health, and fills h with it.
+func probe(ctx context.Context, h *nodeHealth) {
+	pl, err := conn.Get().Get(h.Addr)
+	if err != nil {
+		h.Status, h.Error = unhealthy, err.Error()
+		return
+	}
+	var reply *api.Payload
+	if h.Zero {
+		reply, err = pb.NewZeroClient(pl.Get()).Health(ctx, &api.Payload{})
+	} else {
+		reply, err = pb.NewWorkerClient(pl.Get()).Health(ctx, &api.Payload{})
+	}
+	if err == nil {
+		// The node knows its own group and address best, but not how Zero sees it.
+		zero, learner, heartbeat := h.Zero, h.Learner, h.LastHeartbeat
+		err = json.Unmarshal(reply.GetData(), h)
+		h.Zero, h.Learner, h.LastHeartbeat = zero, learner, heartbeat
+	}
+	if err != nil {
+		h.Status, h.Error = unhealthy, err.Error()
+	}
+}
+
+// clusterStatus returns the status of the cluster in state, given the health of its nodes. It's
+// unhealthy if Zero or any group lost the quorum of its voters, and degraded if any node isn't
+// healthy.
+func clusterStatus(state *pb.MembershipState, nodes []nodeHealth) string {
+	status := healthy
+	down := make(map[uint32]int)
+	downZeros := 0
+	for _, h := range nodes {
+		if h.Status != healthy {
+			status = degraded
+		}
+		if h.Status != unhealthy || h.Learner {
+			continue
+		}
+		if h.Zero {
+			downZeros++
+		} else {
+			down[h.Group]++
+		}
+	}
+	if downZeros > 0 && 2*downZeros >= voterZeros(state) {
+		return unhealthy
+	}
+	for gid, group := range state.GetGroups() {
+		if down[gid] > 0 && 2*down[gid] >= voters(group) {
+			return unhealthy
+		}
+	}
+	return status
+}
+
+func voterZeros(state *pb.MembershipState) int {
+	var n int
+	for _, m := range state.GetZeros() {
+		if !m.Learner {
+			n++
+		}
+	}
+	return n
+}
+
+// clusterHealth probes every member of the cluster in parallel, this Zero included.
+func (s *Server) clusterHealth(ctx context.Context) *clusterHealth {
+	state := s.membershipState()
+	var nodes []nodeHealth
+	for id, m := range state.GetZeros() {
+		if id == s.Node.Id {
+			continue
+		}
+		nodes = append(nodes, nodeHealth{Id: id, Addr: m.Addr, Zero: true, Learner: m.Learner})
+	}
+	for gid, group := range state.GetGroups() {
+		for id, m := range group.Members {
+			h := nodeHealth{Id: id, Group: gid, Addr: m.Addr, Learner: m.Learner}
+			if at, ok := s.alive.seen(id); ok {
+				h.LastHeartbeat = at.Unix()
+			}
+			nodes = append(nodes, h)
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, healthTimeout)
+	defer cancel()
+	var wg sync.WaitGroup
+	for i := range nodes {
+		wg.Add(1)
+		go func(h *nodeHealth) {
+			defer wg.Done()
+			probe(ctx, h)
+		}(&nodes[i])
+	}
+	wg.Wait()
+
+	nodes = append(nodes, *s.health())
+	sort.Slice(nodes, func(i, j int) bool {
+		if nodes[i].Zero != nodes[j].Zero {
+			return nodes[i].Zero
+		}
+		if nodes[i].Group != nodes[j].Group {
+			return nodes[i].Group < nodes[j].Group
+		}
+		return nodes[i].Id < nodes[j].Id
+	})
+	return &clusterHealth{Status: clusterStatus(state, nodes), Nodes: nodes}
+}
+
+// getClusterHealth serves /health/cluster. It responds with 503 Service Unavailable if the cluster
+// is unhealthy, so that it can be used as a readiness probe.
+func (st *state) getClusterHealth(w http.ResponseWriter, r *http.Request) {
+	x.AddCorsHeaders(w)
+	w.Header().Set("Content-Type", "application/json")
+
+	ch := st.zero.clusterHealth(r.Context())
+	js, err := json.Marshal(ch)
+	if err != nil {
+		x.SetStatus(w, x.Error, err.Error())
+		return
+	}
+	if ch.Status == unhealthy {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}
+	w.Write(js)
+}
@@ -0,0 +1,65 @@
+/*
+ * Copyright 2018 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package zero
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dgraph-io/dgraph/protos/pb"
+)
+
+func TestClusterStatus(t *testing.T) {
+	state := &pb.MembershipState{
+		Zeros: map[uint64]*pb.Member{1: {Id: 1}, 2: {Id: 2}, 3: {Id: 3}, 4: {Id: 4, Learner: true}},
+		Groups: map[uint32]*pb.Group{
+			1: {Members: map[uint64]*pb.Member{1: {Id: 1}, 2: {Id: 2}, 3: {Id: 3}}},
+			2: {Members: map[uint64]*pb.Member{4: {Id: 4}}},
+		},
+	}
+	nodes := func(down ...nodeHealth) []nodeHealth {
+		all := []nodeHealth{
+			{Id: 1, Zero: true}, {Id: 2, Zero: true}, {Id: 3, Zero: true},
+			{Id: 4, Zero: true, Learner: true},
+			{Id: 1, Group: 1}, {Id: 2, Group: 1}, {Id: 3, Group: 1}, {Id: 4, Group: 2},
+		}
+		for i := range all {
+			all[i].Status = healthy
+			for _, d := range down {
+				if all[i].Id == d.Id && all[i].Zero == d.Zero {
+					all[i].Status = d.Status
+				}
+			}
+		}
+		return all
+	}
+	require.Equal(t, healthy, clusterStatus(state, nodes()))
+	require.Equal(t, degraded, clusterStatus(state, nodes(nodeHealth{Id: 2, Status: degraded})))
+
+	// Groups and Zero keep their quorum with one voter down out of three.
+	require.Equal(t, degraded, clusterStatus(state, nodes(nodeHealth{Id: 1, Status: unhealthy})))
+	require.Equal(t, degraded, clusterStatus(state, nodes(
+		nodeHealth{Id: 3, Zero: true, Status: unhealthy},
+		nodeHealth{Id: 4, Zero: true, Status: unhealthy})))
+	require.Equal(t, unhealthy, clusterStatus(state, nodes(
+		nodeHealth{Id: 2, Zero: true, Status: unhealthy},
+		nodeHealth{Id: 3, Zero: true, Status: unhealthy})))
+
+	// Group 2 has a single member.
+	require.Equal(t, unhealthy, clusterStatus(state, nodes(nodeHealth{Id: 4, Status: unhealthy})))
+}
@@ -19,6 +19,7 @@ package zero
 import (
 	"context"
 	"fmt"
+	"math"
 	"net"
 	"net/http"
 	"strconv"
@@ -26,6 +27,7 @@ import (
 	"time"
 
 	"github.com/dgraph-io/dgraph/protos/pb"
+	"github.com/dgraph-io/dgraph/worker"
 	"github.com/dgraph-io/dgraph/x"
 	"github.com/gogo/protobuf/jsonpb"
 	"github.com/golang/glog"
@@ -175,13 +177,429 @@ func (st *state) moveTablet(w http.ResponseWriter, r *http.Request) {
 		tablet, srcGroup, dstGroup)))
 }
 
+// splitTablet splits the part of the tablet given by the tablet query parameter held by the group
+// given by from, or the biggest part if it's missing, to the group given by group.
+func (st *state) splitTablet(w http.ResponseWriter, r *http.Request) {
+	x.AddCorsHeaders(w)
+	if r.Method == "OPTIONS" {
+		return
+	}
+	if r.Method != http.MethodGet {
+		w.WriteHeader(http.StatusBadRequest)
+		x.SetStatus(w, x.ErrorInvalidMethod, "Invalid method")
+		return
+	}
+
+	tablet := r.URL.Query().Get("tablet")
+	if len(tablet) == 0 {
+		w.WriteHeader(http.StatusBadRequest)
+		x.SetStatus(w, x.ErrorInvalidRequest, "tablet is a mandatory query parameter")
+		return
+	}
+	groupId, ok := intFromQueryParam(w, r, "group")
+	if !ok {
+		return
+	}
+	dstGroup := uint32(groupId)
+	var isKnown bool
+	for _, grp := range st.zero.KnownGroups() {
+		if grp == dstGroup {
+			isKnown = true
+			break
+		}
+	}
+	if !isKnown {
+		w.WriteHeader(http.StatusBadRequest)
+		x.SetStatus(w, x.ErrorInvalidRequest, fmt.Sprintf("Group: [%d] is not a known group.",
+			dstGroup))
+		return
+	}
+
+	tab := st.zero.ServingTablet(tablet)
+	if tab == nil {
+		w.WriteHeader(http.StatusBadRequest)
+		x.SetStatus(w, x.ErrorInvalidRequest, fmt.Sprintf("No tablet found for: %s", tablet))
+		return
+	}
+	var srcGroup uint32
+	if len(r.URL.Query().Get("from")) > 0 {
+		from, ok := intFromQueryParam(w, r, "from")
+		if !ok {
+			return
+		}
+		srcGroup = uint32(from)
+	} else {
+		var size int64 = -1
+		for _, part := range tabletParts(tab) {
+			if part.space > size {
+				srcGroup, size = part.gid, part.space
+			}
+		}
+	}
+
+	if err := st.zero.splitTablet(tablet, srcGroup, dstGroup); err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		x.SetStatus(w, x.Error, err.Error())
+		return
+	}
+
+	w.Write([]byte(fmt.Sprintf("Predicate: [%s] split from group: [%d] to [%d]",
+		tablet, srcGroup, dstGroup)))
+}
+
+// tabletReplicas sets the number of replicas wanted for the tablet given by the tablet query
+// parameter to the one given by replicas, or to --replicas if it's zero. The tablet is then moved
+// or copied to other groups by the rebalancing.
+func (st *state) tabletReplicas(w http.ResponseWriter, r *http.Request) {
+	x.AddCorsHeaders(w)
+	if r.Method == "OPTIONS" {
+		return
+	}
+	if r.Method != http.MethodGet {
+		w.WriteHeader(http.StatusBadRequest)
+		x.SetStatus(w, x.ErrorInvalidMethod, "Invalid method")
+		return
+	}
+
+	tablet := r.URL.Query().Get("tablet")
+	if len(tablet) == 0 {
+		w.WriteHeader(http.StatusBadRequest)
+		x.SetStatus(w, x.ErrorInvalidRequest, "tablet is a mandatory query parameter")
+		return
+	}
+	replicas, ok := intFromQueryParam(w, r, "replicas")
+	if !ok {
+		return
+	}
+	if replicas > math.MaxUint32 {
+		w.WriteHeader(http.StatusBadRequest)
+		x.SetStatus(w, x.ErrorInvalidRequest, fmt.Sprintf("Invalid replicas: %d", replicas))
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	if err := st.zero.setTabletReplicas(ctx, tablet, uint32(replicas)); err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		x.SetStatus(w, x.Error, err.Error())
+		return
+	}
+
+	if replicas == 0 {
+		replicas = uint64(st.zero.NumReplicas)
+	}
+	w.Write([]byte(fmt.Sprintf("Predicate: [%s] wants %d replicas", tablet, replicas)))
+}
+
+// renamePredicate renames the predicate given by the from query parameter to the one given by
+// the to query parameter.
+func (st *state) renamePredicate(w http.ResponseWriter, r *http.Request) {
+	x.AddCorsHeaders(w)
+	if r.Method == "OPTIONS" {
+		return
+	}
+	if r.Method != http.MethodGet {
+		w.WriteHeader(http.StatusBadRequest)
+		x.SetStatus(w, x.ErrorInvalidMethod, "Invalid method")
+		return
+	}
+
+	from := r.URL.Query().Get("from")
+	to := r.URL.Query().Get("to")
+	if len(from) == 0 || len(to) == 0 {
+		w.WriteHeader(http.StatusBadRequest)
+		x.SetStatus(w, x.ErrorInvalidRequest, "from and to are mandatory query parameters")
+		return
+	}
+
+	if err := st.zero.renamePredicate(from, to); err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		x.SetStatus(w, x.Error, err.Error())
+		return
+	}
+
+	w.Write([]byte(fmt.Sprintf("Predicate: [%s] renamed to [%s]", from, to)))
+}
+
+// namespaces lists the namespaces (GET), creates the namespace given by the name query
+// parameter (POST) or drops it along with all its data (DELETE). The token of a new namespace is
+// only returned when it's created.
+func (st *state) namespaces(w http.ResponseWriter, r *http.Request) {
+	x.AddCorsHeaders(w)
+	w.Header().Set("Content-Type", "application/json")
+	if r.Method == "OPTIONS" {
+		return
+	}
+
+	name := r.URL.Query().Get("name")
+	if r.Method != http.MethodGet && len(name) == 0 {
+		w.WriteHeader(http.StatusBadRequest)
+		x.SetStatus(w, x.ErrorInvalidRequest, "name is a mandatory query parameter")
+		return
+	}
+	switch r.Method {
+	case http.MethodGet:
+		x.Reply(w, st.zero.namespaces())
+
+	case http.MethodPost, http.MethodPut:
+		info, err := st.zero.createNamespace(name)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			x.SetStatus(w, x.Error, err.Error())
+			return
+		}
+		x.Reply(w, info)
+
+	case http.MethodDelete:
+		if err := st.zero.dropNamespace(name); err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			x.SetStatus(w, x.Error, err.Error())
+			return
+		}
+		x.SetStatus(w, x.Success, fmt.Sprintf("Namespace %s dropped", name))
+
+	default:
+		w.WriteHeader(http.StatusBadRequest)
+		x.SetStatus(w, x.ErrorInvalidMethod, "Invalid method")
+	}
+}
+
+// export starts a cluster-wide export, in the format given by the format query parameter. The
+// response describes the export, which can then be followed via exportStatus.
+func (st *state) export(w http.ResponseWriter, r *http.Request) {
+	x.AddCorsHeaders(w)
+	w.Header().Set("Content-Type", "application/json")
+	if r.Method == "OPTIONS" {
+		return
+	}
+	if r.Method != http.MethodGet && r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusBadRequest)
+		x.SetStatus(w, x.ErrorInvalidMethod, "Invalid method")
+		return
+	}
+
+	format := r.URL.Query().Get("format")
+	if err := worker.ValidateExportFormat(format); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		x.SetStatus(w, x.ErrorInvalidRequest, err.Error())
+		return
+	}
+	job, err := st.zero.startExport(format)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		x.SetStatus(w, x.Error, err.Error())
+		return
+	}
+	x.Reply(w, job)
+}
+
+// exportStatus returns the status of the export identified by the readTs query parameter, or of
+// all recent exports if it isn't passed. The status of a finished export is its manifest.
+func (st *state) exportStatus(w http.ResponseWriter, r *http.Request) {
+	x.AddCorsHeaders(w)
+	w.Header().Set("Content-Type", "application/json")
+	if r.Method == "OPTIONS" {
+		return
+	}
+	if r.Method != http.MethodGet {
+		w.WriteHeader(http.StatusBadRequest)
+		x.SetStatus(w, x.ErrorInvalidMethod, "Invalid method")
+		return
+	}
+
+	if len(r.URL.Query().Get("readTs")) == 0 {
+		x.Reply(w, st.zero.exportStatus(0))
+		return
+	}
+	readTs, ok := intFromQueryParam(w, r, "readTs")
+	if !ok {
+		return
+	}
+	jobs := st.zero.exportStatus(readTs)
+	if len(jobs) == 0 {
+		w.WriteHeader(http.StatusNotFound)
+		x.SetStatus(w, x.ErrorNoData, fmt.Sprintf("No export found at readTs %d", readTs))
+		return
+	}
+	x.Reply(w, jobs[0])
+}
+
+// fencingLease acquires or renews the lease given by the name query parameter for the holder,
+// valid for ttl (e.g. 30s). Without a holder, it returns the current lease instead, if any.
+func (st *state) fencingLease(w http.ResponseWriter, r *http.Request) {
+	x.AddCorsHeaders(w)
+	w.Header().Set("Content-Type", "application/json")
+	if r.Method == "OPTIONS" {
+		return
+	}
+	if r.Method != http.MethodGet && r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusBadRequest)
+		x.SetStatus(w, x.ErrorInvalidMethod, "Invalid method")
+		return
+	}
+
+	name := r.URL.Query().Get("name")
+	holder := r.URL.Query().Get("holder")
+	if len(holder) == 0 {
+		l, err := st.zero.currentFencingLease(name)
+		if err != nil {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			x.SetStatus(w, x.Error, err.Error())
+			return
+		}
+		if l == nil {
+			w.WriteHeader(http.StatusNotFound)
+			x.SetStatus(w, x.ErrorNoData, fmt.Sprintf("Lease %q isn't held", name))
+			return
+		}
+		x.Reply(w, l)
+		return
+	}
+
+	var ttl time.Duration
+	if str := r.URL.Query().Get("ttl"); len(str) > 0 {
+		var err error
+		if ttl, err = time.ParseDuration(str); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			x.SetStatus(w, x.ErrorInvalidRequest, "Error while parsing ttl")
+			return
+		}
+	}
+	l, err := st.zero.acquireFencingLease(name, holder, ttl)
+	switch {
+	case err == errFencingHeld:
+		w.WriteHeader(http.StatusConflict)
+		x.SetStatus(w, x.Error, err.Error())
+		return
+	case err != nil:
+		w.WriteHeader(http.StatusServiceUnavailable)
+		x.SetStatus(w, x.Error, err.Error())
+		return
+	}
+	x.Reply(w, l)
+}
+
+// releaseFencingLease gives up the lease given by the name query parameter, if it's held by the
+// holder.
+func (st *state) releaseFencingLease(w http.ResponseWriter, r *http.Request) {
+	x.AddCorsHeaders(w)
+	if r.Method == "OPTIONS" {
+		return
+	}
+	if r.Method != http.MethodGet && r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusBadRequest)
+		x.SetStatus(w, x.ErrorInvalidMethod, "Invalid method")
+		return
+	}
+
+	name := r.URL.Query().Get("name")
+	err := st.zero.releaseFencingLease(name, r.URL.Query().Get("holder"))
+	switch {
+	case err == errFencingHeld:
+		w.WriteHeader(http.StatusConflict)
+		x.SetStatus(w, x.Error, err.Error())
+		return
+	case err != nil:
+		w.WriteHeader(http.StatusServiceUnavailable)
+		x.SetStatus(w, x.Error, err.Error())
+		return
+	}
+	w.Write([]byte(fmt.Sprintf("Released lease: %v", name)))
+}
+
+// startClone picks the read timestamp to clone the cluster at, and keeps the tablets from moving
+// for the duration given by the hold query parameter, or until cloneDone is called. The response
+// is the plan of the clone.
+func (st *state) startClone(w http.ResponseWriter, r *http.Request) {
+	x.AddCorsHeaders(w)
+	w.Header().Set("Content-Type", "application/json")
+	if r.Method == "OPTIONS" {
+		return
+	}
+	if r.Method != http.MethodGet && r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusBadRequest)
+		x.SetStatus(w, x.ErrorInvalidMethod, "Invalid method")
+		return
+	}
+
+	var hold time.Duration
+	if str := r.URL.Query().Get("hold"); len(str) > 0 {
+		var err error
+		if hold, err = time.ParseDuration(str); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			x.SetStatus(w, x.ErrorInvalidRequest, "Error while parsing hold")
+			return
+		}
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	plan, err := st.zero.startClone(ctx, hold)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		x.SetStatus(w, x.Error, err.Error())
+		return
+	}
+	x.Reply(w, plan)
+}
+
+// cloneDone lets the tablets move again, once the clone identified by the readTs query parameter
+// is done.
+func (st *state) cloneDone(w http.ResponseWriter, r *http.Request) {
+	x.AddCorsHeaders(w)
+	w.Header().Set("Content-Type", "application/json")
+	if r.Method == "OPTIONS" {
+		return
+	}
+	if r.Method != http.MethodGet && r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusBadRequest)
+		x.SetStatus(w, x.ErrorInvalidMethod, "Invalid method")
+		return
+	}
+
+	readTs, ok := intFromQueryParam(w, r, "readTs")
+	if !ok {
+		return
+	}
+	if err := st.zero.endClone(readTs); err != nil {
+		w.WriteHeader(http.StatusNotFound)
+		x.SetStatus(w, x.ErrorNoData, err.Error())
+		return
+	}
+	x.SetStatus(w, x.Success, fmt.Sprintf("Clone at readTs %d is done", readTs))
+}
+
+// promote makes a cluster replicating another one stop doing so, and accept writes. The response
+// is the state of the replication it stopped at.
+func (st *state) promote(w http.ResponseWriter, r *http.Request) {
+	x.AddCorsHeaders(w)
+	w.Header().Set("Content-Type", "application/json")
+	if r.Method == "OPTIONS" {
+		return
+	}
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusBadRequest)
+		x.SetStatus(w, x.ErrorInvalidMethod, "Invalid method")
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+	replication, err := st.zero.promote(ctx)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		x.SetStatus(w, x.Error, err.Error())
+		return
+	}
+	x.Reply(w, replication)
+}
+
 func (st *state) getState(w http.ResponseWriter, r *http.Request) {
 	x.AddCorsHeaders(w)
 	w.Header().Set("Content-Type", "application/json")
 
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
-	if err := st.node.WaitLinearizableRead(ctx); err != nil {
+	if err := st.node.waitForRead(ctx); err != nil {
 		w.WriteHeader(http.StatusInternalServerError)
 		x.SetStatus(w, x.Error, err.Error())
 		return
@@ -191,6 +609,9 @@ func (st *state) getState(w http.ResponseWriter, r *http.Request) {
 		x.SetStatus(w, x.ErrorNoData, "No membership state found.")
 		return
 	}
+	st.zero.alive.fill(mstate)
+	st.zero.sums.fill(mstate)
+	st.zero.snaps.fill(mstate)
 
 	m := jsonpb.Marshaler{}
 	if err := m.Marshal(w, mstate); err != nil {
@@ -199,8 +620,10 @@ func (st *state) getState(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
-func (st *state) serveHTTP(l net.Listener, wg *sync.WaitGroup) {
+// serveHTTP serves handler on l, or http.DefaultServeMux if it's nil.
+func (st *state) serveHTTP(l net.Listener, handler http.Handler, wg *sync.WaitGroup) {
 	srv := &http.Server{
+		Handler:      handler,
 		ReadTimeout:  10 * time.Second,
 		WriteTimeout: 600 * time.Second,
 		IdleTimeout:  2 * time.Minute,
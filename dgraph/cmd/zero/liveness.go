@@ -0,0 +1,67 @@
+/*
+ * Copyright 2018 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package zero
+
+import (
+	"sync"
+	"time"
+
+	"github.com/dgraph-io/dgraph/protos/pb"
+)
+
+// liveness keeps when the leader last heard of every Alpha, either from the Alpha itself or from
+// the leader of its group, which gossips with it. It's kept in memory only, as it changes all the
+// time and proposing it would make every membership update a Raft proposal.
+type liveness struct {
+	sync.Mutex
+	lastSeen map[uint64]time.Time
+}
+
+func (l *liveness) heard(id uint64, at time.Time) {
+	l.Lock()
+	defer l.Unlock()
+	if l.lastSeen == nil {
+		l.lastSeen = make(map[uint64]time.Time)
+	}
+	if at.After(l.lastSeen[id]) {
+		l.lastSeen[id] = at
+	}
+}
+
+// record notes the members of the group in a membership update as heard of.
+func (l *liveness) record(group *pb.Group) {
+	now := time.Now()
+	for id := range group.Members {
+		l.heard(id, now)
+	}
+	for _, h := range group.Health {
+		l.heard(h.Id, now.Add(-time.Duration(h.UnseenMs)*time.Millisecond))
+	}
+}
+
+// fill sets the time every Alpha in ms was last heard of as its last_update, in Unix seconds.
+func (l *liveness) fill(ms *pb.MembershipState) {
+	l.Lock()
+	defer l.Unlock()
+	for _, group := range ms.Groups {
+		for id, m := range group.Members {
+			if at, ok := l.lastSeen[id]; ok {
+				m.LastUpdate = uint64(at.Unix())
+			}
+		}
+	}
+}
@@ -0,0 +1,52 @@
+/*
+ * Copyright 2018 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package zero
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dgraph-io/dgraph/protos/pb"
+)
+
+func TestLiveness(t *testing.T) {
+	var l liveness
+	l.record(&pb.Group{
+		Members: map[uint64]*pb.Member{1: {Id: 1, GroupId: 1}},
+		Health: []*pb.MemberHealth{
+			{Id: 1},
+			{Id: 2, UnseenMs: uint64(time.Hour / time.Millisecond)},
+		},
+	})
+	// Alpha 3 only sent its membership itself, a while ago.
+	l.heard(3, time.Now().Add(-2*time.Hour))
+	// Older news don't count.
+	l.heard(3, time.Now().Add(-3*time.Hour))
+
+	ms := &pb.MembershipState{Groups: map[uint32]*pb.Group{1: {
+		Members: map[uint64]*pb.Member{1: {Id: 1}, 2: {Id: 2}, 3: {Id: 3}, 4: {Id: 4}},
+	}}}
+	l.fill(ms)
+	members := ms.Groups[1].Members
+	now := time.Now().Unix()
+	require.InDelta(t, now, members[1].LastUpdate, 2)
+	require.InDelta(t, now-3600, members[2].LastUpdate, 2)
+	require.InDelta(t, now-7200, members[3].LastUpdate, 2)
+	require.Equal(t, uint64(0), members[4].LastUpdate)
+}
@@ -0,0 +1,47 @@
+/*
+ * Copyright 2018 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package zero
+
+import (
+	"time"
+
+	"github.com/dgraph-io/dgraph/x"
+)
+
+// updateMetrics keeps the metrics of the Raft node and of the Oracle up to date, until shutdown.
+func (s *Server) updateMetrics() {
+	ticker := time.NewTicker(5 * time.Second)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-s.shutDownCh:
+			return
+		case <-ticker.C:
+			s.recordMetrics()
+		}
+	}
+}
+
+func (s *Server) recordMetrics() {
+	if s.Node != nil && s.Node.Raft() != nil {
+		st := s.Node.Raft().Status()
+		x.ZeroRaftTerm.Set(int64(st.Term))
+		x.ZeroRaftCommitIndex.Set(int64(st.Commit))
+		x.ZeroRaftAppliedIndex.Set(int64(s.Node.Applied.DoneUntil()))
+	}
+	x.ZeroPendingTxns.Set(int64(s.orc.pendingTxns()))
+}
@@ -0,0 +1,106 @@
+/*
+ * Copyright 2016-2018 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package zero
+
+import (
+	"context"
+	"expvar"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+
+	"github.com/dgraph-io/dgraph/protos/pb"
+	"github.com/dgraph-io/dgraph/x"
+)
+
+func mapValue(m *expvar.Map, key string) int64 {
+	if v, ok := m.Get(key).(*expvar.Int); ok {
+		return v.Value()
+	}
+	return 0
+}
+
+type membershipStream struct {
+	grpc.ServerStream
+	ctx  context.Context
+	sent chan *pb.MembershipState
+}
+
+func (s *membershipStream) Context() context.Context { return s.ctx }
+
+func (s *membershipStream) Send(ms *pb.MembershipState) error {
+	s.sent <- ms
+	return nil
+}
+
+func TestZeroMetrics(t *testing.T) {
+	s, stop := startTestZero(t)
+	defer stop()
+	ctx := context.Background()
+
+	// Leases, which need proposals to renew them.
+	proposals, proposalSecs := x.ZeroProposals.Value(), x.ZeroProposalSeconds.Value()
+	uids, timestamps := mapValue(x.ZeroLeased, "uids"), mapValue(x.ZeroLeased, "timestamps")
+	_, err := s.lease(ctx, &pb.Num{Val: 10}, false)
+	require.NoError(t, err)
+	_, err = s.lease(ctx, &pb.Num{Val: 5, ReadOnly: true}, true)
+	require.NoError(t, err)
+	require.Equal(t, uids+10, mapValue(x.ZeroLeased, "uids"))
+	require.Equal(t, timestamps+6, mapValue(x.ZeroLeased, "timestamps"))
+	require.True(t, x.ZeroProposals.Value() >= proposals+2)
+	require.True(t, x.ZeroProposalSeconds.Value() > proposalSecs)
+
+	// The state of Raft and of the Oracle.
+	s.recordMetrics()
+	require.True(t, x.ZeroRaftTerm.Value() >= 1)
+	require.True(t, x.ZeroRaftCommitIndex.Value() > 0)
+	require.True(t, x.ZeroRaftAppliedIndex.Value() > 0)
+	require.Equal(t, int64(s.orc.pendingTxns()), x.ZeroPendingTxns.Value())
+
+	// Membership streams are counted while they're open.
+	streams := x.ZeroMembershipStreams.Value()
+	sctx, cancel := context.WithCancel(ctx)
+	stream := &membershipStream{ctx: sctx, sent: make(chan *pb.MembershipState, 10)}
+	done := make(chan error)
+	go func() { done <- s.StreamMembership(nil, stream) }()
+	<-stream.sent
+	require.Equal(t, streams+1, x.ZeroMembershipStreams.Value())
+	cancel()
+	<-done
+	require.Equal(t, streams, x.ZeroMembershipStreams.Value())
+
+	// Failed tablet moves, splits and copies, as group 1 has no leader to send the tablets.
+	s.Lock()
+	s.state.Groups[1] = &pb.Group{Tablets: map[string]*pb.Tablet{
+		"name":   {GroupId: 1, Predicate: "name"},
+		"friend": {GroupId: 1, Predicate: "friend"},
+		"age":    {GroupId: 1, Predicate: "age"},
+	}}
+	s.Unlock()
+	moveSecs := x.ZeroTabletMoveSeconds.Value()
+	failed := func(m *expvar.Map, op func() error) {
+		errors := mapValue(m, "error")
+		require.Error(t, op())
+		require.Equal(t, errors+1, mapValue(m, "error"))
+		require.Equal(t, int64(0), mapValue(m, "ok"))
+	}
+	failed(x.ZeroTabletMoves, func() error { return s.movePredicate("name", 1, 2) })
+	require.True(t, x.ZeroTabletMoveSeconds.Value() > moveSecs)
+	failed(x.ZeroTabletSplits, func() error { return s.splitTablet("friend", 1, 2) })
+	failed(x.ZeroTabletCopies, func() error { return s.copyTablet("age", 2) })
+}
@@ -0,0 +1,182 @@
+/*
+ * Copyright 2018 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package zero
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"sort"
+	"time"
+
+	"github.com/dgraph-io/dgraph/protos/pb"
+	"github.com/dgraph-io/dgraph/x"
+	"github.com/golang/glog"
+)
+
+// Namespaces are registered in the membership state, along with the hash of their token, so that
+// every Alpha can check the tokens of requests against them. Only the hash is kept, the token
+// itself is returned once, when the namespace is created.
+
+// namespaceInfo describes a namespace and the tablets of its predicates.
+type namespaceInfo struct {
+	Name    string `json:"name"`
+	Tablets int    `json:"tablets"`
+	Space   int64  `json:"space"`
+	Token   string `json:"token,omitempty"`
+}
+
+func (s *Server) namespace(name string) *pb.Namespace {
+	s.RLock()
+	defer s.RUnlock()
+	return s.namespaceLocked(name)
+}
+
+func (s *Server) namespaceLocked(name string) *pb.Namespace {
+	for _, ns := range s.state.GetNamespaces() {
+		if ns.Name == name {
+			return ns
+		}
+	}
+	return nil
+}
+
+// createNamespace registers a new namespace, returning it along with its token.
+func (s *Server) createNamespace(name string) (*namespaceInfo, error) {
+	if !s.Node.AmLeader() {
+		return nil, x.Errorf("Namespaces can only be created by the leader of Zero")
+	}
+	if !x.ValidNamespaceName(name) {
+		return nil, x.Errorf("Invalid namespace name %q. Use up to 64 letters, digits, _ or -.",
+			name)
+	}
+	if s.namespace(name) != nil {
+		return nil, x.Errorf("Namespace %s already exists", name)
+	}
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return nil, err
+	}
+	token := hex.EncodeToString(buf)
+	hash := sha256.Sum256([]byte(token))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	ns := &pb.Namespace{Name: name, TokenHash: hex.EncodeToString(hash[:])}
+	if err := s.Node.proposeAndWait(ctx, &pb.ZeroProposal{Namespace: ns}); err != nil {
+		return nil, err
+	}
+	glog.Infof("Created namespace: %s", name)
+	return &namespaceInfo{Name: name, Token: token}, nil
+}
+
+// dropNamespace asks every group to drop the predicates of the namespace, and then removes the
+// namespace along with its tablets. If a group fails, the namespace is kept, so the drop can be
+// retried.
+func (s *Server) dropNamespace(name string) error {
+	if !s.Node.AmLeader() {
+		return x.Errorf("Namespaces can only be dropped by the leader of Zero")
+	}
+	if s.namespace(name) == nil {
+		return x.Errorf("Namespace %s doesn't exist", name)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), predicateMoveTimeout)
+	defer cancel()
+	for _, gid := range s.KnownGroups() {
+		var err error
+		for i := 1; i <= exportAttempts; i++ {
+			if i > 1 {
+				time.Sleep(time.Duration(i) * time.Second)
+			}
+			pl := s.Leader(gid)
+			if pl == nil {
+				err = x.Errorf("No healthy connection found to leader of group %d", gid)
+				continue
+			}
+			m := &pb.Mutations{GroupId: gid, DropNamespace: name}
+			if _, err = pb.NewWorkerClient(pl.Get()).Mutate(ctx, m); err == nil {
+				break
+			}
+			glog.Warningf("Attempt %d to drop namespace %s in group %d failed: %v", i, name, gid, err)
+		}
+		if err != nil {
+			return x.Errorf("While dropping namespace %s in group %d: %v", name, gid, err)
+		}
+	}
+	if err := s.Node.proposeAndWait(ctx, &pb.ZeroProposal{DropNamespace: name}); err != nil {
+		return err
+	}
+	glog.Infof("Dropped namespace: %s", name)
+	return nil
+}
+
+// namespaces lists all namespaces, along with the number and size of their tablets.
+func (s *Server) namespaces() []*namespaceInfo {
+	s.RLock()
+	defer s.RUnlock()
+	infos := make(map[string]*namespaceInfo)
+	for _, ns := range s.state.GetNamespaces() {
+		infos[ns.Name] = &namespaceInfo{Name: ns.Name}
+	}
+	for _, group := range s.state.GetGroups() {
+		for _, tab := range group.Tablets {
+			ns, _ := x.ParseNamespaceAttr(tab.Predicate)
+			if info, ok := infos[ns]; ok {
+				info.Tablets++
+				info.Space += tab.Space
+			}
+		}
+	}
+	res := make([]*namespaceInfo, 0, len(infos))
+	for _, info := range infos {
+		res = append(res, info)
+	}
+	sort.Slice(res, func(i, j int) bool { return res[i].Name < res[j].Name })
+	return res
+}
+
+func (n *node) handleNamespaceProposal(ns *pb.Namespace) error {
+	n.server.AssertLock()
+	state := n.server.state
+	for _, prev := range state.Namespaces {
+		if prev.Name == ns.Name {
+			return x.Errorf("Namespace %s already exists", ns.Name)
+		}
+	}
+	state.Namespaces = append(state.Namespaces, ns)
+	return nil
+}
+
+func (n *node) handleDropNamespaceProposal(name string) {
+	n.server.AssertLock()
+	state := n.server.state
+	for i, ns := range state.Namespaces {
+		if ns.Name == name {
+			state.Namespaces = append(state.Namespaces[:i], state.Namespaces[i+1:]...)
+			break
+		}
+	}
+	for _, group := range state.Groups {
+		for pred := range group.Tablets {
+			if ns, _ := x.ParseNamespaceAttr(pred); ns == name {
+				delete(group.Tablets, pred)
+			}
+		}
+	}
+}
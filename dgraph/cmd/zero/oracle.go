@@ -19,6 +19,7 @@ package zero
 import (
 	"errors"
 	"math/rand"
+	"strconv"
 	"time"
 
 	"github.com/dgraph-io/badger/y"
@@ -28,6 +29,8 @@ import (
 	"github.com/golang/glog"
 	otrace "go.opencensus.io/trace"
 	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
 )
 
 type syncMark struct {
@@ -44,7 +47,7 @@ type Oracle struct {
 
 	// timestamp at the time of start of server or when it became leader. Used to detect conflicts.
 	tmax uint64
-	// All transactions with startTs < startTxnTs return true for hasConflict.
+	// All transactions with startTs < startTxnTs conflict.
 	startTxnTs  uint64
 	subscribers map[int]chan *pb.OracleDelta
 	updates     chan *pb.OracleDelta
@@ -68,18 +71,24 @@ func (o *Oracle) updateStartTxnTs(ts uint64) {
 	o.keyCommit = make(map[string]uint64)
 }
 
+// txnConflict is what a transaction conflicted on.
+type txnConflict struct {
+	key      string // Fingerprint of the key, empty if the txn started before this leader.
+	commitTs uint64 // Commit timestamp of the transaction which last wrote the key.
+}
+
 // TODO: This should be done during proposal application for Txn status.
-func (o *Oracle) hasConflict(src *api.TxnContext) bool {
+func (o *Oracle) hasConflict(src *api.TxnContext) *txnConflict {
 	// This transaction was started before I became leader.
 	if src.StartTs < o.startTxnTs {
-		return true
+		return &txnConflict{}
 	}
 	for _, k := range src.Keys {
 		if last := o.keyCommit[k]; last > src.StartTs {
-			return true
+			return &txnConflict{key: k, commitTs: last}
 		}
 	}
-	return false
+	return nil
 }
 
 func (o *Oracle) purgeBelow(minTs uint64) {
@@ -105,12 +114,12 @@ func (o *Oracle) purgeBelow(minTs uint64) {
 		minTs, len(o.commits), len(o.keyCommit))
 }
 
-func (o *Oracle) commit(src *api.TxnContext) error {
+func (o *Oracle) commit(src *api.TxnContext) *txnConflict {
 	o.Lock()
 	defer o.Unlock()
 
-	if o.hasConflict(src) {
-		return errConflict
+	if conflict := o.hasConflict(src); conflict != nil {
+		return conflict
 	}
 	for _, k := range src.Keys {
 		o.keyCommit[k] = src.CommitTs // CommitTs is handed out before calling this func.
@@ -261,13 +270,30 @@ func (o *Oracle) storePending(ids *pb.AssignedIds) {
 	o.maxAssigned = x.Max(o.maxAssigned, max)
 }
 
+// pendingTxns returns the number of transactions whose status the Oracle keeps, until all the
+// transactions started before them are done.
+func (o *Oracle) pendingTxns() int {
+	o.RLock()
+	defer o.RUnlock()
+	return len(o.commits)
+}
+
 func (o *Oracle) MaxPending() uint64 {
 	o.RLock()
 	defer o.RUnlock()
 	return o.maxAssigned
 }
 
-var errConflict = errors.New("Transaction conflict")
+// reportConflict tells the Alphas asking for it, via the debug-conflicts header, what the
+// transaction of ctx conflicted on, in the conflict-key and conflict-commit-ts trailers.
+func reportConflict(ctx context.Context, conflict *txnConflict) {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok || len(md.Get("debug-conflicts")) == 0 {
+		return
+	}
+	grpc.SetTrailer(ctx, metadata.Pairs("conflict-key", conflict.key,
+		"conflict-commit-ts", strconv.FormatUint(conflict.commitTs, 10)))
+}
 
 // proposeTxn proposes a txn update, and then updates src to reflect the state
 // of the commit after proposal is run.
@@ -322,8 +348,9 @@ func (s *Server) commit(ctx context.Context, src *api.TxnContext) error {
 	s.orc.RLock()
 	conflict := s.orc.hasConflict(src)
 	s.orc.RUnlock()
-	if conflict {
+	if conflict != nil {
 		span.Annotate(nil, "Oracle found conflict")
+		reportConflict(ctx, conflict)
 		src.Aborted = true
 		return s.proposeTxn(ctx, src)
 	}
@@ -368,8 +395,9 @@ func (s *Server) commit(ctx context.Context, src *api.TxnContext) error {
 	span.Annotatef([]otrace.Attribute{otrace.Int64Attribute("commitTs", int64(src.CommitTs))},
 		"Node Id: %d. Proposing TxnContext: %+v", s.Node.Id, src)
 
-	if err := s.orc.commit(src); err != nil {
+	if conflict := s.orc.commit(src); conflict != nil {
 		span.Annotatef(nil, "Found a conflict. Aborting.")
+		reportConflict(ctx, conflict)
 		src.Aborted = true
 	}
 	if err := ctx.Err(); err != nil {
@@ -390,6 +418,9 @@ func (s *Server) CommitOrAbort(ctx context.Context, src *api.TxnContext) (*api.T
 	if !s.Node.AmLeader() {
 		return nil, x.Errorf("Only leader can decide to commit or abort")
 	}
+	if source := s.replicaOf(); len(source) > 0 && !src.Aborted {
+		return nil, x.Errorf("Cluster is a read-only replica of %s", source)
+	}
 	err := s.commit(ctx, src)
 	return src, err
 }
@@ -0,0 +1,41 @@
+/*
+ * Copyright 2018 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package zero
+
+import (
+	"testing"
+
+	"github.com/dgraph-io/dgo/protos/api"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOracleConflict(t *testing.T) {
+	var o Oracle
+	o.Init()
+	o.updateStartTxnTs(5)
+
+	require.Nil(t, o.commit(&api.TxnContext{StartTs: 10, CommitTs: 12, Keys: []string{"a", "b"}}))
+	require.Nil(t, o.commit(&api.TxnContext{StartTs: 12, CommitTs: 14, Keys: []string{"b"}}))
+
+	// Started before the commit of b.
+	require.Equal(t, &txnConflict{key: "b", commitTs: 14},
+		o.commit(&api.TxnContext{StartTs: 11, CommitTs: 15, Keys: []string{"c", "b"}}))
+	// The aborted txn didn't write c.
+	require.Nil(t, o.hasConflict(&api.TxnContext{StartTs: 13, Keys: []string{"a", "c"}}))
+	// Started before this Zero became the leader.
+	require.Equal(t, &txnConflict{}, o.hasConflict(&api.TxnContext{StartTs: 4}))
+}
@@ -21,6 +21,7 @@ import (
 	"fmt"
 	"log"
 	"math"
+	"sync/atomic"
 	"time"
 
 	otrace "go.opencensus.io/trace"
@@ -29,7 +30,9 @@ import (
 	"github.com/coreos/etcd/raft"
 	"github.com/coreos/etcd/raft/raftpb"
 	"github.com/dgraph-io/badger/y"
+	"github.com/dgraph-io/dgraph/changes"
 	"github.com/dgraph-io/dgraph/conn"
+	"github.com/dgraph-io/dgraph/fault"
 	"github.com/dgraph-io/dgraph/protos/pb"
 	"github.com/dgraph-io/dgraph/x"
 	"github.com/golang/glog"
@@ -45,6 +48,9 @@ type node struct {
 	reads       map[uint64]chan uint64
 	subscribers map[uint32]chan struct{}
 	stop        chan struct{} // to send stop signal to Run
+	// readLease is the Unix nano time until which reads don't check with the leader. Accessed
+	// atomically.
+	readLease int64
 }
 
 var errReadIndex = x.Errorf("cannot get linerized read (time expired or no configured leader)")
@@ -120,6 +126,11 @@ func (n *node) proposeAndWait(ctx context.Context, proposal *pb.ZeroProposal) er
 	// to leader can be dropped/end up appearing with empty Data in CommittedEntries.
 	// Having a timeout here prevents the mutation being stuck forever in case they don't have a
 	// timeout. We should always try with a timeout and optionally retry.
+	start := time.Now()
+	defer func() {
+		x.ZeroProposals.Add(1)
+		x.ZeroProposalSeconds.Add(time.Since(start).Seconds())
+	}()
 	err := errInternalRetry
 	timeout := 4 * time.Second
 	for err == errInternalRetry {
@@ -169,6 +180,7 @@ func (n *node) handleMemberProposal(member *pb.Member) error {
 	group := state.Groups[member.GroupId]
 	if group == nil {
 		group = newGroup()
+		group.Replicas = member.Replicas
 		state.Groups[member.GroupId] = group
 	}
 	m, has := group.Members[member.Id]
@@ -181,7 +193,7 @@ func (n *node) handleMemberProposal(member *pb.Member) error {
 		// else already removed.
 		return nil
 	}
-	if !has && len(group.Members) >= n.server.NumReplicas {
+	if !has && !member.Learner && voters(group) >= n.server.groupReplicas(group) {
 		// We shouldn't allow more members than the number of replicas.
 		return x.Errorf("Group reached replication level. Can't add another member: %+v", member)
 	}
@@ -192,7 +204,7 @@ func (n *node) handleMemberProposal(member *pb.Member) error {
 	group.Members[member.Id] = member
 	// Increment nextGroup when we have enough replicas
 	if member.GroupId == n.server.nextGroup &&
-		len(group.Members) >= n.server.NumReplicas {
+		voters(group) >= n.server.groupReplicas(group) {
 		n.server.nextGroup++
 	}
 	if member.Leader {
@@ -221,11 +233,24 @@ func (n *node) handleTabletProposal(tablet *pb.Tablet) error {
 	group := state.Groups[tablet.GroupId]
 	if tablet.Remove {
 		glog.Infof("Removing tablet for attr: [%v], gid: [%v]\n", tablet.Predicate, tablet.GroupId)
+		if tab := n.server.servingTablet(tablet.Predicate); tab != nil &&
+			tab.GroupId == tablet.GroupId && shardsHaveData(tab) {
+			// The other parts of a split tablet are still there, so only the first one is gone.
+			removed := *tab
+			removed.Space = 0
+			group.Tablets[tab.Predicate] = &removed
+			return nil
+		}
 		if group != nil {
 			delete(group.Tablets, tablet.Predicate)
 		}
 		return nil
 	}
+	if ns, _ := x.ParseNamespaceAttr(tablet.Predicate); len(ns) > 0 {
+		if n.server.namespaceLocked(ns) == nil {
+			return x.Errorf("Namespace %s of tablet %s doesn't exist", ns, tablet.Predicate)
+		}
+	}
 	if group == nil {
 		group = newGroup()
 		state.Groups[tablet.GroupId] = group
@@ -239,6 +264,10 @@ func (n *node) handleTabletProposal(tablet *pb.Tablet) error {
 			// TODO: Try and remove this whole Force flag logic.
 			originalGroup := state.Groups[prev.GroupId]
 			delete(originalGroup.Tablets, tablet.Predicate)
+			// Only splits change the shards of a tablet, the other proposals keep them.
+			if len(tablet.Shards) == 0 {
+				tablet.Shards = prev.Shards
+			}
 		} else {
 			if prev.GroupId != tablet.GroupId {
 				glog.Infof(
@@ -248,12 +277,68 @@ func (n *node) handleTabletProposal(tablet *pb.Tablet) error {
 			}
 			// This update can come from tablet size.
 			tablet.ReadOnly = prev.ReadOnly
+			tablet.MovingTo = prev.MovingTo
+			tablet.MoveStartedAt = prev.MoveStartedAt
+			tablet.MoveEta = prev.MoveEta
+			tablet.Shards = mergeShardSpaces(prev.Shards, tablet.Shards)
 		}
+		// The replicas of a tablet are only changed by their own proposals.
+		tablet.Replicas = prev.Replicas
+		tablet.Copies = prev.Copies
 	}
 	group.Tablets[tablet.Predicate] = tablet
 	return nil
 }
 
+// handleTabletReplicasProposal sets the replicas wanted for a tablet.
+func (n *node) handleTabletReplicasProposal(tr *pb.TabletReplicas) error {
+	n.server.AssertLock()
+	tab := n.server.servingTablet(tr.Predicate)
+	if tab == nil {
+		return x.Errorf("No tablet found for: %s", tr.Predicate)
+	}
+	tab.Replicas = tr.Replicas
+	return nil
+}
+
+// handleTabletCopiesProposal sets the groups holding a copy of a tablet, and makes it writable
+// again, once a copy has been made or dropped.
+func (n *node) handleTabletCopiesProposal(tc *pb.TabletCopies) error {
+	n.server.AssertLock()
+	tab := n.server.servingTablet(tc.Predicate)
+	if tab == nil {
+		return x.Errorf("No tablet found for: %s", tc.Predicate)
+	}
+	for _, c := range tc.Copies {
+		if c.GroupId == tab.GroupId || n.server.state.Groups[c.GroupId] == nil {
+			return x.Errorf("Group %d can't hold a copy of tablet %s", c.GroupId, tc.Predicate)
+		}
+	}
+	tab.Copies = tc.Copies
+	tab.ReadOnly = false
+	tab.MovingTo = 0
+	tab.MoveStartedAt = 0
+	tab.MoveEta = 0
+	return nil
+}
+
+// zeroProposalKind names the kind of a proposal, for the faults injected when applying it.
+func zeroProposalKind(p *pb.ZeroProposal) string {
+	switch {
+	case p.Member != nil:
+		return "member"
+	case p.Tablet != nil, p.TabletReplicas != nil, p.TabletCopies != nil:
+		return "tablet"
+	case p.Txn != nil:
+		return "txn"
+	case len(p.SnapshotTs) > 0:
+		return "snapshot_ts"
+	case p.MaxLeaseId > 0 || p.MaxTxnTs > 0 || p.MaxRaftId > 0:
+		return "lease"
+	}
+	return "other"
+}
+
 func (n *node) applyProposal(e raftpb.Entry) (string, error) {
 	var p pb.ZeroProposal
 	// Raft commits empty entry on becoming a leader.
@@ -266,6 +351,11 @@ func (n *node) applyProposal(e raftpb.Entry) (string, error) {
 	if len(p.Key) == 0 {
 		return p.Key, errInvalidProposal
 	}
+	if fault.Enabled {
+		labels := []string{"group=0", "kind=" + zeroProposalKind(&p), "key=" + p.Key}
+		fault.Inject(fault.ApplyBefore, labels...)
+		defer fault.Inject(fault.ApplyAfter, labels...)
+	}
 	span := otrace.FromContext(n.Proposals.Ctx(p.Key))
 
 	n.server.Lock()
@@ -279,6 +369,21 @@ func (n *node) applyProposal(e raftpb.Entry) (string, error) {
 		}
 		state.Cid = p.Cid
 	}
+	switch p.SchemaMode {
+	case "":
+	case schemaStrict:
+		state.StrictSchema = true
+	case schemaFlexible:
+		state.StrictSchema = false
+	default:
+		return p.Key, errInvalidProposal
+	}
+	if p.ChangeStreamRoutes != nil {
+		if _, err := changes.ParseRoutes(p.ChangeStreamRoutes.Rules); err != nil {
+			return p.Key, errInvalidProposal
+		}
+		state.ChangeStreamRoutes = p.ChangeStreamRoutes.Rules
+	}
 	if p.MaxRaftId > 0 {
 		if p.MaxRaftId <= state.MaxRaftId {
 			return p.Key, errInvalidProposal
@@ -306,6 +411,23 @@ func (n *node) applyProposal(e raftpb.Entry) (string, error) {
 			return p.Key, err
 		}
 	}
+	if p.Namespace != nil {
+		if err := n.handleNamespaceProposal(p.Namespace); err != nil {
+			span.Annotatef(nil, "While applying namespace proposal: %+v", err)
+			glog.Errorf("While applying namespace proposal: %+v", err)
+			return p.Key, err
+		}
+	}
+	if len(p.DropNamespace) > 0 {
+		n.handleDropNamespaceProposal(p.DropNamespace)
+	}
+	if p.Replication != nil {
+		// A promoted cluster never replicates again.
+		if state.Replication.GetPromoted() {
+			return p.Key, errInvalidProposal
+		}
+		state.Replication = p.Replication
+	}
 	if p.Tablet != nil {
 		if err := n.handleTabletProposal(p.Tablet); err != nil {
 			span.Annotatef(nil, "While applying tablet proposal: %+v", err)
@@ -313,6 +435,20 @@ func (n *node) applyProposal(e raftpb.Entry) (string, error) {
 			return p.Key, err
 		}
 	}
+	if p.TabletReplicas != nil {
+		if err := n.handleTabletReplicasProposal(p.TabletReplicas); err != nil {
+			span.Annotatef(nil, "While applying tablet replicas proposal: %+v", err)
+			glog.Errorf("While applying tablet replicas proposal: %+v", err)
+			return p.Key, err
+		}
+	}
+	if p.TabletCopies != nil {
+		if err := n.handleTabletCopiesProposal(p.TabletCopies); err != nil {
+			span.Annotatef(nil, "While applying tablet copies proposal: %+v", err)
+			glog.Errorf("While applying tablet copies proposal: %+v", err)
+			return p.Key, err
+		}
+	}
 
 	if p.MaxLeaseId > state.MaxLeaseId {
 		state.MaxLeaseId = p.MaxLeaseId
@@ -327,6 +463,9 @@ func (n *node) applyProposal(e raftpb.Entry) (string, error) {
 	if p.Txn != nil {
 		n.server.orc.updateCommitStatus(e.Index, p.Txn)
 	}
+	if p.Event != nil {
+		n.recordEvent(e.Index, p.Event)
+	}
 
 	return p.Key, nil
 }
@@ -338,13 +477,15 @@ func (n *node) applyConfChange(e raftpb.Entry) {
 	if cc.Type == raftpb.ConfChangeRemoveNode {
 		n.DeletePeer(cc.NodeID)
 		n.server.removeZero(cc.NodeID)
+		n.recordEvent(e.Index, newEvent(eventMemberRemoved, 0, cc.NodeID, "Zero %d removed",
+			cc.NodeID))
 
 	} else if len(cc.Context) > 0 {
 		var rc pb.RaftContext
 		x.Check(rc.Unmarshal(cc.Context))
 		go n.Connect(rc.Id, rc.Addr)
 
-		m := &pb.Member{Id: rc.Id, Addr: rc.Addr, GroupId: 0}
+		m := &pb.Member{Id: rc.Id, Addr: rc.Addr, GroupId: 0, Learner: rc.IsLearner}
 		for _, member := range n.server.membershipState().Removed {
 			// It is not recommended to reuse RAFT ids.
 			if member.GroupId == 0 && m.Id == member.Id {
@@ -358,6 +499,8 @@ func (n *node) applyConfChange(e raftpb.Entry) {
 		}
 
 		n.server.storeZero(m)
+		n.recordEvent(e.Index, newEvent(eventMemberJoined, 0, m.Id, "Zero %d at %s joined",
+			m.Id, m.Addr))
 	}
 
 	cs := n.Raft().ApplyConfChange(cc)
@@ -370,6 +513,14 @@ func (n *node) applyConfChange(e raftpb.Entry) {
 	n.triggerLeaderChange()
 }
 
+// recordEvent adds ev to the event log, as of the Raft entry at index.
+func (n *node) recordEvent(index uint64, ev *pb.ClusterEvent) {
+	ev.Index = index
+	if err := n.server.events.add(ev); err != nil {
+		glog.Errorf("While recording event %+v: %v", ev, err)
+	}
+}
+
 func (n *node) triggerLeaderChange() {
 	n.server.triggerLeaderChange()
 	// We update leader information on each node without proposal. This
@@ -377,6 +528,61 @@ func (n *node) triggerLeaderChange() {
 	n.server.updateZeroLeader()
 }
 
+// waitForRead waits until reads are linearizable. Learners don't wait, they serve the state they
+// have, which could be behind. With --read_staleness, the other nodes, followers included, don't
+// check with the leader again for that long after they last did, so the state they serve misses
+// at most what was committed since.
+func (n *node) waitForRead(ctx context.Context) error {
+	if opts.learner {
+		return nil
+	}
+	start := time.Now()
+	if start.UnixNano() < atomic.LoadInt64(&n.readLease) {
+		return nil
+	}
+	if err := n.WaitLinearizableRead(ctx); err != nil {
+		return err
+	}
+	if opts.readStaleness > 0 {
+		// The lease starts before the read, whose index could be from any time after it.
+		atomic.StoreInt64(&n.readLease, start.Add(opts.readStaleness).UnixNano())
+	}
+	return nil
+}
+
+// joinsPeers tells whether this Zero joins those of --peer, if it's new. Out of the Zeros found
+// through DNS or Kubernetes, like those of a StatefulSet, the one with Raft ID 1 starts the cluster.
+func joinsPeers() bool {
+	return opts.peers != nil && (!opts.peers.Dynamic() || opts.nodeId != 1)
+}
+
+// joinCluster asks one of the Zeros of --peer to add this one to the cluster, another one every
+// try. They're resolved again every try too, as they come and go.
+func (n *node) joinCluster(try int, timeout time.Duration) error {
+	ctx, cancel := context.WithTimeout(n.ctx, timeout)
+	defer cancel()
+	addrs, err := opts.peers.Resolve(ctx)
+	if err != nil {
+		return x.Wrapf(err, "While finding the Zeros through %s", opts.peers)
+	}
+	var others []string
+	for _, addr := range addrs {
+		if addr != opts.myAddr {
+			others = append(others, addr)
+		}
+	}
+	if len(others) == 0 {
+		return x.Errorf("No other Zero found through %s", opts.peers)
+	}
+	addr := others[try%len(others)]
+	p := conn.Get().Connect(addr)
+	if p == nil {
+		return x.Errorf("Unhealthy connection to %v", addr)
+	}
+	_, err = pb.NewRaftClient(p.Get()).JoinCluster(ctx, n.RaftContext)
+	return err
+}
+
 func (n *node) initAndStartNode() error {
 	_, restart, err := n.PastLife()
 	x.Check(err)
@@ -392,28 +598,22 @@ func (n *node) initAndStartNode() error {
 			var state pb.MembershipState
 			x.Check(state.Unmarshal(sp.Data))
 			n.server.SetMembershipState(&state)
-			for _, id := range sp.Metadata.ConfState.Nodes {
-				n.Connect(id, state.Zeros[id].Addr)
+			cs := sp.Metadata.ConfState
+			for _, ids := range [][]uint64{cs.Nodes, cs.Learners} {
+				for _, id := range ids {
+					n.Connect(id, state.Zeros[id].Addr)
+				}
 			}
 		}
 
 		n.SetRaft(raft.RestartNode(n.Cfg))
 
-	} else if len(opts.peer) > 0 {
-		p := conn.Get().Connect(opts.peer)
-		if p == nil {
-			return x.Errorf("Unhealthy connection to %v", opts.peer)
-		}
-
-		gconn := p.Get()
-		c := pb.NewRaftClient(gconn)
+	} else if joinsPeers() {
 		timeout := 8 * time.Second
-		for {
-			ctx, cancel := context.WithTimeout(n.ctx, timeout)
-			defer cancel()
+		for try := 0; ; try++ {
 			// JoinCluster can block indefinitely, raft ignores conf change proposal
 			// if it has pending configuration.
-			_, err := c.JoinCluster(ctx, n.RaftContext)
+			err := n.joinCluster(try, timeout)
 			if err == nil {
 				break
 			}
@@ -433,6 +633,9 @@ func (n *node) initAndStartNode() error {
 		n.SetRaft(raft.StartNode(n.Cfg, nil))
 
 	} else {
+		if opts.learner {
+			return x.Errorf("A learner must join the Zeros of --peer")
+		}
 		data, err := n.RaftContext.Marshal()
 		x.Check(err)
 		peers := []raft.Peer{{ID: n.Id, Context: data}}
@@ -461,6 +664,29 @@ func (n *node) initAndStartNode() error {
 	return nil
 }
 
+const (
+	schemaStrict   = "strict"
+	schemaFlexible = "flexible"
+)
+
+// proposeSchemaMode makes the schema mode of the cluster match the strict_schema flag. It's
+// called on becoming the leader, so the flag of the current leader applies.
+func (n *node) proposeSchemaMode() {
+	if n.server.membershipState().StrictSchema == opts.strictSchema {
+		return
+	}
+	mode := schemaFlexible
+	if opts.strictSchema {
+		mode = schemaStrict
+	}
+	if err := n.proposeAndWait(context.Background(),
+		&pb.ZeroProposal{SchemaMode: mode}); err != nil {
+		glog.Errorf("While proposing schema mode %s: %v", mode, err)
+		return
+	}
+	glog.Infof("Schema mode set for cluster: %s", mode)
+}
+
 func (n *node) updateZeroMembershipPeriodically(closer *y.Closer) {
 	ticker := time.NewTicker(10 * time.Second)
 	defer ticker.Stop()
@@ -548,6 +774,8 @@ func (n *node) Run() {
 				var state pb.MembershipState
 				x.Check(state.Unmarshal(rd.Snapshot.Data))
 				n.server.SetMembershipState(&state)
+				glog.Infof("Restored the snapshot at index %d. The events recorded before it"+
+					" are missing from the event log of this Zero.", rd.Snapshot.Metadata.Index)
 			}
 
 			for _, entry := range rd.CommittedEntries {
@@ -573,6 +801,9 @@ func (n *node) Run() {
 				if rd.RaftState == raft.StateLeader && !leader {
 					glog.Infoln("I've become the leader, updating leases.")
 					n.server.updateLeases()
+					go n.proposeSchemaMode()
+					go n.server.proposeEvent(newEvent(eventLeaderElected, 0, n.Id,
+						"Zero %d became the leader in term %d", n.Id, n.Raft().Status().Term))
 				}
 				leader = rd.RaftState == raft.StateLeader
 				// Oracle stream would close the stream once it steps down as leader
@@ -0,0 +1,100 @@
+/*
+ * Copyright 2016-2018 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package zero
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/dgraph-io/badger"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dgraph-io/dgraph/conn"
+	"github.com/dgraph-io/dgraph/protos/pb"
+	"github.com/dgraph-io/dgraph/raftwal"
+)
+
+// startTestZero starts a single Zero, with its WAL in a temporary directory, and waits for it to
+// become the leader. Calling the returned function stops it.
+func startTestZero(t *testing.T) (*Server, func()) {
+	dir, err := ioutil.TempDir("", "zero")
+	require.NoError(t, err)
+	kv, err := badger.Open(walOptions(dir, true))
+	require.NoError(t, err)
+
+	rc := pb.RaftContext{Id: 1, Addr: "localhost:5080"}
+	n := &node{Node: conn.NewNode(&rc, raftwal.Init(kv, 1, 0)), ctx: context.Background(),
+		stop: make(chan struct{})}
+	s := &Server{Node: n}
+	s.Init()
+	n.server = s
+	require.NoError(t, n.initAndStartNode())
+	for start := time.Now(); !n.AmLeader(); time.Sleep(10 * time.Millisecond) {
+		require.True(t, time.Since(start) < 10*time.Second, "Zero didn't become the leader")
+	}
+	return s, func() {
+		close(s.shutDownCh)
+		n.stop <- struct{}{}
+		// Run stops Raft after receiving from stop. Wait for it before closing the WAL.
+		n.Raft().Stop()
+		kv.Close()
+		os.RemoveAll(dir)
+	}
+}
+
+func TestReadLease(t *testing.T) {
+	s, stop := startTestZero(t)
+	defer stop()
+	n := s.Node
+	defer func(o options) { opts = o }(opts)
+
+	// A canceled context fails the reads that check with the leader.
+	canceled, cancel := context.WithCancel(context.Background())
+	cancel()
+	ctx := context.Background()
+
+	// Without --read_staleness, every read checks with the leader.
+	opts.readStaleness = 0
+	require.NoError(t, n.waitForRead(ctx))
+	require.Zero(t, atomic.LoadInt64(&n.readLease))
+	require.Error(t, n.waitForRead(canceled))
+
+	opts.readStaleness = 200 * time.Millisecond
+	start := time.Now()
+	require.NoError(t, n.waitForRead(ctx))
+	lease := atomic.LoadInt64(&n.readLease)
+	require.True(t, lease >= start.Add(opts.readStaleness).UnixNano())
+	require.True(t, lease <= time.Now().Add(opts.readStaleness).UnixNano())
+	// The reads during the lease don't check with the leader, nor renew the lease.
+	require.NoError(t, n.waitForRead(canceled))
+	require.Equal(t, lease, atomic.LoadInt64(&n.readLease))
+
+	// Once it expires, they check again.
+	time.Sleep(time.Until(time.Unix(0, lease)))
+	require.Error(t, n.waitForRead(canceled))
+	require.NoError(t, n.waitForRead(ctx))
+	require.True(t, atomic.LoadInt64(&n.readLease) > lease)
+
+	// Learners never check.
+	opts.learner = true
+	atomic.StoreInt64(&n.readLease, 0)
+	require.NoError(t, n.waitForRead(canceled))
+}
@@ -0,0 +1,130 @@
+/*
+ * Copyright 2018 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package zero
+
+import (
+	"context"
+
+	"github.com/dgraph-io/dgraph/protos/pb"
+	"github.com/dgraph-io/dgraph/x"
+	"github.com/golang/glog"
+)
+
+// renamePredicate renames the predicate from to to, cluster-wide. Both tablets are kept read-only
+// while the group serving from rewrites its keys, so that no mutations can sneak in. If the
+// rename fails, the tablets are reverted to their previous state. If this node crashes or steps
+// down in between, the recovery run by the next leader makes the tablets writable again; renaming
+// once more then finishes the job, as renames are idempotent in the group.
+func (s *Server) renamePredicate(from, to string) error {
+	if !s.Node.AmLeader() {
+		return x.Errorf("Predicates can only be renamed by the leader of Zero")
+	}
+	if len(from) == 0 || len(to) == 0 {
+		return x.Errorf("Both the predicate to rename and its new name are required")
+	}
+	if from == to {
+		return x.Errorf("Predicate %s can't be renamed to itself", from)
+	}
+	src := s.ServingTablet(from)
+	if src == nil {
+		return x.Errorf("No tablet found for: %s", from)
+	}
+	if src.ReadOnly {
+		return x.Errorf("Tablet %s is read-only. A move or rename might be in progress.", from)
+	}
+	if len(src.Shards) > 0 {
+		return x.Errorf("Tablet %s is split across groups, so it can't be renamed", from)
+	}
+	if len(src.Copies) > 0 {
+		return x.Errorf("Tablet %s is copied to other groups, so it can't be renamed", from)
+	}
+	// The group still serves a dropped predicate until it reports the tablet as gone, so a
+	// predicate can be renamed to a name served by the same group. The group itself refuses the
+	// rename if the name is still in use.
+	dst := s.ServingTablet(to)
+	if dst != nil && dst.GroupId != src.GroupId {
+		return x.Errorf("Predicate %s is already served by group %d", to, dst.GroupId)
+	}
+	if dst != nil && len(dst.Shards) > 0 {
+		return x.Errorf("Tablet %s is split across groups, so it can't be renamed to", to)
+	}
+	if dst != nil && dst.ReadOnly {
+		return x.Errorf("Tablet %s is read-only. A move or rename might be in progress.", to)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), predicateMoveTimeout)
+	defer cancel()
+
+	glog.Infof("Going to rename predicate: [%v] to [%v] in group %d\n", from, to, src.GroupId)
+	propose := func(tablet *pb.Tablet) error {
+		return s.Node.proposeAndWait(ctx, &pb.ZeroProposal{Tablet: tablet})
+	}
+	revert := func() {
+		// Use a fresh context, the one of the rename might have expired.
+		ctx := context.Background()
+		tablets := []*pb.Tablet{
+			{GroupId: src.GroupId, Predicate: from, Space: src.Space, Force: true},
+			{GroupId: src.GroupId, Predicate: to, Remove: true},
+		}
+		if dst != nil {
+			tablets[1] = &pb.Tablet{GroupId: dst.GroupId, Predicate: to, Space: dst.Space,
+				Force: true}
+		}
+		for _, tablet := range tablets {
+			if err := s.Node.proposeAndWait(ctx, &pb.ZeroProposal{Tablet: tablet}); err != nil {
+				glog.Errorf("While reverting tablet %+v after failed rename: %v", tablet, err)
+			}
+		}
+	}
+
+	if err := propose(&pb.Tablet{GroupId: src.GroupId, Predicate: to, ReadOnly: true,
+		Force: true}); err != nil {
+		return err
+	}
+	if err := propose(&pb.Tablet{GroupId: src.GroupId, Predicate: from, Space: src.Space,
+		ReadOnly: true, Force: true}); err != nil {
+		revert()
+		return err
+	}
+
+	pl := s.Leader(src.GroupId)
+	if pl == nil {
+		revert()
+		return x.Errorf("No healthy connection found to leader of group %d", src.GroupId)
+	}
+	in := &pb.RenamePredicatePayload{
+		From:    from,
+		To:      to,
+		GroupId: src.GroupId,
+		State:   s.membershipState(),
+	}
+	if _, err := pb.NewWorkerClient(pl.Get()).RenamePredicate(ctx, in); err != nil {
+		revert()
+		return x.Errorf("Error while renaming predicate %s to %s: %v", from, to, err)
+	}
+
+	if err := propose(&pb.Tablet{GroupId: src.GroupId, Predicate: to, Space: src.Space,
+		Force: true}); err != nil {
+		return err
+	}
+	if err := propose(&pb.Tablet{GroupId: src.GroupId, Predicate: from,
+		Remove: true}); err != nil {
+		return err
+	}
+	glog.Infof("Predicate rename done for: [%v] to [%v]\n", from, to)
+	return nil
+}
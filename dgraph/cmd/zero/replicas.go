@@ -0,0 +1,368 @@
+/*
+ * Copyright 2018 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package zero
+
+import (
+	"sort"
+	"sync/atomic"
+	"time"
+
+	"github.com/dgraph-io/dgraph/protos/pb"
+	"github.com/dgraph-io/dgraph/x"
+	humanize "github.com/dustin/go-humanize"
+	"github.com/golang/glog"
+	"golang.org/x/net/context"
+)
+
+/*
+A tablet is replicated by the voters of the group serving it. Groups have --replicas of them,
+unless the Alphas forming them ask for another number with --group_replicas. A tablet can want
+another number of replicas, set with /tabletReplicas:
+• Fewer than --replicas: Zero moves it to a group of at most as many replicas, like one of a single
+  Alpha for low-value predicates.
+• More than --replicas: Zero copies it to other groups, until the replicas of its group and of the
+  groups holding a copy reach as many. The steps are those of a move, but g1 keeps p:
+  • Propose that p is read only, and moving to g2.
+  • Ask g1 to move p to g2 (Endpoint: Zero → g1).
+  • Propose that g2 holds a copy of p, and that p is writable again.
+The copies no longer needed are dropped, and their groups delete the data. The other tablets are
+only served by groups of --replicas. Split tablets have no copies.
+*/
+
+// groupReplicas returns the number of voters the group is formed of.
+func (s *Server) groupReplicas(group *pb.Group) int {
+	if group.Replicas > 0 {
+		return int(group.Replicas)
+	}
+	return s.NumReplicas
+}
+
+// memberReplicas returns the number of voters of the groups the Alpha can form or join.
+func (s *Server) memberReplicas(m *pb.Member) int {
+	if m.Replicas > 0 {
+		return int(m.Replicas)
+	}
+	return s.NumReplicas
+}
+
+// tabletReplicas returns the number of replicas wanted for the tablet.
+func (s *Server) tabletReplicas(tab *pb.Tablet) int {
+	if tab.Replicas > 0 {
+		return int(tab.Replicas)
+	}
+	return s.NumReplicas
+}
+
+// fitsTablet tells whether a group of the given replicas can serve a tablet wanting want of them.
+// The tablets wanting fewer than numReplicas are served by groups of at most as many, and the
+// others by groups of at least numReplicas, with copies for more.
+func fitsTablet(replicas, want, numReplicas int) bool {
+	if want < numReplicas {
+		return replicas <= want
+	}
+	return replicas >= numReplicas && replicas <= want
+}
+
+func (s *Server) fits(tab *pb.Tablet, group *pb.Group) bool {
+	return fitsTablet(s.groupReplicas(group), s.tabletReplicas(tab), s.NumReplicas)
+}
+
+// holdsCopy tells whether gid holds a copy of tab, rather than serving it.
+func holdsCopy(tab *pb.Tablet, gid uint32) bool {
+	for _, c := range tab.Copies {
+		if c.GroupId == gid {
+			return true
+		}
+	}
+	return false
+}
+
+// heldReplicas returns the replicas tab has, those of its group and of the groups holding a copy.
+func (s *Server) heldReplicas(tab *pb.Tablet) int {
+	s.AssertRLock()
+	var n int
+	if group := s.state.Groups[tab.GroupId]; group != nil {
+		n += s.groupReplicas(group)
+	}
+	for _, c := range tab.Copies {
+		if group := s.state.Groups[c.GroupId]; group != nil {
+			n += s.groupReplicas(group)
+		}
+	}
+	return n
+}
+
+// replicaChange is a change of the groups holding a tablet, towards the replicas wanted for it.
+// At most one of the groups is set.
+type replicaChange struct {
+	predicate string
+	moveTo    uint32 // Group fitting the tablet, if the one serving it doesn't.
+	copyTo    uint32 // Group to copy the tablet to, if it has too few replicas.
+	dropCopy  uint32 // Group to drop the copy of, if the tablet doesn't need it.
+}
+
+// chooseReplicaChange returns the first change, by predicate, for the tablets to have the
+// replicas wanted for them.
+func (s *Server) chooseReplicaChange() replicaChange {
+	s.RLock()
+	defer s.RUnlock()
+	if s.state == nil || !s.Node.AmLeader() {
+		return replicaChange{}
+	}
+	return s.nextReplicaChange()
+}
+
+func (s *Server) nextReplicaChange() replicaChange {
+	s.AssertRLock()
+	var tablets []*pb.Tablet
+	for _, group := range s.state.Groups {
+		for _, tab := range group.Tablets {
+			tablets = append(tablets, tab)
+		}
+	}
+	sort.Slice(tablets, func(i, j int) bool {
+		return tablets[i].Predicate < tablets[j].Predicate
+	})
+
+	spaces := s.groupSpaces()
+	for _, tab := range tablets {
+		if tab.ReadOnly || len(tab.Shards) > 0 {
+			continue
+		}
+		// smallest returns the group taking the least space among those with a leader holding
+		// no copy of tab, and fit for it if fit is set, or zero if there's none.
+		smallest := func(fit bool) uint32 {
+			var gid uint32
+			for g, space := range spaces {
+				group := s.state.Groups[g]
+				if g == tab.GroupId || holdsCopy(tab, g) || !s.hasLeader(g) ||
+					(fit && !s.fits(tab, group)) {
+					continue
+				}
+				if gid == 0 || space < spaces[gid] || (space == spaces[gid] && g < gid) {
+					gid = g
+				}
+			}
+			return gid
+		}
+
+		want, held := s.tabletReplicas(tab), s.heldReplicas(tab)
+		for i := len(tab.Copies) - 1; i >= 0; i-- {
+			gid := tab.Copies[i].GroupId
+			group := s.state.Groups[gid]
+			if group == nil || held-s.groupReplicas(group) >= want {
+				return replicaChange{predicate: tab.Predicate, dropCopy: gid}
+			}
+		}
+		if !s.fits(tab, s.state.Groups[tab.GroupId]) {
+			if gid := smallest(true); gid != 0 {
+				return replicaChange{predicate: tab.Predicate, moveTo: gid}
+			}
+			continue
+		}
+		if held < want {
+			if gid := smallest(false); gid != 0 {
+				return replicaChange{predicate: tab.Predicate, copyTo: gid}
+			}
+		}
+	}
+	return replicaChange{}
+}
+
+// changeReplicas makes the change of the groups holding a tablet.
+func (s *Server) changeReplicas(c replicaChange) error {
+	switch {
+	case c.moveTo != 0:
+		tab := s.ServingTablet(c.predicate)
+		if tab == nil {
+			return x.Errorf("No tablet found for: %s", c.predicate)
+		}
+		return s.movePredicate(c.predicate, tab.GroupId, c.moveTo)
+	case c.copyTo != 0:
+		return s.copyTablet(c.predicate, c.copyTo)
+	case c.dropCopy != 0:
+		return s.dropTabletCopy(c.predicate, c.dropCopy)
+	}
+	return nil
+}
+
+// setTabletReplicas sets the number of replicas wanted for the tablet. Zero means --replicas.
+// The groups holding the tablet are changed by the rebalancing.
+func (s *Server) setTabletReplicas(ctx context.Context, predicate string, replicas uint32) error {
+	tab := s.ServingTablet(predicate)
+	if tab == nil {
+		return x.Errorf("No tablet found for: %s", predicate)
+	}
+	if len(tab.Shards) > 0 && replicas > 0 && int(replicas) != s.NumReplicas {
+		return x.Errorf("Tablet %s is split across groups, so it only has --replicas", predicate)
+	}
+	if replicas == 0 {
+		glog.Infof("Tablet %s now wants --replicas", predicate)
+	} else {
+		glog.Infof("Tablet %s now wants %d replicas", predicate, replicas)
+	}
+	p := &pb.ZeroProposal{TabletReplicas: &pb.TabletReplicas{
+		Predicate: predicate,
+		Replicas:  replicas,
+	}}
+	return s.Node.proposeAndWait(ctx, p)
+}
+
+// copyTablet copies the tablet served by its group to dstGroup, for it to have more replicas.
+func (s *Server) copyTablet(predicate string, dstGroup uint32) error {
+	if readTs, held := s.clones.held(time.Now()); held {
+		return x.Errorf("Tablets can't be copied while the cluster is being cloned at ts %d",
+			readTs)
+	}
+	if source := s.replicaOf(); len(source) > 0 {
+		return x.Errorf("Tablets can't be copied while the cluster replicates %s", source)
+	}
+	tab := s.ServingTablet(predicate)
+	switch {
+	case tab == nil:
+		return x.Errorf("No tablet found for: %s", predicate)
+	case tab.ReadOnly:
+		return x.Errorf("Tablet %s is read only, as it's being moved, split or copied", predicate)
+	case len(tab.Shards) > 0:
+		return x.Errorf("Tablet %s is split across groups, so it can't be copied", predicate)
+	case tab.GroupId == dstGroup || holdsCopy(tab, dstGroup):
+		return x.Errorf("Group %d holds tablet %s already", dstGroup, predicate)
+	}
+	srcGroup := tab.GroupId
+	glog.Infof("Going to copy predicate: [%v], size: [%v] of group %d to %d\n", predicate,
+		humanize.Bytes(uint64(tab.Space)), srcGroup, dstGroup)
+
+	ctx, cancel := context.WithTimeout(context.Background(), predicateMoveTimeout)
+	done := make(chan struct{}, 1)
+	go s.watchMove(done, cancel)
+
+	err := s.copyTabletHelper(ctx, tab, dstGroup)
+	done <- struct{}{}
+	if err != nil {
+		x.ZeroTabletCopies.Add("error", 1)
+		glog.Errorf("Got error during copy: %v", err)
+		if !s.Node.AmLeader() {
+			s.runRecovery()
+		} else {
+			p := &pb.ZeroProposal{}
+			p.Tablet = &pb.Tablet{
+				GroupId:   srcGroup,
+				Predicate: predicate,
+				Space:     tab.Space,
+				Force:     true,
+			}
+			if nerr := s.Node.proposeAndWait(context.Background(), p); nerr != nil {
+				glog.Errorf("Error while reverting tablet %s to RW: %+v\n", predicate, nerr)
+			}
+		}
+		ev := newEvent(eventTabletCopyFailed, srcGroup, 0, "Copying %s of group %d to %d: %v",
+			predicate, srcGroup, dstGroup, err)
+		ev.Predicate = predicate
+		go s.proposeEvent(ev)
+		return x.Errorf("Error while trying to copy predicate %v of group %d to %d: %v",
+			predicate, srcGroup, dstGroup, err)
+	}
+	glog.Infof("Predicate copy done for: [%v] of group %d to %d\n", predicate, srcGroup, dstGroup)
+	x.ZeroTabletCopies.Add("ok", 1)
+	return nil
+}
+
+func (s *Server) copyTabletHelper(ctx context.Context, tab *pb.Tablet, dstGroup uint32) error {
+	n := s.Node
+	// Propose that the tablet is read only, and moving, as for a move.
+	start := time.Now()
+	p := &pb.ZeroProposal{}
+	p.Tablet = &pb.Tablet{
+		GroupId:       tab.GroupId,
+		Predicate:     tab.Predicate,
+		Space:         tab.Space,
+		ReadOnly:      true,
+		Force:         true,
+		MovingTo:      dstGroup,
+		MoveStartedAt: start.Unix(),
+		MoveEta:       s.moveEta(tab.Space, start).Unix(),
+	}
+	p.Event = newEvent(eventTabletCopyStarted, tab.GroupId, 0, "Copying %s of group %d to %d",
+		tab.Predicate, tab.GroupId, dstGroup)
+	p.Event.Predicate = tab.Predicate
+	if err := n.proposeAndWait(ctx, p); err != nil {
+		return err
+	}
+	pl := s.Leader(tab.GroupId)
+	if pl == nil {
+		return x.Errorf("No healthy connection found to leader of group %d", tab.GroupId)
+	}
+
+	// The source group keeps the tablet, as it still serves it once sent.
+	c := pb.NewWorkerClient(pl.Get())
+	in := &pb.MovePredicatePayload{
+		Predicate:     tab.Predicate,
+		State:         s.membershipState(),
+		SourceGroupId: tab.GroupId,
+		DestGroupId:   dstGroup,
+	}
+	if _, err := c.MovePredicate(ctx, in); err != nil {
+		return x.Errorf("While calling MovePredicate: %+v", err)
+	}
+	if d := time.Since(start); d > time.Second {
+		atomic.StoreInt64(&s.moveRate, int64(float64(tab.Space)/d.Seconds()))
+	}
+
+	// Propose that dstGroup holds a copy, and the tablet in RW.
+	copies := append([]*pb.TabletCopy{}, tab.Copies...)
+	copies = append(copies, &pb.TabletCopy{GroupId: dstGroup})
+	p = &pb.ZeroProposal{TabletCopies: &pb.TabletCopies{
+		Predicate: tab.Predicate,
+		Copies:    copies,
+	}}
+	p.Event = newEvent(eventTabletCopied, dstGroup, 0, "Copied %s of group %d to %d in %s",
+		tab.Predicate, tab.GroupId, dstGroup, time.Since(start).Round(time.Millisecond))
+	p.Event.Predicate = tab.Predicate
+	return n.proposeAndWait(ctx, p)
+}
+
+// dropTabletCopy drops the copy of the tablet held by gid. The group deletes the data once it
+// gets the new state.
+func (s *Server) dropTabletCopy(predicate string, gid uint32) error {
+	tab := s.ServingTablet(predicate)
+	if tab == nil {
+		return x.Errorf("No tablet found for: %s", predicate)
+	}
+	if tab.ReadOnly {
+		return x.Errorf("Tablet %s is read only, as it's being moved, split or copied", predicate)
+	}
+	var copies []*pb.TabletCopy
+	for _, c := range tab.Copies {
+		if c.GroupId != gid {
+			copies = append(copies, c)
+		}
+	}
+	if len(copies) == len(tab.Copies) {
+		return x.Errorf("Group %d holds no copy of tablet %s", gid, predicate)
+	}
+	glog.Infof("Dropping the copy of predicate: [%v] held by group %d\n", predicate, gid)
+	p := &pb.ZeroProposal{TabletCopies: &pb.TabletCopies{
+		Predicate: predicate,
+		Copies:    copies,
+	}}
+	p.Event = newEvent(eventTabletCopyDropped, gid, 0, "Dropped the copy of %s held by group %d",
+		predicate, gid)
+	p.Event.Predicate = predicate
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+	return s.Node.proposeAndWait(ctx, p)
+}
@@ -0,0 +1,119 @@
+/*
+ * Copyright 2018 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package zero
+
+import (
+	"testing"
+
+	"github.com/dgraph-io/dgraph/protos/pb"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFitsTablet(t *testing.T) {
+	// Tablets wanting --replicas are served by groups of as many.
+	require.True(t, fitsTablet(3, 3, 3))
+	require.False(t, fitsTablet(1, 3, 3))
+	require.False(t, fitsTablet(5, 3, 3))
+	// Fewer, by groups of at most as many.
+	require.True(t, fitsTablet(1, 1, 3))
+	require.True(t, fitsTablet(1, 2, 3))
+	require.False(t, fitsTablet(3, 1, 3))
+	// More, by groups of at least --replicas, with copies for the rest.
+	require.True(t, fitsTablet(3, 5, 3))
+	require.True(t, fitsTablet(5, 5, 3))
+	require.False(t, fitsTablet(1, 5, 3))
+}
+
+func testGroup(gid uint32, replicas uint32, voters int) *pb.Group {
+	group := &pb.Group{Replicas: replicas, Members: make(map[uint64]*pb.Member),
+		Tablets: make(map[string]*pb.Tablet)}
+	for i := 0; i < voters; i++ {
+		id := uint64(gid)*10 + uint64(i)
+		group.Members[id] = &pb.Member{Id: id, GroupId: gid, Leader: i == 0}
+	}
+	return group
+}
+
+func TestNextReplicaChange(t *testing.T) {
+	s := &Server{NumReplicas: 3, state: &pb.MembershipState{Groups: map[uint32]*pb.Group{
+		1: testGroup(1, 0, 3),
+		2: testGroup(2, 0, 3),
+		3: testGroup(3, 1, 1),
+	}}}
+	groups := s.state.Groups
+	groups[1].Tablets["a"] = &pb.Tablet{GroupId: 1, Predicate: "a", Space: 10, Replicas: 1}
+	groups[1].Tablets["b"] = &pb.Tablet{GroupId: 1, Predicate: "b", Space: 20, Replicas: 5}
+	groups[2].Tablets["c"] = &pb.Tablet{GroupId: 2, Predicate: "c", Space: 50}
+	s.RLock()
+	defer s.RUnlock()
+	require.Equal(t, 3, s.groupReplicas(groups[1]))
+	require.Equal(t, 1, s.groupReplicas(groups[3]))
+
+	// A tablet wanting a single replica moves to the group of one.
+	require.Equal(t, replicaChange{predicate: "a", moveTo: 3}, s.nextReplicaChange())
+	groups[3].Tablets["a"] = groups[1].Tablets["a"]
+	groups[3].Tablets["a"].GroupId = 3
+	delete(groups[1].Tablets, "a")
+
+	// A tablet wanting five is copied to the smallest group, as its group only has three.
+	require.Equal(t, replicaChange{predicate: "b", copyTo: 3}, s.nextReplicaChange())
+	groups[3].Tablets["a"].Space = 100
+	require.Equal(t, replicaChange{predicate: "b", copyTo: 2}, s.nextReplicaChange())
+	groups[1].Tablets["b"].Copies = []*pb.TabletCopy{{GroupId: 2}}
+	require.Equal(t, 6, s.heldReplicas(groups[1].Tablets["b"]))
+	require.Equal(t, replicaChange{}, s.nextReplicaChange())
+
+	// The copies no longer needed are dropped, as are those of groups which are gone.
+	groups[1].Tablets["b"].Replicas = 0
+	require.Equal(t, replicaChange{predicate: "b", dropCopy: 2}, s.nextReplicaChange())
+	groups[1].Tablets["b"].Replicas = 5
+	groups[1].Tablets["b"].Copies = []*pb.TabletCopy{{GroupId: 2}, {GroupId: 4}}
+	require.Equal(t, replicaChange{predicate: "b", dropCopy: 4}, s.nextReplicaChange())
+
+	// Tablets being moved are left alone.
+	groups[1].Tablets["b"].ReadOnly = true
+	require.Equal(t, replicaChange{}, s.nextReplicaChange())
+}
+
+func TestTabletCopiesProposal(t *testing.T) {
+	s := &Server{NumReplicas: 3, state: &pb.MembershipState{Groups: map[uint32]*pb.Group{
+		1: testGroup(1, 0, 3),
+		2: testGroup(2, 0, 3),
+	}}}
+	n := &node{server: s}
+	s.state.Groups[1].Tablets["b"] = &pb.Tablet{GroupId: 1, Predicate: "b", Space: 20,
+		ReadOnly: true, MovingTo: 2}
+	s.Lock()
+	defer s.Unlock()
+
+	require.NoError(t, n.handleTabletReplicasProposal(&pb.TabletReplicas{Predicate: "b",
+		Replicas: 5}))
+	require.Error(t, n.handleTabletCopiesProposal(&pb.TabletCopies{Predicate: "b",
+		Copies: []*pb.TabletCopy{{GroupId: 1}}}))
+	require.NoError(t, n.handleTabletCopiesProposal(&pb.TabletCopies{Predicate: "b",
+		Copies: []*pb.TabletCopy{{GroupId: 2}}}))
+	tab := s.servingTablet("b")
+	require.False(t, tab.ReadOnly)
+	require.Zero(t, tab.MovingTo)
+
+	// The other tablet proposals, like those of its size, keep its replicas.
+	require.NoError(t, n.handleTabletProposal(&pb.Tablet{GroupId: 1, Predicate: "b", Space: 30}))
+	tab = s.servingTablet("b")
+	require.Equal(t, int64(30), tab.Space)
+	require.Equal(t, uint32(5), tab.Replicas)
+	require.Equal(t, []*pb.TabletCopy{{GroupId: 2}}, tab.Copies)
+}
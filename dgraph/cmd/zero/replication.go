@@ -0,0 +1,461 @@
+/*
+ * Copyright 2018 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package zero
+
+import (
+	"context"
+	"expvar"
+	"fmt"
+	"io"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/golang/glog"
+
+	"github.com/dgraph-io/dgraph/conn"
+	"github.com/dgraph-io/dgraph/protos/pb"
+	"github.com/dgraph-io/dgraph/x"
+)
+
+// The Zero of a secondary cluster can replicate a primary cluster, asynchronously. Every round,
+// its leader picks a read ts on the primary, and streams from the leader of every group of the
+// primary the keys changed since the read ts of the last round, along with the keys of the
+// tablets which moved to the group since, and the keys deleted. The predicates no longer served
+// by the primary are dropped from the secondary. The keys are sent to the groups of the secondary
+// serving their predicates, and written at the versions they were committed at in the primary.
+// Once all the groups are done, the timestamps of the secondary are moved past the read ts, so
+// that its queries see the data, and the read ts is recorded in the state as applied_ts.
+//
+// The primary is the only one written to: the secondary rejects any writes until it's promoted,
+// at which point it stops replicating and becomes writable.
+
+const replicationTimeout = 10 * time.Minute
+
+type replicator struct {
+	round sync.Mutex // Held during rounds, so that promotions wait for them.
+
+	sync.Mutex
+	applied map[uint32]time.Time // Group of the primary -> when its last applied data was read.
+}
+
+// replicaOf returns the Zero of the primary cluster, if this cluster is replicating it.
+func (s *Server) replicaOf() string {
+	s.RLock()
+	defer s.RUnlock()
+	if r := s.state.GetReplication(); r != nil && !r.Promoted {
+		return r.Source
+	}
+	return ""
+}
+
+// replicate keeps replicating the cluster of the Zero at source, while this node is the leader.
+func (s *Server) replicate(source string, interval time.Duration) {
+	glog.Infof("Replicating the cluster of %s every %s", source, interval)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-s.shutDownCh:
+			return
+		case <-ticker.C:
+		}
+		if !s.Node.AmLeader() {
+			continue
+		}
+		r := s.membershipState().Replication
+		switch {
+		case r == nil:
+			// The cluster becomes read-only before any data is replicated.
+			err := s.Node.proposeAndWait(context.Background(),
+				&pb.ZeroProposal{Replication: &pb.Replication{Source: source}})
+			if err != nil {
+				glog.Errorf("While starting to replicate %s: %v", source, err)
+			}
+			continue
+		case r.Promoted:
+			glog.Infof("Cluster was promoted. Not replicating %s anymore.", r.Source)
+			return
+		case r.Source != source:
+			glog.Errorf("Cluster is already a replica of %s. Not replicating %s.", r.Source, source)
+			return
+		}
+		if err := s.replicateRound(); err != nil {
+			glog.Errorf("While replicating %s: %v", source, err)
+		}
+		s.replica.updateLag()
+	}
+}
+
+// replicateRound replicates what changed in the primary since the last round.
+func (s *Server) replicateRound() error {
+	s.replica.round.Lock()
+	defer s.replica.round.Unlock()
+	ctx, cancel := context.WithTimeout(context.Background(), replicationTimeout)
+	defer cancel()
+
+	// The replication could have been stopped while waiting for the last round.
+	r := s.membershipState().Replication
+	if r == nil || r.Promoted {
+		return nil
+	}
+	pl := conn.Get().Connect(r.Source)
+	if pl == nil {
+		return x.Errorf("Unable to connect to %s", r.Source)
+	}
+	cs, err := pb.NewZeroClient(pl.Get()).Connect(ctx, &pb.Member{ClusterInfoOnly: true})
+	if err != nil {
+		return err
+	}
+	primary := cs.State
+	readTs, err := primaryReadTs(ctx, primary, pl)
+	if err != nil {
+		return x.Wrapf(err, "while getting the read ts of the primary")
+	}
+
+	start := time.Now()
+	prev := make(map[string]uint32)
+	for _, tablet := range r.Tablets {
+		prev[tablet.Predicate] = tablet.GroupId
+	}
+	if err := s.replayDrops(ctx, droppedTablets(prev, primary), readTs); err != nil {
+		return x.Wrapf(err, "while dropping predicates")
+	}
+	router := &replicaRouter{s: s, groups: make(map[string]uint32)}
+	errCh := make(chan error, len(primary.Groups))
+	var wg sync.WaitGroup
+	for gid, group := range primary.Groups {
+		wg.Add(1)
+		go func(gid uint32, group *pb.Group) {
+			defer wg.Done()
+			moved := movedIn(prev, group, gid, r.AppliedTs > 0)
+			count, err := s.replicateGroup(ctx, router, group, gid, moved, r.AppliedTs, readTs)
+			if err != nil {
+				errCh <- x.Wrapf(err, "while replicating group %d", gid)
+				return
+			}
+			s.replica.done(gid, start, count)
+		}(gid, group)
+	}
+	wg.Wait()
+	close(errCh)
+	if err := <-errCh; err != nil {
+		return err
+	}
+
+	if err := s.advanceTs(ctx, readTs); err != nil {
+		return err
+	}
+	applied := &pb.Replication{Source: r.Source, AppliedTs: readTs, Tablets: tabletGroups(primary)}
+	if err := s.Node.proposeAndWait(ctx, &pb.ZeroProposal{Replication: applied}); err != nil {
+		return err
+	}
+	glog.V(2).Infof("Replicated %s up to ts %d", r.Source, readTs)
+	return nil
+}
+
+// primaryReadTs gets a read ts from the leader of the Zeros of the primary, which pl connects to
+// a Zero of.
+func primaryReadTs(ctx context.Context, primary *pb.MembershipState,
+	pl *conn.Pool) (uint64, error) {
+	for _, m := range primary.Zeros {
+		if m.Leader {
+			if lpl := conn.Get().Connect(m.Addr); lpl != nil {
+				pl = lpl
+			}
+			break
+		}
+	}
+	ts, err := pb.NewZeroClient(pl.Get()).Timestamps(ctx, &pb.Num{ReadOnly: true})
+	if err != nil {
+		return 0, err
+	}
+	return ts.ReadOnly, nil
+}
+
+// movedIn returns the tablets of group gid which were served by other groups, or by none, as of
+// the last round. Their keys were written to the group at the versions they had in the other
+// group, or in the predicate renamed to them, so they're streamed regardless of the ts of the
+// last round. Without a last round, nothing moved.
+func movedIn(prev map[string]uint32, group *pb.Group, gid uint32,
+	hasPrev bool) map[string]bool {
+	moved := make(map[string]bool)
+	if !hasPrev {
+		return moved
+	}
+	for pred := range group.Tablets {
+		if src, ok := prev[pred]; !ok || src != gid {
+			moved[pred] = true
+		}
+	}
+	return moved
+}
+
+// droppedTablets returns the tablets served by the primary as of the last round, which it no
+// longer serves. These were dropped, or renamed.
+func droppedTablets(prev map[string]uint32, primary *pb.MembershipState) []string {
+	served := make(map[string]bool)
+	for _, group := range primary.Groups {
+		for pred := range group.Tablets {
+			served[pred] = true
+		}
+	}
+	var dropped []string
+	for pred := range prev {
+		if !served[pred] {
+			dropped = append(dropped, pred)
+		}
+	}
+	sort.Strings(dropped)
+	return dropped
+}
+
+// replayDrops drops the predicates from the groups of the secondary serving them, as the
+// primary did. The drops delete the keys at the versions of their values, so streaming them
+// wouldn't replace the data the secondary has already.
+func (s *Server) replayDrops(ctx context.Context, preds []string, readTs uint64) error {
+	for _, pred := range preds {
+		tablet := s.servingTablet(pred)
+		if tablet == nil {
+			continue
+		}
+		pl := s.Leader(tablet.GroupId)
+		if pl == nil {
+			return x.Errorf("No healthy member found for group %d", tablet.GroupId)
+		}
+		m := &pb.Mutations{
+			GroupId: tablet.GroupId,
+			StartTs: readTs,
+			Edges: []*pb.DirectedEdge{
+				{Attr: pred, Value: []byte(x.Star), Op: pb.DirectedEdge_DEL},
+			},
+		}
+		if _, err := pb.NewWorkerClient(pl.Get()).Mutate(ctx, m); err != nil {
+			return x.Wrapf(err, "while dropping %s", pred)
+		}
+		glog.Infof("Dropped predicate %s, as the primary did", pred)
+	}
+	return nil
+}
+
+// keepReplicated tells whether a key streamed from a group of the primary needs replicating:
+// it must belong to the group, and have changed since sinceTs or belong to a tablet which moved
+// to the group. The schema and the deleted keys, which are empty, are always replicated.
+func keepReplicated(kv *pb.KV, tablets map[string]*pb.Tablet, moved map[string]bool,
+	sinceTs uint64) bool {
+	pk := x.Parse(kv.Key)
+	if pk == nil {
+		return false
+	}
+	if _, ok := tablets[pk.Attr]; !ok {
+		return false
+	}
+	return kv.Version > sinceTs || moved[pk.Attr] || pk.IsSchema() || len(kv.Val) == 0
+}
+
+// tabletGroups returns the groups serving the tablets of state, sorted by predicate.
+func tabletGroups(state *pb.MembershipState) []*pb.Tablet {
+	var tablets []*pb.Tablet
+	for gid, group := range state.Groups {
+		for pred := range group.Tablets {
+			tablets = append(tablets, &pb.Tablet{GroupId: gid, Predicate: pred})
+		}
+	}
+	sort.Slice(tablets, func(i, j int) bool { return tablets[i].Predicate < tablets[j].Predicate })
+	return tablets
+}
+
+// replicateGroup streams the keys to replicate from the leader of a group of the primary, and
+// sends them to the groups of the secondary. It returns the number of keys replicated.
+func (s *Server) replicateGroup(ctx context.Context, router *replicaRouter, group *pb.Group,
+	gid uint32, moved map[string]bool, sinceTs, readTs uint64) (int, error) {
+	var addr string
+	for _, m := range group.Members {
+		if m.Leader {
+			addr = m.Addr
+		}
+	}
+	if len(addr) == 0 {
+		return 0, x.Errorf("No leader found")
+	}
+	pl := conn.Get().Connect(addr)
+	if pl == nil {
+		return 0, x.Errorf("Unable to connect to %s", addr)
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	stream, err := pb.NewWorkerClient(pl.Get()).StreamSnapshot(ctx)
+	if err != nil {
+		return 0, err
+	}
+	// Tablets moving in since the last round need all their keys, so their group streams all.
+	req := &pb.Snapshot{Context: &pb.RaftContext{Group: gid}, ReadTs: readTs, SinceTs: sinceTs}
+	if len(moved) > 0 {
+		req.SinceTs = 0
+	}
+	if err := stream.Send(req); err != nil {
+		return 0, err
+	}
+
+	count := 0
+	for {
+		kvs, err := stream.Recv()
+		if err != nil {
+			return count, err
+		}
+		if kvs.Done {
+			break
+		}
+		batches := make(map[uint32][]*pb.KV)
+		for _, kv := range kvs.Kv {
+			if !keepReplicated(kv, group.Tablets, moved, sinceTs) {
+				continue
+			}
+			dst, err := router.route(ctx, x.Parse(kv.Key).Attr, gid)
+			if err != nil {
+				return count, err
+			}
+			batches[dst] = append(batches[dst], kv)
+		}
+		for dst, batch := range batches {
+			if err := s.applyReplicated(ctx, dst, batch); err != nil {
+				return count, x.Wrapf(err, "while applying to group %d", dst)
+			}
+			count += len(batch)
+		}
+	}
+	if err := stream.Send(&pb.Snapshot{Done: true}); err != nil {
+		return count, err
+	}
+	if err := stream.CloseSend(); err != nil {
+		return count, err
+	}
+	// Wait for the leader to get the acknowledgement, before cancelling the stream.
+	if _, err := stream.Recv(); err != io.EOF {
+		return count, err
+	}
+	return count, nil
+}
+
+func (s *Server) applyReplicated(ctx context.Context, gid uint32, kvs []*pb.KV) error {
+	pl := s.Leader(gid)
+	if pl == nil {
+		return x.Errorf("No healthy member found")
+	}
+	_, err := pb.NewWorkerClient(pl.Get()).ApplyReplicated(ctx, &pb.KVS{Kv: kvs})
+	return err
+}
+
+// replicaRouter picks the groups of the secondary the predicates are replicated to. Predicates
+// already served keep their group. New ones go to the group with the same id as in the primary,
+// or to the first group if there's no such group.
+type replicaRouter struct {
+	sync.Mutex
+	s      *Server
+	groups map[string]uint32 // Predicate -> group of the secondary serving it.
+}
+
+func (r *replicaRouter) route(ctx context.Context, pred string, primaryGid uint32) (uint32,
+	error) {
+	r.Lock()
+	defer r.Unlock()
+	if gid, ok := r.groups[pred]; ok {
+		return gid, nil
+	}
+	tab := r.s.ServingTablet(pred)
+	if tab == nil {
+		gid := replicaGroup(r.s.KnownGroups(), primaryGid)
+		if gid == 0 {
+			return 0, x.Errorf("No groups to replicate %q to", pred)
+		}
+		var err error
+		if tab, err = r.s.ShouldServe(ctx, &pb.Tablet{Predicate: pred, GroupId: gid}); err != nil {
+			return 0, err
+		}
+	}
+	r.groups[pred] = tab.GroupId
+	return tab.GroupId, nil
+}
+
+// replicaGroup returns the group of the secondary to serve a new predicate served by
+// primaryGid in the primary.
+func replicaGroup(gids []uint32, primaryGid uint32) uint32 {
+	var first uint32
+	for _, gid := range gids {
+		if gid == primaryGid {
+			return gid
+		}
+		if first == 0 || gid < first {
+			first = gid
+		}
+	}
+	return first
+}
+
+// advanceTs makes the next timestamps given out by this Zero greater than ts, and tells the
+// Alphas that everything up to them is done, so that queries see the data replicated up to ts.
+func (s *Server) advanceTs(ctx context.Context, ts uint64) error {
+	s.leaseLock.Lock()
+	next := s.nextTxnTs
+	s.leaseLock.Unlock()
+	if next > ts {
+		return nil
+	}
+	_, err := s.Timestamps(ctx, &pb.Num{Val: ts - next + 1})
+	return err
+}
+
+// promote stops the replication, and makes the cluster writable. Whatever the groups applied
+// of the round in progress, if any, is kept.
+func (s *Server) promote(ctx context.Context) (*pb.Replication, error) {
+	if !s.Node.AmLeader() {
+		return nil, x.Errorf("Only the leader of Zero can promote the cluster")
+	}
+	s.replica.round.Lock()
+	defer s.replica.round.Unlock()
+	r := s.membershipState().Replication
+	if r == nil || r.Promoted {
+		return nil, x.Errorf("Cluster isn't replicating another one")
+	}
+	r.Promoted = true
+	if err := s.Node.proposeAndWait(ctx, &pb.ZeroProposal{Replication: r}); err != nil {
+		return nil, err
+	}
+	glog.Infof("Cluster promoted. Stopped replicating %s at ts %d.", r.Source, r.AppliedTs)
+	return r, nil
+}
+
+func (r *replicator) done(gid uint32, readAt time.Time, count int) {
+	r.Lock()
+	if r.applied == nil {
+		r.applied = make(map[uint32]time.Time)
+	}
+	r.applied[gid] = readAt
+	r.Unlock()
+	x.ReplicationKeys.Add(fmt.Sprint(gid), int64(count))
+}
+
+// updateLag sets the lag of every group of the primary to the time since its data was last read.
+func (r *replicator) updateLag() {
+	r.Lock()
+	defer r.Unlock()
+	for gid, readAt := range r.applied {
+		lag := new(expvar.Float)
+		lag.Set(time.Since(readAt).Seconds())
+		x.ReplicationLag.Set(fmt.Sprint(gid), lag)
+	}
+}
@@ -0,0 +1,81 @@
+/*
+ * Copyright 2018 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package zero
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dgraph-io/dgraph/protos/pb"
+	"github.com/dgraph-io/dgraph/x"
+)
+
+func TestMovedIn(t *testing.T) {
+	group := &pb.Group{Tablets: map[string]*pb.Tablet{"name": {}, "age": {}, "friend": {}}}
+	prev := map[string]uint32{"name": 1, "age": 2}
+
+	// Tablets new since the last round could have been renamed, keeping the versions of the keys.
+	require.Equal(t, map[string]bool{"age": true, "friend": true}, movedIn(prev, group, 1, true))
+	require.Empty(t, movedIn(prev, group, 1, false))
+}
+
+func TestDroppedTablets(t *testing.T) {
+	primary := &pb.MembershipState{Groups: map[uint32]*pb.Group{
+		1: {Tablets: map[string]*pb.Tablet{"name": {}}},
+		2: {Tablets: map[string]*pb.Tablet{"age": {}}},
+	}}
+	prev := map[string]uint32{"name": 1, "age": 1, "friend": 2, "email": 1}
+	require.Equal(t, []string{"email", "friend"}, droppedTablets(prev, primary))
+	require.Empty(t, droppedTablets(nil, primary))
+}
+
+func TestKeepReplicated(t *testing.T) {
+	tablets := map[string]*pb.Tablet{"name": {}, "age": {}}
+	moved := map[string]bool{"age": true}
+	kv := func(key []byte, version uint64) *pb.KV {
+		return &pb.KV{Key: key, Val: []byte("value"), Version: version}
+	}
+
+	require.True(t, keepReplicated(kv(x.DataKey("name", 1), 11), tablets, moved, 10))
+	require.False(t, keepReplicated(kv(x.DataKey("name", 1), 10), tablets, moved, 10))
+	require.True(t, keepReplicated(kv(x.DataKey("age", 1), 5), tablets, moved, 10))
+	require.True(t, keepReplicated(kv(x.SchemaKey("name"), 1), tablets, moved, 10))
+	// Deleted keys keep the versions of the values deleted.
+	deleted := &pb.KV{Key: x.DataKey("name", 2), Version: 5}
+	require.True(t, keepReplicated(deleted, tablets, moved, 10))
+	// Tablets which moved away from the group.
+	require.False(t, keepReplicated(kv(x.DataKey("friend", 1), 11), tablets, moved, 10))
+}
+
+func TestReplicaGroup(t *testing.T) {
+	require.Equal(t, uint32(2), replicaGroup([]uint32{3, 1, 2}, 2))
+	require.Equal(t, uint32(1), replicaGroup([]uint32{3, 1, 2}, 4))
+	require.Equal(t, uint32(0), replicaGroup(nil, 1))
+}
+
+func TestTabletGroups(t *testing.T) {
+	state := &pb.MembershipState{Groups: map[uint32]*pb.Group{
+		1: {Tablets: map[string]*pb.Tablet{"name": {}}},
+		2: {Tablets: map[string]*pb.Tablet{"age": {}, "friend": {}}},
+	}}
+	require.Equal(t, []*pb.Tablet{
+		{GroupId: 2, Predicate: "age"},
+		{GroupId: 2, Predicate: "friend"},
+		{GroupId: 1, Predicate: "name"},
+	}, tabletGroups(state))
+}
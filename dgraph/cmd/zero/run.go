@@ -27,16 +27,18 @@ import (
 	"syscall"
 	"time"
 
-	"go.opencensus.io/exporter/jaeger"
 	"go.opencensus.io/plugin/ocgrpc"
-	otrace "go.opencensus.io/trace"
 	"go.opencensus.io/zpages"
 	"golang.org/x/net/context"
 	"golang.org/x/net/trace"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/reflection"
 
 	"github.com/dgraph-io/badger"
+	"github.com/dgraph-io/dgraph/access"
+	"github.com/dgraph-io/dgraph/audit"
 	"github.com/dgraph-io/dgraph/conn"
+	"github.com/dgraph-io/dgraph/fault"
 	"github.com/dgraph-io/dgraph/protos/pb"
 	"github.com/dgraph-io/dgraph/raftwal"
 	"github.com/dgraph-io/dgraph/x"
@@ -52,7 +54,21 @@ type options struct {
 	numReplicas       int
 	peer              string
 	w                 string
+	inMemory          bool
 	rebalanceInterval time.Duration
+	tabletSplitMB     int64
+	strictSchema      bool
+	learner           bool
+	replicateFrom     string
+	replicateInterval time.Duration
+	deadAfter         time.Duration
+	readStaleness     time.Duration
+	maxEvents         int
+	audit             string
+	auditRotateMB     int64
+	adminAddr         string
+	access            *access.Controller
+	peers             *conn.Discovery // Parsed from peer.
 }
 
 var opts options
@@ -70,7 +86,7 @@ instances to achieve high-availability.
 `,
 		Run: func(cmd *cobra.Command, args []string) {
 			defer x.StartProfile(Zero.Conf).Stop()
-			run()
+			run(nil)
 		},
 	}
 	Zero.EnvPrefix = "DGRAPH_ZERO"
@@ -83,14 +99,44 @@ instances to achieve high-availability.
 	flag.Uint64("idx", 1, "Unique node index for this server.")
 	flag.Int("replicas", 1, "How many replicas to run per data shard."+
 		" The count includes the original shard.")
-	flag.String("peer", "", "Address of another dgraphzero server.")
+	flag.String("peer", "", "Address of another dgraphzero server, or comma separated ones. Or"+
+		" the Zeros to find through the SRV records of a name, like"+
+		" dns+srv://_grpc._tcp.zero.ns.svc.cluster.local, or the endpoints of a Kubernetes"+
+		" service, like k8s://namespace/service:port_name. Zeros found that way join them,"+
+		" except the one with --idx 1, which starts the cluster if it's new.")
+	flag.Bool("learner", false, "Join the Zeros of --peer without voting. Learners replicate"+
+		" the state of the cluster and serve it, possibly stale, but never become the leader.")
 	flag.StringP("wal", "w", "zw", "Directory storing WAL.")
+	flag.Bool("badger.inmemory", false, "NOT DURABLE. Keep the WAL in memory, without syncing"+
+		" writes, in a temporary directory removed when Zero stops, instead of --wal. For tests,"+
+		" as the state of the cluster is lost when Zero stops.")
 	flag.Duration("rebalance_interval", 8*time.Minute, "Interval for trying a predicate move.")
+	flag.Int64("tablet_split_mb", 0, "Split the tablets bigger than this across groups, when"+
+		" trying a predicate move. Zero disables it.")
 	flag.Bool("telemetry", true, "Send anonymous telemetry data to Dgraph devs.")
+	flag.Bool("strict_schema", false, "Reject mutations on predicates which aren't in the"+
+		" schema, or with values of another type. Applied by the leader of Zero.")
+	flag.String("replicate_from", "", "Address of a Zero of another cluster to replicate"+
+		" asynchronously. The cluster is read-only until promoted.")
+	flag.Duration("replicate_interval", time.Second, "Interval between rounds of replication.")
+	flag.Duration("dead_after", 0, "Remove the Alphas unseen for this long from their groups,"+
+		" so that spare Alphas can replace them. Zero disables it.")
+	flag.Duration("read_staleness", 0, "Serve the membership state from any Zero, followers"+
+		" included, without checking with the leader for this long after the last check."+
+		" The state can then be this stale. Zero makes every read check with the leader.")
+	flag.Int("max_events", 10000, "Number of the most recent events of the cluster kept in"+
+		" the event log served by /events.")
+	flag.String("audit", "", "Audit log of the calls to the HTTP endpoints changing the"+
+		" cluster, either file=<path> or syslog[=<network>://<address>]. Empty disables it.")
+	flag.Int64("audit_rotate_mb", 100, "Size at which the audit log file is rotated."+
+		" Zero disables rotation.")
 
 	// OpenCensus flags.
-	flag.Float64("trace", 1.0, "The ratio of queries to trace.")
-	flag.String("jaeger.collector", "", "Send opencensus traces to Jaeger.")
+	x.RegisterTracingFlags(flag)
+	x.RegisterMetricsPushFlags(flag)
+	x.RegisterAdminFlags(flag)
+	access.RegisterFlags(flag)
+	conn.RegisterInternalTLSFlags(flag)
 }
 
 func setupListener(addr string, port int, kind string) (listener net.Listener, err error) {
@@ -106,18 +152,8 @@ type state struct {
 }
 
 func (st *state) serveGRPC(l net.Listener, wg *sync.WaitGroup, store *raftwal.DiskStorage) {
-	if collector := Zero.Conf.GetString("jaeger.collector"); len(collector) > 0 {
-		// Port details: https://www.jaegertracing.io/docs/getting-started/
-		// Default collectorEndpointURI := "http://localhost:14268"
-		je, err := jaeger.NewExporter(jaeger.Options{
-			Endpoint:    collector,
-			ServiceName: "dgraph.zero",
-		})
-		if err != nil {
-			log.Fatalf("Failed to create the Jaeger exporter: %v", err)
-		}
-		// And now finally register it as a Trace Exporter
-		otrace.RegisterExporter(je)
+	if err := x.RegisterTraceExportersFromConfig(Zero.Conf, "dgraph.zero"); err != nil {
+		log.Fatalf("Failed to set up tracing: %v", err)
 	}
 	// Exclusively for stats, metrics, etc. Not for tracing.
 	// var views = append(ocgrpc.DefaultServerViews, ocgrpc.DefaultClientViews...)
@@ -125,13 +161,18 @@ func (st *state) serveGRPC(l net.Listener, wg *sync.WaitGroup, store *raftwal.Di
 	// 	glog.Fatalf("Unable to register OpenCensus stats: %v", err)
 	// }
 
-	s := grpc.NewServer(
+	opt := []grpc.ServerOption{
 		grpc.MaxRecvMsgSize(x.GrpcMaxSize),
 		grpc.MaxSendMsgSize(x.GrpcMaxSize),
 		grpc.MaxConcurrentStreams(1000),
-		grpc.StatsHandler(&ocgrpc.ServerHandler{}))
+		grpc.StatsHandler(&ocgrpc.ServerHandler{}),
+	}
+	// Like the internal port of Alphas, the gRPC port only serves the Alphas, Zeros and loaders
+	// of the cluster, through pb.Zero and pb.Raft, so the allowlists and rate limits don't apply
+	// to it. It's secured with internal TLS instead.
+	s := grpc.NewServer(append(opt, conn.ServerOptions()...)...)
 
-	rc := pb.RaftContext{Id: opts.nodeId, Addr: opts.myAddr, Group: 0}
+	rc := pb.RaftContext{Id: opts.nodeId, Addr: opts.myAddr, Group: 0, IsLearner: opts.learner}
 	m := conn.NewNode(&rc, store)
 
 	// Zero followers should not be forwarding proposals to the leader, to avoid txn commits which
@@ -146,6 +187,8 @@ func (st *state) serveGRPC(l net.Listener, wg *sync.WaitGroup, store *raftwal.Di
 
 	pb.RegisterZeroServer(s, st.zero)
 	pb.RegisterRaftServer(s, st.rs)
+	pb.RegisterNegotiationServer(s, st.zero)
+	reflection.Register(s)
 
 	go func() {
 		defer wg.Done()
@@ -171,7 +214,26 @@ func (st *state) serveGRPC(l net.Listener, wg *sync.WaitGroup, store *raftwal.Di
 	}()
 }
 
-func run() {
+// walOptions returns the options of the Badger store of the WAL in dir. A WAL in memory isn't
+// synced.
+func walOptions(dir string, inMemory bool) badger.Options {
+	opt := badger.LSMOnlyOptions
+	opt.SyncWrites = !inMemory
+	opt.Truncate = true
+	opt.Dir = dir
+	opt.ValueDir = dir
+	opt.ValueLogFileSize = 64 << 20
+	return opt
+}
+
+// Run runs Zero with the configuration of Zero.Conf until stop is closed. It's used by package
+// embed, which runs Zero inside another process, so it doesn't handle the signals.
+func Run(stop <-chan struct{}) {
+	run(stop)
+}
+
+// run runs Zero until stop is closed, or until it gets a signal to terminate if stop is nil.
+func run(stop <-chan struct{}) {
 	x.PrintVersion()
 	opts = options{
 		bindall:           Zero.Conf.GetBool("bindall"),
@@ -181,7 +243,36 @@ func run() {
 		numReplicas:       Zero.Conf.GetInt("replicas"),
 		peer:              Zero.Conf.GetString("peer"),
 		w:                 Zero.Conf.GetString("wal"),
+		inMemory:          Zero.Conf.GetBool("badger.inmemory"),
 		rebalanceInterval: Zero.Conf.GetDuration("rebalance_interval"),
+		tabletSplitMB:     Zero.Conf.GetInt64("tablet_split_mb"),
+		strictSchema:      Zero.Conf.GetBool("strict_schema"),
+		learner:           Zero.Conf.GetBool("learner"),
+		replicateFrom:     Zero.Conf.GetString("replicate_from"),
+		replicateInterval: Zero.Conf.GetDuration("replicate_interval"),
+		deadAfter:         Zero.Conf.GetDuration("dead_after"),
+		readStaleness:     Zero.Conf.GetDuration("read_staleness"),
+		maxEvents:         Zero.Conf.GetInt("max_events"),
+		audit:             Zero.Conf.GetString("audit"),
+		auditRotateMB:     Zero.Conf.GetInt64("audit_rotate_mb"),
+		adminAddr:         Zero.Conf.GetString("admin_addr"),
+	}
+
+	x.Checkf(conn.SetupInternalTLSFromConfig(Zero.Conf), "While setting up internal TLS")
+	var err error
+	if len(opts.peer) > 0 {
+		opts.peers, err = conn.ParseDiscovery(opts.peer)
+		x.Checkf(err, "While parsing --peer")
+	}
+	opts.access, err = access.FromConfig(Zero.Conf)
+	x.Checkf(err, "While setting up the access control")
+	if opts.rebalanceInterval <= 0 {
+		log.Fatalf("ERROR: --rebalance_interval must be positive. Found: %s",
+			opts.rebalanceInterval)
+	}
+	rebalanceInterval = int64(opts.rebalanceInterval)
+	if opts.tabletSplitMB < 0 {
+		log.Fatalf("ERROR: --tablet_split_mb can't be negative. Found: %d", opts.tabletSplitMB)
 	}
 
 	if opts.numReplicas < 0 || opts.numReplicas%2 == 0 {
@@ -195,8 +286,7 @@ func run() {
 		}
 	}
 	grpc.EnableTracing = false
-	otrace.ApplyConfig(otrace.Config{
-		DefaultSampler: otrace.ProbabilitySampler(Zero.Conf.GetFloat64("trace"))})
+	x.Checkf(x.SetTraceRatio(Zero.Conf.GetFloat64("trace")), "While setting --trace")
 
 	addr := "localhost"
 	if opts.bindall {
@@ -215,14 +305,23 @@ func run() {
 	}
 
 	// Open raft write-ahead log and initialize raft node.
+	if opts.inMemory {
+		var shm bool
+		opts.w, shm, err = x.MemoryDir("dgraph-zero")
+		x.Checkf(err, "While creating the directory of the WAL in memory")
+		// Removed once the WAL is closed, as the defers run in reverse.
+		defer os.RemoveAll(opts.w)
+		if shm {
+			glog.Warningf("The WAL isn't durable: it's kept in memory, in %s, and lost when"+
+				" Zero stops.", opts.w)
+		} else {
+			glog.Warningf("There's no /dev/shm to keep the WAL in memory. It isn't durable:"+
+				" it's kept on disk, in %s, without syncing it, and lost when Zero stops.", opts.w)
+		}
+	}
 	x.Checkf(os.MkdirAll(opts.w, 0700), "Error while creating WAL dir.")
-	kvOpt := badger.LSMOnlyOptions
-	kvOpt.SyncWrites = true
-	kvOpt.Truncate = true
-	kvOpt.Dir = opts.w
-	kvOpt.ValueDir = opts.w
-	kvOpt.ValueLogFileSize = 64 << 20
-	kv, err := badger.Open(kvOpt)
+	x.Check(x.CheckFormat(opts.w, x.WALFormat))
+	kv, err := badger.Open(walOptions(opts.w, opts.inMemory))
 	x.Checkf(err, "Error while opening WAL store")
 	defer kv.Close()
 	store := raftwal.Init(kv, opts.nodeId, 0)
@@ -232,31 +331,105 @@ func run() {
 	// Initialize the servers.
 	var st state
 	st.serveGRPC(grpcListener, &wg, store)
-	st.serveHTTP(httpListener, &wg)
-
-	http.HandleFunc("/state", st.getState)
-	http.HandleFunc("/removeNode", st.removeNode)
-	http.HandleFunc("/moveTablet", st.moveTablet)
-	http.HandleFunc("/assignIds", st.assignUids)
-	zpages.Handle(http.DefaultServeMux, "/z")
+	st.zero.events = eventLog{db: kv, max: opts.maxEvents}
+	go st.zero.events.trimPeriodically(st.zero.shutDownCh)
+
+	// Zero has its own mux, so that it can run along with an Alpha in the same process. The debug
+	// endpoints, like those of traces and metrics, are registered on http.DefaultServeMux.
+	mux := http.NewServeMux()
+	mux.Handle("/debug/", http.DefaultServeMux)
+	mux.HandleFunc("/state", st.getState)
+	mux.HandleFunc("/health/cluster", st.getClusterHealth)
+	mux.HandleFunc("/events", st.getEvents)
+	// The calls changing the cluster are audited, and need the admin tokens if set. They're served
+	// on their own listener with --admin_addr.
+	var auditLog *audit.Logger
+	if len(opts.audit) > 0 {
+		auditLog, err = audit.Open(opts.audit, opts.auditRotateMB)
+		x.Checkf(err, "While opening the audit log")
+		defer auditLog.Close()
+	}
+	adminAuth, err := x.AdminAuthFromConfig(Zero.Conf)
+	x.Checkf(err, "While setting up the admin tokens")
+	adminMux := mux
+	if len(opts.adminAddr) > 0 {
+		adminMux = http.NewServeMux()
+	}
+	adminPaths := make(map[string]bool)
+	handleAdmin := func(path string, h http.HandlerFunc) {
+		adminPaths[path] = true
+		adminMux.HandleFunc(path, audit.Handler(auditLog, "admin", adminAuth.Handler(h)))
+	}
+	handleAdmin("/removeNode", st.removeNode)
+	handleAdmin("/moveTablet", st.moveTablet)
+	handleAdmin("/splitTablet", st.splitTablet)
+	handleAdmin("/tabletReplicas", st.tabletReplicas)
+	handleAdmin("/renamePredicate", st.renamePredicate)
+	handleAdmin("/namespaces", st.namespaces)
+	handleAdmin("/assignIds", st.assignUids)
+	handleAdmin("/export", st.export)
+	handleAdmin("/exportStatus", st.exportStatus)
+	handleAdmin("/clone", st.startClone)
+	handleAdmin("/cloneDone", st.cloneDone)
+	handleAdmin("/promote", st.promote)
+	handleAdmin("/lease", st.fencingLease)
+	handleAdmin("/releaseLease", st.releaseFencingLease)
+	handleAdmin("/admin/config", x.NewTunables(x.TraceTunable(), rebalanceTunable(),
+		st.zero.changeStreamRoutesTunable()).Handler)
+	handleAdmin("/admin/faults", fault.Handler)
+	zpages.Handle(mux, "/z")
+
+	// The handlers are all set up, so the HTTP servers can start.
+	isAdmin := func(path string) bool { return adminPaths[path] }
+	st.serveHTTP(httpListener, access.Handler(opts.access, mux, isAdmin), &wg)
+	var adminListener net.Listener
+	if len(opts.adminAddr) > 0 {
+		adminListener, err = net.Listen("tcp", opts.adminAddr)
+		if err != nil {
+			log.Fatal(err)
+		}
+		wg.Add(1)
+		st.serveHTTP(adminListener, access.Handler(opts.access, adminMux, isAdmin), &wg)
+		glog.Infof("Admin endpoints served at %s", adminListener.Addr())
+	}
 
 	// This must be here. It does not work if placed before Grpc init.
 	x.Check(st.node.initAndStartNode())
 
+	x.Checkf(x.StartMetricsPushFromConfig(Zero.Conf, opts.myAddr, st.zero.shutDownCh),
+		"While setting up the push of metrics")
+
 	if Zero.Conf.GetBool("telemetry") {
 		go st.zero.periodicallyPostTelemetry()
 	}
+	if len(opts.replicateFrom) > 0 {
+		go st.zero.replicate(opts.replicateFrom, opts.replicateInterval)
+	}
+	if opts.deadAfter > 0 {
+		go st.zero.healPeriodically(opts.deadAfter)
+	}
 
-	sdCh := make(chan os.Signal, 1)
-	signal.Notify(sdCh, os.Interrupt, syscall.SIGINT, syscall.SIGTERM)
+	if stop == nil {
+		sdCh := make(chan os.Signal, 1)
+		signal.Notify(sdCh, os.Interrupt, syscall.SIGINT, syscall.SIGTERM)
+		sig := make(chan struct{})
+		go func() {
+			<-sdCh
+			close(sig)
+		}()
+		stop = sig
+	}
 
 	go func() {
 		defer wg.Done()
-		<-sdCh
+		<-stop
 		glog.Infof("Shutting down...")
 		// Close doesn't close already opened connections.
 		httpListener.Close()
 		grpcListener.Close()
+		if adminListener != nil {
+			adminListener.Close()
+		}
 		close(st.zero.shutDownCh)
 		st.node.trySnapshot(0)
 	}()
@@ -0,0 +1,357 @@
+/*
+ * Copyright 2018 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package zero
+
+import (
+	"sort"
+	"time"
+
+	"github.com/dgraph-io/dgraph/protos/pb"
+	"github.com/dgraph-io/dgraph/x"
+	humanize "github.com/dustin/go-humanize"
+	"github.com/golang/glog"
+	"golang.org/x/net/context"
+)
+
+/*
+Steps to split the part of tablet p held by g1, with the uids from start to end, to g2:
+• Propose that p is read only, and moving to g2, as for a move.
+• Ask g1 to split its part (Endpoint: Zero → g1). g1 picks the uid splitting its data in halves,
+  and streams the data of the uids from it to end to g2. The index, reverse and count lists are
+  sent whole, as these are keyed by values or counts; every part skips the uids it doesn't hold.
+• Propose that g2 holds p from the split uid to end, and that p is writable again.
+• Once g1 gets the new state, it deletes the data of the uids it no longer holds.
+
+The group a tablet was served by before any split keeps holding the first part of it, and is the
+one the tablet is listed under. The other parts are in its shards, by their first uid.
+*/
+
+// tabletPart is the range of uids of a tablet held by a group, from start to end. An end of zero
+// means up to the last uid.
+type tabletPart struct {
+	gid   uint32
+	start uint64
+	end   uint64
+	space int64
+}
+
+// tabletParts returns the parts of tab by uid. A tablet which isn't split has a single part.
+func tabletParts(tab *pb.Tablet) []tabletPart {
+	parts := []tabletPart{{gid: tab.GroupId, start: 0, space: tab.Space}}
+	for _, sh := range tab.Shards {
+		parts[len(parts)-1].end = sh.StartUid
+		parts = append(parts, tabletPart{gid: sh.GroupId, start: sh.StartUid, space: sh.Space})
+	}
+	return parts
+}
+
+// groupSpaces returns the space taken by the tablets of each group, including the parts of the
+// tablets split to it and the copies it holds.
+func (s *Server) groupSpaces() map[uint32]int64 {
+	s.AssertRLock()
+	spaces := make(map[uint32]int64)
+	for gid := range s.state.Groups {
+		spaces[gid] = 0
+	}
+	for gid, group := range s.state.Groups {
+		for _, tab := range group.Tablets {
+			spaces[gid] += tab.Space
+			for _, sh := range tab.Shards {
+				if _, ok := spaces[sh.GroupId]; ok {
+					spaces[sh.GroupId] += sh.Space
+				}
+			}
+			for _, c := range tab.Copies {
+				if _, ok := spaces[c.GroupId]; ok {
+					spaces[c.GroupId] += tab.Space
+				}
+			}
+		}
+	}
+	return spaces
+}
+
+// smallestGroupWithout returns the group taking the least space among those holding none of the
+// parts, or zero if all groups hold one.
+func smallestGroupWithout(spaces map[uint32]int64, parts []tabletPart) uint32 {
+	holds := make(map[uint32]bool)
+	for _, part := range parts {
+		holds[part.gid] = true
+	}
+	var gid uint32
+	for g, space := range spaces {
+		if holds[g] {
+			continue
+		}
+		if gid == 0 || space < spaces[gid] || (space == spaces[gid] && g < gid) {
+			gid = g
+		}
+	}
+	return gid
+}
+
+// chooseSplit returns the biggest part of a tablet over --tablet_split_mb, with the group holding
+// it and the smallest group to split it to. Only the tablets wanting --replicas are split, among
+// the groups of as many.
+func (s *Server) chooseSplit() (predicate string, srcGroup uint32, dstGroup uint32) {
+	s.RLock()
+	defer s.RUnlock()
+	limit := opts.tabletSplitMB << 20
+	if s.state == nil || limit <= 0 || !s.Node.AmLeader() || len(s.state.Groups) <= 1 {
+		return
+	}
+
+	spaces := s.groupSpaces()
+	for gid, group := range s.state.Groups {
+		if s.groupReplicas(group) != s.NumReplicas {
+			delete(spaces, gid)
+		}
+	}
+	var size int64
+	for _, group := range s.state.Groups {
+		for _, tab := range group.Tablets {
+			if tab.ReadOnly || len(tab.Copies) > 0 || s.tabletReplicas(tab) != s.NumReplicas {
+				continue
+			}
+			parts := tabletParts(tab)
+			dst := smallestGroupWithout(spaces, parts)
+			if dst == 0 || !s.hasLeader(dst) {
+				continue
+			}
+			for _, part := range parts {
+				if part.space > limit && part.space > size {
+					predicate, srcGroup, dstGroup, size = tab.Predicate, part.gid, dst, part.space
+				}
+			}
+		}
+	}
+	return
+}
+
+// splitShards returns the shards of tab once the part held by src is split to dst at uid. The
+// space of both halves is estimated as half of the part, until the groups report it.
+func splitShards(tab *pb.Tablet, src tabletPart, dst uint32, uid uint64) []*pb.TabletShard {
+	half := src.space / 2
+	var shards []*pb.TabletShard
+	for _, sh := range tab.Shards {
+		sh := *sh
+		if sh.GroupId == src.gid {
+			sh.Space -= half
+		}
+		shards = append(shards, &sh)
+	}
+	shards = append(shards, &pb.TabletShard{StartUid: uid, GroupId: dst, Space: half})
+	sort.Slice(shards, func(i, j int) bool {
+		return shards[i].StartUid < shards[j].StartUid
+	})
+	return shards
+}
+
+// mergeShardSpaces returns the shards of prev, with the spaces of those in reported.
+func mergeShardSpaces(prev, reported []*pb.TabletShard) []*pb.TabletShard {
+	var shards []*pb.TabletShard
+	for _, sh := range prev {
+		sh := *sh
+		for _, r := range reported {
+			if r.GroupId == sh.GroupId && r.StartUid == sh.StartUid {
+				sh.Space = r.Space
+			}
+		}
+		shards = append(shards, &sh)
+	}
+	return shards
+}
+
+// shardProposal returns the proposal updating the space of the shard of a split tablet held by
+// the group reporting it, or nil if it hasn't changed much.
+func (s *Server) shardProposal(reported *pb.Tablet) *pb.ZeroProposal {
+	s.AssertRLock()
+	tab := s.servingTablet(reported.Predicate)
+	if tab == nil {
+		return nil
+	}
+	for _, sh := range tab.Shards {
+		if sh.GroupId != reported.GroupId {
+			continue
+		}
+		space := reported.Space
+		if reported.Remove {
+			space = 0
+		}
+		if !spaceChanged(sh.Space, space) {
+			return nil
+		}
+		p := &pb.ZeroProposal{}
+		p.Tablet = &pb.Tablet{
+			GroupId:   tab.GroupId,
+			Predicate: tab.Predicate,
+			Space:     tab.Space,
+			Shards:    []*pb.TabletShard{{StartUid: sh.StartUid, GroupId: sh.GroupId, Space: space}},
+		}
+		return p
+	}
+	return nil
+}
+
+// shardsHaveData tells whether any of the shards of tab still takes space.
+func shardsHaveData(tab *pb.Tablet) bool {
+	for _, sh := range tab.Shards {
+		if sh.Space > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// splitTablet splits the part of the tablet held by srcGroup to dstGroup.
+func (s *Server) splitTablet(predicate string, srcGroup, dstGroup uint32) error {
+	if readTs, held := s.clones.held(time.Now()); held {
+		return x.Errorf("Tablets can't be split while the cluster is being cloned at ts %d",
+			readTs)
+	}
+	if source := s.replicaOf(); len(source) > 0 {
+		return x.Errorf("Tablets can't be split while the cluster replicates %s", source)
+	}
+	tab := s.ServingTablet(predicate)
+	if tab == nil {
+		return x.Errorf("No tablet found for: %s", predicate)
+	}
+	if tab.ReadOnly {
+		return x.Errorf("Tablet %s is read only, as it's being moved or split", predicate)
+	}
+	if len(tab.Copies) > 0 || s.tabletReplicas(tab) != s.NumReplicas {
+		return x.Errorf("Tablet %s wants %d replicas, so it can't be split", predicate,
+			s.tabletReplicas(tab))
+	}
+	if group := s.membershipState().Groups[dstGroup]; group != nil &&
+		s.groupReplicas(group) != s.NumReplicas {
+		return x.Errorf("Group %d has %d replicas, so tablets can't be split to it", dstGroup,
+			s.groupReplicas(group))
+	}
+	var src *tabletPart
+	for _, part := range tabletParts(tab) {
+		part := part
+		switch part.gid {
+		case srcGroup:
+			src = &part
+		case dstGroup:
+			return x.Errorf("Group %d already holds a part of tablet %s", dstGroup, predicate)
+		}
+	}
+	if src == nil {
+		return x.Errorf("Group %d holds no part of tablet %s", srcGroup, predicate)
+	}
+	glog.Infof("Going to split predicate: [%v], size: [%v] of group %d to %d\n", predicate,
+		humanize.Bytes(uint64(src.space)), srcGroup, dstGroup)
+
+	ctx, cancel := context.WithTimeout(context.Background(), predicateMoveTimeout)
+	done := make(chan struct{}, 1)
+	go s.watchMove(done, cancel)
+
+	err := s.splitTabletHelper(ctx, tab, *src, dstGroup)
+	done <- struct{}{}
+	if err != nil {
+		x.ZeroTabletSplits.Add("error", 1)
+		glog.Errorf("Got error during split: %v", err)
+		if !s.Node.AmLeader() {
+			s.runRecovery()
+		} else {
+			p := &pb.ZeroProposal{}
+			p.Tablet = &pb.Tablet{
+				GroupId:   tab.GroupId,
+				Predicate: predicate,
+				Space:     tab.Space,
+				Force:     true,
+			}
+			if nerr := s.Node.proposeAndWait(context.Background(), p); nerr != nil {
+				glog.Errorf("Error while reverting tablet %s to RW: %+v\n", predicate, nerr)
+			}
+		}
+		ev := newEvent(eventTabletSplitFailed, srcGroup, 0, "Splitting %s of group %d to %d: %v",
+			predicate, srcGroup, dstGroup, err)
+		ev.Predicate = predicate
+		go s.proposeEvent(ev)
+		return x.Errorf("Error while trying to split predicate %v of group %d to %d: %v",
+			predicate, srcGroup, dstGroup, err)
+	}
+	glog.Infof("Predicate split done for: [%v] of group %d to %d\n", predicate, srcGroup, dstGroup)
+	x.ZeroTabletSplits.Add("ok", 1)
+	return nil
+}
+
+func (s *Server) splitTabletHelper(ctx context.Context, tab *pb.Tablet, src tabletPart,
+	dstGroup uint32) error {
+	n := s.Node
+	// Propose that the tablet is read only, and moving, as for a move of half of the part.
+	start := time.Now()
+	p := &pb.ZeroProposal{}
+	p.Tablet = &pb.Tablet{
+		GroupId:       tab.GroupId,
+		Predicate:     tab.Predicate,
+		Space:         tab.Space,
+		ReadOnly:      true,
+		Force:         true,
+		MovingTo:      dstGroup,
+		MoveStartedAt: start.Unix(),
+		MoveEta:       s.moveEta(src.space/2, start).Unix(),
+	}
+	p.Event = newEvent(eventTabletSplitStarted, src.gid, 0, "Splitting %s of group %d to %d",
+		tab.Predicate, src.gid, dstGroup)
+	p.Event.Predicate = tab.Predicate
+	if err := n.proposeAndWait(ctx, p); err != nil {
+		return err
+	}
+	pl := s.Leader(src.gid)
+	if pl == nil {
+		return x.Errorf("No healthy connection found to leader of group %d", src.gid)
+	}
+
+	c := pb.NewWorkerClient(pl.Get())
+	in := &pb.SplitTabletPayload{
+		Predicate:     tab.Predicate,
+		SourceGroupId: src.gid,
+		DestGroupId:   dstGroup,
+		StartUid:      src.start,
+		EndUid:        src.end,
+		State:         s.membershipState(),
+	}
+	out, err := c.SplitTablet(ctx, in)
+	if err != nil {
+		return x.Errorf("While calling SplitTablet: %+v", err)
+	}
+	if out.SplitUid <= src.start || (src.end > 0 && out.SplitUid >= src.end) {
+		return x.Errorf("Group %d split its uids from %#x to %#x at %#x", src.gid, src.start,
+			src.end, out.SplitUid)
+	}
+
+	// Propose that dstGroup holds the uids from the split one on, and the tablet in RW.
+	space := tab.Space
+	if src.gid == tab.GroupId {
+		space -= src.space / 2
+	}
+	p.Tablet = &pb.Tablet{
+		GroupId:   tab.GroupId,
+		Predicate: tab.Predicate,
+		Space:     space,
+		Force:     true,
+		Shards:    splitShards(tab, src, dstGroup, out.SplitUid),
+	}
+	p.Event = newEvent(eventTabletSplit, dstGroup, 0, "Split %s of group %d to %d at uid %#x in %s",
+		tab.Predicate, src.gid, dstGroup, out.SplitUid, time.Since(start).Round(time.Millisecond))
+	p.Event.Predicate = tab.Predicate
+	return n.proposeAndWait(ctx, p)
+}
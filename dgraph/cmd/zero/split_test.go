@@ -0,0 +1,87 @@
+/*
+ * Copyright 2018 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package zero
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dgraph-io/dgraph/protos/pb"
+)
+
+func TestTabletParts(t *testing.T) {
+	tab := &pb.Tablet{GroupId: 1, Space: 10, Shards: []*pb.TabletShard{
+		{StartUid: 100, GroupId: 2, Space: 20},
+		{StartUid: 200, GroupId: 3, Space: 30},
+	}}
+	require.Equal(t, []tabletPart{
+		{gid: 1, start: 0, end: 100, space: 10},
+		{gid: 2, start: 100, end: 200, space: 20},
+		{gid: 3, start: 200, end: 0, space: 30},
+	}, tabletParts(tab))
+	require.Equal(t, []tabletPart{{gid: 1, space: 10}}, tabletParts(&pb.Tablet{GroupId: 1, Space: 10}))
+
+	parts := tabletParts(tab)
+	require.Equal(t, uint32(4), smallestGroupWithout(map[uint32]int64{1: 5, 2: 1, 3: 2, 4: 2}, parts))
+	require.Equal(t, uint32(0), smallestGroupWithout(map[uint32]int64{1: 5, 2: 1, 3: 2}, parts))
+}
+
+func TestSplitShards(t *testing.T) {
+	tab := &pb.Tablet{GroupId: 1, Space: 10, Shards: []*pb.TabletShard{
+		{StartUid: 100, GroupId: 2, Space: 8},
+	}}
+	// Splitting the last part appends a shard.
+	shards := splitShards(tab, tabletPart{gid: 2, start: 100, space: 8}, 3, 150)
+	require.Equal(t, []*pb.TabletShard{
+		{StartUid: 100, GroupId: 2, Space: 4},
+		{StartUid: 150, GroupId: 3, Space: 4},
+	}, shards)
+	// Splitting the first one inserts it before the others.
+	shards = splitShards(tab, tabletPart{gid: 1, start: 0, end: 100, space: 10}, 3, 50)
+	require.Equal(t, []*pb.TabletShard{
+		{StartUid: 50, GroupId: 3, Space: 5},
+		{StartUid: 100, GroupId: 2, Space: 8},
+	}, shards)
+	require.Equal(t, int64(8), tab.Shards[0].Space)
+
+	merged := mergeShardSpaces(shards, []*pb.TabletShard{{StartUid: 100, GroupId: 2, Space: 1}})
+	require.Equal(t, int64(5), merged[0].Space)
+	require.Equal(t, int64(1), merged[1].Space)
+	require.Equal(t, int64(8), shards[1].Space)
+	require.True(t, shardsHaveData(&pb.Tablet{Shards: merged}))
+	require.False(t, shardsHaveData(&pb.Tablet{Shards: []*pb.TabletShard{{StartUid: 100}}}))
+}
+
+func TestGroupSpaces(t *testing.T) {
+	s := &Server{state: &pb.MembershipState{Groups: map[uint32]*pb.Group{
+		1: {Tablets: map[string]*pb.Tablet{
+			"big":   {GroupId: 1, Predicate: "big", Space: 3 << 20},
+			"small": {GroupId: 1, Predicate: "small", Space: 1 << 10},
+		}},
+		2: {Tablets: map[string]*pb.Tablet{
+			"other": {GroupId: 2, Predicate: "other", Space: 2 << 20, Shards: []*pb.TabletShard{
+				{StartUid: 100, GroupId: 3, Space: 1 << 20},
+				{StartUid: 200, GroupId: 4, Space: 1 << 20},
+			}},
+		}},
+		3: {},
+	}}}
+	s.RLock()
+	defer s.RUnlock()
+	// The shards of groups which are gone aren't counted.
+	require.Equal(t, map[uint32]int64{1: 3<<20 + 1<<10, 2: 2 << 20, 3: 1 << 20}, s.groupSpaces())
+}
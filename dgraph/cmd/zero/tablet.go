@@ -19,6 +19,7 @@ package zero
 import (
 	"fmt"
 	"sort"
+	"sync/atomic"
 	"time"
 
 	"github.com/dgraph-io/dgraph/protos/pb"
@@ -30,6 +31,8 @@ import (
 
 const (
 	predicateMoveTimeout = 20 * time.Minute
+	// Rate at which predicates are assumed to move, until a move has been timed.
+	defaultMoveRate = 8 << 20 // bytes per second.
 )
 
 /*
@@ -56,17 +59,54 @@ This would trigger G1 to get latest state. Wait for it.
 
 */
 
+// rebalanceInterval is the time.Duration between the tries to move a tablet. It can be changed at
+// runtime, through /admin/config.
+var rebalanceInterval int64
+
+func rebalanceTunable() x.Tunable {
+	return x.DurationTunable("rebalance_interval",
+		func() time.Duration { return time.Duration(atomic.LoadInt64(&rebalanceInterval)) },
+		func(d time.Duration) error {
+			if d <= 0 {
+				return x.Errorf("rebalance_interval must be positive")
+			}
+			return nil
+		},
+		func(d time.Duration) { atomic.StoreInt64(&rebalanceInterval, int64(d)) })
+}
+
 //  TODO: Have a event log for everything.
 func (s *Server) rebalanceTablets() {
-	ticker := time.NewTicker(opts.rebalanceInterval)
 	for {
 		select {
-		case <-ticker.C:
+		case <-time.After(time.Duration(atomic.LoadInt64(&rebalanceInterval))):
+			if _, held := s.clones.held(time.Now()); held {
+				break
+			}
+			if len(s.replicaOf()) > 0 {
+				// The tablets are placed as they're replicated.
+				break
+			}
+			// Tablets are placed by their replicas first, then by their sizes.
+			if c := s.chooseReplicaChange(); len(c.predicate) > 0 {
+				if err := s.changeReplicas(c); err != nil {
+					glog.Errorln(err)
+				}
+				break
+			}
 			predicate, srcGroup, dstGroup := s.chooseTablet()
+			if len(predicate) > 0 {
+				if err := s.movePredicate(predicate, srcGroup, dstGroup); err != nil {
+					glog.Errorln(err)
+				}
+				break
+			}
+			// Tablets too big to be moved are split instead.
+			predicate, srcGroup, dstGroup = s.chooseSplit()
 			if len(predicate) == 0 {
 				break
 			}
-			if err := s.movePredicate(predicate, srcGroup, dstGroup); err != nil {
+			if err := s.splitTablet(predicate, srcGroup, dstGroup); err != nil {
 				glog.Errorln(err)
 			}
 		}
@@ -78,48 +118,76 @@ func (s *Server) movePredicate(predicate string, srcGroup, dstGroup uint32) erro
 	// can also trigger a predicate move. We could render them invalid here by checking if this node
 	// is actually the leader. But, I have noticed no side effects with allowing them to run, even
 	// if this node is a follower node.
+	if readTs, held := s.clones.held(time.Now()); held {
+		return x.Errorf("Tablets can't be moved while the cluster is being cloned at ts %d",
+			readTs)
+	}
+	if source := s.replicaOf(); len(source) > 0 {
+		return x.Errorf("Tablets can't be moved while the cluster replicates %s", source)
+	}
 	tab := s.ServingTablet(predicate)
 	x.AssertTruef(tab != nil, "Tablet to be moved: [%v] should not be nil", predicate)
+	if len(tab.Shards) > 0 {
+		return x.Errorf("Tablet %s is split across groups, so it can't be moved", predicate)
+	}
+	if holdsCopy(tab, dstGroup) {
+		return x.Errorf("Group %d holds a copy of tablet %s already", dstGroup, predicate)
+	}
+	if group := s.membershipState().Groups[dstGroup]; group != nil && !s.fits(tab, group) {
+		return x.Errorf("Group %d has %d replicas, which don't fit the %d wanted by tablet %s",
+			dstGroup, s.groupReplicas(group), s.tabletReplicas(tab), predicate)
+	}
 	glog.Infof("Going to move predicate: [%v], size: [%v] from group %d to %d\n", predicate,
 		humanize.Bytes(uint64(tab.Space)), srcGroup, dstGroup)
 
 	ctx, cancel := context.WithTimeout(context.Background(), predicateMoveTimeout)
 	done := make(chan struct{}, 1)
+	go s.watchMove(done, cancel)
 
-	go func(done chan struct{}, cancel context.CancelFunc) {
-		select {
-		case <-s.leaderChangeChannel():
-			// Cancel predicate moves when you step down as leader.
-			if !s.Node.AmLeader() {
-				cancel()
-				break
-			}
-
-			glog.Infof("Sleeping before we run recovery for tablet move")
-			// We might have initiated predicate move on some other node, give it some
-			// time to get cancelled. On cancellation the other node would set the predicate
-			// to write mode again and we need to be sure that it doesn't happen after we
-			// decide to move the predicate and set it to read mode.
-			time.Sleep(time.Minute)
-			// Check if any predicates were stuck in read mode. We don't need to do it
-			// periodically because we revert back the predicate to write state in case
-			// of any error unless a node crashes or is shutdown.
-			s.runRecovery()
-		case <-done:
-			cancel()
-		}
-	}(done, cancel)
-
+	start := time.Now()
 	err := s.moveTablet(ctx, predicate, srcGroup, dstGroup)
 	done <- struct{}{}
+	x.ZeroTabletMoveSeconds.Add(time.Since(start).Seconds())
 	if err != nil {
+		x.ZeroTabletMoves.Add("error", 1)
+		ev := newEvent(eventTabletMoveFailed, srcGroup, 0, "Moving %s from group %d to %d: %v",
+			predicate, srcGroup, dstGroup, err)
+		ev.Predicate = predicate
+		go s.proposeEvent(ev)
 		return x.Errorf("Error while trying to move predicate %v from %d to %d: %v", predicate,
 			srcGroup, dstGroup, err)
 	}
 	glog.Infof("Predicate move done for: [%v] from group %d to %d\n", predicate, srcGroup, dstGroup)
+	x.ZeroTabletMoves.Add("ok", 1)
 	return nil
 }
 
+// watchMove cancels a move, or a split, by calling cancel once it's done or as soon as this node
+// steps down as the leader.
+func (s *Server) watchMove(done chan struct{}, cancel context.CancelFunc) {
+	select {
+	case <-s.leaderChangeChannel():
+		// Cancel predicate moves when you step down as leader.
+		if !s.Node.AmLeader() {
+			cancel()
+			break
+		}
+
+		glog.Infof("Sleeping before we run recovery for tablet move")
+		// We might have initiated predicate move on some other node, give it some
+		// time to get cancelled. On cancellation the other node would set the predicate
+		// to write mode again and we need to be sure that it doesn't happen after we
+		// decide to move the predicate and set it to read mode.
+		time.Sleep(time.Minute)
+		// Check if any predicates were stuck in read mode. We don't need to do it
+		// periodically because we revert back the predicate to write state in case
+		// of any error unless a node crashes or is shutdown.
+		s.runRecovery()
+	case <-done:
+		cancel()
+	}
+}
+
 func (s *Server) runRecovery() {
 	s.RLock()
 	defer s.RUnlock()
@@ -169,19 +237,22 @@ func (s *Server) chooseTablet() (predicate string, srcGroup uint32, dstGroup uin
 		return
 	}
 
-	// Sort all groups by their sizes.
+	// Sort all groups by their sizes. Only the groups of --replicas are balanced, the tablets of
+	// the others are placed by the replicas wanted for them.
 	type kv struct {
 		gid  uint32
 		size int64 // in bytes
 	}
 	var groups []kv
-	for k, v := range s.state.Groups {
-		space := int64(0)
-		for _, tab := range v.Tablets {
-			space += tab.Space
+	for k, space := range s.groupSpaces() {
+		if s.groupReplicas(s.state.Groups[k]) != s.NumReplicas {
+			continue
 		}
 		groups = append(groups, kv{k, space})
 	}
+	if numGroups = len(groups); numGroups <= 1 {
+		return
+	}
 	sort.Slice(groups, func(i, j int) bool {
 		return groups[i].size < groups[j].size
 	})
@@ -206,10 +277,13 @@ func (s *Server) chooseTablet() (predicate string, srcGroup uint32, dstGroup uin
 		// Try to find a predicate which we can move.
 		size := int64(0)
 		group := s.state.Groups[srcGroup]
+		dst := s.state.Groups[dstGroup]
 		for _, tab := range group.Tablets {
 			// Finds a tablet as big a possible such that on moving it dstGroup's size is
-			// less than or equal to srcGroup.
-			if tab.Space <= size_diff/2 && tab.Space > size {
+			// less than or equal to srcGroup. Split tablets stay where they are, and the others
+			// only move to groups fitting their replicas.
+			if len(tab.Shards) == 0 && !holdsCopy(tab, dstGroup) && s.fits(tab, dst) &&
+				tab.Space <= size_diff/2 && tab.Space > size {
 				predicate = tab.Predicate
 				size = tab.Space
 			}
@@ -255,15 +329,23 @@ func (s *Server) movePredicateHelper(ctx context.Context, predicate string, srcG
 	n := s.Node
 	stab := s.ServingTablet(predicate)
 	x.AssertTrue(stab != nil)
-	// Propose that predicate in read only
+	// Propose that predicate in read only, and moving. Clients writing to the predicate are told
+	// when the move is expected to finish.
+	start := time.Now()
 	p := &pb.ZeroProposal{}
 	p.Tablet = &pb.Tablet{
-		GroupId:   srcGroup,
-		Predicate: predicate,
-		Space:     stab.Space,
-		ReadOnly:  true,
-		Force:     true,
+		GroupId:       srcGroup,
+		Predicate:     predicate,
+		Space:         stab.Space,
+		ReadOnly:      true,
+		Force:         true,
+		MovingTo:      dstGroup,
+		MoveStartedAt: start.Unix(),
+		MoveEta:       s.moveEta(stab.Space, start).Unix(),
 	}
+	p.Event = newEvent(eventTabletMoveStarted, srcGroup, 0, "Moving %s from group %d to %d",
+		predicate, srcGroup, dstGroup)
+	p.Event.Predicate = predicate
 	if err := n.proposeAndWait(ctx, p); err != nil {
 		return err
 	}
@@ -282,6 +364,9 @@ func (s *Server) movePredicateHelper(ctx context.Context, predicate string, srcG
 	if _, err := c.MovePredicate(ctx, in); err != nil {
 		return fmt.Errorf("While calling MovePredicate: %+v\n", err)
 	}
+	if d := time.Since(start); d > time.Second {
+		atomic.StoreInt64(&s.moveRate, int64(float64(stab.Space)/d.Seconds()))
+	}
 
 	// Propose that predicate is served by dstGroup in RW.
 	p.Tablet = &pb.Tablet{
@@ -290,6 +375,9 @@ func (s *Server) movePredicateHelper(ctx context.Context, predicate string, srcG
 		Space:     stab.Space,
 		Force:     true,
 	}
+	p.Event = newEvent(eventTabletMoved, dstGroup, 0, "Moved %s from group %d to %d in %s",
+		predicate, srcGroup, dstGroup, time.Since(start).Round(time.Millisecond))
+	p.Event.Predicate = predicate
 	if err := n.proposeAndWait(ctx, p); err != nil {
 		return err
 	}
@@ -298,3 +386,13 @@ func (s *Server) movePredicateHelper(ctx context.Context, predicate string, srcG
 	// for sure.
 	return nil
 }
+
+// moveEta returns when a move of a tablet of the given size, started at start, is expected to
+// finish. The estimate is based on the rate of the last move done by this node.
+func (s *Server) moveEta(space int64, start time.Time) time.Time {
+	rate := atomic.LoadInt64(&s.moveRate)
+	if rate <= 0 {
+		rate = defaultMoveRate
+	}
+	return start.Add(time.Duration(float64(space) / float64(rate) * float64(time.Second)))
+}
@@ -0,0 +1,75 @@
+/*
+ * Copyright 2018 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package zero
+
+import (
+	"sync"
+	"time"
+
+	"github.com/dgraph-io/dgraph/protos/pb"
+)
+
+// Progress which wasn't reported for this long is dropped, as the Alpha is likely gone.
+const transferStaleAfter = 5 * time.Minute
+
+// transfers keeps the progress of the snapshots the Alphas are receiving from the leaders of their
+// groups, as last reported by them. Like liveness, it's kept in memory only.
+type transfers struct {
+	sync.Mutex
+	byMember map[uint64]*memberTransfer
+}
+
+type memberTransfer struct {
+	groupId  uint32
+	progress *pb.SnapshotProgress
+	at       time.Time
+}
+
+// record keeps the progress sent along with the members of a membership update. It's taken out
+// of the members, so that it doesn't get proposed along with them.
+func (t *transfers) record(group *pb.Group) {
+	t.Lock()
+	defer t.Unlock()
+	if t.byMember == nil {
+		t.byMember = make(map[uint64]*memberTransfer)
+	}
+	now := time.Now()
+	for id, m := range group.Members {
+		if m.SnapshotProgress == nil {
+			// Done, or never started.
+			delete(t.byMember, id)
+			continue
+		}
+		t.byMember[id] = &memberTransfer{groupId: m.GroupId, progress: m.SnapshotProgress, at: now}
+		m.SnapshotProgress = nil
+	}
+}
+
+// fill sets the progress last reported by every Alpha in ms still receiving a snapshot.
+func (t *transfers) fill(ms *pb.MembershipState) {
+	t.Lock()
+	defer t.Unlock()
+	for id, mt := range t.byMember {
+		if time.Since(mt.at) > transferStaleAfter {
+			delete(t.byMember, id)
+			continue
+		}
+		if m, ok := ms.Groups[mt.groupId].GetMembers()[id]; ok {
+			m.SnapshotProgress = mt.progress
+		}
+	}
+}
@@ -0,0 +1,63 @@
+/*
+ * Copyright 2018 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package zero
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dgraph-io/dgraph/protos/pb"
+)
+
+func TestTransfers(t *testing.T) {
+	var tr transfers
+	progress := &pb.SnapshotProgress{Index: 10, ReadTs: 20, Keys: 100}
+	group := &pb.Group{Members: map[uint64]*pb.Member{
+		1: {Id: 1, GroupId: 1, SnapshotProgress: progress},
+		2: {Id: 2, GroupId: 1},
+	}}
+	tr.record(group)
+	// The progress isn't proposed.
+	require.Nil(t, group.Members[1].SnapshotProgress)
+
+	state := func() *pb.MembershipState {
+		return &pb.MembershipState{Groups: map[uint32]*pb.Group{1: {
+			Members: map[uint64]*pb.Member{1: {Id: 1}, 2: {Id: 2}},
+		}}}
+	}
+	ms := state()
+	tr.fill(ms)
+	require.Equal(t, progress, ms.Groups[1].Members[1].SnapshotProgress)
+	require.Nil(t, ms.Groups[1].Members[2].SnapshotProgress)
+
+	// Alpha 1 got the whole snapshot.
+	tr.record(&pb.Group{Members: map[uint64]*pb.Member{1: {Id: 1, GroupId: 1}}})
+	ms = state()
+	tr.fill(ms)
+	require.Nil(t, ms.Groups[1].Members[1].SnapshotProgress)
+
+	// Alpha 2 stopped reporting a while ago.
+	tr.record(&pb.Group{Members: map[uint64]*pb.Member{2: {Id: 2, GroupId: 1,
+		SnapshotProgress: progress}}})
+	tr.byMember[2].at = time.Now().Add(-time.Hour)
+	ms = state()
+	tr.fill(ms)
+	require.Nil(t, ms.Groups[1].Members[2].SnapshotProgress)
+	require.Empty(t, tr.byMember)
+}
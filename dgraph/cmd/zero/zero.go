@@ -26,6 +26,7 @@ import (
 	"golang.org/x/net/context"
 
 	"github.com/dgraph-io/dgo/protos/api"
+	"github.com/dgraph-io/dgraph/changes"
 	"github.com/dgraph-io/dgraph/conn"
 	"github.com/dgraph-io/dgraph/protos/pb"
 	"github.com/dgraph-io/dgraph/x"
@@ -60,6 +61,16 @@ type Server struct {
 	leaderChangeCh chan struct{}
 	shutDownCh     chan struct{} // Used to tell stream to close.
 	connectLock    sync.Mutex    // Used to serialize connect requests from servers.
+
+	exports  exportJobs    // Cluster-wide exports coordinated by this node.
+	fencing  fencingLeases // Leases granted to external processes while leader.
+	moveRate int64         // Bytes per second of the last predicate move. Accessed atomically.
+	alive    liveness      // When the Alphas were last heard of.
+	sums     checksums     // Checksums of the tablets last reported by the Alphas.
+	snaps    transfers     // Progress of the snapshots the Alphas are receiving.
+	clones   cloneHolds    // Clones keeping the tablets from moving.
+	replica  replicator    // Progress of the replication of a primary cluster, if any.
+	events   eventLog      // Significant events of the cluster.
 }
 
 func (s *Server) Init() {
@@ -78,6 +89,7 @@ func (s *Server) Init() {
 	s.leaderChangeCh = make(chan struct{}, 1)
 	s.shutDownCh = make(chan struct{}, 1)
 	go s.rebalanceTablets()
+	go s.updateMetrics()
 }
 
 func (s *Server) periodicallyPostTelemetry() {
@@ -230,6 +242,40 @@ func (s *Server) membershipState() *pb.MembershipState {
 	return proto.Clone(s.state).(*pb.MembershipState)
 }
 
+// changeStreamRoutesTunable sets the routes of the change stream of the whole cluster, which
+// every Alpha applies as it gets the membership state, instead of its --change_stream_routes.
+// Setting them empty makes the Alphas go back to their flags.
+func (s *Server) changeStreamRoutesTunable() x.Tunable {
+	return x.Tunable{
+		Name: "change_stream_routes",
+		Get:  func() interface{} { return s.membershipState().ChangeStreamRoutes },
+		Check: func(val string) (func(), error) {
+			_, err := changes.ParseRoutes(val)
+			return func() {
+				ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+				defer cancel()
+				proposal := &pb.ZeroProposal{ChangeStreamRoutes: &pb.ChangeStreamRoutes{Rules: val}}
+				if err := s.Node.proposeAndWait(ctx, proposal); err != nil {
+					glog.Errorf("While proposing the change stream routes %q: %v", val, err)
+				}
+			}, err
+		},
+	}
+}
+
+// Negotiate tells the client which version and features of the API it can use, as an Alpha
+// would.
+func (s *Server) Negotiate(ctx context.Context,
+	req *pb.NegotiateRequest) (*pb.NegotiateResponse, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+	s.RLock()
+	features := x.ClusterFeatures(s.state)
+	s.RUnlock()
+	return x.Negotiate(req, features), nil
+}
+
 func (s *Server) storeZero(m *pb.Member) {
 	s.Lock()
 	defer s.Unlock()
@@ -305,11 +351,23 @@ func (s *Server) createProposals(dst *pb.Group) ([]*pb.ZeroProposal, error) {
 			return res, errUnknownMember
 		}
 		if srcMember.Addr != dstMember.Addr ||
-			srcMember.Leader != dstMember.Leader {
+			srcMember.Leader != dstMember.Leader ||
+			srcMember.Draining != dstMember.Draining ||
+			!sameFeatures(srcMember.Features, dstMember.Features) {
 
 			proposal := &pb.ZeroProposal{
 				Member: dstMember,
 			}
+			switch {
+			case srcMember.Draining != dstMember.Draining:
+				if dstMember.Draining {
+					proposal.Event = newEvent(eventMemberDraining, dstMember.GroupId, mid,
+						"Alpha %d of group %d is draining", mid, dstMember.GroupId)
+				}
+			case dstMember.Leader:
+				proposal.Event = newEvent(eventLeaderElected, dstMember.GroupId, mid,
+					"Alpha %d became the leader of group %d", mid, dstMember.GroupId)
+			}
 			res = append(res, proposal)
 		}
 		if !dstMember.Leader {
@@ -329,13 +387,14 @@ func (s *Server) createProposals(dst *pb.Group) ([]*pb.ZeroProposal, error) {
 		}
 		srcTablet, has := group.Tablets[key]
 		if !has {
-			// Tablet moved to new group
+			// Tablet moved to new group, or split to it.
+			if p := s.shardProposal(dstTablet); p != nil {
+				res = append(res, p)
+			}
 			continue
 		}
 
-		s := float64(srcTablet.Space)
-		d := float64(dstTablet.Space)
-		if dstTablet.Remove || (s == 0 && d > 0) || (s > 0 && math.Abs(d/s-1) > 0.1) {
+		if dstTablet.Remove || spaceChanged(srcTablet.Space, dstTablet.Space) {
 			dstTablet.Force = false
 			proposal := &pb.ZeroProposal{
 				Tablet: dstTablet,
@@ -346,6 +405,13 @@ func (s *Server) createProposals(dst *pb.Group) ([]*pb.ZeroProposal, error) {
 	return res, nil
 }
 
+// spaceChanged tells whether the space of a tablet changed enough to be proposed.
+func spaceChanged(prev, cur int64) bool {
+	s := float64(prev)
+	d := float64(cur)
+	return (s == 0 && d > 0) || (s > 0 && math.Abs(d/s-1) > 0.1)
+}
+
 // Its users responsibility to ensure that node doesn't come back again before calling the api.
 func (s *Server) removeNode(ctx context.Context, nodeId uint64, groupId uint32) error {
 	if groupId == 0 {
@@ -353,6 +419,8 @@ func (s *Server) removeNode(ctx context.Context, nodeId uint64, groupId uint32)
 	}
 	zp := &pb.ZeroProposal{}
 	zp.Member = &pb.Member{Id: nodeId, GroupId: groupId, AmDead: true}
+	zp.Event = newEvent(eventMemberRemoved, groupId, nodeId, "Alpha %d removed from group %d",
+		nodeId, groupId)
 	if _, ok := s.state.Groups[groupId]; !ok {
 		return x.Errorf("No group with groupId %d found", groupId)
 	}
@@ -413,62 +481,106 @@ func (s *Server) Connect(ctx context.Context,
 	// Create a connection and check validity of the address by doing an Echo.
 	conn.Get().Connect(m.Addr)
 
-	createProposal := func() *pb.ZeroProposal {
+	createProposal := func() (*pb.ZeroProposal, error) {
 		s.Lock()
 		defer s.Unlock()
 
 		proposal := new(pb.ZeroProposal)
 		// Check if we already have this member.
 		for _, group := range s.state.Groups {
-			if _, has := group.Members[m.Id]; has {
-				return nil
+			if member, has := group.Members[m.Id]; has {
+				return featuresProposal(member, m.Features), nil
 			}
 		}
+		if m.Spare {
+			// Spares wait for a group to lose a member, instead of forming new groups.
+			gid, ok := s.groupMissingReplica()
+			if !ok {
+				glog.Infof("No group is missing a replica. Spare at %s keeps waiting.", m.Addr)
+				return nil, nil
+			}
+			m.GroupId, m.Spare = gid, false
+		}
 		if m.Id == 0 {
 			m.Id = s.state.MaxRaftId + 1
 			proposal.MaxRaftId = m.Id
 		}
 
+		if m.Learner {
+			// Learners join an existing group, and don't count towards its replicas.
+			if _, has := s.state.Groups[m.GroupId]; has {
+				proposal.Member = m
+				return proposal, nil
+			}
+			var best *pb.Group
+			for gid, group := range s.state.Groups {
+				if voters(group) == 0 {
+					continue
+				}
+				if best == nil || learners(group) < learners(best) {
+					m.GroupId, best = gid, group
+				}
+			}
+			if best == nil {
+				return nil, x.Errorf("No group for learner %d to join", m.Id)
+			}
+			proposal.Member = m
+			return proposal, nil
+		}
+
 		// We don't have this member. So, let's see if it has preference for a group.
 		if m.GroupId > 0 {
 			group, has := s.state.Groups[m.GroupId]
 			if !has {
 				// We don't have this group. Add the server to this group.
 				proposal.Member = m
-				return proposal
+				return proposal, nil
 			}
 
 			if _, has := group.Members[m.Id]; has {
 				proposal.Member = m // Update in case some fields have changed, like address.
-				return proposal
+				return proposal, nil
 			}
 
 			// We don't have this server in the list.
-			if len(group.Members) < s.NumReplicas {
+			if voters(group) < s.groupReplicas(group) {
 				// We need more servers here, so let's add it.
 				proposal.Member = m
-				return proposal
+				return proposal, nil
 			}
 			// Already have plenty of servers serving this group.
 		}
-		// Let's assign this server to a new group.
+		// Let's assign this server to a new group, of as many replicas as it wants.
 		for gid, group := range s.state.Groups {
-			if len(group.Members) < s.NumReplicas {
+			if replicas := s.groupReplicas(group); voters(group) < replicas &&
+				replicas == s.memberReplicas(m) {
 				m.GroupId = gid
 				proposal.Member = m
-				return proposal
+				return proposal, nil
 			}
 		}
 		// We either don't have any groups, or don't have any groups which need another member.
+		// The next group could be one of another size still missing members.
 		m.GroupId = s.nextGroup
+		for s.state.Groups[m.GroupId] != nil {
+			m.GroupId++
+		}
 		// We shouldn't increase nextGroup here as we don't know whether we have enough
 		// replicas until proposal is committed and can cause issues due to race.
 		proposal.Member = m
-		return proposal
+		return proposal, nil
 	}
 
-	proposal := createProposal()
+	proposal, err := createProposal()
+	if err != nil {
+		return &emptyConnectionState, err
+	}
 	if proposal != nil {
+		// Members already known are proposed as they're in the state, not as they connected.
+		if proposal.Member == m {
+			proposal.Event = newEvent(eventMemberJoined, m.GroupId, m.Id,
+				"Alpha %d at %s joined group %d", m.Id, m.Addr, m.GroupId)
+		}
 		if err := s.Node.proposeAndWait(ctx, proposal); err != nil {
 			return &emptyConnectionState, err
 		}
@@ -480,6 +592,50 @@ func (s *Server) Connect(ctx context.Context,
 	return resp, nil
 }
 
+// featuresProposal proposes member with features, if it had others. Alphas restarted on another
+// version during a rolling upgrade connect again with the features of that version.
+func featuresProposal(member *pb.Member, features []string) *pb.ZeroProposal {
+	if sameFeatures(member.Features, features) {
+		return nil
+	}
+	updated := *member
+	updated.Features = features
+	return &pb.ZeroProposal{Member: &updated}
+}
+
+// sameFeatures tells whether a and b have the same features, in any order.
+func sameFeatures(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	has := make(map[string]bool, len(a))
+	for _, f := range a {
+		has[f] = true
+	}
+	for _, f := range b {
+		if !has[f] {
+			return false
+		}
+	}
+	return true
+}
+
+// voters returns the number of members of the group which vote in it, which are all but the
+// learners.
+func voters(group *pb.Group) int {
+	return len(group.Members) - learners(group)
+}
+
+func learners(group *pb.Group) int {
+	var n int
+	for _, m := range group.Members {
+		if m.Learner {
+			n++
+		}
+	}
+	return n
+}
+
 func (s *Server) ShouldServe(
 	ctx context.Context, tablet *pb.Tablet) (resp *pb.Tablet, err error) {
 	ctx, span := otrace.StartSpan(ctx, "Zero.ShouldServe")
@@ -518,6 +674,9 @@ func (s *Server) ShouldServe(
 }
 
 func (s *Server) UpdateMembership(ctx context.Context, group *pb.Group) (*api.Payload, error) {
+	s.alive.record(group)
+	s.sums.record(group)
+	s.snaps.record(group)
 	proposals, err := s.createProposals(group)
 	if err != nil {
 		// Sleep here so the caller doesn't keep on retrying indefinitely, creating a busy
@@ -551,6 +710,8 @@ func (s *Server) UpdateMembership(ctx context.Context, group *pb.Group) (*api.Pa
 func (s *Server) StreamMembership(_ *api.Payload, stream pb.Zero_StreamMembershipServer) error {
 	// Send MembershipState right away. So, the connection is correctly established.
 	ctx := stream.Context()
+	x.ZeroMembershipStreams.Add(1)
+	defer x.ZeroMembershipStreams.Add(-1)
 	ms, err := s.latestMembershipState(ctx)
 	if err != nil {
 		return err
@@ -581,12 +742,13 @@ func (s *Server) StreamMembership(_ *api.Payload, stream pb.Zero_StreamMembershi
 }
 
 func (s *Server) latestMembershipState(ctx context.Context) (*pb.MembershipState, error) {
-	if err := s.Node.WaitLinearizableRead(ctx); err != nil {
+	if err := s.Node.waitForRead(ctx); err != nil {
 		return nil, err
 	}
 	ms := s.membershipState()
 	if ms == nil {
 		return &pb.MembershipState{}, nil
 	}
+	s.alive.fill(ms)
 	return ms, nil
 }
@@ -21,6 +21,7 @@ import (
 	"testing"
 
 	"github.com/dgraph-io/dgraph/protos/pb"
+	"github.com/dgraph-io/dgraph/x"
 	"github.com/stretchr/testify/require"
 )
 
@@ -37,3 +38,89 @@ func TestRemoveNode(t *testing.T) {
 	err = server.removeNode(nil, 1, 2)
 	require.Error(t, err)
 }
+
+func TestVoters(t *testing.T) {
+	group := &pb.Group{Members: map[uint64]*pb.Member{
+		1: {Id: 1},
+		2: {Id: 2, Learner: true},
+		3: {Id: 3},
+		4: {Id: 4, Learner: true},
+	}}
+	require.Equal(t, 2, voters(group))
+	require.Equal(t, 2, learners(group))
+	require.Equal(t, 0, voters(&pb.Group{}))
+}
+
+func TestDrainingProposal(t *testing.T) {
+	server := &Server{
+		state: &pb.MembershipState{
+			Groups: map[uint32]*pb.Group{1: {Members: map[uint64]*pb.Member{
+				1: {Id: 1, GroupId: 1, Addr: "alpha1:7080"},
+			}}},
+		},
+	}
+	update := func(draining bool) []*pb.ZeroProposal {
+		proposals, err := server.createProposals(&pb.Group{Members: map[uint64]*pb.Member{
+			1: {Id: 1, GroupId: 1, Addr: "alpha1:7080", Draining: draining},
+		}})
+		require.NoError(t, err)
+		return proposals
+	}
+	require.Empty(t, update(false))
+
+	proposals := update(true)
+	require.Len(t, proposals, 1)
+	require.True(t, proposals[0].Member.Draining)
+	require.Equal(t, eventMemberDraining, proposals[0].Event.Type)
+
+	server.state.Groups[1].Members[1].Draining = true
+	require.Empty(t, update(true))
+	proposals = update(false)
+	require.Len(t, proposals, 1)
+	require.False(t, proposals[0].Member.Draining)
+	require.Nil(t, proposals[0].Event)
+}
+
+func TestFeaturesUpgrade(t *testing.T) {
+	old := []string{x.FeatureUpsert}
+	server := &Server{
+		state: &pb.MembershipState{
+			Groups: map[uint32]*pb.Group{1: {Members: map[uint64]*pb.Member{
+				1: {Id: 1, GroupId: 1, Addr: "alpha1:7080", Features: old},
+				2: {Id: 2, GroupId: 1, Addr: "alpha2:7080", Features: old},
+			}}},
+		},
+	}
+	require.Equal(t, old, x.ClusterFeatures(server.state))
+
+	// Alpha 1 restarts on the new version, and connects again.
+	proposal := featuresProposal(server.state.Groups[1].Members[1], x.Features)
+	require.NotNil(t, proposal)
+	require.Equal(t, "alpha1:7080", proposal.Member.Addr)
+	require.Equal(t, x.Features, proposal.Member.Features)
+	server.state.Groups[1].Members[1] = proposal.Member
+	require.Nil(t, featuresProposal(proposal.Member, x.Features))
+	require.Equal(t, old, x.ClusterFeatures(server.state))
+
+	// Alpha 2 restarts too, and updates its membership.
+	proposals, err := server.createProposals(&pb.Group{Members: map[uint64]*pb.Member{
+		2: {Id: 2, GroupId: 1, Addr: "alpha2:7080", Features: x.Features},
+	}})
+	require.NoError(t, err)
+	require.Len(t, proposals, 1)
+	require.Equal(t, x.Features, proposals[0].Member.Features)
+	server.state.Groups[1].Members[2] = proposals[0].Member
+	require.Equal(t, x.Features, x.ClusterFeatures(server.state))
+
+	require.True(t, sameFeatures([]string{"a", "b"}, []string{"b", "a"}))
+	require.False(t, sameFeatures([]string{"a", "b"}, []string{"a", "c"}))
+}
+
+func TestWalOptions(t *testing.T) {
+	require.True(t, walOptions("w", false).SyncWrites)
+	// A WAL in memory is never synced.
+	opt := walOptions("w", true)
+	require.False(t, opt.SyncWrites)
+	require.Equal(t, "w", opt.Dir)
+	require.Equal(t, "w", opt.ValueDir)
+}
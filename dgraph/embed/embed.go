@@ -0,0 +1,265 @@
+/*
+ * Copyright 2018 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package embed runs Dgraph inside a Go process, as a single node cluster of a Zero and an Alpha,
+// so that applications and tests can use it without running Dgraph on its own.
+//
+//	dg, err := embed.Start(embed.Options{})
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	defer dg.Close()
+//	txn := dg.Client().NewTxn()
+//
+// Zero and Alpha keep much of their state in globals, so Dgraph can only be embedded once per
+// process. As when they run on their own, errors they can't recover from end the process.
+package embed
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"math/rand"
+	"net"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/dgraph-io/dgo"
+	"github.com/dgraph-io/dgo/protos/api"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"google.golang.org/grpc"
+
+	"github.com/dgraph-io/dgraph/dgraph/cmd/alpha"
+	"github.com/dgraph-io/dgraph/dgraph/cmd/zero"
+	"github.com/dgraph-io/dgraph/x"
+)
+
+// Options configure the embedded Dgraph.
+type Options struct {
+	// Dir is the directory of the data of Zero and Alpha. If empty, it's a temporary directory,
+	// removed by Close.
+	Dir string
+	// InMemory keeps the data of Zero and Alpha in memory, like with --badger.inmemory, so it's
+	// lost on Close. Dir then only holds the exports.
+	InMemory bool
+	// PortOffset is added to all the ports Zero and Alpha listen on, like with --port_offset.
+	// If zero, an offset at which they're all free is picked.
+	PortOffset int
+	// Timeout is how long Start waits for Dgraph to be ready. If zero, it's a minute.
+	Timeout time.Duration
+	// Zero and Alpha set their flags by name, like "txn_ttl" to time.Minute. They take precedence
+	// over those set by Start.
+	Zero  map[string]interface{}
+	Alpha map[string]interface{}
+}
+
+// Dgraph is the embedded Dgraph, started by Start.
+type Dgraph struct {
+	dir        string
+	removeDir  bool
+	portOffset int
+
+	conn   *grpc.ClientConn
+	client *dgo.Dgraph
+
+	stopZero, stopAlpha chan struct{}
+	zeroDone, alphaDone chan struct{}
+	closeOnce           sync.Once
+	closeErr            error
+}
+
+var started int32
+
+// Start starts Zero and Alpha, and returns once Alpha serves queries.
+func Start(opts Options) (*Dgraph, error) {
+	if !atomic.CompareAndSwapInt32(&started, 0, 1) {
+		return nil, x.Errorf("Dgraph can only be embedded once per process")
+	}
+
+	d := &Dgraph{
+		dir:        opts.Dir,
+		portOffset: opts.PortOffset,
+		stopZero:   make(chan struct{}),
+		stopAlpha:  make(chan struct{}),
+		zeroDone:   make(chan struct{}),
+		alphaDone:  make(chan struct{}),
+	}
+	var err error
+	if len(d.dir) == 0 {
+		if d.dir, err = ioutil.TempDir("", "dgraph"); err != nil {
+			return nil, err
+		}
+		d.removeDir = true
+	}
+	if d.portOffset == 0 {
+		if d.portOffset, err = freePortOffset(); err != nil {
+			d.removeTempDir()
+			return nil, err
+		}
+	}
+
+	zero.Zero.Conf = config(zero.Zero.Cmd, map[string]interface{}{
+		"bindall":         false,
+		"port_offset":     d.portOffset,
+		"wal":             filepath.Join(d.dir, "zw"),
+		"telemetry":       false,
+		"badger.inmemory": opts.InMemory,
+	}, opts.Zero)
+	alpha.Alpha.Conf = config(alpha.Alpha.Cmd, map[string]interface{}{
+		"bindall":         false,
+		"port_offset":     d.portOffset,
+		"zero":            fmt.Sprintf("localhost:%d", x.PortZeroGrpc+d.portOffset),
+		"postings":        filepath.Join(d.dir, "p"),
+		"wal":             filepath.Join(d.dir, "w"),
+		"export":          filepath.Join(d.dir, "export"),
+		"lru_mb":          1024,
+		"badger.inmemory": opts.InMemory,
+	}, opts.Alpha)
+
+	go func() {
+		defer close(d.zeroDone)
+		zero.Run(d.stopZero)
+	}()
+	go func() {
+		defer close(d.alphaDone)
+		alpha.Run(d.stopAlpha)
+	}()
+
+	d.conn, err = grpc.Dial(d.Addr(),
+		grpc.WithDefaultCallOptions(
+			grpc.MaxCallRecvMsgSize(x.GrpcMaxSize),
+			grpc.MaxCallSendMsgSize(x.GrpcMaxSize)),
+		grpc.WithInsecure())
+	if err != nil {
+		d.Close()
+		return nil, err
+	}
+	d.client = dgo.NewDgraphClient(api.NewDgraphClient(d.conn))
+
+	timeout := opts.Timeout
+	if timeout == 0 {
+		timeout = time.Minute
+	}
+	if err := d.waitReady(timeout); err != nil {
+		d.Close()
+		return nil, err
+	}
+	return d, nil
+}
+
+// config returns the configuration of cmd, with the defaults of its flags, set then overridden.
+func config(cmd *cobra.Command, set, override map[string]interface{}) *viper.Viper {
+	conf := viper.New()
+	x.Check(conf.BindPFlags(cmd.Flags()))
+	for k, v := range set {
+		conf.Set(k, v)
+	}
+	for k, v := range override {
+		conf.Set(k, v)
+	}
+	return conf
+}
+
+var ports = []int{x.PortZeroGrpc, x.PortZeroHTTP, x.PortInternal, x.PortHTTP, x.PortGrpc}
+
+// freePortOffset returns a port offset at which Zero and Alpha can listen on all their ports.
+func freePortOffset() (int, error) {
+	// The main of dgraph seeds the global source, but that of the application embedding it
+	// needn't, and then every process would try the same offsets.
+	r := rand.New(rand.NewSource(time.Now().UnixNano()))
+	for i := 0; i < 100; i++ {
+		// The offset keeps the ports below those ephemeral on most systems, from 32768.
+		offset := 1000 + r.Intn(22000)
+		free := true
+		for _, port := range ports {
+			l, err := net.Listen("tcp", fmt.Sprintf("localhost:%d", port+offset))
+			if err != nil {
+				free = false
+				break
+			}
+			l.Close()
+		}
+		if free {
+			return offset, nil
+		}
+	}
+	return 0, x.Errorf("Couldn't find free ports for Zero and Alpha")
+}
+
+// waitReady waits for Alpha to serve queries, which it does once it's a member of its group.
+func (d *Dgraph) waitReady(timeout time.Duration) error {
+	dc := api.NewDgraphClient(d.conn)
+	deadline := time.Now().Add(timeout)
+	for {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		_, err := dc.Query(ctx, &api.Request{Query: "schema {}"})
+		cancel()
+		if err == nil {
+			return nil
+		}
+		if time.Now().After(deadline) {
+			return x.Wrapf(err, "Dgraph wasn't ready after %s", timeout)
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+}
+
+// Client returns the client of the embedded Dgraph.
+func (d *Dgraph) Client() *dgo.Dgraph {
+	return d.client
+}
+
+// Addr returns the address of the gRPC API of Alpha, for other clients.
+func (d *Dgraph) Addr() string {
+	return fmt.Sprintf("localhost:%d", x.PortGrpc+d.portOffset)
+}
+
+// HTTPAddr returns the address of the HTTP API of Alpha.
+func (d *Dgraph) HTTPAddr() string {
+	return fmt.Sprintf("localhost:%d", x.PortHTTP+d.portOffset)
+}
+
+// Dir returns the directory of the data of Zero and Alpha.
+func (d *Dgraph) Dir() string {
+	return d.dir
+}
+
+// Close stops Alpha and then Zero, and removes the directory of the data if it's temporary.
+// Dgraph can't be started again in the same process afterwards.
+func (d *Dgraph) Close() error {
+	d.closeOnce.Do(func() {
+		if d.conn != nil {
+			d.conn.Close()
+		}
+		close(d.stopAlpha)
+		<-d.alphaDone
+		close(d.stopZero)
+		<-d.zeroDone
+		d.closeErr = d.removeTempDir()
+	})
+	return d.closeErr
+}
+
+func (d *Dgraph) removeTempDir() error {
+	if !d.removeDir {
+		return nil
+	}
+	return os.RemoveAll(d.dir)
+}
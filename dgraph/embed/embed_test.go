@@ -0,0 +1,65 @@
+/*
+ * Copyright 2018 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package embed
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/dgraph-io/dgo/protos/api"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfig(t *testing.T) {
+	cmd := &cobra.Command{}
+	cmd.Flags().String("wal", "w", "")
+	cmd.Flags().Int("port_offset", 0, "")
+	cmd.Flags().Bool("telemetry", true, "")
+
+	conf := config(cmd, map[string]interface{}{"wal": "/tmp/w", "port_offset": 100},
+		map[string]interface{}{"port_offset": 200})
+	require.Equal(t, "/tmp/w", conf.GetString("wal"))
+	require.Equal(t, 200, conf.GetInt("port_offset"))
+	require.True(t, conf.GetBool("telemetry"))
+}
+
+func TestEmbed(t *testing.T) {
+	dg, err := Start(Options{})
+	require.NoError(t, err)
+	dir := dg.Dir()
+
+	_, err = Start(Options{})
+	require.Error(t, err)
+
+	ctx := context.Background()
+	c := dg.Client()
+	require.NoError(t, c.Alter(ctx, &api.Operation{Schema: "name: string @index(exact) ."}))
+	_, err = c.NewTxn().Mutate(ctx, &api.Mutation{
+		SetNquads: []byte(`_:a <name> "Alice" .`),
+		CommitNow: true,
+	})
+	require.NoError(t, err)
+	resp, err := c.NewTxn().Query(ctx, `{ q(func: eq(name, "Alice")) { name } }`)
+	require.NoError(t, err)
+	require.JSONEq(t, `{"q": [{"name": "Alice"}]}`, string(resp.Json))
+
+	require.NoError(t, dg.Close())
+	_, err = os.Stat(dir)
+	require.True(t, os.IsNotExist(err))
+}
@@ -0,0 +1,111 @@
+/*
+ * Copyright 2018 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package edgraph
+
+import (
+	"fmt"
+	"net"
+	"sort"
+	"strings"
+
+	"github.com/dgraph-io/dgo/protos/api"
+	"github.com/golang/glog"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+
+	"github.com/dgraph-io/dgraph/audit"
+	"github.com/dgraph-io/dgraph/protos/pb"
+)
+
+// clientHost returns the host of the client of ctx: the peer of the gRPC request, or the client
+// of the HTTP request it's made for.
+func clientHost(ctx context.Context) string {
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.Addr == nil {
+		return ""
+	}
+	if host, _, err := net.SplitHostPort(p.Addr.String()); err == nil {
+		return host
+	}
+	return p.Addr.String()
+}
+
+// auditRequest logs the operation op of the request in ctx to the audit log, if there's one. The
+// client is the peer of the gRPC request, or the client of the HTTP request it's made for.
+func auditRequest(ctx context.Context, op, details string, err error) {
+	if State.Audit == nil {
+		return
+	}
+	e := &audit.Entry{Op: op, Details: details, Client: clientHost(ctx)}
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		// The namespace asked for, which failed requests might not have got.
+		if names := md.Get("namespace"); len(names) > 0 {
+			e.Namespace = names[0]
+		}
+		if fwd := md.Get("forwarded-for"); len(fwd) > 0 {
+			e.ForwardedFor = fwd[0]
+		}
+	}
+	if err != nil {
+		e.Error = err.Error()
+	}
+	if err := State.Audit.Log(e); err != nil {
+		glog.Errorf("While writing to the audit log: %v", err)
+	}
+}
+
+func auditMutation(ctx context.Context, mu *api.Mutation, m *pb.Mutations, err error) {
+	details := fmt.Sprintf("start_ts=%d commit_now=%v", mu.StartTs, mu.CommitNow)
+	if m != nil {
+		preds := make(map[string]bool)
+		for _, edge := range m.Edges {
+			preds[edge.Attr] = true
+		}
+		var names []string
+		for pred := range preds {
+			names = append(names, pred)
+		}
+		sort.Strings(names)
+		details += fmt.Sprintf(" edges=%d predicates=%s", len(m.Edges), strings.Join(names, ","))
+	}
+	auditRequest(ctx, "mutate", details, err)
+}
+
+func auditAlter(ctx context.Context, op *api.Operation, err error) {
+	switch {
+	case op.DropAll:
+		auditRequest(ctx, "drop_all", "", err)
+	case len(op.DropAttr) > 0:
+		auditRequest(ctx, "drop_attr", op.DropAttr, err)
+	default:
+		auditRequest(ctx, "alter", op.Schema, err)
+	}
+}
+
+func auditCommit(ctx context.Context, abort bool, startTs uint64, resp *api.TxnContext,
+	err error) {
+	op := "commit"
+	if abort {
+		op = "abort"
+	}
+	details := fmt.Sprintf("start_ts=%d", startTs)
+	if resp != nil && resp.CommitTs > 0 {
+		details += fmt.Sprintf(" commit_ts=%d", resp.CommitTs)
+	}
+	auditRequest(ctx, op, details, err)
+}
@@ -0,0 +1,252 @@
+/*
+ * Copyright 2018 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package edgraph
+
+import (
+	"container/list"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/dgraph-io/dgraph/gql"
+	"github.com/dgraph-io/dgraph/query"
+	"github.com/dgraph-io/dgraph/worker"
+	"github.com/dgraph-io/dgraph/x"
+	"golang.org/x/net/context"
+)
+
+// Alphas can cache the results of queries run outside of transactions, so that the same queries
+// sent again and again, e.g. by dashboards, aren't run every time. Results are keyed by the
+// namespace, the query, its variables and the bucket of resultCacheTsBucket timestamps their
+// read timestamp falls in. A result is only served for later read timestamps of its bucket, and
+// only until this Alpha learns about a commit, schema change or drop of a predicate read by the
+// query. As the commits of other groups are learnt about asynchronously, a result is only served
+// once this Alpha learnt about the commits up to the read timestamp of all the groups it was read
+// from, see worker.InvalidatedUpTo.
+const resultCacheTsBucket = 1000
+
+type cachedResult struct {
+	key    string
+	readTs uint64
+	attrs  []string
+	json   []byte
+}
+
+func (r *cachedResult) size() int64 {
+	return int64(len(r.key) + len(r.json))
+}
+
+type resultCache struct {
+	sync.Mutex
+	maxSize int64
+	size    int64
+	ll      *list.List
+	entries map[string]*list.Element
+	byAttr  map[string]map[*list.Element]struct{}
+
+	// lastCommit holds the latest commit timestamp seen for every predicate, so that results read
+	// before a commit aren't added after it was seen.
+	lastCommit map[string]uint64
+	// resets counts the times everything was invalidated, so that results read before a reset
+	// aren't added after it.
+	resets uint64
+	// invalidatedUpTo tells whether the commits up to readTs of the groups serving attrs were
+	// learnt about.
+	invalidatedUpTo func(attrs []string, readTs uint64) bool
+}
+
+// results is nil if caching is disabled.
+var results *resultCache
+
+func newResultCache(maxSize int64) *resultCache {
+	return &resultCache{
+		maxSize:    maxSize,
+		ll:         list.New(),
+		entries:    make(map[string]*list.Element),
+		byAttr:     make(map[string]map[*list.Element]struct{}),
+		lastCommit: make(map[string]uint64),
+
+		invalidatedUpTo: worker.InvalidatedUpTo,
+	}
+}
+
+func resultCacheKey(ns, q string, vars map[string]string, readTs uint64) string {
+	names := make([]string, 0, len(vars))
+	for name := range vars {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var b strings.Builder
+	b.WriteString(strconv.FormatUint(readTs/resultCacheTsBucket, 10))
+	b.WriteByte(0)
+	b.WriteString(ns)
+	b.WriteByte(0)
+	b.WriteString(q)
+	for _, name := range names {
+		b.WriteByte(0)
+		b.WriteString(name)
+		b.WriteByte(0)
+		b.WriteString(vars[name])
+	}
+	return b.String()
+}
+
+// cacheable tells whether the results of the query can be cached. Expanding all predicates of
+// nodes depends on predicates the query doesn't name, and schema queries aren't tracked.
+func cacheable(ctx context.Context, res *gql.Result) bool {
+	if res.Schema != nil || query.IsDebug(ctx) {
+		return false
+	}
+	var expands func(gqs []*gql.GraphQuery) bool
+	expands = func(gqs []*gql.GraphQuery) bool {
+		for _, gq := range gqs {
+			if len(gq.Expand) > 0 || expands(gq.Children) {
+				return true
+			}
+		}
+		return false
+	}
+	return !expands(res.Query)
+}
+
+// resultAttrs returns the stored names of the predicates read by the subgraphs.
+func resultAttrs(ctx context.Context, sgs []*query.SubGraph) []string {
+	ns := x.NamespaceFromContext(ctx)
+	attrs := query.GetAllPredicates(sgs)
+	for i, attr := range attrs {
+		attrs[i] = x.NamespaceAttr(ns, strings.TrimPrefix(attr, "~"))
+	}
+	return attrs
+}
+
+// generation must be called before running a query, and passed to put along with its result.
+func (c *resultCache) generation() uint64 {
+	c.Lock()
+	defer c.Unlock()
+	return c.resets
+}
+
+// get returns the result cached under key, if it can be served at readTs.
+func (c *resultCache) get(key string, readTs uint64) ([]byte, bool) {
+	c.Lock()
+	defer c.Unlock()
+	elem, ok := c.entries[key]
+	if !ok || elem.Value.(*cachedResult).readTs > readTs ||
+		!c.invalidatedUpTo(elem.Value.(*cachedResult).attrs, readTs) {
+		x.QcacheMiss.Add(1)
+		return nil, false
+	}
+	c.ll.MoveToFront(elem)
+	x.QcacheHit.Add(1)
+	return elem.Value.(*cachedResult).json, true
+}
+
+// put caches the result of a query read at readTs, unless it might be outdated already.
+func (c *resultCache) put(key string, readTs uint64, attrs []string, json []byte, gen uint64) {
+	r := &cachedResult{key: key, readTs: readTs, attrs: attrs, json: json}
+	c.Lock()
+	defer c.Unlock()
+	if gen != c.resets || r.size() > c.maxSize {
+		return
+	}
+	for _, attr := range attrs {
+		if c.lastCommit[attr] > readTs {
+			return
+		}
+	}
+	if elem, ok := c.entries[key]; ok {
+		c.remove(elem)
+	}
+	elem := c.ll.PushFront(r)
+	c.entries[key] = elem
+	for _, attr := range attrs {
+		elems, ok := c.byAttr[attr]
+		if !ok {
+			elems = make(map[*list.Element]struct{})
+			c.byAttr[attr] = elems
+		}
+		elems[elem] = struct{}{}
+	}
+	c.size += r.size()
+	for c.size > c.maxSize {
+		c.remove(c.ll.Back())
+		x.QcacheEvicts.Add(1)
+	}
+	x.QcacheSize.Set(c.size)
+}
+
+// resize changes the most bytes of results held, evicting those least recently used over it.
+func (c *resultCache) resize(maxSize int64) {
+	c.Lock()
+	defer c.Unlock()
+	c.maxSize = maxSize
+	for c.size > c.maxSize {
+		c.remove(c.ll.Back())
+		x.QcacheEvicts.Add(1)
+	}
+	x.QcacheSize.Set(c.size)
+}
+
+func (c *resultCache) maxBytes() int64 {
+	c.Lock()
+	defer c.Unlock()
+	return c.maxSize
+}
+
+func (c *resultCache) remove(elem *list.Element) {
+	r := elem.Value.(*cachedResult)
+	c.ll.Remove(elem)
+	delete(c.entries, r.key)
+	for _, attr := range r.attrs {
+		elems := c.byAttr[attr]
+		delete(elems, elem)
+		if len(elems) == 0 {
+			delete(c.byAttr, attr)
+		}
+	}
+	c.size -= r.size()
+}
+
+// invalidate drops the results read before commitTs from any of attrs, or all results if attrs
+// is nil. It's subscribed to the invalidations of the worker.
+func (c *resultCache) invalidate(attrs []string, commitTs uint64) {
+	c.Lock()
+	defer c.Unlock()
+	if attrs == nil {
+		c.ll.Init()
+		c.entries = make(map[string]*list.Element)
+		c.byAttr = make(map[string]map[*list.Element]struct{})
+		c.lastCommit = make(map[string]uint64)
+		c.size = 0
+		c.resets++
+		x.QcacheSize.Set(0)
+		return
+	}
+	for _, attr := range attrs {
+		if commitTs > c.lastCommit[attr] {
+			c.lastCommit[attr] = commitTs
+		}
+		for elem := range c.byAttr[attr] {
+			if elem.Value.(*cachedResult).readTs < commitTs {
+				c.remove(elem)
+			}
+		}
+	}
+	x.QcacheSize.Set(c.size)
+}
@@ -0,0 +1,124 @@
+/*
+ * Copyright 2018 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package edgraph
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestResultCacheKey(t *testing.T) {
+	vars := map[string]string{"$a": "1", "$b": "2"}
+	require.Equal(t, resultCacheKey("", "q", vars, 10), resultCacheKey("", "q", vars, 999))
+	require.NotEqual(t, resultCacheKey("", "q", vars, 10), resultCacheKey("", "q", vars, 1000))
+	require.NotEqual(t, resultCacheKey("", "q", vars, 10), resultCacheKey("ns", "q", vars, 10))
+	require.NotEqual(t, resultCacheKey("", "q", vars, 10),
+		resultCacheKey("", "q", map[string]string{"$a": "2", "$b": "1"}, 10))
+}
+
+// newTestResultCache returns a cache which learnt about the commits of all groups up to seen.
+func newTestResultCache(maxSize int64, seen *uint64) *resultCache {
+	c := newResultCache(maxSize)
+	c.invalidatedUpTo = func(attrs []string, readTs uint64) bool { return readTs <= *seen }
+	return c
+}
+
+func TestResultCacheInvalidate(t *testing.T) {
+	seen := uint64(100)
+	c := newTestResultCache(1<<20, &seen)
+	c.put("a", 10, []string{"name"}, []byte("A"), c.generation())
+	c.put("b", 10, []string{"age"}, []byte("B"), c.generation())
+
+	json, ok := c.get("a", 12)
+	require.True(t, ok)
+	require.Equal(t, "A", string(json))
+	// Results aren't served for earlier reads.
+	_, ok = c.get("a", 9)
+	require.False(t, ok)
+
+	// Commits seen at the read timestamp are part of the result.
+	c.invalidate([]string{"name"}, 10)
+	_, ok = c.get("a", 12)
+	require.True(t, ok)
+
+	c.invalidate([]string{"name"}, 11)
+	_, ok = c.get("a", 12)
+	require.False(t, ok)
+	_, ok = c.get("b", 12)
+	require.True(t, ok)
+
+	// Results read before a seen commit aren't added.
+	c.put("a", 10, []string{"name"}, []byte("A"), c.generation())
+	_, ok = c.get("a", 12)
+	require.False(t, ok)
+
+	// Nor are results read before everything was invalidated.
+	gen := c.generation()
+	c.invalidate(nil, 20)
+	_, ok = c.get("b", 12)
+	require.False(t, ok)
+	c.put("b", 20, []string{"age"}, []byte("B"), gen)
+	_, ok = c.get("b", 20)
+	require.False(t, ok)
+}
+
+func TestResultCacheWatermark(t *testing.T) {
+	seen := uint64(12)
+	c := newTestResultCache(1<<20, &seen)
+	c.put("a", 10, []string{"name"}, []byte("A"), c.generation())
+	_, ok := c.get("a", 12)
+	require.True(t, ok)
+
+	// The commits between 12 and 14 of the groups serving name might not be learnt about yet.
+	_, ok = c.get("a", 14)
+	require.False(t, ok)
+	seen = 14
+	_, ok = c.get("a", 14)
+	require.True(t, ok)
+}
+
+func TestResultCacheEvict(t *testing.T) {
+	seen := uint64(100)
+	c := newTestResultCache(4, &seen)
+	c.put("a", 1, nil, []byte("A"), 0)
+	c.put("b", 1, nil, []byte("B"), 0)
+	_, ok := c.get("a", 1)
+	require.True(t, ok)
+
+	c.put("c", 1, nil, []byte("C"), 0)
+	_, ok = c.get("a", 1)
+	require.True(t, ok)
+	_, ok = c.get("b", 1)
+	require.False(t, ok)
+	_, ok = c.get("c", 1)
+	require.True(t, ok)
+	require.Equal(t, int64(4), c.size)
+
+	// Results larger than the cache aren't added.
+	c.put("d", 1, nil, []byte("DDDD"), 0)
+	_, ok = c.get("d", 1)
+	require.False(t, ok)
+
+	// Shrinking the cache evicts the results used least recently.
+	c.resize(2)
+	_, ok = c.get("a", 1)
+	require.False(t, ok)
+	_, ok = c.get("c", 1)
+	require.True(t, ok)
+	require.Equal(t, int64(2), c.size)
+}
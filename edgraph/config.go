@@ -19,7 +19,10 @@ package edgraph
 import (
 	"expvar"
 	"path/filepath"
+	"sync/atomic"
+	"time"
 
+	"github.com/dgraph-io/dgraph/access"
 	"github.com/dgraph-io/dgraph/posting"
 	"github.com/dgraph-io/dgraph/worker"
 	"github.com/dgraph-io/dgraph/x"
@@ -33,11 +36,36 @@ type Options struct {
 	Nomutations  bool
 	AuthToken    string
 
+	// BadgerInMemory doesn't sync the writes, as the directories are in memory.
+	BadgerInMemory bool
+
 	AllottedMemory float64
+	PostingCacheMB float64
+	IndexCacheMB   float64
+	BlockCacheMB   float64
+	QueryCacheMB   float64
+
+	// QueryTimeout cancels the queries running for longer. Zero means no timeout.
+	QueryTimeout time.Duration
+
+	SlowQuery       time.Duration
+	SlowQuerySample float64
+	SlowQueryRate   int
+	SlowQueryLog    string
+
+	Audit         string
+	AuditRotateMB int64
+
+	// TxnTTLExempt holds the clients whose transactions aren't aborted when idle.
+	TxnTTLExempt *access.List
 }
 
 var Config Options
 
+// queryTimeout holds the nanoseconds of Config.QueryTimeout, which can be changed at runtime.
+// Accessed atomically.
+var queryTimeout int64
+
 // Sometimes users use config.yaml flag so /debug/vars doesn't have information about the
 // value of the flags. Hence we dump conf options we care about to the conf map.
 func setConfVar(conf Options) {
@@ -53,43 +81,68 @@ func setConfVar(conf Options) {
 		return v
 	}
 
-	newInt := func(i int) *expvar.Int {
-		v := new(expvar.Int)
-		v.Set(int64(i))
-		return v
-	}
-
 	// Expvar doesn't have bool type so we use an int.
-	newIntFromBool := func(b bool) *expvar.Int {
-		v := new(expvar.Int)
+	boolToInt := func(b bool) int64 {
 		if b {
-			v.Set(1)
-		} else {
-			v.Set(0)
+			return 1
 		}
+		return 0
+	}
+	newIntFromBool := func(b bool) *expvar.Int {
+		v := new(expvar.Int)
+		v.Set(boolToInt(b))
 		return v
 	}
 
 	// This is so we can find these options in /debug/vars.
 	x.Conf.Set("badger.tables", newStr(conf.BadgerTables))
 	x.Conf.Set("badger.vlog", newStr(conf.BadgerVlog))
+	x.Conf.Set("badger.inmemory", newIntFromBool(conf.BadgerInMemory))
 	x.Conf.Set("posting_dir", newStr(conf.PostingDir))
 	x.Conf.Set("wal_dir", newStr(conf.WALDir))
-	x.Conf.Set("allotted_memory", newFloat(conf.AllottedMemory))
+	x.Conf.Set("posting_cache_mb", newFloat(conf.PostingCacheMB))
+	x.Conf.Set("index_cache_mb", newFloat(conf.IndexCacheMB))
+	x.Conf.Set("block_cache_mb", newFloat(conf.BlockCacheMB))
+	x.Conf.Set("slow_query_log", newStr(conf.SlowQueryLog))
+	x.Conf.Set("audit", newStr(conf.Audit))
+
+	// The options which can be changed at runtime report their effective values.
+	tunables := make(map[string]x.Tunable)
+	for _, tun := range append(Tunables(), x.TraceTunable()) {
+		tunables[tun.Name] = tun
+	}
+	for key, name := range map[string]string{
+		"allotted_memory":   "lru_mb",
+		"query_cache_mb":    "query_cache_mb",
+		"query_timeout":     "query_timeout",
+		"slow_query":        "slow_query",
+		"slow_query_sample": "slow_query_sample",
+		"slow_query_rate":   "slow_query_rate",
+		"tracing":           "trace",
+	} {
+		x.Conf.Set(key, expvar.Func(tunables[name].Get))
+	}
 
-	// Set some vars from worker.Config.
-	x.Conf.Set("tracing", newFloat(worker.Config.Tracing))
-	x.Conf.Set("num_pending_proposals", newInt(worker.Config.NumPendingProposals))
-	x.Conf.Set("expand_edge", newIntFromBool(worker.Config.ExpandEdge))
+	// Set some vars from worker.Config, which is set after this is called.
+	x.Conf.Set("num_pending_proposals", expvar.Func(func() interface{} {
+		return worker.Config.NumPendingProposals
+	}))
+	x.Conf.Set("expand_edge", expvar.Func(func() interface{} {
+		return boolToInt(worker.Config.ExpandEdge)
+	}))
 }
 
 func SetConfiguration(newConfig Options) {
 	newConfig.validate()
 	setConfVar(newConfig)
 	Config = newConfig
+	atomic.StoreInt64(&queryTimeout, int64(Config.QueryTimeout))
 
 	posting.Config.Mu.Lock()
 	posting.Config.AllottedMemory = Config.AllottedMemory
+	posting.Config.PostingCacheMB = Config.PostingCacheMB
+	posting.Config.IndexCacheMB = Config.IndexCacheMB
+	posting.Config.BlockCacheMB = Config.BlockCacheMB
 	posting.Config.Mu.Unlock()
 }
 
@@ -107,3 +160,101 @@ func (o *Options) validate() {
 		"LRU memory (--lru_mb) must be at least %.0f MB. Currently set to: %f",
 		MinAllottedMemory, o.AllottedMemory)
 }
+
+// Tunables returns the options of edgraph which can be changed at runtime.
+func Tunables() []x.Tunable {
+	return []x.Tunable{
+		x.FloatTunable("lru_mb",
+			func() float64 {
+				posting.Config.Mu.Lock()
+				defer posting.Config.Mu.Unlock()
+				return posting.Config.AllottedMemory
+			},
+			func(mb float64) error {
+				if mb < MinAllottedMemory {
+					return x.Errorf("lru_mb must be at least %.0f", MinAllottedMemory)
+				}
+				return nil
+			},
+			func(mb float64) {
+				posting.Config.Mu.Lock()
+				defer posting.Config.Mu.Unlock()
+				posting.Config.AllottedMemory = mb
+			}),
+		x.FloatTunable("query_cache_mb",
+			func() float64 {
+				if results == nil {
+					return 0
+				}
+				return float64(results.maxBytes()) / (1 << 20)
+			},
+			func(mb float64) error {
+				switch {
+				case mb < 0:
+					return x.Errorf("query_cache_mb can't be negative")
+				case results == nil && mb > 0:
+					return x.Errorf("The query cache is disabled. Set --query_cache_mb to enable it")
+				}
+				return nil
+			},
+			func(mb float64) {
+				if results != nil {
+					results.resize(int64(mb * (1 << 20)))
+				}
+			}),
+		x.DurationTunable("query_timeout",
+			func() time.Duration { return time.Duration(atomic.LoadInt64(&queryTimeout)) },
+			func(d time.Duration) error {
+				if d < 0 {
+					return x.Errorf("query_timeout can't be negative")
+				}
+				return nil
+			},
+			func(d time.Duration) { atomic.StoreInt64(&queryTimeout, int64(d)) }),
+		x.DurationTunable("slow_query",
+			func() time.Duration {
+				threshold, _, _ := slowQueries.settings()
+				return threshold
+			},
+			func(d time.Duration) error {
+				if d < 0 {
+					return x.Errorf("slow_query can't be negative")
+				}
+				return nil
+			},
+			func(d time.Duration) {
+				_, sample, rate := slowQueries.settings()
+				slowQueries.set(d, sample, rate)
+			}),
+		x.FloatTunable("slow_query_sample",
+			func() float64 {
+				_, sample, _ := slowQueries.settings()
+				return sample
+			},
+			func(sample float64) error {
+				if sample < 0 || sample > 1 {
+					return x.Errorf("slow_query_sample must be between 0 and 1")
+				}
+				return nil
+			},
+			func(sample float64) {
+				threshold, _, rate := slowQueries.settings()
+				slowQueries.set(threshold, sample, rate)
+			}),
+		x.IntTunable("slow_query_rate",
+			func() int64 {
+				_, _, rate := slowQueries.settings()
+				return int64(rate)
+			},
+			func(rate int64) error {
+				if rate < 0 {
+					return x.Errorf("slow_query_rate can't be negative")
+				}
+				return nil
+			},
+			func(rate int64) {
+				threshold, sample, _ := slowQueries.settings()
+				slowQueries.set(threshold, sample, int(rate))
+			}),
+	}
+}
@@ -0,0 +1,51 @@
+/*
+ * Copyright 2016-2018 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package edgraph
+
+import (
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dgraph-io/dgraph/worker"
+	"github.com/dgraph-io/dgraph/x"
+)
+
+func TestConfVars(t *testing.T) {
+	defer func(ratio float64, pending int) {
+		x.Check(x.SetTraceRatio(ratio))
+		worker.Config.NumPendingProposals = pending
+		atomic.StoreInt64(&queryTimeout, 0)
+	}(x.TraceRatio(), worker.Config.NumPendingProposals)
+
+	setConfVar(Options{SlowQueryLog: "slow.log"})
+	require.Equal(t, `"slow.log"`, x.Conf.Get("slow_query_log").String())
+
+	// The options changed at runtime, and those of the worker, which are set later, are reported
+	// with their effective values.
+	require.NoError(t, x.SetTraceRatio(0.25))
+	worker.Config.NumPendingProposals = 500
+	tunables := x.NewTunables(Tunables()...)
+	require.NoError(t, tunables.Set(map[string]string{"query_timeout": "2s"}))
+	require.Equal(t, "0.25", x.Conf.Get("tracing").String())
+	require.Equal(t, "500", x.Conf.Get("num_pending_proposals").String())
+	require.Equal(t, `"2s"`, x.Conf.Get("query_timeout").String())
+
+	require.Error(t, tunables.Set(map[string]string{"query_timeout": "-1s"}))
+	require.Equal(t, `"2s"`, x.Conf.Get("query_timeout").String())
+}
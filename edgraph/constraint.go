@@ -0,0 +1,479 @@
+/*
+ * Copyright 2018 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package edgraph
+
+import (
+	"bytes"
+	"encoding/json"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/dgraph-io/dgo/protos/api"
+	"github.com/dgraph-io/dgraph/gql"
+	"github.com/dgraph-io/dgraph/worker"
+	"github.com/dgraph-io/dgraph/x"
+	"github.com/golang/glog"
+	otrace "go.opencensus.io/trace"
+	"golang.org/x/net/context"
+)
+
+// Constraints are assertions about the graph, expressed as queries which must return no results.
+// Like upsert templates, they are stored in the graph itself.
+const constraintSchema = `
+	dgraph.constraint.name: string @index(exact) @upsert .
+	dgraph.constraint.query: string .
+	dgraph.constraint.mode: string .
+`
+
+const (
+	// ConstraintOnCommit constraints are checked before every commit, and transactions which
+	// would violate them are aborted. They are also checked periodically.
+	ConstraintOnCommit = "commit"
+	// ConstraintPeriodic constraints are only checked periodically, and violations are reported.
+	ConstraintPeriodic = "periodic"
+)
+
+// Constraint is a query which must not return any results. Every block of the query which
+// returns data counts as a violation, so var blocks can be used for the intermediate steps.
+//
+// Constraints enforced on commit see the writes of the committing transaction, but not the
+// uncommitted writes of concurrent ones. So two transactions can each pass the check while
+// jointly violating the constraint; the periodic checks report such violations.
+type Constraint struct {
+	Name  string `json:"name"`
+	Query string `json:"query"`
+	Mode  string `json:"mode,omitempty"`
+
+	attrs []string // Stored names of the predicates the query reads, nil if it can read any.
+}
+
+// ConstraintStatus is the result of the last periodic check of a constraint.
+type ConstraintStatus struct {
+	Constraint
+	CheckedAt  time.Time       `json:"checked_at,omitempty"`
+	ReadTs     uint64          `json:"read_ts,omitempty"`
+	Violations json.RawMessage `json:"violations,omitempty"`
+	Error      string          `json:"error,omitempty"`
+}
+
+var registerConstraint = compileBuiltin(&UpsertTemplate{
+	Name:  "dgraph.constraint.register",
+	Query: `query q($name: string) { c as var(func: eq(dgraph.constraint.name, $name)) }`,
+	Set: `uid(c) <dgraph.constraint.name> "$name" .
+		uid(c) <dgraph.constraint.query> "$query" .
+		uid(c) <dgraph.constraint.mode> "$mode" .`,
+})
+
+var removeConstraint = compileBuiltin(&UpsertTemplate{
+	Name:   "dgraph.constraint.remove",
+	Query:  `query q($name: string) { c as var(func: eq(dgraph.constraint.name, $name)) }`,
+	Delete: `uid(c) * * .`,
+})
+
+// Constraints are read on every commit, so they're cached and reloaded from the graph in the
+// background once they're older than constraintRefresh. Changes made via another Alpha can take
+// that long to apply, or longer while reloading them fails.
+const constraintRefresh = 5 * time.Second
+
+// Every namespace has its own constraints.
+var constraints struct {
+	sync.Mutex
+	loaded map[string]*loadedConstraints // By namespace.
+	status map[string]*ConstraintStatus  // By namespace qualified name.
+	// resets counts the times constraints were invalidated, so that constraints read before an
+	// invalidation aren't stored after it.
+	resets uint64
+}
+
+type loadedConstraints struct {
+	list       []Constraint
+	loadedAt   time.Time
+	refreshing bool
+}
+
+// constraintCtxKey marks the transactions changing the constraints themselves, which don't have
+// to be checked against them.
+type constraintCtxKey struct{}
+
+func invalidateConstraints(ctx context.Context) {
+	constraints.Lock()
+	delete(constraints.loaded, x.NamespaceFromContext(ctx))
+	constraints.resets++
+	constraints.Unlock()
+}
+
+// loadConstraints returns the constraints of the namespace of ctx, which must have been passed
+// through namespaceContext. Only the first load of a namespace is waited for. Later, the
+// constraints loaded last are returned while they're reloaded in the background.
+func (s *Server) loadConstraints(ctx context.Context) ([]Constraint, error) {
+	ns := x.NamespaceFromContext(ctx)
+	constraints.Lock()
+	l, ok := constraints.loaded[ns]
+	gen := constraints.resets
+	if ok {
+		if time.Since(l.loadedAt) >= constraintRefresh && !l.refreshing {
+			l.refreshing = true
+			go s.refreshConstraints(ns, gen)
+		}
+		constraints.Unlock()
+		return l.list, nil
+	}
+	constraints.Unlock()
+	return s.fetchConstraints(ctx, gen)
+}
+
+func (s *Server) refreshConstraints(ns string, gen uint64) {
+	ctx, cancel := context.WithTimeout(x.WithNamespace(context.Background(), ns), time.Minute)
+	defer cancel()
+	if _, err := s.fetchConstraints(ctx, gen); err != nil {
+		glog.Errorf("While reloading the constraints of namespace %q: %v", ns, err)
+		constraints.Lock()
+		if l, ok := constraints.loaded[ns]; ok {
+			l.refreshing = false
+		}
+		constraints.Unlock()
+	}
+}
+
+// fetchConstraints reads the constraints of the namespace of ctx from the graph, and stores them
+// unless they were invalidated since gen.
+func (s *Server) fetchConstraints(ctx context.Context, gen uint64) ([]Constraint, error) {
+	resp, err := s.Query(ctx, &api.Request{
+		Query: `{
+			c(func: has(dgraph.constraint.name)) {
+				name: dgraph.constraint.name
+				query: dgraph.constraint.query
+				mode: dgraph.constraint.mode
+			}
+		}`,
+		ReadOnly: true,
+	})
+	if err != nil {
+		return nil, err
+	}
+	var res struct {
+		C []Constraint `json:"c"`
+	}
+	if err := json.Unmarshal(resp.Json, &res); err != nil {
+		return nil, err
+	}
+	sort.Slice(res.C, func(i, j int) bool { return res.C[i].Name < res.C[j].Name })
+	ns := x.NamespaceFromContext(ctx)
+	for i := range res.C {
+		res.C[i].attrs = constraintAttrs(ns, res.C[i].Query)
+	}
+
+	constraints.Lock()
+	defer constraints.Unlock()
+	if gen != constraints.resets {
+		return res.C, nil
+	}
+	if constraints.loaded == nil {
+		constraints.loaded = make(map[string]*loadedConstraints)
+	}
+	constraints.loaded[ns] = &loadedConstraints{list: res.C, loadedAt: time.Now()}
+	return res.C, nil
+}
+
+// constraintAttrs returns the stored names of the predicates read by the query of a constraint
+// in namespace ns, or nil if it can read any, by expanding the predicates of nodes.
+func constraintAttrs(ns, query string) []string {
+	res, err := gql.Parse(gql.Request{Str: query})
+	if err != nil {
+		return nil
+	}
+	var attrs []string
+	add := func(attr string) {
+		if len(attr) == 0 || attr == "uid" {
+			return
+		}
+		attr = x.NamespaceAttr(ns, strings.TrimPrefix(attr, "~"))
+		if !x.HasString(attrs, attr) {
+			attrs = append(attrs, attr)
+		}
+	}
+	var addFilter func(f *gql.FilterTree)
+	addFilter = func(f *gql.FilterTree) {
+		if f == nil {
+			return
+		}
+		if f.Func != nil {
+			add(f.Func.Attr)
+		}
+		for _, child := range f.Child {
+			addFilter(child)
+		}
+	}
+	var addQuery func(gq *gql.GraphQuery) bool
+	addQuery = func(gq *gql.GraphQuery) bool {
+		if len(gq.Expand) > 0 {
+			return false
+		}
+		add(gq.Attr)
+		if gq.Func != nil {
+			add(gq.Func.Attr)
+		}
+		addFilter(gq.Filter)
+		for _, child := range gq.Children {
+			if !addQuery(child) {
+				return false
+			}
+		}
+		return true
+	}
+	for _, gq := range res.Query {
+		if !addQuery(gq) {
+			return nil
+		}
+	}
+	return attrs
+}
+
+// reads tells whether the constraint reads any of the predicates, or can't tell.
+func (c *Constraint) reads(preds []string) bool {
+	if c.attrs == nil || len(preds) == 0 {
+		return true
+	}
+	for _, pred := range preds {
+		if x.HasString(c.attrs, pred) {
+			return true
+		}
+	}
+	return false
+}
+
+// nonEmptyBlocks returns the blocks of a query response which contain data.
+func nonEmptyBlocks(js []byte) (map[string]json.RawMessage, error) {
+	var blocks map[string]json.RawMessage
+	if err := json.Unmarshal(js, &blocks); err != nil {
+		return nil, err
+	}
+	for name, b := range blocks {
+		var buf bytes.Buffer
+		if err := json.Compact(&buf, b); err != nil {
+			return nil, err
+		}
+		switch buf.String() {
+		case "[]", "{}", "null":
+			delete(blocks, name)
+		}
+	}
+	return blocks, nil
+}
+
+// violations runs the query of the constraint at ts, returning the offending results if any.
+func (s *Server) violations(ctx context.Context, c Constraint, ts uint64) ([]byte, error) {
+	resp, err := s.Query(ctx, &api.Request{Query: c.Query, StartTs: ts})
+	if err != nil {
+		return nil, x.Wrapf(err, "while checking constraint %q", c.Name)
+	}
+	blocks, err := nonEmptyBlocks(resp.Json)
+	if err != nil || len(blocks) == 0 {
+		return nil, err
+	}
+	return json.Marshal(blocks)
+}
+
+// checkConstraints verifies that the transaction with the given startTs, including its own
+// uncommitted writes, doesn't violate any of the constraints enforced on commit which read the
+// predicates it wrote to. Without those predicates, all the constraints are checked.
+func (s *Server) checkConstraints(ctx context.Context, startTs uint64, preds []string) error {
+	if ctx.Value(constraintCtxKey{}) != nil {
+		return nil
+	}
+	ctx, err := namespaceContext(ctx)
+	if err != nil {
+		return err
+	}
+	list, err := s.loadConstraints(ctx)
+	if err != nil {
+		return err
+	}
+	for _, c := range list {
+		if c.Mode != ConstraintOnCommit || !c.reads(preds) {
+			continue
+		}
+		v, err := s.violations(ctx, c, startTs)
+		if err != nil {
+			return err
+		}
+		if len(v) > 0 {
+			return x.Errorf("Transaction violates constraint %q: %s", c.Name, v)
+		}
+	}
+	return nil
+}
+
+func (c *Constraint) validate() error {
+	if len(c.Name) == 0 {
+		return x.Errorf("Constraint must have a name")
+	}
+	if len(c.Mode) == 0 {
+		c.Mode = ConstraintOnCommit
+	}
+	if c.Mode != ConstraintOnCommit && c.Mode != ConstraintPeriodic {
+		return x.Errorf("Invalid mode %q for constraint %q. Must be %q or %q.",
+			c.Mode, c.Name, ConstraintOnCommit, ConstraintPeriodic)
+	}
+	if _, err := gql.Parse(gql.Request{Str: c.Query}); err != nil {
+		return x.Wrapf(err, "while parsing query of constraint %q", c.Name)
+	}
+	return nil
+}
+
+// RegisterConstraint stores the constraint under its name, replacing any previous constraint
+// with the same name. A constraint enforced on commit can't be registered while the graph
+// violates it, as no transaction could commit afterwards.
+func (s *Server) RegisterConstraint(ctx context.Context, c *Constraint) error {
+	ctx, span := otrace.StartSpan(ctx, "Server.RegisterConstraint")
+	defer span.End()
+
+	if err := isTemplateChangeAllowed(ctx); err != nil {
+		return err
+	}
+	ctx, err := namespaceContext(ctx)
+	if err != nil {
+		return err
+	}
+	if err := c.validate(); err != nil {
+		return err
+	}
+	if c.Mode == ConstraintOnCommit {
+		v, err := s.violations(ctx, *c, State.getTimestamp(true))
+		if err != nil {
+			return err
+		}
+		if len(v) > 0 {
+			return x.Errorf("Constraint %q is already violated: %s", c.Name, v)
+		}
+	}
+	if err := s.alterInternalSchema(ctx, constraintSchema); err != nil {
+		return err
+	}
+	ctx = context.WithValue(ctx, constraintCtxKey{}, struct{}{})
+	_, err = s.runTemplate(ctx, registerConstraint, map[string]string{
+		"$name":  c.Name,
+		"$query": c.Query,
+		"$mode":  c.Mode,
+	})
+	invalidateConstraints(ctx)
+	return err
+}
+
+// RemoveConstraint deletes the constraint registered under name.
+func (s *Server) RemoveConstraint(ctx context.Context, name string) error {
+	if err := isTemplateChangeAllowed(ctx); err != nil {
+		return err
+	}
+	ctx, err := namespaceContext(ctx)
+	if err != nil {
+		return err
+	}
+	ctx = context.WithValue(ctx, constraintCtxKey{}, struct{}{})
+	_, err = s.runTemplate(ctx, removeConstraint, map[string]string{"$name": name})
+	invalidateConstraints(ctx)
+
+	constraints.Lock()
+	delete(constraints.status, constraintKey(ctx, name))
+	constraints.Unlock()
+	return err
+}
+
+// ListConstraints returns all registered constraints, along with the result of their last
+// check on this Alpha.
+func (s *Server) ListConstraints(ctx context.Context) ([]ConstraintStatus, error) {
+	ctx, err := namespaceContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	list, err := s.loadConstraints(ctx)
+	if err != nil {
+		return nil, err
+	}
+	constraints.Lock()
+	defer constraints.Unlock()
+	res := make([]ConstraintStatus, 0, len(list))
+	for _, c := range list {
+		st := ConstraintStatus{Constraint: c}
+		if prev, ok := constraints.status[constraintKey(ctx, c.Name)]; ok && prev.Query == c.Query {
+			st = *prev
+			st.Mode = c.Mode
+		}
+		res = append(res, st)
+	}
+	return res, nil
+}
+
+// CheckConstraints checks all registered constraints at a fresh read timestamp and records the
+// results, which can then be retrieved via ListConstraints.
+func (s *Server) CheckConstraints(ctx context.Context) error {
+	ctx, err := namespaceContext(ctx)
+	if err != nil {
+		return err
+	}
+	list, err := s.loadConstraints(ctx)
+	if err != nil {
+		return err
+	}
+	readTs := State.getTimestamp(true)
+	for _, c := range list {
+		st := &ConstraintStatus{Constraint: c, CheckedAt: time.Now(), ReadTs: readTs}
+		if v, err := s.violations(ctx, c, readTs); err != nil {
+			st.Error = err.Error()
+		} else if len(v) > 0 {
+			st.Violations = v
+			glog.Warningf("Constraint %q is violated at ts %d: %s", c.Name, readTs, v)
+		}
+
+		constraints.Lock()
+		if constraints.status == nil {
+			constraints.status = make(map[string]*ConstraintStatus)
+		}
+		constraints.status[constraintKey(ctx, c.Name)] = st
+		constraints.Unlock()
+	}
+	return nil
+}
+
+// constraintKey identifies the constraint with the given name in the namespace of ctx.
+func constraintKey(ctx context.Context, name string) string {
+	return x.NamespaceAttr(x.NamespaceFromContext(ctx), name)
+}
+
+// RunConstraintChecks checks the constraints of all namespaces every interval, until closer is closed.
+func (s *Server) RunConstraintChecks(interval time.Duration, closer <-chan struct{}) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			if err := x.HealthCheck(); err != nil {
+				continue
+			}
+			for _, ns := range append([]string{""}, worker.Namespaces()...) {
+				ctx := x.WithNamespace(context.Background(), ns)
+				if err := s.CheckConstraints(ctx); err != nil {
+					glog.Errorf("While checking constraints of namespace %q: %v", ns, err)
+				}
+			}
+		case <-closer:
+			return
+		}
+	}
+}
@@ -0,0 +1,68 @@
+/*
+ * Copyright 2018 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package edgraph
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNonEmptyBlocks(t *testing.T) {
+	blocks, err := nonEmptyBlocks([]byte(`{"a": [], "b": [{"uid": "0x1"}], "c": {}, "d": null}`))
+	require.NoError(t, err)
+	require.Len(t, blocks, 1)
+	require.JSONEq(t, `[{"uid": "0x1"}]`, string(blocks["b"]))
+
+	blocks, err = nonEmptyBlocks([]byte(`{"a": [ ]}`))
+	require.NoError(t, err)
+	require.Empty(t, blocks)
+}
+
+func TestConstraintValidate(t *testing.T) {
+	c := &Constraint{Name: "c", Query: `{ q(func: has(order)) @filter(not has(customer)) { uid } }`}
+	require.NoError(t, c.validate())
+	require.Equal(t, ConstraintOnCommit, c.Mode)
+
+	c.Mode = "never"
+	require.Error(t, c.validate())
+
+	require.Error(t, (&Constraint{Query: c.Query}).validate())
+	require.Error(t, (&Constraint{Name: "c", Query: "{ q(func: "}).validate())
+}
+
+func TestConstraintAttrs(t *testing.T) {
+	query := `{
+		q(func: has(order)) @filter(not has(customer) or eq(status, "open")) {
+			uid
+			~placed { name }
+		}
+	}`
+	c := &Constraint{Name: "c", Query: query, attrs: constraintAttrs("", query)}
+	require.Equal(t, []string{"order", "customer", "status", "placed", "name"}, c.attrs)
+	require.True(t, c.reads([]string{"name", "age"}))
+	require.False(t, c.reads([]string{"age"}))
+	// Without the predicates written, every constraint is checked.
+	require.True(t, c.reads(nil))
+
+	require.Equal(t, []string{"ns1|order"}, constraintAttrs("ns1", `{ q(func: has(order)) { uid } }`))
+
+	// A constraint expanding the predicates of nodes can read any.
+	c.attrs = constraintAttrs("", `{ q(func: has(order)) { expand(_all_) } }`)
+	require.Nil(t, c.attrs)
+	require.True(t, c.reads([]string{"age"}))
+}
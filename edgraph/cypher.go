@@ -0,0 +1,79 @@
+/*
+ * Copyright 2018 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package edgraph
+
+import (
+	"time"
+
+	"github.com/dgraph-io/dgraph/cypher"
+	"github.com/dgraph-io/dgraph/query"
+	"github.com/dgraph-io/dgraph/x"
+	otrace "go.opencensus.io/trace"
+	"golang.org/x/net/context"
+)
+
+// Cypher runs the openCypher query, with the values of its parameters. It's translated to a query
+// of Dgraph fetching the nodes matching its patterns, out of which the rows it returns are made.
+func (s *Server) Cypher(ctx context.Context, src string,
+	params map[string]interface{}) (*cypher.Result, error) {
+	ctx, span := otrace.StartSpan(ctx, "Server.Cypher")
+	defer span.End()
+
+	if err := x.HealthCheck(); err != nil {
+		return nil, err
+	}
+	ctx, err := namespaceContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	x.PendingQueries.Add(1)
+	x.NumQueries.Add(1)
+	defer x.PendingQueries.Add(-1)
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	var l query.Latency
+	l.Start = time.Now()
+	q, err := cypher.Parse(src, params)
+	if err != nil {
+		return nil, err
+	}
+	gq, err := q.GraphQuery()
+	if err != nil {
+		return nil, err
+	}
+	span.Annotatef(nil, "Cypher query received: %s", src)
+
+	readTs := State.getTimestamp(true)
+	annotateStartTs(span, readTs)
+	queryRequest := query.QueryRequest{
+		Latency:  &l,
+		GqlQuery: gq,
+		ReadTs:   readTs,
+	}
+	er, err := queryRequest.Process(ctx)
+	if err != nil {
+		return nil, x.Wrap(err)
+	}
+	js, err := query.ToJson(&l, er.Subgraphs)
+	if err != nil {
+		return nil, err
+	}
+	return q.Rows(js)
+}
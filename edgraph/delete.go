@@ -0,0 +1,324 @@
+/*
+ * Copyright 2018 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package edgraph
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/dgraph-io/dgo/protos/api"
+	"github.com/dgraph-io/dgraph/gql"
+	"github.com/dgraph-io/dgraph/query"
+	"github.com/dgraph-io/dgraph/worker"
+	"github.com/dgraph-io/dgraph/x"
+	"github.com/golang/glog"
+	otrace "go.opencensus.io/trace"
+	"golang.org/x/net/context"
+)
+
+// DeleteQuery deletes the N-Quads of Delete for all the uids of the uid variable they use, defined
+// by Query, like the delete of an upsert template. The deletes are committed in transactions of
+// BatchSize uids each, by the Alpha the request is sent to, so that matching millions of uids
+// neither takes a huge transaction nor clients paging through them.
+type DeleteQuery struct {
+	Query     string            `json:"query"`
+	Delete    string            `json:"delete"`
+	Variables map[string]string `json:"variables,omitempty"`
+	BatchSize int               `json:"batch_size,omitempty"`
+}
+
+// DeleteStatus is the progress of a delete by query. Done and Total are numbers of uids.
+type DeleteStatus struct {
+	ID         uint64    `json:"id"`
+	Query      string    `json:"query"`
+	Delete     string    `json:"delete"`
+	ReadTs     uint64    `json:"read_ts"`
+	Status     string    `json:"status"`
+	Done       int64     `json:"done"`
+	Total      int64     `json:"total"`
+	Batches    int64     `json:"batches"`
+	Percent    float64   `json:"percent"`
+	StartedAt  time.Time  `json:"started_at"`
+	FinishedAt *time.Time `json:"finished_at,omitempty"`
+	Error      string    `json:"error,omitempty"`
+}
+
+const (
+	deleteRunning   = "running"
+	deleteFailed    = "failed"
+	deleteDone      = "done"
+	deleteCancelled = "cancelled"
+)
+
+const (
+	defaultDeleteBatch = 1000
+	// Number of times a batch aborted by conflicting transactions is retried.
+	deleteRetries = 5
+	// Number of finished deletes whose status is kept.
+	maxFinishedDeletes = 100
+)
+
+type deleteJob struct {
+	ns     string
+	status DeleteStatus
+	cancel context.CancelFunc
+}
+
+// The deletes run by this Alpha, by ID. Their status is guarded by the lock.
+var deleteJobs struct {
+	sync.Mutex
+	lastID uint64
+	jobs   map[uint64]*deleteJob
+}
+
+// deleteVar returns the uid variable used by the lines of del, which must all use the same one,
+// as the uids of a batch are those of a single variable.
+func deleteVar(del compiledNquads) (string, error) {
+	if len(del) == 0 {
+		return "", x.Errorf("No N-Quads to delete")
+	}
+	var name string
+	for _, cl := range del {
+		if len(cl.uids) != 1 || (len(name) > 0 && cl.uids[0] != name) {
+			return "", x.Errorf("Every N-Quad to delete must use the same uid variable,"+
+				" and only it. Got: %s", cl.text)
+		}
+		name = cl.uids[0]
+	}
+	return name, nil
+}
+
+// parseDeleteQuery parses the query of a delete like that of an upsert template, the uid variable
+// name counting as used by the delete.
+func parseDeleteQuery(query, name string, vars map[string]string) (gql.Result, error) {
+	t, err := gql.ParseTemplate(query, name)
+	if err != nil {
+		return gql.Result{}, err
+	}
+	return t.Bind(t.Declared(vars))
+}
+
+// DeleteByQuery runs the query of req, and starts deleting the N-Quads for the uids it matched.
+// The uids are those as of the start, so nodes only matching the query after it aren't deleted.
+// It returns once the deletes are started, with the status to follow them with DeleteStatuses.
+func (s *Server) DeleteByQuery(ctx context.Context, req *DeleteQuery) (*DeleteStatus, error) {
+	ctx, span := otrace.StartSpan(ctx, "Server.DeleteByQuery")
+	defer span.End()
+
+	if err := x.HealthCheck(); err != nil {
+		return nil, err
+	}
+	ctx, err := namespaceContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if !isMutationAllowed(ctx) {
+		return nil, x.Errorf("No mutations allowed.")
+	}
+	if err := worker.CheckWritable(); err != nil {
+		return nil, err
+	}
+
+	del := parseNquadsTemplate(req.Delete)
+	name, err := deleteVar(del)
+	if err != nil {
+		return nil, err
+	}
+	batch := req.BatchSize
+	if batch <= 0 {
+		batch = defaultDeleteBatch
+	}
+	vars := req.Variables
+	if vars == nil {
+		vars = map[string]string{}
+	}
+
+	parsed, err := parseDeleteQuery(req.Query, name, vars)
+	if err != nil {
+		return nil, err
+	}
+	readTs := State.getTimestamp(true)
+	annotateStartTs(span, readTs)
+	var l query.Latency
+	qr := query.QueryRequest{Latency: &l, GqlQuery: &parsed, ReadTs: readTs}
+	if err := qr.ProcessQuery(ctx); err != nil {
+		return nil, x.Wrapf(err, "while running the query of the delete")
+	}
+	uids := qr.UidVars()[name]
+
+	// The deletes outlive the request, so they only keep its namespace.
+	ns := x.NamespaceFromContext(ctx)
+	jobCtx, cancel := context.WithCancel(x.WithNamespace(context.Background(), ns))
+	job := &deleteJob{
+		ns: ns,
+		status: DeleteStatus{
+			Query:     req.Query,
+			Delete:    req.Delete,
+			ReadTs:    readTs,
+			Status:    deleteRunning,
+			Total:     int64(len(uids)),
+			StartedAt: time.Now(),
+		},
+		cancel: cancel,
+	}
+	deleteJobs.Lock()
+	deleteJobs.lastID++
+	job.status.ID = deleteJobs.lastID
+	if deleteJobs.jobs == nil {
+		deleteJobs.jobs = make(map[uint64]*deleteJob)
+	}
+	deleteJobs.jobs[job.status.ID] = job
+	trimDeleteJobsLocked()
+	st := job.statusLocked()
+	deleteJobs.Unlock()
+
+	glog.Infof("Delete %d started for %d uids of %q at %d", st.ID, st.Total, name, readTs)
+	go s.runDelete(jobCtx, job, del, name, uids, vars, batch)
+	return &st, nil
+}
+
+func (s *Server) runDelete(ctx context.Context, job *deleteJob, del compiledNquads, name string,
+	uids []uint64, vars map[string]string, batch int) {
+	defer job.cancel()
+
+	err := func() error {
+		for start := 0; start < len(uids); start += batch {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+			end := start + batch
+			if end > len(uids) {
+				end = len(uids)
+			}
+			nquads, err := del.expand(map[string][]uint64{name: uids[start:end]}, vars, true)
+			if err != nil {
+				return err
+			}
+			if err := s.deleteBatch(ctx, nquads); err != nil {
+				return err
+			}
+			deleteJobs.Lock()
+			job.status.Done = int64(end)
+			job.status.Batches++
+			deleteJobs.Unlock()
+		}
+		return nil
+	}()
+
+	deleteJobs.Lock()
+	defer deleteJobs.Unlock()
+	now := time.Now()
+	job.status.FinishedAt = &now
+	switch {
+	case err == nil:
+		job.status.Status = deleteDone
+	case ctx.Err() == context.Canceled:
+		// Cancelled by CancelDelete, as the deferred cancel hasn't run yet.
+		job.status.Status = deleteCancelled
+	default:
+		job.status.Status = deleteFailed
+		job.status.Error = err.Error()
+	}
+	glog.Infof("Delete %d %s after %d of %d uids", job.status.ID, job.status.Status,
+		job.status.Done, job.status.Total)
+	trimDeleteJobsLocked()
+}
+
+// deleteBatch commits the deletion of nquads in a transaction of its own, retried if aborted.
+func (s *Server) deleteBatch(ctx context.Context, nquads string) error {
+	var err error
+	for i := 0; i < deleteRetries; i++ {
+		_, err = s.Mutate(ctx, &api.Mutation{DelNquads: []byte(nquads), CommitNow: true})
+		if !worker.IsAborted(err) {
+			return err
+		}
+	}
+	return err
+}
+
+func (job *deleteJob) statusLocked() DeleteStatus {
+	st := job.status
+	switch {
+	case st.Total == 0 && st.Status != deleteRunning:
+		st.Percent = 100
+	case st.Total > 0:
+		st.Percent = float64(st.Done) * 100 / float64(st.Total)
+	}
+	return st
+}
+
+// trimDeleteJobsLocked forgets the oldest finished deletes beyond maxFinishedDeletes.
+func trimDeleteJobsLocked() {
+	var finished []uint64
+	for id, job := range deleteJobs.jobs {
+		if job.status.Status != deleteRunning {
+			finished = append(finished, id)
+		}
+	}
+	if len(finished) <= maxFinishedDeletes {
+		return
+	}
+	sort.Slice(finished, func(i, j int) bool { return finished[i] < finished[j] })
+	for _, id := range finished[:len(finished)-maxFinishedDeletes] {
+		delete(deleteJobs.jobs, id)
+	}
+}
+
+// DeleteStatuses returns the status of the deletes by query of the namespace of ctx run by this
+// Alpha, or only of the one with the given ID if it isn't zero.
+func (s *Server) DeleteStatuses(ctx context.Context, id uint64) ([]DeleteStatus, error) {
+	ctx, err := namespaceContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	ns := x.NamespaceFromContext(ctx)
+
+	deleteJobs.Lock()
+	defer deleteJobs.Unlock()
+	res := []DeleteStatus{}
+	for _, job := range deleteJobs.jobs {
+		if job.ns == ns && (id == 0 || job.status.ID == id) {
+			res = append(res, job.statusLocked())
+		}
+	}
+	if id != 0 && len(res) == 0 {
+		return nil, x.Errorf("No delete with id %d", id)
+	}
+	sort.Slice(res, func(i, j int) bool { return res[i].ID < res[j].ID })
+	return res, nil
+}
+
+// CancelDelete stops the delete by query with the given ID once its current batch is done. The
+// batches already committed stay deleted.
+func (s *Server) CancelDelete(ctx context.Context, id uint64) error {
+	ctx, err := namespaceContext(ctx)
+	if err != nil {
+		return err
+	}
+	deleteJobs.Lock()
+	defer deleteJobs.Unlock()
+	job, ok := deleteJobs.jobs[id]
+	if !ok || job.ns != x.NamespaceFromContext(ctx) {
+		return x.Errorf("No delete with id %d", id)
+	}
+	if job.status.Status != deleteRunning {
+		return x.Errorf("Delete %d is %s already", id, job.status.Status)
+	}
+	job.cancel()
+	return nil
+}
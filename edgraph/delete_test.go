@@ -0,0 +1,80 @@
+/*
+ * Copyright 2018 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package edgraph
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeleteVar(t *testing.T) {
+	name, err := deleteVar(parseNquadsTemplate(`uid(v) <ts> * .
+		uid(v) <name> * .`))
+	require.NoError(t, err)
+	require.Equal(t, "v", name)
+
+	_, err = deleteVar(parseNquadsTemplate(""))
+	require.Error(t, err)
+	// The uids of a batch are those of a single variable.
+	_, err = deleteVar(parseNquadsTemplate("uid(u) <follows> uid(v) ."))
+	require.Error(t, err)
+	_, err = deleteVar(parseNquadsTemplate("uid(u) <ts> * .\nuid(v) <ts> * ."))
+	require.Error(t, err)
+	_, err = deleteVar(parseNquadsTemplate("<0x1> <ts> * ."))
+	require.Error(t, err)
+}
+
+func TestParseDeleteQuery(t *testing.T) {
+	// The example of the wiki, whose variable is only used by the delete.
+	query := `query q($before: string) {
+		v as var(func: has(event.ts)) @filter(lt(event.ts, $before))
+	}`
+	parsed, err := parseDeleteQuery(query, "v", map[string]string{"$before": "2018-01-01"})
+	require.NoError(t, err)
+	require.Equal(t, "v", parsed.Query[0].Var)
+	require.Equal(t, "2018-01-01", parsed.Query[0].Filter.Func.Args[0].Value)
+
+	_, err = parseDeleteQuery(query, "u", map[string]string{"$before": "2018-01-01"})
+	require.Error(t, err)
+}
+
+func TestDeleteBatches(t *testing.T) {
+	del := parseNquadsTemplate(`uid(v) <ts> * .`)
+	nquads, err := del.expand(map[string][]uint64{"v": {0x1, 0x2}}, map[string]string{}, true)
+	require.NoError(t, err)
+	require.Equal(t, "<0x1> <ts> * .\n<0x2> <ts> * .\n", nquads)
+}
+
+func TestTrimDeleteJobs(t *testing.T) {
+	deleteJobs.Lock()
+	defer deleteJobs.Unlock()
+	deleteJobs.jobs = make(map[uint64]*deleteJob)
+	defer func() { deleteJobs.jobs = nil }()
+
+	for id := uint64(1); id <= maxFinishedDeletes+10; id++ {
+		deleteJobs.jobs[id] = &deleteJob{status: DeleteStatus{ID: id, Status: deleteDone}}
+	}
+	deleteJobs.jobs[1].status.Status = deleteRunning
+	trimDeleteJobsLocked()
+
+	// The running delete is kept, along with the latest finished ones.
+	require.Len(t, deleteJobs.jobs, maxFinishedDeletes+1)
+	require.NotNil(t, deleteJobs.jobs[1])
+	require.Nil(t, deleteJobs.jobs[10])
+	require.NotNil(t, deleteJobs.jobs[11])
+}
@@ -0,0 +1,60 @@
+/*
+ * Copyright 2018 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package edgraph
+
+import (
+	"sync"
+	"time"
+
+	"github.com/dgraph-io/dgraph/worker"
+)
+
+// openTxns are the transactions which mutated through this Alpha, and weren't committed or
+// aborted through it yet, by start ts. An Alpha being drained waits for them before its restart.
+var openTxns struct {
+	sync.Mutex
+	byTs map[uint64]time.Time
+}
+
+func trackTxn(startTs uint64) {
+	openTxns.Lock()
+	defer openTxns.Unlock()
+	if openTxns.byTs == nil {
+		openTxns.byTs = make(map[uint64]time.Time)
+	}
+	openTxns.byTs[startTs] = time.Now()
+}
+
+// TxnDone forgets about the transaction at startTs, once committed or aborted.
+func TxnDone(startTs uint64) {
+	openTxns.Lock()
+	defer openTxns.Unlock()
+	delete(openTxns.byTs, startTs)
+}
+
+// OpenTxns returns the number of transactions which mutated through this Alpha and aren't done
+// yet. Those last mutated over --txn_ttl ago are left out, as they're aborted anyway.
+func OpenTxns() int {
+	openTxns.Lock()
+	defer openTxns.Unlock()
+	for ts, at := range openTxns.byTs {
+		if ttl := worker.Config.TxnTTL; ttl > 0 && time.Since(at) > ttl {
+			delete(openTxns.byTs, ts)
+		}
+	}
+	return len(openTxns.byTs)
+}
@@ -0,0 +1,44 @@
+/*
+ * Copyright 2018 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package edgraph
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dgraph-io/dgraph/worker"
+)
+
+func TestOpenTxns(t *testing.T) {
+	defer func(ttl time.Duration) { worker.Config.TxnTTL = ttl }(worker.Config.TxnTTL)
+	worker.Config.TxnTTL = time.Minute
+
+	trackTxn(10)
+	trackTxn(11)
+	trackTxn(11)
+	require.Equal(t, 2, OpenTxns())
+	TxnDone(10)
+	TxnDone(12)
+	require.Equal(t, 1, OpenTxns())
+
+	// Transactions idle for over --txn_ttl are aborted, so they're not waited for.
+	openTxns.Lock()
+	openTxns.byTs[11] = time.Now().Add(-2 * time.Minute)
+	openTxns.Unlock()
+	require.Equal(t, 0, OpenTxns())
+}
@@ -0,0 +1,305 @@
+/*
+ * Copyright 2018 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package edgraph
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/dgraph-io/dgo/protos/api"
+	"github.com/dgraph-io/dgraph/gql"
+	"github.com/dgraph-io/dgraph/graphalgo"
+	"github.com/dgraph-io/dgraph/protos/pb"
+	"github.com/dgraph-io/dgraph/query"
+	"github.com/dgraph-io/dgraph/types"
+	"github.com/dgraph-io/dgraph/worker"
+	"github.com/dgraph-io/dgraph/x"
+	"github.com/golang/glog"
+	otrace "go.opencensus.io/trace"
+	"golang.org/x/net/context"
+)
+
+// Graph algorithms run as jobs on the Alpha they're started on. A job has the groups serving its
+// predicate hold their part of its edges at a single timestamp, and run every superstep of the
+// algorithm over them. The Alpha only merges their results into the values of the nodes. The
+// values changed by every superstep are written to a temporary predicate, which the groups keep
+// like any other one, so that the progress of a job can be queried while it runs. Once done, the
+// values are written to the output predicate, and the temporary predicate is dropped.
+
+const (
+	AlgorithmRunning   = "running"
+	AlgorithmDone      = "done"
+	AlgorithmFailed    = "failed"
+	AlgorithmCancelled = "cancelled"
+)
+
+// Values written by a single mutation.
+const algoWriteBatch = 5000
+
+// AlgorithmJob is a graph algorithm run over the edges of a uid predicate, with its results
+// stored in an output predicate.
+type AlgorithmJob struct {
+	Algorithm string            `json:"algorithm"`
+	Predicate string            `json:"predicate"`
+	Output    string            `json:"output"`
+	MaxSteps  int               `json:"max_steps,omitempty"`
+	Options   graphalgo.Options `json:"options,omitempty"`
+
+	// The job is referred to by the timestamp it reads at, which is unique in the cluster.
+	Id         uint64     `json:"id"`
+	Namespace  string     `json:"namespace,omitempty"`
+	Status     string     `json:"status"`
+	Temporary  string     `json:"temporary,omitempty"`
+	Nodes      int        `json:"nodes"`
+	Edges      int        `json:"edges"`
+	Steps      int        `json:"steps"`
+	Error      string     `json:"error,omitempty"`
+	StartedAt  time.Time  `json:"started_at"`
+	FinishedAt *time.Time `json:"finished_at,omitempty"`
+
+	cancel context.CancelFunc
+}
+
+var algoJobs struct {
+	sync.Mutex
+	jobs map[uint64]*AlgorithmJob
+}
+
+func (j *AlgorithmJob) validate() error {
+	if len(j.Predicate) == 0 || len(j.Output) == 0 {
+		return x.Errorf("Algorithm job must have a predicate and an output predicate")
+	}
+	if j.Predicate == j.Output {
+		return x.Errorf("Output predicate must be different from the predicate")
+	}
+	if strings.HasPrefix(j.Output, "dgraph.") {
+		return x.Errorf("Output predicate %q is reserved", j.Output)
+	}
+	if j.MaxSteps < 0 {
+		return x.Errorf("Invalid max_steps: %d", j.MaxSteps)
+	}
+	_, err := graphalgo.New(j.Algorithm, graphalgo.NewGraph(nil, 0), j.Options)
+	return err
+}
+
+// update changes the job while holding the lock of the jobs.
+func (j *AlgorithmJob) update(f func(j *AlgorithmJob)) {
+	algoJobs.Lock()
+	defer algoJobs.Unlock()
+	f(j)
+}
+
+// StartAlgorithm starts running the job in the background, and returns its id.
+func (s *Server) StartAlgorithm(ctx context.Context, job *AlgorithmJob) (uint64, error) {
+	if !isMutationAllowed(ctx) {
+		return 0, x.Errorf("No mutations allowed by server.")
+	}
+	ctx, err := namespaceContext(ctx)
+	if err != nil {
+		return 0, err
+	}
+	if len(x.NamespaceFromContext(ctx)) == 0 {
+		if err := isAlterAllowed(ctx); err != nil {
+			return 0, err
+		}
+	}
+	if err := job.validate(); err != nil {
+		return 0, err
+	}
+	nodes, err := worker.GetSchemaOverNetwork(ctx, &pb.SchemaRequest{
+		Predicates: []string{job.Predicate},
+		Fields:     []string{"type"},
+	})
+	if err != nil {
+		return 0, err
+	}
+	if len(nodes) == 0 || nodes[0].Type != "uid" {
+		return 0, x.Errorf("Predicate %q must be of type uid", job.Predicate)
+	}
+
+	j := &AlgorithmJob{
+		Algorithm: job.Algorithm,
+		Predicate: job.Predicate,
+		Output:    job.Output,
+		MaxSteps:  job.MaxSteps,
+		Options:   job.Options,
+		Id:        State.getTimestamp(true),
+		Namespace: x.NamespaceFromContext(ctx),
+		Status:    AlgorithmRunning,
+		StartedAt: time.Now(),
+	}
+	j.Temporary = fmt.Sprintf("dgraph.algo.%d", j.Id)
+	// The job outlives the request starting it.
+	jctx, cancel := context.WithCancel(x.WithNamespace(context.Background(), j.Namespace))
+	j.cancel = cancel
+
+	algoJobs.Lock()
+	if algoJobs.jobs == nil {
+		algoJobs.jobs = make(map[uint64]*AlgorithmJob)
+	}
+	algoJobs.jobs[j.Id] = j
+	algoJobs.Unlock()
+
+	glog.Infof("Starting algorithm job %d: %s over %s into %s", j.Id, j.Algorithm, j.Predicate,
+		j.Output)
+	go func() {
+		defer cancel()
+		err := s.runAlgorithm(jctx, j)
+		j.update(func(j *AlgorithmJob) {
+			now := time.Now()
+			j.FinishedAt = &now
+			switch {
+			case err == nil:
+				j.Status = AlgorithmDone
+			case jctx.Err() == context.Canceled:
+				j.Status = AlgorithmCancelled
+			default:
+				j.Status = AlgorithmFailed
+				j.Error = err.Error()
+			}
+		})
+		if err != nil {
+			glog.Errorf("Algorithm job %d stopped: %v", j.Id, err)
+		} else {
+			glog.Infof("Algorithm job %d done.", j.Id)
+		}
+	}()
+	return j.Id, nil
+}
+
+// AlgorithmJobs returns the jobs run on this Alpha since it started, in the order they started.
+func AlgorithmJobs() []AlgorithmJob {
+	algoJobs.Lock()
+	defer algoJobs.Unlock()
+	jobs := make([]AlgorithmJob, 0, len(algoJobs.jobs))
+	for _, j := range algoJobs.jobs {
+		jobs = append(jobs, *j)
+	}
+	sort.Slice(jobs, func(i, k int) bool { return jobs[i].Id < jobs[k].Id })
+	return jobs
+}
+
+// CancelAlgorithm stops the job with the given id, if it's still running.
+func CancelAlgorithm(id uint64) error {
+	algoJobs.Lock()
+	defer algoJobs.Unlock()
+	j, ok := algoJobs.jobs[id]
+	if !ok {
+		return x.Errorf("No algorithm job with id %d", id)
+	}
+	if j.Status != AlgorithmRunning {
+		return x.Errorf("Algorithm job %d is %s", id, j.Status)
+	}
+	j.cancel()
+	return nil
+}
+
+func (s *Server) runAlgorithm(ctx context.Context, j *AlgorithmJob) (rerr error) {
+	ctx, span := otrace.StartSpan(ctx, "Server.runAlgorithm")
+	defer span.End()
+
+	g, err := worker.LoadAlgorithmGraph(ctx, j.Predicate, j.Id)
+	if err != nil {
+		return err
+	}
+	defer g.Release()
+	j.update(func(j *AlgorithmJob) {
+		j.Nodes, j.Edges = g.NumNodes(), g.NumEdges()
+	})
+	span.Annotatef(nil, "Loaded %d nodes and %d edges", g.NumNodes(), g.NumEdges())
+
+	a, err := graphalgo.New(j.Algorithm, g.Graph, j.Options)
+	if err != nil {
+		return err
+	}
+	typ := a.Type().Name()
+	if err := s.alterInternalSchema(ctx, fmt.Sprintf("%s: %s .\n%s: %s .",
+		j.Temporary, typ, j.Output, typ)); err != nil {
+		return err
+	}
+	defer func() {
+		// Don't use ctx, which could be cancelled.
+		bctx := x.WithNamespace(context.Background(), j.Namespace)
+		if err := dropInternalPredicate(bctx, j.Temporary); err != nil && rerr == nil {
+			rerr = err
+		}
+	}()
+
+	_, err = graphalgo.Run(ctx, a, j.MaxSteps, g.Run, func(n int, changed []int) error {
+		if err := s.writeValues(ctx, j.Temporary, g.Graph, a, changed); err != nil {
+			return err
+		}
+		j.update(func(j *AlgorithmJob) { j.Steps = n })
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	all := make([]int, g.NumNodes())
+	for i := range all {
+		all[i] = i
+	}
+	return s.writeValues(ctx, j.Output, g.Graph, a, all)
+}
+
+// writeValues sets the values of the given nodes as attr, committing every batch right away.
+func (s *Server) writeValues(ctx context.Context, attr string, g *graphalgo.Graph,
+	a graphalgo.Algorithm, nodes []int) error {
+	for start := 0; start < len(nodes); start += algoWriteBatch {
+		end := start + algoWriteBatch
+		if end > len(nodes) {
+			end = len(nodes)
+		}
+		mu := &api.Mutation{CommitNow: true}
+		for _, i := range nodes[start:end] {
+			v := a.Value(i)
+			val, err := types.ObjectValue(v.Tid, v.Value)
+			if err != nil {
+				return err
+			}
+			mu.Set = append(mu.Set, &api.NQuad{
+				Subject:     fmt.Sprintf("%#x", g.Uid(i)),
+				Predicate:   attr,
+				ObjectValue: val,
+			})
+		}
+		if _, err := s.Mutate(ctx, mu); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// dropInternalPredicate drops a predicate used internally by Dgraph, like the temporary ones of
+// the algorithm jobs, right away.
+func dropInternalPredicate(ctx context.Context, attr string) error {
+	nq := &gql.NQuad{NQuad: &api.NQuad{
+		Subject:     x.Star,
+		Predicate:   attr,
+		ObjectValue: &api.Value{Val: &api.Value_StrVal{StrVal: x.Star}},
+	}}
+	edge, err := nq.ToDeletePredEdge()
+	if err != nil {
+		return err
+	}
+	m := &pb.Mutations{StartTs: State.getTimestamp(false), Edges: []*pb.DirectedEdge{edge}}
+	_, err = query.ApplyMutations(ctx, m)
+	return err
+}
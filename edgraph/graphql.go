@@ -0,0 +1,214 @@
+/*
+ * Copyright 2018 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package edgraph
+
+import (
+	"encoding/json"
+	"sync"
+
+	"github.com/dgraph-io/dgo/protos/api"
+	"github.com/dgraph-io/dgraph/graphql"
+	"github.com/dgraph-io/dgraph/x"
+	otrace "go.opencensus.io/trace"
+	"golang.org/x/net/context"
+)
+
+// The GraphQL schema given in the SDL is stored in the graph, like upsert templates. Without one,
+// the GraphQL schema is generated from the Dgraph schema.
+const graphQLSchema = `
+	dgraph.graphql.schema: string .
+`
+
+var setGraphQLSchema = compileBuiltin(&UpsertTemplate{
+	Name:  "dgraph.graphql.set",
+	Query: `{ s as var(func: has(dgraph.graphql.schema)) }`,
+	Set:   `uid(s) <dgraph.graphql.schema> "$schema" .`,
+})
+
+var removeGraphQLSchema = compileBuiltin(&UpsertTemplate{
+	Name:   "dgraph.graphql.remove",
+	Query:  `{ s as var(func: has(dgraph.graphql.schema)) }`,
+	Delete: `uid(s) <dgraph.graphql.schema> * .`,
+})
+
+// GraphQL schemas are built for every namespace from the SDL, or the Dgraph schema, they were
+// last built from, and rebuilt once that changes.
+var graphQLSchemas struct {
+	sync.Mutex
+	m map[string]builtGraphQLSchema // By namespace.
+}
+
+type builtGraphQLSchema struct {
+	from   string
+	schema *graphql.Schema
+}
+
+// SetGraphQLSchema stores the GraphQL schema given in the SDL, and alters the Dgraph schema to
+// have its predicates. An empty SDL removes the schema stored, so that it's generated again.
+func (s *Server) SetGraphQLSchema(ctx context.Context, sdl string) error {
+	ctx, span := otrace.StartSpan(ctx, "Server.SetGraphQLSchema")
+	defer span.End()
+
+	if err := isTemplateChangeAllowed(ctx); err != nil {
+		return err
+	}
+	if len(sdl) == 0 {
+		ctx, err := namespaceContext(ctx)
+		if err != nil {
+			return err
+		}
+		_, err = s.runTemplate(ctx, removeGraphQLSchema, nil)
+		return err
+	}
+	gs, err := graphql.ParseSchema(sdl)
+	if err != nil {
+		return err
+	}
+	if _, err := s.Alter(ctx, &api.Operation{Schema: gs.DgraphSchema()}); err != nil {
+		return err
+	}
+	ctx, err = namespaceContext(ctx)
+	if err != nil {
+		return err
+	}
+	if err := s.alterInternalSchema(ctx, graphQLSchema); err != nil {
+		return err
+	}
+	_, err = s.runTemplate(ctx, setGraphQLSchema, map[string]string{"$schema": sdl})
+	return err
+}
+
+// GetGraphQLSchema returns the GraphQL schema stored, or the one generated from the Dgraph schema
+// if there is none.
+func (s *Server) GetGraphQLSchema(ctx context.Context) (*graphql.Schema, error) {
+	resp, err := s.Query(ctx, &api.Request{
+		Query:    `{ s(func: has(dgraph.graphql.schema)) { sdl: dgraph.graphql.schema } }`,
+		ReadOnly: true,
+	})
+	if err != nil {
+		return nil, err
+	}
+	var res struct {
+		S []struct {
+			SDL string `json:"sdl"`
+		} `json:"s"`
+	}
+	if err := json.Unmarshal(resp.Json, &res); err != nil {
+		return nil, err
+	}
+	var from string
+	var preds []graphql.Predicate
+	if len(res.S) > 0 {
+		from = res.S[0].SDL
+	} else {
+		// Schema blocks can't be part of other queries.
+		resp, err := s.Query(ctx, &api.Request{Query: "schema {}", ReadOnly: true})
+		if err != nil {
+			return nil, err
+		}
+		for _, n := range resp.Schema {
+			preds = append(preds, graphql.Predicate{
+				Name:       n.Predicate,
+				Type:       n.Type,
+				List:       n.List,
+				Tokenizers: n.Tokenizer,
+			})
+		}
+		js, err := json.Marshal(preds)
+		if err != nil {
+			return nil, err
+		}
+		from = string(js)
+	}
+
+	ns := namespaceOf(ctx)
+	graphQLSchemas.Lock()
+	defer graphQLSchemas.Unlock()
+	if built, ok := graphQLSchemas.m[ns]; ok && built.from == from {
+		return built.schema, nil
+	}
+	var gs *graphql.Schema
+	if len(res.S) > 0 {
+		if gs, err = graphql.ParseSchema(from); err != nil {
+			return nil, x.Wrapf(err, "while parsing the GraphQL schema stored")
+		}
+	} else {
+		gs = graphql.FromPredicates(preds)
+	}
+	if graphQLSchemas.m == nil {
+		graphQLSchemas.m = make(map[string]builtGraphQLSchema)
+	}
+	graphQLSchemas.m[ns] = builtGraphQLSchema{from: from, schema: gs}
+	return gs, nil
+}
+
+// namespaceOf returns the namespace the request is made against, or the default namespace if the
+// request isn't allowed to use the one it names, which the request is then refused for anyway.
+func namespaceOf(ctx context.Context) string {
+	ctx, err := namespaceContext(ctx)
+	if err != nil {
+		return ""
+	}
+	return x.NamespaceFromContext(ctx)
+}
+
+// GraphQL executes the GraphQL request against the GraphQL schema.
+func (s *Server) GraphQL(ctx context.Context, req *graphql.Request) *graphql.Response {
+	ctx, span := otrace.StartSpan(ctx, "Server.GraphQL")
+	defer span.End()
+
+	gs, err := s.GetGraphQLSchema(ctx)
+	if err != nil {
+		return &graphql.Response{Errors: []*graphql.Error{{Message: err.Error()}}}
+	}
+	return graphql.Execute(ctx, gs, graphQLExecutor{s}, req)
+}
+
+// graphQLExecutor runs the queries and mutations GraphQL requests are translated to.
+type graphQLExecutor struct {
+	s *Server
+}
+
+func (e graphQLExecutor) Query(ctx context.Context, q string, startTs uint64) (
+	[]byte, uint64, error) {
+	resp, err := e.s.Query(ctx, &api.Request{Query: q, StartTs: startTs})
+	if err != nil {
+		return nil, 0, err
+	}
+	var ts uint64
+	if resp.Txn != nil {
+		ts = resp.Txn.StartTs
+	}
+	return resp.Json, ts, nil
+}
+
+func (e graphQLExecutor) Mutate(ctx context.Context, startTs uint64, set, del []byte,
+	commit bool) (map[string]string, uint64, error) {
+	assigned, err := e.s.Mutate(ctx, &api.Mutation{
+		SetJson:    set,
+		DeleteJson: del,
+		StartTs:    startTs,
+		CommitNow:  commit,
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+	if assigned.Context == nil {
+		return nil, 0, x.Errorf("No transaction context in the response of the mutation")
+	}
+	return assigned.Uids, assigned.Context.StartTs, nil
+}
@@ -0,0 +1,58 @@
+/*
+ * Copyright 2018 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package edgraph
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+
+	"github.com/dgraph-io/dgraph/worker"
+	"github.com/dgraph-io/dgraph/x"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/metadata"
+)
+
+var errNamespaceToken = x.Errorf("Invalid or missing token for namespace")
+
+// namespaceContext returns the context for the namespace the request is made against. Requests
+// pass the namespace, and its token, in the namespace and namespace-token keys of the context.
+// Requests without a namespace are run against the default namespace.
+func namespaceContext(ctx context.Context) (context.Context, error) {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ctx, nil
+	}
+	names := md.Get("namespace")
+	if len(names) == 0 || len(names[0]) == 0 {
+		return ctx, nil
+	}
+	ns := names[0]
+	hash, ok := worker.NamespaceTokenHash(ns)
+	if !ok {
+		return ctx, x.Errorf("Namespace %s doesn't exist", ns)
+	}
+	tokens := md.Get("namespace-token")
+	if len(tokens) == 0 {
+		return ctx, errNamespaceToken
+	}
+	sum := sha256.Sum256([]byte(tokens[0]))
+	if subtle.ConstantTimeCompare([]byte(hex.EncodeToString(sum[:])), []byte(hash)) != 1 {
+		return ctx, errNamespaceToken
+	}
+	return x.WithNamespace(ctx, ns), nil
+}
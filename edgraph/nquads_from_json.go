@@ -135,7 +135,7 @@ func handleBasicType(k string, v interface{}, op int, nq *api.NQuad) error {
 		nq.Predicate, nq.Lang = x.PredicateLang(k)
 
 		// Default value is considered as S P * deletion.
-		if v == "" && op == delete {
+		if v == "" && op == del {
 			nq.ObjectValue = &api.Value{Val: &api.Value_DefaultVal{DefaultVal: x.Star}}
 			return nil
 		}
@@ -146,14 +146,14 @@ func handleBasicType(k string, v interface{}, op int, nq *api.NQuad) error {
 		nq.ObjectValue = &api.Value{Val: &api.Value_StrVal{StrVal: v}}
 
 	case float64:
-		if v == 0 && op == delete {
+		if v == 0 && op == del {
 			nq.ObjectValue = &api.Value{Val: &api.Value_DefaultVal{DefaultVal: x.Star}}
 			return nil
 		}
 		nq.ObjectValue = &api.Value{Val: &api.Value_DoubleVal{DoubleVal: v}}
 
 	case bool:
-		if v == false && op == delete {
+		if v == false && op == del {
 			nq.ObjectValue = &api.Value{Val: &api.Value_DefaultVal{DefaultVal: x.Star}}
 			return nil
 		}
@@ -168,7 +168,7 @@ func handleBasicType(k string, v interface{}, op int, nq *api.NQuad) error {
 
 func checkForDeletion(mr *mapResponse, m map[string]interface{}, op int) {
 	// Since uid is the only key, this must be S * * deletion.
-	if op == delete && len(mr.uid) > 0 && len(m) == 1 {
+	if op == del && len(mr.uid) > 0 && len(m) == 1 {
 		mr.nquads = append(mr.nquads, &api.NQuad{
 			Subject:     mr.uid,
 			Predicate:   x.Star,
@@ -243,7 +243,7 @@ func mapToNquads(m map[string]interface{}, idx *int, op int, parentPred string)
 	}
 
 	if len(mr.uid) == 0 {
-		if op == delete {
+		if op == del {
 			// Delete operations with a non-nil value must have a uid specified.
 			return mr, x.Errorf("uid must be present and non-zero while deleting edges.")
 		}
@@ -261,7 +261,7 @@ func mapToNquads(m map[string]interface{}, idx *int, op int, parentPred string)
 			continue
 		}
 
-		if op == delete {
+		if op == del {
 			// This corresponds to edge deletion.
 			if v == nil {
 				mr.nquads = append(mr.nquads, &api.NQuad{
@@ -288,7 +288,7 @@ func mapToNquads(m map[string]interface{}, idx *int, op int, parentPred string)
 		}
 
 		if v == nil {
-			if op == delete {
+			if op == del {
 				nq.ObjectValue = &api.Value{Val: &api.Value_DefaultVal{DefaultVal: x.Star}}
 				mr.nquads = append(mr.nquads, &nq)
 			}
@@ -376,7 +376,7 @@ func mapToNquads(m map[string]interface{}, idx *int, op int, parentPred string)
 
 const (
 	set = iota
-	delete
+	del
 )
 
 func nquadsFromJson(b []byte, op int) ([]*api.NQuad, error) {
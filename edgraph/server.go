@@ -21,8 +21,10 @@ import (
 	"fmt"
 	"math"
 	"os"
+	"strconv"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 	"unicode"
 
@@ -30,7 +32,9 @@ import (
 	"github.com/dgraph-io/badger/options"
 	"github.com/dgraph-io/dgo/protos/api"
 	"github.com/dgraph-io/dgo/y"
+	"github.com/dgraph-io/dgraph/audit"
 	"github.com/dgraph-io/dgraph/gql"
+	"github.com/dgraph-io/dgraph/posting"
 	"github.com/dgraph-io/dgraph/protos/pb"
 	"github.com/dgraph-io/dgraph/query"
 	"github.com/dgraph-io/dgraph/rdf"
@@ -43,6 +47,7 @@ import (
 	otrace "go.opencensus.io/trace"
 	"golang.org/x/net/context"
 	"golang.org/x/net/trace"
+	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/peer"
@@ -55,6 +60,7 @@ type ServerState struct {
 
 	Pstore   *badger.DB
 	WALstore *badger.DB
+	Audit    *audit.Logger // Nil, unless --audit is set.
 
 	vlogTicker          *time.Ticker // runs every 1m, check size of vlog and run GC conditionally.
 	mandatoryVlogTicker *time.Ticker // runs every 10m, we always run vlog GC.
@@ -74,6 +80,21 @@ func InitServerState() {
 
 	State.initStorage()
 
+	if Config.QueryCacheMB > 0 {
+		results = newResultCache(int64(Config.QueryCacheMB * (1 << 20)))
+		worker.SubscribeInvalidations(results.invalidate)
+	}
+	worker.SubscribeInvalidations(templates.invalidate)
+	// The slow query log is set up even if disabled, so that it can be enabled at runtime.
+	var err error
+	slowQueries, err = newSlowQueryLog(Config.SlowQuery, Config.SlowQuerySample,
+		Config.SlowQueryRate, Config.SlowQueryLog)
+	x.Checkf(err, "While opening the slow query log")
+	if len(Config.Audit) > 0 {
+		State.Audit, err = audit.Open(Config.Audit, Config.AuditRotateMB)
+		x.Checkf(err, "While opening the audit log")
+	}
+
 	go State.fillTimestampRequests()
 }
 
@@ -83,6 +104,10 @@ func (s *ServerState) runVlogGC(store *badger.DB) {
 	const GB = int64(1 << 30)
 
 	runGC := func() {
+		if !worker.CompactionAllowed() {
+			// Paused, or outside of the rollup windows. The next tick tries again.
+			return
+		}
 		var err error
 		for err == nil {
 			// If a GC is successful, immediately run it again.
@@ -106,10 +131,17 @@ func (s *ServerState) runVlogGC(store *badger.DB) {
 }
 
 func setBadgerOptions(opt badger.Options, dir string) badger.Options {
-	opt.SyncWrites = true
+	opt.SyncWrites = !Config.BadgerInMemory
 	opt.Truncate = true
 	opt.Dir = dir
 	opt.ValueDir = dir
+	if Config.BadgerInMemory {
+		// The files are in memory already, so they're mapped rather than copied.
+		glog.Infof("Badger files are in memory, in %s", dir)
+		opt.TableLoadingMode = options.MemoryMap
+		opt.ValueLogLoadingMode = options.MemoryMap
+		return opt
+	}
 
 	glog.Infof("Setting Badger table load option: %s", Config.BadgerTables)
 	switch Config.BadgerTables {
@@ -140,6 +172,7 @@ func (s *ServerState) initStorage() {
 	{
 		// Write Ahead Log directory
 		x.Checkf(os.MkdirAll(Config.WALDir, 0700), "Error while creating WAL dir.")
+		x.Check(x.CheckFormat(Config.WALDir, x.WALFormat))
 		opt := badger.LSMOnlyOptions
 		opt = setBadgerOptions(opt, Config.WALDir)
 		opt.ValueLogMaxEntries = 10000 // Allow for easy space reclamation.
@@ -160,6 +193,7 @@ func (s *ServerState) initStorage() {
 		// All the writes to posting store should be synchronous. We use batched writers
 		// for posting lists, so the cost of sync writes is amortized.
 		x.Check(os.MkdirAll(Config.PostingDir, 0700))
+		x.Check(x.CheckFormat(Config.PostingDir, x.PostingFormat))
 		opt := badger.DefaultOptions
 		opt.ValueThreshold = 1 << 10 // 1KB
 		opt.NumVersionsToKeep = math.MaxInt32
@@ -185,6 +219,9 @@ func (s *ServerState) Dispose() {
 	}
 	s.vlogTicker.Stop()
 	s.mandatoryVlogTicker.Stop()
+	if err := s.Audit.Close(); err != nil {
+		glog.Errorf("Error while closing the audit log: %v", err)
+	}
 }
 
 // Server implements protos.DgraphServer
@@ -263,9 +300,10 @@ func (s *ServerState) getTimestamp(readOnly bool) uint64 {
 	return <-tr.ch
 }
 
-func (s *Server) Alter(ctx context.Context, op *api.Operation) (*api.Payload, error) {
+func (s *Server) Alter(ctx context.Context, op *api.Operation) (resp *api.Payload, err error) {
 	ctx, span := otrace.StartSpan(ctx, "Server.Alter")
 	defer span.End()
+	defer func() { auditAlter(ctx, op, err) }()
 	span.Annotatef(nil, "Alter operation: %+v", op)
 
 	// Always print out Alter operations because they are important and rare.
@@ -287,10 +325,20 @@ func (s *Server) Alter(ctx context.Context, op *api.Operation) (*api.Payload, er
 	if !isMutationAllowed(ctx) {
 		return nil, x.Errorf("No mutations allowed by server.")
 	}
-	if err := isAlterAllowed(ctx); err != nil {
-		glog.Warningf("Alter denied with error: %v\n", err)
+	if err := worker.CheckWritable(); err != nil {
+		return nil, err
+	}
+	ctx, err = namespaceContext(ctx)
+	if err != nil {
 		return nil, err
 	}
+	// The token of a namespace allows altering its schema.
+	if len(x.NamespaceFromContext(ctx)) == 0 {
+		if err := isAlterAllowed(ctx); err != nil {
+			glog.Warningf("Alter denied with error: %v\n", err)
+			return nil, err
+		}
+	}
 	// All checks done.
 
 	defer glog.Infof("ALTER op: %+v done", op)
@@ -300,6 +348,9 @@ func (s *Server) Alter(ctx context.Context, op *api.Operation) (*api.Payload, er
 	if op.DropAll {
 		m.DropAll = true
 		_, err := query.ApplyMutations(ctx, m)
+		if err == nil {
+			worker.RecordEvent(worker.EventSchemaChanged, "", "Dropped all data")
+		}
 		return empty, err
 	}
 	if len(op.DropAttr) > 0 {
@@ -315,7 +366,15 @@ func (s *Server) Alter(ctx context.Context, op *api.Operation) (*api.Payload, er
 		}
 		edges := []*pb.DirectedEdge{edge}
 		m.Edges = edges
+		if worker.Config.DropGrace > 0 && !worker.IsTombstone(op.DropAttr) {
+			// Keep the predicate around for a while, so that the drop can be undone.
+			m.Tombstone = worker.TombstoneName(op.DropAttr, time.Now())
+		}
 		_, err = query.ApplyMutations(ctx, m)
+		if err == nil {
+			worker.RecordEvent(worker.EventSchemaChanged, op.DropAttr, "Dropped predicate %s",
+				op.DropAttr)
+		}
 		return empty, err
 	}
 	updates, err := schema.Parse(op.Schema)
@@ -325,10 +384,29 @@ func (s *Server) Alter(ctx context.Context, op *api.Operation) (*api.Payload, er
 	glog.Infof("Got schema: %+v\n", updates)
 	// TODO: Maybe add some checks about the schema.
 	m.Schema = updates
+	m.BackgroundIndex = isBackgroundIndex(ctx)
 	_, err = query.ApplyMutations(ctx, m)
+	if err == nil {
+		for _, su := range updates {
+			worker.RecordEvent(worker.EventSchemaChanged, su.Predicate, "Schema of %s changed",
+				su.Predicate)
+		}
+	}
 	return empty, err
 }
 
+// isBackgroundIndex tells whether the Alter asks for the indexes it adds to be built in the
+// background, via the background-index key of the context. The Alter then returns before they
+// can be used, instead of once they are built.
+func isBackgroundIndex(ctx context.Context) bool {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return false
+	}
+	vals := md.Get("background-index")
+	return len(vals) > 0 && vals[0] == "true"
+}
+
 func annotateStartTs(span *otrace.Span, ts uint64) {
 	span.Annotate([]otrace.Attribute{otrace.Int64Attribute("startTs", int64(ts))}, "")
 }
@@ -336,11 +414,16 @@ func annotateStartTs(span *otrace.Span, ts uint64) {
 func (s *Server) Mutate(ctx context.Context, mu *api.Mutation) (resp *api.Assigned, err error) {
 	ctx, span := otrace.StartSpan(ctx, "Server.Mutate")
 	defer span.End()
+	var m *pb.Mutations
+	defer func() { auditMutation(ctx, mu, m, err) }()
 
 	resp = &api.Assigned{}
 	if err := x.HealthCheck(); err != nil {
 		return resp, err
 	}
+	if ctx, err = namespaceContext(ctx); err != nil {
+		return resp, err
+	}
 
 	if len(mu.SetJson) > 0 {
 		span.Annotatef(nil, "Got JSON Mutation: %s", mu.SetJson)
@@ -351,10 +434,18 @@ func (s *Server) Mutate(ctx context.Context, mu *api.Mutation) (resp *api.Assign
 	if !isMutationAllowed(ctx) {
 		return nil, x.Errorf("No mutations allowed.")
 	}
+	if err := worker.CheckWritable(); err != nil {
+		return nil, err
+	}
 	if mu.StartTs == 0 {
 		mu.StartTs = State.getTimestamp(false)
 	}
 	annotateStartTs(span, mu.StartTs)
+	if mu.CommitNow {
+		defer TxnDone(mu.StartTs)
+	} else {
+		trackTxn(mu.StartTs)
+	}
 	emptyMutation :=
 		len(mu.GetSetJson()) == 0 && len(mu.GetDeleteJson()) == 0 &&
 			len(mu.Set) == 0 && len(mu.Del) == 0 &&
@@ -389,9 +480,11 @@ func (s *Server) Mutate(ctx context.Context, mu *api.Mutation) (resp *api.Assign
 		return resp, err
 	}
 
-	m := &pb.Mutations{
+	m = &pb.Mutations{
 		Edges:   edges,
 		StartTs: mu.StartTs,
+		// Long loads of the exempt clients aren't aborted when idle.
+		KeepAlive: Config.TxnTTLExempt.Contains(clientHost(ctx)),
 	}
 	span.Annotatef(nil, "Applying mutations: %+v", m)
 	resp.Context, err = query.ApplyMutations(ctx, m)
@@ -422,12 +515,17 @@ func (s *Server) Mutate(ctx context.Context, mu *api.Mutation) (resp *api.Assign
 	}
 	span.Annotatef(nil, "Prewrites err: %v. Attempting to commit/abort immediately.", err)
 	ctxn := resp.Context
+	if err := s.checkConstraints(ctx, ctxn.StartTs, ctxn.Preds); err != nil {
+		ctxn.Aborted = true
+		_, _ = worker.CommitOverNetwork(ctx, ctxn)
+		return resp, status.Error(codes.FailedPrecondition, err.Error())
+	}
 	// zero would assign the CommitTs
 	cts, err := worker.CommitOverNetwork(ctx, ctxn)
 	span.Annotatef(nil, "Status of commit at ts: %d: %v", ctxn.StartTs, err)
 	if err != nil {
-		if err == y.ErrAborted {
-			err = status.Errorf(codes.Aborted, err.Error())
+		if worker.IsAborted(err) {
+			err = status.Error(codes.Aborted, err.Error())
 			resp.Context.Aborted = true
 		}
 		return resp, err
@@ -441,6 +539,13 @@ func (s *Server) Mutate(ctx context.Context, mu *api.Mutation) (resp *api.Assign
 // This method is used to execute the query and return the response to the
 // client as a protocol buffer message.
 func (s *Server) Query(ctx context.Context, req *api.Request) (resp *api.Response, err error) {
+	return s.query(ctx, req, nil)
+}
+
+// query runs the query in req. With emit, the JSON of the response is passed to it a chunk at a
+// time as it's encoded, instead of being returned in resp, and the results aren't cached.
+func (s *Server) query(ctx context.Context, req *api.Request,
+	emit func([]byte) error) (resp *api.Response, err error) {
 	if glog.V(3) {
 		glog.Infof("Got a query: %+v", req)
 	}
@@ -453,6 +558,14 @@ func (s *Server) Query(ctx context.Context, req *api.Request) (resp *api.Respons
 		}
 		return resp, err
 	}
+	if ctx, err = namespaceContext(ctx); err != nil {
+		return resp, err
+	}
+	if timeout := time.Duration(atomic.LoadInt64(&queryTimeout)); timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
 
 	x.PendingQueries.Add(1)
 	x.NumQueries.Add(1)
@@ -468,8 +581,14 @@ func (s *Server) Query(ctx context.Context, req *api.Request) (resp *api.Respons
 	}
 
 	var l query.Latency
+	var er query.ExecuteResult
 	l.Start = time.Now()
 	span.Annotatef(nil, "Query received: %v", req)
+	if slowQueries != nil {
+		defer func() {
+			slowQueries.observe(ctx, req, &l, er.Subgraphs, resp, err)
+		}()
+	}
 
 	parsedReq, err := gql.Parse(gql.Request{
 		Str:       req.Query,
@@ -479,14 +598,64 @@ func (s *Server) Query(ctx context.Context, req *api.Request) (resp *api.Respons
 		return resp, err
 	}
 
+	asOf, err := asOfTs(ctx)
+	if err != nil {
+		return resp, err
+	}
+	// Only cache the results of queries outside of transactions, which can't see pending writes.
+	cache := emit == nil && results != nil && req.StartTs == 0 && asOf == 0 &&
+		cacheable(ctx, &parsedReq)
+	bestEffort := isBestEffort(ctx)
+	if bestEffort && req.StartTs != 0 {
+		return resp, errBestEffortTxn
+	}
+	if asOf > 0 {
+		if req.StartTs != 0 || bestEffort {
+			return resp, errAsOfTxn
+		}
+		if latest := posting.Oracle().MaxAssigned(); asOf > latest {
+			return resp, x.Errorf("asOfTs: %d is newer than the latest timestamp: %d", asOf,
+				latest)
+		}
+		if oldest := posting.HistoryTs(); asOf < oldest {
+			return resp, x.Errorf("asOfTs: %d is older than the history kept, from %d on",
+				asOf, oldest)
+		}
+		// Read at the past timestamp, outside of any transaction.
+		req.StartTs = asOf
+		span.Annotatef(nil, "Historical read at: %d", req.StartTs)
+	}
+	if bestEffort {
+		// Read at the latest timestamp this Alpha knows about, instead of asking Zero for one.
+		req.StartTs = posting.Oracle().MaxAssigned()
+		span.Annotatef(nil, "Best-effort read at: %d", req.StartTs)
+		// Fails for HTTP requests, which get the mark in the extensions of the response.
+		grpc.SetHeader(ctx, metadata.Pairs("best-effort", "true"))
+	}
 	if req.StartTs == 0 {
 		req.StartTs = State.getTimestamp(req.ReadOnly)
 	}
-	resp.Txn = &api.TxnContext{
-		StartTs: req.StartTs,
+	// The timestamp of a best-effort or historical read isn't to be used by a transaction.
+	if !bestEffort && asOf == 0 {
+		resp.Txn = &api.TxnContext{
+			StartTs: req.StartTs,
+		}
 	}
 	annotateStartTs(span, req.StartTs)
 
+	var cacheKey string
+	var cacheGen uint64
+	if cache {
+		cacheKey = resultCacheKey(x.NamespaceFromContext(ctx), req.Query, req.Vars, req.StartTs)
+		if json, ok := results.get(cacheKey, req.StartTs); ok {
+			span.Annotate(nil, "Query result cached")
+			resp.Json = json
+			resp.Latency = &api.Latency{ParsingNs: uint64(time.Since(l.Start).Nanoseconds())}
+			return resp, nil
+		}
+		cacheGen = results.generation()
+	}
+
 	var queryRequest = query.QueryRequest{
 		Latency:  &l,
 		GqlQuery: &parsedReq,
@@ -494,18 +663,26 @@ func (s *Server) Query(ctx context.Context, req *api.Request) (resp *api.Respons
 	}
 
 	// Core processing happens here.
-	var er query.ExecuteResult
 	if er, err = queryRequest.Process(ctx); err != nil {
 		return resp, x.Wrap(err)
 	}
 	resp.Schema = er.SchemaNode
 
-	json, err := query.ToJson(&l, er.Subgraphs)
-	if err != nil {
-		return resp, err
+	if emit != nil && len(resp.Schema) == 0 {
+		if err := query.StreamJson(&l, er.Subgraphs, streamChunkSize, emit); err != nil {
+			return resp, err
+		}
+	} else {
+		json, err := query.ToJson(&l, er.Subgraphs)
+		if err != nil {
+			return resp, err
+		}
+		resp.Json = json
+		span.Annotatef(nil, "Response = %s", json)
+		if cache {
+			results.put(cacheKey, req.StartTs, resultAttrs(ctx, er.Subgraphs), json, cacheGen)
+		}
 	}
-	resp.Json = json
-	span.Annotatef(nil, "Response = %s", json)
 
 	gl := &api.Latency{
 		ParsingNs:    uint64(l.Parsing.Nanoseconds()),
@@ -517,9 +694,48 @@ func (s *Server) Query(ctx context.Context, req *api.Request) (resp *api.Respons
 	return resp, err
 }
 
-func (s *Server) CommitOrAbort(ctx context.Context, tc *api.TxnContext) (*api.TxnContext, error) {
+var errBestEffortTxn = x.Errorf("Best-effort queries can't be part of a transaction")
+
+// isBestEffort tells whether the query asks for a best-effort read, via the best-effort key of
+// the context. Best-effort queries read at the latest timestamp their Alpha knows about, which
+// saves getting a timestamp from Zero and waiting for the other Alphas to catch up with it, but
+// might miss the latest commits.
+func isBestEffort(ctx context.Context) bool {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return false
+	}
+	vals := md.Get("best-effort")
+	return len(vals) > 0 && vals[0] == "true"
+}
+
+var errAsOfTxn = x.Errorf("Queries as of a past timestamp can't be part of a transaction," +
+	" nor best-effort")
+
+// asOfTs returns the past timestamp the query asks to read the data as of, via the as-of-ts key of
+// the context, or zero if it doesn't.
+func asOfTs(ctx context.Context) (uint64, error) {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return 0, nil
+	}
+	vals := md.Get("as-of-ts")
+	if len(vals) == 0 {
+		return 0, nil
+	}
+	ts, err := strconv.ParseUint(vals[0], 10, 64)
+	if err != nil || ts == 0 {
+		return 0, x.Errorf("Invalid asOfTs: %q, must be a timestamp", vals[0])
+	}
+	return ts, nil
+}
+
+func (s *Server) CommitOrAbort(ctx context.Context,
+	tc *api.TxnContext) (resp *api.TxnContext, err error) {
 	ctx, span := otrace.StartSpan(ctx, "Server.CommitOrAbort")
 	defer span.End()
+	abort := tc.Aborted
+	defer func() { auditCommit(ctx, abort, tc.StartTs, resp, err) }()
 
 	if err := x.HealthCheck(); err != nil {
 		if tr, ok := trace.FromContext(ctx); ok {
@@ -527,18 +743,31 @@ func (s *Server) CommitOrAbort(ctx context.Context, tc *api.TxnContext) (*api.Tx
 		}
 		return &api.TxnContext{}, err
 	}
+	ctx, err = namespaceContext(ctx)
+	if err != nil {
+		return &api.TxnContext{}, err
+	}
 
 	tctx := &api.TxnContext{}
 	if tc.StartTs == 0 {
 		return &api.TxnContext{}, fmt.Errorf("StartTs cannot be zero while committing a transaction.")
 	}
 	annotateStartTs(span, tc.StartTs)
+	defer TxnDone(tc.StartTs)
 
 	span.Annotatef(nil, "Txn Context received: %+v", tc)
+	if !tc.Aborted {
+		if err := s.checkConstraints(ctx, tc.StartTs, tc.Preds); err != nil {
+			tc.Aborted = true
+			_, _ = worker.CommitOverNetwork(ctx, tc)
+			tctx.Aborted = true
+			return tctx, status.Error(codes.FailedPrecondition, err.Error())
+		}
+	}
 	commitTs, err := worker.CommitOverNetwork(ctx, tc)
-	if err == y.ErrAborted {
+	if worker.IsAborted(err) {
 		tctx.Aborted = true
-		return tctx, status.Errorf(codes.Aborted, err.Error())
+		return tctx, status.Error(codes.Aborted, err.Error())
 	}
 	tctx.CommitTs = commitTs
 	return tctx, err
@@ -557,6 +786,21 @@ func (s *Server) CheckVersion(ctx context.Context, c *api.Check) (v *api.Version
 	return v, nil
 }
 
+// Negotiate tells the client which version and features of the API it can use. Features are only
+// offered once every Alpha of the cluster has them, so that clients keep working against clusters
+// in the middle of an upgrade, whichever Alpha they talk to.
+func (s *Server) Negotiate(ctx context.Context,
+	req *pb.NegotiateRequest) (*pb.NegotiateResponse, error) {
+	if err := x.HealthCheck(); err != nil {
+		return nil, err
+	}
+	if glog.V(2) {
+		glog.Infof("Client %q negotiating API version %d with features %v", req.Client,
+			req.ApiVersion, req.Features)
+	}
+	return x.Negotiate(req, worker.ClusterFeatures()), nil
+}
+
 //-------------------------------------------------------------------------------------------------
 // HELPER FUNCTIONS
 //-------------------------------------------------------------------------------------------------
@@ -589,8 +833,8 @@ func isAlterAllowed(ctx context.Context) error {
 	if len(tokens) == 0 {
 		return errNoAuth
 	}
-	if tokens[0] != Config.AuthToken {
-		return x.Errorf("Provided auth token [%s] does not match. Permission denied.", tokens[0])
+	if !x.TokenMatches(tokens[0], Config.AuthToken) {
+		return x.Errorf("Provided auth token does not match. Permission denied.")
 	}
 	return nil
 }
@@ -621,7 +865,7 @@ func parseMutationObject(mu *api.Mutation) (*gql.Mutation, error) {
 		res.Set = append(res.Set, nqs...)
 	}
 	if len(mu.DeleteJson) > 0 {
-		nqs, err := nquadsFromJson(mu.DeleteJson, delete)
+		nqs, err := nquadsFromJson(mu.DeleteJson, del)
 		if err != nil {
 			return nil, err
 		}
@@ -21,12 +21,16 @@ import (
 	"testing"
 	"time"
 
+	"github.com/dgraph-io/badger"
+	"github.com/dgraph-io/badger/options"
 	"github.com/dgraph-io/dgo/protos/api"
 	"github.com/dgraph-io/dgraph/types"
 	"github.com/dgraph-io/dgraph/x"
 	"github.com/stretchr/testify/require"
 	geom "github.com/twpayne/go-geom"
 	"github.com/twpayne/go-geom/encoding/geojson"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/metadata"
 )
 
 func makeNquad(sub, pred string, val *api.Value) *api.NQuad {
@@ -239,7 +243,7 @@ func TestNquadsFromJson_BlankNodes(t *testing.T) {
 
 func TestNquadsDeleteEdges(t *testing.T) {
 	json := `[{"uid": "0x1","name":null,"mobile":null,"car":null}]`
-	nq, err := nquadsFromJson([]byte(json), delete)
+	nq, err := nquadsFromJson([]byte(json), del)
 	require.NoError(t, err)
 	require.Equal(t, 3, len(nq))
 }
@@ -284,7 +288,7 @@ func TestNquadsFromJsonError1(t *testing.T) {
 	b, err := json.Marshal(p)
 	require.NoError(t, err)
 
-	_, err = nquadsFromJson(b, delete)
+	_, err = nquadsFromJson(b, del)
 	require.Error(t, err)
 	require.Contains(t, err.Error(), "uid must be present and non-zero while deleting edges.")
 }
@@ -300,7 +304,7 @@ func TestNquadsFromJsonList(t *testing.T) {
 func TestNquadsFromJsonDelete(t *testing.T) {
 	json := `{"uid":1000,"friend":[{"uid":1001}]}`
 
-	nq, err := nquadsFromJson([]byte(json), delete)
+	nq, err := nquadsFromJson([]byte(json), del)
 	require.NoError(t, err)
 	require.Equal(t, nq[0], makeNquadEdge("1000", "friend", "1001"))
 }
@@ -377,3 +381,29 @@ func TestValidateKeys(t *testing.T) {
 		})
 	}
 }
+
+func TestIsBestEffort(t *testing.T) {
+	require.False(t, isBestEffort(context.Background()))
+
+	md := metadata.Pairs("best-effort", "true")
+	require.True(t, isBestEffort(metadata.NewIncomingContext(context.Background(), md)))
+
+	md = metadata.Pairs("best-effort", "false")
+	require.False(t, isBestEffort(metadata.NewIncomingContext(context.Background(), md)))
+}
+
+func TestSetBadgerOptions(t *testing.T) {
+	defer func(c Options) { Config = c }(Config)
+	Config.BadgerTables, Config.BadgerVlog = "disk", "disk"
+	opt := setBadgerOptions(badger.DefaultOptions, "p")
+	require.True(t, opt.SyncWrites)
+	require.Equal(t, options.FileIO, opt.TableLoadingMode)
+
+	// Data in memory is never synced, whatever the other options.
+	Config.BadgerInMemory = true
+	opt = setBadgerOptions(badger.DefaultOptions, "p")
+	require.False(t, opt.SyncWrites)
+	require.Equal(t, options.MemoryMap, opt.TableLoadingMode)
+	require.Equal(t, options.MemoryMap, opt.ValueLogLoadingMode)
+	require.Equal(t, "p", opt.Dir)
+}
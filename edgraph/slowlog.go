@@ -0,0 +1,163 @@
+/*
+ * Copyright 2018 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package edgraph
+
+import (
+	"encoding/json"
+	"io"
+	"math/rand"
+	"os"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/dgraph-io/dgo/protos/api"
+	"github.com/golang/glog"
+	"golang.org/x/net/context"
+
+	"github.com/dgraph-io/dgraph/query"
+	"github.com/dgraph-io/dgraph/x"
+)
+
+// slowQuery is an entry of the slow query log.
+type slowQuery struct {
+	Time          time.Time         `json:"time"`
+	Namespace     string            `json:"namespace,omitempty"`
+	DurationMs    float64           `json:"duration_ms"`
+	Query         string            `json:"query"`
+	Vars          map[string]string `json:"vars,omitempty"`
+	ReadTs        uint64            `json:"read_ts"`
+	Predicates    []string          `json:"predicates,omitempty"`
+	ParsingMs     float64           `json:"parsing_ms"`
+	ProcessingMs  float64           `json:"processing_ms"`
+	EncodingMs    float64           `json:"encoding_ms"`
+	MemoryBytes   int64             `json:"memory_bytes"`
+	ResponseBytes int               `json:"response_bytes"`
+	Error         string            `json:"error,omitempty"`
+	// Skipped is the number of slow queries left out of the log since the previous entry, by
+	// sampling or rate limiting.
+	Skipped uint64 `json:"skipped,omitempty"`
+}
+
+// slowQueryLog logs the queries taking longer than threshold, as JSON lines. Only a sample of them
+// is logged, and at most rate of them every second, so that a burst of slow queries doesn't flood
+// the log.
+type slowQueryLog struct {
+	sync.Mutex
+	threshold time.Duration // Zero disables the log.
+	sample    float64
+	rate      int
+	w         io.Writer // If nil, entries are logged with glog.
+	second    int64     // The Unix time in seconds of the last entry.
+	logged    int       // Entries logged in that second.
+	skipped   uint64
+}
+
+var slowQueries *slowQueryLog
+
+func newSlowQueryLog(threshold time.Duration, sample float64, rate int,
+	path string) (*slowQueryLog, error) {
+	l := &slowQueryLog{threshold: threshold, sample: sample, rate: rate}
+	if len(path) > 0 {
+		f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+		if err != nil {
+			return nil, err
+		}
+		l.w = f
+	}
+	return l, nil
+}
+
+// settings returns the threshold, sample and rate of the log. A zero threshold disables it.
+func (l *slowQueryLog) settings() (time.Duration, float64, int) {
+	l.Lock()
+	defer l.Unlock()
+	return l.threshold, l.sample, l.rate
+}
+
+func (l *slowQueryLog) set(threshold time.Duration, sample float64, rate int) {
+	l.Lock()
+	defer l.Unlock()
+	l.threshold, l.sample, l.rate = threshold, sample, rate
+}
+
+func millis(d time.Duration) float64 {
+	return float64(d) / float64(time.Millisecond)
+}
+
+// observe logs the query in req if it was slow. lat, sgs, resp and err are what the query got to
+// before it returned.
+func (l *slowQueryLog) observe(ctx context.Context, req *api.Request, lat *query.Latency,
+	sgs []*query.SubGraph, resp *api.Response, err error) {
+	d := time.Since(lat.Start)
+	if threshold, _, _ := l.settings(); threshold == 0 || d < threshold {
+		return
+	}
+	preds := query.GetAllPredicates(sgs)
+	sort.Strings(preds)
+	e := &slowQuery{
+		Time:         lat.Start,
+		Namespace:    x.NamespaceFromContext(ctx),
+		DurationMs:   millis(d),
+		Query:        req.Query,
+		Vars:         req.Vars,
+		ReadTs:       req.StartTs,
+		Predicates:   preds,
+		ParsingMs:    millis(lat.Parsing),
+		ProcessingMs: millis(lat.Processing),
+		EncodingMs:   millis(lat.Json),
+		// The results are all held until they are encoded, along with the response.
+		MemoryBytes:   query.ResultSize(sgs) + int64(len(resp.GetJson())),
+		ResponseBytes: len(resp.GetJson()),
+	}
+	if err != nil {
+		e.Error = err.Error()
+	}
+	l.record(e, time.Now())
+}
+
+// record logs e, unless it's left out of the sample or over the rate.
+func (l *slowQueryLog) record(e *slowQuery, now time.Time) {
+	l.Lock()
+	defer l.Unlock()
+	if l.sample < 1 && rand.Float64() >= l.sample {
+		l.skipped++
+		return
+	}
+	if sec := now.Unix(); sec != l.second {
+		l.second, l.logged = sec, 0
+	}
+	if l.rate > 0 && l.logged >= l.rate {
+		l.skipped++
+		return
+	}
+	l.logged++
+	e.Skipped, l.skipped = l.skipped, 0
+
+	js, err := json.Marshal(e)
+	if err != nil {
+		glog.Errorf("While encoding slow query: %v", err)
+		return
+	}
+	if l.w == nil {
+		glog.Warningf("Slow query: %s", js)
+		return
+	}
+	if _, err := l.w.Write(append(js, '\n')); err != nil {
+		glog.Errorf("While writing to the slow query log: %v", err)
+	}
+}
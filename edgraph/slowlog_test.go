@@ -0,0 +1,74 @@
+/*
+ * Copyright 2018 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package edgraph
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func loggedQueries(t *testing.T, buf *bytes.Buffer) []slowQuery {
+	var out []slowQuery
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		if len(line) == 0 {
+			continue
+		}
+		var e slowQuery
+		require.NoError(t, json.Unmarshal([]byte(line), &e))
+		out = append(out, e)
+	}
+	return out
+}
+
+func TestSlowQueryLogRate(t *testing.T) {
+	var buf bytes.Buffer
+	l := &slowQueryLog{threshold: time.Second, sample: 1, rate: 2, w: &buf}
+	now := time.Unix(100, 0)
+	for i := 0; i < 5; i++ {
+		l.record(&slowQuery{Query: "q", ReadTs: uint64(i)}, now)
+	}
+	l.record(&slowQuery{Query: "q", ReadTs: 5}, now.Add(time.Second))
+
+	entries := loggedQueries(t, &buf)
+	require.Len(t, entries, 3)
+	require.Equal(t, uint64(0), entries[0].ReadTs)
+	require.Equal(t, uint64(1), entries[1].ReadTs)
+	// The queries over the rate are counted in the next entry.
+	require.Equal(t, uint64(5), entries[2].ReadTs)
+	require.Equal(t, uint64(3), entries[2].Skipped)
+}
+
+func TestSlowQueryLogSample(t *testing.T) {
+	var buf bytes.Buffer
+	l := &slowQueryLog{threshold: time.Second, sample: 0, w: &buf}
+	for i := 0; i < 10; i++ {
+		l.record(&slowQuery{Query: "q"}, time.Unix(int64(i), 0))
+	}
+	require.Empty(t, buf.String())
+	require.Equal(t, uint64(10), l.skipped)
+
+	l.sample = 1
+	l.record(&slowQuery{Query: "q"}, time.Unix(20, 0))
+	entries := loggedQueries(t, &buf)
+	require.Len(t, entries, 1)
+	require.Equal(t, uint64(10), entries[0].Skipped)
+}
@@ -0,0 +1,56 @@
+/*
+ * Copyright 2018 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package edgraph
+
+import (
+	"github.com/dgraph-io/dgo/protos/api"
+	"github.com/dgraph-io/dgraph/protos/pb"
+	"golang.org/x/net/context"
+)
+
+// streamChunkSize is how many nodes of a block the responses of streamed queries are encoded at a
+// time.
+const streamChunkSize = 1000
+
+// StreamQuery runs the query in req like Query, but passes the JSON of the response to emit a
+// chunk at a time as it's encoded, instead of returning it in the response, so that Alpha doesn't
+// hold the whole of it. Only the encoding is streamed: the query is processed whole first. The
+// chunk is only valid until emit returns. The response of schema queries is returned whole, as
+// they're never large.
+func (s *Server) StreamQuery(ctx context.Context, req *api.Request,
+	emit func([]byte) error) (*api.Response, error) {
+	return s.query(ctx, req, emit)
+}
+
+// Streamer serves pb.Stream to clients.
+type Streamer struct {
+	Server
+}
+
+// Query runs the query in req, sending the JSON of the response a chunk at a time, and the rest of
+// the response in the last chunk.
+func (s *Streamer) Query(req *api.Request, stream pb.Stream_QueryServer) error {
+	resp, err := s.StreamQuery(stream.Context(), req, func(json []byte) error {
+		return stream.Send(&pb.QueryChunk{Json: json})
+	})
+	if err != nil {
+		return err
+	}
+	last := &pb.QueryChunk{Json: resp.Json, Response: resp}
+	resp.Json = nil
+	return stream.Send(last)
+}
@@ -0,0 +1,471 @@
+/*
+ * Copyright 2018 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package edgraph
+
+import (
+	"container/list"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/dgraph-io/dgo/protos/api"
+	"github.com/dgraph-io/dgraph/gql"
+	"github.com/dgraph-io/dgraph/protos/pb"
+	"github.com/dgraph-io/dgraph/query"
+	"github.com/dgraph-io/dgraph/schema"
+	"github.com/dgraph-io/dgraph/x"
+	otrace "go.opencensus.io/trace"
+	"golang.org/x/net/context"
+)
+
+// Upsert templates are stored in the graph itself, so they are replicated and persisted like any
+// other data and can be registered on one Alpha and invoked on any other.
+const templateSchema = `
+	dgraph.template.name: string @index(exact) @upsert .
+	dgraph.template.query: string .
+	dgraph.template.set: string .
+	dgraph.template.delete: string .
+`
+
+// UpsertTemplate is a query together with a mutation, registered under a name and invoked with
+// variables. The N-Quads in Set and Delete can refer to the uid variables defined in the query
+// via uid(v) and to the GraphQL variables via $name. If a uid variable is empty, uid(v) becomes
+// the blank node _:v in Set (creating a new node) and the N-Quad is skipped in Delete. GraphQL
+// variables are escaped for use within string literals.
+type UpsertTemplate struct {
+	Name   string `json:"name"`
+	Query  string `json:"query,omitempty"`
+	Set    string `json:"set,omitempty"`
+	Delete string `json:"delete,omitempty"`
+}
+
+var registerTemplate = compileBuiltin(&UpsertTemplate{
+	Name:  "dgraph.template.register",
+	Query: `query q($name: string) { t as var(func: eq(dgraph.template.name, $name)) }`,
+	Set: `uid(t) <dgraph.template.name> "$name" .
+		uid(t) <dgraph.template.query> "$query" .
+		uid(t) <dgraph.template.set> "$set" .
+		uid(t) <dgraph.template.delete> "$delete" .`,
+})
+
+var removeTemplate = compileBuiltin(&UpsertTemplate{
+	Name:   "dgraph.template.remove",
+	Query:  `query q($name: string) { t as var(func: eq(dgraph.template.name, $name)) }`,
+	Delete: `uid(t) * * .`,
+})
+
+var (
+	uidPlaceholder = regexp.MustCompile(`uid\(\s*([a-zA-Z_][a-zA-Z0-9_.]*)\s*\)`)
+	varPlaceholder = regexp.MustCompile(`\$[a-zA-Z_][a-zA-Z0-9_]*`)
+)
+
+// compiledNquads holds the lines of a mutation template along with the placeholders they use,
+// so they don't have to be parsed on every invocation.
+type compiledNquads []compiledLine
+
+type compiledLine struct {
+	text string
+	uids []string // Names of the uid variables used in this line.
+}
+
+// uidVars returns the names of the uid variables the lines use.
+func (c compiledNquads) uidVars() []string {
+	var res []string
+	for _, cl := range c {
+		res = append(res, cl.uids...)
+	}
+	return res
+}
+
+// compiledTemplate is a template with its query parsed and its mutations compiled, so that
+// invoking it again doesn't parse them again.
+type compiledTemplate struct {
+	*UpsertTemplate
+	query    *gql.Template // Nil if the template has no query.
+	set, del compiledNquads
+}
+
+func compileTemplate(t *UpsertTemplate) (*compiledTemplate, error) {
+	c := &compiledTemplate{
+		UpsertTemplate: t,
+		set:            parseNquadsTemplate(t.Set),
+		del:            parseNquadsTemplate(t.Delete),
+	}
+	if len(t.Query) > 0 {
+		q, err := gql.ParseTemplate(t.Query, append(c.set.uidVars(), c.del.uidVars()...)...)
+		if err != nil {
+			return nil, x.Wrapf(err, "while parsing query of template %q", t.Name)
+		}
+		c.query = q
+	}
+	return c, nil
+}
+
+// compileBuiltin compiles the templates Dgraph uses internally, which are known to be valid.
+func compileBuiltin(t *UpsertTemplate) *compiledTemplate {
+	c, err := compileTemplate(t)
+	x.Check(err)
+	return c
+}
+
+// maxCachedTemplates is how many registered templates an Alpha keeps compiled. The least
+// recently used ones are evicted over it.
+const maxCachedTemplates = 1000
+
+// templateCache holds the registered templates compiled, by namespace and name. Registering or
+// removing a template drops them all, right away on the Alpha doing it and on the others once
+// they learn about the commit.
+type templateCache struct {
+	sync.Mutex
+	ll      *list.List
+	entries map[string]*list.Element
+	// resets counts the times the templates were dropped, so that templates read before a reset
+	// aren't added after it.
+	resets uint64
+}
+
+type templateEntry struct {
+	key string
+	t   *compiledTemplate
+}
+
+var templates = &templateCache{ll: list.New(), entries: make(map[string]*list.Element)}
+
+func templateKey(ns, name string) string {
+	return ns + "\x00" + name
+}
+
+// generation must be called before reading a template, and passed to put along with it.
+func (c *templateCache) generation() uint64 {
+	c.Lock()
+	defer c.Unlock()
+	return c.resets
+}
+
+func (c *templateCache) get(key string) (*compiledTemplate, bool) {
+	c.Lock()
+	defer c.Unlock()
+	elem, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+	c.ll.MoveToFront(elem)
+	return elem.Value.(*templateEntry).t, true
+}
+
+func (c *templateCache) put(key string, t *compiledTemplate, gen uint64) {
+	c.Lock()
+	defer c.Unlock()
+	if gen != c.resets {
+		return
+	}
+	if elem, ok := c.entries[key]; ok {
+		c.ll.Remove(elem)
+	}
+	c.entries[key] = c.ll.PushFront(&templateEntry{key: key, t: t})
+	for c.ll.Len() > maxCachedTemplates {
+		elem := c.ll.Back()
+		c.ll.Remove(elem)
+		delete(c.entries, elem.Value.(*templateEntry).key)
+	}
+}
+
+func (c *templateCache) reset() {
+	c.Lock()
+	defer c.Unlock()
+	c.ll.Init()
+	c.entries = make(map[string]*list.Element)
+	c.resets++
+}
+
+// invalidate drops the templates if any of attrs stores templates, or if attrs is nil. It's
+// subscribed to the invalidations of the worker.
+func (c *templateCache) invalidate(attrs []string, commitTs uint64) {
+	if attrs == nil {
+		c.reset()
+		return
+	}
+	for _, attr := range attrs {
+		if _, attr = x.ParseNamespaceAttr(attr); strings.HasPrefix(attr, "dgraph.template.") {
+			c.reset()
+			return
+		}
+	}
+}
+
+// parseNquadsTemplate compiles the lines of nquads.
+func parseNquadsTemplate(nquads string) compiledNquads {
+	var c compiledNquads
+	for _, line := range strings.Split(nquads, "\n") {
+		line = strings.TrimSpace(line)
+		if len(line) == 0 {
+			continue
+		}
+		cl := compiledLine{text: line}
+		for _, m := range uidPlaceholder.FindAllStringSubmatch(line, -1) {
+			if !x.HasString(cl.uids, m[1]) {
+				cl.uids = append(cl.uids, m[1])
+			}
+		}
+		c = append(c, cl)
+	}
+	return c
+}
+
+// expand replaces the placeholders in the template. For lines using uid variables which hold
+// multiple uids, a line is generated for every combination of uids. If vars is nil, only the uid
+// variables are replaced.
+func (c compiledNquads) expand(uidVars map[string][]uint64, vars map[string]string,
+	isDelete bool) (string, error) {
+	var buf strings.Builder
+	for _, cl := range c {
+		lines := []string{cl.text}
+		for _, name := range cl.uids {
+			uids := uidVars[name]
+			if len(uids) == 0 && isDelete {
+				lines = nil
+				break
+			}
+			var next []string
+			for _, l := range lines {
+				replace := func(with string) string {
+					return uidPlaceholder.ReplaceAllStringFunc(l, func(s string) string {
+						if uidPlaceholder.FindStringSubmatch(s)[1] != name {
+							return s
+						}
+						return with
+					})
+				}
+				if len(uids) == 0 {
+					next = append(next, replace("_:"+name))
+					continue
+				}
+				for _, uid := range uids {
+					next = append(next, replace(fmt.Sprintf("<%#x>", uid)))
+				}
+			}
+			lines = next
+		}
+
+		// Substitute the GraphQL variables last, so that their values are never interpreted
+		// as placeholders themselves.
+		for _, l := range lines {
+			if vars == nil {
+				buf.WriteString(l)
+				buf.WriteByte('\n')
+				continue
+			}
+			var missing string
+			l = varPlaceholder.ReplaceAllStringFunc(l, func(v string) string {
+				val, ok := vars[v]
+				if !ok {
+					missing = v
+					return v
+				}
+				quoted := strconv.Quote(val)
+				return quoted[1 : len(quoted)-1]
+			})
+			if len(missing) > 0 {
+				return "", x.Errorf("Value for variable %s not provided", missing)
+			}
+			buf.WriteString(l)
+			buf.WriteByte('\n')
+		}
+	}
+	return buf.String(), nil
+}
+
+// runTemplate executes the query of the template and then applies its mutation in the same
+// transaction, committing immediately.
+func (s *Server) runTemplate(ctx context.Context, t *compiledTemplate,
+	vars map[string]string) (*api.Assigned, error) {
+	if vars == nil {
+		vars = map[string]string{}
+	}
+	startTs := State.getTimestamp(false)
+	uidVars := make(map[string][]uint64)
+	if t.query != nil {
+		// The other variables are only used by the mutations.
+		parsed, err := t.query.Bind(t.query.Declared(vars))
+		if err != nil {
+			return nil, err
+		}
+		var l query.Latency
+		qr := query.QueryRequest{Latency: &l, GqlQuery: &parsed, ReadTs: startTs}
+		if err := qr.ProcessQuery(ctx); err != nil {
+			return nil, x.Wrapf(err, "while running query of template %q", t.Name)
+		}
+		uidVars = qr.UidVars()
+	}
+
+	set, err := t.set.expand(uidVars, vars, false)
+	if err != nil {
+		return nil, err
+	}
+	del, err := t.del.expand(uidVars, vars, true)
+	if err != nil {
+		return nil, err
+	}
+	if len(set) == 0 && len(del) == 0 {
+		// Nothing to do, e.g. a delete template whose query didn't match anything.
+		return &api.Assigned{Context: &api.TxnContext{StartTs: startTs}}, nil
+	}
+	return s.Mutate(ctx, &api.Mutation{
+		SetNquads: []byte(set),
+		DelNquads: []byte(del),
+		StartTs:   startTs,
+		CommitNow: true,
+	})
+}
+
+// alterInternalSchema applies the schema of predicates used internally by Dgraph, such as the ones
+// storing templates and constraints.
+func (s *Server) alterInternalSchema(ctx context.Context, sch string) error {
+	updates, err := schema.Parse(sch)
+	if err != nil {
+		return err
+	}
+	m := &pb.Mutations{StartTs: State.getTimestamp(false), Schema: updates}
+	_, err = query.ApplyMutations(ctx, m)
+	return err
+}
+
+// Templates define how data gets written, so changing them requires the same permissions as Alter.
+func isTemplateChangeAllowed(ctx context.Context) error {
+	if !isMutationAllowed(ctx) {
+		return x.Errorf("No mutations allowed by server.")
+	}
+	// The token of a namespace allows changes within the namespace.
+	ctx, err := namespaceContext(ctx)
+	if err != nil {
+		return err
+	}
+	if len(x.NamespaceFromContext(ctx)) > 0 {
+		return nil
+	}
+	return isAlterAllowed(ctx)
+}
+
+// RegisterTemplate stores the template under its name, replacing any previous template with
+// the same name.
+func (s *Server) RegisterTemplate(ctx context.Context, t *UpsertTemplate) error {
+	ctx, span := otrace.StartSpan(ctx, "Server.RegisterTemplate")
+	defer span.End()
+
+	if err := isTemplateChangeAllowed(ctx); err != nil {
+		return err
+	}
+	if len(t.Name) == 0 {
+		return x.Errorf("Template must have a name")
+	}
+	if len(t.Set) == 0 && len(t.Delete) == 0 {
+		return x.Errorf("Template %q must have a set or delete mutation", t.Name)
+	}
+	if _, err := compileTemplate(t); err != nil {
+		return err
+	}
+	if err := s.alterInternalSchema(ctx, templateSchema); err != nil {
+		return err
+	}
+	_, err := s.runTemplate(ctx, registerTemplate, map[string]string{
+		"$name":   t.Name,
+		"$query":  t.Query,
+		"$set":    t.Set,
+		"$delete": t.Delete,
+	})
+	templates.reset()
+	return err
+}
+
+// RemoveTemplate deletes the template registered under name.
+func (s *Server) RemoveTemplate(ctx context.Context, name string) error {
+	if err := isTemplateChangeAllowed(ctx); err != nil {
+		return err
+	}
+	_, err := s.runTemplate(ctx, removeTemplate, map[string]string{"$name": name})
+	templates.reset()
+	return err
+}
+
+// GetTemplate returns the template registered under name, or nil if there is none.
+func (s *Server) GetTemplate(ctx context.Context, name string) (*UpsertTemplate, error) {
+	q := `query q($name: string) {
+		t(func: eq(dgraph.template.name, $name)) {
+			dgraph.template.name
+			dgraph.template.query
+			dgraph.template.set
+			dgraph.template.delete
+		}
+	}`
+	resp, err := s.Query(ctx, &api.Request{
+		Query:    q,
+		Vars:     map[string]string{"$name": name},
+		ReadOnly: true,
+	})
+	if err != nil {
+		return nil, err
+	}
+	var res struct {
+		T []struct {
+			Name   string `json:"dgraph.template.name"`
+			Query  string `json:"dgraph.template.query"`
+			Set    string `json:"dgraph.template.set"`
+			Delete string `json:"dgraph.template.delete"`
+		} `json:"t"`
+	}
+	if err := json.Unmarshal(resp.Json, &res); err != nil {
+		return nil, err
+	}
+	if len(res.T) == 0 {
+		return nil, nil
+	}
+	t := res.T[0]
+	return &UpsertTemplate{Name: t.Name, Query: t.Query, Set: t.Set, Delete: t.Delete}, nil
+}
+
+// Upsert invokes the template registered under name with the given variables.
+func (s *Server) Upsert(ctx context.Context, name string,
+	vars map[string]string) (*api.Assigned, error) {
+	ctx, span := otrace.StartSpan(ctx, "Server.Upsert")
+	defer span.End()
+
+	nsCtx, err := namespaceContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	key := templateKey(x.NamespaceFromContext(nsCtx), name)
+	c, ok := templates.get(key)
+	if !ok {
+		gen := templates.generation()
+		t, err := s.GetTemplate(ctx, name)
+		if err != nil {
+			return nil, err
+		}
+		if t == nil {
+			return nil, x.Errorf("No template registered with name %q", name)
+		}
+		if c, err = compileTemplate(t); err != nil {
+			return nil, err
+		}
+		templates.put(key, c, gen)
+	}
+	span.Annotatef(nil, "Running template: %+v", c.UpsertTemplate)
+	return s.runTemplate(ctx, c, vars)
+}
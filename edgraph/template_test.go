@@ -0,0 +1,116 @@
+/*
+ * Copyright 2018 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package edgraph
+
+import (
+	"container/list"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dgraph-io/dgraph/x"
+)
+
+func TestExpandTemplateNewNode(t *testing.T) {
+	c := parseNquadsTemplate(`uid(u) <email> "$email" .
+		uid(u) <name> "$name" .`)
+	vars := map[string]string{"$email": "a@b.com", "$name": `Alice "Al" Smith`}
+
+	set, err := c.expand(map[string][]uint64{}, vars, false)
+	require.NoError(t, err)
+	require.Equal(t, `_:u <email> "a@b.com" .
+_:u <name> "Alice \"Al\" Smith" .
+`, set)
+
+	del, err := c.expand(map[string][]uint64{}, vars, true)
+	require.NoError(t, err)
+	require.Empty(t, del)
+}
+
+func TestExpandTemplateExistingNodes(t *testing.T) {
+	c := parseNquadsTemplate(`uid(u) <follows> uid(v) .`)
+	uids := map[string][]uint64{"u": {0x1, 0x2}, "v": {0xa}}
+
+	set, err := c.expand(uids, nil, false)
+	require.NoError(t, err)
+	require.Equal(t, "<0x1> <follows> <0xa> .\n<0x2> <follows> <0xa> .\n", set)
+}
+
+func TestExpandTemplateMissingVar(t *testing.T) {
+	c := parseNquadsTemplate(`uid(u) <name> "$name" .`)
+	_, err := c.expand(nil, map[string]string{"$other": "x"}, false)
+	require.Error(t, err)
+}
+
+func TestExpandTemplateVarsNotExpanded(t *testing.T) {
+	// Values of variables must not be treated as placeholders themselves.
+	set, err := registerTemplate.set.expand(nil, map[string]string{
+		"$name":   "t",
+		"$query":  "",
+		"$set":    `uid(x) <name> "$name" .`,
+		"$delete": "",
+	}, false)
+	require.NoError(t, err)
+	require.Contains(t, set, `_:t <dgraph.template.set> "uid(x) <name> \"$name\" ." .`)
+}
+
+func TestBuiltinTemplatesBind(t *testing.T) {
+	// As run by runTemplate, with the variables of the mutations, and the uid variable of the
+	// query only used by them.
+	vars := map[string]string{"$name": "t", "$query": "", "$set": "", "$delete": ""}
+	for _, c := range []*compiledTemplate{registerTemplate, removeTemplate} {
+		_, err := c.query.Bind(c.query.Declared(vars))
+		require.NoError(t, err, c.Name)
+	}
+}
+
+func TestTemplateCache(t *testing.T) {
+	c := &templateCache{ll: list.New(), entries: make(map[string]*list.Element)}
+	tmpl := compileBuiltin(&UpsertTemplate{Name: "t", Set: `uid(u) <name> "$name" .`})
+	key := templateKey("ns1", "t")
+	c.put(key, tmpl, c.generation())
+	got, ok := c.get(key)
+	require.True(t, ok)
+	require.Equal(t, tmpl, got)
+	_, ok = c.get(templateKey("", "t"))
+	require.False(t, ok)
+
+	// Commits to other predicates keep the templates, those to templates drop them.
+	c.invalidate([]string{"name"}, 10)
+	_, ok = c.get(key)
+	require.True(t, ok)
+	c.invalidate([]string{x.NamespaceAttr("ns1", "dgraph.template.set")}, 11)
+	_, ok = c.get(key)
+	require.False(t, ok)
+
+	// Templates read before a reset aren't added after it.
+	gen := c.generation()
+	c.reset()
+	c.put(key, tmpl, gen)
+	_, ok = c.get(key)
+	require.False(t, ok)
+
+	for i := 0; i <= maxCachedTemplates; i++ {
+		c.put(templateKey("", fmt.Sprint(i)), tmpl, c.generation())
+	}
+	require.Len(t, c.entries, maxCachedTemplates)
+	_, ok = c.get(templateKey("", "0"))
+	require.False(t, ok)
+	_, ok = c.get(templateKey("", fmt.Sprint(maxCachedTemplates)))
+	require.True(t, ok)
+}
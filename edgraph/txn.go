@@ -0,0 +1,193 @@
+/*
+ * Copyright 2018 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package edgraph
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/dgraph-io/dgo/protos/api"
+	"github.com/dgraph-io/dgraph/gql"
+	"github.com/dgraph-io/dgraph/protos/pb"
+	"github.com/dgraph-io/dgraph/query"
+	"github.com/dgraph-io/dgraph/worker"
+	"github.com/dgraph-io/dgraph/x"
+	otrace "go.opencensus.io/trace"
+	"golang.org/x/net/context"
+)
+
+// RunTxn runs the queries and mutations of a transaction one after the other, in the order they
+// are given, and then commits it if asked to, so that simple transactions take a single round
+// trip instead of one per operation and one for the commit. Every operation sees the writes of
+// the ones before it. As in upsert templates, uid(v) in the N-Quads of a mutation refers to the
+// uids of the variable v defined by the queries before it, or to the new node _:v if there are
+// none, which allows for upserts without going back and forth with the client.
+//
+// If an operation fails, the transaction is aborted if it was to be committed. Otherwise, it's
+// left to the client, which gets the keys written so far along with the error.
+func (s *Server) RunTxn(ctx context.Context, req *pb.TxnRequest) (resp *pb.TxnResponse,
+	err error) {
+	ctx, span := otrace.StartSpan(ctx, "Server.RunTxn")
+	defer span.End()
+
+	resp = &pb.TxnResponse{}
+	if err := x.HealthCheck(); err != nil {
+		return resp, err
+	}
+	if ctx, err = namespaceContext(ctx); err != nil {
+		return resp, err
+	}
+	if len(req.Operations) == 0 {
+		return resp, x.Errorf("Transaction must have at least one operation")
+	}
+
+	txn := &api.TxnContext{StartTs: req.StartTs}
+	if txn.StartTs == 0 {
+		txn.StartTs = State.getTimestamp(false)
+	}
+	annotateStartTs(span, txn.StartTs)
+	resp.Txn = txn
+
+	uidVars := make(map[string][]uint64)
+	var mutated bool
+	for i, op := range req.Operations {
+		if err := validateTxnOperation(op, txn.StartTs); err != nil {
+			return resp, x.Wrapf(err, "while checking operation %d", i)
+		}
+		res := &pb.TxnOperationResult{}
+		if op.Query != nil {
+			var vars map[string][]uint64
+			res.Query, vars, err = s.txnQuery(ctx, op.Query, txn.StartTs)
+			for name, uids := range vars {
+				uidVars[name] = uids
+			}
+		} else {
+			res.Mutation, err = s.txnMutate(ctx, op.Mutation, txn.StartTs, uidVars)
+			if res.Mutation != nil && res.Mutation.Context != nil {
+				txn.Keys = append(txn.Keys, res.Mutation.Context.Keys...)
+				res.Mutation.Context.Keys = nil
+			}
+			mutated = true
+		}
+		if err != nil {
+			span.Annotatef(nil, "Operation %d failed: %v", i, err)
+			if req.CommitNow {
+				txn.Aborted = true
+				_, _ = worker.CommitOverNetwork(ctx, &api.TxnContext{StartTs: txn.StartTs,
+					Aborted: true})
+				TxnDone(txn.StartTs)
+			}
+			return resp, err
+		}
+		resp.Results = append(resp.Results, res)
+	}
+
+	if !req.CommitNow || !mutated {
+		return resp, nil
+	}
+	tc, err := s.CommitOrAbort(ctx, &api.TxnContext{StartTs: txn.StartTs, Keys: txn.Keys})
+	txn.Aborted = tc.Aborted
+	if err != nil {
+		return resp, err
+	}
+	txn.CommitTs = tc.CommitTs
+	// Committed, no need to send the keys.
+	txn.Keys = txn.Keys[:0]
+	return resp, nil
+}
+
+func validateTxnOperation(op *pb.TxnOperation, startTs uint64) error {
+	if (op.Query == nil) == (op.Mutation == nil) {
+		return x.Errorf("Operation must have either a query or a mutation")
+	}
+	if op.Query != nil && op.Query.StartTs != 0 && op.Query.StartTs != startTs {
+		return x.Errorf("Query has start ts %d, the transaction %d", op.Query.StartTs, startTs)
+	}
+	if op.Mutation != nil {
+		if op.Mutation.StartTs != 0 && op.Mutation.StartTs != startTs {
+			return x.Errorf("Mutation has start ts %d, the transaction %d",
+				op.Mutation.StartTs, startTs)
+		}
+		if op.Mutation.CommitNow {
+			return x.Errorf("Mutations can't commit on their own. Set commit_now of the request.")
+		}
+	}
+	return nil
+}
+
+// txnQuery runs a query of a transaction, returning the uid variables it defined along with its
+// response.
+func (s *Server) txnQuery(ctx context.Context, req *api.Request,
+	startTs uint64) (*api.Response, map[string][]uint64, error) {
+	if len(req.Query) == 0 {
+		return nil, nil, fmt.Errorf("empty query")
+	}
+	var l query.Latency
+	l.Start = time.Now()
+	parsed, err := gql.Parse(gql.Request{Str: req.Query, Variables: req.Vars})
+	if err != nil {
+		return nil, nil, err
+	}
+	qr := query.QueryRequest{Latency: &l, GqlQuery: &parsed, ReadTs: startTs}
+	er, err := qr.Process(ctx)
+	if err != nil {
+		return nil, nil, x.Wrap(err)
+	}
+	json, err := query.ToJson(&l, er.Subgraphs)
+	if err != nil {
+		return nil, nil, err
+	}
+	return &api.Response{
+		Json:   json,
+		Schema: er.SchemaNode,
+		Txn:    &api.TxnContext{StartTs: startTs},
+		Latency: &api.Latency{
+			ParsingNs:    uint64(l.Parsing.Nanoseconds()),
+			ProcessingNs: uint64(l.Processing.Nanoseconds()),
+			EncodingNs:   uint64(l.Json.Nanoseconds()),
+		},
+	}, qr.UidVars(), nil
+}
+
+// txnMutate runs a mutation of a transaction, after replacing the uid variables in its N-Quads.
+func (s *Server) txnMutate(ctx context.Context, mu *api.Mutation, startTs uint64,
+	uidVars map[string][]uint64) (*api.Assigned, error) {
+	m := *mu
+	m.StartTs = startTs
+	var err error
+	if m.SetNquads, err = expandUidVars(m.SetNquads, uidVars, false); err != nil {
+		return nil, err
+	}
+	if m.DelNquads, err = expandUidVars(m.DelNquads, uidVars, true); err != nil {
+		return nil, err
+	}
+	hadNquads := len(mu.SetNquads) > 0 || len(mu.DelNquads) > 0
+	if hadNquads && len(m.SetNquads) == 0 && len(m.DelNquads) == 0 &&
+		len(m.SetJson) == 0 && len(m.DeleteJson) == 0 && len(m.Set) == 0 && len(m.Del) == 0 {
+		// Nothing left to do, e.g. deleting the nodes of a variable which is empty.
+		return &api.Assigned{Context: &api.TxnContext{StartTs: startTs}}, nil
+	}
+	return s.Mutate(ctx, &m)
+}
+
+func expandUidVars(nquads []byte, uidVars map[string][]uint64, isDelete bool) ([]byte, error) {
+	if !uidPlaceholder.Match(nquads) {
+		return nquads, nil
+	}
+	out, err := parseNquadsTemplate(string(nquads)).expand(uidVars, nil, isDelete)
+	return []byte(out), err
+}
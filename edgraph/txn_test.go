@@ -0,0 +1,64 @@
+/*
+ * Copyright 2018 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package edgraph
+
+import (
+	"testing"
+
+	"github.com/dgraph-io/dgo/protos/api"
+	"github.com/dgraph-io/dgraph/protos/pb"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateTxnOperation(t *testing.T) {
+	q := &api.Request{Query: "{ q(func: uid(1)) { uid } }"}
+	mu := &api.Mutation{SetNquads: []byte(`_:a <name> "a" .`)}
+	require.NoError(t, validateTxnOperation(&pb.TxnOperation{Query: q}, 10))
+	require.NoError(t, validateTxnOperation(&pb.TxnOperation{Mutation: mu}, 10))
+
+	for _, op := range []*pb.TxnOperation{
+		{},
+		{Query: q, Mutation: mu},
+		{Query: &api.Request{Query: q.Query, StartTs: 5}},
+		{Mutation: &api.Mutation{SetNquads: mu.SetNquads, StartTs: 5}},
+		{Mutation: &api.Mutation{SetNquads: mu.SetNquads, CommitNow: true}},
+	} {
+		require.Error(t, validateTxnOperation(op, 10), "%+v", op)
+	}
+}
+
+func TestExpandUidVars(t *testing.T) {
+	uids := map[string][]uint64{"u": {0x1}, "empty": nil}
+
+	// N-Quads without variables are left as they are, GraphQL variables included.
+	nquads := []byte(`_:a <name> "$name" .`)
+	out, err := expandUidVars(nquads, uids, false)
+	require.NoError(t, err)
+	require.Equal(t, string(nquads), string(out))
+
+	out, err = expandUidVars([]byte(`uid(u) <name> "$name" .`), uids, false)
+	require.NoError(t, err)
+	require.Equal(t, "<0x1> <name> \"$name\" .\n", string(out))
+
+	out, err = expandUidVars([]byte(`uid(empty) <name> "a" .`), uids, false)
+	require.NoError(t, err)
+	require.Equal(t, "_:empty <name> \"a\" .\n", string(out))
+
+	out, err = expandUidVars([]byte(`uid(empty) * * .`), uids, true)
+	require.NoError(t, err)
+	require.Empty(t, out)
+}
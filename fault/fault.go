@@ -0,0 +1,59 @@
+/*
+ * Copyright 2018 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package fault injects faults into Alphas and Zeros, like lost RPCs or crashes at a given point,
+// so that consistency bugs can be reproduced in tests. It's only built in with the faults build
+// tag, as in go build -tags faults. Otherwise, its hooks do nothing, and Enabled is false so that
+// the code preparing their calls is compiled out.
+//
+// Faults are injected by rules, set through /admin/faults. A rule applies to the operations at
+// one of the points below whose labels match it, like the RPCs to a given address.
+package fault
+
+// Action is what the caller of Inject has to do about the fault injected. Inject delays and
+// crashes by itself.
+type Action int
+
+const (
+	// None means to go on.
+	None Action = iota
+	// Drop means to fail the operation, as if it was lost.
+	Drop
+	// Duplicate means to do the operation twice.
+	Duplicate
+)
+
+// The points faults can be injected at.
+const (
+	// RPC is every outgoing gRPC call, labelled with method=<full method> and addr=<target>. The
+	// calls can be dropped, delayed or duplicated. Streams can only be dropped or delayed.
+	RPC = "rpc"
+	// WALSave and WALSaved are before and after the Raft entries and state are written to the
+	// WAL, labelled with group=<id>. They can be delayed, or crash the process.
+	WALSave  = "wal.save"
+	WALSaved = "wal.saved"
+	// ApplyBefore and ApplyAfter are before and after a committed proposal is applied, labelled
+	// with group=<id>, kind=<what it proposes> and key=<key of the proposal>. They can be
+	// delayed, or crash the process.
+	ApplyBefore = "apply.before"
+	ApplyAfter  = "apply.after"
+	// Clock is the clock used for the expiry of transactions and values, and for the history of
+	// the data. It can be skewed.
+	Clock = "clock"
+)
+
+// CrashCode is the exit status of an injected crash.
+const CrashCode = 3
@@ -0,0 +1,324 @@
+// +build faults
+
+/*
+ * Copyright 2018 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package fault
+
+import (
+	"fmt"
+	"math/rand"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/golang/glog"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/dgraph-io/dgraph/x"
+)
+
+// Enabled tells whether faults can be injected, in builds with the faults tag.
+const Enabled = true
+
+// Rule injects a fault into the operations at Point matching it.
+type Rule struct {
+	ID    int    `json:"id"`
+	Point string `json:"point"`
+	// Match has comma separated conditions, like method=/pb.Raft/RaftMessage,addr=alpha2:7080,
+	// which must all be part of a label of an operation for the rule to apply to it. Empty
+	// matches all the operations.
+	Match string `json:"match,omitempty"`
+	// Action is drop, delay, duplicate or crash, or skew for Clock.
+	Action string `json:"action"`
+	// Duration is how long to delay, or to skew the clock by, like 500ms or -1m.
+	Duration string `json:"duration,omitempty"`
+	// Probability is the chance of the rule applying to a matching operation, 1 if zero. The
+	// choices are made with Seed, so that they're the same every time.
+	Probability float64 `json:"probability,omitempty"`
+	Seed        int64   `json:"seed,omitempty"`
+	// After is how many matching operations are let through before the rule applies, and Count
+	// how many times it applies then. Zero means forever.
+	After int `json:"after,omitempty"`
+	Count int `json:"count,omitempty"`
+	// Fired is how many times the rule applied.
+	Fired int `json:"fired"`
+
+	seen  int
+	delay time.Duration
+	conds []string
+	rng   *rand.Rand
+}
+
+// actions has the actions possible at every point.
+var actions = map[string][]string{
+	RPC:         {"drop", "delay", "duplicate"},
+	WALSave:     {"delay", "crash"},
+	WALSaved:    {"delay", "crash"},
+	ApplyBefore: {"delay", "crash"},
+	ApplyAfter:  {"delay", "crash"},
+	Clock:       {"skew"},
+}
+
+func (r *Rule) init() error {
+	allowed, ok := actions[r.Point]
+	if !ok {
+		return x.Errorf("Unknown point %q, must be one of rpc, wal.save, wal.saved,"+
+			" apply.before, apply.after and clock", r.Point)
+	}
+	if !x.HasString(allowed, r.Action) {
+		return x.Errorf("Action %q isn't possible at %s, which takes %s", r.Action, r.Point,
+			strings.Join(allowed, ", "))
+	}
+	if r.Action == "delay" || r.Action == "skew" {
+		d, err := time.ParseDuration(r.Duration)
+		if err != nil {
+			return x.Wrapf(err, "Invalid duration %q", r.Duration)
+		}
+		r.delay = d
+	}
+	if r.Probability < 0 || r.Probability > 1 {
+		return x.Errorf("Probability must be between 0 and 1, not %v", r.Probability)
+	}
+	if r.After < 0 || r.Count < 0 {
+		return x.Errorf("After and count can't be negative")
+	}
+	for _, cond := range strings.Split(r.Match, ",") {
+		if cond = strings.TrimSpace(cond); len(cond) > 0 {
+			r.conds = append(r.conds, cond)
+		}
+	}
+	r.rng = rand.New(rand.NewSource(r.Seed))
+	return nil
+}
+
+func (r *Rule) matches(labels []string) bool {
+	for _, cond := range r.conds {
+		var found bool
+		for _, l := range labels {
+			if strings.Contains(l, cond) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// fire tells whether the rule applies to the matching operation seen.
+func (r *Rule) fire() bool {
+	r.seen++
+	switch {
+	case r.seen <= r.After:
+		return false
+	case r.Count > 0 && r.Fired >= r.Count:
+		return false
+	case r.Probability > 0 && r.rng.Float64() >= r.Probability:
+		return false
+	}
+	r.Fired++
+	return true
+}
+
+type registry struct {
+	sync.Mutex
+	rules  []*Rule
+	nextID int
+	active int32 // Number of rules, so that Inject doesn't lock when there's none.
+	skew   int64 // Sum of the skews of the clock, as a time.Duration.
+}
+
+var faults registry
+
+func (f *registry) add(r *Rule) (Rule, error) {
+	if err := r.init(); err != nil {
+		return Rule{}, err
+	}
+	f.Lock()
+	defer f.Unlock()
+	f.nextID++
+	r.ID = f.nextID
+	f.rules = append(f.rules, r)
+	f.updateLocked()
+	glog.Warningf("Fault injection rule %d added: %s %s %q", r.ID, r.Point, r.Action, r.Match)
+	return *r, nil
+}
+
+// remove removes the rule with id, or all the rules if id is zero.
+func (f *registry) remove(id int) error {
+	f.Lock()
+	defer f.Unlock()
+	if id == 0 {
+		f.rules = nil
+		f.updateLocked()
+		glog.Warningf("Fault injection rules all removed")
+		return nil
+	}
+	for i, r := range f.rules {
+		if r.ID == id {
+			f.rules = append(f.rules[:i], f.rules[i+1:]...)
+			f.updateLocked()
+			glog.Warningf("Fault injection rule %d removed", id)
+			return nil
+		}
+	}
+	return x.Errorf("No rule with id %d", id)
+}
+
+func (f *registry) updateLocked() {
+	var skew time.Duration
+	for _, r := range f.rules {
+		if r.Point == Clock {
+			skew += r.delay
+		}
+	}
+	atomic.StoreInt64(&f.skew, int64(skew))
+	atomic.StoreInt32(&f.active, int32(len(f.rules)))
+}
+
+func (f *registry) list() []Rule {
+	f.Lock()
+	defer f.Unlock()
+	res := make([]Rule, 0, len(f.rules))
+	for _, r := range f.rules {
+		res = append(res, *r)
+	}
+	return res
+}
+
+// Inject injects the fault of the first rule matching an operation at point, with labels.
+func Inject(point string, labels ...string) Action {
+	if atomic.LoadInt32(&faults.active) == 0 {
+		return None
+	}
+	faults.Lock()
+	var fired *Rule
+	for _, r := range faults.rules {
+		if r.Point == point && r.matches(labels) && r.fire() {
+			fired = r
+			break
+		}
+	}
+	var action string
+	var delay time.Duration
+	if fired != nil {
+		action, delay = fired.Action, fired.delay
+	}
+	faults.Unlock()
+	if fired == nil {
+		return None
+	}
+
+	glog.V(2).Infof("Fault injected by rule %d at %s %v: %s", fired.ID, point, labels, action)
+	switch action {
+	case "delay":
+		time.Sleep(delay)
+	case "crash":
+		glog.Errorf("Fault injected by rule %d: crashing at %s %v", fired.ID, point, labels)
+		glog.Flush()
+		os.Exit(CrashCode)
+	case "drop":
+		return Drop
+	case "duplicate":
+		return Duplicate
+	}
+	return None
+}
+
+// Now returns the current time, skewed by the rules for Clock.
+func Now() time.Time {
+	return time.Now().Add(time.Duration(atomic.LoadInt64(&faults.skew)))
+}
+
+// DialOptions returns the options injecting faults into the calls of gRPC connections.
+func DialOptions() []grpc.DialOption {
+	return []grpc.DialOption{
+		grpc.WithUnaryInterceptor(unaryInterceptor),
+		grpc.WithStreamInterceptor(streamInterceptor),
+	}
+}
+
+func dropped(method, addr string) error {
+	return status.Errorf(codes.Unavailable, "Fault injected: dropped %s to %s", method, addr)
+}
+
+func unaryInterceptor(ctx context.Context, method string, req, reply interface{},
+	cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+	switch Inject(RPC, "method="+method, "addr="+cc.Target()) {
+	case Drop:
+		return dropped(method, cc.Target())
+	case Duplicate:
+		if err := invoker(ctx, method, req, reply, cc, opts...); err != nil {
+			return err
+		}
+	}
+	return invoker(ctx, method, req, reply, cc, opts...)
+}
+
+func streamInterceptor(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn,
+	method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+	if Inject(RPC, "method="+method, "addr="+cc.Target()) == Drop {
+		return nil, dropped(method, cc.Target())
+	}
+	return streamer(ctx, desc, cc, method, opts...)
+}
+
+// Handler lists (GET), adds (POST) and removes (DELETE) the rules injecting faults. A POST takes a
+// Rule as JSON, and a DELETE the id of the rule to remove, or removes them all without one.
+func Handler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	switch r.Method {
+	case http.MethodGet:
+		x.Reply(w, faults.list())
+	case http.MethodPost:
+		var rule Rule
+		if !x.ParseRequest(w, r, &rule) {
+			return
+		}
+		added, err := faults.add(&rule)
+		if err != nil {
+			x.SetStatus(w, x.ErrorInvalidRequest, err.Error())
+			return
+		}
+		x.Reply(w, added)
+	case http.MethodDelete:
+		var id int
+		if s := r.URL.Query().Get("id"); len(s) > 0 {
+			var err error
+			if id, err = strconv.Atoi(s); err != nil || id <= 0 {
+				x.SetStatus(w, x.ErrorInvalidRequest, fmt.Sprintf("Invalid id %q", s))
+				return
+			}
+		}
+		if err := faults.remove(id); err != nil {
+			x.SetStatus(w, x.ErrorInvalidRequest, err.Error())
+			return
+		}
+		x.Reply(w, faults.list())
+	default:
+		x.SetStatus(w, x.ErrorInvalidMethod, "Invalid method")
+	}
+}
@@ -0,0 +1,136 @@
+// +build faults
+
+/*
+ * Copyright 2018 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package fault
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestInject(t *testing.T) {
+	defer faults.remove(0)
+	require.Equal(t, None, Inject(RPC, "method=/pb.Raft/RaftMessage"))
+
+	_, err := faults.add(&Rule{Point: RPC, Match: "method=/pb.Raft/,addr=alpha2",
+		Action: "drop", After: 1, Count: 2})
+	require.NoError(t, err)
+	_, err = faults.add(&Rule{Point: RPC, Action: "duplicate"})
+	require.NoError(t, err)
+
+	raft := []string{"method=/pb.Raft/RaftMessage", "addr=alpha2:7080"}
+	require.Equal(t, Duplicate, Inject(RPC, raft...))
+	require.Equal(t, Drop, Inject(RPC, raft...))
+	require.Equal(t, Drop, Inject(RPC, raft...))
+	require.Equal(t, Duplicate, Inject(RPC, raft...))
+	require.Equal(t, Duplicate, Inject(RPC, "method=/pb.Raft/RaftMessage", "addr=alpha3:7080"))
+	require.Equal(t, None, Inject(WALSave, raft...))
+
+	rules := faults.list()
+	require.Len(t, rules, 2)
+	require.Equal(t, 2, rules[0].Fired)
+	require.Equal(t, 3, rules[1].Fired)
+
+	require.NoError(t, faults.remove(rules[1].ID))
+	require.Error(t, faults.remove(rules[1].ID))
+	require.Equal(t, None, Inject(RPC, raft...))
+}
+
+func TestInjectProbability(t *testing.T) {
+	defer faults.remove(0)
+	fired := func() []bool {
+		_, err := faults.add(&Rule{Point: ApplyBefore, Action: "delay", Duration: "1ns",
+			Probability: 0.5, Seed: 7})
+		require.NoError(t, err)
+		defer faults.remove(0)
+		var res []bool
+		for i := 0; i < 20; i++ {
+			Inject(ApplyBefore, "kind=mutations")
+			res = append(res, faults.list()[0].Fired > len(trues(res)))
+		}
+		return res
+	}
+	first := fired()
+	require.Equal(t, first, fired())
+	require.NotEmpty(t, trues(first))
+	require.True(t, len(trues(first)) < len(first))
+}
+
+func trues(bs []bool) []bool {
+	var res []bool
+	for _, b := range bs {
+		if b {
+			res = append(res, b)
+		}
+	}
+	return res
+}
+
+func TestRuleInit(t *testing.T) {
+	for _, r := range []Rule{
+		{Point: "disk", Action: "drop"},
+		{Point: RPC, Action: "crash"},
+		{Point: RPC, Action: "delay", Duration: "soon"},
+		{Point: Clock, Action: "skew"},
+		{Point: RPC, Action: "drop", Probability: 2},
+	} {
+		require.Error(t, r.init(), "%+v", r)
+	}
+}
+
+func TestClockSkew(t *testing.T) {
+	defer faults.remove(0)
+	_, err := faults.add(&Rule{Point: Clock, Action: "skew", Duration: "-1h"})
+	require.NoError(t, err)
+	require.InDelta(t, float64(time.Now().Add(-time.Hour).Unix()), float64(Now().Unix()), 1)
+	faults.remove(0)
+	require.InDelta(t, float64(time.Now().Unix()), float64(Now().Unix()), 1)
+}
+
+func TestHandler(t *testing.T) {
+	defer faults.remove(0)
+	serve := func(method, url, body string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		Handler(w, httptest.NewRequest(method, url, strings.NewReader(body)))
+		return w
+	}
+	w := serve(http.MethodPost, "/admin/faults",
+		`{"point": "rpc", "match": "addr=zero1", "action": "delay", "duration": "10ms"}`)
+	var rule Rule
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &rule))
+	require.Equal(t, "addr=zero1", rule.Match)
+	require.True(t, rule.ID > 0)
+
+	w = serve(http.MethodPost, "/admin/faults", `{"point": "rpc", "action": "skew"}`)
+	require.Contains(t, w.Body.String(), "errors")
+
+	var rules []Rule
+	require.NoError(t, json.Unmarshal(serve(http.MethodGet, "/admin/faults", "").Body.Bytes(),
+		&rules))
+	require.Len(t, rules, 1)
+
+	require.NoError(t, json.Unmarshal(serve(http.MethodDelete, "/admin/faults", "").Body.Bytes(),
+		&rules))
+	require.Empty(t, rules)
+}
@@ -0,0 +1,46 @@
+// +build !faults
+
+/*
+ * Copyright 2018 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package fault
+
+import (
+	"net/http"
+	"time"
+
+	"google.golang.org/grpc"
+
+	"github.com/dgraph-io/dgraph/x"
+)
+
+// Enabled tells whether faults can be injected, in builds with the faults tag.
+const Enabled = false
+
+// Inject injects the fault of the first rule matching an operation at point, with labels.
+func Inject(point string, labels ...string) Action { return None }
+
+// Now returns the current time, skewed by the rules for Clock.
+func Now() time.Time { return time.Now() }
+
+// DialOptions returns the options injecting faults into the calls of gRPC connections.
+func DialOptions() []grpc.DialOption { return nil }
+
+// Handler lists (GET), adds (POST) and removes (DELETE) the rules injecting faults.
+func Handler(w http.ResponseWriter, r *http.Request) {
+	x.SetStatus(w, x.ErrorInvalidRequest,
+		"Faults can't be injected, this binary wasn't built with the faults tag")
+}
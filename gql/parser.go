@@ -60,6 +60,7 @@ type GraphQuery struct {
 	RecurseArgs  RecurseArgs
 	Cascade      bool
 	IgnoreReflex bool
+	Hints        QueryHints
 	Facets       *pb.FacetParams
 	FacetsFilter *FilterTree
 	GroupbyAttrs []GroupByAttr
@@ -85,6 +86,18 @@ type GraphQuery struct {
 type RecurseArgs struct {
 	Depth     uint64
 	AllowLoop bool
+	MaxNodes  uint64 // Distinct nodes which can be reached besides the root nodes, 0 if unlimited.
+	Annotate  bool   // Report why the traversal stopped along with the results.
+}
+
+// QueryHints are set via the @hint directive of a query block, to override the decisions taken
+// by default while processing it. They apply to the whole block.
+type QueryHints struct {
+	UseIndex       string // Tokenizer to use for comparison functions and sorting.
+	NoParallel     bool   // Process the children and filters one after the other.
+	OrderedFilters bool   // Run the filters joined by and in order, each on the previous results.
+	NoValueScan    bool   // Use the index, even if comparing the values would be faster.
+	Sort           string // Sort using either the "index" or the "values".
 }
 
 type GroupByAttr struct {
@@ -459,9 +472,19 @@ type Result struct {
 // Parse initializes and runs the lexer. It also constructs the GraphQuery subgraph
 // from the lexed items.
 func Parse(r Request) (res Result, rerr error) {
-	query := r.Str
 	vmap := convertToVarMap(r.Variables)
+	if res, rerr = parse(r.Str, vmap, true); rerr != nil {
+		return res, rerr
+	}
+	if rerr = res.substitute(vmap, nil); rerr != nil {
+		return res, rerr
+	}
+	return res, nil
+}
 
+// parse parses the query, filling vmap with the types and default values of the variables it
+// declares. The values of the variables are checked if check is true.
+func parse(query string, vmap varMap, check bool) (res Result, rerr error) {
 	lexer := lex.Lexer{Input: query}
 	lexer.Run(lexTopLevel)
 
@@ -499,7 +522,7 @@ func Parse(r Request) (res Result, rerr error) {
 				if res.Schema != nil {
 					return res, x.Errorf("schema block is not allowed with query block")
 				}
-				if qu, rerr = getVariablesAndQuery(it, vmap); rerr != nil {
+				if qu, rerr = getVariablesAndQuery(it, vmap, check); rerr != nil {
 					return res, rerr
 				}
 				res.Query = append(res.Query, qu)
@@ -518,18 +541,25 @@ func Parse(r Request) (res Result, rerr error) {
 		}
 	}
 
+	for _, qu := range res.Query {
+		// Try expanding fragments using fragment map.
+		if err := qu.expandFragments(fmap); err != nil {
+			return res, err
+		}
+	}
+	return res, nil
+}
+
+// substitute replaces the variables of the queries with their values in vmap, and checks the
+// variables the queries define and use. The uid variables in uses count as used, if defined.
+func (res *Result) substitute(vmap varMap, uses []string) error {
 	if len(res.Query) != 0 {
 		res.QueryVars = make([]*Vars, 0, len(res.Query))
 		for i := 0; i < len(res.Query); i++ {
 			qu := res.Query[i]
-			// Try expanding fragments using fragment map.
-			if err := qu.expandFragments(fmap); err != nil {
-				return res, err
-			}
-
 			// Substitute all variables with corresponding values
 			if err := substituteVariables(qu, vmap); err != nil {
-				return res, err
+				return err
 			}
 
 			res.QueryVars = append(res.QueryVars, &Vars{})
@@ -538,16 +568,22 @@ func Parse(r Request) (res Result, rerr error) {
 		}
 
 		allVars := res.QueryVars
+		if len(uses) > 0 {
+			_, defines := flatten(allVars)
+			outside := &Vars{}
+			for _, v := range uses {
+				if x.HasString(defines, v) {
+					outside.Needs = append(outside.Needs, v)
+				}
+			}
+			allVars = append(allVars[:len(allVars):len(allVars)], outside)
+		}
 		if err := checkDependency(allVars); err != nil {
-			return res, err
+			return err
 		}
 	}
 
-	if err := validateResult(&res); err != nil {
-		return res, err
-	}
-
-	return res, nil
+	return validateResult(res)
 }
 
 func validateResult(res *Result) error {
@@ -666,7 +702,8 @@ func (f *FilterTree) hasVars() bool {
 // getVariablesAndQuery checks if the query has a variable list and stores it in
 // vmap. For variable list to be present, the query should have a name which is
 // also checked for. It also calls getQuery to create the GraphQuery object tree.
-func getVariablesAndQuery(it *lex.ItemIterator, vmap varMap) (gq *GraphQuery, rerr error) {
+func getVariablesAndQuery(it *lex.ItemIterator, vmap varMap,
+	check bool) (gq *GraphQuery, rerr error) {
 	var name string
 L2:
 	for it.Next() {
@@ -688,6 +725,9 @@ L2:
 				return nil, rerr
 			}
 
+			if !check {
+				break
+			}
 			if rerr = checkValueType(vmap); rerr != nil {
 				return nil, rerr
 			}
@@ -740,6 +780,18 @@ func parseRecurseArgs(it *lex.ItemIterator, gq *GraphQuery) error {
 				return err
 			}
 			gq.RecurseArgs.AllowLoop = allowLoop
+		case "maxnodes":
+			maxNodes, err := strconv.ParseUint(val, 0, 64)
+			if err != nil {
+				return err
+			}
+			gq.RecurseArgs.MaxNodes = maxNodes
+		case "annotate":
+			annotate, err := strconv.ParseBool(val)
+			if err != nil {
+				return err
+			}
+			gq.RecurseArgs.Annotate = annotate
 		default:
 			return fmt.Errorf("Unexpected key: [%s] inside @recurse block", key)
 		}
@@ -755,6 +807,57 @@ func parseRecurseArgs(it *lex.ItemIterator, gq *GraphQuery) error {
 	return nil
 }
 
+func parseHintArgs(it *lex.ItemIterator, gq *GraphQuery) error {
+	if ok := trySkipItemTyp(it, itemLeftRound); !ok {
+		return x.Errorf("Expected hints inside @hint().")
+	}
+
+	for it.Next() {
+		item := it.Item()
+		if item.Typ != itemName {
+			return x.Errorf("Expected hint inside @hint().")
+		}
+		key := strings.ToLower(item.Val)
+
+		var val string
+		if ok := trySkipItemTyp(it, itemColon); ok {
+			if item, ok = tryParseItemType(it, itemName); !ok {
+				return x.Errorf("Expected value inside @hint() for hint: %s.", key)
+			}
+			val = item.Val
+		}
+
+		switch key {
+		case "useindex":
+			if len(val) == 0 {
+				return x.Errorf("Expected tokenizer for hint useIndex.")
+			}
+			gq.Hints.UseIndex = val
+		case "noparallel":
+			gq.Hints.NoParallel = true
+		case "orderedfilters":
+			gq.Hints.OrderedFilters = true
+		case "novaluescan":
+			gq.Hints.NoValueScan = true
+		case "sort":
+			if val != "index" && val != "values" {
+				return x.Errorf("Hint sort must be either index or values. Got: [%s]", val)
+			}
+			gq.Hints.Sort = val
+		default:
+			return x.Errorf("Unknown hint: [%s] inside @hint block", key)
+		}
+
+		if _, ok := tryParseItemType(it, itemRightRound); ok {
+			return nil
+		}
+		if _, ok := tryParseItemType(it, itemComma); !ok {
+			return x.Errorf("Expected comma after hint: %s inside @hint block.", key)
+		}
+	}
+	return x.Errorf("Unclosed @hint block.")
+}
+
 // getQuery creates a GraphQuery object tree by calling getRoot
 // and goDeep functions by looking at '{'.
 func getQuery(it *lex.ItemIterator) (gq *GraphQuery, rerr error) {
@@ -806,6 +909,10 @@ L:
 				if err := parseRecurseArgs(it, gq); err != nil {
 					return nil, err
 				}
+			case "hint":
+				if err := parseHintArgs(it, gq); err != nil {
+					return nil, err
+				}
 			default:
 				return nil, x.Errorf("Unknown directive [%s]", item.Val)
 			}
@@ -2145,6 +2252,9 @@ func validKey(k string) bool {
 	switch k {
 	case "orderasc", "orderdesc", "first", "offset", "after":
 		return true
+	case "levels":
+		// Specific to the predicates of recurse queries
+		return true
 	}
 	return false
 }
@@ -2780,6 +2780,86 @@ func TestParseNormalize(t *testing.T) {
 	require.True(t, res.Query[0].Normalize)
 }
 
+func TestParseHint(t *testing.T) {
+	query := `
+	query {
+		me(func: ge(age, 18)) @hint(useIndex: int, noParallel, sort: values) @filter(has(name)) {
+			name
+		}
+}
+`
+	res, err := Parse(Request{Str: query})
+	require.NoError(t, err)
+	require.NotNil(t, res.Query[0])
+	require.Equal(t, QueryHints{UseIndex: "int", NoParallel: true, Sort: "values"},
+		res.Query[0].Hints)
+	require.NotNil(t, res.Query[0].Filter)
+}
+
+func TestParseHintError(t *testing.T) {
+	query := `
+	query {
+		me(func: ge(age, 18)) @hint(sort: random) {
+			name
+		}
+}
+`
+	_, err := Parse(Request{Str: query})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "Hint sort must be either index or values")
+
+	query = `
+	query {
+		me(func: ge(age, 18)) @hint(fast) {
+			name
+		}
+}
+`
+	_, err = Parse(Request{Str: query})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "Unknown hint: [fast]")
+}
+
+func TestParseRecurseArgs(t *testing.T) {
+	query := `
+	query {
+		me(func: uid(1)) @recurse(depth: 5, maxNodes: 100, annotate: true) {
+			friend(levels: 2-4)
+			name
+		}
+}
+`
+	res, err := Parse(Request{Str: query})
+	require.NoError(t, err)
+	require.NotNil(t, res.Query[0])
+	require.True(t, res.Query[0].Recurse)
+	require.Equal(t, RecurseArgs{Depth: 5, MaxNodes: 100, Annotate: true}, res.Query[0].RecurseArgs)
+	require.Equal(t, "2-4", res.Query[0].Children[0].Args["levels"])
+}
+
+func TestParseRecurseArgsError(t *testing.T) {
+	query := `
+	query {
+		me(func: uid(1)) @recurse(maxnodes: many) {
+			friend
+		}
+}
+`
+	_, err := Parse(Request{Str: query})
+	require.Error(t, err)
+
+	query = `
+	query {
+		me(func: uid(1)) @recurse(breadth: 10) {
+			friend
+		}
+}
+`
+	_, err = Parse(Request{Str: query})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "Unexpected key: [breadth] inside @recurse block")
+}
+
 func TestParseGroupbyRoot(t *testing.T) {
 	query := `
 	query {
@@ -0,0 +1,130 @@
+/*
+ * Copyright 2015-2018 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gql
+
+// Template is a query parsed once, to be run with different values of its variables. Every Bind
+// substitutes them into a copy of the parts of the query they appear in, sharing the rest, which
+// mustn't be changed.
+type Template struct {
+	res  Result
+	vars varMap   // Types and default values of the variables declared.
+	uses []string // Uid variables used outside of the query.
+}
+
+// ParseTemplate parses the query of a template, without the values of its variables. The uid
+// variables in uses are used outside of the query, e.g. by the mutations of an upsert template,
+// so the query may define them without using them.
+func ParseTemplate(query string, uses ...string) (*Template, error) {
+	t := &Template{vars: make(varMap), uses: uses}
+	res, err := parse(query, t.vars, false)
+	if err != nil {
+		return nil, err
+	}
+	t.res = res
+	return t, nil
+}
+
+// Declared returns the variables the query of the template declares, out of the given ones.
+// Bind rejects the others, as Parse would.
+func (t *Template) Declared(variables map[string]string) map[string]string {
+	res := make(map[string]string, len(t.vars))
+	for name, val := range variables {
+		if _, ok := t.vars[name]; ok {
+			res[name] = val
+		}
+	}
+	return res
+}
+
+// Bind returns the query of the template with the values of the variables, as Parse would for
+// the same query and variables.
+func (t *Template) Bind(variables map[string]string) (Result, error) {
+	vmap := convertToVarMap(variables)
+	for name, decl := range t.vars {
+		v := vmap[name]
+		v.Type = decl.Type
+		if v.Value == "" {
+			v.Value = decl.Value
+		}
+		vmap[name] = v
+	}
+	if len(t.vars) > 0 {
+		if err := checkValueType(vmap); err != nil {
+			return Result{}, err
+		}
+	}
+	res := Result{Schema: t.res.Schema}
+	for _, qu := range t.res.Query {
+		res.Query = append(res.Query, qu.clone())
+	}
+	if err := res.substitute(vmap, t.uses); err != nil {
+		return Result{}, err
+	}
+	return res, nil
+}
+
+// clone copies the parts of gq which substituteVariables changes.
+func (gq *GraphQuery) clone() *GraphQuery {
+	c := *gq
+	if gq.UID != nil {
+		c.UID = append([]uint64{}, gq.UID...)
+	}
+	if gq.Args != nil {
+		c.Args = make(map[string]string, len(gq.Args))
+		for k, v := range gq.Args {
+			c.Args[k] = v
+		}
+	}
+	c.Func = gq.Func.clone()
+	c.Filter = gq.Filter.clone()
+	if gq.Children != nil {
+		c.Children = make([]*GraphQuery, len(gq.Children))
+		for i, child := range gq.Children {
+			c.Children[i] = child.clone()
+		}
+	}
+	return &c
+}
+
+func (f *Function) clone() *Function {
+	if f == nil {
+		return nil
+	}
+	c := *f
+	if f.Args != nil {
+		c.Args = append([]Arg{}, f.Args...)
+	}
+	if f.UID != nil {
+		c.UID = append([]uint64{}, f.UID...)
+	}
+	return &c
+}
+
+func (t *FilterTree) clone() *FilterTree {
+	if t == nil {
+		return nil
+	}
+	c := *t
+	c.Func = t.Func.clone()
+	if t.Child != nil {
+		c.Child = make([]*FilterTree, len(t.Child))
+		for i, child := range t.Child {
+			c.Child[i] = child.clone()
+		}
+	}
+	return &c
+}
@@ -0,0 +1,83 @@
+/*
+ * Copyright 2015-2018 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gql
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTemplateBind(t *testing.T) {
+	query := `query q($name: string, $id: string, $first: int = 10, $re: string) {
+		me(func: eq(name, $name), first: $first) @filter(uid($id) or regexp(bio, $re)) {
+			name
+			friend(first: $first) @filter(eq(name, $name)) {
+				name
+			}
+		}
+	}`
+	tmpl, err := ParseTemplate(query)
+	require.NoError(t, err)
+	for _, vars := range []map[string]string{
+		{"$name": "alice", "$id": "0x1", "$re": "/^a/i"},
+		{"$name": "bob", "$id": "0x2", "$first": "3", "$re": "/b$/"},
+	} {
+		want, err := Parse(Request{Str: query, Variables: vars})
+		require.NoError(t, err)
+		got, err := tmpl.Bind(vars)
+		require.NoError(t, err)
+		require.Equal(t, want, got)
+	}
+
+	// The values bound before don't leak into the template.
+	got, err := tmpl.Bind(map[string]string{"$name": "carol", "$id": "0x3", "$re": "/c/"})
+	require.NoError(t, err)
+	require.Equal(t, "carol", got.Query[0].Func.Args[0].Value)
+	require.Equal(t, "10", got.Query[0].Args["first"])
+	require.Equal(t, []uint64{3}, got.Query[0].Filter.Child[0].Func.UID)
+
+	_, err = tmpl.Bind(map[string]string{"$name": "dave", "$id": "0x4", "$re": "/d/",
+		"$first": "many"})
+	require.Error(t, err)
+	_, err = tmpl.Bind(map[string]string{"$name": "erin", "$id": "0x5", "$re": "/e/",
+		"$email": "erin@example.com"})
+	require.Error(t, err)
+	got, err = tmpl.Bind(tmpl.Declared(map[string]string{"$name": "erin", "$id": "0x5",
+		"$re": "/e/", "$email": "erin@example.com"}))
+	require.NoError(t, err)
+	require.Equal(t, "erin", got.Query[0].Func.Args[0].Value)
+
+	_, err = ParseTemplate(`query q($name: string) { me(func: eq(name, $name) { name } }`)
+	require.Error(t, err)
+}
+
+func TestTemplateUses(t *testing.T) {
+	query := `query q($email: string) { u as var(func: eq(email, $email)) }`
+	vars := map[string]string{"$email": "alice@example.com"}
+	tmpl, err := ParseTemplate(query)
+	require.NoError(t, err)
+	_, err = tmpl.Bind(vars)
+	require.Error(t, err)
+
+	// The uid variables of the mutations count as used, the others are blank nodes.
+	tmpl, err = ParseTemplate(query, "u", "v")
+	require.NoError(t, err)
+	res, err := tmpl.Bind(vars)
+	require.NoError(t, err)
+	require.Equal(t, "u", res.Query[0].Var)
+}
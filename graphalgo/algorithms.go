@@ -0,0 +1,297 @@
+/*
+ * Copyright 2018 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package graphalgo
+
+import (
+	"math"
+
+	"github.com/dgraph-io/dgraph/types"
+	"github.com/dgraph-io/dgraph/x"
+)
+
+// Names of the algorithms, as passed to New.
+const (
+	ConnectedComponents = "wcc"
+	PageRank            = "pagerank"
+	Triangles           = "triangles"
+)
+
+// Options of the algorithms which take any.
+type Options struct {
+	// Damping is the probability of following an edge in PageRank. Defaults to 0.85.
+	Damping float64 `json:"damping,omitempty"`
+	// Tolerance is the change of rank below which PageRank considers a node converged. Defaults
+	// to 1e-6.
+	Tolerance float64 `json:"tolerance,omitempty"`
+}
+
+// New returns the algorithm named name, to be run over g.
+func New(name string, g *Graph, opt Options) (Algorithm, error) {
+	switch name {
+	case ConnectedComponents:
+		return &wcc{g: g}, nil
+	case PageRank:
+		if opt.Damping == 0 {
+			opt.Damping = 0.85
+		}
+		if opt.Tolerance == 0 {
+			opt.Tolerance = 1e-6
+		}
+		if opt.Damping < 0 || opt.Damping >= 1 || opt.Tolerance < 0 {
+			return nil, x.Errorf("Invalid options for PageRank: %+v", opt)
+		}
+		return &pageRank{g: g, damping: opt.Damping, tolerance: opt.Tolerance}, nil
+	case Triangles:
+		return &triangles{g: g}, nil
+	}
+	return nil, x.Errorf("Unknown algorithm: %q. Must be one of %s, %s or %s.",
+		name, ConnectedComponents, PageRank, Triangles)
+}
+
+// wcc finds the weakly connected components of the graph, by propagating the smallest uid of
+// every component along its edges, ignoring their direction. The value of a node is the smallest
+// uid of its component.
+type wcc struct {
+	g      *Graph
+	labels []uint64
+}
+
+func (a *wcc) Step(run func(steps []*Step) ([]*StepResult, error)) ([]int, error) {
+	if a.labels == nil {
+		a.labels = make([]uint64, a.g.NumNodes())
+		for i := range a.labels {
+			a.labels[i] = a.g.Uid(i)
+		}
+		return allNodes(a.g), nil
+	}
+
+	results, err := run(a.g.steps(ConnectedComponents, func(s *Step, uid uint64, i int) {
+		if s.Labels == nil {
+			s.Labels = make(map[uint64]uint64)
+		}
+		s.Labels[uid] = a.labels[i]
+	}))
+	if err != nil {
+		return nil, err
+	}
+	// Supersteps are synchronous: labels only spread by one edge per superstep.
+	next := make([]uint64, len(a.labels))
+	copy(next, a.labels)
+	for _, r := range results {
+		for uid, label := range r.Labels {
+			if i, ok := a.g.index[uid]; ok && label < next[i] {
+				next[i] = label
+			}
+		}
+	}
+	var changed []int
+	for i := range next {
+		if next[i] != a.labels[i] {
+			changed = append(changed, i)
+		}
+	}
+	a.labels = next
+	return changed, nil
+}
+
+func (a *wcc) Value(i int) types.Val {
+	return types.Val{Tid: types.IntID, Value: int64(a.labels[i])}
+}
+
+func (a *wcc) Type() types.TypeID {
+	return types.IntID
+}
+
+// wccStep finds the smallest label of the neighbours of every node along the edges of p.
+func (p *Partition) wccStep(s *Step) *StepResult {
+	res := &StepResult{Labels: make(map[uint64]uint64)}
+	min := func(uid, label uint64) {
+		cur, ok := res.Labels[uid]
+		if !ok {
+			cur = s.Labels[uid]
+		}
+		if label < cur {
+			res.Labels[uid] = label
+		}
+	}
+	for k, from := range p.subjects {
+		for _, to := range p.out[k] {
+			min(from, s.Labels[to])
+			min(to, s.Labels[from])
+		}
+	}
+	return res
+}
+
+// pageRank ranks the nodes by the probability of reaching them by following edges at random,
+// jumping to a random node with probability 1 - damping, or when there are no edges to follow.
+// The ranks of all nodes add up to 1.
+type pageRank struct {
+	g                  *Graph
+	damping, tolerance float64
+	ranks              []float64
+}
+
+func (a *pageRank) Step(run func(steps []*Step) ([]*StepResult, error)) ([]int, error) {
+	n := float64(a.g.NumNodes())
+	if a.ranks == nil {
+		a.ranks = make([]float64, a.g.NumNodes())
+		for i := range a.ranks {
+			a.ranks[i] = 1 / n
+		}
+		return allNodes(a.g), nil
+	}
+
+	results, err := run(a.g.steps(PageRank, func(s *Step, uid uint64, i int) {
+		if s.Ranks == nil {
+			s.Ranks = make(map[uint64]float64)
+		}
+		s.Ranks[uid] = a.ranks[i]
+	}))
+	if err != nil {
+		return nil, err
+	}
+	next := make([]float64, len(a.ranks))
+	// Every subject is held by a single partition, and the nodes which aren't the subject of any
+	// edge are the ones whose rank is left to spread evenly.
+	var dangling float64
+	for _, r := range a.ranks {
+		dangling += r
+	}
+	for _, r := range results {
+		dangling -= r.Spread
+		for uid, rank := range r.Ranks {
+			if i, ok := a.g.index[uid]; ok {
+				next[i] += rank
+			}
+		}
+	}
+	var changed []int
+	for i := range next {
+		next[i] = (1-a.damping)/n + a.damping*(next[i]+dangling/n)
+		if math.Abs(next[i]-a.ranks[i]) > a.tolerance {
+			changed = append(changed, i)
+		}
+	}
+	if len(changed) > 0 {
+		a.ranks = next
+	}
+	return changed, nil
+}
+
+func (a *pageRank) Value(i int) types.Val {
+	return types.Val{Tid: types.FloatID, Value: a.ranks[i]}
+}
+
+func (a *pageRank) Type() types.TypeID {
+	return types.FloatID
+}
+
+// pageRankStep has every subject of p share its rank evenly between the objects of its edges.
+func (p *Partition) pageRankStep(s *Step) *StepResult {
+	res := &StepResult{Ranks: make(map[uint64]float64)}
+	for k, from := range p.subjects {
+		if len(p.out[k]) == 0 {
+			continue
+		}
+		rank := s.Ranks[from]
+		res.Spread += rank
+		share := rank / float64(len(p.out[k]))
+		for _, to := range p.out[k] {
+			res.Ranks[to] += share
+		}
+	}
+	return res
+}
+
+// triangles counts the triangles every node is part of, ignoring the direction of the edges.
+// It takes a single superstep. As the edges of a triangle can be held by different partitions,
+// it can only be run over a graph in a single partition.
+type triangles struct {
+	g      *Graph
+	counts []int64
+}
+
+func (a *triangles) Step(run func(steps []*Step) ([]*StepResult, error)) ([]int, error) {
+	if a.counts != nil {
+		return nil, nil
+	}
+	if len(a.g.parts) > 1 {
+		return nil, x.Errorf("Triangles can't be counted over a graph in %d partitions",
+			len(a.g.parts))
+	}
+	results, err := run(a.g.steps(Triangles, func(*Step, uint64, int) {}))
+	if err != nil {
+		return nil, err
+	}
+	a.counts = make([]int64, a.g.NumNodes())
+	for _, r := range results {
+		for uid, n := range r.Counts {
+			if i, ok := a.g.index[uid]; ok {
+				a.counts[i] = n
+			}
+		}
+	}
+	return allNodes(a.g), nil
+}
+
+func (a *triangles) Value(i int) types.Val {
+	return types.Val{Tid: types.IntID, Value: a.counts[i]}
+}
+
+func (a *triangles) Type() types.TypeID {
+	return types.IntID
+}
+
+// trianglesStep counts the triangles of every node made of the edges of p.
+func (p *Partition) trianglesStep() *StepResult {
+	ns := p.neighbours()
+	counts := make([]int64, len(p.nodes))
+	// Count every triangle i < j < k once, from its smallest node.
+	for i := range ns {
+		for _, j := range ns[i] {
+			if j <= i {
+				continue
+			}
+			// Both lists are sorted, so merge them to find the k > j next to both i and j.
+			a1, a2 := ns[i], ns[j]
+			for u, v := 0, 0; u < len(a1) && v < len(a2); {
+				switch {
+				case a1[u] < a2[v]:
+					u++
+				case a1[u] > a2[v]:
+					v++
+				default:
+					if k := a1[u]; k > j {
+						counts[i]++
+						counts[j]++
+						counts[k]++
+					}
+					u++
+					v++
+				}
+			}
+		}
+	}
+	res := &StepResult{Counts: make(map[uint64]int64)}
+	for i, n := range counts {
+		if n > 0 {
+			res.Counts[p.nodes[i]] = n
+		}
+	}
+	return res
+}
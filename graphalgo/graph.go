@@ -0,0 +1,249 @@
+/*
+ * Copyright 2018 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package graphalgo implements graph algorithms computing a value for every node reached by the
+// edges of a uid predicate, like connected components and PageRank. The edges of the graph are
+// split in partitions, each held by the group serving a part of the predicate. The algorithms run
+// one superstep at a time: every partition runs the superstep over its edges, and the coordinator
+// of the algorithm merges their results into the values of the nodes. So whoever runs them can
+// keep the values which changed in every superstep, and stop them in between.
+package graphalgo
+
+import (
+	"sort"
+
+	"golang.org/x/net/context"
+
+	"github.com/dgraph-io/dgraph/types"
+	"github.com/dgraph-io/dgraph/x"
+)
+
+// Graph is the nodes of a directed graph, as known to the coordinator, which are referred to by
+// their index in the uids. Their edges are held by the partitions.
+type Graph struct {
+	uids  []uint64
+	index map[uint64]int
+	// The nodes of every partition.
+	parts [][]uint64
+	edges int
+}
+
+// NewGraph returns the graph made of the partitions holding the given nodes, and edges in all.
+func NewGraph(parts [][]uint64, edges int) *Graph {
+	g := &Graph{index: make(map[uint64]int), parts: parts, edges: edges}
+	for _, nodes := range parts {
+		for _, uid := range nodes {
+			if _, ok := g.index[uid]; !ok {
+				g.index[uid] = len(g.uids)
+				g.uids = append(g.uids, uid)
+			}
+		}
+	}
+	// Number the nodes in order of uid, so that results don't depend on the partitions.
+	sort.Slice(g.uids, func(i, j int) bool { return g.uids[i] < g.uids[j] })
+	for i, uid := range g.uids {
+		g.index[uid] = i
+	}
+	return g
+}
+
+// NumNodes returns the number of nodes of the graph.
+func (g *Graph) NumNodes() int {
+	return len(g.uids)
+}
+
+// NumEdges returns the number of edges of the graph.
+func (g *Graph) NumEdges() int {
+	return g.edges
+}
+
+// Uid returns the uid of node i.
+func (g *Graph) Uid(i int) uint64 {
+	return g.uids[i]
+}
+
+// steps returns a superstep of algo for every partition, carrying the values of its nodes.
+func (g *Graph) steps(algo string, value func(s *Step, uid uint64, i int)) []*Step {
+	steps := make([]*Step, len(g.parts))
+	for p, nodes := range g.parts {
+		steps[p] = &Step{Algorithm: algo}
+		for _, uid := range nodes {
+			value(steps[p], uid, g.index[uid])
+		}
+	}
+	return steps
+}
+
+// Step is a superstep of an algorithm, to be run by a partition. It carries the values of the
+// nodes of the partition at the end of the previous superstep.
+type Step struct {
+	Algorithm string             `json:"algorithm"`
+	Labels    map[uint64]uint64  `json:"labels,omitempty"`
+	Ranks     map[uint64]float64 `json:"ranks,omitempty"`
+}
+
+// StepResult is what a partition computed in a superstep, to be merged with the results of the
+// other partitions.
+type StepResult struct {
+	// Labels are the smallest labels of the neighbours of the nodes, where smaller than theirs.
+	Labels map[uint64]uint64 `json:"labels,omitempty"`
+	// Ranks are the ranks the nodes received along the edges, and Spread the sum of the ranks
+	// the subjects of the partition shared.
+	Ranks  map[uint64]float64 `json:"ranks,omitempty"`
+	Spread float64            `json:"spread,omitempty"`
+	// Counts are the numbers of triangles of the nodes, where not zero.
+	Counts map[uint64]int64 `json:"counts,omitempty"`
+}
+
+// Partition is the part of a graph held by a group: the edges of the subjects it holds.
+type Partition struct {
+	subjects []uint64
+	out      [][]uint64
+	nodes    []uint64
+}
+
+// NewPartition returns the partition made of the edges from every uid in edges to the uids it
+// maps to.
+func NewPartition(edges map[uint64][]uint64) *Partition {
+	p := &Partition{}
+	seen := make(map[uint64]bool)
+	add := func(uid uint64) {
+		if !seen[uid] {
+			seen[uid] = true
+			p.nodes = append(p.nodes, uid)
+		}
+	}
+	for from, to := range edges {
+		p.subjects = append(p.subjects, from)
+		add(from)
+		for _, uid := range to {
+			add(uid)
+		}
+	}
+	sort.Slice(p.subjects, func(i, j int) bool { return p.subjects[i] < p.subjects[j] })
+	sort.Slice(p.nodes, func(i, j int) bool { return p.nodes[i] < p.nodes[j] })
+	p.out = make([][]uint64, len(p.subjects))
+	for i, uid := range p.subjects {
+		p.out[i] = edges[uid]
+	}
+	return p
+}
+
+// Nodes returns the uids of the subjects and objects of the edges of the partition, in order.
+func (p *Partition) Nodes() []uint64 {
+	return p.nodes
+}
+
+// NumEdges returns the number of edges of the partition.
+func (p *Partition) NumEdges() int {
+	var n int
+	for _, out := range p.out {
+		n += len(out)
+	}
+	return n
+}
+
+// Step runs the superstep s over the edges of the partition.
+func (p *Partition) Step(s *Step) (*StepResult, error) {
+	switch s.Algorithm {
+	case ConnectedComponents:
+		return p.wccStep(s), nil
+	case PageRank:
+		return p.pageRankStep(s), nil
+	case Triangles:
+		return p.trianglesStep(), nil
+	}
+	return nil, x.Errorf("Unknown algorithm: %q", s.Algorithm)
+}
+
+// neighbours returns the neighbours of every node of the partition, by index in its nodes,
+// regardless of the direction of the edges, without duplicates or the node itself.
+func (p *Partition) neighbours() [][]int {
+	index := make(map[uint64]int, len(p.nodes))
+	for i, uid := range p.nodes {
+		index[uid] = i
+	}
+	ns := make([][]int, len(p.nodes))
+	for k, from := range p.subjects {
+		i := index[from]
+		for _, to := range p.out[k] {
+			if j := index[to]; i != j {
+				ns[i] = append(ns[i], j)
+				ns[j] = append(ns[j], i)
+			}
+		}
+	}
+	for i, n := range ns {
+		sort.Ints(n)
+		uniq := n[:0]
+		for k, j := range n {
+			if k == 0 || j != n[k-1] {
+				uniq = append(uniq, j)
+			}
+		}
+		ns[i] = uniq
+	}
+	return ns
+}
+
+// Algorithm computes a value for every node of a graph.
+type Algorithm interface {
+	// Step runs a superstep of the algorithm, calling run to have the partitions run the
+	// supersteps passed to it, one per partition, and return their results in the same order.
+	// It returns the nodes whose values it changed. The values are set by the first superstep, so
+	// it returns all the nodes. The algorithm is done once a superstep changes nothing.
+	Step(run func(steps []*Step) ([]*StepResult, error)) ([]int, error)
+	// Value returns the value of node i, of type Type.
+	Value(i int) types.Val
+	Type() types.TypeID
+}
+
+// RunFunc runs a superstep in every partition, and returns their results in the same order.
+type RunFunc func(ctx context.Context, steps []*Step) ([]*StepResult, error)
+
+// Run runs the supersteps of a until it's done, or maxSteps supersteps ran if maxSteps isn't 0.
+// The partitions run them through run. After every superstep, step is called with its number,
+// starting at 1, and the nodes it changed. It returns the number of supersteps which ran.
+func Run(ctx context.Context, a Algorithm, maxSteps int, run RunFunc,
+	step func(n int, changed []int) error) (int, error) {
+	for n := 1; maxSteps == 0 || n <= maxSteps; n++ {
+		if err := ctx.Err(); err != nil {
+			return n - 1, err
+		}
+		changed, err := a.Step(func(steps []*Step) ([]*StepResult, error) {
+			return run(ctx, steps)
+		})
+		if err != nil {
+			return n - 1, err
+		}
+		if len(changed) == 0 {
+			return n - 1, nil
+		}
+		if err := step(n, changed); err != nil {
+			return n, err
+		}
+	}
+	return maxSteps, nil
+}
+
+// allNodes returns the indexes of all the nodes of g.
+func allNodes(g *Graph) []int {
+	all := make([]int, g.NumNodes())
+	for i := range all {
+		all[i] = i
+	}
+	return all
+}
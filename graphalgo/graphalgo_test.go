@@ -0,0 +1,197 @@
+/*
+ * Copyright 2018 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package graphalgo
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/context"
+)
+
+// partitions splits the edges in n partitions by subject, the way the groups serving a split
+// predicate hold them, and returns them with the graph they make.
+func partitions(edges map[uint64][]uint64, n int) (*Graph, []*Partition) {
+	split := make([]map[uint64][]uint64, n)
+	for i := range split {
+		split[i] = make(map[uint64][]uint64)
+	}
+	for from, to := range edges {
+		split[from%uint64(n)][from] = to
+	}
+	var parts []*Partition
+	var nodes [][]uint64
+	var numEdges int
+	for _, e := range split {
+		p := NewPartition(e)
+		parts = append(parts, p)
+		nodes = append(nodes, p.Nodes())
+		numEdges += p.NumEdges()
+	}
+	return NewGraph(nodes, numEdges), parts
+}
+
+func runLocal(parts []*Partition) RunFunc {
+	return func(_ context.Context, steps []*Step) ([]*StepResult, error) {
+		results := make([]*StepResult, len(steps))
+		for i, s := range steps {
+			var err error
+			if results[i], err = parts[i].Step(s); err != nil {
+				return nil, err
+			}
+		}
+		return results, nil
+	}
+}
+
+func runIn(t *testing.T, name string, edges map[uint64][]uint64,
+	n int) map[uint64]interface{} {
+	g, parts := partitions(edges, n)
+	a, err := New(name, g, Options{})
+	require.NoError(t, err)
+	_, err = Run(context.Background(), a, 0, runLocal(parts), func(int, []int) error { return nil })
+	require.NoError(t, err)
+	res := make(map[uint64]interface{})
+	for i := 0; i < g.NumNodes(); i++ {
+		res[g.Uid(i)] = a.Value(i).Value
+	}
+	return res
+}
+
+// run runs the algorithm over the edges in a single partition, and checks that it finds the same
+// values when they're split in several.
+func run(t *testing.T, name string, edges map[uint64][]uint64) map[uint64]interface{} {
+	res := runIn(t, name, edges, 1)
+	if name != Triangles {
+		for n := 2; n <= 3; n++ {
+			split := runIn(t, name, edges, n)
+			require.Len(t, split, len(res))
+			for uid, v := range res {
+				if f, ok := v.(float64); ok {
+					require.InDelta(t, f, split[uid].(float64), 1e-9)
+				} else {
+					require.Equal(t, v, split[uid])
+				}
+			}
+		}
+	}
+	return res
+}
+
+func TestGraph(t *testing.T) {
+	p := NewPartition(map[uint64][]uint64{9: {2, 3}, 3: {2}})
+	require.Equal(t, []uint64{2, 3, 9}, p.Nodes())
+	require.Equal(t, 3, p.NumEdges())
+	require.Equal(t, [][]int{{1, 2}, {0, 2}, {0, 1}}, p.neighbours())
+
+	g := NewGraph([][]uint64{p.Nodes(), {9, 11}}, 4)
+	require.Equal(t, 4, g.NumNodes())
+	require.Equal(t, 4, g.NumEdges())
+	require.Equal(t, []uint64{2, 3, 9, 11}, []uint64{g.Uid(0), g.Uid(1), g.Uid(2), g.Uid(3)})
+	steps := g.steps(PageRank, func(s *Step, uid uint64, i int) {
+		if s.Ranks == nil {
+			s.Ranks = make(map[uint64]float64)
+		}
+		s.Ranks[uid] = float64(i)
+	})
+	// Every partition gets the values of its own nodes.
+	require.Equal(t, map[uint64]float64{2: 0, 3: 1, 9: 2}, steps[0].Ranks)
+	require.Equal(t, map[uint64]float64{9: 2, 11: 3}, steps[1].Ranks)
+}
+
+func TestConnectedComponents(t *testing.T) {
+	res := run(t, ConnectedComponents, map[uint64][]uint64{
+		5: {6},
+		7: {6, 8},
+		3: {4},
+		4: {3},
+		9: {9},
+	})
+	require.Equal(t, map[uint64]interface{}{
+		3: int64(3), 4: int64(3),
+		5: int64(5), 6: int64(5), 7: int64(5), 8: int64(5),
+		9: int64(9),
+	}, res)
+}
+
+func TestPageRank(t *testing.T) {
+	// 1 and 2 link to each other, 3 links to 1 and nothing links to 3.
+	res := run(t, PageRank, map[uint64][]uint64{1: {2}, 2: {1}, 3: {1}})
+	var sum float64
+	for _, r := range res {
+		sum += r.(float64)
+	}
+	require.InDelta(t, 1, sum, 1e-6)
+	require.True(t, res[1].(float64) > res[2].(float64))
+	require.True(t, res[2].(float64) > res[3].(float64))
+	require.InDelta(t, 0.05, res[3].(float64), 1e-6)
+
+	// Nodes without edges to follow spread their rank evenly.
+	res = run(t, PageRank, map[uint64][]uint64{1: {2}})
+	require.InDelta(t, 1, res[1].(float64)+res[2].(float64), 1e-6)
+	require.True(t, res[2].(float64) > res[1].(float64))
+
+	_, err := New(PageRank, NewGraph(nil, 0), Options{Damping: 1})
+	require.Error(t, err)
+}
+
+func TestTriangles(t *testing.T) {
+	// Two triangles sharing the edge 2-3, in both directions, and a dangling edge.
+	res := run(t, Triangles, map[uint64][]uint64{
+		1: {2, 3},
+		2: {3},
+		3: {2},
+		4: {2, 3},
+		5: {4},
+	})
+	require.Equal(t, map[uint64]interface{}{
+		1: int64(1), 2: int64(2), 3: int64(2), 4: int64(1), 5: int64(0),
+	}, res)
+
+	// The edges of a triangle can be held by different partitions.
+	g, parts := partitions(map[uint64][]uint64{1: {2}, 2: {3}}, 2)
+	a, err := New(Triangles, g, Options{})
+	require.NoError(t, err)
+	_, err = Run(context.Background(), a, 0, runLocal(parts), func(int, []int) error { return nil })
+	require.Error(t, err)
+}
+
+func TestRun(t *testing.T) {
+	g, parts := partitions(map[uint64][]uint64{1: {2}, 2: {3}, 3: {4}}, 2)
+	a, err := New(ConnectedComponents, g, Options{})
+	require.NoError(t, err)
+
+	var changed [][]int
+	n, err := Run(context.Background(), a, 0, runLocal(parts), func(n int, c []int) error {
+		require.Equal(t, len(changed)+1, n)
+		changed = append(changed, c)
+		return nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, 4, n)
+	require.Equal(t, [][]int{{0, 1, 2, 3}, {1, 2, 3}, {2, 3}, {3}}, changed)
+
+	a, _ = New(ConnectedComponents, g, Options{})
+	n, err = Run(context.Background(), a, 2, runLocal(parts), func(int, []int) error { return nil })
+	require.NoError(t, err)
+	require.Equal(t, 2, n)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = Run(ctx, a, 0, runLocal(parts), func(int, []int) error { return nil })
+	require.Error(t, err)
+}
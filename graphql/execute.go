@@ -0,0 +1,737 @@
+/*
+ * Copyright 2018 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package graphql
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/net/context"
+
+	"github.com/dgraph-io/dgraph/x"
+)
+
+// Request is a GraphQL request, as per the GraphQL over HTTP convention.
+type Request struct {
+	Query         string                 `json:"query"`
+	OperationName string                 `json:"operationName,omitempty"`
+	Variables     map[string]interface{} `json:"variables,omitempty"`
+}
+
+// Response is the response to a GraphQL request. Data is missing if the request couldn't be
+// executed at all.
+type Response struct {
+	Data   json.RawMessage `json:"data,omitempty"`
+	Errors []*Error        `json:"errors,omitempty"`
+}
+
+// Executor runs the GraphQL± queries and the mutations GraphQL requests are turned into.
+type Executor interface {
+	// Query runs query in the transaction started at startTs, or in a new one if zero. It returns
+	// the JSON result and the start timestamp of the transaction.
+	Query(ctx context.Context, query string, startTs uint64) ([]byte, uint64, error)
+	// Mutate sets and deletes the JSON objects in the transaction started at startTs, or in a new
+	// one if zero, and commits it with commit. It returns the uids of the blank nodes, by name.
+	Mutate(ctx context.Context, startTs uint64, set, del []byte, commit bool) (
+		map[string]string, uint64, error)
+}
+
+// maxDepth is how deeply selections can be nested, which also stops fragments spreading
+// themselves.
+const maxDepth = 64
+
+type execution struct {
+	ctx    context.Context
+	schema *Schema
+	exec   Executor
+	doc    *document
+	vars   map[string]interface{}
+	errs   []*Error
+}
+
+// selected is a field selected in a selection set, with the fields of the same key merged.
+type selected struct {
+	key   string
+	def   *gqlField
+	field *field
+	args  map[string]interface{}
+	sub   []*selected // Of fields of objects.
+}
+
+func errResponse(err error) *Response {
+	if gerr, ok := err.(*Error); ok {
+		return &Response{Errors: []*Error{gerr}}
+	}
+	return &Response{Errors: []*Error{{Message: err.Error()}}}
+}
+
+// Execute executes the GraphQL request against the schema, with the queries and mutations run by
+// exec.
+func Execute(ctx context.Context, s *Schema, exec Executor, req *Request) *Response {
+	doc, err := parseDocument(req.Query)
+	if err != nil {
+		return errResponse(err)
+	}
+	var op *operation
+	for _, o := range doc.operations {
+		if o.name == req.OperationName || (len(req.OperationName) == 0 && len(doc.operations) == 1) {
+			op = o
+			break
+		}
+	}
+	if op == nil {
+		if len(req.OperationName) == 0 {
+			return errResponse(&Error{Message: "The operation to run must be given by name"})
+		}
+		return errResponse(&Error{Message: fmt.Sprintf("Unknown operation %q", req.OperationName)})
+	}
+
+	e := &execution{ctx: ctx, schema: s, exec: exec, doc: doc}
+	if e.vars, err = e.coerceVars(op, req.Variables); err != nil {
+		return errResponse(err)
+	}
+	var root *gqlType
+	switch op.kind {
+	case "query":
+		root = s.types["Query"]
+	case "mutation":
+		root = s.types["Mutation"]
+	default:
+		return errResponse(errorAt(op.loc, "Subscriptions aren't supported"))
+	}
+	sels, err := e.plan(root, op.selections, 0)
+	if err != nil {
+		return errResponse(err)
+	}
+
+	var data *ordered
+	if op.kind == "query" {
+		data = e.query(sels)
+	} else {
+		data = e.mutate(sels)
+	}
+	js, err := json.Marshal(data)
+	if err != nil {
+		return errResponse(err)
+	}
+	return &Response{Data: js, Errors: e.errs}
+}
+
+func (e *execution) coerceVars(op *operation, given map[string]interface{}) (
+	map[string]interface{}, error) {
+	vars := make(map[string]interface{})
+	for _, v := range op.vars {
+		t, ok := e.schema.types[v.typ.named()]
+		if !ok {
+			return nil, errorAt(v.loc, "Unknown type %s of variable $%s", v.typ.named(), v.name)
+		}
+		if t.kind == kindObject {
+			return nil, errorAt(v.loc, "Variable $%s can't be of type %s, which isn't an input type",
+				v.name, t.name)
+		}
+		val, ok := given[v.name]
+		switch {
+		case ok:
+			val = normalize(val)
+		case v.def != nil:
+			var err error
+			if val, err = v.def.resolve(nil); err != nil {
+				return nil, err
+			}
+		case v.typ.nonNull:
+			return nil, errorAt(v.loc, "Variable $%s of type %s is required", v.name, v.typ)
+		default:
+			continue
+		}
+		c, err := e.schema.coerceInput(v.typ, val, "Variable $"+v.name)
+		if err != nil {
+			return nil, errorAt(v.loc, "%v", err)
+		}
+		vars[v.name] = c
+	}
+	return vars, nil
+}
+
+// included tells whether @skip and @include let a selection be included.
+func (e *execution) included(dirs []*directive) (bool, error) {
+	for _, d := range dirs {
+		if d.name != "skip" && d.name != "include" {
+			return false, errorAt(d.loc, "Unknown directive @%s", d.name)
+		}
+		a := d.arg("if")
+		if a == nil {
+			return false, errorAt(d.loc, "@%s takes an if argument", d.name)
+		}
+		v, err := a.val.resolve(e.vars)
+		if err != nil {
+			return false, err
+		}
+		b, ok := v.(bool)
+		if !ok {
+			return false, errorAt(a.loc, "The if argument of @%s must be a Boolean", d.name)
+		}
+		if b == (d.name == "skip") {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// collect collects the fields of the selection set on type t, grouped by key, in order.
+func (e *execution) collect(t *gqlType, sels []selection, keys *[]string,
+	groups map[string][]*field, visited map[string]bool) error {
+	for _, sel := range sels {
+		switch sel := sel.(type) {
+		case *field:
+			ok, err := e.included(sel.directives)
+			if err != nil {
+				return err
+			}
+			if !ok {
+				continue
+			}
+			if _, ok := groups[sel.key()]; !ok {
+				*keys = append(*keys, sel.key())
+			}
+			groups[sel.key()] = append(groups[sel.key()], sel)
+		case *fragmentSpread:
+			ok, err := e.included(sel.directives)
+			if err != nil {
+				return err
+			}
+			if !ok || visited[sel.name] {
+				continue
+			}
+			visited[sel.name] = true
+			f, ok := e.doc.fragments[sel.name]
+			if !ok {
+				return errorAt(sel.loc, "Unknown fragment %q", sel.name)
+			}
+			if _, ok := e.schema.types[f.on]; !ok {
+				return errorAt(f.loc, "Unknown type %s of fragment %s", f.on, f.name)
+			}
+			if f.on != t.name {
+				continue
+			}
+			if err := e.collect(t, f.selections, keys, groups, visited); err != nil {
+				return err
+			}
+		case *inlineFragment:
+			ok, err := e.included(sel.directives)
+			if err != nil {
+				return err
+			}
+			if !ok {
+				continue
+			}
+			if len(sel.on) > 0 {
+				if _, ok := e.schema.types[sel.on]; !ok {
+					return errorAt(sel.loc, "Unknown type %s of inline fragment", sel.on)
+				}
+				if sel.on != t.name {
+					continue
+				}
+			}
+			if err := e.collect(t, sel.selections, keys, groups, visited); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// plan validates the selection set on type t, and returns the fields it selects with their
+// arguments.
+func (e *execution) plan(t *gqlType, sels []selection, depth int) ([]*selected, error) {
+	if depth > maxDepth {
+		return nil, &Error{Message: fmt.Sprintf("Selections can't be nested over %d deep",
+			maxDepth)}
+	}
+	var keys []string
+	groups := make(map[string][]*field)
+	if err := e.collect(t, sels, &keys, groups, make(map[string]bool)); err != nil {
+		return nil, err
+	}
+	var res []*selected
+	for _, key := range keys {
+		fs := groups[key]
+		f := fs[0]
+		var def *gqlField
+		switch {
+		case f.name == "__typename":
+			def = typenameField
+		case t.name == "Query" && metaFields[f.name] != nil:
+			def = metaFields[f.name]
+		default:
+			def = t.field(f.name)
+		}
+		if def == nil {
+			return nil, errorAt(f.loc, "Cannot query field %q on type %s", f.name, t.name)
+		}
+		var subs []selection
+		for _, other := range fs {
+			if other.name != f.name {
+				return nil, errorAt(other.loc, "Fields %s and %s can't both be selected as %q",
+					f.name, other.name, key)
+			}
+			subs = append(subs, other.selections...)
+		}
+		args, err := e.coerceArgs(def, f)
+		if err != nil {
+			return nil, err
+		}
+		sel := &selected{key: key, def: def, field: f, args: args}
+		nt := e.schema.types[def.typ.named()]
+		switch {
+		case nt.kind == kindObject && len(subs) == 0:
+			return nil, errorAt(f.loc, "Field %q of type %s must have a selection of subfields",
+				f.name, def.typ)
+		case nt.kind == kindObject:
+			if sel.sub, err = e.plan(nt, subs, depth+1); err != nil {
+				return nil, err
+			}
+		case len(subs) > 0:
+			return nil, errorAt(f.loc, "Field %q of type %s can't have a selection of subfields",
+				f.name, def.typ)
+		}
+		res = append(res, sel)
+	}
+	return res, nil
+}
+
+func (e *execution) coerceArgs(def *gqlField, f *field) (map[string]interface{}, error) {
+	for _, a := range f.args {
+		if def.arg(a.name) == nil {
+			return nil, errorAt(a.loc, "Unknown argument %q of field %q", a.name, f.name)
+		}
+	}
+	args := make(map[string]interface{})
+	for _, ad := range def.args {
+		a := f.arg(ad.name)
+		var v interface{}
+		present := a != nil
+		if present && a.val.kind == valVariable {
+			v, present = e.vars[a.val.raw]
+		} else if present {
+			var err error
+			if v, err = a.val.resolve(e.vars); err != nil {
+				return nil, err
+			}
+		}
+		if !present {
+			if ad.def == "false" {
+				args[ad.name] = false
+			} else if ad.typ.nonNull {
+				return nil, errorAt(f.loc, "Argument %q of field %q of type %s is required",
+					ad.name, f.name, ad.typ)
+			}
+			continue
+		}
+		c, err := e.schema.coerceInput(ad.typ, v, fmt.Sprintf("Argument %q", ad.name))
+		if err != nil {
+			loc := f.loc
+			if a != nil {
+				loc = a.loc
+			}
+			return nil, errorAt(loc, "%v", err)
+		}
+		args[ad.name] = c
+	}
+	return args, nil
+}
+
+// dateLayouts are the layouts of the values of DateTime.
+var dateLayouts = []string{time.RFC3339Nano, "2006-01-02T15:04:05", "2006-01-02T15:04",
+	"2006-01-02", "2006-01", "2006"}
+
+// coerceInput checks that v is of type t, and returns it as used in the translation: objects as
+// maps, lists as slices, IDs as uids in hex.
+func (s *Schema) coerceInput(t *typeRef, v interface{}, where string) (interface{}, error) {
+	if v == nil {
+		if t.nonNull {
+			return nil, x.Errorf("%s of type %s can't be null", where, t)
+		}
+		return nil, nil
+	}
+	if t.elem != nil {
+		list, ok := v.([]interface{})
+		if !ok {
+			list = []interface{}{v}
+		}
+		res := make([]interface{}, 0, len(list))
+		for i, elem := range list {
+			c, err := s.coerceInput(t.elem, elem, fmt.Sprintf("%s[%d]", where, i))
+			if err != nil {
+				return nil, err
+			}
+			res = append(res, c)
+		}
+		return res, nil
+	}
+
+	nt := s.types[t.name]
+	switch nt.kind {
+	case kindInput:
+		m, ok := v.(map[string]interface{})
+		if !ok {
+			return nil, x.Errorf("%s must be an object of type %s", where, nt.name)
+		}
+		for k := range m {
+			if nt.field(k) == nil {
+				return nil, x.Errorf("%s has no field %q, of type %s", where, k, nt.name)
+			}
+		}
+		res := make(map[string]interface{}, len(m))
+		for _, f := range nt.fields {
+			val, ok := m[f.name]
+			if !ok {
+				if f.typ.nonNull {
+					return nil, x.Errorf("%s must have field %q of type %s", where, f.name, f.typ)
+				}
+				continue
+			}
+			c, err := s.coerceInput(f.typ, val, where+"."+f.name)
+			if err != nil {
+				return nil, err
+			}
+			// Null is kept, for patches which remove values with it.
+			res[f.name] = c
+		}
+		return res, nil
+	case kindEnum:
+		if str, ok := v.(string); ok && x.HasString(nt.values, str) {
+			return str, nil
+		}
+		return nil, x.Errorf("%s must be one of %s, values of enum %s, not %v", where,
+			strings.Join(nt.values, ", "), nt.name, v)
+	case kindScalar:
+		if c, ok := coerceScalar(nt.name, v); ok {
+			return c, nil
+		}
+		return nil, x.Errorf("%s must be of type %s, not %v", where, nt.name, v)
+	}
+	return nil, x.Errorf("%s can't be of type %s, which isn't an input type", where, nt.name)
+}
+
+func coerceScalar(scalar string, v interface{}) (interface{}, bool) {
+	switch scalar {
+	case "Int":
+		i, ok := v.(int64)
+		return i, ok
+	case "Float":
+		switch v := v.(type) {
+		case int64:
+			return float64(v), true
+		case float64:
+			return v, true
+		}
+	case "String":
+		s, ok := v.(string)
+		return s, ok
+	case "Boolean":
+		b, ok := v.(bool)
+		return b, ok
+	case "ID":
+		var uid uint64
+		var err error
+		switch v := v.(type) {
+		case string:
+			uid, err = strconv.ParseUint(v, 0, 64)
+		case int64:
+			uid = uint64(v)
+		default:
+			return nil, false
+		}
+		if err != nil || uid == 0 {
+			return nil, false
+		}
+		return fmt.Sprintf("%#x", uid), true
+	case "DateTime":
+		s, ok := v.(string)
+		if !ok {
+			return nil, false
+		}
+		for _, layout := range dateLayouts {
+			if _, err := time.Parse(layout, s); err == nil {
+				return s, true
+			}
+		}
+	}
+	return nil, false
+}
+
+// ordered is an object of the response, whose keys are in the order of the selection.
+type ordered struct {
+	keys []string
+	vals []interface{}
+}
+
+func (o *ordered) set(key string, val interface{}) {
+	o.keys = append(o.keys, key)
+	o.vals = append(o.vals, val)
+}
+
+func (o *ordered) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, key := range o.keys {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		k, err := json.Marshal(key)
+		if err != nil {
+			return nil, err
+		}
+		v, err := json.Marshal(o.vals[i])
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(k)
+		buf.WriteByte(':')
+		buf.Write(v)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+func (e *execution) addError(path []interface{}, format string, args ...interface{}) {
+	p := make([]interface{}, len(path))
+	copy(p, path)
+	e.errs = append(e.errs, &Error{Message: fmt.Sprintf(format, args...), Path: p})
+}
+
+// complete returns the value of type t in the response, as selected by sels. It returns false if
+// a non null value is null, and the error was added already.
+func (e *execution) complete(t *typeRef, sels []*selected, v interface{},
+	path []interface{}) (interface{}, bool) {
+	if t.nonNull {
+		inner := *t
+		inner.nonNull = false
+		c, ok := e.completeNullable(&inner, sels, v, path)
+		if !ok {
+			return nil, false
+		}
+		if c == nil {
+			e.addError(path, "Cannot return null for non-nullable field of type %s", t)
+			return nil, false
+		}
+		return c, true
+	}
+	c, ok := e.completeNullable(t, sels, v, path)
+	if !ok {
+		return nil, true
+	}
+	return c, true
+}
+
+func (e *execution) completeNullable(t *typeRef, sels []*selected, v interface{},
+	path []interface{}) (interface{}, bool) {
+	if v == nil {
+		return nil, true
+	}
+	list, isList := v.([]interface{})
+	if t.elem != nil {
+		if !isList {
+			list = []interface{}{v}
+		}
+		res := make([]interface{}, 0, len(list))
+		for i, elem := range list {
+			c, ok := e.complete(t.elem, sels, elem, append(path, i))
+			if !ok {
+				return nil, false
+			}
+			res = append(res, c)
+		}
+		return res, true
+	}
+	if isList {
+		// Dgraph returns lists of nodes, and of the values of list predicates.
+		if len(list) == 0 {
+			return nil, true
+		}
+		v = list[0]
+	}
+
+	nt := e.schema.types[t.name]
+	switch nt.kind {
+	case kindObject:
+		m, ok := v.(map[string]interface{})
+		if !ok {
+			e.addError(path, "Expected an object of type %s, got %v", nt.name, v)
+			return nil, true
+		}
+		return e.completeObject(nt, sels, m, path)
+	case kindEnum:
+		return v, true
+	}
+	c, ok := outputScalar(nt.name, v)
+	if !ok {
+		e.addError(path, "Invalid value %v of type %s", v, nt.name)
+		return nil, true
+	}
+	return c, true
+}
+
+// completeObject completes the object m of type t. The values of the introspection types are keyed
+// by the names of their fields, and the others by the keys of the selection, prefixed with g as
+// they are in the GraphQL± the data is queried with.
+func (e *execution) completeObject(t *gqlType, sels []*selected, m map[string]interface{},
+	path []interface{}) (interface{}, bool) {
+	res := &ordered{}
+	meta := strings.HasPrefix(t.name, "__")
+	for _, sel := range sels {
+		var v interface{}
+		switch {
+		case sel.def == typenameField:
+			v = t.name
+		case meta:
+			v = m[sel.def.name]
+		default:
+			v = m[alias(sel.key)]
+		}
+		c, ok := e.complete(sel.def.typ, sel.sub, v, append(path, sel.key))
+		if !ok {
+			return nil, false
+		}
+		res.set(sel.key, c)
+	}
+	return res, true
+}
+
+// outputScalar returns v, from the JSON of a result, as a value of the scalar.
+func outputScalar(scalar string, v interface{}) (interface{}, bool) {
+	switch v := v.(type) {
+	case json.Number:
+		switch scalar {
+		case "Int":
+			if i, err := v.Int64(); err == nil {
+				return i, true
+			}
+			f, err := v.Float64()
+			return int64(f), err == nil
+		case "Float":
+			f, err := v.Float64()
+			return f, err == nil
+		case "String", "ID":
+			return v.String(), true
+		}
+	case string:
+		switch scalar {
+		case "String", "ID", "DateTime":
+			return v, true
+		}
+	case bool:
+		switch scalar {
+		case "Boolean":
+			return v, true
+		case "String":
+			return strconv.FormatBool(v), true
+		}
+	case int64:
+		return v, scalar == "Int"
+	}
+	return nil, false
+}
+
+// alias is the alias in GraphQL± of the field with the key in the response. Prefixing the keys
+// keeps them from being taken for the keywords of GraphQL±.
+func alias(key string) string {
+	return "g" + key
+}
+
+// query runs the fields of a query: the data of all of them in a single GraphQL± query.
+func (e *execution) query(sels []*selected) *ordered {
+	var b strings.Builder
+	for _, sel := range sels {
+		if sel.def.op != "get" && sel.def.op != "query" {
+			continue
+		}
+		if err := e.rootBlock(&b, sel); err != nil {
+			e.addError([]interface{}{sel.key}, "%v", err)
+			return nil
+		}
+	}
+	var result map[string]interface{}
+	if b.Len() > 0 {
+		var err error
+		if result, _, err = e.run("{\n"+b.String()+"}", 0); err != nil {
+			e.errs = append(e.errs, &Error{Message: err.Error()})
+			return nil
+		}
+	}
+
+	schemaResult, typeResults := e.schema.introspection()
+	data := make(map[string]interface{})
+	for _, sel := range sels {
+		switch sel.def.op {
+		case "typename":
+		case "schema":
+			data[alias(sel.key)] = schemaResult
+		case "type":
+			if t, ok := typeResults[sel.args["name"].(string)]; ok {
+				data[alias(sel.key)] = t
+			}
+		default:
+			data[alias(sel.key)] = result[alias(sel.key)]
+		}
+	}
+	res, ok := e.completeObject(e.schema.types["Query"], sels, data, nil)
+	if !ok {
+		return nil
+	}
+	return res.(*ordered)
+}
+
+// run runs the GraphQL± query, and returns its result.
+func (e *execution) run(q string, startTs uint64) (map[string]interface{}, uint64, error) {
+	js, ts, err := e.exec.Query(e.ctx, q, startTs)
+	if err != nil {
+		return nil, 0, err
+	}
+	var result map[string]interface{}
+	dec := json.NewDecoder(bytes.NewReader(js))
+	dec.UseNumber()
+	if err := dec.Decode(&result); err != nil {
+		return nil, 0, err
+	}
+	return result, ts, nil
+}
+
+// mutate runs the fields of a mutation, one after the other.
+func (e *execution) mutate(sels []*selected) *ordered {
+	data := make(map[string]interface{})
+	for _, sel := range sels {
+		if sel.def.op == "typename" {
+			continue
+		}
+		payload, err := e.mutation(sel)
+		if err != nil {
+			e.addError([]interface{}{sel.key}, "%v", err)
+			continue
+		}
+		data[alias(sel.key)] = payload
+	}
+	res, ok := e.completeObject(e.schema.types["Mutation"], sels, data, nil)
+	if !ok {
+		return nil
+	}
+	return res.(*ordered)
+}
@@ -0,0 +1,197 @@
+/*
+ * Copyright 2018 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package graphql
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/context"
+)
+
+type mutation struct {
+	startTs  uint64
+	set, del string
+	commit   bool
+}
+
+// fakeExecutor records the queries and mutations run, and returns the results given, in order.
+type fakeExecutor struct {
+	results   []string
+	queries   []string
+	mutations []mutation
+}
+
+func (f *fakeExecutor) Query(ctx context.Context, q string, startTs uint64) ([]byte, uint64,
+	error) {
+	f.queries = append(f.queries, q)
+	res := "{}"
+	if len(f.results) > 0 {
+		res, f.results = f.results[0], f.results[1:]
+	}
+	return []byte(res), 10, nil
+}
+
+func (f *fakeExecutor) Mutate(ctx context.Context, startTs uint64, set, del []byte,
+	commit bool) (map[string]string, uint64, error) {
+	f.mutations = append(f.mutations, mutation{startTs, string(set), string(del), commit})
+	return map[string]string{"n0": "0x9", "n1": "0xa"}, startTs, nil
+}
+
+func execute(t *testing.T, f *fakeExecutor, query string, vars map[string]interface{}) string {
+	s, err := ParseSchema(personSDL)
+	require.NoError(t, err)
+	resp := Execute(context.Background(), s, f, &Request{Query: query, Variables: vars})
+	js, err := json.Marshal(resp)
+	require.NoError(t, err)
+	return string(js)
+}
+
+func TestExecuteGet(t *testing.T) {
+	f := &fakeExecutor{results: []string{`{"gp": [{"uid": "0x1", "gname": "Alice",
+		"gfriends": [{"uid": "0x2", "gname": "Bob"}]}]}`}}
+	res := execute(t, f, `query Q($id: ID!) {
+		p: getPerson(id: $id) { name friends(first: 2, order: {asc: name}) { name } __typename }
+	}`, map[string]interface{}{"id": "0x1"})
+
+	require.Equal(t, `{
+gp(func: uid(0x1)) @filter(eq(dgraph.graphql.type, "Person")) {
+	uid
+	gname : Person.name
+	gfriends : Person.friends (orderasc: Person.name, first: 2) {
+		uid
+		gname : Person.name
+	}
+}
+}`, f.queries[0])
+	require.JSONEq(t, `{"data": {"p": {"name": "Alice", "friends": [{"name": "Bob"}],
+		"__typename": "Person"}}}`, res)
+}
+
+func TestExecuteQueryFilter(t *testing.T) {
+	f := &fakeExecutor{results: []string{`{"gqueryPerson": []}`}}
+	res := execute(t, f, `{
+		queryPerson(filter: {name: {anyofterms: "a \"b\""},
+			or: [{age: {gt: 3}}, {not: {id: ["0x3"]}}]}, first: 1) { id }
+	}`, nil)
+
+	require.Equal(t, `{
+gqueryPerson(func: eq(dgraph.graphql.type, "Person"), first: 1) `+
+		`@filter(anyofterms(Person.name, "a \"b\"") AND `+
+		`(gt(Person.age, 3) OR NOT (uid(0x3)))) {
+	uid
+	gid : uid
+}
+}`, f.queries[0])
+	require.JSONEq(t, `{"data": {"queryPerson": []}}`, res)
+}
+
+func TestExecuteNullPropagation(t *testing.T) {
+	// name is non-null, so the person without one is null, and so is the field of the list.
+	f := &fakeExecutor{results: []string{`{"gqueryPerson": [{"uid": "0x1"}]}`}}
+	res := execute(t, f, `{ queryPerson { name } }`, nil)
+	require.JSONEq(t, `{"data": {"queryPerson": [null]}, "errors": [{"message":
+		"Cannot return null for non-nullable field of type String!", "path": ["queryPerson", 0, "name"]}]}`, res)
+}
+
+func TestExecuteValidation(t *testing.T) {
+	for _, q := range []string{
+		`{ getPerson { name } }`,
+		`{ getPerson(id: "0x1") { unknown } }`,
+		`{ getPerson(id: "0x1") }`,
+		`{ queryPerson(filter: {nick: {eq: "a"}}) { name } }`,
+		`subscription { queryPerson { name } }`,
+		`mutation { addPerson(input: [{age: 3}]) { numUids } }`,
+	} {
+		f := &fakeExecutor{}
+		res := execute(t, f, q, nil)
+		require.Contains(t, res, `"errors"`, q)
+		require.NotContains(t, res, `"data"`, q)
+		require.Empty(t, f.queries, q)
+		require.Empty(t, f.mutations, q)
+	}
+}
+
+func TestExecuteIntrospection(t *testing.T) {
+	res := execute(t, &fakeExecutor{}, `{
+		__schema { queryType { name } mutationType { name } }
+		__type(name: "Person") { kind fields { name type { kind ofType { name } } } }
+	}`, nil)
+	require.JSONEq(t, `{"data": {
+		"__schema": {"queryType": {"name": "Query"}, "mutationType": {"name": "Mutation"}},
+		"__type": {"kind": "OBJECT", "fields": [
+			{"name": "id", "type": {"kind": "NON_NULL", "ofType": {"name": "ID"}}},
+			{"name": "name", "type": {"kind": "NON_NULL", "ofType": {"name": "String"}}},
+			{"name": "age", "type": {"kind": "SCALAR", "ofType": null}},
+			{"name": "nick", "type": {"kind": "SCALAR", "ofType": null}},
+			{"name": "friends", "type": {"kind": "LIST", "ofType": {"name": "Person"}}}
+		]}
+	}}`, res)
+}
+
+func TestExecuteAdd(t *testing.T) {
+	f := &fakeExecutor{results: []string{`{"gperson": [{"uid": "0x9", "gname": "Carol"}]}`}}
+	res := execute(t, f, `mutation {
+		addPerson(input: [{name: "Carol", friends: [{id: "0x1"}, {name: "Dan"}]}]) {
+			person { name }
+			numUids
+		}
+	}`, nil)
+
+	require.Len(t, f.mutations, 1)
+	m := f.mutations[0]
+	require.True(t, m.commit)
+	require.JSONEq(t, `[{"uid": "_:n0", "dgraph.graphql.type": "Person",
+		"Person.name": "Carol", "Person.friends": [{"uid": "0x1"},
+		{"uid": "_:n1", "dgraph.graphql.type": "Person", "Person.name": "Dan"}]}]`, m.set)
+	require.Contains(t, f.queries[0], "gperson(func: uid(0x9))")
+	require.JSONEq(t, `{"data": {"addPerson": {"person": [{"name": "Carol"}],
+		"numUids": 1}}}`, res)
+}
+
+func TestExecuteUpdate(t *testing.T) {
+	f := &fakeExecutor{results: []string{`{"matching": [{"uid": "0x1"}]}`}}
+	res := execute(t, f, `mutation {
+		updatePerson(filter: {id: ["0x1"]}, set: {age: 4, nick: null},
+			remove: {friends: [{id: "0x2"}]}) { numUids }
+	}`, nil)
+
+	require.Len(t, f.mutations, 2)
+	require.Equal(t, uint64(10), f.mutations[0].startTs)
+	require.False(t, f.mutations[0].commit)
+	require.JSONEq(t, `[{"uid": "0x1", "nickname": null,
+		"Person.friends": [{"uid": "0x2"}]}]`, f.mutations[0].del)
+	require.True(t, f.mutations[1].commit)
+	require.JSONEq(t, `[{"uid": "0x1", "Person.age": 4}]`, f.mutations[1].set)
+	require.JSONEq(t, `{"data": {"updatePerson": {"numUids": 1}}}`, res)
+}
+
+func TestExecuteDelete(t *testing.T) {
+	f := &fakeExecutor{results: []string{`{"matching": [{"uid": "0x1"}, {"uid": "0x2"}]}`}}
+	res := execute(t, f, `mutation { deletePerson(filter: {age: {lt: 18}}) { msg numUids } }`,
+		nil)
+
+	require.Contains(t, f.queries[0], `@filter(lt(Person.age, 18))`)
+	require.Len(t, f.mutations, 1)
+	require.JSONEq(t, `[
+		{"uid": "0x1", "Person.name": null, "Person.age": null, "nickname": null,
+			"Person.friends": null, "dgraph.graphql.type": null},
+		{"uid": "0x2", "Person.name": null, "Person.age": null, "nickname": null,
+			"Person.friends": null, "dgraph.graphql.type": null}]`, f.mutations[0].del)
+	require.JSONEq(t, `{"data": {"deletePerson": {"msg": "Deleted", "numUids": 2}}}`, res)
+}
@@ -0,0 +1,241 @@
+/*
+ * Copyright 2018 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package graphql
+
+import (
+	"sort"
+)
+
+// The schema can be introspected as per the spec, with __schema, __type and __typename, which
+// GraphiQL and the other tools rely on. The introspection types are part of every schema, and the
+// results of introspection are maps, whose keys are the names of the fields.
+
+const introspectionSDL = `
+type __Schema {
+	description: String
+	types: [__Type!]!
+	queryType: __Type!
+	mutationType: __Type
+	subscriptionType: __Type
+	directives: [__Directive!]!
+}
+
+type __Type {
+	kind: __TypeKind!
+	name: String
+	description: String
+	specifiedByURL: String
+	fields(includeDeprecated: Boolean = false): [__Field!]
+	interfaces: [__Type!]
+	possibleTypes: [__Type!]
+	enumValues(includeDeprecated: Boolean = false): [__EnumValue!]
+	inputFields(includeDeprecated: Boolean = false): [__InputValue!]
+	ofType: __Type
+}
+
+type __Field {
+	name: String!
+	description: String
+	args(includeDeprecated: Boolean = false): [__InputValue!]!
+	type: __Type!
+	isDeprecated: Boolean!
+	deprecationReason: String
+}
+
+type __InputValue {
+	name: String!
+	description: String
+	type: __Type!
+	defaultValue: String
+	isDeprecated: Boolean!
+	deprecationReason: String
+}
+
+type __EnumValue {
+	name: String!
+	description: String
+	isDeprecated: Boolean!
+	deprecationReason: String
+}
+
+type __Directive {
+	name: String!
+	description: String
+	locations: [__DirectiveLocation!]!
+	args(includeDeprecated: Boolean = false): [__InputValue!]!
+	isRepeatable: Boolean!
+}
+
+enum __TypeKind { SCALAR OBJECT INTERFACE UNION ENUM INPUT_OBJECT LIST NON_NULL }
+
+enum __DirectiveLocation {
+	QUERY MUTATION SUBSCRIPTION FIELD FRAGMENT_DEFINITION FRAGMENT_SPREAD INLINE_FRAGMENT
+	VARIABLE_DEFINITION SCHEMA SCALAR OBJECT FIELD_DEFINITION ARGUMENT_DEFINITION INTERFACE UNION
+	ENUM ENUM_VALUE INPUT_OBJECT INPUT_FIELD_DEFINITION
+}
+`
+
+// metaTypes are the introspection types, and metaFields the fields of Query introspecting the
+// schema.
+var (
+	metaTypes  []*gqlType
+	metaFields = map[string]*gqlField{
+		"__schema": {name: "__schema", typ: nonNull(named("__Schema")), op: "schema"},
+		"__type": {name: "__type", typ: named("__Type"), op: "type",
+			args: []*gqlField{{name: "name", typ: nonNull(named("String"))}}},
+	}
+	typenameField = &gqlField{name: "__typename", typ: nonNull(named("String")), op: "typename"}
+)
+
+func init() {
+	defs, err := parseSDL(introspectionSDL)
+	if err != nil {
+		panic(err)
+	}
+	for _, def := range defs {
+		t := &gqlType{kind: kindObject, name: def.name, values: def.values}
+		if def.kind == "enum" {
+			t.kind = kindEnum
+		}
+		for _, fd := range def.fields {
+			f := &gqlField{name: fd.name, typ: fd.typ}
+			for _, a := range fd.args {
+				f.args = append(f.args, &gqlField{name: a.name, typ: a.typ, def: "false"})
+			}
+			t.fields = append(t.fields, f)
+		}
+		metaTypes = append(metaTypes, t)
+	}
+}
+
+type directiveDef struct {
+	name      string
+	desc      string
+	locations []string
+	args      []*gqlField
+}
+
+var directives = []directiveDef{
+	{name: "include", desc: "Includes the field or fragment only if the argument is true.",
+		locations: []string{"FIELD", "FRAGMENT_SPREAD", "INLINE_FRAGMENT"},
+		args:      []*gqlField{{name: "if", typ: nonNull(named("Boolean"))}}},
+	{name: "skip", desc: "Skips the field or fragment if the argument is true.",
+		locations: []string{"FIELD", "FRAGMENT_SPREAD", "INLINE_FRAGMENT"},
+		args:      []*gqlField{{name: "if", typ: nonNull(named("Boolean"))}}},
+	{name: "deprecated", desc: "Marks an element of the schema as deprecated.",
+		locations: []string{"FIELD_DEFINITION", "ENUM_VALUE"},
+		args: []*gqlField{{name: "reason", typ: named("String"),
+			def: `"No longer supported"`}}},
+}
+
+type object = map[string]interface{}
+
+// introspection returns the result of __schema, and the results of __type by name.
+func (s *Schema) introspection() (object, map[string]object) {
+	s.metaOnce.Do(func() {
+		s.metaSchema, s.metaTypes = s.buildIntrospection()
+	})
+	return s.metaSchema, s.metaTypes
+}
+
+func (s *Schema) buildIntrospection() (object, map[string]object) {
+	names := make([]string, 0, len(s.types))
+	for name := range s.types {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	// The types refer to each other, so they're all created before being filled in.
+	types := make(map[string]object, len(names))
+	for _, name := range names {
+		types[name] = object{"__typename": "__Type"}
+	}
+	var ref func(t *typeRef) object
+	ref = func(t *typeRef) object {
+		switch {
+		case t.nonNull:
+			inner := *t
+			inner.nonNull = false
+			return object{"__typename": "__Type", "kind": "NON_NULL", "ofType": ref(&inner)}
+		case t.elem != nil:
+			return object{"__typename": "__Type", "kind": "LIST", "ofType": ref(t.elem)}
+		}
+		return types[t.name]
+	}
+	inputValues := func(fs []*gqlField) []interface{} {
+		res := make([]interface{}, 0, len(fs))
+		for _, f := range fs {
+			v := object{"__typename": "__InputValue", "name": f.name, "type": ref(f.typ),
+				"isDeprecated": false}
+			if len(f.desc) > 0 {
+				v["description"] = f.desc
+			}
+			if len(f.def) > 0 {
+				v["defaultValue"] = f.def
+			}
+			res = append(res, v)
+		}
+		return res
+	}
+
+	var all []interface{}
+	for _, name := range names {
+		t := s.types[name]
+		m := types[name]
+		m["kind"], m["name"] = t.kind, t.name
+		if len(t.desc) > 0 {
+			m["description"] = t.desc
+		}
+		switch t.kind {
+		case kindObject:
+			fields := make([]interface{}, 0, len(t.fields))
+			for _, f := range t.fields {
+				fm := object{"__typename": "__Field", "name": f.name, "type": ref(f.typ),
+					"args": inputValues(f.args), "isDeprecated": false}
+				if len(f.desc) > 0 {
+					fm["description"] = f.desc
+				}
+				fields = append(fields, fm)
+			}
+			m["fields"], m["interfaces"] = fields, []interface{}{}
+		case kindInput:
+			m["inputFields"] = inputValues(t.fields)
+		case kindEnum:
+			values := make([]interface{}, 0, len(t.values))
+			for _, v := range t.values {
+				values = append(values, object{"__typename": "__EnumValue", "name": v,
+					"isDeprecated": false})
+			}
+			m["enumValues"] = values
+		}
+		all = append(all, m)
+	}
+
+	var dirs []interface{}
+	for _, d := range directives {
+		locs := make([]interface{}, 0, len(d.locations))
+		for _, l := range d.locations {
+			locs = append(locs, l)
+		}
+		dirs = append(dirs, object{"__typename": "__Directive", "name": d.name,
+			"description": d.desc, "locations": locs, "args": inputValues(d.args),
+			"isRepeatable": false})
+	}
+	schema := object{"__typename": "__Schema", "types": all, "queryType": types["Query"],
+		"mutationType": types["Mutation"], "directives": dirs}
+	return schema, types
+}
@@ -0,0 +1,291 @@
+/*
+ * Copyright 2018 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package graphql
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+type tokenKind int
+
+const (
+	tokEOF tokenKind = iota
+	tokPunct
+	tokName
+	tokInt
+	tokFloat
+	tokString
+)
+
+// Location is the position of a token in the source, from 1.
+type Location struct {
+	Line   int `json:"line"`
+	Column int `json:"column"`
+}
+
+type token struct {
+	kind tokenKind
+	val  string // The value of strings, unescaped.
+	loc  Location
+}
+
+func (t token) String() string {
+	switch t.kind {
+	case tokEOF:
+		return "end of input"
+	case tokString:
+		return strconv.Quote(t.val)
+	}
+	return fmt.Sprintf("%q", t.val)
+}
+
+// Error is an error with the location of the source it's about, as GraphQL reports them.
+type Error struct {
+	Message   string        `json:"message"`
+	Locations []Location    `json:"locations,omitempty"`
+	Path      []interface{} `json:"path,omitempty"`
+}
+
+func (e *Error) Error() string {
+	if len(e.Locations) == 0 {
+		return e.Message
+	}
+	return fmt.Sprintf("%s at line %d, column %d", e.Message, e.Locations[0].Line,
+		e.Locations[0].Column)
+}
+
+func errorAt(loc Location, format string, args ...interface{}) *Error {
+	return &Error{Message: fmt.Sprintf(format, args...), Locations: []Location{loc}}
+}
+
+// lex splits the source of a GraphQL document into tokens, as per the spec. Commas are
+// insignificant, like white space and comments.
+func lex(src string) ([]token, error) {
+	src = strings.TrimPrefix(src, "\ufeff")
+	var toks []token
+	line, lineStart := 1, 0
+	for i := 0; i < len(src); {
+		loc := Location{Line: line, Column: i - lineStart + 1}
+		c := src[i]
+		switch {
+		case c == '\n':
+			i++
+			line, lineStart = line+1, i
+		case c == ' ' || c == '\t' || c == '\r' || c == ',':
+			i++
+		case c == '#':
+			for i < len(src) && src[i] != '\n' {
+				i++
+			}
+		case strings.IndexByte("!$():=@[]{}|&", c) >= 0:
+			toks = append(toks, token{kind: tokPunct, val: string(c), loc: loc})
+			i++
+		case c == '.':
+			if !strings.HasPrefix(src[i:], "...") {
+				return nil, errorAt(loc, "Unexpected %q", ".")
+			}
+			toks = append(toks, token{kind: tokPunct, val: "...", loc: loc})
+			i += 3
+		case isNameStart(c):
+			j := i + 1
+			for j < len(src) && (isNameStart(src[j]) || isDigit(src[j])) {
+				j++
+			}
+			toks = append(toks, token{kind: tokName, val: src[i:j], loc: loc})
+			i = j
+		case c == '-' || isDigit(c):
+			t, n, err := lexNumber(src[i:], loc)
+			if err != nil {
+				return nil, err
+			}
+			toks = append(toks, t)
+			i += n
+		case c == '"':
+			if strings.HasPrefix(src[i:], `"""`) {
+				end := strings.Index(src[i+3:], `"""`)
+				for end >= 0 && src[i+3+end-1] == '\\' {
+					next := strings.Index(src[i+3+end+3:], `"""`)
+					if next < 0 {
+						end = -1
+						break
+					}
+					end += 3 + next
+				}
+				if end < 0 {
+					return nil, errorAt(loc, "Unterminated block string")
+				}
+				raw := src[i+3 : i+3+end]
+				toks = append(toks, token{kind: tokString, val: blockString(raw), loc: loc})
+				line += strings.Count(raw, "\n")
+				if k := strings.LastIndexByte(raw, '\n'); k >= 0 {
+					lineStart = i + 3 + k + 1
+				}
+				i += end + 6
+				continue
+			}
+			s, n, err := lexString(src[i:], loc)
+			if err != nil {
+				return nil, err
+			}
+			toks = append(toks, token{kind: tokString, val: s, loc: loc})
+			i += n
+		default:
+			r, _ := utf8.DecodeRuneInString(src[i:])
+			return nil, errorAt(loc, "Unexpected character %q", r)
+		}
+	}
+	toks = append(toks, token{kind: tokEOF,
+		loc: Location{Line: line, Column: len(src) - lineStart + 1}})
+	return toks, nil
+}
+
+func isNameStart(c byte) bool {
+	return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+func lexNumber(src string, loc Location) (token, int, error) {
+	i := 0
+	if src[i] == '-' {
+		i++
+	}
+	start := i
+	for i < len(src) && isDigit(src[i]) {
+		i++
+	}
+	if i == start {
+		return token{}, 0, errorAt(loc, "Invalid number, expected a digit after -")
+	}
+	kind := tokInt
+	if i < len(src) && src[i] == '.' {
+		kind = tokFloat
+		i++
+		digits := i
+		for i < len(src) && isDigit(src[i]) {
+			i++
+		}
+		if i == digits {
+			return token{}, 0, errorAt(loc, "Invalid number %q", src[:i])
+		}
+	}
+	if i < len(src) && (src[i] == 'e' || src[i] == 'E') {
+		kind = tokFloat
+		i++
+		if i < len(src) && (src[i] == '+' || src[i] == '-') {
+			i++
+		}
+		digits := i
+		for i < len(src) && isDigit(src[i]) {
+			i++
+		}
+		if i == digits {
+			return token{}, 0, errorAt(loc, "Invalid number %q", src[:i])
+		}
+	}
+	if i < len(src) && (isNameStart(src[i]) || src[i] == '.') {
+		return token{}, 0, errorAt(loc, "Invalid number %q", src[:i+1])
+	}
+	return token{kind: kind, val: src[:i], loc: loc}, i, nil
+}
+
+// lexString returns the value of the quoted string at the start of src, and its length in src.
+func lexString(src string, loc Location) (string, int, error) {
+	var b strings.Builder
+	for i := 1; i < len(src); {
+		c := src[i]
+		switch {
+		case c == '"':
+			return b.String(), i + 1, nil
+		case c == '\n':
+			return "", 0, errorAt(loc, "Unterminated string")
+		case c != '\\':
+			b.WriteByte(c)
+			i++
+			continue
+		}
+		if i+1 >= len(src) {
+			break
+		}
+		switch e := src[i+1]; e {
+		case '"', '\\', '/':
+			b.WriteByte(e)
+		case 'b':
+			b.WriteByte('\b')
+		case 'f':
+			b.WriteByte('\f')
+		case 'n':
+			b.WriteByte('\n')
+		case 'r':
+			b.WriteByte('\r')
+		case 't':
+			b.WriteByte('\t')
+		case 'u':
+			if i+6 > len(src) {
+				return "", 0, errorAt(loc, "Invalid unicode escape in string")
+			}
+			r, err := strconv.ParseUint(src[i+2:i+6], 16, 32)
+			if err != nil {
+				return "", 0, errorAt(loc, "Invalid unicode escape %q in string", src[i:i+6])
+			}
+			b.WriteRune(rune(r))
+			i += 6
+			continue
+		default:
+			return "", 0, errorAt(loc, "Invalid escape %q in string", src[i:i+2])
+		}
+		i += 2
+	}
+	return "", 0, errorAt(loc, "Unterminated string")
+}
+
+// blockString returns the value of a block string, without the common indentation of its lines
+// and the blank lines around them.
+func blockString(raw string) string {
+	lines := strings.Split(strings.Replace(raw, `\"""`, `"""`, -1), "\n")
+	indent := -1
+	for _, l := range lines[1:] {
+		trimmed := strings.TrimLeft(l, " \t")
+		if len(trimmed) == 0 {
+			continue
+		}
+		if n := len(l) - len(trimmed); indent < 0 || n < indent {
+			indent = n
+		}
+	}
+	if indent > 0 {
+		for i := 1; i < len(lines); i++ {
+			if len(lines[i]) >= indent {
+				lines[i] = lines[i][indent:]
+			} else {
+				lines[i] = ""
+			}
+		}
+	}
+	for len(lines) > 0 && len(strings.TrimSpace(lines[0])) == 0 {
+		lines = lines[1:]
+	}
+	for len(lines) > 0 && len(strings.TrimSpace(lines[len(lines)-1])) == 0 {
+		lines = lines[:len(lines)-1]
+	}
+	return strings.Join(lines, "\n")
+}
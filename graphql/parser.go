@@ -0,0 +1,748 @@
+/*
+ * Copyright 2018 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package graphql
+
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+)
+
+// document is a parsed executable GraphQL document: its operations and fragments.
+type document struct {
+	operations []*operation
+	fragments  map[string]*fragment
+}
+
+type operation struct {
+	kind       string // query, mutation or subscription.
+	name       string
+	vars       []*inputValue
+	directives []*directive
+	selections []selection
+	loc        Location
+}
+
+type fragment struct {
+	name       string
+	on         string
+	directives []*directive
+	selections []selection
+	loc        Location
+}
+
+// selection is a *field, *fragmentSpread or *inlineFragment.
+type selection interface{}
+
+type field struct {
+	alias      string
+	name       string
+	args       []*argument
+	directives []*directive
+	selections []selection
+	loc        Location
+}
+
+// key returns the key of the field in the response.
+func (f *field) key() string {
+	if len(f.alias) > 0 {
+		return f.alias
+	}
+	return f.name
+}
+
+func (f *field) arg(name string) *argument {
+	for _, a := range f.args {
+		if a.name == name {
+			return a
+		}
+	}
+	return nil
+}
+
+type fragmentSpread struct {
+	name       string
+	directives []*directive
+	loc        Location
+}
+
+type inlineFragment struct {
+	on         string
+	directives []*directive
+	selections []selection
+	loc        Location
+}
+
+type argument struct {
+	name string
+	val  *value
+	loc  Location
+}
+
+type directive struct {
+	name string
+	args []*argument
+	loc  Location
+}
+
+func (d *directive) arg(name string) *argument {
+	for _, a := range d.args {
+		if a.name == name {
+			return a
+		}
+	}
+	return nil
+}
+
+// inputValue is a variable of an operation, or an argument or input field in the SDL.
+type inputValue struct {
+	name       string
+	typ        *typeRef
+	def        *value
+	directives []*directive
+	desc       string
+	loc        Location
+}
+
+// typeRef is a reference to a type: a named one, or a list of elem. Either can be non null.
+type typeRef struct {
+	name    string
+	elem    *typeRef
+	nonNull bool
+}
+
+func (t *typeRef) String() string {
+	var s string
+	if t.elem != nil {
+		s = "[" + t.elem.String() + "]"
+	} else {
+		s = t.name
+	}
+	if t.nonNull {
+		s += "!"
+	}
+	return s
+}
+
+// named returns the name of the type a list or non null type wraps.
+func (t *typeRef) named() string {
+	for t.elem != nil {
+		t = t.elem
+	}
+	return t.name
+}
+
+type valueKind int
+
+const (
+	valVariable valueKind = iota
+	valInt
+	valFloat
+	valString
+	valBoolean
+	valNull
+	valEnum
+	valList
+	valObject
+)
+
+type value struct {
+	kind   valueKind
+	raw    string // Name of the variable, or the literal of scalars and enums.
+	list   []*value
+	fields []*argument // Fields of objects, in order.
+	loc    Location
+}
+
+// resolve returns the Go value of v, with the variables in vars: int64, float64, string, bool, nil,
+// []interface{} or map[string]interface{}. Enum values are strings.
+func (v *value) resolve(vars map[string]interface{}) (interface{}, error) {
+	switch v.kind {
+	case valVariable:
+		return vars[v.raw], nil
+	case valInt:
+		i, err := strconv.ParseInt(v.raw, 10, 64)
+		if err != nil {
+			return nil, errorAt(v.loc, "Invalid integer %s", v.raw)
+		}
+		return i, nil
+	case valFloat:
+		f, err := strconv.ParseFloat(v.raw, 64)
+		if err != nil {
+			return nil, errorAt(v.loc, "Invalid float %s", v.raw)
+		}
+		return f, nil
+	case valString, valEnum:
+		return v.raw, nil
+	case valBoolean:
+		return v.raw == "true", nil
+	case valList:
+		res := make([]interface{}, 0, len(v.list))
+		for _, elem := range v.list {
+			r, err := elem.resolve(vars)
+			if err != nil {
+				return nil, err
+			}
+			res = append(res, r)
+		}
+		return res, nil
+	case valObject:
+		res := make(map[string]interface{}, len(v.fields))
+		for _, f := range v.fields {
+			r, err := f.val.resolve(vars)
+			if err != nil {
+				return nil, err
+			}
+			res[f.name] = r
+		}
+		return res, nil
+	}
+	return nil, nil
+}
+
+// normalize turns the numbers of variables decoded from JSON with UseNumber into int64 or float64,
+// like the literals in a document.
+func normalize(v interface{}) interface{} {
+	switch v := v.(type) {
+	case json.Number:
+		if i, err := v.Int64(); err == nil {
+			return i
+		}
+		f, _ := v.Float64()
+		return f
+	case float64:
+		if i := int64(v); float64(i) == v {
+			return i
+		}
+	case []interface{}:
+		for i := range v {
+			v[i] = normalize(v[i])
+		}
+	case map[string]interface{}:
+		for k := range v {
+			v[k] = normalize(v[k])
+		}
+	}
+	return v
+}
+
+type parser struct {
+	toks []token
+	pos  int
+}
+
+func newParser(src string) (*parser, error) {
+	toks, err := lex(src)
+	if err != nil {
+		return nil, err
+	}
+	return &parser{toks: toks}, nil
+}
+
+func (p *parser) peek() token {
+	return p.toks[p.pos]
+}
+
+func (p *parser) next() token {
+	t := p.toks[p.pos]
+	if t.kind != tokEOF {
+		p.pos++
+	}
+	return t
+}
+
+func (p *parser) unexpected(t token) *Error {
+	return errorAt(t.loc, "Unexpected %s", t)
+}
+
+// isPunct tells whether the next token is the punctuator s.
+func (p *parser) isPunct(s string) bool {
+	t := p.peek()
+	return t.kind == tokPunct && t.val == s
+}
+
+// skip consumes the next token if it's the punctuator s.
+func (p *parser) skip(s string) bool {
+	if p.isPunct(s) {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+func (p *parser) expect(s string) error {
+	if t := p.next(); t.kind != tokPunct || t.val != s {
+		return errorAt(t.loc, "Expected %q, got %s", s, t)
+	}
+	return nil
+}
+
+func (p *parser) name() (string, error) {
+	t := p.next()
+	if t.kind != tokName {
+		return "", errorAt(t.loc, "Expected a name, got %s", t)
+	}
+	return t.val, nil
+}
+
+// isKeyword tells whether the next token is the name kw.
+func (p *parser) isKeyword(kw string) bool {
+	t := p.peek()
+	return t.kind == tokName && t.val == kw
+}
+
+// parseDocument parses an executable document, with operations and fragments.
+func parseDocument(src string) (*document, error) {
+	p, err := newParser(src)
+	if err != nil {
+		return nil, err
+	}
+	doc := &document{fragments: make(map[string]*fragment)}
+	for p.peek().kind != tokEOF {
+		t := p.peek()
+		switch {
+		case t.kind == tokPunct && t.val == "{":
+			sels, err := p.selectionSet()
+			if err != nil {
+				return nil, err
+			}
+			doc.operations = append(doc.operations,
+				&operation{kind: "query", selections: sels, loc: t.loc})
+		case t.kind == tokName && (t.val == "query" || t.val == "mutation" ||
+			t.val == "subscription"):
+			op, err := p.operation()
+			if err != nil {
+				return nil, err
+			}
+			doc.operations = append(doc.operations, op)
+		case t.kind == tokName && t.val == "fragment":
+			f, err := p.fragment()
+			if err != nil {
+				return nil, err
+			}
+			if _, ok := doc.fragments[f.name]; ok {
+				return nil, errorAt(f.loc, "There can be only one fragment named %q", f.name)
+			}
+			doc.fragments[f.name] = f
+		default:
+			return nil, p.unexpected(t)
+		}
+	}
+	if len(doc.operations) == 0 {
+		return nil, &Error{Message: "The document has no operation"}
+	}
+	return doc, nil
+}
+
+func (p *parser) operation() (*operation, error) {
+	t := p.next()
+	op := &operation{kind: t.val, loc: t.loc}
+	var err error
+	if p.peek().kind == tokName {
+		op.name = p.next().val
+	}
+	if p.skip("(") {
+		for !p.skip(")") {
+			if err := p.expect("$"); err != nil {
+				return nil, err
+			}
+			v, err := p.inputValue()
+			if err != nil {
+				return nil, err
+			}
+			op.vars = append(op.vars, v)
+		}
+	}
+	if op.directives, err = p.directives(); err != nil {
+		return nil, err
+	}
+	if op.selections, err = p.selectionSet(); err != nil {
+		return nil, err
+	}
+	return op, nil
+}
+
+func (p *parser) fragment() (*fragment, error) {
+	f := &fragment{loc: p.next().loc}
+	var err error
+	if f.name, err = p.name(); err != nil {
+		return nil, err
+	}
+	if f.name == "on" {
+		return nil, errorAt(f.loc, "Fragments can't be named \"on\"")
+	}
+	if !p.isKeyword("on") {
+		return nil, errorAt(p.peek().loc, "Expected \"on\", got %s", p.peek())
+	}
+	p.next()
+	if f.on, err = p.name(); err != nil {
+		return nil, err
+	}
+	if f.directives, err = p.directives(); err != nil {
+		return nil, err
+	}
+	if f.selections, err = p.selectionSet(); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+// inputValue parses name: Type = default @directives, of a variable or an SDL argument.
+func (p *parser) inputValue() (*inputValue, error) {
+	v := &inputValue{loc: p.peek().loc}
+	if p.peek().kind == tokString {
+		v.desc = p.next().val
+	}
+	var err error
+	if v.name, err = p.name(); err != nil {
+		return nil, err
+	}
+	if err := p.expect(":"); err != nil {
+		return nil, err
+	}
+	if v.typ, err = p.typeRef(); err != nil {
+		return nil, err
+	}
+	if p.skip("=") {
+		if v.def, err = p.value(true); err != nil {
+			return nil, err
+		}
+	}
+	if v.directives, err = p.directives(); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+func (p *parser) typeRef() (*typeRef, error) {
+	t := &typeRef{}
+	if p.skip("[") {
+		elem, err := p.typeRef()
+		if err != nil {
+			return nil, err
+		}
+		if err := p.expect("]"); err != nil {
+			return nil, err
+		}
+		t.elem = elem
+	} else {
+		var err error
+		if t.name, err = p.name(); err != nil {
+			return nil, err
+		}
+	}
+	t.nonNull = p.skip("!")
+	return t, nil
+}
+
+func (p *parser) selectionSet() ([]selection, error) {
+	if err := p.expect("{"); err != nil {
+		return nil, err
+	}
+	var sels []selection
+	for !p.skip("}") {
+		if p.peek().kind == tokEOF {
+			return nil, p.unexpected(p.peek())
+		}
+		sel, err := p.selection()
+		if err != nil {
+			return nil, err
+		}
+		sels = append(sels, sel)
+	}
+	if len(sels) == 0 {
+		return nil, errorAt(p.toks[p.pos-1].loc, "Selection sets can't be empty")
+	}
+	return sels, nil
+}
+
+func (p *parser) selection() (selection, error) {
+	var err error
+	if t := p.peek(); t.kind == tokPunct && t.val == "..." {
+		p.next()
+		if p.peek().kind == tokName && !p.isKeyword("on") {
+			s := &fragmentSpread{name: p.next().val, loc: t.loc}
+			if s.directives, err = p.directives(); err != nil {
+				return nil, err
+			}
+			return s, nil
+		}
+		f := &inlineFragment{loc: t.loc}
+		if p.isKeyword("on") {
+			p.next()
+			if f.on, err = p.name(); err != nil {
+				return nil, err
+			}
+		}
+		if f.directives, err = p.directives(); err != nil {
+			return nil, err
+		}
+		if f.selections, err = p.selectionSet(); err != nil {
+			return nil, err
+		}
+		return f, nil
+	}
+
+	f := &field{loc: p.peek().loc}
+	if f.name, err = p.name(); err != nil {
+		return nil, err
+	}
+	if p.skip(":") {
+		f.alias = f.name
+		if f.name, err = p.name(); err != nil {
+			return nil, err
+		}
+	}
+	if f.args, err = p.arguments(false); err != nil {
+		return nil, err
+	}
+	if f.directives, err = p.directives(); err != nil {
+		return nil, err
+	}
+	if p.isPunct("{") {
+		if f.selections, err = p.selectionSet(); err != nil {
+			return nil, err
+		}
+	}
+	return f, nil
+}
+
+func (p *parser) arguments(constant bool) ([]*argument, error) {
+	if !p.skip("(") {
+		return nil, nil
+	}
+	var args []*argument
+	for !p.skip(")") {
+		a := &argument{loc: p.peek().loc}
+		var err error
+		if a.name, err = p.name(); err != nil {
+			return nil, err
+		}
+		for _, prev := range args {
+			if prev.name == a.name {
+				return nil, errorAt(a.loc, "There can be only one argument named %q", a.name)
+			}
+		}
+		if err := p.expect(":"); err != nil {
+			return nil, err
+		}
+		if a.val, err = p.value(constant); err != nil {
+			return nil, err
+		}
+		args = append(args, a)
+	}
+	return args, nil
+}
+
+func (p *parser) directives() ([]*directive, error) {
+	var dirs []*directive
+	for p.isPunct("@") {
+		d := &directive{loc: p.next().loc}
+		var err error
+		if d.name, err = p.name(); err != nil {
+			return nil, err
+		}
+		if d.args, err = p.arguments(true); err != nil {
+			return nil, err
+		}
+		dirs = append(dirs, d)
+	}
+	return dirs, nil
+}
+
+// value parses a value, which can't have variables if constant.
+func (p *parser) value(constant bool) (*value, error) {
+	t := p.next()
+	v := &value{raw: t.val, loc: t.loc}
+	switch t.kind {
+	case tokInt:
+		v.kind = valInt
+	case tokFloat:
+		v.kind = valFloat
+	case tokString:
+		v.kind = valString
+	case tokName:
+		switch t.val {
+		case "true", "false":
+			v.kind = valBoolean
+		case "null":
+			v.kind = valNull
+		default:
+			v.kind = valEnum
+		}
+	case tokPunct:
+		switch t.val {
+		case "$":
+			if constant {
+				return nil, errorAt(t.loc, "Variables can't be used here")
+			}
+			name, err := p.name()
+			if err != nil {
+				return nil, err
+			}
+			v.kind, v.raw = valVariable, name
+		case "[":
+			v.kind = valList
+			for !p.skip("]") {
+				elem, err := p.value(constant)
+				if err != nil {
+					return nil, err
+				}
+				v.list = append(v.list, elem)
+			}
+		case "{":
+			v.kind = valObject
+			for !p.skip("}") {
+				f := &argument{loc: p.peek().loc}
+				var err error
+				if f.name, err = p.name(); err != nil {
+					return nil, err
+				}
+				if err := p.expect(":"); err != nil {
+					return nil, err
+				}
+				if f.val, err = p.value(constant); err != nil {
+					return nil, err
+				}
+				v.fields = append(v.fields, f)
+			}
+		default:
+			return nil, p.unexpected(t)
+		}
+	default:
+		return nil, p.unexpected(t)
+	}
+	return v, nil
+}
+
+// typeDef is a definition of a type in the SDL.
+type typeDef struct {
+	kind       string // type, input, enum or scalar.
+	name       string
+	desc       string
+	fields     []*fieldDef
+	values     []string // Of enums.
+	directives []*directive
+	loc        Location
+}
+
+type fieldDef struct {
+	name       string
+	desc       string
+	args       []*inputValue
+	typ        *typeRef
+	directives []*directive
+	loc        Location
+}
+
+// parseSDL parses the definitions of types in the GraphQL schema definition language.
+func parseSDL(src string) ([]*typeDef, error) {
+	p, err := newParser(src)
+	if err != nil {
+		return nil, err
+	}
+	var defs []*typeDef
+	for p.peek().kind != tokEOF {
+		def := &typeDef{loc: p.peek().loc}
+		if p.peek().kind == tokString {
+			def.desc = p.next().val
+		}
+		t := p.next()
+		if t.kind != tokName {
+			return nil, p.unexpected(t)
+		}
+		switch t.val {
+		case "type", "input", "enum", "scalar":
+			def.kind = t.val
+		case "interface", "union", "schema", "directive", "extend":
+			return nil, errorAt(t.loc, "%s definitions aren't supported", strings.Title(t.val))
+		default:
+			return nil, p.unexpected(t)
+		}
+		if def.name, err = p.name(); err != nil {
+			return nil, err
+		}
+		if p.isKeyword("implements") {
+			return nil, errorAt(p.peek().loc, "Interfaces aren't supported")
+		}
+		if def.directives, err = p.directives(); err != nil {
+			return nil, err
+		}
+		switch def.kind {
+		case "enum":
+			if err := p.expect("{"); err != nil {
+				return nil, err
+			}
+			for !p.skip("}") {
+				if p.peek().kind == tokString {
+					p.next()
+				}
+				name, err := p.name()
+				if err != nil {
+					return nil, err
+				}
+				if _, err := p.directives(); err != nil {
+					return nil, err
+				}
+				def.values = append(def.values, name)
+			}
+		case "type", "input":
+			if err := p.expect("{"); err != nil {
+				return nil, err
+			}
+			for !p.skip("}") {
+				f, err := p.fieldDef()
+				if err != nil {
+					return nil, err
+				}
+				def.fields = append(def.fields, f)
+			}
+		}
+		defs = append(defs, def)
+	}
+	return defs, nil
+}
+
+func (p *parser) fieldDef() (*fieldDef, error) {
+	f := &fieldDef{loc: p.peek().loc}
+	if p.peek().kind == tokString {
+		f.desc = p.next().val
+	}
+	var err error
+	if f.name, err = p.name(); err != nil {
+		return nil, err
+	}
+	if p.skip("(") {
+		for !p.skip(")") {
+			a, err := p.inputValue()
+			if err != nil {
+				return nil, err
+			}
+			f.args = append(f.args, a)
+		}
+	}
+	if err := p.expect(":"); err != nil {
+		return nil, err
+	}
+	if f.typ, err = p.typeRef(); err != nil {
+		return nil, err
+	}
+	if f.directives, err = p.directives(); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
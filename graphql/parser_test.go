@@ -0,0 +1,105 @@
+/*
+ * Copyright 2018 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package graphql
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseDocument(t *testing.T) {
+	doc, err := parseDocument(`
+		query Q($id: ID!, $first: Int = 10) {
+			p: getPerson(id: $id) { name ...F }
+		}
+		fragment F on Person { friends(first: $first) { name } }
+	`)
+	require.NoError(t, err)
+	require.Len(t, doc.operations, 1)
+	require.Len(t, doc.fragments, 1)
+
+	op := doc.operations[0]
+	require.Equal(t, "query", op.kind)
+	require.Equal(t, "Q", op.name)
+	require.Len(t, op.vars, 2)
+	require.Equal(t, "ID!", op.vars[0].typ.String())
+	require.NotNil(t, op.vars[1].def)
+
+	f := op.selections[0].(*field)
+	require.Equal(t, "p", f.key())
+	require.Equal(t, "getPerson", f.name)
+	v, err := f.arg("id").val.resolve(map[string]interface{}{"id": "0x1"})
+	require.NoError(t, err)
+	require.Equal(t, "0x1", v)
+	require.IsType(t, &fragmentSpread{}, f.selections[1])
+}
+
+func TestParseValues(t *testing.T) {
+	doc, err := parseDocument(`{ f(a: [1, 2.5, "s\né", true, null, RED], b: {c: """ block
+		string """}) }`)
+	require.NoError(t, err)
+	f := doc.operations[0].selections[0].(*field)
+
+	a, err := f.arg("a").val.resolve(nil)
+	require.NoError(t, err)
+	require.Equal(t, []interface{}{int64(1), 2.5, "s\né", true, nil, "RED"}, a)
+	b, err := f.arg("b").val.resolve(nil)
+	require.NoError(t, err)
+	require.Equal(t, map[string]interface{}{"c": " block\nstring "}, b)
+}
+
+func TestParseErrors(t *testing.T) {
+	for _, q := range []string{
+		`{ f(a: ) }`,
+		`{ f`,
+		`{ f(a: "unterminated) }`,
+		`query Q($a) { f }`,
+		`{ f } extra`,
+	} {
+		_, err := parseDocument(q)
+		require.Error(t, err, q)
+		require.IsType(t, &Error{}, err, q)
+		require.NotEmpty(t, err.(*Error).Locations, q)
+	}
+}
+
+func TestParseSDL(t *testing.T) {
+	defs, err := parseSDL(`
+		"People"
+		type Person {
+			name: String! @search(by: [term])
+			friends: [Person!]
+		}
+		enum Color { RED GREEN }
+	`)
+	require.NoError(t, err)
+	require.Len(t, defs, 2)
+	require.Equal(t, "People", defs[0].desc)
+	require.Equal(t, "[Person!]", defs[0].fields[1].typ.String())
+	require.Equal(t, []string{"RED", "GREEN"}, defs[1].values)
+
+	for _, sdl := range []string{
+		`interface Named { name: String }`,
+		`type A implements B { a: Int }`,
+		`union U = A | B`,
+		`schema { query: Q }`,
+	} {
+		_, err := parseSDL(sdl)
+		require.Error(t, err, sdl)
+	}
+}
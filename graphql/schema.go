@@ -0,0 +1,738 @@
+/*
+ * Copyright 2018 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package graphql
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"unicode"
+
+	"github.com/dgraph-io/dgraph/tok"
+	"github.com/dgraph-io/dgraph/x"
+)
+
+// A GraphQL schema has the types of the nodes, given in the SDL or generated from the predicates
+// of the Dgraph schema. The API is generated from them: the queries getT and queryT, and the
+// mutations addT, updateT and deleteT of every type T, with the input types they take.
+
+// TypePredicate holds the type of the nodes added through GraphQL, for schemas given in the SDL.
+// The nodes of a type are those with it in this predicate.
+const TypePredicate = "dgraph.graphql.type"
+
+// Kinds of types, as introspection reports them.
+const (
+	kindScalar = "SCALAR"
+	kindObject = "OBJECT"
+	kindInput  = "INPUT_OBJECT"
+	kindEnum   = "ENUM"
+)
+
+type gqlType struct {
+	kind   string
+	name   string
+	desc   string
+	fields []*gqlField // Of objects and input objects.
+	values []string    // Of enums.
+	// node is set for the types of nodes, and idField to the name of their ID field if any.
+	node    bool
+	idField string
+}
+
+// userEnum tells whether t is an enum of the schema, rather than of introspection.
+func (t *gqlType) userEnum() bool {
+	return t.kind == kindEnum && !strings.HasPrefix(t.name, "__")
+}
+
+func (t *gqlType) field(name string) *gqlField {
+	for _, f := range t.fields {
+		if f.name == name {
+			return f
+		}
+	}
+	return nil
+}
+
+type gqlField struct {
+	name string
+	desc string
+	args []*gqlField // The arguments of fields, which have a typ and def.
+	typ  *typeRef
+	def  string // Default value of arguments and input fields, as a GraphQL literal.
+
+	// For the fields of the types of nodes, pred is the predicate the field is stored in, uid for
+	// the ID, and search has the tokenizers of its index.
+	pred   string
+	search []string
+	// For the fields of Query, Mutation and the payloads, op is what they do, to the nodes of
+	// type node.
+	op   string
+	node *gqlType
+}
+
+func (f *gqlField) arg(name string) *gqlField {
+	for _, a := range f.args {
+		if a.name == name {
+			return a
+		}
+	}
+	return nil
+}
+
+// Schema is the GraphQL schema used to serve a GraphQL API.
+type Schema struct {
+	types map[string]*gqlType
+	nodes []*gqlType // The types of nodes, in order of definition.
+	// typed is set for schemas given in the SDL, whose nodes are told apart by TypePredicate.
+	typed bool
+	sdl   string
+
+	metaOnce   sync.Once
+	metaSchema object
+	metaTypes  map[string]object
+}
+
+var builtinScalars = map[string]string{
+	"ID":       "The id of a node, its uid in Dgraph.",
+	"String":   "",
+	"Int":      "",
+	"Float":    "",
+	"Boolean":  "",
+	"DateTime": "A date and time, in RFC 3339 format.",
+}
+
+// valueType returns the Dgraph type of the values of scalars.
+func valueType(scalar string) string {
+	switch scalar {
+	case "Int":
+		return "int"
+	case "Float":
+		return "float"
+	case "Boolean":
+		return "bool"
+	case "DateTime":
+		return "datetime"
+	}
+	return "string"
+}
+
+// defaultSearch is the tokenizer of @search without arguments, per Dgraph type.
+var defaultSearch = map[string]string{
+	"string":   "term",
+	"int":      "int",
+	"float":    "float",
+	"bool":     "bool",
+	"datetime": "year",
+}
+
+var reservedNames = map[string]bool{
+	"Query": true, "Mutation": true, "Subscription": true, "DeletePayload": true,
+	"StringFilter": true, "IntFilter": true, "FloatFilter": true, "BooleanFilter": true,
+	"DateTimeFilter": true, "IDFilter": true,
+}
+
+func newSchema() *Schema {
+	s := &Schema{types: make(map[string]*gqlType)}
+	for name, desc := range builtinScalars {
+		s.types[name] = &gqlType{kind: kindScalar, name: name, desc: desc}
+	}
+	for _, t := range metaTypes {
+		s.types[t.name] = t
+	}
+	return s
+}
+
+// ParseSchema parses the types of the nodes of a GraphQL schema, in the SDL. The fields of type T
+// are stored in the predicates T.field, or in the one given with @dgraph(pred: "..."), and can be
+// indexed with @search(by: [...]), with the tokenizers of Dgraph. The field of type ID, if any,
+// is the uid of the node.
+func ParseSchema(sdl string) (*Schema, error) {
+	defs, err := parseSDL(sdl)
+	if err != nil {
+		return nil, err
+	}
+	s := newSchema()
+	s.typed, s.sdl = true, sdl
+
+	// Every type can be referred to before it's defined.
+	for _, def := range defs {
+		if def.kind != "type" && def.kind != "enum" {
+			return nil, errorAt(def.loc, "Only object types and enums can be defined, not %s %s",
+				def.kind, def.name)
+		}
+		if err := checkTypeName(def.name); err != nil {
+			return nil, errorAt(def.loc, "%v", err)
+		}
+		if _, ok := s.types[def.name]; ok {
+			return nil, errorAt(def.loc, "There can be only one type named %q", def.name)
+		}
+		t := &gqlType{kind: kindObject, name: def.name, desc: def.desc, node: true}
+		if def.kind == "enum" {
+			t = &gqlType{kind: kindEnum, name: def.name, desc: def.desc, values: def.values}
+			if len(t.values) == 0 {
+				return nil, errorAt(def.loc, "Enum %s must have values", def.name)
+			}
+		} else {
+			s.nodes = append(s.nodes, t)
+		}
+		s.types[def.name] = t
+	}
+	preds := make(map[string]string)
+	for _, def := range defs {
+		if def.kind != "type" {
+			continue
+		}
+		t := s.types[def.name]
+		if len(def.fields) == 0 {
+			return nil, errorAt(def.loc, "Type %s must have fields", def.name)
+		}
+		for _, fd := range def.fields {
+			f, err := s.nodeField(t, fd)
+			if err != nil {
+				return nil, err
+			}
+			if t.field(f.name) != nil {
+				return nil, errorAt(fd.loc, "Type %s has two fields named %q", t.name, f.name)
+			}
+			if f.pred != "uid" {
+				// A predicate has a single type in Dgraph.
+				typ := f.typ.String()
+				if prev, ok := preds[f.pred]; ok && prev != strings.TrimSuffix(typ, "!") {
+					return nil, errorAt(fd.loc, "Predicate %s is used by fields of types %s and %s",
+						f.pred, prev, typ)
+				}
+				preds[f.pred] = strings.TrimSuffix(typ, "!")
+			}
+			t.fields = append(t.fields, f)
+		}
+	}
+	if len(s.nodes) == 0 {
+		return nil, &Error{Message: "The schema must define at least one type"}
+	}
+	if err := s.checkNames(); err != nil {
+		return nil, &Error{Message: err.Error()}
+	}
+	s.build()
+	return s, nil
+}
+
+func checkTypeName(name string) error {
+	switch {
+	case strings.HasPrefix(name, "__"):
+		return x.Errorf("Names starting with __ are reserved")
+	case reservedNames[name]:
+		return x.Errorf("Type name %s is reserved", name)
+	}
+	if _, ok := builtinScalars[name]; ok {
+		return x.Errorf("Type name %s is the name of a scalar", name)
+	}
+	return nil
+}
+
+// generatedNames returns the names of the types generated for the type of nodes or enum t.
+func generatedNames(t *gqlType) []string {
+	if t.kind == kindEnum {
+		return []string{t.name + "Filter"}
+	}
+	return []string{t.name + "Filter", t.name + "Order", t.name + "Orderable",
+		"Add" + t.name + "Input", t.name + "Patch", t.name + "Ref", "Add" + t.name + "Payload",
+		"Update" + t.name + "Payload"}
+}
+
+// checkNames checks that the types generated don't have the names of others.
+func (s *Schema) checkNames() error {
+	names := make(map[string]string)
+	for _, t := range s.types {
+		if t.node || t.userEnum() {
+			for _, name := range generatedNames(t) {
+				if other, ok := names[name]; ok {
+					return x.Errorf("Types %s and %s would both generate type %s", other, t.name,
+						name)
+				}
+				if _, ok := s.types[name]; ok {
+					return x.Errorf("Type %s has the name of a type generated for %s", name,
+						t.name)
+				}
+				names[name] = t.name
+			}
+		}
+	}
+	return nil
+}
+
+// nodeField returns the field of the type of nodes t defined by fd.
+func (s *Schema) nodeField(t *gqlType, fd *fieldDef) (*gqlField, error) {
+	if strings.HasPrefix(fd.name, "__") {
+		return nil, errorAt(fd.loc, "Names starting with __ are reserved")
+	}
+	if len(fd.args) > 0 {
+		return nil, errorAt(fd.loc, "Fields of types can't have arguments, they're generated")
+	}
+	f := &gqlField{name: fd.name, desc: fd.desc, typ: fd.typ, pred: t.name + "." + fd.name}
+	if fd.typ.elem != nil && fd.typ.elem.elem != nil {
+		return nil, errorAt(fd.loc, "Lists of lists aren't supported")
+	}
+	named, ok := s.types[fd.typ.named()]
+	if !ok {
+		return nil, errorAt(fd.loc, "Unknown type %s of field %s.%s", fd.typ.named(), t.name,
+			fd.name)
+	}
+	if named.name == "ID" {
+		if fd.typ.elem != nil {
+			return nil, errorAt(fd.loc, "Field %s.%s can't be a list of IDs", t.name, fd.name)
+		}
+		if len(t.idField) > 0 {
+			return nil, errorAt(fd.loc, "Type %s has two ID fields", t.name)
+		}
+		t.idField = fd.name
+		f.pred = "uid"
+		f.typ = &typeRef{name: "ID", nonNull: true}
+	}
+
+	for _, d := range fd.directives {
+		switch d.name {
+		case "dgraph":
+			a := d.arg("pred")
+			if a == nil || a.val.kind != valString || len(a.val.raw) == 0 {
+				return nil, errorAt(d.loc, "@dgraph takes the name of a predicate, as pred: \"...\"")
+			}
+			if f.pred == "uid" {
+				return nil, errorAt(d.loc, "The ID field is always the uid of the node")
+			}
+			if strings.HasPrefix(a.val.raw, "dgraph.") {
+				return nil, errorAt(d.loc, "Predicates starting with dgraph. are reserved")
+			}
+			f.pred = a.val.raw
+		case "search":
+			if named.kind == kindObject || named.name == "ID" {
+				return nil, errorAt(d.loc, "Only fields of scalars and enums can be searched")
+			}
+			vt := valueType(named.name)
+			a := d.arg("by")
+			if a == nil {
+				tokenizer := defaultSearch[vt]
+				if named.kind == kindEnum {
+					tokenizer = "hash"
+				}
+				f.search = []string{tokenizer}
+				continue
+			}
+			vals := a.val.list
+			if a.val.kind == valEnum {
+				vals = []*value{a.val}
+			}
+			for _, v := range vals {
+				if v.kind != valEnum && v.kind != valString {
+					return nil, errorAt(v.loc, "@search takes the names of tokenizers")
+				}
+				tokenizer, ok := tok.GetTokenizer(v.raw)
+				if !ok {
+					return nil, errorAt(v.loc, "Unknown tokenizer %s", v.raw)
+				}
+				if tokenizer.Type() != vt {
+					return nil, errorAt(v.loc, "Tokenizer %s can't index field %s of type %s",
+						v.raw, fd.name, named.name)
+				}
+				f.search = append(f.search, v.raw)
+			}
+		default:
+			return nil, errorAt(d.loc, "Unknown directive @%s", d.name)
+		}
+	}
+	return f, nil
+}
+
+// Predicate is a predicate of the Dgraph schema.
+type Predicate struct {
+	Name       string
+	Type       string // The Dgraph type, like string or uid.
+	List       bool
+	Tokenizers []string
+}
+
+// NodeType is the type of the nodes of schemas generated from the Dgraph schema. It has a field
+// for every predicate.
+const NodeType = "Node"
+
+// FromPredicates generates a GraphQL schema from the predicates of the Dgraph schema. The
+// predicates named T.field are the fields of type T, and all of them the fields of type Node, as
+// far as they can be given GraphQL names. Edges lead to nodes of type Node. Without a type
+// predicate, the nodes of a type are queried as the ones with a value for the first field of the
+// filter, or of the type.
+func FromPredicates(preds []Predicate) *Schema {
+	s := newSchema()
+	sort.Slice(preds, func(i, j int) bool { return preds[i].Name < preds[j].Name })
+
+	node := &gqlType{kind: kindObject, name: NodeType, node: true, idField: "id",
+		desc: "A node, with every predicate of the Dgraph schema."}
+	types := map[string]*gqlType{NodeType: node}
+	add := func(t *gqlType, name string, p Predicate) {
+		f := &gqlField{name: name, pred: p.Name, search: p.Tokenizers}
+		scalar := graphqlType(p.Type)
+		switch {
+		case scalar == "":
+			return
+		case p.Type == "uid":
+			// Edges always lead to many nodes.
+			f.typ = &typeRef{elem: &typeRef{name: NodeType, nonNull: true}}
+			f.search = nil
+		case p.List:
+			f.typ = &typeRef{elem: &typeRef{name: scalar, nonNull: true}}
+		default:
+			f.typ = &typeRef{name: scalar}
+		}
+		names := make(map[string]bool)
+		for _, prev := range t.fields {
+			names[prev.name] = true
+		}
+		f.name = uniqueName(name, names)
+		t.fields = append(t.fields, f)
+	}
+	node.fields = append(node.fields, &gqlField{name: "id", pred: "uid",
+		typ: &typeRef{name: "ID", nonNull: true}})
+	for _, p := range preds {
+		if strings.HasPrefix(p.Name, "dgraph.") || strings.HasPrefix(p.Name, "_") {
+			continue
+		}
+		add(node, graphqlName(p.Name), p)
+		idx := strings.IndexByte(p.Name, '.')
+		if idx <= 0 || idx == len(p.Name)-1 {
+			continue
+		}
+		typeName := graphqlName(p.Name[:idx])
+		if typeName == NodeType || checkTypeName(typeName) != nil ||
+			!unicode.IsUpper(rune(typeName[0])) {
+			continue
+		}
+		t, ok := types[typeName]
+		if !ok {
+			t = &gqlType{kind: kindObject, name: typeName, node: true, idField: "id",
+				fields: []*gqlField{{name: "id", pred: "uid",
+					typ: &typeRef{name: "ID", nonNull: true}}}}
+			types[typeName] = t
+			s.nodes = append(s.nodes, t)
+		}
+		add(t, graphqlName(p.Name[idx+1:]), p)
+	}
+	s.nodes = append([]*gqlType{node}, s.nodes...)
+	for _, t := range s.nodes {
+		s.types[t.name] = t
+	}
+	if err := s.checkNames(); err != nil {
+		// Only with type names like those generated, which Node can't collide with.
+		for _, t := range s.nodes[1:] {
+			delete(s.types, t.name)
+		}
+		s.nodes = s.nodes[:1]
+	}
+	s.build()
+	return s
+}
+
+// graphqlType returns the GraphQL scalar of values of the Dgraph type, or empty if it has none.
+func graphqlType(dgraphType string) string {
+	switch dgraphType {
+	case "string", "default":
+		return "String"
+	case "int":
+		return "Int"
+	case "float":
+		return "Float"
+	case "bool":
+		return "Boolean"
+	case "datetime":
+		return "DateTime"
+	case "uid":
+		return NodeType
+	}
+	return ""
+}
+
+// graphqlName returns name with the characters which can't be part of GraphQL names replaced by _.
+func graphqlName(name string) string {
+	var b strings.Builder
+	for i, r := range name {
+		switch {
+		case r == '_' || (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z'):
+			b.WriteRune(r)
+		case r >= '0' && r <= '9':
+			if i == 0 {
+				b.WriteByte('_')
+			}
+			b.WriteRune(r)
+		default:
+			b.WriteByte('_')
+		}
+	}
+	s := b.String()
+	if strings.HasPrefix(s, "__") {
+		s = "f" + s
+	}
+	return s
+}
+
+// uniqueName returns name, with a number appended if it's in names already, and adds it to them.
+func uniqueName(name string, names map[string]bool) string {
+	unique := name
+	for i := 2; names[unique]; i++ {
+		unique = fmt.Sprintf("%s%d", name, i)
+	}
+	names[unique] = true
+	return unique
+}
+
+func lowerFirst(s string) string {
+	return strings.ToLower(s[:1]) + s[1:]
+}
+
+func named(name string) *typeRef { return &typeRef{name: name} }
+
+func nonNull(t *typeRef) *typeRef {
+	c := *t
+	c.nonNull = true
+	return &c
+}
+
+func listOf(t *typeRef) *typeRef { return &typeRef{elem: t} }
+
+// scalarFilters has the operators of the filters of scalars, and the Dgraph functions they're
+// turned into.
+var scalarFilters = map[string][]string{
+	"String": {"eq", "le", "lt", "ge", "gt", "anyofterms", "allofterms", "anyoftext",
+		"alloftext", "regexp"},
+	"Int":      {"eq", "le", "lt", "ge", "gt"},
+	"Float":    {"eq", "le", "lt", "ge", "gt"},
+	"DateTime": {"eq", "le", "lt", "ge", "gt"},
+	"Boolean":  {"eq"},
+}
+
+// build generates the types of the API from the types of the nodes.
+func (s *Schema) build() {
+	for _, scalar := range []string{"String", "Int", "Float", "DateTime", "Boolean"} {
+		t := &gqlType{kind: kindInput, name: scalar + "Filter"}
+		for _, op := range scalarFilters[scalar] {
+			t.fields = append(t.fields, &gqlField{name: op, typ: named(scalar)})
+		}
+		s.types[t.name] = t
+	}
+	s.types["DeletePayload"] = &gqlType{kind: kindObject, name: "DeletePayload", fields: []*gqlField{
+		{name: "msg", typ: named("String"), op: "msg"},
+		{name: "numUids", typ: named("Int"), op: "numUids"},
+	}}
+
+	query := &gqlType{kind: kindObject, name: "Query"}
+	mutation := &gqlType{kind: kindObject, name: "Mutation"}
+	s.types[query.name], s.types[mutation.name] = query, mutation
+	for _, e := range s.types {
+		if e.userEnum() {
+			s.types[e.name+"Filter"] = &gqlType{kind: kindInput, name: e.name + "Filter",
+				fields: []*gqlField{{name: "eq", typ: named(e.name)}}}
+		}
+	}
+
+	for _, t := range s.nodes {
+		s.buildNode(t)
+		listArgs := s.listArgs(t)
+		if len(t.idField) > 0 {
+			query.fields = append(query.fields, &gqlField{name: "get" + t.name, op: "get", node: t,
+				desc: fmt.Sprintf("Returns the %s with the id, if any.", t.name),
+				typ:  named(t.name),
+				args: []*gqlField{{name: "id", typ: nonNull(named("ID"))}}})
+		}
+		query.fields = append(query.fields, &gqlField{name: "query" + t.name, op: "query",
+			node: t, typ: listOf(named(t.name)), args: listArgs,
+			desc: fmt.Sprintf("Returns the nodes of type %s matching the filter.", t.name)})
+
+		payload := func(name string) *gqlType {
+			p := &gqlType{kind: kindObject, name: name, fields: []*gqlField{
+				{name: lowerFirst(t.name), typ: listOf(named(t.name)), args: listArgs,
+					op: "nodes", node: t},
+				{name: "numUids", typ: named("Int"), op: "numUids"},
+			}}
+			s.types[name] = p
+			return p
+		}
+		filter := nonNull(named(t.name + "Filter"))
+		mutation.fields = append(mutation.fields,
+			&gqlField{name: "add" + t.name, op: "add", node: t,
+				desc: fmt.Sprintf("Adds nodes of type %s.", t.name),
+				typ:  named(payload("Add" + t.name + "Payload").name),
+				args: []*gqlField{{name: "input",
+					typ: nonNull(listOf(nonNull(named("Add" + t.name + "Input"))))}}},
+			&gqlField{name: "update" + t.name, op: "update", node: t,
+				desc: fmt.Sprintf("Sets and removes the values of the nodes of type %s matching"+
+					" the filter.", t.name),
+				typ: named(payload("Update" + t.name + "Payload").name),
+				args: []*gqlField{{name: "filter", typ: filter},
+					{name: "set", typ: named(t.name + "Patch")},
+					{name: "remove", typ: named(t.name + "Patch")}}},
+			&gqlField{name: "delete" + t.name, op: "delete", node: t,
+				desc: fmt.Sprintf("Deletes the nodes of type %s matching the filter.", t.name),
+				typ:  named("DeletePayload"),
+				args: []*gqlField{{name: "filter", typ: filter}}})
+	}
+}
+
+// listArgs returns the arguments of the fields returning lists of nodes of type t.
+func (s *Schema) listArgs(t *gqlType) []*gqlField {
+	args := []*gqlField{{name: "filter", typ: named(t.name + "Filter")}}
+	if len(s.orderable(t)) > 0 {
+		args = append(args, &gqlField{name: "order", typ: named(t.name + "Order")})
+	}
+	return append(args, &gqlField{name: "first", typ: named("Int")},
+		&gqlField{name: "offset", typ: named("Int")})
+}
+
+// orderable returns the names of the fields the nodes of type t can be ordered by.
+func (s *Schema) orderable(t *gqlType) []string {
+	var names []string
+	for _, f := range t.fields {
+		if f.pred != "uid" && f.typ.elem == nil && s.types[f.typ.named()].kind != kindObject {
+			names = append(names, f.name)
+		}
+	}
+	return names
+}
+
+// buildNode generates the input types of the type of nodes t, and adds the arguments of its
+// fields of nodes.
+func (s *Schema) buildNode(t *gqlType) {
+	filter := &gqlType{kind: kindInput, name: t.name + "Filter"}
+	if len(t.idField) > 0 {
+		filter.fields = append(filter.fields,
+			&gqlField{name: t.idField, typ: listOf(nonNull(named("ID")))})
+	}
+	orderable := &gqlType{kind: kindEnum, name: t.name + "Orderable"}
+	add := &gqlType{kind: kindInput, name: "Add" + t.name + "Input"}
+	patch := &gqlType{kind: kindInput, name: t.name + "Patch"}
+	ref := &gqlType{kind: kindInput, name: t.name + "Ref",
+		desc: fmt.Sprintf("A %s, existing with its id or new with its fields.", t.name)}
+	if len(t.idField) > 0 {
+		ref.fields = append(ref.fields, &gqlField{name: t.idField, typ: named("ID")})
+	}
+	for _, f := range t.fields {
+		if f.pred == "uid" {
+			continue
+		}
+		target := s.types[f.typ.named()]
+		switch target.kind {
+		case kindObject:
+			in := named(target.name + "Ref")
+			if f.typ.elem != nil {
+				in = listOf(nonNull(in))
+				f.args = s.listArgs(target)
+			}
+			if f.typ.nonNull {
+				add.fields = append(add.fields, &gqlField{name: f.name, typ: nonNull(in)})
+			} else {
+				add.fields = append(add.fields, &gqlField{name: f.name, typ: in})
+			}
+			patch.fields = append(patch.fields, &gqlField{name: f.name, typ: in})
+			ref.fields = append(ref.fields, &gqlField{name: f.name, typ: in})
+			continue
+		}
+		add.fields = append(add.fields, &gqlField{name: f.name, typ: f.typ})
+		opt := *f.typ
+		opt.nonNull = false
+		patch.fields = append(patch.fields, &gqlField{name: f.name, typ: &opt})
+		ref.fields = append(ref.fields, &gqlField{name: f.name, typ: &opt})
+		if len(f.search) > 0 {
+			filter.fields = append(filter.fields,
+				&gqlField{name: f.name, typ: named(target.name + "Filter")})
+		}
+	}
+	orderable.values = s.orderable(t)
+	filter.fields = append(filter.fields,
+		&gqlField{name: "and", typ: listOf(nonNull(named(filter.name)))},
+		&gqlField{name: "or", typ: listOf(nonNull(named(filter.name)))},
+		&gqlField{name: "not", typ: named(filter.name)})
+	for _, it := range []*gqlType{filter, add, patch, ref} {
+		s.types[it.name] = it
+	}
+	if len(orderable.values) > 0 {
+		s.types[orderable.name] = orderable
+		s.types[t.name+"Order"] = &gqlType{kind: kindInput, name: t.name + "Order",
+			fields: []*gqlField{
+				{name: "asc", typ: named(orderable.name)},
+				{name: "desc", typ: named(orderable.name)},
+				{name: "then", typ: named(t.name + "Order")},
+			}}
+	}
+}
+
+// DgraphSchema returns the schema of the predicates storing the fields of the types of nodes.
+func (s *Schema) DgraphSchema() string {
+	var b strings.Builder
+	done := make(map[string]bool)
+	if s.typed {
+		fmt.Fprintf(&b, "%s: string @index(exact) .\n", TypePredicate)
+	}
+	for _, t := range s.nodes {
+		for _, f := range t.fields {
+			if f.pred == "uid" || done[f.pred] {
+				continue
+			}
+			done[f.pred] = true
+			target := s.types[f.typ.named()]
+			vt := "uid"
+			if target.kind != kindObject {
+				vt = valueType(target.name)
+				if f.typ.elem != nil {
+					vt = "[" + vt + "]"
+				}
+			}
+			fmt.Fprintf(&b, "%s: %s", f.pred, vt)
+			if len(f.search) > 0 {
+				fmt.Fprintf(&b, " @index(%s)", strings.Join(f.search, ", "))
+			}
+			b.WriteString(" .\n")
+		}
+	}
+	return b.String()
+}
+
+// SDL returns the schema given in the SDL, or the one generated if none was.
+func (s *Schema) SDL() string {
+	if len(s.sdl) > 0 {
+		return s.sdl
+	}
+	var b strings.Builder
+	for _, t := range s.nodes {
+		if len(t.desc) > 0 {
+			fmt.Fprintf(&b, "%q\n", t.desc)
+		}
+		fmt.Fprintf(&b, "type %s {\n", t.name)
+		for _, f := range t.fields {
+			fmt.Fprintf(&b, "\t%s: %s", f.name, f.typ)
+			if f.pred != "uid" && f.pred != t.name+"."+f.name {
+				fmt.Fprintf(&b, " @dgraph(pred: %q)", f.pred)
+			}
+			if len(f.search) > 0 {
+				fmt.Fprintf(&b, " @search(by: [%s])", strings.Join(f.search, ", "))
+			}
+			b.WriteByte('\n')
+		}
+		b.WriteString("}\n\n")
+	}
+	return strings.TrimSuffix(b.String(), "\n")
+}
+
+// Typed tells whether the schema was given in the SDL.
+func (s *Schema) Typed() bool {
+	return s.typed
+}
@@ -0,0 +1,97 @@
+/*
+ * Copyright 2018 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package graphql
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const personSDL = `
+type Person {
+	id: ID!
+	name: String! @search(by: [term])
+	age: Int @search
+	nick: String @dgraph(pred: "nickname")
+	friends: [Person]
+}
+`
+
+func TestParseSchema(t *testing.T) {
+	s, err := ParseSchema(personSDL)
+	require.NoError(t, err)
+	require.True(t, s.Typed())
+	require.Equal(t, personSDL, s.SDL())
+	require.Equal(t, `dgraph.graphql.type: string @index(exact) .
+Person.name: string @index(term) .
+Person.age: int @index(int) .
+nickname: string .
+Person.friends: uid .
+`, s.DgraphSchema())
+
+	for _, name := range []string{"getPerson", "queryPerson"} {
+		require.NotNil(t, s.types["Query"].field(name), name)
+	}
+	for _, name := range []string{"addPerson", "updatePerson", "deletePerson"} {
+		require.NotNil(t, s.types["Mutation"].field(name), name)
+	}
+	filter := s.types["PersonFilter"]
+	require.NotNil(t, filter)
+	require.NotNil(t, filter.field("name"))
+	require.Nil(t, filter.field("nick"), "fields without an index can't be filtered by")
+}
+
+func TestParseSchemaErrors(t *testing.T) {
+	for _, sdl := range []string{
+		`type Person { name: Strin }`,
+		`type Person { name: String @search(by: [int]) }`,
+		`type Person { name: String } type PersonFilter { a: Int }`,
+		`type A { name: String @dgraph(pred: "p") } type B { age: Int @dgraph(pred: "p") }`,
+		`type Query { a: Int }`,
+		`type Person { id: ID! other: ID }`,
+		`type Person { name: String } type Person { age: Int }`,
+	} {
+		_, err := ParseSchema(sdl)
+		require.Error(t, err, sdl)
+	}
+}
+
+func TestFromPredicates(t *testing.T) {
+	s := FromPredicates([]Predicate{
+		{Name: "name", Type: "string", Tokenizers: []string{"term"}},
+		{Name: "Person.age", Type: "int"},
+		{Name: "friend", Type: "uid", List: true},
+		{Name: "dgraph.template.name", Type: "string"},
+		{Name: "_predicate_", Type: "string"},
+	})
+	require.False(t, s.Typed())
+
+	node := s.types[NodeType]
+	require.NotNil(t, node)
+	require.Equal(t, "[Node!]", node.field("friend").typ.String())
+	require.Equal(t, "Person.age", node.field("Person_age").pred)
+	require.Nil(t, node.field("dgraph_template_name"))
+
+	person := s.types["Person"]
+	require.NotNil(t, person)
+	require.Equal(t, "Person.age", person.field("age").pred)
+
+	// The SDL printed is a schema which can be set back.
+	_, err := ParseSchema(s.SDL())
+	require.NoError(t, err)
+}
@@ -0,0 +1,602 @@
+/*
+ * Copyright 2018 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package graphql
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/dgraph-io/dgraph/x"
+)
+
+// The queries and mutations of GraphQL are translated to GraphQL± and JSON mutations. Every node
+// selected is queried with its uid, so that nodes show up even without any of the values selected.
+
+var (
+	plainPred     = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_.]*$`)
+	regexpLiteral = regexp.MustCompile(`^/([^/\\]|\\.)*/i?$`)
+)
+
+// predRef returns how the predicate is referred to in GraphQL±.
+func predRef(pred string) string {
+	if plainPred.MatchString(pred) {
+		return pred
+	}
+	return "<" + pred + ">"
+}
+
+// quote quotes s as a string of GraphQL±, which only has the escapes of N-Quads.
+func quote(s string) string {
+	var b strings.Builder
+	b.WriteByte('"')
+	for _, r := range s {
+		switch r {
+		case '"', '\\':
+			b.WriteByte('\\')
+			b.WriteRune(r)
+		case '\n':
+			b.WriteString(`\n`)
+		case '\r':
+			b.WriteString(`\r`)
+		case '\t':
+			b.WriteString(`\t`)
+		default:
+			b.WriteRune(r)
+		}
+	}
+	b.WriteByte('"')
+	return b.String()
+}
+
+// literal returns the GraphQL± literal of the value of an argument.
+func literal(v interface{}) string {
+	switch v := v.(type) {
+	case string:
+		return quote(v)
+	case int64:
+		return strconv.FormatInt(v, 10)
+	case float64:
+		return strconv.FormatFloat(v, 'g', -1, 64)
+	case bool:
+		return strconv.FormatBool(v)
+	}
+	return quote(fmt.Sprint(v))
+}
+
+// typeFilter returns the filter the nodes of type t must pass, empty if every node does.
+func (s *Schema) typeFilter(t *gqlType) string {
+	switch {
+	case s.typed:
+		return fmt.Sprintf("eq(%s, %s)", TypePredicate, quote(t.name))
+	case t.name == NodeType:
+		return ""
+	}
+	if pred := firstPred(t, nil); len(pred) > 0 {
+		return fmt.Sprintf("has(%s)", predRef(pred))
+	}
+	return ""
+}
+
+// firstPred returns the predicate of the first field of t in the filter, or of t if none is.
+func firstPred(t *gqlType, filter map[string]interface{}) string {
+	for _, f := range t.fields {
+		if _, ok := filter[f.name]; ok && f.pred != "uid" {
+			return f.pred
+		}
+	}
+	for _, f := range t.fields {
+		if f.pred != "uid" {
+			return f.pred
+		}
+	}
+	return ""
+}
+
+// rootFunc returns the function of the root of a query of the nodes of type t.
+func (s *Schema) rootFunc(t *gqlType, filter map[string]interface{}) (string, error) {
+	if s.typed {
+		return s.typeFilter(t), nil
+	}
+	pred := firstPred(t, filter)
+	if len(pred) == 0 {
+		return "", x.Errorf("Type %s has no fields to find its nodes by", t.name)
+	}
+	return fmt.Sprintf("has(%s)", predRef(pred)), nil
+}
+
+// filter returns the GraphQL± filter of the value of an argument of type filter, of nodes of t.
+func (s *Schema) filter(t *gqlType, f map[string]interface{}) (string, error) {
+	var parts []string
+	for _, fd := range s.types[t.name+"Filter"].fields {
+		v, ok := f[fd.name]
+		if !ok || v == nil {
+			continue
+		}
+		switch {
+		case fd.name == "and" || fd.name == "or":
+			var subs []string
+			for _, sub := range v.([]interface{}) {
+				expr, err := s.filter(t, sub.(map[string]interface{}))
+				if err != nil {
+					return "", err
+				}
+				if len(expr) > 0 {
+					subs = append(subs, expr)
+				}
+			}
+			if len(subs) > 0 {
+				parts = append(parts,
+					"("+strings.Join(subs, " "+strings.ToUpper(fd.name)+" ")+")")
+			}
+		case fd.name == "not":
+			expr, err := s.filter(t, v.(map[string]interface{}))
+			if err != nil {
+				return "", err
+			}
+			if len(expr) > 0 {
+				parts = append(parts, "NOT ("+expr+")")
+			}
+		case fd.name == t.idField:
+			var ids []string
+			for _, id := range v.([]interface{}) {
+				ids = append(ids, id.(string))
+			}
+			if len(ids) == 0 {
+				return "", x.Errorf("Filter %s of type %s must have ids", fd.name, t.name)
+			}
+			parts = append(parts, "uid("+strings.Join(ids, ", ")+")")
+		default:
+			pred := predRef(t.field(fd.name).pred)
+			ops := v.(map[string]interface{})
+			for _, op := range s.types[fd.typ.name].fields {
+				arg, ok := ops[op.name]
+				if !ok || arg == nil {
+					continue
+				}
+				// Regular expressions are given as /pattern/flags, which isn't quoted.
+				if op.name == "regexp" {
+					if !regexpLiteral.MatchString(arg.(string)) {
+						return "", x.Errorf("Filter regexp of %s.%s must be /pattern/ or /pattern/i",
+							t.name, fd.name)
+					}
+					parts = append(parts, fmt.Sprintf("regexp(%s, %s)", pred, arg))
+				} else {
+					parts = append(parts, fmt.Sprintf("%s(%s, %s)", op.name, pred, literal(arg)))
+				}
+			}
+		}
+	}
+	return strings.Join(parts, " AND "), nil
+}
+
+// orderArgs returns the arguments of GraphQL± for the order, first and offset arguments of sel.
+func (s *Schema) orderArgs(t *gqlType, sel *selected) []string {
+	var args []string
+	for order, _ := sel.args["order"].(map[string]interface{}); order != nil; {
+		if asc, ok := order["asc"].(string); ok {
+			args = append(args, "orderasc: "+predRef(t.field(asc).pred))
+		}
+		if desc, ok := order["desc"].(string); ok {
+			args = append(args, "orderdesc: "+predRef(t.field(desc).pred))
+		}
+		order, _ = order["then"].(map[string]interface{})
+	}
+	if first, ok := sel.args["first"].(int64); ok {
+		args = append(args, fmt.Sprintf("first: %d", first))
+	}
+	if offset, ok := sel.args["offset"].(int64); ok {
+		args = append(args, fmt.Sprintf("offset: %d", offset))
+	}
+	return args
+}
+
+// filters joins the GraphQL± filters which must all pass, some of which can be empty.
+func filters(exprs ...string) string {
+	var parts []string
+	for _, expr := range exprs {
+		if len(expr) > 0 {
+			parts = append(parts, expr)
+		}
+	}
+	switch len(parts) {
+	case 0:
+		return ""
+	case 1:
+		return " @filter(" + parts[0] + ")"
+	}
+	return " @filter((" + strings.Join(parts, ") AND (") + "))"
+}
+
+// rootBlock writes the GraphQL± block of a get or query field of Query.
+func (e *execution) rootBlock(b *strings.Builder, sel *selected) error {
+	t := sel.def.node
+	if sel.def.op == "get" {
+		fmt.Fprintf(b, "%s(func: uid(%s))%s {\n", alias(sel.key), sel.args["id"],
+			filters(e.schema.typeFilter(t)))
+		e.schema.nodeFields(b, t, sel.sub, 1)
+		b.WriteString("}\n")
+		return nil
+	}
+	filter, _ := sel.args["filter"].(map[string]interface{})
+	return e.schema.nodesBlock(b, alias(sel.key), t, sel, filter)
+}
+
+// nodesBlock writes a GraphQL± block named name, of the nodes of type t matching the filter.
+func (s *Schema) nodesBlock(b *strings.Builder, name string, t *gqlType, sel *selected,
+	filter map[string]interface{}) error {
+	root, err := s.rootFunc(t, filter)
+	if err != nil {
+		return err
+	}
+	expr, err := s.filter(t, filter)
+	if err != nil {
+		return err
+	}
+	args := append([]string{"func: " + root}, s.orderArgs(t, sel)...)
+	fmt.Fprintf(b, "%s(%s)%s {\n", name, strings.Join(args, ", "), filters(expr))
+	s.nodeFields(b, t, sel.sub, 1)
+	b.WriteString("}\n")
+	return nil
+}
+
+// uidsBlock writes a GraphQL± block named name, of the nodes with the uids of type t.
+func (s *Schema) uidsBlock(b *strings.Builder, name string, t *gqlType, sel *selected,
+	uids []string) error {
+	filter, _ := sel.args["filter"].(map[string]interface{})
+	expr, err := s.filter(t, filter)
+	if err != nil {
+		return err
+	}
+	args := append([]string{"func: uid(" + strings.Join(uids, ", ") + ")"}, s.orderArgs(t, sel)...)
+	fmt.Fprintf(b, "%s(%s)%s {\n", name, strings.Join(args, ", "), filters(expr))
+	s.nodeFields(b, t, sel.sub, 1)
+	b.WriteString("}\n")
+	return nil
+}
+
+// nodeFields writes the GraphQL± of the fields selected of nodes of type t.
+func (s *Schema) nodeFields(b *strings.Builder, t *gqlType, sels []*selected, depth int) {
+	indent := strings.Repeat("\t", depth)
+	fmt.Fprintf(b, "%suid\n", indent)
+	for _, sel := range sels {
+		f := sel.def
+		switch {
+		case f == typenameField:
+			continue
+		case f.pred == "uid":
+			fmt.Fprintf(b, "%s%s : uid\n", indent, alias(sel.key))
+			continue
+		}
+		target := s.types[f.typ.named()]
+		if target.kind != kindObject {
+			fmt.Fprintf(b, "%s%s : %s\n", indent, alias(sel.key), predRef(f.pred))
+			continue
+		}
+		fmt.Fprintf(b, "%s%s : %s", indent, alias(sel.key), predRef(f.pred))
+		if f.typ.elem != nil {
+			if args := s.orderArgs(target, sel); len(args) > 0 {
+				fmt.Fprintf(b, " (%s)", strings.Join(args, ", "))
+			}
+			filter, _ := sel.args["filter"].(map[string]interface{})
+			// Errors are only for filters by empty lists of ids, which match nothing anyway.
+			expr, err := s.filter(target, filter)
+			if err != nil {
+				expr = "uid(0x0)"
+			}
+			b.WriteString(filters(expr))
+		}
+		b.WriteString(" {\n")
+		s.nodeFields(b, target, sel.sub, depth+1)
+		fmt.Fprintf(b, "%s}\n", indent)
+	}
+}
+
+// mutation runs a field of Mutation, and returns its payload.
+func (e *execution) mutation(sel *selected) (map[string]interface{}, error) {
+	t := sel.def.node
+	switch sel.def.op {
+	case "add":
+		var nodes []interface{}
+		var names []string
+		blank := 0
+		for _, in := range sel.args["input"].([]interface{}) {
+			names = append(names, fmt.Sprintf("n%d", blank))
+			n, err := e.schema.newNode(t, in.(map[string]interface{}), &blank)
+			if err != nil {
+				return nil, err
+			}
+			nodes = append(nodes, n)
+		}
+		set, err := json.Marshal(nodes)
+		if err != nil {
+			return nil, err
+		}
+		assigned, _, err := e.exec.Mutate(e.ctx, 0, set, nil, true)
+		if err != nil {
+			return nil, err
+		}
+		var uids []string
+		for _, name := range names {
+			uids = append(uids, assigned[name])
+		}
+		return e.payload(sel, uids)
+
+	case "update":
+		uids, ts, err := e.matching(t, sel.args["filter"].(map[string]interface{}))
+		if err != nil || len(uids) == 0 {
+			return e.payload(sel, uids)
+		}
+		set, _ := sel.args["set"].(map[string]interface{})
+		remove, _ := sel.args["remove"].(map[string]interface{})
+		var setNodes, delNodes []interface{}
+		blank := 0
+		for _, uid := range uids {
+			sn, dn, err := e.schema.patch(t, uid, set, remove, &blank)
+			if err != nil {
+				return nil, err
+			}
+			if len(sn) > 1 {
+				setNodes = append(setNodes, sn)
+			}
+			if len(dn) > 1 {
+				delNodes = append(delNodes, dn)
+			}
+		}
+		// The values replaced are deleted first, as Dgraph keeps the edges of every uid
+		// predicate.
+		if len(delNodes) > 0 {
+			del, err := json.Marshal(delNodes)
+			if err != nil {
+				return nil, err
+			}
+			if _, ts, err = e.exec.Mutate(e.ctx, ts, nil, del, len(setNodes) == 0); err != nil {
+				return nil, err
+			}
+		}
+		if len(setNodes) > 0 {
+			set, err := json.Marshal(setNodes)
+			if err != nil {
+				return nil, err
+			}
+			if _, _, err := e.exec.Mutate(e.ctx, ts, set, nil, true); err != nil {
+				return nil, err
+			}
+		}
+		return e.payload(sel, uids)
+
+	case "delete":
+		uids, ts, err := e.matching(t, sel.args["filter"].(map[string]interface{}))
+		if err != nil {
+			return nil, err
+		}
+		if len(uids) > 0 {
+			var nodes []interface{}
+			for _, uid := range uids {
+				n := map[string]interface{}{"uid": uid}
+				for _, f := range t.fields {
+					if f.pred != "uid" {
+						n[f.pred] = nil
+					}
+				}
+				if e.schema.typed {
+					n[TypePredicate] = nil
+				}
+				nodes = append(nodes, n)
+			}
+			del, err := json.Marshal(nodes)
+			if err != nil {
+				return nil, err
+			}
+			if _, _, err := e.exec.Mutate(e.ctx, ts, nil, del, true); err != nil {
+				return nil, err
+			}
+		}
+		return map[string]interface{}{
+			alias("msg"):     "Deleted",
+			alias("numUids"): int64(len(uids)),
+		}, nil
+	}
+	return nil, x.Errorf("Unknown mutation %s", sel.def.name)
+}
+
+// matching returns the uids of the nodes of type t matching the filter, and the start timestamp
+// of the transaction they were queried in.
+func (e *execution) matching(t *gqlType, filter map[string]interface{}) ([]string, uint64, error) {
+	var b strings.Builder
+	b.WriteString("{\n")
+	sel := &selected{args: map[string]interface{}{}}
+	if err := e.schema.nodesBlock(&b, "matching", t, sel, filter); err != nil {
+		return nil, 0, err
+	}
+	b.WriteString("}")
+	result, ts, err := e.run(b.String(), 0)
+	if err != nil {
+		return nil, 0, err
+	}
+	var uids []string
+	nodes, _ := result["matching"].([]interface{})
+	for _, n := range nodes {
+		if m, ok := n.(map[string]interface{}); ok {
+			if uid, ok := m["uid"].(string); ok {
+				uids = append(uids, uid)
+			}
+		}
+	}
+	return uids, ts, nil
+}
+
+// payload returns the payload of a mutation of the nodes with the uids, querying the nodes
+// selected.
+func (e *execution) payload(sel *selected, uids []string) (map[string]interface{}, error) {
+	res := map[string]interface{}{alias("numUids"): int64(len(uids))}
+	var b strings.Builder
+	for _, sub := range sel.sub {
+		if sub.def.op != "nodes" {
+			continue
+		}
+		if len(uids) == 0 {
+			res[alias(sub.key)] = []interface{}{}
+			continue
+		}
+		if err := e.schema.uidsBlock(&b, alias(sub.key), sub.def.node, sub, uids); err != nil {
+			return nil, err
+		}
+	}
+	if b.Len() == 0 {
+		return res, nil
+	}
+	result, _, err := e.run("{\n"+b.String()+"}", 0)
+	if err != nil {
+		return nil, err
+	}
+	for _, sub := range sel.sub {
+		if sub.def.op == "nodes" && len(uids) > 0 {
+			nodes := result[alias(sub.key)]
+			if nodes == nil {
+				nodes = []interface{}{}
+			}
+			res[alias(sub.key)] = nodes
+		}
+	}
+	return res, nil
+}
+
+// newNode returns the JSON of a new node of type t with the input in.
+func (s *Schema) newNode(t *gqlType, in map[string]interface{}, blank *int) (
+	map[string]interface{}, error) {
+	n := map[string]interface{}{"uid": fmt.Sprintf("_:n%d", *blank)}
+	*blank++
+	if s.typed {
+		n[TypePredicate] = t.name
+	}
+	for _, f := range t.fields {
+		if f.pred == "uid" {
+			continue
+		}
+		v := in[f.name]
+		if v == nil {
+			if f.typ.nonNull {
+				return nil, x.Errorf("Field %s of new %s can't be null", f.name, t.name)
+			}
+			continue
+		}
+		val, err := s.inputValue(f, v, blank)
+		if err != nil {
+			return nil, err
+		}
+		n[f.pred] = val
+	}
+	return n, nil
+}
+
+// inputValue returns the JSON of the value v of the field f of a node.
+func (s *Schema) inputValue(f *gqlField, v interface{}, blank *int) (interface{}, error) {
+	target := s.types[f.typ.named()]
+	if target.kind != kindObject {
+		return v, nil
+	}
+	if f.typ.elem == nil {
+		return s.ref(target, v.(map[string]interface{}), blank)
+	}
+	var refs []interface{}
+	for _, r := range v.([]interface{}) {
+		ref, err := s.ref(target, r.(map[string]interface{}), blank)
+		if err != nil {
+			return nil, err
+		}
+		refs = append(refs, ref)
+	}
+	return refs, nil
+}
+
+// ref returns the JSON of a reference to a node of type t: an existing one with its id, whose
+// other fields given are set, or a new one.
+func (s *Schema) ref(t *gqlType, r map[string]interface{}, blank *int) (
+	map[string]interface{}, error) {
+	id, ok := r[t.idField].(string)
+	if !ok || len(t.idField) == 0 {
+		return s.newNode(t, r, blank)
+	}
+	n := map[string]interface{}{"uid": id}
+	for _, f := range t.fields {
+		if v := r[f.name]; v != nil && f.pred != "uid" {
+			val, err := s.inputValue(f, v, blank)
+			if err != nil {
+				return nil, err
+			}
+			n[f.pred] = val
+		}
+	}
+	return n, nil
+}
+
+// patch returns the JSON of the values to set and delete of the node with the uid, of type t.
+func (s *Schema) patch(t *gqlType, uid string, set, remove map[string]interface{}, blank *int) (
+	map[string]interface{}, map[string]interface{}, error) {
+	sn := map[string]interface{}{"uid": uid}
+	dn := map[string]interface{}{"uid": uid}
+	for _, f := range t.fields {
+		if f.pred == "uid" {
+			continue
+		}
+		if v, ok := set[f.name]; ok {
+			if v == nil {
+				dn[f.pred] = nil
+				continue
+			}
+			val, err := s.inputValue(f, v, blank)
+			if err != nil {
+				return nil, nil, err
+			}
+			sn[f.pred] = val
+			if s.types[f.typ.named()].kind == kindObject && f.typ.elem == nil {
+				// A node replaces the one the field had.
+				dn[f.pred] = nil
+			}
+		}
+		v, ok := remove[f.name]
+		if !ok {
+			continue
+		}
+		target := s.types[f.typ.named()]
+		switch {
+		case v == nil:
+			dn[f.pred] = nil
+		case target.kind != kindObject:
+			dn[f.pred] = v
+		default:
+			refs, isList := v.([]interface{})
+			if !isList {
+				refs = []interface{}{v}
+			}
+			var del []interface{}
+			for _, r := range refs {
+				id, ok := r.(map[string]interface{})[target.idField].(string)
+				if !ok || len(target.idField) == 0 {
+					return nil, nil, x.Errorf("Nodes removed from %s.%s must be given by id",
+						t.name, f.name)
+				}
+				del = append(del, map[string]interface{}{"uid": id})
+			}
+			dn[f.pred] = del
+		}
+	}
+	return sn, dn, nil
+}
@@ -0,0 +1,214 @@
+/*
+ * Copyright 2018 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package posting
+
+import (
+	"encoding/binary"
+	"expvar"
+	"sync"
+
+	"github.com/dgraph-io/dgraph/protos/pb"
+)
+
+// blockCache keeps the encoded posting lists evicted from the list caches, so that reading them
+// again doesn't need to go to Badger. The lists are kept in a ring buffer allocated outside of
+// the Go heap, so that a large cache doesn't add to the work of the garbage collector. Only the
+// position of every list in the ring is on the heap. Each entry is made of a header holding the
+// lengths of the key and value, the key and the value, and doesn't wrap around the end of the
+// ring: if it doesn't fit before the end, the rest of the ring is skipped. Writing an entry
+// overwrites the oldest ones, so the cache is FIFO.
+type blockCache struct {
+	sync.Mutex
+
+	buf []byte
+	// head and tail only ever grow, their position in buf is modulo its length.
+	head, tail uint64
+	index      map[string]uint64
+	evicts     uint64
+
+	hits, misses *expvar.Map
+}
+
+const (
+	blockHeaderSize = 8
+	// blockPadding marks the rest of the ring as skipped.
+	blockPadding = ^uint32(0)
+)
+
+func newBlockCache(size uint64) (*blockCache, error) {
+	buf, err := mmapArena(int(size))
+	if err != nil {
+		return nil, err
+	}
+	return &blockCache{buf: buf, index: make(map[string]uint64)}, nil
+}
+
+// encodeList returns the value under which the list is kept, or false if the list has mutations
+// which aren't rolled up into its immutable layer.
+func encodeList(l *List) ([]byte, bool) {
+	l.RLock()
+	defer l.RUnlock()
+	if len(l.mutationMap) > 0 {
+		return nil, false
+	}
+	val := make([]byte, 8+l.plist.Size())
+	binary.BigEndian.PutUint64(val, l.minTs)
+	if _, err := l.plist.MarshalTo(val[8:]); err != nil {
+		return nil, false
+	}
+	return val, true
+}
+
+func decodeList(key, val []byte) (*List, error) {
+	l := new(List)
+	l.key = key
+	l.mutationMap = make(map[uint64]*pb.PostingList)
+	l.plist = new(pb.PostingList)
+	l.minTs = binary.BigEndian.Uint64(val)
+	if err := l.plist.Unmarshal(val[8:]); err != nil {
+		return nil, err
+	}
+	l.estimatedSize = l.calculateSize()
+	return l, nil
+}
+
+// put keeps the list l, evicted from a list cache. Lists with mutations not rolled up are dropped.
+func (c *blockCache) put(key string, l *List) {
+	val, ok := encodeList(l)
+	if !ok {
+		return
+	}
+	c.Lock()
+	defer c.Unlock()
+	c.write(key, val)
+}
+
+func (c *blockCache) write(key string, val []byte) {
+	size := uint64(len(c.buf))
+	n := uint64(blockHeaderSize + len(key) + len(val))
+	if n > size {
+		return
+	}
+	if pos := c.head % size; pos+n > size {
+		pad := size - pos
+		c.reserve(pad)
+		if pad >= blockHeaderSize {
+			binary.BigEndian.PutUint32(c.buf[pos:], blockPadding)
+		}
+		c.head += pad
+	}
+	c.reserve(n)
+
+	pos := c.head % size
+	binary.BigEndian.PutUint32(c.buf[pos:], uint32(len(key)))
+	binary.BigEndian.PutUint32(c.buf[pos+4:], uint32(len(val)))
+	copy(c.buf[pos+blockHeaderSize:], key)
+	copy(c.buf[pos+blockHeaderSize+uint64(len(key)):], val)
+	c.index[key] = c.head
+	c.head += n
+}
+
+// reserve overwrites the oldest entries until n more bytes can be written at the head.
+func (c *blockCache) reserve(n uint64) {
+	size := uint64(len(c.buf))
+	for c.head+n-c.tail > size {
+		pos := c.tail % size
+		if size-pos < blockHeaderSize {
+			c.tail += size - pos
+			continue
+		}
+		klen := binary.BigEndian.Uint32(c.buf[pos:])
+		if klen == blockPadding {
+			c.tail += size - pos
+			continue
+		}
+		vlen := binary.BigEndian.Uint32(c.buf[pos+4:])
+		key := c.buf[pos+blockHeaderSize : pos+blockHeaderSize+uint64(klen)]
+		if at, ok := c.index[string(key)]; ok && at == c.tail {
+			delete(c.index, string(key))
+			c.evicts++
+		}
+		c.tail += blockHeaderSize + uint64(klen) + uint64(vlen)
+	}
+}
+
+// read returns a copy of the value kept under key. If remove is set, the entry is removed too.
+func (c *blockCache) read(key string, remove bool) ([]byte, bool) {
+	c.Lock()
+	defer c.Unlock()
+	at, ok := c.index[key]
+	if !ok {
+		return nil, false
+	}
+	if remove {
+		delete(c.index, key)
+	}
+	pos := at % uint64(len(c.buf))
+	klen := uint64(binary.BigEndian.Uint32(c.buf[pos:]))
+	vlen := uint64(binary.BigEndian.Uint32(c.buf[pos+4:]))
+	start := pos + blockHeaderSize + klen
+	val := make([]byte, vlen)
+	copy(val, c.buf[start:start+vlen])
+	return val, true
+}
+
+// get returns the list kept under key, or nil. If take is set, the list is removed from the
+// cache, as it's going to be added to a list cache, where it can be mutated.
+func (c *blockCache) get(key []byte, take bool) *List {
+	attr, _ := keyAttr(key)
+	val, ok := c.read(string(key), take)
+	if !ok {
+		countAccess(c.misses, attr)
+		return nil
+	}
+	l, err := decodeList(key, val)
+	if err != nil {
+		countAccess(c.misses, attr)
+		return nil
+	}
+	countAccess(c.hits, attr)
+	return l
+}
+
+// clear removes the lists whose keys match.
+func (c *blockCache) clear(remove func(key []byte) bool) {
+	c.Lock()
+	defer c.Unlock()
+	for key := range c.index {
+		if remove([]byte(key)) {
+			delete(c.index, key)
+		}
+	}
+}
+
+func (c *blockCache) reset() {
+	c.Lock()
+	defer c.Unlock()
+	c.index = make(map[string]uint64)
+	c.head, c.tail = 0, 0
+}
+
+func (c *blockCache) Stats() CacheStats {
+	c.Lock()
+	defer c.Unlock()
+	return CacheStats{
+		Length:    len(c.index),
+		Size:      c.head - c.tail,
+		Capacity:  uint64(len(c.buf)),
+		NumEvicts: c.evicts,
+	}
+}
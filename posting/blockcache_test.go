@@ -0,0 +1,121 @@
+/*
+ * Copyright 2018 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package posting
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/dgraph-io/dgraph/x"
+	"github.com/stretchr/testify/require"
+)
+
+func blockKeys(c *blockCache) []string {
+	var keys []string
+	for i := 0; i < 10; i++ {
+		key := fmt.Sprintf("k%d", i)
+		if _, ok := c.read(key, false); ok {
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
+
+func TestBlockCacheEviction(t *testing.T) {
+	c, err := newBlockCache(100)
+	require.NoError(t, err)
+
+	// Every entry takes 20 bytes.
+	for i := 0; i < 10; i++ {
+		c.write(fmt.Sprintf("k%d", i), []byte(fmt.Sprintf("value%05d", i)))
+	}
+	require.Equal(t, []string{"k5", "k6", "k7", "k8", "k9"}, blockKeys(c))
+	require.Equal(t, uint64(5), c.evicts)
+
+	val, ok := c.read("k7", false)
+	require.True(t, ok)
+	require.Equal(t, "value00007", string(val))
+}
+
+func TestBlockCacheWrap(t *testing.T) {
+	c, err := newBlockCache(50)
+	require.NoError(t, err)
+
+	c.write("k0", []byte("0123456789"))
+	c.write("k1", []byte("0123456789"))
+	// Doesn't fit before the end, so skips it and overwrites k0.
+	c.write("k2", []byte("0123456789"))
+	require.Equal(t, []string{"k1", "k2"}, blockKeys(c))
+	c.write("k3", []byte("0123456789"))
+	require.Equal(t, []string{"k2", "k3"}, blockKeys(c))
+	c.write("k4", []byte("0123456789"))
+	require.Equal(t, []string{"k3", "k4"}, blockKeys(c))
+
+	// Too large to be kept.
+	c.write("k5", make([]byte, 50))
+	require.Equal(t, []string{"k3", "k4"}, blockKeys(c))
+}
+
+func TestBlockCacheTake(t *testing.T) {
+	c, err := newBlockCache(100)
+	require.NoError(t, err)
+
+	c.write("k0", []byte("first"))
+	c.write("k0", []byte("second"))
+	val, ok := c.read("k0", true)
+	require.True(t, ok)
+	require.Equal(t, "second", string(val))
+	_, ok = c.read("k0", false)
+	require.False(t, ok)
+
+	c.write("k1", []byte("value"))
+	c.clear(func(key []byte) bool { return string(key) == "k1" })
+	require.Empty(t, blockKeys(c))
+}
+
+func TestLCacheSpill(t *testing.T) {
+	bc, err := newBlockCache(1 << 10)
+	require.NoError(t, err)
+	lcache := newListCache(100)
+	lcache.spill = bc
+
+	key := x.DataKey("name", 1)
+	l := getPosting()
+	l.minTs = 7
+	lcache.PutIfMissing(string(key), l)
+	lcache.PutIfMissing("2", getPosting())
+	lcache.removeOldest()
+	require.Nil(t, lcache.Get(string(key)))
+
+	spilled := bc.get(key, false)
+	require.NotNil(t, spilled)
+	require.Equal(t, key, spilled.key)
+	require.Equal(t, uint64(7), spilled.minTs)
+}
+
+func TestKeyAttr(t *testing.T) {
+	attr, isIndex := keyAttr(x.IndexKey("name", "\x01david"))
+	require.Equal(t, "name", attr)
+	require.True(t, isIndex)
+
+	attr, isIndex = keyAttr(x.DataKey("friend", 1))
+	require.Equal(t, "friend", attr)
+	require.False(t, isIndex)
+
+	attr, _ = keyAttr(x.ReverseKey("friend", 1))
+	require.Equal(t, "friend", attr)
+}
@@ -0,0 +1,93 @@
+/*
+ * Copyright 2016-2018 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package posting
+
+import (
+	"math"
+
+	"github.com/dgraph-io/badger"
+
+	"github.com/dgraph-io/dgraph/codec"
+	"github.com/dgraph-io/dgraph/protos/pb"
+	"github.com/dgraph-io/dgraph/x"
+)
+
+// CheckPostings decodes every version of every key in db, calling corrupt for those which can't
+// be, as long as it returns true. It returns the number of keys checked.
+func CheckPostings(db *badger.DB, corrupt func(key []byte, version uint64, err error) bool) int {
+	txn := db.NewTransactionAt(math.MaxUint64, false)
+	defer txn.Discard()
+	iopts := badger.DefaultIteratorOptions
+	iopts.AllVersions = true
+	itr := txn.NewIterator(iopts)
+	defer itr.Close()
+
+	var keys int
+	var lastKey []byte
+	for itr.Rewind(); itr.Valid(); itr.Next() {
+		item := itr.Item()
+		if string(item.Key()) != string(lastKey) {
+			lastKey = item.KeyCopy(lastKey[:0])
+			keys++
+		}
+		if err := checkItem(item); err != nil && !corrupt(item.Key(), item.Version(), err) {
+			break
+		}
+	}
+	return keys
+}
+
+// checkItem checks that the key of item parses, and that its value is a schema or a posting list.
+func checkItem(item *badger.Item) (rerr error) {
+	// Parsing panics on keys too short for their type.
+	defer func() {
+		if r := recover(); r != nil {
+			rerr = x.Errorf("undecodable key: %v", r)
+		}
+	}()
+	pk := x.Parse(item.Key())
+	if pk == nil {
+		return x.Errorf("unknown kind of key")
+	}
+	if item.IsDeletedOrExpired() {
+		return nil
+	}
+	// Item.Value doesn't return the error of its callback for prefetched values.
+	val, err := item.ValueCopy(nil)
+	if err != nil {
+		return err
+	}
+	if pk.IsSchema() {
+		var s pb.SchemaUpdate
+		return s.Unmarshal(val)
+	}
+	var pl pb.PostingList
+	switch meta := item.UserMeta(); {
+	case meta&BitCompletePosting > 0:
+		if err := pl.Unmarshal(val); err != nil {
+			return err
+		}
+		if n := codec.ExactLen(pl.Pack); n > 0 && len(codec.Decode(pl.Pack, 0)) != n {
+			return x.Errorf("the packed uids don't decode to the %d uids they should", n)
+		}
+		return nil
+	case meta&BitDeltaPosting > 0:
+		return pl.Unmarshal(val)
+	default:
+		return x.Errorf("unexpected meta: %d", meta)
+	}
+}
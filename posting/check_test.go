@@ -0,0 +1,82 @@
+/*
+ * Copyright 2016-2018 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package posting
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/dgraph-io/badger"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dgraph-io/dgraph/protos/pb"
+	"github.com/dgraph-io/dgraph/x"
+)
+
+func TestCheckPostings(t *testing.T) {
+	dir, err := ioutil.TempDir("", "check")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	opt := badger.DefaultOptions
+	opt.Dir = dir
+	opt.ValueDir = dir
+	db, err := badger.OpenManaged(opt)
+	require.NoError(t, err)
+	defer db.Close()
+
+	pl := &pb.PostingList{Postings: []*pb.Posting{{Uid: 1}}}
+	data, err := pl.Marshal()
+	require.NoError(t, err)
+	txn := db.NewTransactionAt(1, true)
+	require.NoError(t, txn.SetWithMeta(x.DataKey("name", 1), data, BitCompletePosting))
+	require.NoError(t, txn.SetWithMeta(x.DataKey("name", 2), data, BitDeltaPosting))
+	require.NoError(t, txn.Set(x.SchemaKey("name"), nil))
+	require.NoError(t, txn.CommitAt(2, nil))
+
+	corrupt := func(key []byte, version uint64, err error) bool {
+		t.Fatalf("Key %x at %d is corrupt: %v", key, version, err)
+		return false
+	}
+	require.Equal(t, 3, CheckPostings(db, corrupt))
+
+	txn = db.NewTransactionAt(2, true)
+	require.NoError(t, txn.SetWithMeta(x.DataKey("age", 1), []byte{0xff}, BitDeltaPosting))
+	require.NoError(t, txn.SetWithMeta(x.DataKey("age", 2), data, 0))
+	// A key too short for a data key.
+	require.NoError(t, txn.Set(x.DataKey("age", 3)[:6], data))
+	require.NoError(t, txn.CommitAt(3, nil))
+
+	var errs []string
+	keys := CheckPostings(db, func(key []byte, version uint64, err error) bool {
+		require.EqualValues(t, 3, version)
+		errs = append(errs, err.Error())
+		return true
+	})
+	require.Equal(t, 6, keys)
+	require.Len(t, errs, 3)
+	require.Contains(t, errs[0], "undecodable key")
+	require.Contains(t, errs[2], "unexpected meta")
+
+	// Checking stops once corrupt returns false.
+	var calls int
+	CheckPostings(db, func(key []byte, version uint64, err error) bool {
+		calls++
+		return false
+	})
+	require.Equal(t, 1, calls)
+}
@@ -23,6 +23,12 @@ type Options struct {
 	AllottedMemory float64
 
 	CommitFraction float64
+
+	// Sizes of the caches in MB. If zero, the posting list and index caches are sized according
+	// to AllottedMemory, and the block cache is disabled.
+	PostingCacheMB float64
+	IndexCacheMB   float64
+	BlockCacheMB   float64
 }
 
 var Config Options
@@ -38,8 +38,9 @@ import (
 
 var emptyCountParams countParams
 
-// IndexTokens return tokens, without the predicate prefix and index rune.
-func indexTokens(attr, lang string, src types.Val) ([]string, error) {
+// IndexTokens returns the tokens the value of attr is indexed with, in the given language, without
+// the predicate prefix.
+func IndexTokens(attr, lang string, src types.Val) ([]string, error) {
 	schemaType, err := schema.State().TypeOf(attr)
 	if err != nil || !schemaType.IsScalar() {
 		return nil, x.Errorf("Cannot index attribute %s of type object.", attr)
@@ -78,7 +79,7 @@ func (txn *Txn) addIndexMutations(ctx context.Context, t *pb.DirectedEdge, p typ
 	attr := t.Attr
 	uid := t.Entity
 	x.AssertTrue(uid != 0)
-	tokens, err := indexTokens(attr, t.GetLang(), p)
+	tokens, err := IndexTokens(attr, t.GetLang(), p)
 
 	if err != nil {
 		// This data is not indexable
@@ -90,6 +91,7 @@ func (txn *Txn) addIndexMutations(ctx context.Context, t *pb.DirectedEdge, p typ
 		ValueId: uid,
 		Attr:    attr,
 		Op:      op,
+		TtlFrom: t.TtlFrom, // Index entries expire with the value.
 	}
 
 	for _, token := range tokens {
@@ -177,6 +179,7 @@ func (txn *Txn) addReverseMutation(ctx context.Context, t *pb.DirectedEdge) erro
 		Attr:    t.Attr,
 		Op:      t.Op,
 		Facets:  t.Facets,
+		TtlFrom: t.TtlFrom,
 	}
 
 	hasCountIndex := schema.State().HasCount(t.Attr)
@@ -425,7 +428,7 @@ func compareAttrAndType(key []byte, attr string, typ byte) bool {
 }
 
 func DeleteReverseEdges(attr string) error {
-	lcache.clear(func(key []byte) bool {
+	clearCaches(func(key []byte) bool {
 		return compareAttrAndType(key, attr, x.ByteReverse)
 	})
 	// Delete index entries from data store.
@@ -445,10 +448,10 @@ func deleteCountIndex(attr string, reverse bool) error {
 }
 
 func DeleteCountIndex(attr string) error {
-	lcache.clear(func(key []byte) bool {
+	clearCaches(func(key []byte) bool {
 		return compareAttrAndType(key, attr, x.ByteCount)
 	})
-	lcache.clear(func(key []byte) bool {
+	clearCaches(func(key []byte) bool {
 		return compareAttrAndType(key, attr, x.ByteCountRev)
 	})
 	// Delete index entries from data store.
@@ -461,6 +464,27 @@ func DeleteCountIndex(attr string) error {
 	return nil
 }
 
+// DeleteIndexExcept deletes the index entries of attr, except for the ones created by the
+// tokenizers with the given identifiers.
+func DeleteIndexExcept(attr string, keep []byte) error {
+	isStale := func(key []byte) bool {
+		pk := x.Parse(key)
+		return pk != nil && pk.Attr == attr && pk.IsIndex() && len(pk.Term) > 0 &&
+			bytes.IndexByte(keep, pk.Term[0]) < 0
+	}
+	clearCaches(isStale)
+	pk := x.ParsedKey{Attr: attr}
+	return deleteEntries(pk.IndexPrefix(), isStale)
+}
+
+type rebuildHookKey struct{}
+
+// WithRebuildHook returns a context which makes the rebuilds run with it call hook for every key
+// they read. The hook can block to throttle the rebuild, and stop it by returning an error.
+func WithRebuildHook(ctx context.Context, hook func() error) context.Context {
+	return context.WithValue(ctx, rebuildHookKey{}, hook)
+}
+
 // Index rebuilding logic here.
 type rebuild struct {
 	prefix  []byte
@@ -515,6 +539,7 @@ func (r *rebuild) Run(ctx context.Context) error {
 		return pl, nil
 	}
 
+	hook, _ := ctx.Value(rebuildHookKey{}).(func() error)
 	var prevKey []byte
 	for it.Seek(r.prefix); it.ValidForPrefix(r.prefix); {
 		item := it.Item()
@@ -545,6 +570,11 @@ func (r *rebuild) Run(ctx context.Context) error {
 		if err := r.fn(pk.Uid, l, txn); err != nil {
 			return err
 		}
+		if hook != nil {
+			if err := hook(); err != nil {
+				return err
+			}
+		}
 	}
 	glog.V(1).Infof("Rebuild: Iteration done. Now commiting at ts=%d\n", r.startTs)
 
@@ -600,6 +630,7 @@ func RebuildIndex(ctx context.Context, attr string, startTs uint64) error {
 				Value: p.Value,
 				Tid:   types.TypeID(p.ValType),
 			}
+			edge.TtlFrom = p.TtlFrom
 
 			for {
 				err := txn.addIndexMutations(ctx, &edge, val, pb.DirectedEdge_SET)
@@ -675,6 +706,7 @@ func RebuildReverseEdges(ctx context.Context, attr string, startTs uint64) error
 			edge.Op = pb.DirectedEdge_SET
 			edge.Facets = pp.Facets
 			edge.Label = pp.Label
+			edge.TtlFrom = pp.TtlFrom
 
 			for {
 				err := txn.addReverseMutation(ctx, &edge)
@@ -690,8 +722,17 @@ func RebuildReverseEdges(ctx context.Context, attr string, startTs uint64) error
 	return builder.Run(ctx)
 }
 
+// EvictIndexes removes the index, reverse and count lists of attr from the caches, after a rebuild
+// wrote them to the store directly.
+func EvictIndexes(attr string) {
+	clearCaches(func(key []byte) bool {
+		pk := x.Parse(key)
+		return pk != nil && pk.Attr == attr && !pk.IsData()
+	})
+}
+
 func DeleteIndex(attr string) error {
-	lcache.clear(func(key []byte) bool {
+	clearCaches(func(key []byte) bool {
 		return compareAttrAndType(key, attr, x.ByteIndex)
 	})
 	// Delete index entries from data store.
@@ -710,7 +751,7 @@ func RebuildListType(ctx context.Context, attr string, startTs uint64) error {
 	// Let's clear out the cache for anything which belongs to this attribute,
 	// so once we're done, any reads would see the new list type. Note that we
 	// don't use lcache during the rebuild process.
-	lcache.clear(func(key []byte) bool {
+	clearCaches(func(key []byte) bool {
 		return compareAttrAndType(key, attr, x.ByteData)
 	})
 
@@ -760,7 +801,7 @@ func RebuildListType(ctx context.Context, attr string, startTs uint64) error {
 }
 
 func DeleteAll() error {
-	lcache.clear(func([]byte) bool { return true })
+	clearCaches(func([]byte) bool { return true })
 	return deleteEntries(nil, func(key []byte) bool {
 		pk := x.Parse(key)
 		if pk == nil {
@@ -775,7 +816,7 @@ func DeleteAll() error {
 
 func DeletePredicate(ctx context.Context, attr string) error {
 	glog.Infof("Dropping predicate: [%s]", attr)
-	lcache.clear(func(key []byte) bool {
+	clearCaches(func(key []byte) bool {
 		return compareAttrAndType(key, attr, x.ByteData)
 	})
 	pk := x.ParsedKey{
@@ -812,3 +853,135 @@ func DeletePredicate(ctx context.Context, attr string) error {
 
 	return schema.State().Delete(attr)
 }
+
+// RenamePredicate moves the data, indexes and schema of predicate from to predicate to. Every
+// posting list is rewritten rolled up, at the version of its latest commit. Renaming again after
+// a crash is safe, as the keys of from are only deleted once all of them have been rewritten.
+func RenamePredicate(ctx context.Context, from, to string) error {
+	su, ok := schema.State().Get(from)
+	if _, has := schema.State().Get(to); has {
+		if !ok {
+			// Already renamed.
+			return nil
+		}
+		return x.Errorf("Predicate %s already exists", to)
+	}
+	glog.Infof("Renaming predicate: [%s] to [%s]", from, to)
+	clearCaches(func(key []byte) bool {
+		pk := x.Parse(key)
+		return pk == nil || pk.Attr == from || pk.Attr == to
+	})
+
+	if err := renameEntries(from, to); err != nil {
+		return err
+	}
+	err := deleteEntries(x.PredicatePrefix(from), func(key []byte) bool {
+		return true
+	})
+	if err != nil || !ok {
+		return err
+	}
+
+	data, err := su.Marshal()
+	if err != nil {
+		return err
+	}
+	txn := pstore.NewTransactionAt(1, true)
+	defer txn.Discard()
+	if err := txn.Set(x.SchemaKey(to), data); err != nil {
+		return err
+	}
+	if err := txn.CommitAt(1, nil); err != nil {
+		return err
+	}
+	schema.State().Set(to, su)
+	return schema.State().Delete(from)
+}
+
+// TrimPredicate deletes the data of attr for the uids keep returns false for, along with their
+// postings in the index, reverse and count lists of attr. This is how a group drops the uids of a
+// split tablet it no longer holds, which its queries skip anyway.
+func TrimPredicate(ctx context.Context, attr string, keep func(uid uint64) bool) error {
+	glog.Infof("Trimming predicate: [%s]", attr)
+	clearCaches(func(key []byte) bool {
+		pk := x.Parse(key)
+		return pk == nil || pk.Attr == attr
+	})
+
+	txn := pstore.NewTransactionAt(math.MaxUint64, false)
+	defer txn.Discard()
+	opt := badger.DefaultIteratorOptions
+	opt.AllVersions = true
+	itr := txn.NewIterator(opt)
+	defer itr.Close()
+
+	writer := x.NewTxnWriter(pstore)
+	prefix := x.PredicatePrefix(attr)
+	for itr.Seek(prefix); itr.ValidForPrefix(prefix); {
+		item := itr.Item()
+		key := item.KeyCopy(nil)
+		pk := x.Parse(key)
+		switch {
+		case pk == nil || pk.IsSchema():
+		case pk.IsData():
+			if !keep(pk.Uid) {
+				if err := writer.Delete(key, item.Version()); err != nil {
+					return err
+				}
+			}
+		default:
+			l, err := ReadPostingList(key, itr)
+			if err != nil {
+				return err
+			}
+			kv, err := l.MarshalToKvFiltered(keep)
+			if err != nil {
+				return err
+			}
+			if kv.Version > 0 {
+				if err := writer.SetAt(key, kv.Val, kv.UserMeta[0], kv.Version); err != nil {
+					return err
+				}
+			}
+		}
+		for itr.Valid() && bytes.Equal(itr.Item().Key(), key) {
+			itr.Next()
+		}
+	}
+	return writer.Flush()
+}
+
+// renameEntries writes all the posting lists of predicate from under predicate to.
+func renameEntries(from, to string) error {
+	txn := pstore.NewTransactionAt(math.MaxUint64, false)
+	defer txn.Discard()
+	opt := badger.DefaultIteratorOptions
+	opt.AllVersions = true
+	itr := txn.NewIterator(opt)
+	defer itr.Close()
+
+	writer := x.NewTxnWriter(pstore)
+	prefix := x.PredicatePrefix(from)
+	for itr.Seek(prefix); itr.ValidForPrefix(prefix); {
+		key := itr.Item().KeyCopy(nil)
+		l, err := ReadPostingList(key, itr)
+		if err != nil {
+			return err
+		}
+		kv, err := l.MarshalToKv()
+		if err != nil {
+			return err
+		}
+		// Empty lists have been deleted, there's nothing to rename.
+		if len(kv.Val) > 0 {
+			if err := writer.SetAt(x.RenameAttr(key, to), kv.Val, kv.UserMeta[0],
+				kv.Version); err != nil {
+				return err
+			}
+		}
+		for itr.Valid() && bytes.Equal(itr.Item().Key(), key) {
+			itr.Next()
+		}
+	}
+	return writer.Flush()
+}
@@ -19,6 +19,7 @@ package posting
 import (
 	"bytes"
 	"context"
+	"fmt"
 	"math"
 	"testing"
 	"time"
@@ -44,35 +45,35 @@ func uids(l *List, readTs uint64) []uint64 {
 
 func TestIndexingInt(t *testing.T) {
 	schema.ParseBytes([]byte("age:int @index(int) ."), 1)
-	a, err := indexTokens("age", "", types.Val{Tid: types.StringID, Value: []byte("10")})
+	a, err := IndexTokens("age", "", types.Val{Tid: types.StringID, Value: []byte("10")})
 	require.NoError(t, err)
 	require.EqualValues(t, []byte{0x6, 0x1, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0xa}, []byte(a[0]))
 }
 
 func TestIndexingIntNegative(t *testing.T) {
 	schema.ParseBytes([]byte("age:int @index(int) ."), 1)
-	a, err := indexTokens("age", "", types.Val{Tid: types.StringID, Value: []byte("-10")})
+	a, err := IndexTokens("age", "", types.Val{Tid: types.StringID, Value: []byte("-10")})
 	require.NoError(t, err)
 	require.EqualValues(t, []byte{0x6, 0x0, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xf6}, []byte(a[0]))
 }
 
 func TestIndexingFloat(t *testing.T) {
 	schema.ParseBytes([]byte("age:float @index(float) ."), 1)
-	a, err := indexTokens("age", "", types.Val{Tid: types.StringID, Value: []byte("10.43")})
+	a, err := IndexTokens("age", "", types.Val{Tid: types.StringID, Value: []byte("10.43")})
 	require.NoError(t, err)
 	require.EqualValues(t, []byte{0x7, 0x1, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0xa}, []byte(a[0]))
 }
 
 func TestIndexingTime(t *testing.T) {
 	schema.ParseBytes([]byte("age:dateTime @index(year) ."), 1)
-	a, err := indexTokens("age", "", types.Val{Tid: types.StringID, Value: []byte("0010-01-01T01:01:01.000000001")})
+	a, err := IndexTokens("age", "", types.Val{Tid: types.StringID, Value: []byte("0010-01-01T01:01:01.000000001")})
 	require.NoError(t, err)
 	require.EqualValues(t, []byte{0x4, 0x0, 0xa}, []byte(a[0]))
 }
 
 func TestIndexing(t *testing.T) {
 	schema.ParseBytes([]byte("name:string @index(term) ."), 1)
-	a, err := indexTokens("name", "", types.Val{Tid: types.StringID, Value: []byte("abc")})
+	a, err := IndexTokens("name", "", types.Val{Tid: types.StringID, Value: []byte("abc")})
 	require.NoError(t, err)
 	require.EqualValues(t, "\x01abc", string(a[0]))
 }
@@ -81,22 +82,22 @@ func TestIndexingMultiLang(t *testing.T) {
 	schema.ParseBytes([]byte("name:string @index(fulltext) ."), 1)
 
 	// ensure that default tokenizer is suitable for English
-	a, err := indexTokens("name", "", types.Val{Tid: types.StringID, Value: []byte("stemming")})
+	a, err := IndexTokens("name", "", types.Val{Tid: types.StringID, Value: []byte("stemming")})
 	require.NoError(t, err)
 	require.EqualValues(t, "\x08stem", string(a[0]))
 
 	// ensure that Finnish tokenizer is used
-	a, err = indexTokens("name", "fi", types.Val{Tid: types.StringID, Value: []byte("edeltäneessä")})
+	a, err = IndexTokens("name", "fi", types.Val{Tid: types.StringID, Value: []byte("edeltäneessä")})
 	require.NoError(t, err)
 	require.EqualValues(t, "\x08edeltän", string(a[0]))
 
 	// ensure that German tokenizer is used
-	a, err = indexTokens("name", "de", types.Val{Tid: types.StringID, Value: []byte("Auffassungsvermögen")})
+	a, err = IndexTokens("name", "de", types.Val{Tid: types.StringID, Value: []byte("Auffassungsvermögen")})
 	require.NoError(t, err)
 	require.EqualValues(t, "\x08auffassungsvermog", string(a[0]))
 
 	// ensure that default tokenizer works differently than German
-	a, err = indexTokens("name", "", types.Val{Tid: types.StringID, Value: []byte("Auffassungsvermögen")})
+	a, err = IndexTokens("name", "", types.Val{Tid: types.StringID, Value: []byte("Auffassungsvermögen")})
 	require.NoError(t, err)
 	require.EqualValues(t, "\x08auffassungsvermögen", string(a[0]))
 }
@@ -105,18 +106,18 @@ func TestIndexingInvalidLang(t *testing.T) {
 	schema.ParseBytes([]byte("name:string @index(fulltext) ."), 1)
 
 	// tokenizer for "xx" language won't return an error.
-	_, err := indexTokens("name", "xx", types.Val{Tid: types.StringID, Value: []byte("error")})
+	_, err := IndexTokens("name", "xx", types.Val{Tid: types.StringID, Value: []byte("error")})
 	require.NoError(t, err)
 }
 
 func TestIndexingAliasedLang(t *testing.T) {
 	schema.ParseBytes([]byte("name:string @index(fulltext) @lang ."), 1)
-	_, err := indexTokens("name", "es", types.Val{Tid: types.StringID, Value: []byte("base")})
+	_, err := IndexTokens("name", "es", types.Val{Tid: types.StringID, Value: []byte("base")})
 	require.NoError(t, err)
 	// es-es and es-419 are aliased to es
-	_, err = indexTokens("name", "es-es", types.Val{Tid: types.StringID, Value: []byte("alias")})
+	_, err = IndexTokens("name", "es-es", types.Val{Tid: types.StringID, Value: []byte("alias")})
 	require.NoError(t, err)
-	_, err = indexTokens("name", "es-419", types.Val{Tid: types.StringID, Value: []byte("alias")})
+	_, err = IndexTokens("name", "es-419", types.Val{Tid: types.StringID, Value: []byte("alias")})
 	require.NoError(t, err)
 }
 
@@ -346,3 +347,70 @@ func TestRebuildReverseEdges(t *testing.T) {
 	require.EqualValues(t, 2, uids0[1])
 	require.EqualValues(t, 1, uids1[0])
 }
+
+// predicateKeys returns the kinds of the keys of attr on disk, with the term or uid they're for.
+func predicateKeys(t *testing.T, attr string) []string {
+	txn := pstore.NewTransactionAt(math.MaxUint64, false)
+	defer txn.Discard()
+	it := txn.NewIterator(badger.DefaultIteratorOptions)
+	defer it.Close()
+
+	var keys []string
+	prefix := x.PredicatePrefix(attr)
+	for it.Seek(prefix); it.ValidForPrefix(prefix); it.Next() {
+		pk := x.Parse(it.Item().Key())
+		require.NotNil(t, pk)
+		switch {
+		case pk.IsData():
+			keys = append(keys, fmt.Sprintf("data %d", pk.Uid))
+		case pk.IsIndex():
+			keys = append(keys, "index "+pk.Term)
+		case pk.IsReverse():
+			keys = append(keys, fmt.Sprintf("reverse %d", pk.Uid))
+		}
+	}
+	return keys
+}
+
+func TestRenamePredicate(t *testing.T) {
+	require.NoError(t, schema.ParseBytes([]byte(`
+		rename.name: string @index(exact) .
+		rename.friend: uid @reverse .
+	`), 1))
+	for _, edge := range []*pb.DirectedEdge{
+		{Attr: "rename.name", Entity: 1, Value: []byte("alice")},
+		{Attr: "rename.friend", Entity: 1, ValueId: 2},
+	} {
+		l, err := Get(x.DataKey(edge.Attr, edge.Entity))
+		require.NoError(t, err)
+		addMutation(t, l, edge, Set, 30, 31, true)
+	}
+	nameKeys := predicateKeys(t, "rename.name")
+	require.Equal(t, []string{"data 1", "index \x02alice"}, nameKeys)
+	friendKeys := predicateKeys(t, "rename.friend")
+	require.Equal(t, []string{"data 1", "reverse 2"}, friendKeys)
+
+	for _, attr := range []string{"rename.name", "rename.friend"} {
+		require.NoError(t, RenamePredicate(context.Background(), attr, "renamed."+attr))
+		require.Empty(t, predicateKeys(t, attr))
+		_, ok := schema.State().Get(attr)
+		require.False(t, ok)
+	}
+	// The data, index and reverse keys all move, along with the schema.
+	require.Equal(t, nameKeys, predicateKeys(t, "renamed.rename.name"))
+	require.Equal(t, friendKeys, predicateKeys(t, "renamed.rename.friend"))
+	require.True(t, schema.State().IsIndexed("renamed.rename.name"))
+	require.True(t, schema.State().IsReversed("renamed.rename.friend"))
+
+	l, err := Get(x.DataKey("renamed.rename.name", 1))
+	require.NoError(t, err)
+	val, err := l.Value(32)
+	require.NoError(t, err)
+	require.Equal(t, "alice", string(val.Value.([]byte)))
+	l, err = Get(x.ReverseKey("renamed.rename.friend", 2))
+	require.NoError(t, err)
+	require.Equal(t, []uint64{1}, uids(l, 32))
+
+	// Renaming again is a no-op.
+	require.NoError(t, RenamePredicate(context.Background(), "rename.name", "renamed.rename.name"))
+}
@@ -36,6 +36,7 @@ import (
 	"github.com/dgraph-io/dgo/y"
 	"github.com/dgraph-io/dgraph/algo"
 	"github.com/dgraph-io/dgraph/codec"
+	"github.com/dgraph-io/dgraph/fault"
 	"github.com/dgraph-io/dgraph/protos/pb"
 	"github.com/dgraph-io/dgraph/schema"
 	"github.com/dgraph-io/dgraph/types"
@@ -213,6 +214,7 @@ func NewPosting(t *pb.DirectedEdge) *pb.Posting {
 		Label:       t.Label,
 		Op:          op,
 		Facets:      t.Facets,
+		TtlFrom:     t.TtlFrom,
 	}
 }
 
@@ -327,8 +329,8 @@ func (l *List) addMutation(ctx context.Context, txn *Txn, t *pb.DirectedEdge) er
 	// order. We can do so by proposing them in the same order as received by the Oracle delta
 	// stream from Zero, instead of in goroutines.
 	var conflictKey string
-	if t.Attr == "_predicate_" {
-		// Don't check for conflict.
+	if t.Attr == "_predicate_" || x.IsScratchAttr(t.Attr) {
+		// Don't check for conflict. Scratch predicates are only seen by the txn writing them.
 
 	} else if schema.State().HasUpsert(t.Attr) {
 		// Consider checking to see if a email id is unique. A user adds:
@@ -382,6 +384,17 @@ func (l *List) GetMutation(startTs uint64) []byte {
 	return nil
 }
 
+// mutationPostings returns the postings the transaction started at startTs set or deleted in the
+// list, if it isn't committed yet.
+func (l *List) mutationPostings(startTs uint64) []*pb.Posting {
+	l.RLock()
+	defer l.RUnlock()
+	if pl, ok := l.mutationMap[startTs]; ok {
+		return append([]*pb.Posting{}, pl.Postings...)
+	}
+	return nil
+}
+
 func (l *List) CommitMutation(startTs, commitTs uint64) error {
 	l.Lock()
 	defer l.Unlock()
@@ -525,13 +538,52 @@ func (l *List) pickPostings(readTs uint64) (*pb.PostingList, []*pb.Posting) {
 	return storedList, posts
 }
 
+// expiredBefore returns the Unix time before which the postings of the list have expired, as per
+// the @ttl of its predicate. It returns zero if they don't expire.
+func (l *List) expiredBefore() int64 {
+	if !schema.State().HasTTLs() {
+		return 0
+	}
+	pk := x.Parse(l.key)
+	if pk == nil || pk.IsCount() {
+		return 0
+	}
+	ttl, _ := schema.State().TTL(pk.Attr)
+	if ttl == 0 {
+		return 0
+	}
+	return fault.Now().Add(-ttl).Unix()
+}
+
+// iterate calls f with the postings of the list as of readTs, skipping the expired ones. As rollup
+// relies on it, those are purged at the next rollup.
 func (l *List) iterate(readTs uint64, afterUid uint64, f func(obj *pb.Posting) error) error {
 	l.AssertRLock()
 
-	plist, mposts := l.pickPostings(readTs)
+	if cutoff := l.expiredBefore(); cutoff > 0 {
+		fn := f
+		f = func(p *pb.Posting) error {
+			if p.TtlFrom > 0 && p.TtlFrom < cutoff {
+				return nil
+			}
+			return fn(p)
+		}
+	}
+
 	if readTs < l.minTs {
-		return x.Errorf("readTs: %d less than minTs: %d for key: %q", readTs, l.minTs, l.key)
+		// The list was rolled up past readTs, whose versions have to be read from disk.
+		h, err := l.readHistory(readTs)
+		if err != nil {
+			return err
+		}
+		h.RLock()
+		defer h.RUnlock()
+		if readTs < h.minTs {
+			return x.Errorf("readTs: %d less than minTs: %d for key: %q", readTs, h.minTs, l.key)
+		}
+		return h.iterate(readTs, afterUid, f)
 	}
+	plist, mposts := l.pickPostings(readTs)
 
 	midx, mlen := 0, len(mposts)
 	if afterUid > 0 {
@@ -655,6 +707,42 @@ func (l *List) MarshalToKv() (*pb.KV, error) {
 	return kv, nil
 }
 
+// MarshalToKvFiltered is like MarshalToKv, keeping only the postings of the uids keep returns
+// true for.
+func (l *List) MarshalToKvFiltered(keep func(uid uint64) bool) (*pb.KV, error) {
+	l.Lock()
+	defer l.Unlock()
+	if err := l.rollup(math.MaxUint64); err != nil {
+		return nil, err
+	}
+
+	final := new(pb.PostingList)
+	enc := codec.Encoder{BlockSize: blockSize}
+	err := l.iterate(math.MaxUint64, 0, func(p *pb.Posting) error {
+		if !keep(p.Uid) {
+			return nil
+		}
+		enc.Add(p.Uid)
+		if p.Facets != nil || p.PostingType != pb.Posting_REF || len(p.Label) != 0 ||
+			p.TtlFrom > 0 {
+			final.Postings = append(final.Postings, p)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	final.Pack = enc.Done()
+
+	kv := &pb.KV{}
+	kv.Version = l.minTs
+	kv.Key = l.key
+	val, meta := marshalPostingList(final)
+	kv.UserMeta = []byte{meta}
+	kv.Val = val
+	return kv, nil
+}
+
 func marshalPostingList(plist *pb.PostingList) (data []byte, meta byte) {
 	if plist.Pack == nil || len(plist.Pack.Blocks) == 0 {
 		return nil, BitEmptyPosting
@@ -694,8 +782,9 @@ func (l *List) rollup(readTs uint64) error {
 		// in a bug.
 		enc.Add(p.Uid)
 
-		// We want to add the posting if it has facets or has a value.
-		if p.Facets != nil || p.PostingType != pb.Posting_REF || len(p.Label) != 0 {
+		// We want to add the posting if it has facets, a value, or has to expire.
+		if p.Facets != nil || p.PostingType != pb.Posting_REF || len(p.Label) != 0 ||
+			p.TtlFrom > 0 {
 			// I think it's okay to take the pointer from the iterator, because we have a lock
 			// over List; which won't be released until final has been marshalled. Thus, the
 			// underlying data wouldn't be changed.
@@ -743,11 +832,9 @@ func (l *List) Uids(opt ListOptions) (*pb.List, error) {
 	// Use approximate length for initial capacity.
 	res := make([]uint64, 0, len(l.mutationMap)+codec.ApproxLen(l.plist.Pack))
 	out := &pb.List{}
-	if len(l.mutationMap) == 0 && opt.Intersect != nil {
-		if opt.ReadTs < l.minTs {
-			l.RUnlock()
-			return out, ErrTsTooOld
-		}
+	// The packed uids can't tell the expired ones apart, nor be read before minTs.
+	if len(l.mutationMap) == 0 && opt.Intersect != nil && l.expiredBefore() == 0 &&
+		opt.ReadTs >= l.minTs {
 		algo.IntersectCompressedWith(l.plist.Pack, opt.AfterUID, opt.Intersect, out)
 		l.RUnlock()
 		return out, nil
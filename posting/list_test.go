@@ -23,7 +23,9 @@ import (
 	"math/rand"
 	"os"
 	"strconv"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/dgraph-io/badger"
 	"github.com/stretchr/testify/require"
@@ -163,6 +165,32 @@ func TestAddMutation_Value(t *testing.T) {
 	checkValue(t, ol, "119", txn.StartTs)
 }
 
+func TestAddMutation_Scratch(t *testing.T) {
+	key := x.DataKey(x.ScratchPrefix+"seen", 10)
+	ol, err := getNew(key, ps)
+	require.NoError(t, err)
+	edge := &pb.DirectedEdge{
+		Value: []byte("yes"),
+		Label: "scratch",
+	}
+	txn := &Txn{StartTs: 1, getList: func([]byte) (*List, error) { return ol, nil }}
+	addMutationHelper(t, ol, edge, Set, txn)
+	// The txn sees its own writes, which aren't checked for conflicts.
+	checkValue(t, ol, "yes", txn.StartTs)
+	require.Empty(t, txn.conflicts)
+
+	writer := x.NewTxnWriter(ps)
+	require.NoError(t, txn.CommitToDisk(writer, 2))
+	require.NoError(t, writer.Flush())
+	require.NoError(t, txn.CommitToMemory(2))
+
+	// Committing discards them, both in memory and on disk.
+	require.Empty(t, listToArray(t, 0, ol, 3))
+	ol, err = getNew(key, ps)
+	require.NoError(t, err)
+	require.Empty(t, listToArray(t, 0, ol, 3))
+}
+
 func TestAddMutation_jchiu1(t *testing.T) {
 	key := x.DataKey("value", 12)
 	ol, err := Get(key)
@@ -777,6 +805,77 @@ func TestDelete(t *testing.T) {
 	require.EqualValues(t, 0, ol.Length(txn.StartTs+2, 0))
 }
 
+func TestExpiry(t *testing.T) {
+	defer schema.State().Set("session", pb.SchemaUpdate{ValueType: pb.Posting_UID})
+
+	key := x.DataKey("session", 1)
+	ol, err := getNew(key, ps)
+	require.NoError(t, err)
+
+	now := time.Now().Unix()
+	txn := &Txn{StartTs: 1}
+	addMutationHelper(t, ol, &pb.DirectedEdge{ValueId: 2, TtlFrom: now}, Set, txn)
+	addMutationHelper(t, ol, &pb.DirectedEdge{ValueId: 3, TtlFrom: now - 7200}, Set, txn)
+	addMutationHelper(t, ol, &pb.DirectedEdge{ValueId: 4}, Set, txn)
+	ol.CommitMutation(1, 2)
+	require.NoError(t, ol.Rollup(3))
+	require.Equal(t, []uint64{2, 3, 4}, listToArray(t, 0, ol, 3))
+
+	// Once the predicate has a TTL, the postings older than it are hidden, but not those without
+	// the time they were written at.
+	schema.State().Set("session", pb.SchemaUpdate{ValueType: pb.Posting_UID, Ttl: 3600})
+	require.Equal(t, []uint64{2, 4}, listToArray(t, 0, ol, 3))
+	uids, err := ol.Uids(ListOptions{ReadTs: 3, Intersect: &pb.List{Uids: []uint64{2, 3, 4}}})
+	require.NoError(t, err)
+	require.Equal(t, []uint64{2, 4}, uids.Uids)
+
+	// And purged at the next rollup.
+	require.NoError(t, ol.Rollup(3))
+	require.Len(t, ol.PostingList().Postings, 1)
+	require.Equal(t, now, ol.PostingList().Postings[0].TtlFrom)
+	require.Equal(t, []uint64{2, 4}, listToArray(t, 0, ol, 3))
+}
+
+func TestReadHistory(t *testing.T) {
+	key := x.DataKey("history", 1)
+	ol, err := getNew(key, ps)
+	require.NoError(t, err)
+
+	commit := func(startTs, commitTs, uid uint64) {
+		txn := &Txn{StartTs: startTs, getList: func([]byte) (*List, error) { return ol, nil }}
+		addMutationHelper(t, ol, &pb.DirectedEdge{ValueId: uid}, Set, txn)
+		writer := x.NewTxnWriter(ps)
+		require.NoError(t, txn.CommitToDisk(writer, commitTs))
+		require.NoError(t, writer.Flush())
+		require.NoError(t, txn.CommitToMemory(commitTs))
+	}
+	commit(1, 2, 10)
+	commit(3, 4, 20)
+
+	// Roll up on disk, like the rollups of the Alphas do.
+	kv, err := ol.MarshalToKv()
+	require.NoError(t, err)
+	writer := x.NewTxnWriter(ps)
+	writer.BlindWrite = true
+	require.NoError(t, writer.SetAt(kv.Key, kv.Val, kv.UserMeta[0], kv.Version))
+	require.NoError(t, writer.Flush())
+
+	// Reads older than the rollup come from the versions on disk.
+	ol, err = getNew(key, ps)
+	require.NoError(t, err)
+	require.Equal(t, []uint64{10, 20}, listToArray(t, 0, ol, 5))
+	require.Equal(t, []uint64{10}, listToArray(t, 0, ol, 3))
+	uids, err := ol.Uids(ListOptions{ReadTs: 3, Intersect: &pb.List{Uids: []uint64{10, 20}}})
+	require.NoError(t, err)
+	require.Equal(t, []uint64{10}, uids.Uids)
+
+	// Unless they might have been discarded.
+	RaiseHistoryTs(3)
+	defer atomic.StoreUint64(&historyTs, 0)
+	require.Error(t, ol.Iterate(2, 0, func(p *pb.Posting) error { return nil }))
+	require.Equal(t, []uint64{10}, listToArray(t, 0, ol, 3))
+}
+
 func TestAfterUIDCountWithCommit(t *testing.T) {
 	key := x.DataKey("value", 26)
 	ol, err := getNew(key, ps)
@@ -17,6 +17,7 @@
 package posting
 
 import (
+	"expvar"
 	"fmt"
 	"io/ioutil"
 	"math"
@@ -114,12 +115,71 @@ func getMemUsage() int {
 	return rss * os.Getpagesize()
 }
 
+// updateCacheMetrics sets the metrics of every cache, and the totals of the list caches.
+func updateCacheMetrics() {
+	var total CacheStats
+	for _, c := range []*listCache{lcache, icache} {
+		stats := c.Stats()
+		total.Length += stats.Length
+		total.Size += stats.Size
+		total.NumEvicts += stats.NumEvicts
+		if stats.Capacity > math.MaxInt64-total.Capacity {
+			total.Capacity = math.MaxInt64
+		} else {
+			total.Capacity += stats.Capacity
+		}
+		setCacheStats(c.name, stats)
+	}
+	if bcache != nil {
+		setCacheStats(blockCacheName, bcache.Stats())
+	}
+	x.LcacheEvicts.Set(int64(total.NumEvicts))
+	x.LcacheSize.Set(int64(total.Size))
+	x.LcacheLen.Set(int64(total.Length))
+	x.LcacheCapacity.Set(int64(total.Capacity))
+}
+
+func setCacheStats(name string, stats CacheStats) {
+	set := func(m *expvar.Map, v uint64) {
+		iv := new(expvar.Int)
+		if v > math.MaxInt64 {
+			v = math.MaxInt64
+		}
+		iv.Set(int64(v))
+		m.Set(name, iv)
+	}
+	set(x.LcacheSizes, stats.Size)
+	set(x.LcacheCapacities, stats.Capacity)
+	set(x.LcacheEvictions, stats.NumEvicts)
+}
+
+// periodicUpdateStats sizes the list caches which weren't given a size, so that the memory in use
+// stays around Config.AllottedMemory.
 func periodicUpdateStats(lc *y.Closer) {
 	defer lc.Done()
 	ticker := time.NewTicker(10 * time.Second)
 	defer ticker.Stop()
+	var adaptive []*listCache
+	for _, c := range []*listCache{lcache, icache} {
+		if !c.fixed {
+			adaptive = append(adaptive, c)
+		}
+	}
+	resize := func(grow bool) {
+		for _, c := range adaptive {
+			maxSize := c.Stats().Capacity
+			delta := maxSize / 10
+			if delta > 50<<20 {
+				delta = 50 << 20 // Change lru cache size by max 50mb.
+			}
+			if grow {
+				c.UpdateMaxSize(maxSize + delta)
+			} else {
+				c.UpdateMaxSize(maxSize - delta)
+			}
+		}
+	}
 	setLruMemory := true
-	var maxSize uint64
 	var lastUse float64
 	for {
 		select {
@@ -131,10 +191,7 @@ func periodicUpdateStats(lc *y.Closer) {
 			megs := (ms.HeapInuse + ms.StackInuse) / (1 << 20)
 			inUse := float64(megs)
 
-			stats := lcache.Stats()
-			x.LcacheEvicts.Set(int64(stats.NumEvicts))
-			x.LcacheSize.Set(int64(stats.Size))
-			x.LcacheLen.Set(int64(stats.Length))
+			updateCacheMetrics()
 
 			// Okay, we exceed the max memory threshold.
 			// Stop the world, and deal with this first.
@@ -144,7 +201,9 @@ func periodicUpdateStats(lc *y.Closer) {
 			Config.Mu.Unlock()
 			if setLruMemory {
 				if inUse > 0.75*mem {
-					maxSize = lcache.UpdateMaxSize(0)
+					for _, c := range adaptive {
+						c.UpdateMaxSize(0)
+					}
 					setLruMemory = false
 					lastUse = inUse
 				}
@@ -156,17 +215,11 @@ func periodicUpdateStats(lc *y.Closer) {
 				break
 			}
 
-			delta := maxSize / 10
-			if delta > 50<<20 {
-				delta = 50 << 20 // Change lru cache size by max 50mb.
-			}
 			if inUse > 0.85*mem { // Decrease max Size by 10%
-				maxSize -= delta
-				maxSize = lcache.UpdateMaxSize(maxSize)
+				resize(false)
 				lastUse = inUse
 			} else if inUse < 0.65*mem { // Increase max Size by 10%
-				maxSize += delta
-				maxSize = lcache.UpdateMaxSize(maxSize)
+				resize(true)
 				lastUse = inUse
 			}
 		}
@@ -202,17 +255,61 @@ func updateMemoryMetrics(lc *y.Closer) {
 	}
 }
 
+const (
+	postingCacheName = "postings"
+	indexCacheName   = "index"
+	blockCacheName   = "block"
+)
+
 var (
 	pstore *badger.DB
+	// lcache holds the data, reverse and count posting lists, icache the index ones.
 	lcache *listCache
+	icache *listCache
+	// bcache is nil, unless Config.BlockCacheMB is set.
+	bcache *blockCache
 	closer *y.Closer
 )
 
+func init() {
+	for _, m := range []*expvar.Map{x.LcachePredicateHits, x.LcachePredicateMiss} {
+		for _, name := range []string{postingCacheName, indexCacheName, blockCacheName} {
+			m.Set(name, new(expvar.Map).Init())
+		}
+	}
+}
+
+func newCache(name string, sizeMB float64) *listCache {
+	c := newListCache(math.MaxUint64)
+	if sizeMB > 0 {
+		c.MaxSize = uint64(sizeMB * MB)
+		c.fixed = true
+	}
+	c.name = name
+	c.hits = x.LcachePredicateHits.Get(name).(*expvar.Map)
+	c.misses = x.LcachePredicateMiss.Get(name).(*expvar.Map)
+	return c
+}
+
 // Init initializes the posting lists package, the in memory and dirty list hash.
 func Init(ps *badger.DB) {
 	pstore = ps
-	lcache = newListCache(math.MaxUint64)
-	x.LcacheCapacity.Set(math.MaxInt64)
+	lcache = newCache(postingCacheName, Config.PostingCacheMB)
+	icache = newCache(indexCacheName, Config.IndexCacheMB)
+	bcache = nil
+	if Config.BlockCacheMB > 0 {
+		bc, err := newBlockCache(uint64(Config.BlockCacheMB * MB))
+		if err != nil {
+			glog.Errorf("Unable to allocate the block cache, continuing without it: %v", err)
+		} else {
+			bc.hits = x.LcachePredicateHits.Get(blockCacheName).(*expvar.Map)
+			bc.misses = x.LcachePredicateMiss.Get(blockCacheName).(*expvar.Map)
+			bcache = bc
+			lcache.spill = bc
+			icache.spill = bc
+		}
+	}
+	updateCacheMetrics()
 
 	closer = y.NewCloser(2)
 
@@ -224,6 +321,40 @@ func Cleanup() {
 	closer.SignalAndWait()
 }
 
+// cacheFor returns the list cache which holds the key.
+func cacheFor(key []byte) (*listCache, string) {
+	attr, isIndex := keyAttr(key)
+	if isIndex {
+		return icache, attr
+	}
+	return lcache, attr
+}
+
+// clearCaches removes the matching lists from all caches, without syncing them to disk.
+func clearCaches(remove func(key []byte) bool) {
+	lcache.clear(remove)
+	icache.clear(remove)
+	if bcache != nil {
+		bcache.clear(remove)
+	}
+}
+
+// EvictKeys removes the lists of keys from all caches, after they were written to the store
+// directly, without going through the lists.
+func EvictKeys(keys [][]byte) {
+	if len(keys) == 0 {
+		return
+	}
+	evict := make(map[string]struct{}, len(keys))
+	for _, key := range keys {
+		evict[string(key)] = struct{}{}
+	}
+	clearCaches(func(key []byte) bool {
+		_, ok := evict[string(key)]
+		return ok
+	})
+}
+
 // Get stores the List corresponding to key, if it's not there already.
 // to lru cache and returns it.
 //
@@ -235,21 +366,30 @@ func Cleanup() {
 // And watermark stuff would have to be located outside worker pkg, maybe in x.
 // That way, we don't have a dependency conflict.
 func Get(key []byte) (rlist *List, err error) {
-	lp := lcache.Get(string(key))
+	c, attr := cacheFor(key)
+	lp := c.Get(string(key))
 	if lp != nil {
 		x.LcacheHit.Add(1)
+		countAccess(c.hits, attr)
 		return lp, nil
 	}
 	x.LcacheMiss.Add(1)
+	countAccess(c.misses, attr)
 
 	// Any initialization for l must be done before PutIfMissing. Once it's added
 	// to the map, any other goroutine can retrieve it.
-	l, err := getNew(key, pstore)
-	if err != nil {
-		return nil, err
+	var l *List
+	if bcache != nil {
+		// Taken out of the block cache, as the list can now be mutated.
+		l = bcache.get(key, true)
+	}
+	if l == nil {
+		if l, err = getNew(key, pstore); err != nil {
+			return nil, err
+		}
 	}
 	// We are always going to return lp to caller, whether it is l or not
-	lp = lcache.PutIfMissing(string(key), l)
+	lp = c.PutIfMissing(string(key), l)
 	if lp != l {
 		x.LcacheRace.Add(1)
 	}
@@ -258,16 +398,27 @@ func Get(key []byte) (rlist *List, err error) {
 
 // GetLru checks the lru map and returns it if it exits
 func GetLru(key []byte) *List {
-	return lcache.Get(string(key))
+	c, _ := cacheFor(key)
+	return c.Get(string(key))
 }
 
 // GetNoStore takes a key. It checks if the in-memory map has an updated value and returns it if it exists
 // or it gets from the store and DOES NOT ADD to lru cache.
 func GetNoStore(key []byte) (*List, error) {
-	lp := lcache.Get(string(key))
-	if lp != nil {
+	c, attr := cacheFor(key)
+	if lp := c.Get(string(key)); lp != nil {
+		x.LcacheHit.Add(1)
+		countAccess(c.hits, attr)
 		return lp, nil
 	}
+	x.LcacheMiss.Add(1)
+	countAccess(c.misses, attr)
+	if bcache != nil {
+		// Left in the block cache, as the list isn't added to the list cache.
+		if l := bcache.get(key, false); l != nil {
+			return l, nil
+		}
+	}
 	return getNew(key, pstore) // This retrieves a new *List and sets refcount to 1.
 }
 
@@ -275,4 +426,8 @@ func GetNoStore(key []byte) (*List, error) {
 // memory(for example before populating snapshot) or after calling syncAllMarks
 func EvictLRU() {
 	lcache.Reset()
+	icache.Reset()
+	if bcache != nil {
+		bcache.reset()
+	}
 }
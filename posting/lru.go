@@ -22,6 +22,8 @@ package posting
 import (
 	"container/list"
 	"context"
+	"encoding/binary"
+	"expvar"
 	"sync"
 	"time"
 
@@ -40,11 +42,19 @@ type listCache struct {
 	evicts  uint64
 	ll      *list.List
 	cache   map[string]*list.Element
+
+	name string
+	// fixed is set if MaxSize was configured, instead of following the memory in use.
+	fixed bool
+	// spill, if set, keeps the evicted lists.
+	spill        *blockCache
+	hits, misses *expvar.Map
 }
 
 type CacheStats struct {
 	Length    int
 	Size      uint64
+	Capacity  uint64
 	NumEvicts uint64
 }
 
@@ -76,7 +86,6 @@ func (c *listCache) UpdateMaxSize(size uint64) uint64 {
 		size = 50 << 20
 	}
 	c.MaxSize = size
-	x.LcacheCapacity.Set(int64(c.MaxSize))
 	return c.MaxSize
 }
 
@@ -140,6 +149,10 @@ func (c *listCache) removeOldest() {
 		// No mutations found and we have marked the PL for deletion. Now we can
 		// safely delete it from the cache.
 		delete(c.cache, e.key)
+		if c.spill != nil {
+			// Done before unlocking, so that Get finds the list in either cache.
+			c.spill.put(e.key, e.pl)
+		}
 
 		// ele gets Reset once it's passed to Remove, so store the prev.
 		prev := ele.Prev()
@@ -174,6 +187,7 @@ func (c *listCache) Stats() CacheStats {
 	return CacheStats{
 		Length:    c.ll.Len(),
 		Size:      c.curSize,
+		Capacity:  c.MaxSize,
 		NumEvicts: c.evicts,
 	}
 }
@@ -221,6 +235,7 @@ func (c *listCache) clear(remove func(key []byte) bool) {
 
 		c.ll.Remove(e)
 		delete(c.cache, k)
+		c.curSize -= kv.size
 	}
 }
 
@@ -232,5 +247,26 @@ func (c *listCache) delete(key []byte) {
 	if ele, ok := c.cache[string(key)]; ok {
 		c.ll.Remove(ele)
 		delete(c.cache, string(key))
+		c.curSize -= ele.Value.(*entry).size
+	}
+}
+
+// keyAttr returns the predicate of a data, index, reverse or count key, and whether it's an
+// index key, without the allocations of x.Parse.
+func keyAttr(key []byte) (string, bool) {
+	if len(key) < 3 {
+		return "", false
+	}
+	sz := int(binary.BigEndian.Uint16(key[1:3]))
+	if len(key) < 3+sz+1 {
+		return "", false
+	}
+	return string(key[3 : 3+sz]), key[3+sz] == x.ByteIndex
+}
+
+// countAccess counts a hit or miss of the predicate in m, if set.
+func countAccess(m *expvar.Map, attr string) {
+	if m != nil {
+		m.Add(attr, 1)
 	}
 }
@@ -19,6 +19,7 @@ package posting
 import (
 	"bytes"
 	"math"
+	"sort"
 	"strconv"
 	"sync/atomic"
 	"time"
@@ -35,6 +36,49 @@ var (
 	ErrTsTooOld = x.Errorf("Transaction is too old")
 )
 
+// historyTs is the timestamp as of which, and after, the versions of the posting lists are all kept
+// on disk, so that they can be read as of any timestamp from it on.
+var historyTs uint64
+
+// SetDiscardTs lets Badger discard the versions of the posting lists below ts. They can't be read
+// anymore after that.
+func SetDiscardTs(ts uint64) {
+	pstore.SetDiscardTs(ts)
+	RaiseHistoryTs(ts)
+}
+
+// RaiseHistoryTs records that the versions of the posting lists below ts might be gone.
+func RaiseHistoryTs(ts uint64) {
+	for {
+		cur := atomic.LoadUint64(&historyTs)
+		if ts <= cur || atomic.CompareAndSwapUint64(&historyTs, cur, ts) {
+			return
+		}
+	}
+}
+
+// HistoryTs returns the oldest timestamp the posting lists can be read as of.
+func HistoryTs() uint64 {
+	return atomic.LoadUint64(&historyTs)
+}
+
+// readHistory reads the posting list as of readTs from the versions on disk, for the reads older
+// than the list in memory, like those of historical queries.
+func (l *List) readHistory(readTs uint64) (*List, error) {
+	if readTs < HistoryTs() {
+		return nil, x.Errorf("readTs: %d is older than the history kept, from %d on, for key: %q",
+			readTs, HistoryTs(), l.key)
+	}
+	txn := pstore.NewTransactionAt(readTs, false)
+	defer txn.Discard()
+	iterOpts := badger.DefaultIteratorOptions
+	iterOpts.AllVersions = true
+	it := txn.NewIterator(iterOpts)
+	defer it.Close()
+	it.Seek(l.key)
+	return ReadPostingList(l.key, it)
+}
+
 func (t *Txn) SetAbort() {
 	atomic.StoreUint32(&t.shouldAbort, 1)
 }
@@ -46,6 +90,16 @@ func (t *Txn) ShouldAbort() bool {
 	return atomic.LoadUint32(&t.shouldAbort) > 0
 }
 
+// SetKeepAlive keeps the transaction from being aborted when idle.
+func (t *Txn) SetKeepAlive() {
+	atomic.StoreUint32(&t.keepAlive, 1)
+}
+
+// KeptAlive tells whether the transaction is kept from being aborted when idle.
+func (t *Txn) KeptAlive() bool {
+	return atomic.LoadUint32(&t.keepAlive) > 0
+}
+
 func (t *Txn) AddKeys(key, conflictKey string) {
 	t.Lock()
 	defer t.Unlock()
@@ -80,6 +134,60 @@ func (t *Txn) Fill(ctx *api.TxnContext) {
 	}
 }
 
+// ConflictKey returns the conflict key of the transaction whose fingerprint, as sent to Zero by
+// Fill, is fp.
+func (t *Txn) ConflictKey(fp string) (string, bool) {
+	t.Lock()
+	defer t.Unlock()
+	for key := range t.conflicts {
+		if strconv.FormatUint(farm.Fingerprint64([]byte(key)), 36) == fp {
+			return key, true
+		}
+	}
+	return "", false
+}
+
+// Attrs returns the predicates modified by the transaction.
+func (t *Txn) Attrs() []string {
+	t.Lock()
+	defer t.Unlock()
+	var attrs []string
+	for key := range t.deltas {
+		pk := x.Parse([]byte(key))
+		if !x.HasString(attrs, pk.Attr) {
+			attrs = append(attrs, pk.Attr)
+		}
+	}
+	return attrs
+}
+
+// DataPostings calls fn for the postings the transaction set or deleted in the lists of data keys.
+// It must be called before the transaction is committed to memory.
+func (t *Txn) DataPostings(fn func(pk *x.ParsedKey, p *pb.Posting)) error {
+	var keys []string
+	t.Lock()
+	for key := range t.deltas {
+		keys = append(keys, key)
+	}
+	t.Unlock()
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		pk := x.Parse([]byte(key))
+		if !pk.IsData() || x.IsScratchAttr(pk.Attr) {
+			continue
+		}
+		plist, err := t.Get([]byte(key))
+		if err != nil {
+			return err
+		}
+		for _, p := range plist.mutationPostings(t.StartTs) {
+			fn(pk, p)
+		}
+	}
+	return nil
+}
+
 // Don't call this for schema mutations. Directly commit them.
 // This function only stores deltas to the commit timestamps. It does not try to generate a state.
 // TODO: Simplify this function. All it should be doing is to store the deltas, and not try to
@@ -103,6 +211,9 @@ func (tx *Txn) CommitToDisk(writer *x.TxnWriter, commitTs uint64) error {
 	// memory, instead of writing them back again.
 
 	for _, key := range keys {
+		if x.IsScratchAttr(x.Parse([]byte(key)).Attr) {
+			continue
+		}
 		plist, err := tx.Get([]byte(key))
 		if err != nil {
 			return err
@@ -127,13 +238,18 @@ func (tx *Txn) CommitToMemory(commitTs uint64) error {
 	// 	atomic.StoreUint32(&tx.shouldAbort, 1)
 	// }()
 	for key := range tx.deltas {
+		ts := commitTs
+		if x.IsScratchAttr(x.Parse([]byte(key)).Attr) {
+			// Writes to scratch predicates are discarded, as if the txn was aborted.
+			ts = 0
+		}
 	inner:
 		for {
 			plist, err := tx.Get([]byte(key))
 			if err != nil {
 				return err
 			}
-			err = plist.CommitMutation(tx.StartTs, commitTs)
+			err = plist.CommitMutation(tx.StartTs, ts)
 			switch err {
 			case nil:
 				break inner
@@ -22,7 +22,9 @@ import (
 	"sync"
 	"time"
 
+	"github.com/dgraph-io/dgraph/fault"
 	"github.com/dgraph-io/dgraph/protos/pb"
+	"github.com/dgraph-io/dgraph/schema"
 	"github.com/dgraph-io/dgraph/x"
 )
 
@@ -44,6 +46,7 @@ type Txn struct {
 
 	// atomic
 	shouldAbort uint32
+	keepAlive   uint32 // Not aborted when idle, see TxnOlderThan.
 	// Fields which can changed after init
 	sync.Mutex
 	// Deltas keeps track of the posting list keys, and whether they should be considered for
@@ -120,13 +123,15 @@ func (o *oracle) MinPendingStartTs() uint64 {
 	return min
 }
 
+// TxnOlderThan returns the start timestamps of the pending transactions last updated over dur
+// ago, leaving out the ones kept alive.
 func (o *oracle) TxnOlderThan(dur time.Duration) (res []uint64) {
 	o.RLock()
 	defer o.RUnlock()
 
-	cutoff := time.Now().Add(-dur)
+	cutoff := fault.Now().Add(-dur)
 	for startTs, txn := range o.pendingTxns {
-		if txn.lastUpdate.Before(cutoff) {
+		if txn.lastUpdate.Before(cutoff) && !txn.KeptAlive() {
 			res = append(res, startTs)
 		}
 	}
@@ -166,8 +171,18 @@ func (o *oracle) WaitForTs(ctx context.Context, startTs uint64) error {
 func (o *oracle) ProcessDelta(delta *pb.OracleDelta) {
 	o.Lock()
 	defer o.Unlock()
-	for _, txn := range delta.Txns {
-		delete(o.pendingTxns, txn.StartTs)
+	var scratch []string
+	for _, status := range delta.Txns {
+		if txn, ok := o.pendingTxns[status.StartTs]; ok {
+			scratch = txn.scratchAttrs(scratch)
+		}
+		delete(o.pendingTxns, status.StartTs)
+	}
+	// The schema of a scratch predicate is only kept while pending txns write to it.
+	for _, attr := range scratch {
+		if !o.pendingWrites(attr) {
+			schema.State().Forget(attr)
+		}
 	}
 	if delta.MaxAssigned < o.maxAssigned {
 		return
@@ -209,6 +224,28 @@ func (t *Txn) matchesDelta(ok func(key []byte) bool) bool {
 	return false
 }
 
+// scratchAttrs appends to attrs the scratch predicates the txn wrote to.
+func (t *Txn) scratchAttrs(attrs []string) []string {
+	t.Lock()
+	defer t.Unlock()
+	for key := range t.deltas {
+		if attr := x.Parse([]byte(key)).Attr; x.IsScratchAttr(attr) && !x.HasString(attrs, attr) {
+			attrs = append(attrs, attr)
+		}
+	}
+	return attrs
+}
+
+// pendingWrites tells whether a pending txn writes to attr. It must be called with o locked.
+func (o *oracle) pendingWrites(attr string) bool {
+	for _, txn := range o.pendingTxns {
+		if txn.matchesDelta(func(key []byte) bool { return x.Parse(key).Attr == attr }) {
+			return true
+		}
+	}
+	return false
+}
+
 // IterateTxns returns a list of start timestamps for currently pending transactions, which match
 // the provided function.
 func (o *oracle) IterateTxns(ok func(key []byte) bool) []uint64 {
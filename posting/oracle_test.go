@@ -0,0 +1,77 @@
+/*
+ * Copyright 2018 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package posting
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dgraph-io/dgraph/protos/pb"
+	"github.com/dgraph-io/dgraph/schema"
+	"github.com/dgraph-io/dgraph/x"
+)
+
+func TestTxnOlderThan(t *testing.T) {
+	o := Oracle()
+	defer o.ResetTxns()
+
+	idle := o.RegisterStartTs(10)
+	idle.lastUpdate = time.Now().Add(-time.Hour)
+	kept := o.RegisterStartTs(11)
+	kept.lastUpdate = time.Now().Add(-time.Hour)
+	kept.SetKeepAlive()
+	o.RegisterStartTs(12)
+
+	require.Equal(t, []uint64{10}, o.TxnOlderThan(time.Minute))
+
+	// A new mutation of the txn makes it active again.
+	o.RegisterStartTs(10)
+	require.Empty(t, o.TxnOlderThan(time.Minute))
+}
+
+func TestProcessDelta_ScratchSchema(t *testing.T) {
+	o := Oracle()
+	defer o.ResetTxns()
+
+	attr := x.ScratchPrefix + "visited"
+	write := func(startTs uint64) {
+		txn := o.RegisterStartTs(startTs)
+		l, err := Get(x.DataKey(attr, startTs))
+		require.NoError(t, err)
+		addMutationHelper(t, l, &pb.DirectedEdge{Value: []byte("yes")}, Set, txn)
+	}
+	hasSchema := func() bool {
+		_, ok := schema.State().Get(attr)
+		return ok
+	}
+	schema.State().Set(attr, pb.SchemaUpdate{ValueType: pb.Posting_STRING, Predicate: attr})
+	for ts := uint64(100); ts < 104; ts++ {
+		write(ts)
+	}
+
+	// The schema is kept while other txns write to the predicate.
+	o.ProcessDelta(&pb.OracleDelta{Txns: []*pb.TxnStatus{{StartTs: 100, CommitTs: 104}}})
+	require.True(t, hasSchema())
+	o.ProcessDelta(&pb.OracleDelta{Txns: []*pb.TxnStatus{{StartTs: 101}}})
+	require.True(t, hasSchema())
+	// Finishing the last ones forgets it, even when they finish together.
+	o.ProcessDelta(&pb.OracleDelta{Txns: []*pb.TxnStatus{{StartTs: 102}, {StartTs: 103,
+		CommitTs: 105}}})
+	require.False(t, hasSchema())
+}
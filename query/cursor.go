@@ -0,0 +1,235 @@
+/*
+ * Copyright 2018 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package query
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"strings"
+
+	"golang.org/x/net/context"
+
+	"github.com/dgraph-io/dgraph/gql"
+	"github.com/dgraph-io/dgraph/protos/pb"
+	"github.com/dgraph-io/dgraph/worker"
+	"github.com/dgraph-io/dgraph/x"
+)
+
+// A cursor points at the last result of a page of a query block, so that the next page can be
+// read with after: <cursor>. Unlike offsets, cursors don't need the results before them to be
+// read again, and aren't thrown off by results added or removed before them. Results sorted by a
+// predicate are paginated after the sort value and uid of the last one, other results after its
+// uid.
+//
+// Cursors are opaque to clients. They are made of a letter, so that they can't be mistaken for a
+// uid, followed by the hex encoding of:
+//
+//	version(1) | uid(8) | flags(1) [| attr length(2) | attr [| value type(1) | value]]
+//
+// where the attribute and value are only there for sorted results.
+type cursor struct {
+	uid uint64
+	// attr and desc are the order of sorted results.
+	attr string
+	desc bool
+	// val is the sort value of uid, nil if it doesn't have any.
+	val *pb.TaskValue
+}
+
+const (
+	cursorPrefix  = "c"
+	cursorVersion = 1
+)
+
+// Flags of the cursor encoding.
+const (
+	cursorSorted byte = 1 << iota
+	cursorDesc
+	cursorHasValue
+)
+
+// cursorsAttr is the key under which the cursors of the query blocks are returned.
+const cursorsAttr = "_cursors_"
+
+var errInvalidCursor = x.Errorf("Invalid cursor")
+
+func (c *cursor) sorted() bool {
+	return len(c.attr) > 0
+}
+
+func (c *cursor) encode() string {
+	buf := make([]byte, 10, 16)
+	buf[0] = cursorVersion
+	binary.BigEndian.PutUint64(buf[1:], c.uid)
+	if !c.sorted() {
+		return cursorPrefix + hex.EncodeToString(buf)
+	}
+
+	buf[9] |= cursorSorted
+	if c.desc {
+		buf[9] |= cursorDesc
+	}
+	var l [2]byte
+	binary.BigEndian.PutUint16(l[:], uint16(len(c.attr)))
+	buf = append(buf, l[:]...)
+	buf = append(buf, c.attr...)
+	if c.val != nil {
+		buf[9] |= cursorHasValue
+		buf = append(buf, byte(c.val.ValType))
+		buf = append(buf, c.val.Val...)
+	}
+	return cursorPrefix + hex.EncodeToString(buf)
+}
+
+func parseCursor(s string) (*cursor, error) {
+	if !strings.HasPrefix(s, cursorPrefix) {
+		return nil, errInvalidCursor
+	}
+	buf, err := hex.DecodeString(s[len(cursorPrefix):])
+	if err != nil || len(buf) < 10 || buf[0] != cursorVersion {
+		return nil, errInvalidCursor
+	}
+	c := &cursor{uid: binary.BigEndian.Uint64(buf[1:])}
+	flags := buf[9]
+	buf = buf[10:]
+	if flags&cursorSorted == 0 {
+		if len(buf) > 0 || c.uid == 0 {
+			return nil, errInvalidCursor
+		}
+		return c, nil
+	}
+
+	if len(buf) < 2 {
+		return nil, errInvalidCursor
+	}
+	n := int(binary.BigEndian.Uint16(buf))
+	buf = buf[2:]
+	if n == 0 || len(buf) < n {
+		return nil, errInvalidCursor
+	}
+	c.attr = string(buf[:n])
+	c.desc = flags&cursorDesc != 0
+	buf = buf[n:]
+	if flags&cursorHasValue == 0 {
+		if len(buf) > 0 {
+			return nil, errInvalidCursor
+		}
+		return c, nil
+	}
+	if len(buf) < 1 {
+		return nil, errInvalidCursor
+	}
+	c.val = &pb.TaskValue{ValType: pb.Posting_ValType(buf[0]), Val: buf[1:]}
+	return c, nil
+}
+
+// fillCursor sets the pagination of args after the cursor in v. Cursors of sorted results can
+// only be used to paginate results sorted the same way.
+func (args *params) fillCursor(v string) error {
+	c, err := parseCursor(v)
+	if err != nil {
+		return err
+	}
+	if len(args.FacetOrder) > 0 {
+		return x.Errorf("Cursors can't be used with results sorted by facets")
+	}
+	if !c.sorted() {
+		if len(args.Order) > 0 {
+			return x.Errorf("Cursor is for unsorted results")
+		}
+		args.AfterUID = c.uid
+		args.cursor = c
+		return nil
+	}
+	if len(args.Order) != 1 {
+		return x.Errorf("Cursor is for results sorted by a single predicate")
+	}
+	if o := args.Order[0]; o.Attr != c.attr || o.Desc != c.desc {
+		return x.Errorf("Cursor is for results sorted by %s", c.attr)
+	}
+	args.cursor = c
+	return nil
+}
+
+// ordersByVar tells whether the results of sg are sorted by a value variable.
+func (sg *SubGraph) ordersByVar() bool {
+	if len(sg.Params.Order) == 0 {
+		return false
+	}
+	for _, it := range sg.Params.NeedsVar {
+		if it.Name == sg.Params.Order[0].Attr && it.Typ == gql.VALUE_VAR {
+			return true
+		}
+	}
+	return false
+}
+
+// setCursor sets the cursor of the next page of the root results of sg, if they are paginated and
+// the page is full.
+func (sg *SubGraph) setCursor(ctx context.Context) error {
+	params := &sg.Params
+	params.nextCursor = ""
+	if params.Count <= 0 || len(params.FacetOrder) > 0 || len(params.Order) > 1 ||
+		params.Alias == "var" || params.Alias == "shortest" || params.IsEmpty ||
+		params.Recurse || params.isGroupBy || params.DoCount || sg.ordersByVar() {
+		return nil
+	}
+	if len(sg.uidMatrix) != 1 || len(sg.uidMatrix[0].Uids) < params.Count {
+		return nil
+	}
+
+	uids := sg.uidMatrix[0].Uids
+	c := &cursor{uid: uids[len(uids)-1]}
+	if len(params.Order) == 0 {
+		params.nextCursor = c.encode()
+		return nil
+	}
+
+	order := params.Order[0]
+	c.attr, c.desc = order.Attr, order.Desc
+	result, err := worker.ProcessTaskOverNetwork(ctx, &pb.Query{
+		Attr:    order.Attr,
+		Langs:   order.Langs,
+		ReadTs:  sg.ReadTs,
+		UidList: &pb.List{Uids: []uint64{c.uid}},
+	})
+	if err != nil {
+		return err
+	}
+	if len(result.ValueMatrix) > 0 && len(result.ValueMatrix[0].Values) > 0 {
+		c.val = result.ValueMatrix[0].Values[0]
+	}
+	params.nextCursor = c.encode()
+	return nil
+}
+
+// filterAfterCursor leaves out the uids up to the cursor, for results which aren't sorted.
+func (sg *SubGraph) filterAfterCursor() {
+	c := sg.Params.cursor
+	if c == nil || c.sorted() {
+		return
+	}
+	for i, ul := range sg.uidMatrix {
+		out := &pb.List{}
+		for _, uid := range ul.Uids {
+			if uid > c.uid {
+				out.Uids = append(out.Uids, uid)
+			}
+		}
+		sg.uidMatrix[i] = out
+	}
+}
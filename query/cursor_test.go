@@ -0,0 +1,92 @@
+/*
+ * Copyright 2018 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package query
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dgraph-io/dgraph/protos/pb"
+)
+
+func TestCursorEncoding(t *testing.T) {
+	cursors := []*cursor{
+		{uid: 0x2a},
+		{uid: 0x2a, attr: "name"},
+		{uid: 0x2a, attr: "name", desc: true,
+			val: &pb.TaskValue{ValType: pb.Posting_STRING, Val: []byte("Alice")}},
+		{uid: 0x2a, attr: "age", val: &pb.TaskValue{ValType: pb.Posting_INT, Val: []byte{}}},
+	}
+	for _, c := range cursors {
+		s := c.encode()
+		require.Equal(t, cursorPrefix, s[:1])
+		parsed, err := parseCursor(s)
+		require.NoError(t, err)
+		require.Equal(t, c.uid, parsed.uid)
+		require.Equal(t, c.attr, parsed.attr)
+		require.Equal(t, c.desc, parsed.desc)
+		if c.val == nil {
+			require.Nil(t, parsed.val)
+			continue
+		}
+		require.Equal(t, c.val.ValType, parsed.val.ValType)
+		require.Equal(t, string(c.val.Val), string(parsed.val.Val))
+	}
+}
+
+func TestCursorInvalid(t *testing.T) {
+	valid := (&cursor{uid: 0x2a, attr: "name"}).encode()
+	for _, s := range []string{
+		"",
+		"0x2a",
+		"c",
+		"cxyz",
+		"c02000000000000002a00",
+		(&cursor{}).encode(),
+		valid[:len(valid)-2],
+		valid + "00",
+	} {
+		_, err := parseCursor(s)
+		require.Error(t, err, s)
+	}
+}
+
+func TestFillCursor(t *testing.T) {
+	unsorted := (&cursor{uid: 0x2a}).encode()
+	sorted := (&cursor{uid: 0x2a, attr: "name"}).encode()
+
+	var args params
+	require.NoError(t, args.fillCursor(unsorted))
+	require.Equal(t, uint64(0x2a), args.AfterUID)
+
+	args = params{Order: []*pb.Order{{Attr: "name"}}}
+	require.NoError(t, args.fillCursor(sorted))
+	require.Equal(t, uint64(0), args.AfterUID)
+	require.Equal(t, "name", args.cursor.attr)
+
+	args = params{Order: []*pb.Order{{Attr: "name"}}}
+	require.Error(t, args.fillCursor(unsorted))
+	args = params{Order: []*pb.Order{{Attr: "name", Desc: true}}}
+	require.Error(t, args.fillCursor(sorted))
+	args = params{Order: []*pb.Order{{Attr: "age"}}}
+	require.Error(t, args.fillCursor(sorted))
+	args = params{Order: []*pb.Order{{Attr: "name"}, {Attr: "age"}}}
+	require.Error(t, args.fillCursor(sorted))
+	args = params{}
+	require.Error(t, args.fillCursor(sorted))
+}
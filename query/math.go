@@ -159,6 +159,10 @@ func processBinaryBoolean(mNode *mathTree) (err error) {
 			// Use the constant value that was supplied.
 			curVal = ch.Const
 		}
+		if curVal.Value == nil {
+			// Nothing to compare with, like for a uid missing from a variable of another block.
+			continue
+		}
 		res, err := compareValues(aggName, val, curVal)
 		if err != nil {
 			return x.Wrapf(err, "Wrong values in comaprison function.")
@@ -205,6 +209,9 @@ func processTernary(mNode *mathTree) (err error) {
 				res = varTwo[k]
 			}
 		}
+		if res.Value == nil {
+			continue
+		}
 		destMap[k] = res
 	}
 	mNode.Val = destMap
@@ -415,7 +415,9 @@ func (n *fastJsonNode) addAggregations(sg *SubGraph) error {
 	return nil
 }
 
-func processNodeUids(n *fastJsonNode, sg *SubGraph) error {
+// processNodeUids adds the output nodes of the results of sg to the root n. With a stream, they
+// are encoded and taken out of n a chunk at a time.
+func processNodeUids(n *fastJsonNode, sg *SubGraph, out *jsonStream) error {
 	var seedNode *fastJsonNode
 	if sg.Params.IsEmpty {
 		return n.addAggregations(sg)
@@ -463,6 +465,9 @@ func processNodeUids(n *fastJsonNode, sg *SubGraph) error {
 		hasChild = true
 		if !sg.Params.Normalize {
 			n.AddListChild(sg.Params.Alias, n1)
+			if err := out.flush(n, false); err != nil {
+				return err
+			}
 			continue
 		}
 
@@ -474,6 +479,9 @@ func processNodeUids(n *fastJsonNode, sg *SubGraph) error {
 		for _, c := range normalized {
 			n.AddListChild(sg.Params.Alias, &fastJsonNode{attrs: c})
 		}
+		if err := out.flush(n, false); err != nil {
+			return err
+		}
 	}
 
 	if !hasChild {
@@ -484,8 +492,10 @@ func processNodeUids(n *fastJsonNode, sg *SubGraph) error {
 }
 
 type Extensions struct {
-	Latency *api.Latency    `json:"server_latency,omitempty"`
-	Txn     *api.TxnContext `json:"txn,omitempty"`
+	Latency    *api.Latency    `json:"server_latency,omitempty"`
+	Txn        *api.TxnContext `json:"txn,omitempty"`
+	BestEffort bool            `json:"best_effort,omitempty"`
+	AsOfTs     uint64          `json:"as_of_ts,omitempty"`
 }
 
 func (sg *SubGraph) toFastJSON(l *Latency) ([]byte, error) {
@@ -496,11 +506,30 @@ func (sg *SubGraph) toFastJSON(l *Latency) ([]byte, error) {
 	var seedNode *fastJsonNode
 	var err error
 	n := seedNode.New("_root_")
+	cursors := seedNode.New(cursorsAttr)
+	recurse := seedNode.New(recurseAttr)
 	for _, sg := range sg.Children {
-		err = processNodeUids(n.(*fastJsonNode), sg)
+		err = processNodeUids(n.(*fastJsonNode), sg, nil)
 		if err != nil {
 			return nil, err
 		}
+		if sg.Params.nextCursor != "" {
+			cursors.AddValue(sg.Params.Alias,
+				types.Val{Tid: types.StringID, Value: sg.Params.nextCursor})
+		}
+		if st := sg.Params.recurseStats; st != nil {
+			a := recurse.New(sg.Params.Alias)
+			a.AddValue("nodes", types.Val{Tid: types.IntID, Value: int64(st.nodes)})
+			a.AddValue("cycles", types.Val{Tid: types.BoolID, Value: st.cycles})
+			a.AddValue("stop", types.Val{Tid: types.StringID, Value: st.stop})
+			recurse.AddMapChild(sg.Params.Alias, a, false)
+		}
+	}
+	if !cursors.IsEmpty() {
+		n.AddMapChild(cursorsAttr, cursors, false)
+	}
+	if !recurse.IsEmpty() {
+		n.AddMapChild(recurseAttr, recurse, false)
 	}
 
 	// According to GraphQL spec response should only contain data, errors and extensions as top
@@ -18,6 +18,7 @@ package query
 
 import (
 	"bytes"
+	"errors"
 	"fmt"
 	"runtime"
 	"sync"
@@ -25,6 +26,7 @@ import (
 
 	"github.com/stretchr/testify/require"
 
+	"github.com/dgraph-io/dgraph/gql"
 	"github.com/dgraph-io/dgraph/types"
 )
 
@@ -152,3 +154,45 @@ func TestNormalizeJSONUid2(t *testing.T) {
 	nn.(*fastJsonNode).encode(&b)
 	require.JSONEq(t, `{"alias":[{"___attr1":"","___attr2":"","uid":"0x3","attr3":""}]}`, b.String())
 }
+
+func TestStreamJson(t *testing.T) {
+	for _, q := range []string{
+		`{ me(func: uid(1, 23, 24, 25, 31)) { name friend { name } } }`,
+		`{ me(func: uid(1)) { count(friend) } you(func: uid(23, 24, 25)) { name } }`,
+		`{ me(func: uid(1, 23)) @normalize { n: name friend { f: name } } }`,
+		`{ me(func: uid(1000)) { name } }`,
+		`{ var(func: uid(1)) { f as friend } me(func: uid(f)) { count(uid) name } }`,
+	} {
+		res, err := gql.Parse(gql.Request{Str: q})
+		require.NoError(t, err)
+		startTs := timestamp()
+		maxPendingCh <- startTs
+		queryRequest := QueryRequest{Latency: &Latency{}, GqlQuery: &res, ReadTs: startTs}
+		require.NoError(t, queryRequest.ProcessQuery(defaultContext()))
+		want, err := ToJson(queryRequest.Latency, queryRequest.Subgraphs)
+		require.NoError(t, err)
+
+		for _, size := range []int{1, 2, 1000} {
+			var got bytes.Buffer
+			chunks := 0
+			require.NoError(t, StreamJson(queryRequest.Latency, queryRequest.Subgraphs, size,
+				func(b []byte) error {
+					chunks++
+					got.Write(b)
+					return nil
+				}))
+			require.Equal(t, string(want), got.String(), "query %s, chunks of %d", q, size)
+			require.True(t, chunks >= 1)
+		}
+
+		// An error of emit stops the encoding.
+		errStop := errors.New("stop")
+		chunks := 0
+		err = StreamJson(queryRequest.Latency, queryRequest.Subgraphs, 1, func(b []byte) error {
+			chunks++
+			return errStop
+		})
+		require.Equal(t, errStop, err)
+		require.Equal(t, 1, chunks)
+	}
+}
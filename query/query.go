@@ -129,6 +129,7 @@ type params struct {
 	RecurseArgs  gql.RecurseArgs
 	Cascade      bool
 	IgnoreReflex bool
+	Hints        gql.QueryHints
 
 	From           uint64
 	To             uint64
@@ -148,6 +149,10 @@ type params struct {
 	IsEmpty        bool     // Won't have any SrcUids or DestUids. Only used to get aggregated vars
 	expandAll      bool     // expand all languages
 	shortest       bool
+	cursor         *cursor       // Set if the results are paginated after a cursor.
+	nextCursor     string        // Cursor of the next page of root results, if any.
+	levels         levelRange    // Levels of a recurse query the predicate is followed at.
+	recurseStats   *recurseStats // Set for annotated recurse queries once processed.
 }
 
 // Function holds the information about gql functions.
@@ -692,6 +697,7 @@ func treeCopy(gq *gql.GraphQuery, sg *SubGraph) error {
 			FacetOrder:     gchild.FacetOrder,
 			FacetOrderDesc: gchild.FacetDesc,
 			IgnoreReflex:   sg.Params.IgnoreReflex,
+			Hints:          sg.Params.Hints,
 			Order:          gchild.Order,
 			Facet:          gchild.Facets,
 		}
@@ -783,9 +789,13 @@ func (args *params) fill(gq *gql.GraphQuery) error {
 	if v, ok := gq.Args["after"]; ok {
 		after, err := strconv.ParseUint(v, 0, 64)
 		if err != nil {
-			return err
+			// Unless it's a uid, it has to be a cursor.
+			if err := args.fillCursor(v); err != nil {
+				return err
+			}
+		} else {
+			args.AfterUID = uint64(after)
 		}
-		args.AfterUID = uint64(after)
 	}
 
 	if v, ok := gq.Args["depth"]; ok && (args.Alias == "shortest") {
@@ -823,6 +833,13 @@ func (args *params) fill(gq *gql.GraphQuery) error {
 		}
 		args.Count = int(first)
 	}
+	if v, ok := gq.Args["levels"]; ok {
+		levels, err := parseLevels(v)
+		if err != nil {
+			return err
+		}
+		args.levels = levels
+	}
 	return nil
 }
 
@@ -839,7 +856,8 @@ func ToSubGraph(ctx context.Context, gq *gql.GraphQuery) (*SubGraph, error) {
 	return sg, err
 }
 
-func isDebug(ctx context.Context) bool {
+// IsDebug tells whether the query is run in debug mode, which adds the uids of nodes to results.
+func IsDebug(ctx context.Context) bool {
 	var debug bool
 	// gRPC client passes information about debug as metadata.
 	if md, ok := metadata.FromIncomingContext(ctx); ok {
@@ -867,7 +885,7 @@ func newGraph(ctx context.Context, gq *gql.GraphQuery) (*SubGraph, error) {
 	// For the root, the name to be used in result is stored in Alias, not Attr.
 	// The attr at root (if present) would stand for the source functions attr.
 	args := params{
-		GetUid:        isDebug(ctx),
+		GetUid:        IsDebug(ctx),
 		Alias:         gq.Alias,
 		Langs:         gq.Langs,
 		Var:           gq.Var,
@@ -879,6 +897,7 @@ func newGraph(ctx context.Context, gq *gql.GraphQuery) (*SubGraph, error) {
 		uidCount:      gq.UidCount,
 		uidCountAlias: gq.UidCountAlias,
 		IgnoreReflex:  gq.IgnoreReflex,
+		Hints:         gq.Hints,
 		IsEmpty:       gq.IsEmpty,
 		Order:         gq.Order,
 		Recurse:       gq.Recurse,
@@ -998,6 +1017,7 @@ func createTaskQuery(sg *SubGraph) (*pb.Query, error) {
 		FacetParam:   sg.Params.Facet,
 		FacetsFilter: sg.facetsFilter,
 		ExpandAll:    sg.Params.expandAll,
+		Hints:        taskHints(sg.Params.Hints),
 	}
 	if sg.SrcUIDs != nil {
 		out.UidList = sg.SrcUIDs
@@ -1005,6 +1025,14 @@ func createTaskQuery(sg *SubGraph) (*pb.Query, error) {
 	return out, nil
 }
 
+// taskHints returns the hints which have to be passed on to the workers, if any.
+func taskHints(h gql.QueryHints) *pb.QueryHints {
+	if len(h.UseIndex) == 0 && !h.NoValueScan && len(h.Sort) == 0 {
+		return nil
+	}
+	return &pb.QueryHints{UseIndex: h.UseIndex, NoValueScan: h.NoValueScan, Sort: h.Sort}
+}
+
 type varValue struct {
 	Uids *pb.List
 	Vals map[uint64]types.Val
@@ -1156,9 +1184,30 @@ func (fromNode *varValue) transformTo(toPath []*SubGraph) (map[uint64]types.Val,
 	return newMap, nil
 }
 
-// transformVars transforms all the variables to the variable at the lowest level
+// inBlock tells whether the variable was defined in the block of the path.
+func (fromNode *varValue) inBlock(path []*SubGraph) bool {
+	return len(fromNode.path) > 0 && len(path) > 0 && fromNode.path[0] == path[0]
+}
+
+// valsOf returns the values of vals for the uids of the list.
+func valsOf(vals map[uint64]types.Val, list *pb.List) map[uint64]types.Val {
+	if vals == nil || list == nil {
+		return vals
+	}
+	res := make(map[uint64]types.Val, len(list.Uids))
+	for _, uid := range list.Uids {
+		if v, ok := vals[uid]; ok {
+			res[uid] = v
+		}
+	}
+	return res
+}
+
+// transformVars transforms all the variables to the variable at the lowest level. Only the values
+// of the variables of other blocks for the uids at the level of sg are used, except for those
+// aggregated over an empty block, which are constants.
 func (sg *SubGraph) transformVars(doneVars map[string]varValue,
-	path []*SubGraph) error {
+	path []*SubGraph, parent *SubGraph) error {
 	mNode := sg.MathExp
 	mvarList := mNode.extractVarNodes()
 	for i := 0; i < len(mvarList); i++ {
@@ -1169,6 +1218,15 @@ func (sg *SubGraph) transformVars(doneVars map[string]varValue,
 			return err
 		}
 		mt.Val = newMap
+		if parent == nil || parent.Params.IsEmpty || curNode.inBlock(path) {
+			continue
+		}
+		// Aggregations over an empty block are put at uid 0, see evalLevelAgg.
+		if v, ok := newMap[0]; ok && len(newMap) == 1 {
+			mt.Const, mt.Val = v, nil
+			continue
+		}
+		mt.Val = valsOf(newMap, sg.SrcUIDs)
 	}
 	return nil
 }
@@ -1203,7 +1261,7 @@ func (sg *SubGraph) valueVarAggregation(doneVars map[string]varValue, path []*Su
 		sg.Params.uidToVal = mp
 	} else if sg.MathExp != nil {
 		// Preprocess to bring all variables to the same level.
-		err := sg.transformVars(doneVars, path)
+		err := sg.transformVars(doneVars, path, parent)
 		if err != nil {
 			return err
 		}
@@ -1968,7 +2026,14 @@ func ProcessGraph(ctx context.Context, sg, parent *SubGraph, rch chan error) {
 
 	// Run filters if any.
 	if len(sg.Filters) > 0 {
-		// Run all filters in parallel.
+		// Run all filters in parallel, unless hinted otherwise. Ordered filters of an
+		// intersection are run one after the other, each only on the uids kept by the previous.
+		ordered := sg.Params.Hints.OrderedFilters && sg.FilterOp != "or" && sg.FilterOp != "not"
+		parallel := !ordered && !sg.Params.Hints.NoParallel
+		if ordered {
+			span.Annotatef(nil, "Hint: running %d filters in order", len(sg.Filters))
+		}
+		srcUIDs := sg.DestUIDs
 		filterChan := make(chan error, len(sg.Filters))
 		for _, filter := range sg.Filters {
 			isUidFuncWithoutVar := filter.SrcFunc != nil && filter.SrcFunc.Name == "uid" &&
@@ -1978,13 +2043,24 @@ func ProcessGraph(ctx context.Context, sg, parent *SubGraph, rch chan error) {
 			if isUidFuncWithoutVar {
 				filter.DestUIDs = filter.SrcUIDs
 				filterChan <- nil
-				continue
+			} else if len(srcUIDs.Uids) == 0 {
+				// A previous ordered filter didn't keep anything.
+				filter.DestUIDs = &pb.List{}
+				filterChan <- nil
+			} else {
+				filter.SrcUIDs = srcUIDs
+				// Passing the pointer is okay since the filter only reads.
+				filter.Params.ParentVars = sg.Params.ParentVars // Pass to the child.
+				filter.Params.Hints = sg.Params.Hints
+				if parallel {
+					go ProcessGraph(ctx, filter, sg, filterChan)
+					continue
+				}
+				ProcessGraph(ctx, filter, sg, filterChan)
+			}
+			if ordered && filter.DestUIDs != nil {
+				srcUIDs = algo.IntersectSorted([]*pb.List{srcUIDs, filter.DestUIDs})
 			}
-
-			filter.SrcUIDs = sg.DestUIDs
-			// Passing the pointer is okay since the filter only reads.
-			filter.Params.ParentVars = sg.Params.ParentVars // Pass to the child.
-			go ProcessGraph(ctx, filter, sg, filterChan)
 		}
 
 		var filterErr error
@@ -2101,10 +2177,15 @@ func ProcessGraph(ctx context.Context, sg, parent *SubGraph, rch chan error) {
 		}
 
 		child.SrcUIDs = sg.DestUIDs // Make the connection.
+		child.Params.Hints = sg.Params.Hints
 		if child.IsInternal() {
 			// We dont have to execute these nodes.
 			continue
 		}
+		if sg.Params.Hints.NoParallel {
+			ProcessGraph(ctx, child, sg, childChan)
+			continue
+		}
 		go ProcessGraph(ctx, child, sg, childChan)
 	}
 
@@ -2128,11 +2209,12 @@ func ProcessGraph(ctx context.Context, sg, parent *SubGraph, rch chan error) {
 func (sg *SubGraph) applyPagination(ctx context.Context) error {
 	params := sg.Params
 
-	if params.Count == 0 && params.Offset == 0 { // No pagination.
+	if params.Count == 0 && params.Offset == 0 && params.cursor == nil { // No pagination.
 		return nil
 	}
 
 	sg.updateUidMatrix()
+	sg.filterAfterCursor()
 	for i := 0; i < len(sg.uidMatrix); i++ {
 		// Apply the offsets.
 		start, end := x.PageRange(sg.Params.Count, sg.Params.Offset, len(sg.uidMatrix[i].Uids))
@@ -2179,6 +2261,11 @@ func (sg *SubGraph) applyOrderAndPagination(ctx context.Context) error {
 		Offset:    int32(sg.Params.Offset),
 		Count:     int32(sg.Params.Count),
 		ReadTs:    sg.ReadTs,
+		Hints:     taskHints(sg.Params.Hints),
+	}
+	if c := sg.Params.cursor; c != nil {
+		sort.AfterUid = c.uid
+		sort.AfterValue = c.val
 	}
 	result, err := worker.SortOverNetwork(ctx, sort)
 	if err != nil {
@@ -2326,7 +2413,8 @@ func (sg *SubGraph) sortAndPaginateUsingVar(ctx context.Context) error {
 // isValidArg checks if arg passed is valid keyword.
 func isValidArg(a string) bool {
 	switch a {
-	case "numpaths", "from", "to", "orderasc", "orderdesc", "first", "offset", "after", "depth":
+	case "numpaths", "from", "to", "orderasc", "orderdesc", "first", "offset", "after", "depth",
+		"levels":
 		return true
 	}
 	return false
@@ -2390,6 +2478,26 @@ func GetAllPredicates(subGraphs []*SubGraph) (predicates []string) {
 	return predicates
 }
 
+// ResultSize estimates the memory taken by the results of subGraphs, i.e. by their uids, values
+// and counts.
+func ResultSize(subGraphs []*SubGraph) int64 {
+	var size int64
+	for _, sg := range subGraphs {
+		sg.recurse(func(sg *SubGraph) {
+			for _, l := range sg.uidMatrix {
+				size += 8 * int64(len(l.Uids))
+			}
+			for _, vl := range sg.valueMatrix {
+				for _, v := range vl.Values {
+					size += int64(len(v.Val))
+				}
+			}
+			size += 4 * int64(len(sg.counts))
+		})
+	}
+	return size
+}
+
 func (sg *SubGraph) getAllPredicates(predicates map[string]bool) {
 	if len(sg.Attr) != 0 {
 		predicates[sg.Attr] = true
@@ -2573,6 +2681,11 @@ func (req *QueryRequest) ProcessQuery(ctx context.Context) (err error) {
 			return x.Errorf("Query couldn't be executed")
 		}
 	}
+	for _, sg := range req.Subgraphs {
+		if err := sg.setCursor(ctx); err != nil {
+			return err
+		}
+	}
 	req.Latency.Processing += time.Since(execStart)
 
 	// If we had a shortestPath SG, append it to the result.
@@ -2582,6 +2695,18 @@ func (req *QueryRequest) ProcessQuery(ctx context.Context) (err error) {
 	return nil
 }
 
+// UidVars returns the uids held by each uid variable defined in the query. It must be called
+// after ProcessQuery.
+func (req *QueryRequest) UidVars() map[string][]uint64 {
+	res := make(map[string][]uint64, len(req.vars))
+	for name, v := range req.vars {
+		if v.Uids != nil {
+			res[name] = v.Uids.Uids
+		}
+	}
+	return res
+}
+
 var MutationNotAllowedErr = x.Errorf("Mutations are forbidden on this server.")
 
 type InvalidRequestError struct {
@@ -1484,6 +1484,129 @@ func TestVarInIneqScore(t *testing.T) {
 		js)
 }
 
+func TestMathVarsAcrossBlocks(t *testing.T) {
+	// The count of uid 1 isn't used, as it isn't a friend of 1.
+	query := `
+		{
+			var(func: uid(1)) {
+				friend {
+					a as age
+				}
+			}
+
+			var(func: uid(1, 23, 31)) {
+				c as count(friend)
+			}
+
+			var(func: uid(1)) {
+				friend {
+					s as math(a * 2 + c)
+				}
+			}
+
+			me(func: uid(s), orderdesc: val(s)) {
+				name
+				val(s)
+			}
+		}
+	`
+	js := processToFastJsonNoErr(t, query)
+	require.JSONEq(t, `{"data": {"me":[{"name":"Andrea","val(s)":39.000000},{"name":"Daryl Dixon","val(s)":34.000000},{"name":"Rick Grimes","val(s)":31.000000},{"name":"Glenn Rhee","val(s)":30.000000}]}}`,
+		js)
+}
+
+func TestMathVarsAcrossBlocksFilter(t *testing.T) {
+	query := `
+		{
+			var(func: uid(1)) {
+				friend {
+					a as age
+				}
+			}
+
+			var(func: uid(23, 31)) {
+				c as count(friend)
+			}
+
+			var(func: uid(1)) {
+				f as friend {
+					s as math(a * 2 + c)
+				}
+			}
+
+			me(func: uid(f), orderdesc: val(s)) @filter(gt(val(s), 30)) {
+				name
+				val(s)
+			}
+		}
+	`
+	js := processToFastJsonNoErr(t, query)
+	require.JSONEq(t, `{"data": {"me":[{"name":"Andrea","val(s)":39.000000},{"name":"Daryl Dixon","val(s)":34.000000},{"name":"Rick Grimes","val(s)":31.000000}]}}`,
+		js)
+}
+
+func TestMathVarsAcrossBlocksAggregate(t *testing.T) {
+	// The maximum over the empty block is the same for every friend.
+	query := `
+		{
+			var(func: uid(1)) {
+				friend {
+					a as age
+				}
+			}
+
+			var() {
+				m as max(val(a))
+			}
+
+			var(func: uid(1)) {
+				friend {
+					n as math(a / m)
+				}
+			}
+
+			me(func: uid(n), orderdesc: val(n), first: 2) {
+				name
+				val(n)
+			}
+		}
+	`
+	js := processToFastJsonNoErr(t, query)
+	require.JSONEq(t, `{"data": {"me":[{"name":"Andrea","val(n)":1.000000},{"name":"Daryl Dixon","val(n)":0.894737}]}}`,
+		js)
+}
+
+func TestMathVarsAcrossBlocksCompareMissing(t *testing.T) {
+	// Friends without a count have nothing to be compared with, so they have no value.
+	query := `
+		{
+			var(func: uid(1)) {
+				friend {
+					a as age
+				}
+			}
+
+			var(func: uid(23, 31)) {
+				c as count(friend)
+			}
+
+			var(func: uid(1)) {
+				friend {
+					b as math(a > c)
+				}
+			}
+
+			me(func: uid(b)) {
+				name
+				val(b)
+			}
+		}
+	`
+	js := processToFastJsonNoErr(t, query)
+	require.JSONEq(t, `{"data": {"me":[{"name":"Rick Grimes","val(b)":true},{"name":"Andrea","val(b)":true}]}}`,
+		js)
+}
+
 func TestVarInIneq(t *testing.T) {
 
 	query := `
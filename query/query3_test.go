@@ -162,6 +162,108 @@ func TestRecurseQueryLimitDepth2(t *testing.T) {
 		`{"data": {"me":[{"uid":"0x1","friend":[{"uid":"0x17","name":"Rick Grimes"},{"uid":"0x18","name":"Glenn Rhee"},{"uid":"0x19","name":"Daryl Dixon"},{"uid":"0x1f","name":"Andrea"},{"uid":"0x65"}],"name":"Michonne"}]}}`, js)
 }
 
+func TestRecurseQueryAnnotate(t *testing.T) {
+
+	query := `
+		{
+			me(func: uid(0x01)) @recurse(annotate: true) {
+				friend
+				name
+			}
+		}`
+	js := processToFastJsonNoErr(t, query)
+	require.JSONEq(t,
+		`{"data": {"me":[{"name":"Michonne", "friend":[{"name":"Rick Grimes", "friend":[{"name":"Michonne"}]},{"name":"Glenn Rhee"},{"name":"Daryl Dixon"},{"name":"Andrea", "friend":[{"name":"Glenn Rhee"}]}]}], "_recurse_":{"me":{"nodes":5,"cycles":true,"stop":"complete"}}}}`, js)
+}
+
+func TestRecurseQueryAnnotateDepth(t *testing.T) {
+
+	query := `
+		{
+			me(func: uid(0x01)) @recurse(depth: 2, annotate: true) {
+				friend
+				name
+			}
+		}`
+	js := processToFastJsonNoErr(t, query)
+	require.JSONEq(t,
+		`{"data": {"me":[{"name":"Michonne", "friend":[{"name":"Rick Grimes"},{"name":"Glenn Rhee"},{"name":"Daryl Dixon"},{"name":"Andrea"}]}], "_recurse_":{"me":{"nodes":5,"cycles":false,"stop":"depth"}}}}`, js)
+}
+
+func TestRecurseQueryMaxNodes(t *testing.T) {
+
+	query := `
+		{
+			me(func: uid(0x01)) @recurse(maxnodes: 2, annotate: true) {
+				friend
+				name
+			}
+		}`
+	js := processToFastJsonNoErr(t, query)
+	require.JSONEq(t,
+		`{"data": {"me":[{"name":"Michonne", "friend":[{"name":"Rick Grimes", "friend":[{"name":"Michonne"}]},{"name":"Glenn Rhee"}]}], "_recurse_":{"me":{"nodes":2,"cycles":true,"stop":"max_nodes"}}}}`, js)
+}
+
+func TestRecurseQueryLevels(t *testing.T) {
+
+	query := `
+		{
+			me(func: uid(0x01)) @recurse {
+				friend(levels: 1)
+				name
+			}
+		}`
+	js := processToFastJsonNoErr(t, query)
+	require.JSONEq(t,
+		`{"data": {"me":[{"name":"Michonne", "friend":[{"name":"Rick Grimes"},{"name":"Glenn Rhee"},{"name":"Daryl Dixon"},{"name":"Andrea"}]}]}}`, js)
+
+	query = `
+		{
+			me(func: uid(0x01)) @recurse {
+				friend
+				name(levels: 2-)
+			}
+		}`
+	js = processToFastJsonNoErr(t, query)
+	require.JSONEq(t,
+		`{"data": {"me":[{"friend":[{"name":"Rick Grimes", "friend":[{"name":"Michonne"}]},{"name":"Glenn Rhee"},{"name":"Daryl Dixon"},{"name":"Andrea", "friend":[{"name":"Glenn Rhee"}]}]}]}}`, js)
+}
+
+func TestRecurseQueryLevelsError(t *testing.T) {
+
+	query := `
+		{
+			me(func: uid(0x01)) @recurse {
+				friend(levels: 0)
+				name
+			}
+		}`
+	ctx := defaultContext()
+	_, err := processToFastJsonCtxVars(t, query, ctx, nil)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "Levels start at 1")
+}
+
+func TestParseLevels(t *testing.T) {
+	for v, r := range map[string]levelRange{
+		"1":   {1, 1},
+		"2-4": {2, 4},
+		"3-":  {3, 0},
+	} {
+		parsed, err := parseLevels(v)
+		require.NoError(t, err, v)
+		require.Equal(t, r, parsed, v)
+	}
+	for _, v := range []string{"0", "4-2", "a", "-2", "1-b"} {
+		_, err := parseLevels(v)
+		require.Error(t, err, v)
+	}
+	require.True(t, levelRange{}.has(7))
+	require.True(t, levelRange{2, 0}.has(7))
+	require.False(t, levelRange{2, 4}.has(1))
+	require.False(t, levelRange{2, 4}.has(5))
+}
+
 func TestRecurseVariable(t *testing.T) {
 
 	query := `
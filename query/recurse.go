@@ -21,6 +21,8 @@ import (
 	"errors"
 	"fmt"
 	"math"
+	"strconv"
+	"strings"
 
 	"golang.org/x/net/trace"
 
@@ -28,11 +30,87 @@ import (
 	"github.com/dgraph-io/dgraph/x"
 )
 
+// Why a recurse query stopped, as reported when it's annotated.
+const (
+	recurseComplete = "complete"  // There was nothing more to traverse.
+	recurseDepth    = "depth"     // The depth was reached with nodes left to traverse.
+	recurseMaxNodes = "max_nodes" // The budget of nodes was exhausted.
+)
+
+// recurseAttr is the key under which the annotations of the recurse query blocks are returned.
+const recurseAttr = "_recurse_"
+
+// recurseStats tell how a recurse query went, so that clients can tell apart results cut by a
+// limit from results which are complete.
+type recurseStats struct {
+	// nodes is the number of distinct nodes reached, other than the root nodes.
+	nodes uint64
+	// cycles is set if edges were left out as they had already been traversed.
+	cycles bool
+	stop   string
+}
+
+// levelRange is the range of levels of a recurse query at which a predicate is followed, as set
+// with levels: <min>-<max>. The root nodes are at level 1. The zero value has all the levels.
+type levelRange struct {
+	min, max uint64 // max is 0 if there is no upper bound.
+}
+
+func (r levelRange) has(level uint64) bool {
+	return level >= r.min && (r.max == 0 || level <= r.max)
+}
+
+// parseLevels parses a level range, either a level like 2, or a range like 2-4 or 2-.
+func parseLevels(v string) (levelRange, error) {
+	var r levelRange
+	bounds := strings.SplitN(v, "-", 2)
+	min, err := strconv.ParseUint(bounds[0], 0, 64)
+	if err != nil || min == 0 {
+		return r, x.Errorf("Invalid levels: %q. Levels start at 1.", v)
+	}
+	r.min, r.max = min, min
+	if len(bounds) == 1 {
+		return r, nil
+	}
+	if bounds[1] == "" {
+		r.max = 0
+		return r, nil
+	}
+	if r.max, err = strconv.ParseUint(bounds[1], 0, 64); err != nil || r.max < r.min {
+		return r, x.Errorf("Invalid levels: %q", v)
+	}
+	return r, nil
+}
+
 func (start *SubGraph) expandRecurse(ctx context.Context, maxDepth uint64) error {
 	// Note: Key format is - "attr|fromUID|toUID"
 	reachMap := make(map[string]struct{})
 	allowLoop := start.Params.RecurseArgs.AllowLoop
+	maxNodes := start.Params.RecurseArgs.MaxNodes
 	var numEdges uint64
+
+	stats := &recurseStats{stop: recurseComplete}
+	if start.Params.RecurseArgs.Annotate {
+		defer func() { start.Params.recurseStats = stats }()
+	}
+	// The nodes reached so far are only kept track of if they need to be counted.
+	var reached map[uint64]struct{}
+	if maxNodes > 0 || start.Params.RecurseArgs.Annotate {
+		reached = make(map[uint64]struct{})
+	}
+	admit := func(uid uint64, i int) bool {
+		if _, ok := reached[uid]; ok {
+			return true
+		}
+		if maxNodes > 0 && stats.nodes >= maxNodes {
+			// Past the budget, the traversal goes on only among the nodes already reached.
+			stats.stop = recurseMaxNodes
+			return false
+		}
+		reached[uid] = struct{}{}
+		stats.nodes++
+		return true
+	}
 	var exec []*SubGraph
 	var err error
 
@@ -59,8 +137,14 @@ func (start *SubGraph) expandRecurse(ctx context.Context, maxDepth uint64) error
 		return ctx.Err()
 	}
 
+	if reached != nil {
+		for _, uid := range start.DestUIDs.Uids {
+			reached[uid] = struct{}{}
+		}
+	}
+
 	// Add children back and expand if necessary
-	if exec, err = expandChildren(ctx, start, startChildren); err != nil {
+	if exec, err = expandChildren(ctx, start, startChildren, 1); err != nil {
 		return err
 	}
 
@@ -68,6 +152,9 @@ func (start *SubGraph) expandRecurse(ctx context.Context, maxDepth uint64) error
 	var depth uint64
 	for {
 		if depth >= maxDepth {
+			if stats.stop == recurseComplete {
+				stats.stop = recurseDepth
+			}
 			return nil
 		}
 		depth++
@@ -119,6 +206,7 @@ func (start *SubGraph) expandRecurse(ctx context.Context, maxDepth uint64) error
 						key := fmt.Sprintf("%s|%d|%d", sg.Attr, fromUID, uid)
 						_, seen := reachMap[key] // Combine fromUID here.
 						if seen {
+							stats.cycles = true
 							return false
 						} else {
 							// Mark this edge as taken. We'd disallow this edge later.
@@ -128,6 +216,9 @@ func (start *SubGraph) expandRecurse(ctx context.Context, maxDepth uint64) error
 						}
 					})
 				}
+				if reached != nil {
+					algo.ApplyFilter(sg.uidMatrix[mIdx], admit)
+				}
 			}
 			if len(sg.Params.Order) > 0 || len(sg.Params.FacetOrder) > 0 {
 				// Can't use merge sort if the UIDs are not sorted.
@@ -144,7 +235,7 @@ func (start *SubGraph) expandRecurse(ctx context.Context, maxDepth uint64) error
 			if len(sg.DestUIDs.Uids) == 0 {
 				continue
 			}
-			if exp, err = expandChildren(ctx, sg, startChildren); err != nil {
+			if exp, err = expandChildren(ctx, sg, startChildren, depth+1); err != nil {
 				return err
 			}
 			out = append(out, exp...)
@@ -163,7 +254,9 @@ func (start *SubGraph) expandRecurse(ctx context.Context, maxDepth uint64) error
 }
 
 // expandChildren adds child nodes to a SubGraph with no children, expanding them if necessary.
-func expandChildren(ctx context.Context, sg *SubGraph, children []*SubGraph) ([]*SubGraph, error) {
+// Only the children followed at the given level are added.
+func expandChildren(ctx context.Context, sg *SubGraph, children []*SubGraph,
+	level uint64) ([]*SubGraph, error) {
 	if len(sg.Children) > 0 {
 		return nil, errors.New("Subgraph should not have any children")
 	}
@@ -177,6 +270,9 @@ func expandChildren(ctx context.Context, sg *SubGraph, children []*SubGraph) ([]
 	sg.Children = sg.Children[:0]
 	// Link new child nodes back to parent destination UIDs
 	for _, child := range expandedChildren {
+		if !child.Params.levels.has(level) {
+			continue
+		}
 		newChild := new(SubGraph)
 		newChild.copyFiltersRecurse(child)
 		newChild.SrcUIDs = sg.DestUIDs
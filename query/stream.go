@@ -0,0 +1,147 @@
+/*
+ * Copyright 2018 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package query
+
+import (
+	"bytes"
+	"time"
+
+	"github.com/dgraph-io/dgraph/types"
+)
+
+// jsonStream writes the JSON response of a query a chunk at a time, instead of building the
+// output nodes of all the results before encoding them in one buffer like toFastJSON does.
+type jsonStream struct {
+	chunkSize int
+	emit      func([]byte) error
+	buf       bytes.Buffer
+	keys      int    // Keys written so far.
+	attr      string // Of the last key written.
+	inArray   bool   // Whether the value of the last key is an array yet to be closed.
+}
+
+// StreamJson encodes the results of the blocks into the same JSON as ToJson, but calls emit with
+// it a chunk at a time instead of returning it whole. The nodes of a block are encoded chunkSize
+// at a time, so that only those output nodes are held in memory along with their encoding. The
+// results of all the blocks are processed already, and held until the end. The chunk passed to
+// emit is only valid until it returns, and an error from emit stops the encoding.
+func StreamJson(l *Latency, sgl []*SubGraph, chunkSize int,
+	emit func([]byte) error) error {
+	defer func() {
+		l.Json = time.Since(l.Start) - l.Parsing - l.Processing
+	}()
+	if chunkSize <= 0 {
+		chunkSize = 1
+	}
+	out := &jsonStream{chunkSize: chunkSize, emit: emit}
+
+	var seedNode *fastJsonNode
+	n := seedNode.New("_root_").(*fastJsonNode)
+	cursors := seedNode.New(cursorsAttr)
+	recurse := seedNode.New(recurseAttr)
+	for _, sg := range sgl {
+		if sg.Params.Alias == "var" || sg.Params.Alias == "shortest" {
+			continue
+		}
+		if err := processNodeUids(n, sg, out); err != nil {
+			return err
+		}
+		if err := out.flush(n, true); err != nil {
+			return err
+		}
+		if sg.Params.nextCursor != "" {
+			cursors.AddValue(sg.Params.Alias,
+				types.Val{Tid: types.StringID, Value: sg.Params.nextCursor})
+		}
+		if st := sg.Params.recurseStats; st != nil {
+			a := recurse.New(sg.Params.Alias)
+			a.AddValue("nodes", types.Val{Tid: types.IntID, Value: int64(st.nodes)})
+			a.AddValue("cycles", types.Val{Tid: types.BoolID, Value: st.cycles})
+			a.AddValue("stop", types.Val{Tid: types.StringID, Value: st.stop})
+			recurse.AddMapChild(sg.Params.Alias, a, false)
+		}
+	}
+	if !cursors.IsEmpty() {
+		n.AddMapChild(cursorsAttr, cursors, false)
+	}
+	if !recurse.IsEmpty() {
+		n.AddMapChild(recurseAttr, recurse, false)
+	}
+	return out.close(n)
+}
+
+// flush encodes the nodes added to the root n so far and removes them from it, once there are
+// chunkSize of them, or whatever their number if all is set. It does nothing on a nil stream,
+// for the results to be encoded all at once.
+func (s *jsonStream) flush(n *fastJsonNode, all bool) error {
+	if s == nil || len(n.attrs) == 0 || (!all && len(n.attrs) < s.chunkSize) {
+		return nil
+	}
+	for _, a := range n.attrs {
+		s.write(a)
+	}
+	n.attrs = n.attrs[:0]
+	if err := s.emit(s.buf.Bytes()); err != nil {
+		return err
+	}
+	s.buf.Reset()
+	return nil
+}
+
+// write encodes a, a key of the root, like fastJsonNode.encode does, continuing the array of the
+// last key if a has the same one.
+func (s *jsonStream) write(a *fastJsonNode) {
+	if s.inArray && a.attr == s.attr {
+		s.buf.WriteRune(',')
+		a.encode(&s.buf)
+		return
+	}
+	if s.inArray {
+		s.buf.WriteRune(']')
+		s.inArray = false
+	}
+	if s.keys == 0 {
+		s.buf.WriteRune('{')
+	} else {
+		s.buf.WriteRune(',')
+	}
+	s.keys++
+	s.attr = a.attr
+	a.writeKey(&s.buf)
+	if a.isChild || a.list {
+		s.buf.WriteRune('[')
+		s.inArray = true
+	}
+	a.encode(&s.buf)
+}
+
+// close encodes the rest of the keys of the root n and ends the response.
+func (s *jsonStream) close(n *fastJsonNode) error {
+	for _, a := range n.attrs {
+		s.write(a)
+	}
+	n.attrs = nil
+	if s.keys == 0 {
+		s.buf.WriteString(`{}`)
+	} else {
+		if s.inArray {
+			s.buf.WriteRune(']')
+		}
+		s.buf.WriteRune('}')
+	}
+	return s.emit(s.buf.Bytes())
+}
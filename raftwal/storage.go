@@ -20,6 +20,7 @@ import (
 	"bytes"
 	"encoding/binary"
 	"errors"
+	"fmt"
 	"math"
 	"sync"
 
@@ -29,6 +30,7 @@ import (
 	"github.com/golang/glog"
 	"golang.org/x/net/trace"
 
+	"github.com/dgraph-io/dgraph/fault"
 	"github.com/dgraph-io/dgraph/x"
 )
 
@@ -93,6 +95,48 @@ func Init(db *badger.DB, id uint64, gid uint32) *DiskStorage {
 	return w
 }
 
+// Stored returns the storage of every Raft log in db, to read them as they are. Unlike Init, it
+// doesn't write anything.
+func Stored(db *badger.DB) ([]*DiskStorage, error) {
+	type node struct {
+		id  uint64
+		gid uint32
+	}
+	seen := make(map[node]bool)
+	var res []*DiskStorage
+	err := db.View(func(txn *badger.Txn) error {
+		opt := badger.DefaultIteratorOptions
+		opt.PrefetchValues = false
+		itr := txn.NewIterator(opt)
+		defer itr.Close()
+		for itr.Rewind(); itr.Valid(); itr.Next() {
+			key := itr.Item().Key()
+			var n node
+			switch len(key) {
+			case 20:
+				n = node{binary.BigEndian.Uint64(key[0:8]), binary.BigEndian.Uint32(key[8:12])}
+			case 14:
+				n = node{binary.BigEndian.Uint64(key[0:8]), binary.BigEndian.Uint32(key[10:14])}
+			default:
+				continue
+			}
+			if !seen[n] {
+				seen[n] = true
+				res = append(res, &DiskStorage{db: db, id: n.id, gid: n.gid,
+					elog: trace.NewEventLog("Badger", "RaftStorage")})
+			}
+		}
+		return nil
+	})
+	return res, err
+}
+
+// ID returns the Raft id of the node whose log this is.
+func (w *DiskStorage) ID() uint64 { return w.id }
+
+// Group returns the group of the node whose log this is.
+func (w *DiskStorage) Group() uint32 { return w.gid }
+
 var idKey = []byte("raftid")
 
 func RaftId(db *badger.DB) (uint64, error) {
@@ -129,6 +173,22 @@ func (w *DiskStorage) hardStateKey() []byte {
 	return b
 }
 
+func (w *DiskStorage) transferKey() []byte {
+	b := make([]byte, 14)
+	binary.BigEndian.PutUint64(b[0:8], w.id)
+	copy(b[8:10], []byte("tr"))
+	binary.BigEndian.PutUint32(b[10:14], w.gid)
+	return b
+}
+
+func (w *DiskStorage) historyKey() []byte {
+	b := make([]byte, 14)
+	binary.BigEndian.PutUint64(b[0:8], w.id)
+	copy(b[8:10], []byte("ht"))
+	binary.BigEndian.PutUint32(b[10:14], w.gid)
+	return b
+}
+
 func (w *DiskStorage) entryKey(idx uint64) []byte {
 	b := make([]byte, 20)
 	binary.BigEndian.PutUint64(b[0:8], w.id)
@@ -149,6 +209,48 @@ func (w *DiskStorage) entryPrefix() []byte {
 	return b
 }
 
+// Check checks that every key in db is one of those of a WAL, and that its value can be read.
+func Check(db *badger.DB) error {
+	return db.View(func(txn *badger.Txn) error {
+		it := txn.NewIterator(badger.DefaultIteratorOptions)
+		defer it.Close()
+		for it.Rewind(); it.Valid(); it.Next() {
+			item := it.Item()
+			key := item.Key()
+			var msg interface {
+				Unmarshal([]byte) error
+			}
+			var historyTs bool
+			switch {
+			case bytes.Equal(key, idKey):
+			case len(key) == 20:
+				msg = &pb.Entry{}
+			case len(key) == 14 && string(key[8:10]) == "ss":
+				msg = &pb.Snapshot{}
+			case len(key) == 14 && string(key[8:10]) == "hs":
+				msg = &pb.HardState{}
+			case len(key) == 14 && string(key[8:10]) == "tr":
+			case len(key) == 14 && string(key[8:10]) == "ht":
+				historyTs = true
+			default:
+				return x.Errorf("Unknown key %q in the WAL", key)
+			}
+			// Item.Value doesn't return the error of its callback for prefetched values.
+			val, err := item.ValueCopy(nil)
+			if err == nil && msg != nil {
+				err = msg.Unmarshal(val)
+			}
+			if err == nil && historyTs && len(val) != 8 {
+				err = x.Errorf("The history ts has %d bytes instead of 8", len(val))
+			}
+			if err != nil {
+				return x.Wrapf(err, "while reading key %q of the WAL", key)
+			}
+		}
+		return nil
+	})
+}
+
 func (w *DiskStorage) StoreRaftId(id uint64) error {
 	return w.db.Update(func(txn *badger.Txn) error {
 		var b [8]byte
@@ -298,6 +400,61 @@ func (w *DiskStorage) Snapshot() (snap pb.Snapshot, rerr error) {
 	return snap, err
 }
 
+// TransferState returns the state last stored by SetTransferState, or nil if there's none.
+func (w *DiskStorage) TransferState() (data []byte, rerr error) {
+	err := w.db.View(func(txn *badger.Txn) error {
+		item, err := txn.Get(w.transferKey())
+		if err != nil {
+			return err
+		}
+		data, err = item.ValueCopy(nil)
+		return err
+	})
+	if err == badger.ErrKeyNotFound {
+		return nil, nil
+	}
+	return data, err
+}
+
+// SetTransferState stores the state of the transfer of a snapshot from the leader, so that it can
+// be resumed after a failure. Empty data deletes it.
+func (w *DiskStorage) SetTransferState(data []byte) error {
+	return w.db.Update(func(txn *badger.Txn) error {
+		if len(data) == 0 {
+			return txn.Delete(w.transferKey())
+		}
+		return txn.Set(w.transferKey(), data)
+	})
+}
+
+// HistoryTs returns the timestamp last stored by SetHistoryTs, or zero if there's none.
+func (w *DiskStorage) HistoryTs() (ts uint64, rerr error) {
+	err := w.db.View(func(txn *badger.Txn) error {
+		item, err := txn.Get(w.historyKey())
+		if err != nil {
+			return err
+		}
+		return item.Value(func(val []byte) error {
+			ts = binary.BigEndian.Uint64(val)
+			return nil
+		})
+	})
+	if err == badger.ErrKeyNotFound {
+		return 0, nil
+	}
+	return ts, err
+}
+
+// SetHistoryTs stores the timestamp as of which the versions of the posting lists are all kept,
+// so that it's known after a restart.
+func (w *DiskStorage) SetHistoryTs(ts uint64) error {
+	return w.db.Update(func(txn *badger.Txn) error {
+		var b [8]byte
+		binary.BigEndian.PutUint64(b[:], ts)
+		return txn.Set(w.historyKey(), b[:])
+	})
+}
+
 // setSnapshot would store the snapshot. We can delete all the entries up until the snapshot
 // index. But, keep the raft entry at the snapshot index, to make it easier to build the logic; like
 // the dummy entry in MemoryStorage.
@@ -570,6 +727,9 @@ func (w *DiskStorage) CreateSnapshot(i uint64, cs *pb.ConfState, data []byte) er
 // writes then all of them can be written together. Note that when writing an Entry with Index i,
 // any previously-persisted entries with Index >= i must be discarded.
 func (w *DiskStorage) Save(h pb.HardState, es []pb.Entry, snap pb.Snapshot) error {
+	if fault.Enabled {
+		fault.Inject(fault.WALSave, fmt.Sprintf("group=%d", w.gid))
+	}
 	batch := w.db.NewWriteBatch()
 	defer batch.Cancel()
 
@@ -582,7 +742,13 @@ func (w *DiskStorage) Save(h pb.HardState, es []pb.Entry, snap pb.Snapshot) erro
 	if err := w.setSnapshot(batch, snap); err != nil {
 		return err
 	}
-	return batch.Flush()
+	if err := batch.Flush(); err != nil {
+		return err
+	}
+	if fault.Enabled {
+		fault.Inject(fault.WALSaved, fmt.Sprintf("group=%d", w.gid))
+	}
+	return nil
 }
 
 // Append the new entries to storage.
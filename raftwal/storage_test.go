@@ -363,3 +363,122 @@ func TestStorageAppend(t *testing.T) {
 		}
 	}
 }
+
+func TestStorageTransferState(t *testing.T) {
+	dir, err := ioutil.TempDir("", "badger")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	db, err := openBadger(dir)
+	require.NoError(t, err)
+	ds := Init(db, 0, 0)
+
+	data, err := ds.TransferState()
+	require.NoError(t, err)
+	require.Nil(t, data)
+
+	require.NoError(t, ds.SetTransferState([]byte("state")))
+	data, err = ds.TransferState()
+	require.NoError(t, err)
+	require.Equal(t, []byte("state"), data)
+
+	// The transfer state doesn't get in the way of the entries.
+	ds.cache.firstIndex = 0
+	first, err := ds.FirstIndex()
+	require.NoError(t, err)
+	require.Equal(t, uint64(1), first)
+	last, err := ds.LastIndex()
+	require.NoError(t, err)
+	require.Equal(t, uint64(0), last)
+
+	require.NoError(t, ds.SetTransferState(nil))
+	data, err = ds.TransferState()
+	require.NoError(t, err)
+	require.Nil(t, data)
+}
+
+func TestStorageHistoryTs(t *testing.T) {
+	dir, err := ioutil.TempDir("", "badger")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	db, err := openBadger(dir)
+	require.NoError(t, err)
+	defer db.Close()
+	ds := Init(db, 1, 1)
+
+	ts, err := ds.HistoryTs()
+	require.NoError(t, err)
+	require.Zero(t, ts)
+
+	require.NoError(t, ds.SetHistoryTs(42))
+	ts, err = Init(db, 1, 1).HistoryTs()
+	require.NoError(t, err)
+	require.Equal(t, uint64(42), ts)
+	// Each node keeps its own.
+	ts, err = Init(db, 2, 1).HistoryTs()
+	require.NoError(t, err)
+	require.Zero(t, ts)
+	require.NoError(t, Check(db))
+}
+
+func TestCheck(t *testing.T) {
+	dir, err := ioutil.TempDir("", "badger")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	db, err := openBadger(dir)
+	require.NoError(t, err)
+	defer db.Close()
+	ds := Init(db, 1, 1)
+	require.NoError(t, ds.reset([]pb.Entry{{Index: 3, Term: 3}, {Index: 4, Term: 4}}))
+	require.NoError(t, ds.Save(pb.HardState{Term: 4, Commit: 4}, nil, pb.Snapshot{}))
+	require.NoError(t, Check(db))
+
+	set := func(key, val []byte) {
+		require.NoError(t, db.Update(func(txn *badger.Txn) error {
+			return txn.Set(key, val)
+		}))
+	}
+	set(ds.entryKey(5), []byte{0xff})
+	require.Error(t, Check(db))
+	require.NoError(t, db.Update(func(txn *badger.Txn) error {
+		return txn.Delete(ds.entryKey(5))
+	}))
+	require.NoError(t, Check(db))
+	set(ds.historyKey(), []byte{1})
+	require.Error(t, Check(db))
+	set(ds.historyKey(), make([]byte, 8))
+	require.NoError(t, Check(db))
+	set([]byte("unknown"), nil)
+	require.Error(t, Check(db))
+}
+
+func TestStored(t *testing.T) {
+	dir, err := ioutil.TempDir("", "badger")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	db, err := openBadger(dir)
+	require.NoError(t, err)
+	defer db.Close()
+	ds := Init(db, 1, 2)
+	require.NoError(t, ds.reset([]pb.Entry{{Index: 3, Term: 3}, {Index: 4, Term: 4}}))
+	require.NoError(t, ds.Save(pb.HardState{Term: 4, Commit: 4}, nil, pb.Snapshot{}))
+	require.NoError(t, Init(db, 3, 0).SetTransferState([]byte("state")))
+
+	logs, err := Stored(db)
+	require.NoError(t, err)
+	require.Len(t, logs, 2)
+	require.Equal(t, uint64(1), logs[0].ID())
+	require.Equal(t, uint32(2), logs[0].Group())
+	require.Equal(t, uint64(3), logs[1].ID())
+	require.Equal(t, uint32(0), logs[1].Group())
+
+	hs, err := logs[0].HardState()
+	require.NoError(t, err)
+	require.Equal(t, uint64(4), hs.Commit)
+	es, err := logs[0].Entries(4, 5, math.MaxUint64)
+	require.NoError(t, err)
+	require.Equal(t, []pb.Entry{{Index: 4, Term: 4}}, es)
+}
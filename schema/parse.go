@@ -18,6 +18,7 @@ package schema
 
 import (
 	"strings"
+	"time"
 
 	"github.com/dgraph-io/dgraph/lex"
 	"github.com/dgraph-io/dgraph/protos/pb"
@@ -78,6 +79,10 @@ func parseDirective(it *lex.ItemIterator, schema *pb.SchemaUpdate, t types.TypeI
 				" Got: [%v] for attr: [%v]", t.Name(), schema.Predicate)
 		}
 		schema.Lang = true
+	case "ttl":
+		if err := parseTTLDirective(it, schema); err != nil {
+			return err
+		}
 	default:
 		return x.Errorf("Invalid index specification")
 	}
@@ -247,6 +252,54 @@ func parseIndexDirective(it *lex.ItemIterator, predicate string,
 	return tokenizers, nil
 }
 
+// parseTTLDirective works on "@ttl(24h)" or "@ttl(24h, facet)", where facet is the datetime facet
+// the TTL counts from instead of the time of the write.
+func parseTTLDirective(it *lex.ItemIterator, schema *pb.SchemaUpdate) error {
+	if !it.Next() || it.Item().Typ != itemLeftRound {
+		return x.Errorf("Require a duration for @ttl of pred: %s", schema.Predicate)
+	}
+	var args []string
+	expectArg := true
+	for {
+		if !it.Next() {
+			return x.Errorf("Invalid ending.")
+		}
+		next := it.Item()
+		if next.Typ == itemRightRound {
+			break
+		}
+		if next.Typ == itemComma {
+			if expectArg {
+				return x.Errorf("Expected a directive arg but got comma")
+			}
+			expectArg = true
+			continue
+		}
+		if next.Typ != itemText {
+			return x.Errorf("Expected directive arg but got: %v", next.Val)
+		}
+		if !expectArg {
+			return x.Errorf("Expected a comma but got: %v", next.Val)
+		}
+		args = append(args, next.Val)
+		expectArg = false
+	}
+	if len(args) == 0 || len(args) > 2 || expectArg {
+		return x.Errorf("@ttl of pred: %s takes a duration and optionally a facet, got: %v",
+			schema.Predicate, args)
+	}
+	ttl, err := time.ParseDuration(args[0])
+	if err != nil || ttl < time.Second {
+		return x.Errorf("Invalid duration %s for @ttl of pred: %s, must be like 24h and at least"+
+			" a second", args[0], schema.Predicate)
+	}
+	schema.Ttl = int64(ttl / time.Second)
+	if len(args) == 2 {
+		schema.TtlFacet = args[1]
+	}
+	return nil
+}
+
 // resolveTokenizers resolves default tokenizers and verifies tokenizers definitions.
 func resolveTokenizers(updates []*pb.SchemaUpdate) error {
 	for _, schema := range updates {
@@ -376,3 +376,31 @@ func TestParseUpsert(t *testing.T) {
 	`)
 	require.NoError(t, err)
 }
+
+func TestParseTTL(t *testing.T) {
+	reset()
+	updates, err := Parse(`
+		session: uid @ttl(24h) .
+		event: string @index(exact) @ttl(1h30m, at) .
+	`)
+	require.NoError(t, err)
+	require.Len(t, updates, 2)
+	require.EqualValues(t, 86400, updates[0].Ttl)
+	require.Empty(t, updates[0].TtlFacet)
+	require.EqualValues(t, 5400, updates[1].Ttl)
+	require.Equal(t, "at", updates[1].TtlFacet)
+	require.Equal(t, []string{"exact"}, updates[1].Tokenizer)
+
+	for _, s := range []string{
+		`session: uid @ttl .`,
+		`session: uid @ttl() .`,
+		`session: uid @ttl(day) .`,
+		`session: uid @ttl(10ms) .`,
+		`session: uid @ttl(1h, at, by) .`,
+		`session: uid @ttl(1h at) .`,
+		`session: uid @ttl(1h,) .`,
+	} {
+		_, err := Parse(s)
+		require.Error(t, err, s)
+	}
+}
@@ -20,6 +20,8 @@ import (
 	"bytes"
 	"fmt"
 	"sync"
+	"sync/atomic"
+	"time"
 
 	"github.com/dgraph-io/badger"
 	"github.com/golang/glog"
@@ -38,6 +40,7 @@ var (
 
 func (s *state) init() {
 	s.predicate = make(map[string]*pb.SchemaUpdate)
+	s.served = make(map[string]*pb.SchemaUpdate)
 	s.elog = trace.NewEventLog("Dgraph", "Schema")
 }
 
@@ -45,7 +48,11 @@ type state struct {
 	sync.RWMutex
 	// Map containing predicate to type information.
 	predicate map[string]*pb.SchemaUpdate
-	elog      trace.EventLog
+	// Map containing the schema served to queries, for the predicates whose indexes are
+	// being built.
+	served map[string]*pb.SchemaUpdate
+	elog   trace.EventLog
+	ttls   int32 // Number of predicates with @ttl.
 }
 
 // SateFor returns the schema for given group
@@ -60,9 +67,11 @@ func (s *state) DeleteAll() {
 	for pred := range s.predicate {
 		// We set schema for _predicate_, hence it shouldn't be deleted.
 		if pred != x.PredicateListAttr {
+			s.countTTL(s.predicate[pred], nil)
 			delete(s.predicate, pred)
 		}
 	}
+	s.served = make(map[string]*pb.SchemaUpdate)
 }
 
 // Delete updates the schema in memory and disk
@@ -71,7 +80,9 @@ func (s *state) Delete(attr string) error {
 	defer s.Unlock()
 
 	glog.Infof("Deleting schema for predicate: [%s]", attr)
+	s.countTTL(s.predicate[attr], nil)
 	delete(s.predicate, attr)
+	delete(s.served, attr)
 	txn := pstore.NewTransactionAt(1, true)
 	if err := txn.Delete(x.SchemaKey(attr)); err != nil {
 		return err
@@ -80,6 +91,16 @@ func (s *state) Delete(attr string) error {
 	return txn.CommitAt(1, nil)
 }
 
+// Forget removes the schema of the predicate from memory only, for predicates whose schema isn't
+// stored.
+func (s *state) Forget(attr string) {
+	s.Lock()
+	defer s.Unlock()
+	s.countTTL(s.predicate[attr], nil)
+	delete(s.predicate, attr)
+	delete(s.served, attr)
+}
+
 func logUpdate(schema pb.SchemaUpdate, pred string) string {
 	typ := types.TypeID(schema.ValueType).Name()
 	if schema.List {
@@ -95,10 +116,22 @@ func logUpdate(schema pb.SchemaUpdate, pred string) string {
 func (s *state) Set(pred string, schema pb.SchemaUpdate) {
 	s.Lock()
 	defer s.Unlock()
+	s.countTTL(s.predicate[pred], &schema)
 	s.predicate[pred] = &schema
 	s.elog.Printf(logUpdate(schema, pred))
 }
 
+// countTTL keeps count of the predicates with @ttl, as the schema of a predicate goes from old to
+// cur. Either can be nil.
+func (s *state) countTTL(old, cur *pb.SchemaUpdate) {
+	if old != nil && old.Ttl > 0 {
+		atomic.AddInt32(&s.ttls, -1)
+	}
+	if cur != nil && cur.Ttl > 0 {
+		atomic.AddInt32(&s.ttls, 1)
+	}
+}
+
 // Get gets the schema for given predicate
 func (s *state) Get(pred string) (pb.SchemaUpdate, bool) {
 	s.RLock()
@@ -218,6 +251,22 @@ func (s *state) HasUpsert(pred string) bool {
 	return false
 }
 
+// HasTTLs tells whether any predicate has @ttl. It's cheap, so reads can skip the TTL lookups.
+func (s *state) HasTTLs() bool {
+	return s != nil && atomic.LoadInt32(&s.ttls) > 0
+}
+
+// TTL returns how long the values of the predicate live for, and the datetime facet that time
+// counts from, if any. A zero TTL means they don't expire.
+func (s *state) TTL(pred string) (time.Duration, string) {
+	s.RLock()
+	defer s.RUnlock()
+	if schema, ok := s.predicate[pred]; ok {
+		return time.Duration(schema.Ttl) * time.Second, schema.TtlFacet
+	}
+	return 0, ""
+}
+
 func (s *state) HasLang(pred string) bool {
 	s.RLock()
 	defer s.RUnlock()
@@ -227,6 +276,88 @@ func (s *state) HasLang(pred string) bool {
 	return false
 }
 
+// SetServed makes queries use the given schema for the predicate, instead of the one set via Set,
+// until ClearServed is called. It's used while the indexes of the predicate are being built.
+func (s *state) SetServed(pred string, schema pb.SchemaUpdate) {
+	s.Lock()
+	defer s.Unlock()
+	s.served[pred] = &schema
+}
+
+// ClearServed makes queries use the schema set via Set for the predicate again.
+func (s *state) ClearServed(pred string) {
+	s.Lock()
+	defer s.Unlock()
+	delete(s.served, pred)
+}
+
+// servedState is the view of the schema used by queries.
+type servedState struct {
+	s *state
+}
+
+// Served returns the schema used to decide which indexes queries can use. It only differs from
+// State for the predicates whose indexes are being built, for which the indexes aren't complete.
+func Served() servedState {
+	return servedState{s: pstate}
+}
+
+func (v servedState) get(pred string) (*pb.SchemaUpdate, bool) {
+	v.s.RLock()
+	defer v.s.RUnlock()
+	if schema, ok := v.s.served[pred]; ok {
+		return schema, true
+	}
+	schema, ok := v.s.predicate[pred]
+	return schema, ok
+}
+
+// IsIndexed returns whether queries can use the index of the predicate
+func (v servedState) IsIndexed(pred string) bool {
+	if schema, ok := v.get(pred); ok {
+		return len(schema.Tokenizer) > 0
+	}
+	return false
+}
+
+// Tokenizer returns the tokenizers whose index queries can use for the predicate
+func (v servedState) Tokenizer(pred string) []tok.Tokenizer {
+	schema, ok := v.get(pred)
+	x.AssertTruef(ok, "schema state not found for %s", pred)
+	var tokenizers []tok.Tokenizer
+	for _, it := range schema.Tokenizer {
+		t, found := tok.GetTokenizer(it)
+		x.AssertTruef(found, "Invalid tokenizer %s", it)
+		tokenizers = append(tokenizers, t)
+	}
+	return tokenizers
+}
+
+// TokenizerNames returns the names of the tokenizers whose index queries can use
+func (v servedState) TokenizerNames(pred string) []string {
+	var names []string
+	for _, t := range v.Tokenizer(pred) {
+		names = append(names, t.Name())
+	}
+	return names
+}
+
+// IsReversed returns whether queries can use the reverse edges of the predicate
+func (v servedState) IsReversed(pred string) bool {
+	if schema, ok := v.get(pred); ok {
+		return schema.Directive == pb.SchemaUpdate_REVERSE
+	}
+	return false
+}
+
+// HasCount returns whether queries can use the count index of the predicate
+func (v servedState) HasCount(pred string) bool {
+	if schema, ok := v.get(pred); ok {
+		return schema.Count
+	}
+	return false
+}
+
 func Init(ps *badger.DB) {
 	pstore = ps
 	reset()
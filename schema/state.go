@@ -46,6 +46,9 @@ Loop:
 		case isNameBegin(r):
 			l.Backup()
 			return lexWord
+		case r >= '0' && r <= '9':
+			// Durations, like in @ttl(24h).
+			return lexWord
 		case isSpace(r):
 			l.Ignore()
 		case isEndOfLine(r):
@@ -42,6 +42,13 @@ type Lists struct {
 	DB            *badger.DB
 	ChooseKeyFunc func(item *badger.Item) bool
 	ItemToKVFunc  func(key []byte, itr *badger.Iterator) (*pb.KV, error)
+
+	// StartKey skips the keys before it, e.g. to resume a stream which failed midway.
+	StartKey []byte
+	// Checkpoint sets the checkpoint of the batches sent, from which the stream can be resumed.
+	Checkpoint bool
+	// Rate limits the bytes sent per second. Zero means unlimited.
+	Rate int64
 }
 
 // keyRange is [start, end), including start, excluding end. Do ensure that the start,
@@ -49,12 +56,20 @@ type Lists struct {
 type keyRange struct {
 	start []byte
 	end   []byte
+	seq   int // Position of the range, in the order of the keys.
+}
+
+// rangeKVs are the key-values picked from a key range. The ranges are read concurrently, so
+// they're sent out of order.
+type rangeKVs struct {
+	kr  keyRange
+	kvs *pb.KVS
 }
 
 func (sl *Lists) Orchestrate(ctx context.Context, prefix string, ts uint64) error {
-	keyCh := make(chan keyRange, 100) // Contains keys for posting lists.
-	kvChan := make(chan *pb.KVS, 100) // Contains marshaled posting lists.
-	errCh := make(chan error, 1)      // Stores error by consumeKeys.
+	keyCh := make(chan keyRange, 100)  // Contains keys for posting lists.
+	kvChan := make(chan rangeKVs, 100) // Contains marshaled posting lists.
+	errCh := make(chan error, 1)       // Stores error by consumeKeys.
 
 	// Read the predicate keys and stream to keysCh.
 	go sl.produceRanges(ctx, ts, keyCh)
@@ -107,30 +122,32 @@ func (sl *Lists) produceRanges(ctx context.Context, ts uint64, keyCh chan keyRan
 	it := txn.NewIterator(iterOpts)
 	defer it.Close()
 
-	var start []byte
+	seek := prefix
+	if bytes.Compare(sl.StartKey, seek) > 0 {
+		seek = sl.StartKey
+	}
+	// The first range starts at seek, rather than at the first key, so that the keys deleted
+	// before it can be picked too.
+	start := append([]byte{}, seek...)
 	var size int64
-	for it.Seek(prefix); it.ValidForPrefix(prefix); it.Next() {
+	var seq int
+	for it.Seek(seek); it.ValidForPrefix(prefix); it.Next() {
 		item := it.Item()
-		if len(start) == 0 {
-			start = item.KeyCopy(nil)
-		}
-
 		size += item.EstimatedSize()
 		if size > pageSize {
-			kr := keyRange{start: start, end: item.KeyCopy(nil)}
+			kr := keyRange{start: start, end: item.KeyCopy(nil), seq: seq}
 			keyCh <- kr
 			start = item.KeyCopy(nil)
 			size = 0
+			seq++
 		}
 	}
-	if len(start) > 0 {
-		keyCh <- keyRange{start: start}
-	}
+	keyCh <- keyRange{start: start, seq: seq}
 	close(keyCh)
 }
 
 func (sl *Lists) produceKVs(ctx context.Context, ts uint64,
-	keyCh chan keyRange, kvChan chan *pb.KVS) error {
+	keyCh chan keyRange, kvChan chan rangeKVs) error {
 	var prefix []byte
 	if len(sl.Predicate) > 0 {
 		prefix = x.PredicatePrefix(sl.Predicate)
@@ -173,8 +190,9 @@ func (sl *Lists) produceKVs(ctx context.Context, ts uint64,
 				kvs.Kv = append(kvs.Kv, kv)
 			}
 		}
-		if len(kvs.Kv) > 0 {
-			kvChan <- kvs
+		if len(kvs.Kv) > 0 || sl.Checkpoint {
+			// Empty ranges are sent along too, so the checkpoint can move past them.
+			kvChan <- rangeKVs{kr: kr, kvs: kvs}
 		}
 		return nil
 	}
@@ -195,27 +213,62 @@ func (sl *Lists) produceKVs(ctx context.Context, ts uint64,
 	}
 }
 
-func (sl *Lists) streamKVs(ctx context.Context, prefix string, kvChan chan *pb.KVS) error {
+// checkpoints tracks the key ranges sent, to tell up to which key all of them were.
+type checkpoints struct {
+	next int              // Sequence of the first range not sent yet.
+	sent map[int]keyRange // Ranges sent out of order, after next.
+	key  []byte           // All the keys before it were sent.
+}
+
+func (c *checkpoints) done(kr keyRange) {
+	if c.sent == nil {
+		c.sent = make(map[int]keyRange)
+	}
+	c.sent[kr.seq] = kr
+	for {
+		kr, ok := c.sent[c.next]
+		if !ok {
+			return
+		}
+		delete(c.sent, c.next)
+		c.next++
+		if len(kr.end) > 0 {
+			c.key = kr.end
+		}
+	}
+}
+
+func (sl *Lists) streamKVs(ctx context.Context, prefix string, kvChan chan rangeKVs) error {
 	var count int
 	var bytesSent uint64
 	t := time.NewTicker(time.Second)
 	defer t.Stop()
 	now := time.Now()
+	var cp checkpoints
+	var lastCheckpoint []byte
 
 	slurp := func(batch *pb.KVS) error {
 	loop:
 		for {
 			select {
-			case kvs, ok := <-kvChan:
+			case rkv, ok := <-kvChan:
 				if !ok {
 					break loop
 				}
-				x.AssertTrue(kvs != nil)
-				batch.Kv = append(batch.Kv, kvs.Kv...)
+				x.AssertTrue(rkv.kvs != nil)
+				batch.Kv = append(batch.Kv, rkv.kvs.Kv...)
+				cp.done(rkv.kr)
 			default:
 				break loop
 			}
 		}
+		if sl.Checkpoint {
+			batch.Checkpoint = cp.key
+		}
+		if len(batch.Kv) == 0 && bytes.Equal(batch.Checkpoint, lastCheckpoint) {
+			return nil
+		}
+		lastCheckpoint = batch.Checkpoint
 		sz := uint64(batch.Size())
 		bytesSent += sz
 		count += len(batch.Kv)
@@ -225,7 +278,7 @@ func (sl *Lists) streamKVs(ctx context.Context, prefix string, kvChan chan *pb.K
 		}
 		glog.V(2).Infof("%s Created batch of size: %s in %s.\n",
 			prefix, humanize.Bytes(sz), time.Since(t))
-		return nil
+		return sl.throttle(ctx, bytesSent, time.Since(now))
 	}
 
 outer:
@@ -245,12 +298,13 @@ outer:
 			glog.Infof("%s Time elapsed: %s, bytes sent: %s, speed: %s/sec\n",
 				prefix, x.FixedDuration(dur), humanize.Bytes(bytesSent), humanize.Bytes(speed))
 
-		case kvs, ok := <-kvChan:
+		case rkv, ok := <-kvChan:
 			if !ok {
 				break outer
 			}
-			x.AssertTrue(kvs != nil)
-			batch = kvs
+			x.AssertTrue(rkv.kvs != nil)
+			batch = &pb.KVS{Kv: rkv.kvs.Kv}
+			cp.done(rkv.kr)
 			if err := slurp(batch); err != nil {
 				return err
 			}
@@ -260,3 +314,20 @@ outer:
 	glog.Infof("%s Sent %d keys\n", prefix, count)
 	return nil
 }
+
+// throttle waits until sending bytesSent over elapsed gets within the rate of the stream.
+func (sl *Lists) throttle(ctx context.Context, bytesSent uint64, elapsed time.Duration) error {
+	if sl.Rate <= 0 {
+		return nil
+	}
+	wait := time.Duration(float64(bytesSent)/float64(sl.Rate)*float64(time.Second)) - elapsed
+	if wait <= 0 {
+		return nil
+	}
+	select {
+	case <-time.After(wait):
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -17,12 +17,14 @@
 package stream
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 	"io/ioutil"
 	"math"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/dgraph-io/badger"
 	"github.com/dgraph-io/dgraph/protos/pb"
@@ -158,3 +160,99 @@ func TestOrchestrate(t *testing.T) {
 		require.Equal(t, 50, count, "Count mismatch for pred: %s", pred)
 	}
 }
+
+type batchCollector struct {
+	batches []*pb.KVS
+}
+
+func (c *batchCollector) Send(kvs *pb.KVS) error {
+	c.batches = append(c.batches, kvs)
+	return nil
+}
+
+func TestOrchestrateResume(t *testing.T) {
+	dir, err := ioutil.TempDir("", "badger")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	db, err := openManaged(dir)
+	require.NoError(t, err)
+
+	// Enough data for many key ranges.
+	var keys [][]byte
+	val := make([]byte, 64<<10)
+	for _, pred := range []string{"p0", "p1", "p2"} {
+		txn := db.NewTransactionAt(math.MaxUint64, true)
+		for i := 1; i <= 100; i++ {
+			key := x.DataKey(pred, uint64(i))
+			require.NoError(t, txn.Set(key, val))
+			keys = append(keys, key)
+		}
+		require.NoError(t, txn.CommitAt(5, nil))
+	}
+
+	c := &batchCollector{}
+	sl := Lists{Stream: c, DB: db, Checkpoint: true}
+	sl.ItemToKVFunc = func(key []byte, itr *badger.Iterator) (*pb.KV, error) {
+		kv := &pb.KV{Key: key, Version: itr.Item().Version()}
+		itr.Next()
+		return kv, nil
+	}
+	require.NoError(t, sl.Orchestrate(context.Background(), "Testing", math.MaxUint64))
+
+	// All the keys before a checkpoint were sent before or along with it.
+	sent := make(map[string]bool)
+	var checkpoint []byte
+	for _, batch := range c.batches {
+		for _, kv := range batch.Kv {
+			sent[string(kv.Key)] = true
+		}
+		if len(batch.Checkpoint) == 0 {
+			continue
+		}
+		require.True(t, bytes.Compare(batch.Checkpoint, checkpoint) >= 0)
+		checkpoint = batch.Checkpoint
+		for _, key := range keys {
+			if bytes.Compare(key, checkpoint) < 0 {
+				require.True(t, sent[string(key)])
+			}
+		}
+	}
+	require.Len(t, sent, 300)
+	require.NotEmpty(t, checkpoint)
+
+	// Resuming from the last checkpoint only sends the keys after it.
+	c.batches = c.batches[:0]
+	sl.StartKey = checkpoint
+	require.NoError(t, sl.Orchestrate(context.Background(), "Testing", math.MaxUint64))
+	var resent int
+	for _, batch := range c.batches {
+		for _, kv := range batch.Kv {
+			require.True(t, bytes.Compare(kv.Key, checkpoint) >= 0)
+			resent++
+		}
+	}
+	var after int
+	for _, key := range keys {
+		if bytes.Compare(key, checkpoint) >= 0 {
+			after++
+		}
+	}
+	require.Equal(t, after, resent)
+}
+
+func TestThrottle(t *testing.T) {
+	sl := Lists{Rate: 1 << 20}
+	start := time.Now()
+	require.NoError(t, sl.throttle(context.Background(), 1<<18, 0))
+	require.True(t, time.Since(start) >= 200*time.Millisecond)
+
+	// Under the rate, there's no wait.
+	start = time.Now()
+	require.NoError(t, sl.throttle(context.Background(), 1<<18, time.Second))
+	require.True(t, time.Since(start) < 100*time.Millisecond)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	require.Error(t, sl.throttle(ctx, 1<<30, 0))
+}
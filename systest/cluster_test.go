@@ -131,10 +131,11 @@ func waitForNodeToBeHealthy(t *testing.T, port int) {
 			time.Sleep(2 * time.Second)
 			continue
 		}
-		b, err := ioutil.ReadAll(resp.Body)
+		_, err = ioutil.ReadAll(resp.Body)
 		require.NoError(t, err)
+		resp.Body.Close()
 
-		if string(b) == "OK" {
+		if resp.StatusCode == http.StatusOK {
 			break
 		}
 
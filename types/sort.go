@@ -95,7 +95,9 @@ func SortWithFacet(v [][]Val, ul *pb.List, l []*pb.Facets, desc []bool) error {
 	var toBeSorted sort.Interface
 	b := sortBase{v, desc, ul, l}
 	toBeSorted = byValue{b}
-	sort.Sort(toBeSorted)
+	// Keep the uids of equal values in their given order, so that the order is the same every
+	// time and results can be paginated after one of them.
+	sort.Stable(toBeSorted)
 	return nil
 }
 
@@ -104,6 +106,35 @@ func Sort(v [][]Val, ul *pb.List, desc []bool) error {
 	return SortWithFacet(v, ul, nil, desc)
 }
 
+// Compare returns -1, 0 or 1 if a is sorted before, along with or after b, in ascending order if
+// desc isn't set. Like Sort does, it sorts nil values as greater than all others.
+func Compare(a, b Val, desc bool) int {
+	switch {
+	case a.Value == nil && b.Value == nil:
+		return 0
+	case a.Value == nil:
+		if desc {
+			return -1
+		}
+		return 1
+	case b.Value == nil:
+		if desc {
+			return 1
+		}
+		return -1
+	case equal(a, b):
+		return 0
+	}
+	res := 1
+	if less(a, b) {
+		res = -1
+	}
+	if desc {
+		return -res
+	}
+	return res
+}
+
 // Less returns true if a is strictly less than b.
 func Less(a, b Val) (bool, error) {
 	if a.Tid != b.Tid {
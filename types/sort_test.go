@@ -122,6 +122,37 @@ func TestSortIntAndFloat(t *testing.T) {
 
 }
 
+func TestSortStable(t *testing.T) {
+	list := getInput(t, IntID, []string{"2", "1", "2", "1", "2"})
+	ul := getUIDList(5)
+	require.NoError(t, Sort(list, ul, []bool{false}))
+	require.EqualValues(t, []uint64{200, 400, 100, 300, 500}, ul.Uids)
+
+	list = getInput(t, IntID, []string{"2", "1", "2", "1", "2"})
+	ul = getUIDList(5)
+	require.NoError(t, Sort(list, ul, []bool{true}))
+	require.EqualValues(t, []uint64{100, 300, 500, 200, 400}, ul.Uids)
+
+	// Uids without a value come first in the reverse order when sorting in descending order.
+	list = getInput(t, IntID, []string{"2", "1", "2"})
+	list = append(list, []Val{{Tid: IntID}}, []Val{{Tid: IntID}})
+	ul = getUIDList(5)
+	require.NoError(t, Sort(list, ul, []bool{true}))
+	require.EqualValues(t, []uint64{500, 400, 100, 300, 200}, ul.Uids)
+}
+
+func TestCompare(t *testing.T) {
+	one := Val{Tid: IntID, Value: int64(1)}
+	two := Val{Tid: IntID, Value: int64(2)}
+	null := Val{Tid: IntID}
+	require.Equal(t, -1, Compare(one, two, false))
+	require.Equal(t, 1, Compare(one, two, true))
+	require.Equal(t, 0, Compare(two, two, false))
+	require.Equal(t, -1, Compare(two, null, false))
+	require.Equal(t, 1, Compare(two, null, true))
+	require.Equal(t, 0, Compare(null, null, true))
+}
+
 func TestEqual(t *testing.T) {
 	require.True(t, equal(Val{Tid: IntID, Value: int64(3)}, Val{Tid: IntID, Value: int64(3)}),
 		"equal should return true for two equal values")
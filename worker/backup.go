@@ -132,10 +132,13 @@ func BackupOverNetwork(pctx context.Context, target string) error {
 		err := <-errCh
 		if err != nil {
 			glog.Errorf("Error received during backup: %v", err)
+			RecordEvent(EventBackup, "", "Backup to %s at readTs %d failed: %v", target,
+				req.ReadTs, err)
 			return err
 		}
 	}
 	req.GroupId = 0
 	glog.Infof("Backup for req: %+v. OK.\n", req)
+	RecordEvent(EventBackup, "", "Backup to %s at readTs %d done", target, req.ReadTs)
 	return nil
 }
@@ -0,0 +1,111 @@
+/*
+ * Copyright 2018 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package worker
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/golang/glog"
+
+	"github.com/dgraph-io/dgraph/changes"
+	"github.com/dgraph-io/dgraph/posting"
+	"github.com/dgraph-io/dgraph/protos/pb"
+	"github.com/dgraph-io/dgraph/types"
+	"github.com/dgraph-io/dgraph/x"
+)
+
+var (
+	// changeStream publishes the changes committed to the predicates of this group, if Alpha was
+	// started with --change_stream. Only the leader of the group publishes them.
+	changeStream *changes.Stream
+	// flagRoutes are the routes of --change_stream_routes, which apply while the cluster has none.
+	flagRoutes string
+	// clusterRoutes are the routes kept by Zero for the whole cluster, last applied.
+	clusterRoutes string
+)
+
+// SetChangeStream sets the stream the changes committed by this group are published to.
+func SetChangeStream(s *changes.Stream) {
+	changeStream = s
+	if s != nil {
+		flagRoutes = s.Routes()
+	}
+}
+
+// applyChangeStreamRoutes routes the changes by the rules Zero keeps for the whole cluster, set
+// through its /admin/config, so that every Alpha publishes them alike whichever leads a group.
+// It's called with the group lock held, as the membership state is applied.
+func applyChangeStreamRoutes(rules string) {
+	if changeStream == nil || rules == clusterRoutes {
+		return
+	}
+	clusterRoutes = rules
+	if len(rules) == 0 {
+		rules = flagRoutes
+	}
+	if err := changeStream.SetRoutes(rules); err != nil {
+		glog.Errorf("While routing the change stream by %q: %v", rules, err)
+		return
+	}
+	glog.Infof("Change stream routed by %q", rules)
+}
+
+// committedChanges returns the changes to the data made by txn, committed at commitTs. It must be
+// called before txn is committed to memory.
+func committedChanges(txn *posting.Txn, commitTs uint64) []*changes.Event {
+	var events []*changes.Event
+	err := txn.DataPostings(func(pk *x.ParsedKey, p *pb.Posting) {
+		if pk.Attr == "_predicate_" || IsTombstone(pk.Attr) {
+			return
+		}
+		ns, attr := x.ParseNamespaceAttr(pk.Attr)
+		e := &changes.Event{
+			CommitTs:  commitTs,
+			Namespace: ns,
+			Predicate: attr,
+			Uid:       fmt.Sprintf("%#x", pk.Uid),
+			Op:        changes.OpSet,
+			Lang:      string(p.LangTag),
+		}
+		if p.Op == posting.Del {
+			e.Op = changes.OpDelete
+		}
+		switch {
+		case bytes.Equal(p.Value, []byte(x.Star)):
+			e.Value = "*"
+		case p.PostingType == pb.Posting_REF:
+			e.Object = fmt.Sprintf("%#x", p.Uid)
+		case p.ValType == pb.Posting_PASSWORD:
+			// Passwords aren't published, only that they changed.
+		default:
+			src := types.Val{Tid: types.TypeID(p.ValType), Value: p.Value}
+			str, err := types.Convert(src, types.StringID)
+			if err != nil {
+				glog.Warningf("While converting the value of %s of %#x for the change stream: %v",
+					attr, pk.Uid, err)
+				return
+			}
+			e.Value = str.Value.(string)
+		}
+		events = append(events, e)
+	})
+	if err != nil {
+		glog.Errorf("While reading the changes committed at %d: %v", commitTs, err)
+	}
+	return events
+}
@@ -0,0 +1,86 @@
+/*
+ * Copyright 2018 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package worker
+
+import (
+	"context"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dgraph-io/dgraph/changes"
+	"github.com/dgraph-io/dgraph/posting"
+	"github.com/dgraph-io/dgraph/protos/pb"
+	"github.com/dgraph-io/dgraph/schema"
+	"github.com/dgraph-io/dgraph/x"
+)
+
+func TestCommittedChanges(t *testing.T) {
+	require.NoError(t, schema.ParseBytes([]byte("changes.name: string ."), 1))
+	startTs := timestamp()
+	txn := posting.Oracle().RegisterStartTs(startTs)
+	edges := []*pb.DirectedEdge{
+		{Attr: "changes.name", Entity: 1, Value: []byte("alice"), ValueType: pb.Posting_STRING,
+			Lang: "en"},
+		{Attr: "changes.friend", Entity: 1, ValueId: 2},
+		{Attr: "ns1|changes.age", Entity: 2, Value: []byte("30"),
+			ValueType: pb.Posting_DEFAULT, Op: pb.DirectedEdge_DEL},
+		{Attr: "changes.secret", Entity: 2, Value: []byte("hash"),
+			ValueType: pb.Posting_PASSWORD},
+	}
+	for _, edge := range edges {
+		l := getOrCreate(x.DataKey(edge.Attr, edge.Entity))
+		require.NoError(t, l.AddMutation(context.Background(), txn, edge))
+	}
+
+	commit := commitTs(startTs)
+	events := committedChanges(txn, commit)
+	sort.Slice(events, func(i, j int) bool { return events[i].Predicate < events[j].Predicate })
+	require.Equal(t, []*changes.Event{
+		{CommitTs: commit, Namespace: "ns1", Predicate: "changes.age", Uid: "0x2",
+			Op: changes.OpDelete, Value: "30"},
+		{CommitTs: commit, Predicate: "changes.friend", Uid: "0x1", Op: changes.OpSet,
+			Object: "0x2"},
+		{CommitTs: commit, Predicate: "changes.name", Uid: "0x1", Op: changes.OpSet,
+			Value: "alice", Lang: "en"},
+		// Passwords aren't published.
+		{CommitTs: commit, Predicate: "changes.secret", Uid: "0x2", Op: changes.OpSet},
+	}, events)
+	require.NoError(t, txn.CommitToMemory(commit))
+}
+
+func TestApplyChangeStreamRoutes(t *testing.T) {
+	s, err := changes.New("nats=localhost:4222", "changes.name=nats:names")
+	require.NoError(t, err)
+	defer s.Close()
+	SetChangeStream(s)
+	defer func() {
+		SetChangeStream(nil)
+		flagRoutes, clusterRoutes = "", ""
+	}()
+
+	// The routes of the cluster take precedence over those of the flag.
+	applyChangeStreamRoutes("changes.*=nats:all")
+	require.Equal(t, "changes.*=nats:all", s.Routes())
+	// Routes to sinks this Alpha doesn't have aren't applied.
+	applyChangeStreamRoutes("changes.*=pubsub:all")
+	require.Equal(t, "changes.*=nats:all", s.Routes())
+	// Without routes for the cluster, those of the flag apply again.
+	applyChangeStreamRoutes("")
+	require.Equal(t, "changes.name=nats:names", s.Routes())
+}
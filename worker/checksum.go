@@ -0,0 +1,216 @@
+/*
+ * Copyright 2018 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package worker
+
+import (
+	"bytes"
+	"encoding/binary"
+	"sort"
+	"sync"
+
+	"github.com/dgraph-io/badger"
+	farm "github.com/dgryski/go-farm"
+	"github.com/golang/glog"
+
+	"github.com/dgraph-io/dgraph/posting"
+	"github.com/dgraph-io/dgraph/protos/pb"
+	"github.com/dgraph-io/dgraph/x"
+)
+
+// Every replica computes a digest of each of its tablets, as of the read ts of the last snapshot.
+// All the replicas of a group take the snapshot at the same read ts, so healthy replicas end up
+// with the same digests, and Zero can tell when one of them diverged from the others.
+//
+// The digest is the XOR of the fingerprints of every key, along with the postings it has at the
+// read ts. It only depends on what the posting lists hold, not on how they're stored, so that it
+// doesn't change when lists are rolled up. Computing it means reading whole tablets, so it's only
+// done for the tablets which changed since it was last computed.
+
+// tabletSum is the checksum of a tablet, along with what's needed to tell whether it changed.
+type tabletSum struct {
+	pb.TabletChecksum
+	maxVersion uint64 // Latest version of any key, at the read ts.
+	live       uint64 // Number of keys, including the ones without any postings.
+}
+
+type tabletSums struct {
+	sync.Mutex
+	sums map[string]*tabletSum
+}
+
+// update computes the checksums of the tablets served at readTs, reusing the ones which didn't
+// change since they were last computed.
+func (t *tabletSums) update(db *badger.DB, readTs uint64, serves func(attr string) bool) error {
+	t.Lock()
+	prev := t.sums
+	t.Unlock()
+
+	sums, err := computeChecksums(db, readTs, serves, prev)
+	if err != nil {
+		return err
+	}
+	t.Lock()
+	t.sums = sums
+	t.Unlock()
+	return nil
+}
+
+// checksums returns the checksums of the tablets, sorted by predicate.
+func (t *tabletSums) checksums() []*pb.TabletChecksum {
+	t.Lock()
+	defer t.Unlock()
+	res := make([]*pb.TabletChecksum, 0, len(t.sums))
+	for _, s := range t.sums {
+		c := s.TabletChecksum
+		res = append(res, &c)
+	}
+	sort.Slice(res, func(i, j int) bool { return res[i].Predicate < res[j].Predicate })
+	return res
+}
+
+// TabletChecksums computes the checksums of all the tablets in db as of readTs, as the Alphas
+// report them to Zero.
+func TabletChecksums(db *badger.DB, readTs uint64) ([]*pb.TabletChecksum, error) {
+	var t tabletSums
+	if err := t.update(db, readTs, func(string) bool { return true }); err != nil {
+		return nil, err
+	}
+	return t.checksums(), nil
+}
+
+func computeChecksums(db *badger.DB, readTs uint64, serves func(attr string) bool,
+	prev map[string]*tabletSum) (map[string]*tabletSum, error) {
+	txn := db.NewTransactionAt(readTs, false)
+	defer txn.Discard()
+
+	// First, find the tablets which changed, by only going over the keys.
+	opt := badger.DefaultIteratorOptions
+	opt.PrefetchValues = false
+	itr := txn.NewIterator(opt)
+	sums := make(map[string]*tabletSum)
+	for itr.Rewind(); itr.Valid(); {
+		item := itr.Item()
+		pk := x.Parse(item.Key())
+		if pk == nil || pk.IsSchema() {
+			itr.Next()
+			continue
+		}
+		s, has := sums[pk.Attr]
+		if !has {
+			if !serves(pk.Attr) {
+				itr.Seek(pk.SkipPredicate())
+				continue
+			}
+			s = &tabletSum{}
+			s.Predicate = pk.Attr
+			sums[pk.Attr] = s
+		}
+		if v := item.Version(); v > s.maxVersion {
+			s.maxVersion = v
+		}
+		s.live++
+		itr.Next()
+	}
+	itr.Close()
+
+	opt.PrefetchValues = true
+	opt.AllVersions = true
+	itr = txn.NewIterator(opt)
+	defer itr.Close()
+	var computed int
+	for attr, s := range sums {
+		if p, ok := prev[attr]; ok && p.maxVersion == s.maxVersion && p.live == s.live {
+			// Nothing happened to the tablet, so its digest is still the same.
+			s.Digest, s.Keys = p.Digest, p.Keys
+			s.ReadTs = readTs
+			continue
+		}
+		digest, keys, err := tabletDigest(itr, attr, readTs)
+		if err != nil {
+			return nil, err
+		}
+		s.Digest, s.Keys, s.ReadTs = digest, keys, readTs
+		computed++
+	}
+	glog.V(2).Infof("Checksums at ts %d: computed %d of %d tablets.", readTs, computed,
+		len(sums))
+	return sums, nil
+}
+
+// tabletDigest reads all the keys of attr, returning their digest and how many of them have any
+// postings at readTs.
+func tabletDigest(itr *badger.Iterator, attr string, readTs uint64) (uint64, uint64, error) {
+	prefix := x.PredicatePrefix(attr)
+	var digest, keys uint64
+	var buf bytes.Buffer
+	for itr.Seek(prefix); itr.ValidForPrefix(prefix); {
+		key := itr.Item().KeyCopy(nil)
+		if pk := x.Parse(key); pk == nil || pk.IsSchema() {
+			itr.Next()
+			continue
+		}
+		l, err := posting.ReadPostingList(key, itr)
+		if err != nil {
+			return 0, 0, err
+		}
+		// Skip the older versions of the key.
+		for itr.Valid() && bytes.Equal(itr.Item().Key(), key) {
+			itr.Next()
+		}
+
+		buf.Reset()
+		buf.Write(key)
+		var n int
+		err = l.Iterate(readTs, 0, func(p *pb.Posting) error {
+			writePosting(&buf, p)
+			n++
+			return nil
+		})
+		if err != nil {
+			return 0, 0, err
+		}
+		if n == 0 {
+			continue
+		}
+		digest ^= farm.Fingerprint64(buf.Bytes())
+		keys++
+	}
+	return digest, keys, nil
+}
+
+// writePosting writes what a posting holds, leaving out the timestamps of the transaction which
+// wrote it. Rolled up lists only keep the uids of plain uid postings, so that's all which is
+// written for them.
+func writePosting(buf *bytes.Buffer, p *pb.Posting) {
+	var b [binary.MaxVarintLen64]byte
+	writeBytes := func(data []byte) {
+		buf.Write(b[:binary.PutUvarint(b[:], uint64(len(data)))])
+		buf.Write(data)
+	}
+	buf.Write(b[:binary.PutUvarint(b[:], p.Uid)])
+	if p.PostingType != pb.Posting_REF {
+		buf.Write(b[:binary.PutUvarint(b[:], uint64(p.ValType))])
+		writeBytes(p.Value)
+		writeBytes(p.LangTag)
+	}
+	writeBytes([]byte(p.Label))
+	for _, f := range p.Facets {
+		writeBytes([]byte(f.Key))
+		buf.Write(b[:binary.PutUvarint(b[:], uint64(f.ValType))])
+		writeBytes(f.Value)
+	}
+}
@@ -0,0 +1,76 @@
+/*
+ * Copyright 2018 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package worker
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dgraph-io/dgraph/protos/pb"
+	"github.com/dgraph-io/dgraph/schema"
+	"github.com/dgraph-io/dgraph/x"
+)
+
+func TestChecksums(t *testing.T) {
+	require.NoError(t, schema.ParseBytes([]byte("checksum.friend: uid ."), 1))
+	// Served by another group, so that the exports of the later tests leave the data out.
+	gr.Lock()
+	gr.tablets["checksum.friend"] = &pb.Tablet{GroupId: 2}
+	gr.Unlock()
+	serves := func(attr string) bool { return attr == "checksum.friend" }
+	edge := &pb.DirectedEdge{Attr: "checksum.friend", Entity: 1, ValueId: 2}
+	addEdge(t, edge, getOrCreate(x.DataKey("checksum.friend", 1)))
+	edge.Entity = 3
+	addEdge(t, edge, getOrCreate(x.DataKey("checksum.friend", 3)))
+
+	readTs := timestamp()
+	sums, err := computeChecksums(pstore, readTs, serves, nil)
+	require.NoError(t, err)
+	require.Len(t, sums, 1)
+	sum := sums["checksum.friend"]
+	require.Equal(t, readTs, sum.ReadTs)
+	require.Equal(t, uint64(2), sum.Keys)
+	require.NotEqual(t, uint64(0), sum.Digest)
+
+	// Nothing changed, so the digest stays the same at a later ts.
+	later, err := computeChecksums(pstore, timestamp(), serves, sums)
+	require.NoError(t, err)
+	require.Equal(t, sum.Digest, later["checksum.friend"].Digest)
+	again, err := computeChecksums(pstore, later["checksum.friend"].ReadTs, serves, nil)
+	require.NoError(t, err)
+	require.Equal(t, sum.Digest, again["checksum.friend"].Digest)
+
+	edge.ValueId = 4
+	addEdge(t, edge, getOrCreate(x.DataKey("checksum.friend", 3)))
+	changed, err := computeChecksums(pstore, timestamp(), serves, later)
+	require.NoError(t, err)
+	require.Equal(t, uint64(2), changed["checksum.friend"].Keys)
+	require.NotEqual(t, sum.Digest, changed["checksum.friend"].Digest)
+
+	// The digest doesn't depend on whether the lists are rolled up.
+	l := getOrCreate(x.DataKey("checksum.friend", 3))
+	kv, err := l.MarshalToKv()
+	require.NoError(t, err)
+	writer := x.NewTxnWriter(pstore)
+	writer.BlindWrite = true
+	require.NoError(t, writer.SetAt(kv.Key, kv.Val, kv.UserMeta[0], kv.Version))
+	require.NoError(t, writer.Flush())
+	rolled, err := computeChecksums(pstore, changed["checksum.friend"].ReadTs, serves, nil)
+	require.NoError(t, err)
+	require.Equal(t, changed["checksum.friend"].Digest, rolled["checksum.friend"].Digest)
+}
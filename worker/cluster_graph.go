@@ -0,0 +1,306 @@
+/*
+ * Copyright 2018 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package worker
+
+import (
+	"sort"
+	"strings"
+
+	farm "github.com/dgryski/go-farm"
+	"golang.org/x/net/context"
+
+	"github.com/dgraph-io/dgraph/algo"
+	"github.com/dgraph-io/dgraph/protos/pb"
+	"github.com/dgraph-io/dgraph/types"
+	"github.com/dgraph-io/dgraph/x"
+)
+
+// The membership state of the cluster is exposed to queries as a read-only graph, made of the
+// predicates starting with dgraph.cluster. Groups, Alphas, Zeros and tablets are its nodes, with
+// uids of their own, which never clash with the uids of the data. The graph isn't stored: every
+// Alpha serves it from the membership state it last got from Zero, so it can be a few seconds
+// stale. It can only be queried from the default namespace.
+const clusterPrefix = "dgraph.cluster."
+
+// Kinds of the nodes of the cluster graph, stored in the top byte of their uids.
+const (
+	clusterGroupNode = iota + 0x80
+	clusterAlphaNode
+	clusterZeroNode
+	clusterTabletNode
+)
+
+// clusterPredicates are the predicates of the cluster graph, with their types. Those of type uid
+// link the nodes.
+var clusterPredicates = map[string]types.TypeID{
+	"group.id":          types.IntID,
+	"group.snapshot_ts": types.IntID,
+	"group.replicas":    types.IntID,
+	"group.leader":      types.UidID,
+	"group.members":     types.UidID,
+	"group.tablets":     types.UidID,
+
+	"member.id":          types.IntID,
+	"member.addr":        types.StringID,
+	"member.zero":        types.BoolID,
+	"member.leader":      types.BoolID,
+	"member.learner":     types.BoolID,
+	"member.last_update": types.IntID,
+	"member.group":       types.UidID,
+
+	"tablet.predicate": types.StringID,
+	"tablet.namespace": types.StringID,
+	"tablet.space":     types.IntID,
+	"tablet.read_only": types.BoolID,
+	"tablet.moving_to": types.IntID,
+	"tablet.diverged":  types.BoolID,
+	"tablet.replicas":  types.IntID,
+	"tablet.group":     types.UidID,
+	"tablet.copies":    types.UidID,
+}
+
+// clusterListPredicates are the uid predicates linking a node to many others.
+var clusterListPredicates = map[string]bool{"group.members": true, "group.tablets": true,
+	"tablet.copies": true}
+
+// isClusterAttr tells whether attr, as seen by the clients, is a predicate of the cluster graph.
+func isClusterAttr(attr string) bool {
+	return strings.HasPrefix(strings.TrimPrefix(attr, "~"), clusterPrefix)
+}
+
+func clusterUid(kind int, id uint64) uint64 {
+	return uint64(kind)<<56 | id&(1<<56-1)
+}
+
+// clusterGraph holds the values and the edges of the nodes of the cluster graph, by predicate.
+type clusterGraph struct {
+	values map[string]map[uint64]types.Val
+	edges  map[string]map[uint64][]uint64
+}
+
+func (g *clusterGraph) setValue(pred string, uid uint64, val interface{}) {
+	if g.values[pred] == nil {
+		g.values[pred] = make(map[uint64]types.Val)
+	}
+	g.values[pred][uid] = types.Val{Tid: clusterPredicates[pred], Value: val}
+}
+
+func (g *clusterGraph) addEdge(pred string, from, to uint64) {
+	if g.edges[pred] == nil {
+		g.edges[pred] = make(map[uint64][]uint64)
+	}
+	g.edges[pred][from] = append(g.edges[pred][from], to)
+}
+
+func (g *clusterGraph) addMember(uid uint64, m *pb.Member) {
+	g.setValue("member.id", uid, int64(m.Id))
+	g.setValue("member.addr", uid, m.Addr)
+	g.setValue("member.leader", uid, m.Leader)
+	g.setValue("member.learner", uid, m.Learner)
+	if m.LastUpdate > 0 {
+		g.setValue("member.last_update", uid, int64(m.LastUpdate))
+	}
+}
+
+// newClusterGraph returns the cluster graph of state.
+func newClusterGraph(state *pb.MembershipState) *clusterGraph {
+	g := &clusterGraph{
+		values: make(map[string]map[uint64]types.Val),
+		edges:  make(map[string]map[uint64][]uint64),
+	}
+	for gid, group := range state.GetGroups() {
+		guid := clusterUid(clusterGroupNode, uint64(gid))
+		g.setValue("group.id", guid, int64(gid))
+		g.setValue("group.snapshot_ts", guid, int64(group.SnapshotTs))
+		if group.Replicas > 0 {
+			g.setValue("group.replicas", guid, int64(group.Replicas))
+		}
+		for id, m := range group.Members {
+			muid := clusterUid(clusterAlphaNode, id)
+			g.addMember(muid, m)
+			g.setValue("member.zero", muid, false)
+			g.addEdge("group.members", guid, muid)
+			g.addEdge("member.group", muid, guid)
+			if m.Leader {
+				g.addEdge("group.leader", guid, muid)
+			}
+		}
+		for pred, tablet := range group.Tablets {
+			tuid := clusterUid(clusterTabletNode, farm.Fingerprint64([]byte(pred)))
+			ns, attr := x.ParseNamespaceAttr(pred)
+			g.setValue("tablet.predicate", tuid, attr)
+			if len(ns) > 0 {
+				g.setValue("tablet.namespace", tuid, ns)
+			}
+			g.setValue("tablet.space", tuid, tablet.Space)
+			g.setValue("tablet.read_only", tuid, tablet.ReadOnly)
+			g.setValue("tablet.diverged", tuid, tablet.Diverged)
+			if tablet.MovingTo > 0 {
+				g.setValue("tablet.moving_to", tuid, int64(tablet.MovingTo))
+			}
+			if tablet.Replicas > 0 {
+				g.setValue("tablet.replicas", tuid, int64(tablet.Replicas))
+			}
+			g.addEdge("group.tablets", guid, tuid)
+			g.addEdge("tablet.group", tuid, guid)
+			for _, c := range tablet.Copies {
+				g.addEdge("tablet.copies", tuid, clusterUid(clusterGroupNode, uint64(c.GroupId)))
+			}
+		}
+	}
+	for id, m := range state.GetZeros() {
+		zuid := clusterUid(clusterZeroNode, id)
+		g.addMember(zuid, m)
+		g.setValue("member.zero", zuid, true)
+	}
+	for _, edges := range g.edges {
+		for _, uids := range edges {
+			sort.Slice(uids, func(i, j int) bool { return uids[i] < uids[j] })
+		}
+	}
+	return g
+}
+
+// nodes returns the sorted uids of the nodes having pred.
+func (g *clusterGraph) nodes(pred string) []uint64 {
+	var uids []uint64
+	for uid := range g.values[pred] {
+		uids = append(uids, uid)
+	}
+	for uid := range g.edges[pred] {
+		uids = append(uids, uid)
+	}
+	sort.Slice(uids, func(i, j int) bool { return uids[i] < uids[j] })
+	return uids
+}
+
+// match returns the sorted uids of the nodes having pred, with a value satisfying the comparison
+// fname with any of args.
+func (g *clusterGraph) match(pred, fname string, args []string) ([]uint64, error) {
+	typ := clusterPredicates[pred]
+	var refs []types.Val
+	for _, arg := range args {
+		ref, err := types.Convert(types.Val{Tid: types.StringID, Value: []byte(arg)}, typ)
+		if err != nil {
+			return nil, err
+		}
+		refs = append(refs, ref)
+	}
+	var uids []uint64
+	for _, uid := range g.nodes(pred) {
+		for _, ref := range refs {
+			if types.CompareVals(fname, g.values[pred][uid], ref) {
+				uids = append(uids, uid)
+				break
+			}
+		}
+	}
+	return uids, nil
+}
+
+// processClusterTask answers the query of a predicate of the cluster graph.
+func processClusterTask(ctx context.Context, q *pb.Query) (*pb.Result, error) {
+	if ns := x.NamespaceFromContext(ctx); len(ns) > 0 {
+		return nil, x.Errorf("Cluster metadata can't be queried from namespace %q", ns)
+	}
+	if q.Reverse || strings.HasPrefix(q.Attr, "~") {
+		return nil, x.Errorf("Predicate %s doesn't have reverse edge", q.Attr)
+	}
+	pred := strings.TrimPrefix(q.Attr, clusterPrefix)
+	typ, ok := clusterPredicates[pred]
+	if !ok {
+		return nil, x.Errorf("Unknown cluster predicate %s", q.Attr)
+	}
+
+	gr := groups()
+	gr.RLock()
+	g := newClusterGraph(gr.state)
+	gr.RUnlock()
+
+	out := &pb.Result{List: clusterListPredicates[pred]}
+	fname := strings.ToLower(q.SrcFunc.GetName())
+	if len(fname) == 0 {
+		for _, uid := range q.UidList.GetUids() {
+			if typ == types.UidID {
+				uids := g.edges[pred][uid]
+				if q.DoCount {
+					out.Counts = append(out.Counts, uint32(len(uids)))
+					uids = nil
+				}
+				out.UidMatrix = append(out.UidMatrix, &pb.List{Uids: uids})
+				continue
+			}
+			val, ok := g.values[pred][uid]
+			out.UidMatrix = append(out.UidMatrix, &emptyUIDList)
+			switch {
+			case q.DoCount && ok:
+				out.Counts = append(out.Counts, 1)
+			case q.DoCount:
+				out.Counts = append(out.Counts, 0)
+			case ok:
+				tv, err := convertToType(val, typ)
+				if err != nil {
+					return nil, err
+				}
+				out.ValueMatrix = append(out.ValueMatrix, &pb.ValueList{Values: []*pb.TaskValue{tv}})
+			default:
+				out.ValueMatrix = append(out.ValueMatrix, &emptyValueList)
+			}
+		}
+		return out, nil
+	}
+
+	var uids []uint64
+	switch fname {
+	case "has":
+		uids = g.nodes(pred)
+	case "eq", "le", "lt", "ge", "gt":
+		if typ == types.UidID {
+			return nil, x.Errorf("Function %s can't be applied to uid predicate %s", fname, q.Attr)
+		}
+		var err error
+		if uids, err = g.match(pred, fname, q.SrcFunc.Args); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, x.Errorf("Function %s isn't supported on cluster predicate %s", fname, q.Attr)
+	}
+	list := &pb.List{Uids: uids}
+	if q.UidList != nil {
+		// It's a filter.
+		filtered := &pb.List{}
+		algo.IntersectWith(list, q.UidList, filtered)
+		list = filtered
+	}
+	out.UidMatrix = append(out.UidMatrix, list)
+	return out, nil
+}
+
+// checkClusterAttrs rejects mutations of the predicates of the cluster graph.
+func checkClusterAttrs(m *pb.Mutations) error {
+	for _, edge := range m.Edges {
+		if isClusterAttr(edge.Attr) {
+			return x.Errorf("Predicate %s is read-only cluster metadata", edge.Attr)
+		}
+	}
+	for _, su := range m.Schema {
+		if isClusterAttr(su.Predicate) {
+			return x.Errorf("Predicate %s is read-only cluster metadata", su.Predicate)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,121 @@
+/*
+ * Copyright 2018 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package worker
+
+import (
+	"testing"
+
+	"github.com/dgraph-io/dgraph/protos/pb"
+	"github.com/dgraph-io/dgraph/x"
+	"github.com/stretchr/testify/require"
+)
+
+func testClusterState() *pb.MembershipState {
+	return &pb.MembershipState{
+		Groups: map[uint32]*pb.Group{
+			1: {
+				Members: map[uint64]*pb.Member{
+					1: {Id: 1, GroupId: 1, Addr: "alpha1:7080", Leader: true},
+					2: {Id: 2, GroupId: 1, Addr: "alpha2:7080"},
+				},
+				Tablets: map[string]*pb.Tablet{
+					"name": {GroupId: 1, Predicate: "name", Space: 100, Replicas: 5,
+						Copies: []*pb.TabletCopy{{GroupId: 2}}},
+					x.NamespaceAttr("acme", "age"): {GroupId: 1, Space: 10, ReadOnly: true},
+				},
+			},
+			2: {
+				Members: map[uint64]*pb.Member{3: {Id: 3, GroupId: 2, Addr: "alpha3:7080"}},
+			},
+		},
+		Zeros: map[uint64]*pb.Member{1: {Id: 1, Addr: "zero1:5080", Leader: true}},
+	}
+}
+
+func TestClusterGraph(t *testing.T) {
+	g := newClusterGraph(testClusterState())
+	group1 := clusterUid(clusterGroupNode, 1)
+	group2 := clusterUid(clusterGroupNode, 2)
+	require.Equal(t, []uint64{group1, group2}, g.nodes("group.id"))
+
+	alpha1, alpha2 := clusterUid(clusterAlphaNode, 1), clusterUid(clusterAlphaNode, 2)
+	require.Equal(t, []uint64{alpha1, alpha2}, g.edges["group.members"][group1])
+	require.Equal(t, []uint64{alpha1}, g.edges["group.leader"][group1])
+	require.Equal(t, []uint64{group1}, g.edges["member.group"][alpha2])
+	require.Len(t, g.edges["group.tablets"][group1], 2)
+	require.Empty(t, g.edges["group.tablets"][group2])
+
+	// Alphas and Zeros with the same Raft ID are different nodes.
+	zero1 := clusterUid(clusterZeroNode, 1)
+	require.NotEqual(t, alpha1, zero1)
+	require.Equal(t, true, g.values["member.zero"][zero1].Value)
+	require.Equal(t, false, g.values["member.zero"][alpha1].Value)
+	require.Equal(t, "zero1:5080", g.values["member.addr"][zero1].Value)
+
+	// Tablets are shown with the names of their predicates in their namespace.
+	tablets := g.nodes("tablet.namespace")
+	require.Len(t, tablets, 1)
+	require.Equal(t, "age", g.values["tablet.predicate"][tablets[0]].Value)
+	require.Equal(t, "acme", g.values["tablet.namespace"][tablets[0]].Value)
+
+	// Copies of tablets link them to the groups holding them.
+	tablets = g.nodes("tablet.replicas")
+	require.Len(t, tablets, 1)
+	require.Equal(t, int64(5), g.values["tablet.replicas"][tablets[0]].Value)
+	require.Equal(t, []uint64{group2}, g.edges["tablet.copies"][tablets[0]])
+
+	// None of the nodes can have the uid of data.
+	for _, uid := range append(g.nodes("member.id"), g.nodes("tablet.predicate")...) {
+		require.True(t, uid >= 1<<63)
+	}
+}
+
+func TestClusterGraphMatch(t *testing.T) {
+	g := newClusterGraph(testClusterState())
+	uids, err := g.match("group.id", "ge", []string{"2"})
+	require.NoError(t, err)
+	require.Equal(t, []uint64{clusterUid(clusterGroupNode, 2)}, uids)
+
+	uids, err = g.match("member.addr", "eq", []string{"alpha1:7080", "alpha3:7080"})
+	require.NoError(t, err)
+	require.Equal(t, []uint64{clusterUid(clusterAlphaNode, 1), clusterUid(clusterAlphaNode, 3)},
+		uids)
+
+	uids, err = g.match("tablet.read_only", "eq", []string{"true"})
+	require.NoError(t, err)
+	require.Len(t, uids, 1)
+	require.Equal(t, "age", g.values["tablet.predicate"][uids[0]].Value)
+
+	_, err = g.match("group.id", "eq", []string{"one"})
+	require.Error(t, err)
+}
+
+func TestCheckClusterAttrs(t *testing.T) {
+	require.True(t, isClusterAttr("dgraph.cluster.group.id"))
+	require.True(t, isClusterAttr("~dgraph.cluster.member.group"))
+	require.False(t, isClusterAttr("dgraph.clusters"))
+
+	require.NoError(t, checkClusterAttrs(&pb.Mutations{
+		Edges: []*pb.DirectedEdge{{Attr: "name"}},
+	}))
+	require.Error(t, checkClusterAttrs(&pb.Mutations{
+		Edges: []*pb.DirectedEdge{{Attr: "dgraph.cluster.member.addr"}},
+	}))
+	require.Error(t, checkClusterAttrs(&pb.Mutations{
+		Schema: []*pb.SchemaUpdate{{Predicate: "dgraph.cluster.group.id"}},
+	}))
+}
@@ -15,7 +15,13 @@
  */
 package worker
 
-import "net"
+import (
+	"net"
+	"sync/atomic"
+	"time"
+
+	"github.com/dgraph-io/dgraph/x"
+)
 
 type IPRange struct {
 	Lower, Upper net.IP
@@ -32,6 +38,88 @@ type Options struct {
 	ExpandEdge          bool
 	WhiteListedIPRanges []IPRange
 	MaxRetries          int
+	// DropGrace is how long dropped predicates are kept around as tombstones.
+	DropGrace time.Duration
+	// GossipInterval is how often the members of a group gossip their health. Zero disables it.
+	GossipInterval time.Duration
+	// Learner makes this Alpha replicate a group without voting in it.
+	Learner bool
+	// Spare makes this Alpha wait to replace a dead member of a group, instead of forming a new one.
+	Spare bool
+	// GroupReplicas is the number of replicas of the group this Alpha forms or joins, if not those
+	// of Zero.
+	GroupReplicas int
+	// ZeroFollowerReads makes this Alpha stream the membership state from any Zero, instead of
+	// the leader.
+	ZeroFollowerReads bool
+	// PostingDir and WALDir hold the postings and the Raft logs.
+	PostingDir string
+	WALDir     string
+	// SnapshotLogBytes and SnapshotMaxInterval tune when the Raft logs are snapshotted.
+	SnapshotLogBytes    uint64
+	SnapshotMaxInterval time.Duration
+	// SnapshotRate limits the bytes per second this Alpha streams snapshots at. Zero means
+	// unlimited.
+	SnapshotRate int64
+	// MutationQueue bounds the proposals waiting for room among the NumPendingProposals, and
+	// MutationQueueTimeout how long they wait. Those beyond are turned away. Zero means unbounded.
+	MutationQueue        int
+	MutationQueueTimeout time.Duration
+	// MutationBatch is the most small mutations proposed together. One disables batching.
+	MutationBatch int
+	// DebugConflicts makes the transactions aborted because of a conflict tell what they
+	// conflicted on.
+	DebugConflicts bool
+	// TxnTTL is how long a transaction can stay idle before it's aborted. Zero means forever.
+	TxnTTL time.Duration
+	// HistoryRetention is how long the past versions of the data are kept for, to be queried as of
+	// a past timestamp. Zero keeps them only as long as needed by the transactions.
+	HistoryRetention time.Duration
 }
 
 var Config Options
+
+// Tunables returns the options of the worker which can be changed at runtime.
+func Tunables() []x.Tunable {
+	return []x.Tunable{
+		x.IntTunable("index_build_rate",
+			func() int64 { return atomic.LoadInt64(&indexBuilds.rate) },
+			func(rate int64) error {
+				if rate < 0 {
+					return x.Errorf("index_build_rate can't be negative")
+				}
+				return nil
+			},
+			func(rate int64) { x.Check(SetIndexBuildRate(rate)) }),
+		{
+			Name: "rollup_window",
+			Get: func() interface{} {
+				windows, _ := rollups.scheduleStrings()
+				return windows
+			},
+			Check: func(val string) (func(), error) {
+				ws, err := parseWindows(val)
+				return func() {
+					rollups.Lock()
+					defer rollups.Unlock()
+					rollups.windows = ws
+				}, err
+			},
+		},
+		{
+			Name: "rollup_priority",
+			Get: func() interface{} {
+				_, priorities := rollups.scheduleStrings()
+				return priorities
+			},
+			Check: func(val string) (func(), error) {
+				prios, err := parsePriorities(val)
+				return func() {
+					rollups.Lock()
+					defer rollups.Unlock()
+					rollups.prios = prios
+				}, err
+			},
+		},
+	}
+}
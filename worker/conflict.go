@@ -0,0 +1,107 @@
+/*
+ * Copyright 2018 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package worker
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/dgraph-io/dgo/y"
+	"github.com/dgraph-io/dgraph/posting"
+	"github.com/dgraph-io/dgraph/x"
+	"google.golang.org/grpc/metadata"
+)
+
+// ConflictError is returned instead of y.ErrAborted with --debug_conflicts, for the transactions
+// Zero aborted because of a conflict. It tells what the transaction conflicted on.
+type ConflictError struct {
+	// Key describes the conflicting key, or is its fingerprint if this Alpha doesn't know the key.
+	Key string
+	// Pred is the predicate of the key, if known.
+	Pred string
+	// CommitTs is the commit timestamp of the transaction which last wrote the key. Zero means the
+	// transaction started before the current leader of Zero took over.
+	CommitTs uint64
+}
+
+func (e *ConflictError) Error() string {
+	switch {
+	case e.CommitTs == 0:
+		return fmt.Sprintf("%s It started before the current leader of Zero took over.",
+			y.ErrAborted)
+	case len(e.Pred) > 0:
+		return fmt.Sprintf("%s Conflict on %s of predicate %s, with the transaction committed"+
+			" at %d.", y.ErrAborted, e.Key, e.Pred, e.CommitTs)
+	default:
+		return fmt.Sprintf("%s Conflict on key %s, with the transaction committed at %d.",
+			y.ErrAborted, e.Key, e.CommitTs)
+	}
+}
+
+// IsAborted tells whether err means the transaction was aborted.
+func IsAborted(err error) bool {
+	if _, ok := err.(*ConflictError); ok {
+		return true
+	}
+	return err == y.ErrAborted
+}
+
+// conflictError returns the ConflictError of the transaction started at startTs, from the
+// trailer of Zero's reply to it committing. It returns nil if Zero didn't report a conflict.
+func conflictError(startTs uint64, trailer metadata.MD) error {
+	keys, tss := trailer.Get("conflict-key"), trailer.Get("conflict-commit-ts")
+	if len(keys) == 0 || len(tss) == 0 {
+		return nil
+	}
+	commitTs, err := strconv.ParseUint(tss[0], 10, 64)
+	if err != nil {
+		return nil
+	}
+	e := &ConflictError{Key: keys[0], CommitTs: commitTs}
+	// The mutations this Alpha ran tell what the fingerprint stands for.
+	if txn := posting.Oracle().GetTxn(startTs); txn != nil {
+		if key, ok := txn.ConflictKey(e.Key); ok {
+			e.Key, e.Pred = describeConflictKey(key)
+		}
+	}
+	return e
+}
+
+// describeConflictKey describes a conflict key, made of a key and a uid by posting.List.
+func describeConflictKey(key string) (desc, pred string) {
+	idx := strings.LastIndexByte(key, '|')
+	if idx < 0 {
+		return fmt.Sprintf("%q", key), ""
+	}
+	uid, err := strconv.ParseUint(key[idx+1:], 10, 64)
+	pk := x.Parse([]byte(key[:idx]))
+	if err != nil || pk == nil {
+		return fmt.Sprintf("%q", key), ""
+	}
+	switch {
+	case pk.IsData() && uid > 0:
+		return fmt.Sprintf("the edge <%#x> to <%#x>", pk.Uid, uid), pk.Attr
+	case pk.IsData():
+		return fmt.Sprintf("the value of <%#x>", pk.Uid), pk.Attr
+	case pk.IsIndex() && len(pk.Term) > 0:
+		// The first byte of the term is the identifier of the tokenizer.
+		return fmt.Sprintf("the index token %q", pk.Term[1:]), pk.Attr
+	default:
+		return fmt.Sprintf("%q", key[:idx]), pk.Attr
+	}
+}
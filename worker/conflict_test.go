@@ -0,0 +1,62 @@
+/*
+ * Copyright 2018 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package worker
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/dgraph-io/dgo/y"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/dgraph-io/dgraph/x"
+)
+
+func TestDescribeConflictKey(t *testing.T) {
+	tests := []struct {
+		key  string
+		desc string
+	}{
+		{fmt.Sprintf("%s|%d", x.DataKey("friend", 0x1), 0x2), "the edge <0x1> to <0x2>"},
+		{fmt.Sprintf("%s|0", x.DataKey("name", 0xa)), "the value of <0xa>"},
+		{fmt.Sprintf("%s|0", x.IndexKey("email", "\x01a@b.org")), `the index token "a@b.org"`},
+	}
+	for _, tc := range tests {
+		desc, pred := describeConflictKey(tc.key)
+		require.Equal(t, tc.desc, desc)
+		require.NotEmpty(t, pred)
+	}
+	desc, pred := describeConflictKey("garbage")
+	require.Equal(t, `"garbage"`, desc)
+	require.Empty(t, pred)
+}
+
+func TestConflictError(t *testing.T) {
+	require.Nil(t, conflictError(1, nil))
+
+	err := conflictError(1, metadata.Pairs("conflict-key", "2d9s6d", "conflict-commit-ts", "7"))
+	require.Equal(t, &ConflictError{Key: "2d9s6d", CommitTs: 7}, err)
+	require.Equal(t, y.ErrAborted.Error()+
+		" Conflict on key 2d9s6d, with the transaction committed at 7.", err.Error())
+	require.True(t, IsAborted(err))
+	require.True(t, IsAborted(y.ErrAborted))
+	require.False(t, IsAborted(nil))
+
+	err = &ConflictError{Key: "the value of <0xa>", Pred: "name", CommitTs: 7}
+	require.Contains(t, err.Error(), "Conflict on the value of <0xa> of predicate name")
+}
@@ -0,0 +1,57 @@
+/*
+ * Copyright 2018 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package worker
+
+import "github.com/dgraph-io/dgraph/protos/pb"
+
+// A tablet wanting more replicas than the group serving it has is copied by Zero to other groups,
+// which then hold all of its data too. Mutations and schema updates go to all these groups, and
+// queries to the group serving the tablet, or to a copy if that group can't be reached. The
+// Alphas holding a copy read it locally. The copies are in the membership state, along with the
+// tablet.
+
+// holdsCopy tells whether gid holds a copy of tab, rather than serving it.
+func holdsCopy(tab *pb.Tablet, gid uint32) bool {
+	for _, c := range tab.Copies {
+		if c.GroupId == gid {
+			return true
+		}
+	}
+	return false
+}
+
+// copyGroups returns the groups holding a copy of tab.
+func copyGroups(tab *pb.Tablet) []uint32 {
+	var gids []uint32
+	for _, c := range tab.Copies {
+		gids = append(gids, c.GroupId)
+	}
+	return gids
+}
+
+// HoldsTabletCopy tells whether this group holds a copy of the tablet.
+func (g *groupi) HoldsTabletCopy(key string) bool {
+	tablet := g.Tablet(key)
+	return tablet != nil && holdsCopy(tablet, g.groupId())
+}
+
+// OwnsTablet tells whether this group serves the tablet, or a part of it if it's split, rather
+// than only holding a copy. Only the owner exports a tablet.
+func (g *groupi) OwnsTablet(key string) bool {
+	tablet := g.Tablet(key)
+	return tablet != nil && servedBy(tablet, g.groupId()) && !holdsCopy(tablet, g.groupId())
+}
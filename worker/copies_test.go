@@ -0,0 +1,49 @@
+/*
+ * Copyright 2018 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package worker
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dgraph-io/dgraph/protos/pb"
+	"github.com/dgraph-io/dgraph/x"
+)
+
+func TestTabletCopies(t *testing.T) {
+	tab := &pb.Tablet{GroupId: 1, Copies: []*pb.TabletCopy{{GroupId: 3}}}
+	require.False(t, holdsCopy(tab, 1))
+	require.True(t, holdsCopy(tab, 3))
+
+	// The groups holding a copy hold the whole tablet, as its group does.
+	for _, gid := range []uint32{1, 3} {
+		r, ok := tabletShard(tab, gid)
+		require.True(t, ok)
+		require.Nil(t, r)
+	}
+	_, ok := tabletShard(tab, 2)
+	require.False(t, ok)
+
+	// All the edges are applied by the copies too.
+	require.Equal(t, []uint32{1, 3}, tabletGroups(tab))
+	require.Equal(t, []uint32{1, 3}, edgeGroups(tab, &pb.DirectedEdge{Entity: 7, Attr: "name"}))
+	require.Equal(t, []uint32{1, 3},
+		edgeGroups(tab, &pb.DirectedEdge{Attr: "name", Value: []byte(x.Star)}))
+	require.True(t, servesEdge(tab, &pb.DirectedEdge{Entity: 7}, 3))
+	require.False(t, servesEdge(tab, &pb.DirectedEdge{Entity: 7}, 2))
+}
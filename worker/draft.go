@@ -29,11 +29,14 @@ import (
 	"github.com/coreos/etcd/raft"
 	"github.com/coreos/etcd/raft/raftpb"
 	otrace "go.opencensus.io/trace"
+	"go.opencensus.io/trace/propagation"
 
 	"github.com/dgraph-io/badger"
 	"github.com/dgraph-io/badger/y"
 	dy "github.com/dgraph-io/dgo/y"
+	"github.com/dgraph-io/dgraph/changes"
 	"github.com/dgraph-io/dgraph/conn"
+	"github.com/dgraph-io/dgraph/fault"
 	"github.com/dgraph-io/dgraph/posting"
 	"github.com/dgraph-io/dgraph/protos/pb"
 	"github.com/dgraph-io/dgraph/raftwal"
@@ -60,9 +63,14 @@ type node struct {
 	lastCommitTs uint64 // Only used to ensure that our commit Ts is monotonically increasing.
 
 	streaming int32 // Used to avoid calculating snapshot
+	// Only used by Run, to space out the snapshots proposed by the leader.
+	lastSnapshot time.Time
 
 	canCampaign bool
 	elog        trace.EventLog
+
+	// batchCh takes the proposals to batch, if Config.MutationBatch is over one.
+	batchCh chan *batchedProposal
 }
 
 // Now that we apply txn updates via Raft, waiting based on Txn timestamps is
@@ -72,9 +80,10 @@ func newNode(store *raftwal.DiskStorage, gid uint32, id uint64, myAddr string) *
 	glog.Infof("Node ID: %v with GroupID: %v\n", id, gid)
 
 	rc := &pb.RaftContext{
-		Addr:  myAddr,
-		Group: gid,
-		Id:    id,
+		Addr:      myAddr,
+		Group:     gid,
+		Id:        id,
+		IsLearner: Config.Learner,
 	}
 	m := conn.NewNode(rc, store)
 
@@ -88,7 +97,11 @@ func newNode(store *raftwal.DiskStorage, gid uint32, id uint64, myAddr string) *
 		applyCh:  make(chan []*pb.Proposal, 1000),
 		rollupCh: make(chan uint64, 3),
 		elog:     trace.NewEventLog("Dgraph", "ApplyCh"),
-		closer:   y.NewCloser(3), // Matches CLOSER:1
+		closer:   y.NewCloser(4), // Matches CLOSER:1
+	}
+	if Config.MutationBatch > 1 {
+		n.batchCh = make(chan *batchedProposal, Config.MutationBatch)
+		go n.batchProposals()
 	}
 	return n
 }
@@ -159,15 +172,26 @@ func detectPendingTxns(attr string) error {
 // Wait for all transactions to either abort or complete and all write transactions
 // involving the predicate are aborted until schema mutations are done.
 func (n *node) applyMutations(ctx context.Context, proposal *pb.Proposal) error {
-	span := otrace.FromContext(ctx)
+	ctx, span := otrace.StartSpan(ctx, "node.applyMutations")
+	defer span.End()
+	span.AddAttributes(otrace.Int64Attribute("edges", int64(len(proposal.Mutations.Edges))),
+		otrace.Int64Attribute("schema", int64(len(proposal.Mutations.Schema))))
 
 	if proposal.Mutations.DropAll {
 		// Ensures nothing get written to disk due to commit proposals.
 		posting.Oracle().ResetTxns()
+		indexBuilds.abort()
 		schema.State().DeleteAll()
+		defer invalidations.changed()
 		return posting.DeleteAll()
 	}
 
+	if ns := proposal.Mutations.DropNamespace; len(ns) > 0 {
+		span.Annotatef(nil, "Dropping namespace: %s", ns)
+		defer invalidations.changed()
+		return n.dropNamespace(ctx, ns)
+	}
+
 	if proposal.Mutations.StartTs == 0 {
 		return errors.New("StartTs must be provided.")
 	}
@@ -184,14 +208,19 @@ func (n *node) applyMutations(ctx context.Context, proposal *pb.Proposal) error
 			// would have proposed membershipstate, and all nodes would have the proposed state
 			// or some state after that before reaching here.
 			if tablet := groups().Tablet(supdate.Predicate); tablet != nil && tablet.ReadOnly {
-				return errPredicateMoving
+				return errTabletMoving(tablet)
+			}
+			if err := indexBuilds.settle(supdate.Predicate); err != nil {
+				return err
 			}
 			if err := detectPendingTxns(supdate.Predicate); err != nil {
 				return err
 			}
-			if err := runSchemaMutation(ctx, supdate, startTs); err != nil {
+			if err := runSchemaMutation(ctx, supdate, startTs, proposal.Index,
+				proposal.Mutations.BackgroundIndex); err != nil {
 				return err
 			}
+			invalidations.changed(supdate.Predicate)
 		}
 		return nil
 	}
@@ -208,7 +237,7 @@ func (n *node) applyMutations(ctx context.Context, proposal *pb.Proposal) error
 	for _, edge := range proposal.Mutations.Edges {
 		if tablet := groups().Tablet(edge.Attr); tablet != nil && tablet.ReadOnly {
 			span.Annotatef(nil, "Tablet moving: %+v. Retry later.", tablet)
-			return errPredicateMoving
+			return errTabletMoving(tablet)
 		}
 		if edge.Entity == 0 && bytes.Equal(edge.Value, []byte(x.Star)) {
 			// We should only drop the predicate if there is no pending
@@ -217,6 +246,23 @@ func (n *node) applyMutations(ctx context.Context, proposal *pb.Proposal) error
 				span.Annotatef(nil, "Found pending transactions. Retry later.")
 				return err
 			}
+			indexBuilds.abort(edge.Attr)
+			defer invalidations.changed(edge.Attr)
+			// The parts of a split tablet are deleted right away, as a tombstone is only ever
+			// served by a single group.
+			if tombstone := proposal.Mutations.Tombstone; len(tombstone) > 0 &&
+				!isSplit(groups().Tablet(edge.Attr)) {
+				span.Annotatef(nil, "Dropping predicate: %s to %s", edge.Attr, tombstone)
+				if err := posting.RenamePredicate(ctx, edge.Attr, tombstone); err != nil {
+					return err
+				}
+				// Zero must know the group serving the tombstone, so it can be renamed back.
+				// Should this fail, the tombstone is registered when it's next purged.
+				if tablet := groups().Tablet(tombstone); tablet == nil {
+					glog.Warningf("Unable to register dropped predicate %s with Zero", tombstone)
+				}
+				return nil
+			}
 			span.Annotatef(nil, "Deleting predicate: %s", edge.Attr)
 			return posting.DeletePredicate(ctx, edge.Attr)
 		}
@@ -245,6 +291,9 @@ func (n *node) applyMutations(ctx context.Context, proposal *pb.Proposal) error
 
 	m := proposal.Mutations
 	txn := posting.Oracle().RegisterStartTs(m.StartTs)
+	if m.KeepAlive {
+		txn.SetKeepAlive()
+	}
 	if txn.ShouldAbort() {
 		span.Annotatef(nil, "Txn %d should abort.", m.StartTs)
 		return dy.ErrConflict
@@ -270,9 +319,46 @@ func (n *node) applyMutations(ctx context.Context, proposal *pb.Proposal) error
 	return nil
 }
 
+// proposalKind names the kind of a proposal, for the faults injected when applying it.
+func proposalKind(p *pb.Proposal) string {
+	switch {
+	case p.Mutations != nil:
+		return "mutations"
+	case len(p.Kv) > 0:
+		return "kv"
+	case p.State != nil:
+		return "state"
+	case len(p.CleanPredicate) > 0:
+		return "clean_predicate"
+	case p.Rename != nil:
+		return "rename"
+	case p.CleanShard != nil:
+		return "clean_shard"
+	case p.IndexBuilt != nil:
+		return "index_built"
+	case p.Delta != nil:
+		return "delta"
+	case p.Snapshot != nil:
+		return "snapshot"
+	}
+	return "unknown"
+}
+
 func (n *node) applyCommitted(proposal *pb.Proposal) error {
+	if fault.Enabled {
+		labels := []string{fmt.Sprintf("group=%d", n.gid), "kind=" + proposalKind(proposal),
+			"key=" + proposal.Key}
+		fault.Inject(fault.ApplyBefore, labels...)
+		defer fault.Inject(fault.ApplyAfter, labels...)
+	}
 	ctx := n.Ctx(proposal.Key)
-	ctx, span := otrace.StartSpan(ctx, "node.applyCommitted")
+	var span *otrace.Span
+	if sc, ok := propagation.FromBinary(proposal.TraceContext); ok && otrace.FromContext(ctx) == nil {
+		// Proposed by another node, whose trace this continues.
+		ctx, span = otrace.StartSpanWithRemoteParent(ctx, "node.applyCommitted", sc)
+	} else {
+		ctx, span = otrace.StartSpan(ctx, "node.applyCommitted")
+	}
 	defer span.End()
 	span.Annotatef(nil, "Node id: %d. Group id: %d. Got proposal key: %s",
 		n.Id, n.gid, proposal.Key)
@@ -301,8 +387,21 @@ func (n *node) applyCommitted(proposal *pb.Proposal) error {
 
 	case len(proposal.CleanPredicate) > 0:
 		n.elog.Printf("Cleaning predicate: %s", proposal.CleanPredicate)
+		indexBuilds.abort(proposal.CleanPredicate)
 		return posting.DeletePredicate(ctx, proposal.CleanPredicate)
 
+	case proposal.Rename != nil:
+		n.elog.Printf("Renaming predicate: %s to %s", proposal.Rename.From, proposal.Rename.To)
+		return n.applyRename(ctx, proposal.Rename)
+
+	case proposal.CleanShard != nil:
+		n.elog.Printf("Cleaning split predicate: %s", proposal.CleanShard.Predicate)
+		return n.applyCleanShard(ctx, proposal.CleanShard)
+
+	case proposal.IndexBuilt != nil:
+		n.elog.Printf("Applying end of index build: %+v", proposal.IndexBuilt)
+		return n.finishIndexBuild(proposal.IndexBuilt)
+
 	case proposal.Delta != nil:
 		n.elog.Printf("Applying Oracle Delta for key: %s", proposal.Key)
 		return n.commitOrAbort(proposal.Key, proposal.Delta)
@@ -346,24 +445,50 @@ func (n *node) processRollups() {
 	tick := time.NewTicker(5 * time.Minute) // Rolling up once every 5 minutes seems alright.
 	defer tick.Stop()
 
-	var readTs, last uint64
+	// last is the read ts of the last full rollup, and lastHigh of the last one of the predicates
+	// of high priority, which can run outside of the rollup windows.
+	var readTs, last, lastHigh uint64
 	for {
+		var triggered bool
 		select {
 		case <-n.closer.HasBeenClosed():
 			return
 		case readTs = <-n.rollupCh:
+			history.record(fault.Now(), readTs)
+			continue
+		case <-rollups.runCh:
+			triggered = true
 		case <-tick.C:
-			if readTs <= last {
-				break // Break out of the select case.
-			}
-			if err := n.rollupLists(readTs); err != nil {
+		}
+
+		full, high := rollups.plan(time.Now(), triggered)
+		switch {
+		case full && readTs > last:
+			start := time.Now()
+			rollups.start()
+			err := n.rollupLists(readTs, rollups.order(false), true)
+			rollups.done(readTs, true, start, err)
+			if err != nil {
 				// If we encounter error here, we don't need to do anything about
 				// it. Just let the user know.
 				glog.Errorf("Error while rolling up lists at %d: %v\n", readTs, err)
 			} else {
-				last = readTs // Update last only if we succeeded.
+				last, lastHigh = readTs, readTs // Update last only if we succeeded.
 				glog.Infof("List rollup at Ts %d: OK.\n", readTs)
 			}
+		case high && readTs > lastHigh:
+			start := time.Now()
+			rollups.start()
+			err := n.rollupLists(readTs, rollups.order(true), false)
+			rollups.done(readTs, false, start, err)
+			if err != nil {
+				glog.Errorf("Error while rolling up high priority lists at %d: %v\n", readTs, err)
+			} else {
+				lastHigh = readTs
+				glog.Infof("List rollup of high priority predicates at Ts %d: OK.\n", readTs)
+			}
+		case triggered:
+			glog.Infof("Rollup triggered, but lists are rolled up at Ts %d already.\n", last)
 		}
 	}
 }
@@ -453,7 +578,20 @@ func (n *node) commitOrAbort(pkey string, delta *pb.OracleDelta) error {
 		}
 	}
 
+	var attrs []string
+	var changed []*changes.Event
+	publish := changeStream != nil && n.AmLeader()
+	var maxCommitTs uint64
 	for _, status := range delta.Txns {
+		if status.CommitTs > 0 {
+			if txn := posting.Oracle().GetTxn(status.StartTs); txn != nil {
+				attrs = append(attrs, txn.Attrs()...)
+				if publish {
+					changed = append(changed, committedChanges(txn, status.CommitTs)...)
+				}
+			}
+			maxCommitTs = x.Max(maxCommitTs, status.CommitTs)
+		}
 		if status.CommitTs > 0 && status.CommitTs < n.lastCommitTs {
 			glog.Errorf("Lastcommit %d > current %d. This would cause some commits to be lost.",
 				n.lastCommitTs, status.CommitTs)
@@ -484,8 +622,15 @@ func (n *node) commitOrAbort(pkey string, delta *pb.OracleDelta) error {
 	for _, txn := range delta.Txns {
 		toMemory(txn.StartTs, txn.CommitTs)
 	}
+	// The caches learn about the commits before their reads are serviced, see InvalidatedUpTo.
+	if len(attrs) > 0 {
+		invalidations.committed(attrs, maxCommitTs)
+	}
 	// Now advance Oracle(), so we can service waiting reads.
 	posting.Oracle().ProcessDelta(delta)
+	if publish {
+		changeStream.Publish(changed)
+	}
 	return nil
 }
 
@@ -563,6 +708,11 @@ func (n *node) retrieveSnapshot(snap pb.Snapshot) error {
 	if _, err := n.populateSnapshot(snap, pstore, pool); err != nil {
 		return fmt.Errorf("Cannot retrieve snapshot from peer, error: %v\n", err)
 	}
+	// The snapshot only has the latest versions as of its read ts.
+	posting.RaiseHistoryTs(snap.ReadTs)
+	if err := n.Store.SetHistoryTs(posting.HistoryTs()); err != nil {
+		return fmt.Errorf("Error while storing the history ts: %v", err)
+	}
 	// Populate shard stores the streamed data directly into db, so we need to refresh
 	// schema for current group id
 	if err := schema.LoadFromDb(); err != nil {
@@ -572,17 +722,36 @@ func (n *node) retrieveSnapshot(snap pb.Snapshot) error {
 	return nil
 }
 
-func (n *node) proposeSnapshot(discardN int) error {
-	snap, err := n.calculateSnapshot(discardN)
-	if err != nil || snap == nil {
+func (n *node) proposeSnapshot() error {
+	snap, stats, err := n.computeSnapshot(minSnapshotEntries)
+	if err != nil {
 		return err
 	}
+	stats.sinceLast = time.Since(n.lastSnapshot)
+	stats.diskFree = walDiskFree()
+	if stats.blocked != "streaming" {
+		x.RaftLogBytes.Set(int64(stats.logBytes))
+	}
+
+	policy := snapshotPolicy{
+		logBytes:    Config.SnapshotLogBytes,
+		maxInterval: Config.SnapshotMaxInterval,
+	}
+	take, reason := policy.decide(stats)
+	x.SnapshotDecisions.Add(reason, 1)
+	if !take {
+		return nil
+	}
 	proposal := &pb.Proposal{
 		Snapshot: snap,
 	}
-	n.elog.Printf("Proposing snapshot: %+v\n", snap)
+	n.elog.Printf("Proposing snapshot: %+v. Reason: %s\n", snap, reason)
 	data, err := proposal.Marshal()
 	x.Check(err)
+	if !n.lastSnapshot.IsZero() {
+		x.SnapshotInterval.Set(int64(stats.sinceLast.Seconds()))
+	}
+	n.lastSnapshot = time.Now()
 	return n.Raft().Propose(n.ctx, data)
 }
 
@@ -598,6 +767,9 @@ func (n *node) Run() {
 	slowTicker := time.NewTicker(30 * time.Second)
 	defer slowTicker.Stop()
 
+	snapshotTicker := time.NewTicker(snapshotCheckInterval)
+	defer snapshotTicker.Stop()
+
 	done := make(chan struct{})
 	go func() {
 		<-n.closer.HasBeenClosed()
@@ -610,7 +782,6 @@ func (n *node) Run() {
 		close(done)
 	}()
 
-	var snapshotLoops uint64
 	for {
 		select {
 		case <-done:
@@ -624,25 +795,17 @@ func (n *node) Run() {
 		case <-slowTicker.C:
 			n.elog.Printf("Size of applyCh: %d", len(n.applyCh))
 			if leader {
-				// We try to take a snapshot every slow tick duration, with a 1000 discard entries.
-				// But, once a while, we take a snapshot with 10 discard entries. This avoids the
-				// scenario where after bringing up an Alpha, and doing a hundred schema updates, we
-				// don't take any snapshots because there are not enough updates (discardN=10),
-				// which then really slows down restarts. At the same time, by checking more
-				// frequently, we can quickly take a snapshot if a lot of mutations are coming in
-				// fast (discardN=1000).
-				discardN := 1000
-				if snapshotLoops%5 == 0 {
-					discardN = 10
-				}
-				snapshotLoops++
+				go n.abortOldTransactions()
+			}
+
+		case <-snapshotTicker.C:
+			if leader {
 				// We use disk based storage for Raft. So, we're not too concerned about
-				// snapshotting.  We just need to do enough, so that we don't have a huge backlog of
-				// entries to process on a restart.
-				if err := n.proposeSnapshot(discardN); err != nil {
+				// snapshotting. We just need to do enough, so that we don't have a huge backlog of
+				// entries to process on a restart, nor fill up the disk. See snapshotPolicy.
+				if err := n.proposeSnapshot(); err != nil {
 					x.Errorf("While calculating and proposing snapshot: %v", err)
 				}
-				go n.abortOldTransactions()
 			}
 
 		case <-ticker.C:
@@ -751,14 +914,21 @@ func (n *node) Run() {
 					if err := proposal.Unmarshal(entry.Data); err != nil {
 						x.Fatalf("Unable to unmarshal proposal: %v %q\n", err, entry.Data)
 					}
-					if pctx := n.Proposals.Get(proposal.Key); pctx != nil {
-						atomic.AddUint32(&pctx.Found, 1)
-						if span := otrace.FromContext(pctx.Ctx); span != nil {
-							span.Annotate(nil, "Proposal found in CommittedEntries")
+					// The proposals of a batch are applied one by one, all at the index of
+					// the batch.
+					for i, proposal := range batched(proposal) {
+						if i > 0 {
+							n.Applied.Begin(entry.Index)
+						}
+						if pctx := n.Proposals.Get(proposal.Key); pctx != nil {
+							atomic.AddUint32(&pctx.Found, 1)
+							if span := otrace.FromContext(pctx.Ctx); span != nil {
+								span.Annotate(nil, "Proposal found in CommittedEntries")
+							}
 						}
+						proposal.Index = entry.Index
+						proposals = append(proposals, proposal)
 					}
-					proposal.Index = entry.Index
-					proposals = append(proposals, proposal)
 				}
 			}
 			// Send the whole lot to applyCh in one go, instead of sending proposals one by one.
@@ -794,9 +964,25 @@ func (n *node) Run() {
 	}
 }
 
+// storedHistoryTs returns the history ts stored before a restart. The nodes which didn't store it
+// fall back on the read ts of sp, their last snapshot, which is at or above it.
+func (n *node) storedHistoryTs(sp raftpb.Snapshot) (uint64, error) {
+	ts, err := n.Store.HistoryTs()
+	if err != nil {
+		return 0, err
+	}
+	var snap pb.Snapshot
+	if err := snap.Unmarshal(sp.Data); ts == 0 && err == nil {
+		ts = snap.ReadTs
+	}
+	return ts, nil
+}
+
 // rollupLists would consolidate all the deltas that constitute one posting
-// list, and write back a complete posting list.
-func (n *node) rollupLists(readTs uint64) error {
+// list, and write back a complete posting list. The predicates of preds are rolled up on their
+// own, in order, and the empty predicate stands for all the others. Versions below readTs are only
+// discarded once all the predicates are rolled up, with all.
+func (n *node) rollupLists(readTs uint64, preds []string, all bool) error {
 	writer := x.NewTxnWriter(pstore)
 	writer.BlindWrite = true // Do overwrite keys.
 
@@ -809,26 +995,41 @@ func (n *node) rollupLists(readTs uint64) error {
 		mu.Unlock()
 	}
 
-	sl := stream.Lists{Stream: writer, DB: pstore}
-	sl.ChooseKeyFunc = func(item *badger.Item) bool {
-		pk := x.Parse(item.Key())
-		if pk.IsSchema() {
-			// Skip if schema.
-			return false
+	// The lists of the indexes being built don't have what the builds add to them yet.
+	building := indexBuilds.pendingAttrs()
+	for _, pred := range preds {
+		sl := stream.Lists{Stream: writer, DB: pstore, Predicate: pred}
+		sl.ChooseKeyFunc = func(item *badger.Item) bool {
+			pk := x.Parse(item.Key())
+			if pk.IsSchema() {
+				// Skip if schema.
+				return false
+			}
+			if !pk.IsData() && building[pk.Attr] {
+				return false
+			}
+			if len(pred) == 0 && x.HasString(preds, pk.Attr) {
+				// Rolled up on its own.
+				return false
+			}
+			// Return true if we don't find the BitCompletePosting bit.
+			return item.UserMeta()&posting.BitCompletePosting == 0
 		}
-		// Return true if we don't find the BitCompletePosting bit.
-		return item.UserMeta()&posting.BitCompletePosting == 0
-	}
-	sl.ItemToKVFunc = func(key []byte, itr *badger.Iterator) (*pb.KV, error) {
-		l, err := posting.ReadPostingList(key, itr)
-		if err != nil {
-			return nil, err
+		sl.ItemToKVFunc = func(key []byte, itr *badger.Iterator) (*pb.KV, error) {
+			l, err := posting.ReadPostingList(key, itr)
+			if err != nil {
+				return nil, err
+			}
+			addKey(key)
+			return l.MarshalToKv()
+		}
+		prefix := "Rolling up"
+		if len(pred) > 0 {
+			prefix = fmt.Sprintf("Rolling up %s", pred)
+		}
+		if err := sl.Orchestrate(context.Background(), prefix, readTs); err != nil {
+			return err
 		}
-		addKey(key)
-		return l.MarshalToKv()
-	}
-	if err := sl.Orchestrate(context.Background(), "Rolling up", readTs); err != nil {
-		return err
 	}
 	if err := writer.Flush(); err != nil {
 		return err
@@ -846,17 +1047,21 @@ func (n *node) rollupLists(readTs uint64) error {
 	}
 	glog.Infoln("Rollup in LRU cache done.")
 
-	// We can now discard all invalid versions of keys below this ts.
-	pstore.SetDiscardTs(readTs)
+	// We can now discard all invalid versions of keys below this ts, but those still retained.
+	if all {
+		posting.SetDiscardTs(history.discardTs(readTs, fault.Now()))
+		return n.Store.SetHistoryTs(posting.HistoryTs())
+	}
 	return nil
 }
 
 var errNoConnection = errors.New("No connection exists")
 
-func (n *node) blockingAbort(req *pb.TxnTimestamps) error {
+// blockingAbort aborts the txns of req which aren't committed yet, returning how many it aborted.
+func (n *node) blockingAbort(req *pb.TxnTimestamps) (int, error) {
 	pl := groups().Leader(0)
 	if pl == nil {
-		return errNoConnection
+		return 0, errNoConnection
 	}
 	zc := pb.NewZeroClient(pl.Get())
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
@@ -865,7 +1070,7 @@ func (n *node) blockingAbort(req *pb.TxnTimestamps) error {
 	delta, err := zc.TryAbort(ctx, req)
 	glog.Infof("TryAbort %d txns with start ts. Error: %v\n", len(req.Ts), err)
 	if err != nil || len(delta.Txns) == 0 {
-		return err
+		return 0, err
 	}
 
 	// Let's propose the txn updates received from Zero. This is important because there are edge
@@ -881,7 +1086,7 @@ func (n *node) blockingAbort(req *pb.TxnTimestamps) error {
 	}
 	if len(aborted.Txns) == 0 {
 		glog.Infoln("TryAbort: No aborts found. Quitting.")
-		return nil
+		return 0, nil
 	}
 
 	// We choose not to store the MaxAssigned, because it would cause our Oracle to move ahead
@@ -889,24 +1094,33 @@ func (n *node) blockingAbort(req *pb.TxnTimestamps) error {
 	// muck with that order here.
 	glog.Infof("TryAbort selectively proposing only aborted txns: %+v\n", aborted)
 	proposal := &pb.Proposal{Delta: aborted}
-	return n.proposeAndWait(n.ctx, proposal)
+	if err := n.proposeAndWait(n.ctx, proposal); err != nil {
+		return 0, err
+	}
+	return len(aborted.Txns), nil
 }
 
 // abortOldTransactions would find txns which have done pre-writes, but have been pending for a
 // while. The time that is used is based on the last pre-write seen, so if a txn is doing a
 // pre-write multiple times, we'll pick the timestamp of the last pre-write. Thus, this function
-// would only act on the txns which have not been active in the last N minutes, and send them for
-// abort. Note that only the leader runs this function.
+// would only act on the txns which have not been active in the last --txn_ttl, and send them for
+// abort. Txns kept alive by their clients, see --txn_ttl_exempt, are left alone. Note that only
+// the leader runs this function.
 func (n *node) abortOldTransactions() {
+	if Config.TxnTTL <= 0 {
+		return
+	}
 	// Aborts if not already committed.
-	starts := posting.Oracle().TxnOlderThan(5 * time.Minute)
+	starts := posting.Oracle().TxnOlderThan(Config.TxnTTL)
 	if len(starts) == 0 {
 		return
 	}
 	glog.Infof("Found %d old transactions. Acting to abort them.\n", len(starts))
 	req := &pb.TxnTimestamps{Ts: starts}
-	err := n.blockingAbort(req)
-	glog.Infof("abortOldTransactions for %d txns. Error: %+v\n", len(req.Ts), err)
+	aborted, err := n.blockingAbort(req)
+	x.ReapedTxns.Add(int64(aborted))
+	glog.Infof("abortOldTransactions aborted %d of %d txns. Error: %+v\n",
+		aborted, len(req.Ts), err)
 }
 
 // calculateSnapshot would calculate a snapshot index, considering these factors:
@@ -933,26 +1147,36 @@ func (n *node) abortOldTransactions() {
 // At i7, min pending start ts = S3, therefore snapshotIdx = i5 - 1 = i4.
 // At i7, max commit ts = C1, therefore readTs = C1.
 func (n *node) calculateSnapshot(discardN int) (*pb.Snapshot, error) {
+	snap, _, err := n.computeSnapshot(discardN)
+	return snap, err
+}
+
+// computeSnapshot is calculateSnapshot, also returning the stats of the Raft log it saw, and why
+// it couldn't calculate a snapshot, if so.
+func (n *node) computeSnapshot(discardN int) (*pb.Snapshot, snapshotStats, error) {
 	tr := trace.New("Dgraph.Internal", "Propose.Snapshot")
 	defer tr.Finish()
 
+	var stats snapshotStats
 	if atomic.LoadInt32(&n.streaming) > 0 {
 		tr.LazyPrintf("Skipping calculateSnapshot due to streaming")
-		return nil, nil
+		stats.blocked = "streaming"
+		return nil, stats, nil
 	}
 
 	first, err := n.Store.FirstIndex()
 	if err != nil {
 		tr.LazyPrintf("Error: %v", err)
 		tr.SetError()
-		return nil, err
+		return nil, stats, err
 	}
 	tr.LazyPrintf("First index: %d", first)
 
 	last := n.Applied.DoneUntil()
 	if int(last-first) < discardN {
 		tr.LazyPrintf("Skipping due to insufficient entries")
-		return nil, nil
+		stats.blocked = "few_entries"
+		return nil, stats, nil
 	}
 	tr.LazyPrintf("Found Raft entries: %d", last-first)
 
@@ -960,7 +1184,10 @@ func (n *node) calculateSnapshot(discardN int) (*pb.Snapshot, error) {
 	if err != nil {
 		tr.LazyPrintf("Error: %v", err)
 		tr.SetError()
-		return nil, err
+		return nil, stats, err
+	}
+	for _, entry := range entries {
+		stats.logBytes += uint64(entry.Size())
 	}
 
 	// We can't rely upon the Raft entries to determine the minPendingStart,
@@ -984,11 +1211,13 @@ func (n *node) calculateSnapshot(discardN int) (*pb.Snapshot, error) {
 		if err := proposal.Unmarshal(entry.Data); err != nil {
 			tr.LazyPrintf("Error: %v", err)
 			tr.SetError()
-			return nil, err
+			return nil, stats, err
 		}
-		if proposal.Mutations != nil {
-			start := proposal.Mutations.StartTs
-			if start >= minPendingStart && snapshotIdx == 0 {
+		for _, p := range batched(&proposal) {
+			if p.Mutations == nil {
+				continue
+			}
+			if p.Mutations.StartTs >= minPendingStart && snapshotIdx == 0 {
 				snapshotIdx = entry.Index - 1
 			}
 		}
@@ -1001,7 +1230,8 @@ func (n *node) calculateSnapshot(discardN int) (*pb.Snapshot, error) {
 	}
 	if maxCommitTs == 0 {
 		tr.LazyPrintf("maxCommitTs is zero")
-		return nil, nil
+		stats.blocked = "no_commits"
+		return nil, stats, nil
 	}
 	if snapshotIdx <= 0 {
 		// It is possible that there are no pending transactions. In that case,
@@ -1009,6 +1239,16 @@ func (n *node) calculateSnapshot(discardN int) (*pb.Snapshot, error) {
 		tr.LazyPrintf("Using maxCommitIdx as snapshotIdx: %d", maxCommitIdx)
 		snapshotIdx = maxCommitIdx
 	}
+	if idx := indexBuilds.minIndex(); idx > 0 && snapshotIdx >= idx {
+		// Keep the schema mutations of the index builds in the log, so they're run again on
+		// restart.
+		tr.LazyPrintf("Index build started at: %d", idx)
+		if idx <= first {
+			stats.blocked = "index_build"
+			return nil, stats, nil
+		}
+		snapshotIdx = idx - 1
+	}
 
 	numDiscarding := snapshotIdx - first + 1
 	tr.LazyPrintf("Got snapshotIdx: %d. MaxCommitTs: %d. Discarding: %d. MinPendingStartTs: %d",
@@ -1017,7 +1257,13 @@ func (n *node) calculateSnapshot(discardN int) (*pb.Snapshot, error) {
 		tr.LazyPrintf("Skipping snapshot because insufficient discard entries")
 		glog.Infof("Skipping snapshot at index: %d. Insufficient discard entries: %d."+
 			" MinPendingStartTs: %d\n", snapshotIdx, numDiscarding, minPendingStart)
-		return nil, nil
+		stats.blocked = "pending_txns"
+		return nil, stats, nil
+	}
+	for _, entry := range entries {
+		if entry.Index <= snapshotIdx {
+			stats.bytes += uint64(entry.Size())
+		}
 	}
 
 	snap := &pb.Snapshot{
@@ -1026,7 +1272,75 @@ func (n *node) calculateSnapshot(discardN int) (*pb.Snapshot, error) {
 		ReadTs:  maxCommitTs,
 	}
 	tr.LazyPrintf("Got snapshot: %+v", snap)
-	return snap, nil
+	return snap, stats, nil
+}
+
+const (
+	// How often the leader considers taking a snapshot.
+	snapshotCheckInterval = 10 * time.Second
+	// Snapshots discard at least this many entries, and are proposed at most once per
+	// minSnapshotInterval, unless the log grows past maxSnapshotLogFactor times the size that
+	// triggers them.
+	minSnapshotEntries   = 10
+	minSnapshotInterval  = time.Minute
+	maxSnapshotLogFactor = 4
+	// Under disk pressure, snapshots are taken once the log is this many times smaller.
+	diskPressureLogFactor = 8
+)
+
+// snapshotStats describes the Raft log of the group, when considering a snapshot.
+type snapshotStats struct {
+	logBytes  uint64        // The size of the entries since the last snapshot.
+	bytes     uint64        // The size of the entries the snapshot would discard.
+	blocked   string        // Why no snapshot could be calculated, if so.
+	sinceLast time.Duration // Time since this node last proposed a snapshot.
+	diskFree  float64       // The fraction of the disk holding the Raft log which is free.
+}
+
+// snapshotPolicy decides when the leader of a group takes a snapshot. Snapshots are taken once
+// the entries they would discard reach logBytes, or are older than maxInterval, so the log stays
+// small and restarts stay fast, without taking snapshots in a loop under heavy load. Entries
+// which pending transactions still need can't be discarded, so they don't count. Under disk
+// pressure, snapshots are taken sooner.
+type snapshotPolicy struct {
+	logBytes    uint64
+	maxInterval time.Duration
+}
+
+// decide tells whether to take a snapshot, given stats, along with the reason why.
+func (p snapshotPolicy) decide(stats snapshotStats) (bool, string) {
+	if len(stats.blocked) > 0 {
+		return false, stats.blocked
+	}
+	limit := p.logBytes
+	pressure := stats.diskFree < minDiskFree
+	if pressure {
+		limit /= diskPressureLogFactor
+	}
+	switch {
+	case stats.sinceLast < minSnapshotInterval && stats.bytes < maxSnapshotLogFactor*limit:
+		return false, "min_interval"
+	case stats.bytes >= limit && pressure:
+		return true, "disk_pressure"
+	case stats.bytes >= limit:
+		return true, "log_size"
+	case p.maxInterval > 0 && stats.sinceLast >= p.maxInterval:
+		return true, "max_interval"
+	}
+	return false, "log_small"
+}
+
+// walDiskFree returns the fraction of the disk holding the Raft log which is free, or 1 if
+// unknown.
+func walDiskFree() float64 {
+	if len(Config.WALDir) == 0 {
+		return 1
+	}
+	free, total, err := x.DiskUsage(Config.WALDir)
+	if err != nil || total == 0 {
+		return 1
+	}
+	return float64(free) / float64(total)
 }
 
 func (n *node) joinPeers() error {
@@ -1104,11 +1418,18 @@ func (n *node) InitAndStartNode() {
 			// zero-member Raft group.
 			n.SetConfState(&sp.Metadata.ConfState)
 
+			// The versions below the history ts might have been discarded before the restart.
+			ts, err := n.storedHistoryTs(sp)
+			x.Checkf(err, "Unable to get the history ts")
+			posting.RaiseHistoryTs(ts)
+
 			members := groups().members(n.gid)
-			for _, id := range sp.Metadata.ConfState.Nodes {
-				m, ok := members[id]
-				if ok {
-					n.Connect(id, m.Addr)
+			cs := sp.Metadata.ConfState
+			for _, ids := range [][]uint64{cs.Nodes, cs.Learners} {
+				for _, id := range ids {
+					if m, ok := members[id]; ok {
+						n.Connect(id, m.Addr)
+					}
 				}
 			}
 		}
@@ -1127,6 +1448,9 @@ func (n *node) InitAndStartNode() {
 			n.retryUntilSuccess(n.joinPeers, time.Second)
 			n.SetRaft(raft.StartNode(n.Cfg, nil))
 		} else {
+			if Config.Learner {
+				x.Fatalf("Learner can't start group %d, which has no other members.", n.gid)
+			}
 			peers := []raft.Peer{{ID: n.Id}}
 			n.SetRaft(raft.StartNode(n.Cfg, peers))
 			// Trigger election, so this node can become the leader of this single-node cluster.
@@ -1134,6 +1458,7 @@ func (n *node) InitAndStartNode() {
 		}
 	}
 	go n.processRollups()
+	go n.purgeTombstones()
 	go n.processApplyCh()
 	go n.BatchAndSendMessages()
 	go n.Run()
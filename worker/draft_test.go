@@ -20,6 +20,7 @@ import (
 	"io/ioutil"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/coreos/etcd/raft/raftpb"
 	"github.com/dgraph-io/badger"
@@ -124,3 +125,58 @@ func TestCalculateSnapshot(t *testing.T) {
 	require.NoError(t, err)
 	require.Nil(t, snap)
 }
+
+func TestSnapshotPolicy(t *testing.T) {
+	p := snapshotPolicy{logBytes: 1000, maxInterval: 10 * time.Minute}
+	stats := func(logBytes, bytes uint64, sinceLast time.Duration, diskFree float64) snapshotStats {
+		return snapshotStats{logBytes: logBytes, bytes: bytes, sinceLast: sinceLast, diskFree: diskFree}
+	}
+	tests := []struct {
+		stats  snapshotStats
+		take   bool
+		reason string
+	}{
+		{stats(100, 100, 2*time.Minute, 0.5), false, "log_small"},
+		{stats(1000, 1000, 2*time.Minute, 0.5), true, "log_size"},
+		{stats(100, 100, time.Hour, 0.5), true, "max_interval"},
+		// Snapshots are spaced out, unless the log grows too big.
+		{stats(1000, 1000, time.Second, 0.5), false, "min_interval"},
+		{stats(4000, 4000, time.Second, 0.5), true, "log_size"},
+		// Entries needed by pending transactions can't be discarded.
+		{stats(5000, 100, 2*time.Minute, 0.5), false, "log_small"},
+		{snapshotStats{logBytes: 5000, blocked: "pending_txns"}, false, "pending_txns"},
+		{stats(200, 200, 2*time.Minute, 0.05), true, "disk_pressure"},
+	}
+	for _, tc := range tests {
+		take, reason := p.decide(tc.stats)
+		require.Equal(t, tc.take, take, "%+v", tc.stats)
+		require.Equal(t, tc.reason, reason, "%+v", tc.stats)
+	}
+}
+
+func TestStoredHistoryTs(t *testing.T) {
+	dir, err := ioutil.TempDir("", "badger")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	db, err := openBadger(dir)
+	require.NoError(t, err)
+	defer db.Close()
+
+	data, err := (&pb.Snapshot{ReadTs: 100}).Marshal()
+	require.NoError(t, err)
+	sp := raftpb.Snapshot{Data: data}
+
+	// The history ts stored is kept, though the snapshot is newer.
+	n := newNode(raftwal.Init(db, 1, 1), 1, 1, "")
+	require.NoError(t, n.Store.SetHistoryTs(42))
+	ts, err := n.storedHistoryTs(sp)
+	require.NoError(t, err)
+	require.Equal(t, uint64(42), ts)
+
+	// Without one, the read ts of the snapshot is.
+	n = newNode(raftwal.Init(db, 2, 1), 2, 1, "")
+	ts, err = n.storedHistoryTs(sp)
+	require.NoError(t, err)
+	require.Equal(t, uint64(100), ts)
+}
@@ -0,0 +1,153 @@
+/*
+ * Copyright 2018 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package worker
+
+import (
+	"sync"
+	"time"
+
+	"github.com/dgraph-io/dgraph/x"
+	"github.com/golang/glog"
+)
+
+// An Alpha is drained before being restarted, so that the restart fails no client request: it
+// hands its leadership over to another member of its group, and keeps doing so should it be
+// elected again, while the servers take no new clients and wait for the transactions left.
+// It reports draining to Zero, which shows it in /state, and in /health, for load balancers to
+// stop sending it requests.
+
+const drainCheckInterval = time.Second
+
+var drain struct {
+	sync.Mutex
+	since time.Time // Zero if not draining.
+	stop  chan struct{}
+}
+
+// IsDraining tells whether this Alpha is being drained.
+func IsDraining() bool {
+	drain.Lock()
+	defer drain.Unlock()
+	return !drain.since.IsZero()
+}
+
+// DrainingSince returns when this Alpha started getting drained, or the zero time if it isn't.
+func DrainingSince() time.Time {
+	drain.Lock()
+	defer drain.Unlock()
+	return drain.since
+}
+
+// DrainState is how far the drain of this Alpha went. It's drained once it isn't the leader of its
+// group anymore, and its clients have no transactions open.
+type DrainState struct {
+	Draining bool       `json:"draining"`
+	Since    *time.Time `json:"since,omitempty"`
+	Leader   bool       `json:"leader"`
+	OpenTxns int        `json:"open_txns"`
+	Drained  bool       `json:"drained"`
+}
+
+// GetDrainState returns the state of the drain of this Alpha, given the transactions its clients
+// have open.
+func GetDrainState(openTxns int) *DrainState {
+	st := &DrainState{OpenTxns: openTxns}
+	if g := groups(); g != nil && g.Node != nil {
+		st.Leader = g.Node.AmLeader()
+	}
+	if since := DrainingSince(); !since.IsZero() {
+		st.Draining, st.Since = true, &since
+		st.Drained = !st.Leader && openTxns == 0
+	}
+	return st
+}
+
+// StartDrain puts this Alpha in drain mode. It's a no-op if it's draining already.
+func StartDrain() error {
+	g := groups()
+	if g == nil || g.Node == nil || g.Node.Raft() == nil {
+		return x.Errorf("Alpha can't be drained before it has started")
+	}
+	drain.Lock()
+	defer drain.Unlock()
+	if !drain.since.IsZero() {
+		return nil
+	}
+	drain.since = time.Now()
+	drain.stop = make(chan struct{})
+	glog.Infof("Draining Alpha %#x of group %d", g.Node.Id, g.groupId())
+	go g.Node.drainLeadership(drain.stop)
+	g.triggerMembershipSync()
+	return nil
+}
+
+// StopDrain takes this Alpha out of drain mode, for it to serve as usual again.
+func StopDrain() {
+	drain.Lock()
+	defer drain.Unlock()
+	if drain.since.IsZero() {
+		return
+	}
+	close(drain.stop)
+	drain.since = time.Time{}
+	glog.Infof("Alpha is no longer draining")
+	groups().triggerMembershipSync()
+}
+
+// drainLeadership transfers the leadership of the group to another member every time this node
+// is the leader, until stop is closed.
+func (n *node) drainLeadership(stop <-chan struct{}) {
+	ticker := time.NewTicker(drainCheckInterval)
+	defer ticker.Stop()
+	for {
+		if n.AmLeader() {
+			if peer, ok := n.drainPeer(); ok {
+				glog.Infof("Draining: transferring leadership of group %d to %#x",
+					groups().groupId(), peer)
+				n.Raft().TransferLeadership(n.ctx, n.Id, peer)
+			} else {
+				glog.Warningf("Draining: no member of group %d to hand the leadership over to",
+					groups().groupId())
+			}
+		}
+		select {
+		case <-stop:
+			return
+		case <-n.closer.HasBeenClosed():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// drainPeer returns the voter of the group most up to date among the active ones which aren't
+// draining, as seen by the leader.
+func (n *node) drainPeer() (uint64, bool) {
+	st := n.Raft().Status()
+	members := groups().members(groups().groupId())
+	var peer, match uint64
+	for id, pr := range st.Progress {
+		m, ok := members[id]
+		if id == n.Id || !ok || m.Learner || m.Draining || !pr.RecentActive {
+			continue
+		}
+		if peer == 0 || pr.Match > match {
+			peer, match = id, pr.Match
+		}
+	}
+	return peer, peer != 0
+}
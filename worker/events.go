@@ -0,0 +1,59 @@
+/*
+ * Copyright 2018 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package worker
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/golang/glog"
+	"golang.org/x/net/context"
+
+	"github.com/dgraph-io/dgraph/protos/pb"
+)
+
+// Types of the events the Alphas record in the event log of the cluster, kept by Zero.
+const (
+	EventSchemaChanged = "schema_changed"
+	EventBackup        = "backup"
+)
+
+// RecordEvent records an event of this Alpha in the event log of the cluster, in the background.
+// Events are informational, so failing to record them is only logged.
+func RecordEvent(typ, pred, format string, args ...interface{}) {
+	g := groups()
+	ev := &pb.ClusterEvent{
+		At:        time.Now().UnixNano(),
+		Type:      typ,
+		Group:     g.groupId(),
+		Node:      Config.RaftId,
+		Predicate: pred,
+		Message:   fmt.Sprintf(format, args...),
+	}
+	go func() {
+		pl := g.connToZeroLeader()
+		if pl == nil {
+			glog.Warningf("No Zero leader to record event: %+v", ev)
+			return
+		}
+		ctx, cancel := context.WithTimeout(g.ctx, 10*time.Second)
+		defer cancel()
+		if _, err := pb.NewZeroClient(pl.Get()).RecordEvent(ctx, ev); err != nil {
+			glog.Warningf("While recording event %+v: %v", ev, err)
+		}
+	}()
+}
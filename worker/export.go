@@ -156,6 +156,15 @@ func toSchema(attr string, update pb.SchemaUpdate) (*pb.KV, error) {
 	if update.Upsert {
 		buf.WriteString(" @upsert")
 	}
+	if update.Ttl > 0 {
+		buf.WriteString(" @ttl(")
+		buf.WriteString((time.Duration(update.Ttl) * time.Second).String())
+		if len(update.TtlFacet) > 0 {
+			buf.WriteString(", ")
+			buf.WriteString(update.TtlFacet)
+		}
+		buf.WriteByte(')')
+	}
 	buf.WriteString(" . \n")
 	kv := &pb.KV{
 		Val:     buf.Bytes(),
@@ -222,12 +231,24 @@ func (mux *writerMux) Send(kvs *pb.KVS) error {
 	return nil
 }
 
-// export creates a export of data by exporting it as an RDF gzip.
+// exportDir returns the directory the export is written to. All groups exporting at the same
+// timestamps write to the same directory.
+func exportDir(in *pb.ExportRequest) string {
+	uts := time.Unix(in.UnixTs, 0)
+	return path.Join(Config.ExportPath, fmt.Sprintf(
+		"dgraph.r%d.u%s", in.ReadTs, uts.UTC().Format("0102.1504")))
+}
+
+// export creates a export of data by exporting it as an RDF gzip, or as one table per predicate
+// for the csv and parquet formats.
 func export(ctx context.Context, in *pb.ExportRequest) error {
 	if in.GroupId != groups().groupId() {
 		return x.Errorf("Export request group mismatch. Mine: %d. Requested: %d\n",
 			groups().groupId(), in.GroupId)
 	}
+	if err := ValidateExportFormat(in.Format); err != nil {
+		return err
+	}
 	glog.Infof("Export requested at %d.", in.ReadTs)
 
 	// Let's wait for this server to catch up to all the updates until this ts.
@@ -236,13 +257,17 @@ func export(ctx context.Context, in *pb.ExportRequest) error {
 	}
 	glog.Infof("Running export for group %d at timestamp %d.", in.GroupId, in.ReadTs)
 
-	uts := time.Unix(in.UnixTs, 0)
-	bdir := path.Join(Config.ExportPath, fmt.Sprintf(
-		"dgraph.r%d.u%s", in.ReadTs, uts.UTC().Format("0102.1504")))
-
+	bdir := exportDir(in)
 	if err := os.MkdirAll(bdir, 0700); err != nil {
 		return err
 	}
+	if isTabularFormat(in.Format) {
+		if err := exportTables(ctx, in, bdir); err != nil {
+			return err
+		}
+		glog.Infof("Export DONE for group %d at timestamp %d.", in.GroupId, in.ReadTs)
+		return nil
+	}
 	path := func(suffix string) (string, error) {
 		return filepath.Abs(path.Join(bdir, fmt.Sprintf("g%02d.%s", in.GroupId, suffix)))
 	}
@@ -273,10 +298,10 @@ func export(ctx context.Context, in *pb.ExportRequest) error {
 	sl := stream.Lists{Stream: &mux, DB: pstore}
 	sl.ChooseKeyFunc = func(item *badger.Item) bool {
 		pk := x.Parse(item.Key())
-		if pk.Attr == "_predicate_" {
+		if pk.Attr == "_predicate_" || IsTombstone(pk.Attr) {
 			return false
 		}
-		if !groups().ServesTablet(pk.Attr) {
+		if !groups().OwnsTablet(pk.Attr) {
 			return false
 		}
 		// We need to ensure that schema keys are separately identifiable, so they can be
@@ -345,7 +370,12 @@ func (w *grpcWorker) Export(ctx context.Context, req *pb.ExportRequest) (*pb.Sta
 		return nil, err
 	}
 	glog.Infof("Export request: %+v OK.\n", req)
-	return &pb.Status{Msg: "SUCCESS"}, nil
+	// Let the caller know where to find the export.
+	dir, err := filepath.Abs(exportDir(req))
+	if err != nil {
+		dir = exportDir(req)
+	}
+	return &pb.Status{Msg: dir}, nil
 }
 
 func handleExportOverNetwork(ctx context.Context, in *pb.ExportRequest) error {
@@ -367,7 +397,11 @@ func handleExportOverNetwork(ctx context.Context, in *pb.ExportRequest) error {
 	return err
 }
 
-func ExportOverNetwork(ctx context.Context) error {
+// ExportOverNetwork exports the data of all groups in the given format.
+func ExportOverNetwork(ctx context.Context, format string) error {
+	if err := ValidateExportFormat(format); err != nil {
+		return err
+	}
 	// If we haven't even had a single membership update, don't run export.
 	if err := x.HealthCheck(); err != nil {
 		glog.Errorf("Rejecting export request due to health check error: %v\n", err)
@@ -386,13 +420,16 @@ func ExportOverNetwork(ctx context.Context) error {
 	gids := groups().KnownGroups()
 	glog.Infof("Requesting export for groups: %v\n", gids)
 
+	// All groups must use the same UnixTs, so that they export to the same directory.
+	unixTs := time.Now().Unix()
 	ch := make(chan error, len(gids))
 	for _, gid := range gids {
 		go func(group uint32) {
 			req := &pb.ExportRequest{
 				GroupId: group,
 				ReadTs:  readTs,
-				UnixTs:  time.Now().Unix(),
+				UnixTs:  unixTs,
+				Format:  format,
 			}
 			ch <- handleExportOverNetwork(ctx, req)
 		}(gid)
@@ -0,0 +1,395 @@
+/*
+ * Copyright 2018 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package worker
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/dgraph-io/badger"
+	"github.com/dgraph-io/dgo/protos/api"
+	"github.com/golang/glog"
+	"golang.org/x/net/context"
+
+	"github.com/dgraph-io/dgraph/posting"
+	"github.com/dgraph-io/dgraph/protos/pb"
+	"github.com/dgraph-io/dgraph/schema"
+	"github.com/dgraph-io/dgraph/stream"
+	"github.com/dgraph-io/dgraph/types"
+	"github.com/dgraph-io/dgraph/types/facets"
+	"github.com/dgraph-io/dgraph/x"
+)
+
+// Export formats. RDF is the default, and the only one which can be loaded back by the bulk and
+// live loaders. The tabular formats write one file per predicate, made up of the columns uid,
+// value, lang (for value predicates) and facets, so they can be analyzed directly.
+const (
+	ExportFormatRDF     = "rdf"
+	ExportFormatCSV     = "csv"
+	ExportFormatParquet = "parquet"
+)
+
+func isTabularFormat(format string) bool {
+	return format == ExportFormatCSV || format == ExportFormatParquet
+}
+
+// ValidateExportFormat returns an error if the given export format isn't supported. An empty
+// format means RDF.
+func ValidateExportFormat(format string) error {
+	switch format {
+	case "", ExportFormatRDF, ExportFormatCSV, ExportFormatParquet:
+		return nil
+	}
+	return x.Errorf("Invalid export format: %q. Must be one of rdf, csv or parquet.", format)
+}
+
+// tableRow is a single row of a tabular export. Every posting becomes one row.
+type tableRow struct {
+	uid    uint64
+	value  types.Val // Holds the target uid for uid predicates.
+	lang   string
+	facets string // JSON encoded, empty if the posting has no facets.
+}
+
+type tableWriter interface {
+	writeRow(row *tableRow) error
+	Close() error
+}
+
+// columnType returns the type used for the value column of a predicate. Values of predicates
+// without a schema type are exported as strings.
+func columnType(attr string) types.TypeID {
+	tid, err := schema.State().TypeOf(attr)
+	if err != nil || tid == types.DefaultID || tid == types.PasswordID {
+		return types.StringID
+	}
+	return tid
+}
+
+func facetsToJSON(fcs []*api.Facet) (string, error) {
+	if len(fcs) == 0 {
+		return "", nil
+	}
+	m := make(map[string]interface{}, len(fcs))
+	for _, f := range fcs {
+		m[f.Key] = facets.ValFor(f).Value
+	}
+	b, err := json.Marshal(m)
+	return string(b), err
+}
+
+// toTableRow converts a posting into a row, converting the value to the type of the column.
+func toTableRow(uid uint64, p *pb.Posting, tid types.TypeID) (*tableRow, error) {
+	row := &tableRow{uid: uid, lang: string(p.LangTag)}
+	if p.PostingType == pb.Posting_REF {
+		row.value = types.Val{Tid: types.UidID, Value: p.Uid}
+	} else {
+		src := types.ValueForType(types.TypeID(p.ValType))
+		src.Value = p.Value
+		if tid == types.GeoID {
+			// Geo values are exported as GeoJSON.
+			tid = types.StringID
+		}
+		val, err := types.Convert(src, tid)
+		if err != nil {
+			return nil, err
+		}
+		if s, ok := val.Value.(string); ok {
+			// trim null character at end
+			val.Value = strings.TrimRight(s, "\x00")
+		}
+		row.value = val
+	}
+	var err error
+	row.facets, err = facetsToJSON(p.Facets)
+	return row, err
+}
+
+type csvTable struct {
+	fw    *fileWriter
+	cw    *csv.Writer
+	isUid bool
+	rec   []string
+}
+
+func newCSVTable(fpath string, tid types.TypeID) (*csvTable, error) {
+	t := &csvTable{fw: &fileWriter{}, isUid: tid == types.UidID}
+	if err := t.fw.open(fpath); err != nil {
+		return nil, err
+	}
+	t.cw = csv.NewWriter(t.fw.gw)
+	header := []string{"uid", "value", "lang", "facets"}
+	if t.isUid {
+		header = []string{"uid", "value", "facets"}
+	}
+	return t, t.cw.Write(header)
+}
+
+func (t *csvTable) writeRow(row *tableRow) error {
+	var value string
+	if row.value.Tid == types.UidID {
+		value = fmt.Sprintf("%#x", row.value.Value.(uint64))
+	} else {
+		str := types.ValueForType(types.StringID)
+		if err := types.Marshal(row.value, &str); err != nil {
+			return err
+		}
+		value = str.Value.(string)
+	}
+	t.rec = append(t.rec[:0], fmt.Sprintf("%#x", row.uid), value)
+	if !t.isUid {
+		t.rec = append(t.rec, row.lang)
+	}
+	t.rec = append(t.rec, row.facets)
+	return t.cw.Write(t.rec)
+}
+
+func (t *csvTable) Close() error {
+	t.cw.Flush()
+	if err := t.cw.Error(); err != nil {
+		return err
+	}
+	return t.fw.Close()
+}
+
+type parquetTable struct {
+	pw                         *parquetWriter
+	uid, value, lang, facetCol *parquetColumn
+}
+
+func newParquetTable(fpath string, tid types.TypeID) (*parquetTable, error) {
+	// The facets are null for the postings without any.
+	facetCol := &parquetColumn{name: "facets", typ: parquetByteArray, converted: parquetJSON,
+		optional: true}
+	t := &parquetTable{
+		uid:      &parquetColumn{name: "uid", typ: parquetInt64, converted: parquetUint64},
+		value:    &parquetColumn{name: "value"},
+		facetCol: facetCol,
+	}
+	switch tid {
+	case types.UidID:
+		t.value.typ, t.value.converted = parquetInt64, parquetUint64
+	case types.IntID:
+		t.value.typ, t.value.converted = parquetInt64, parquetNone
+	case types.FloatID:
+		t.value.typ, t.value.converted = parquetDouble, parquetNone
+	case types.BoolID:
+		t.value.typ, t.value.converted = parquetBoolean, parquetNone
+	case types.DateTimeID:
+		t.value.typ, t.value.converted = parquetInt64, parquetTimestampMicros
+	case types.GeoID:
+		t.value.typ, t.value.converted = parquetByteArray, parquetJSON
+	case types.BinaryID:
+		t.value.typ, t.value.converted = parquetByteArray, parquetNone
+	default:
+		t.value.typ, t.value.converted = parquetByteArray, parquetUTF8
+	}
+	columns := []*parquetColumn{t.uid, t.value}
+	if tid != types.UidID {
+		t.lang = &parquetColumn{name: "lang", typ: parquetByteArray, converted: parquetUTF8}
+		columns = append(columns, t.lang)
+	}
+	columns = append(columns, t.facetCol)
+
+	var err error
+	t.pw, err = newParquetWriter(fpath, columns)
+	return t, err
+}
+
+func (t *parquetTable) writeRow(row *tableRow) error {
+	switch v := row.value.Value.(type) {
+	case uint64:
+		t.value.appendInt64(int64(v))
+	case int64:
+		t.value.appendInt64(v)
+	case float64:
+		t.value.appendDouble(v)
+	case bool:
+		t.value.appendBool(v)
+	case time.Time:
+		t.value.appendInt64(v.UnixNano() / int64(time.Microsecond))
+	case string:
+		t.value.appendBytes([]byte(v))
+	case []byte:
+		t.value.appendBytes(v)
+	default:
+		return x.Errorf("Unexpected value of type %T in parquet export", v)
+	}
+	t.uid.appendInt64(int64(row.uid))
+	if t.lang != nil {
+		t.lang.appendBytes([]byte(row.lang))
+	}
+	if len(row.facets) > 0 {
+		t.facetCol.appendBytes([]byte(row.facets))
+	} else {
+		t.facetCol.appendNull()
+	}
+	return t.pw.endRow()
+}
+
+func (t *parquetTable) Close() error {
+	return t.pw.Close()
+}
+
+// tableMux converts the posting lists streamed for a single predicate into rows of its table. The
+// file is only created once the first row is written, so that predicates without any data (at
+// the export timestamp) don't leave empty files behind.
+type tableMux struct {
+	open   func() (tableWriter, error)
+	tid    types.TypeID
+	writer tableWriter
+}
+
+func (mux *tableMux) Send(kvs *pb.KVS) error {
+	for _, kv := range kvs.Kv {
+		pk := x.Parse(kv.Key)
+		var pl pb.PostingList
+		if err := pl.Unmarshal(kv.Val); err != nil {
+			return err
+		}
+		for _, p := range pl.Postings {
+			row, err := toTableRow(pk.Uid, p, mux.tid)
+			if err != nil {
+				glog.Errorf("While converting value of %q for uid %#x. Err=%v. Ignoring.\n",
+					pk.Attr, pk.Uid, err)
+				continue
+			}
+			if mux.writer == nil {
+				if mux.writer, err = mux.open(); err != nil {
+					return err
+				}
+			}
+			if err := mux.writer.writeRow(row); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (mux *tableMux) Close() error {
+	if mux.writer == nil {
+		return nil
+	}
+	return mux.writer.Close()
+}
+
+// exportTables writes the schema of the group in the same format as RDF exports, followed by one
+// table per predicate at <export dir>/<predicate>/g<group>.<format>.
+func exportTables(ctx context.Context, in *pb.ExportRequest, bdir string) error {
+	schemaPath, err := filepath.Abs(path.Join(bdir, fmt.Sprintf("g%02d.schema.gz", in.GroupId)))
+	if err != nil {
+		return err
+	}
+	glog.Infof("Exporting schema for group: %d at %s\n", in.GroupId, schemaPath)
+	schemaWriter := &fileWriter{}
+	if err := schemaWriter.open(schemaPath); err != nil {
+		return err
+	}
+	var preds []string
+	for _, attr := range schema.State().Predicates() {
+		if attr == "_predicate_" || !groups().OwnsTablet(attr) {
+			continue
+		}
+		update, ok := schema.State().Get(attr)
+		if !ok {
+			continue
+		}
+		kv, err := toSchema(attr, update)
+		if err != nil {
+			return err
+		}
+		if _, err := schemaWriter.gw.Write(kv.Val); err != nil {
+			return err
+		}
+		preds = append(preds, attr)
+	}
+	if err := schemaWriter.Close(); err != nil {
+		return err
+	}
+
+	sort.Strings(preds)
+	for _, attr := range preds {
+		if err := exportTable(ctx, in, bdir, attr); err != nil {
+			return x.Wrapf(err, "while exporting predicate %q", attr)
+		}
+	}
+	return nil
+}
+
+func exportTable(ctx context.Context, in *pb.ExportRequest, bdir, attr string) error {
+	tid := columnType(attr)
+	mux := &tableMux{tid: tid}
+	mux.open = func() (tableWriter, error) {
+		dir := path.Join(bdir, url.PathEscape(attr))
+		if err := os.MkdirAll(dir, 0700); err != nil {
+			return nil, err
+		}
+		ext := "csv.gz"
+		if in.Format == ExportFormatParquet {
+			ext = "parquet"
+		}
+		fpath, err := filepath.Abs(path.Join(dir, fmt.Sprintf("g%02d.%s", in.GroupId, ext)))
+		if err != nil {
+			return nil, err
+		}
+		glog.Infof("Exporting predicate %q for group: %d at %s\n", attr, in.GroupId, fpath)
+		if in.Format == ExportFormatParquet {
+			return newParquetTable(fpath, tid)
+		}
+		return newCSVTable(fpath, tid)
+	}
+
+	sl := stream.Lists{Stream: mux, DB: pstore, Predicate: attr}
+	sl.ChooseKeyFunc = func(item *badger.Item) bool {
+		return x.Parse(item.Key()).IsData()
+	}
+	sl.ItemToKVFunc = func(key []byte, itr *badger.Iterator) (*pb.KV, error) {
+		pl, err := posting.ReadPostingList(key, itr)
+		if err != nil {
+			return nil, err
+		}
+		// The rows are generated in Send, from the postings visible at the export timestamp.
+		var out pb.PostingList
+		err = pl.Iterate(in.ReadTs, 0, func(p *pb.Posting) error {
+			cp := *p // The iterator can reuse the posting.
+			out.Postings = append(out.Postings, &cp)
+			return nil
+		})
+		if err != nil || len(out.Postings) == 0 {
+			return nil, err
+		}
+		val, err := out.Marshal()
+		if err != nil {
+			return nil, err
+		}
+		return &pb.KV{Key: key, Val: val, Version: 1}, nil
+	}
+
+	if err := sl.Orchestrate(ctx, "Export "+attr, in.ReadTs); err != nil {
+		return err
+	}
+	return mux.Close()
+}
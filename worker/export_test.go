@@ -20,10 +20,12 @@ import (
 	"bufio"
 	"compress/gzip"
 	"context"
+	"encoding/csv"
 	"io/ioutil"
 	"math"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
 	"testing"
 	"time"
@@ -233,6 +235,54 @@ func TestExport(t *testing.T) {
 	require.Equal(t, 1, count)
 }
 
+func TestExportCSV(t *testing.T) {
+	// The tables are of the predicates in the schema, so it must parse.
+	initTestExport(t, "name: string @index(exact) .\nfriend: uid .")
+	bdir, err := ioutil.TempDir("", "export")
+	require.NoError(t, err)
+	defer os.RemoveAll(bdir)
+
+	time.Sleep(1 * time.Second)
+
+	Config.ExportPath = bdir
+	readTs := timestamp()
+	posting.Oracle().ProcessDelta(&pb.OracleDelta{MaxAssigned: readTs})
+	err = export(context.Background(),
+		&pb.ExportRequest{ReadTs: readTs, GroupId: 1, Format: ExportFormatCSV})
+	require.NoError(t, err)
+
+	files, err := filepath.Glob(filepath.Join(bdir, "*", "name", "g01.csv.gz"))
+	require.NoError(t, err)
+	require.Equal(t, 1, len(files), "files=%v", files)
+	schemaFiles, err := filepath.Glob(filepath.Join(bdir, "*", "g01.schema.gz"))
+	require.NoError(t, err)
+	require.Equal(t, 1, len(schemaFiles), "files=%v", schemaFiles)
+
+	f, err := os.Open(files[0])
+	require.NoError(t, err)
+	defer f.Close()
+	r, err := gzip.NewReader(f)
+	require.NoError(t, err)
+	records, err := csv.NewReader(r).ReadAll()
+	require.NoError(t, err)
+
+	require.Equal(t, []string{"uid", "value", "lang", "facets"}, records[0])
+	rows := records[1:]
+	sort.Slice(rows, func(i, j int) bool { return rows[i][0]+rows[i][2] < rows[j][0]+rows[j][2] })
+	require.Equal(t, [][]string{
+		{"0x1", "pho\ton", "", ""},
+		{"0x2", "pho\ton", "en", ""},
+		{"0x3", "First Line\nSecondLine", "", ""},
+		{"0x5", "", "", ""},
+	}, rows)
+}
+
+func TestExportInvalidFormat(t *testing.T) {
+	require.NoError(t, ValidateExportFormat(""))
+	require.NoError(t, ValidateExportFormat(ExportFormatParquet))
+	require.Error(t, ValidateExportFormat("json"))
+}
+
 type skv struct {
 	attr   string
 	schema pb.SchemaUpdate
@@ -273,6 +323,18 @@ func TestToSchema(t *testing.T) {
 			},
 			expected: "<Alice:best>:string @reverse @lang . \n",
 		},
+		{
+			skv: &skv{
+				attr: "session",
+				schema: pb.SchemaUpdate{
+					Predicate: "session",
+					ValueType: pb.Posting_STRING,
+					Ttl:       86400,
+					TtlFacet:  "seen",
+				},
+			},
+			expected: "session:string @ttl(24h0m0s, seen) . \n",
+		},
 	}
 	for _, testCase := range testCases {
 		kv, err := toSchema(testCase.skv.attr, testCase.skv.schema)
@@ -0,0 +1,268 @@
+/*
+ * Copyright 2018 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package worker
+
+import (
+	"math/rand"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/dgraph-io/badger/y"
+	"github.com/dgraph-io/dgraph/conn"
+	"github.com/dgraph-io/dgraph/protos/pb"
+	"github.com/golang/glog"
+	"golang.org/x/net/context"
+)
+
+// Without gossip, every Alpha sends its membership to the Zero leader every few seconds, which
+// adds up for clusters with many Alphas. With gossip enabled, the members of a group instead
+// exchange their health among themselves: every round, each member bumps its own heartbeat and
+// swaps everything it knows with a few random peers. A member whose heartbeat hasn't gone up for
+// a while is suspected to be down. The leader of the group sends the health of all members to
+// Zero along with its own membership, and right away when a member becomes suspect or recovers,
+// so the other members only need to send theirs every gossipZeroInterval.
+
+const (
+	gossipFanout = 2
+	// A member is suspect if its heartbeat hasn't gone up for this many rounds.
+	gossipSuspectRounds = 5
+	gossipZeroInterval  = time.Minute
+	gossipTimeout       = 2 * time.Second
+)
+
+type gossipPeer struct {
+	health *pb.MemberHealth
+	seenAt time.Time // When the heartbeat last went up.
+}
+
+type gossiper struct {
+	sync.Mutex
+	// heartbeat starts at the time this Alpha started, so that it keeps going up across restarts.
+	heartbeat uint64
+	peers     map[uint64]*gossipPeer
+	suspects  map[uint64]bool
+}
+
+var gossip = &gossiper{
+	heartbeat: uint64(time.Now().UnixNano()),
+	peers:     make(map[uint64]*gossipPeer),
+	suspects:  make(map[uint64]bool),
+}
+
+// gossipEnabled tells whether the members of the groups gossip their health.
+func gossipEnabled() bool {
+	return Config.GossipInterval > 0
+}
+
+func (gs *gossiper) suspectAfter() time.Duration {
+	return gossipSuspectRounds * Config.GossipInterval
+}
+
+// self returns the health of this Alpha, with its heartbeat bumped if bump is set.
+func (gs *gossiper) self(bump bool) *pb.MemberHealth {
+	g := groups()
+	gs.Lock()
+	if bump {
+		gs.heartbeat++
+	}
+	hb := gs.heartbeat
+	gs.Unlock()
+	return &pb.MemberHealth{
+		Id:           g.Node.Id,
+		Addr:         Config.MyAddr,
+		Leader:       g.Node.AmLeader(),
+		Heartbeat:    hb,
+		AppliedIndex: g.Node.Applied.DoneUntil(),
+	}
+}
+
+// digest returns everything this Alpha knows about the health of its group, itself included.
+func (gs *gossiper) digest(self *pb.MemberHealth) *pb.GossipDigest {
+	d := &pb.GossipDigest{GroupId: groups().groupId(), Members: []*pb.MemberHealth{self}}
+	now := time.Now()
+	gs.Lock()
+	defer gs.Unlock()
+	for _, p := range gs.peers {
+		h := *p.health
+		h.UnseenMs = uint64(now.Sub(p.seenAt) / time.Millisecond)
+		d.Members = append(d.Members, &h)
+	}
+	sort.Slice(d.Members, func(i, j int) bool { return d.Members[i].Id < d.Members[j].Id })
+	return d
+}
+
+// merge keeps the health of the members of the group in d which is newer than what's known.
+func (gs *gossiper) merge(d *pb.GossipDigest) {
+	g := groups()
+	if d.GroupId != g.groupId() {
+		return
+	}
+	members := g.members(d.GroupId)
+	now := time.Now()
+	gs.Lock()
+	defer gs.Unlock()
+	for _, h := range d.Members {
+		if h.Id == g.Node.Id {
+			continue
+		}
+		if _, ok := members[h.Id]; !ok {
+			continue
+		}
+		p, ok := gs.peers[h.Id]
+		if ok && h.Heartbeat <= p.health.Heartbeat {
+			continue
+		}
+		// A heartbeat relayed by another peer was seen by that peer some time ago.
+		seenAt := now.Add(-time.Duration(h.UnseenMs) * time.Millisecond)
+		if ok && seenAt.Before(p.seenAt) {
+			seenAt = p.seenAt
+		}
+		hc := *h
+		hc.UnseenMs = 0
+		gs.peers[h.Id] = &gossipPeer{health: &hc, seenAt: seenAt}
+	}
+	for id := range gs.peers {
+		if _, ok := members[id]; !ok {
+			delete(gs.peers, id)
+			delete(gs.suspects, id)
+		}
+	}
+}
+
+// updateSuspects refreshes the set of suspect members, and tells whether it changed.
+func (gs *gossiper) updateSuspects(members map[uint64]*pb.Member, self uint64) bool {
+	now := time.Now()
+	gs.Lock()
+	defer gs.Unlock()
+	changed := false
+	for id, m := range members {
+		if id == self {
+			continue
+		}
+		p, ok := gs.peers[id]
+		if !ok {
+			// Give new members a chance to be heard of.
+			gs.peers[id] = &gossipPeer{health: &pb.MemberHealth{Id: id, Addr: m.Addr}, seenAt: now}
+			continue
+		}
+		suspect := now.Sub(p.seenAt) > gs.suspectAfter()
+		if suspect != gs.suspects[id] {
+			changed = true
+			if suspect {
+				glog.Warningf("Gossip: member %d of group %d is suspect. Not heard of for %s.",
+					id, groups().groupId(), gs.suspectAfter())
+				gs.suspects[id] = true
+			} else {
+				glog.Infof("Gossip: member %d of group %d is back.", id, groups().groupId())
+				delete(gs.suspects, id)
+			}
+		}
+	}
+	return changed
+}
+
+// suspectPeers returns the ids of the members of this group suspected to be down.
+func (gs *gossiper) suspectPeers() []uint64 {
+	gs.Lock()
+	defer gs.Unlock()
+	var ids []uint64
+	for id := range gs.suspects {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	return ids
+}
+
+// pickPeers returns the addresses of up to n random members of the group other than this Alpha.
+func pickPeers(members map[uint64]*pb.Member, self uint64, n int) []string {
+	var addrs []string
+	for id, m := range members {
+		if id != self {
+			addrs = append(addrs, m.Addr)
+		}
+	}
+	rand.Shuffle(len(addrs), func(i, j int) { addrs[i], addrs[j] = addrs[j], addrs[i] })
+	if len(addrs) > n {
+		addrs = addrs[:n]
+	}
+	return addrs
+}
+
+// round gossips with a few peers.
+func (gs *gossiper) round() {
+	g := groups()
+	members := g.members(g.groupId())
+	d := gs.digest(gs.self(true))
+
+	var wg sync.WaitGroup
+	for _, addr := range pickPeers(members, g.Node.Id, gossipFanout) {
+		pl, err := conn.Get().Get(addr)
+		if err != nil {
+			continue
+		}
+		wg.Add(1)
+		go func(pl *conn.Pool) {
+			defer wg.Done()
+			ctx, cancel := context.WithTimeout(context.Background(), gossipTimeout)
+			defer cancel()
+			reply, err := pb.NewWorkerClient(pl.Get()).Gossip(ctx, d)
+			if err != nil {
+				glog.V(2).Infof("Unable to gossip with %s: %v", pl.Addr, err)
+				return
+			}
+			gs.merge(reply)
+		}(pl)
+	}
+	wg.Wait()
+
+	if gs.updateSuspects(members, g.Node.Id) && g.Node.AmLeader() {
+		// Let Zero know right away.
+		g.triggerMembershipSync()
+	}
+}
+
+// run gossips every Config.GossipInterval, until closer is signalled.
+func (gs *gossiper) run(closer *y.Closer) {
+	defer closer.Done() // CLOSER:1
+	if !gossipEnabled() {
+		return
+	}
+
+	ticker := time.NewTicker(Config.GossipInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			gs.round()
+		case <-closer.HasBeenClosed():
+			return
+		}
+	}
+}
+
+// Gossip merges the health known by a peer, and replies with the health known by this Alpha.
+func (w *grpcWorker) Gossip(ctx context.Context, d *pb.GossipDigest) (*pb.GossipDigest, error) {
+	if ctx.Err() != nil {
+		return &pb.GossipDigest{}, ctx.Err()
+	}
+	if !gossipEnabled() {
+		return &pb.GossipDigest{}, nil
+	}
+	gossip.merge(d)
+	return gossip.digest(gossip.self(false)), nil
+}
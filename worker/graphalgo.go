@@ -0,0 +1,312 @@
+/*
+ * Copyright 2018 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package worker
+
+import (
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/dgraph-io/dgo/protos/api"
+	"github.com/golang/glog"
+	otrace "go.opencensus.io/trace"
+	"golang.org/x/net/context"
+
+	"github.com/dgraph-io/dgraph/graphalgo"
+	"github.com/dgraph-io/dgraph/protos/pb"
+	"github.com/dgraph-io/dgraph/x"
+)
+
+// The graph algorithm jobs run their supersteps in the groups serving their predicate. Each group
+// holds the edges of the uids it serves as a partition of the graph, loaded at the read timestamp
+// of the job by its first request, and kept until the job is done. The edges never leave the
+// group: the supersteps carry the values of the nodes of the partition, and their results are
+// merged by the Alpha running the job. So any replica of the group can run a superstep.
+
+const (
+	// Subjects whose edges are read at once while loading a partition.
+	algoReadBatch = 10000
+	// How long a partition is kept after its last superstep, in case the job went away.
+	algoPartitionTTL = 10 * time.Minute
+)
+
+type algoKey struct {
+	attr   string
+	readTs uint64
+}
+
+type algoPartition struct {
+	*graphalgo.Partition
+	used time.Time
+}
+
+var algoPartitions struct {
+	sync.Mutex
+	parts map[algoKey]*algoPartition
+}
+
+// algoRequest asks a group to run a superstep of a graph algorithm over its partition of the
+// edges of a predicate at ReadTs. Without a superstep, it returns the nodes of the partition.
+type algoRequest struct {
+	Attr   string          `json:"attr"`
+	ReadTs uint64          `json:"read_ts"`
+	Step   *graphalgo.Step `json:"step,omitempty"`
+	// Release drops the partition, once the job is done.
+	Release bool `json:"release,omitempty"`
+}
+
+type algoResponse struct {
+	Nodes  []uint64              `json:"nodes,omitempty"`
+	Edges  int                   `json:"edges,omitempty"`
+	Result *graphalgo.StepResult `json:"result,omitempty"`
+}
+
+// AlgorithmGraph is the graph made of the edges of a uid predicate at a timestamp, split in the
+// partitions held by the groups serving the predicate.
+type AlgorithmGraph struct {
+	*graphalgo.Graph
+	attr   string
+	readTs uint64
+	gids   []uint32
+}
+
+// LoadAlgorithmGraph has the groups serving attr load their partitions of its edges at readTs,
+// and returns the graph they make.
+func LoadAlgorithmGraph(ctx context.Context, attr string, readTs uint64) (*AlgorithmGraph, error) {
+	attr, err := namespaceAttr(ctx, attr)
+	if err != nil {
+		return nil, err
+	}
+	tab := groups().Tablet(attr)
+	if tab == nil {
+		return nil, errUnservedTablet
+	}
+	g := &AlgorithmGraph{attr: attr, readTs: readTs, gids: shardGroups(tab)}
+	resps, err := g.send(ctx, func(int) *algoRequest {
+		return &algoRequest{Attr: attr, ReadTs: readTs}
+	})
+	if err != nil {
+		g.Release()
+		return nil, err
+	}
+	nodes := make([][]uint64, len(resps))
+	var edges int
+	for i, resp := range resps {
+		nodes[i] = resp.Nodes
+		edges += resp.Edges
+	}
+	g.Graph = graphalgo.NewGraph(nodes, edges)
+	return g, nil
+}
+
+// Run has the groups run the supersteps over their partitions, which are in the order of the
+// nodes the graph was made of.
+func (g *AlgorithmGraph) Run(ctx context.Context,
+	steps []*graphalgo.Step) ([]*graphalgo.StepResult, error) {
+	if len(steps) != len(g.gids) {
+		return nil, x.Errorf("Got %d supersteps for %d partitions", len(steps), len(g.gids))
+	}
+	resps, err := g.send(ctx, func(i int) *algoRequest {
+		return &algoRequest{Attr: g.attr, ReadTs: g.readTs, Step: steps[i]}
+	})
+	if err != nil {
+		return nil, err
+	}
+	results := make([]*graphalgo.StepResult, len(resps))
+	for i, resp := range resps {
+		if resp.Result == nil {
+			resp.Result = &graphalgo.StepResult{}
+		}
+		results[i] = resp.Result
+	}
+	return results, nil
+}
+
+// Release has the groups drop their partitions.
+func (g *AlgorithmGraph) Release() {
+	// The job could have been cancelled, so don't use its context.
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+	if _, err := g.send(ctx, func(int) *algoRequest {
+		return &algoRequest{Attr: g.attr, ReadTs: g.readTs, Release: true}
+	}); err != nil {
+		glog.Warningf("While releasing the partitions of %s at %d: %v", g.attr, g.readTs, err)
+	}
+}
+
+// send sends the request built for every partition to its group, and returns their responses.
+func (g *AlgorithmGraph) send(ctx context.Context,
+	req func(i int) *algoRequest) ([]*algoResponse, error) {
+	resps := make([]*algoResponse, len(g.gids))
+	errCh := make(chan error, len(g.gids))
+	for i, gid := range g.gids {
+		go func(i int, gid uint32) {
+			var err error
+			resps[i], err = algorithmStepInGroup(ctx, req(i), gid)
+			errCh <- err
+		}(i, gid)
+	}
+	var rerr error
+	for range g.gids {
+		if err := <-errCh; err != nil && rerr == nil {
+			rerr = err
+		}
+	}
+	return resps, rerr
+}
+
+func algorithmStepInGroup(ctx context.Context, req *algoRequest,
+	gid uint32) (*algoResponse, error) {
+	ctx, span := otrace.StartSpan(ctx, "worker.algorithmStepInGroup")
+	defer span.End()
+	span.AddAttributes(otrace.StringAttribute("attr", req.Attr),
+		otrace.Int64Attribute("group", int64(gid)))
+
+	if groups().ServesGroup(gid) {
+		return algorithmStep(ctx, req)
+	}
+	data, err := json.Marshal(req)
+	if err != nil {
+		return nil, err
+	}
+	result, err := processWithRetry(ctx, gid,
+		func(ctx context.Context, c pb.WorkerClient) (interface{}, error) {
+			return c.AlgorithmStep(ctx, &api.Payload{Data: data})
+		})
+	if err != nil {
+		return nil, err
+	}
+	var resp algoResponse
+	if err := json.Unmarshal(result.(*api.Payload).Data, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// AlgorithmStep runs a superstep of a graph algorithm over the partition held by this group.
+func (w *grpcWorker) AlgorithmStep(ctx context.Context, p *api.Payload) (*api.Payload, error) {
+	if ctx.Err() != nil {
+		return &api.Payload{}, ctx.Err()
+	}
+	var req algoRequest
+	if err := json.Unmarshal(p.Data, &req); err != nil {
+		return &api.Payload{}, err
+	}
+	resp, err := algorithmStep(ctx, &req)
+	if err != nil {
+		return &api.Payload{}, err
+	}
+	js, err := json.Marshal(resp)
+	if err != nil {
+		return &api.Payload{}, err
+	}
+	return &api.Payload{Data: js}, nil
+}
+
+func algorithmStep(ctx context.Context, req *algoRequest) (*algoResponse, error) {
+	key := algoKey{attr: req.Attr, readTs: req.ReadTs}
+	if req.Release {
+		algoPartitions.Lock()
+		delete(algoPartitions.parts, key)
+		algoPartitions.Unlock()
+		return &algoResponse{}, nil
+	}
+	p, err := partitionOf(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	if req.Step == nil {
+		return &algoResponse{Nodes: p.Nodes(), Edges: p.NumEdges()}, nil
+	}
+	res, err := p.Step(req.Step)
+	if err != nil {
+		return nil, err
+	}
+	return &algoResponse{Result: res}, nil
+}
+
+// partitionOf returns the partition of the graph of key held by this group, loading it from the
+// edges of the predicate if need be.
+func partitionOf(ctx context.Context, key algoKey) (*graphalgo.Partition, error) {
+	algoPartitions.Lock()
+	now := time.Now()
+	for k, p := range algoPartitions.parts {
+		if now.Sub(p.used) > algoPartitionTTL {
+			delete(algoPartitions.parts, k)
+		}
+	}
+	if p, ok := algoPartitions.parts[key]; ok {
+		p.used = now
+		algoPartitions.Unlock()
+		return p.Partition, nil
+	}
+	algoPartitions.Unlock()
+
+	p, err := loadPartition(ctx, key.attr, key.readTs)
+	if err != nil {
+		return nil, err
+	}
+	algoPartitions.Lock()
+	defer algoPartitions.Unlock()
+	if algoPartitions.parts == nil {
+		algoPartitions.parts = make(map[algoKey]*algoPartition)
+	}
+	// Another request for the same partition could have loaded it in the meantime.
+	if cur, ok := algoPartitions.parts[key]; ok {
+		return cur.Partition, nil
+	}
+	algoPartitions.parts[key] = &algoPartition{Partition: p, used: time.Now()}
+	return p, nil
+}
+
+// loadPartition reads the edges of the uid predicate attr at readTs, of the uids this group holds.
+func loadPartition(ctx context.Context, attr string, readTs uint64) (*graphalgo.Partition, error) {
+	gid := groups().groupId()
+	res, err := processTask(ctx, &pb.Query{
+		Attr:    attr,
+		SrcFunc: &pb.SrcFunction{Name: "has"},
+		ReadTs:  readTs,
+	}, gid)
+	if err != nil {
+		return nil, err
+	}
+	var subjects []uint64
+	if len(res.UidMatrix) > 0 {
+		subjects = res.UidMatrix[0].Uids
+	}
+
+	edges := make(map[uint64][]uint64, len(subjects))
+	for start := 0; start < len(subjects); start += algoReadBatch {
+		end := start + algoReadBatch
+		if end > len(subjects) {
+			end = len(subjects)
+		}
+		batch := subjects[start:end]
+		res, err := processTask(ctx, &pb.Query{
+			Attr:    attr,
+			UidList: &pb.List{Uids: batch},
+			ReadTs:  readTs,
+		}, gid)
+		if err != nil {
+			return nil, err
+		}
+		for i, ul := range res.UidMatrix {
+			edges[batch[i]] = ul.Uids
+		}
+	}
+	return graphalgo.NewPartition(edges), nil
+}
@@ -0,0 +1,90 @@
+/*
+ * Copyright 2018 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package worker
+
+import (
+	"encoding/json"
+	"sync/atomic"
+	"testing"
+
+	"github.com/dgraph-io/dgo/protos/api"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/context"
+
+	"github.com/dgraph-io/dgraph/graphalgo"
+	"github.com/dgraph-io/dgraph/protos/pb"
+	"github.com/dgraph-io/dgraph/schema"
+	"github.com/dgraph-io/dgraph/x"
+)
+
+func TestAlgorithmGraph(t *testing.T) {
+	require.NoError(t, schema.ParseBytes([]byte("algo.follows: uid ."), 1))
+	gr.Lock()
+	gr.tablets["algo.follows"] = &pb.Tablet{GroupId: 1}
+	gr.Unlock()
+
+	for from, to := range map[uint64][]uint64{1: {2}, 2: {3}, 5: {6}} {
+		for _, uid := range to {
+			edge := &pb.DirectedEdge{Attr: "algo.follows", Entity: from, ValueId: uid}
+			addEdge(t, edge, getOrCreate(x.DataKey("algo.follows", from)))
+		}
+	}
+	readTs := atomic.LoadUint64(&ts)
+
+	ctx := context.Background()
+	g, err := LoadAlgorithmGraph(ctx, "algo.follows", readTs)
+	require.NoError(t, err)
+	require.Equal(t, 5, g.NumNodes())
+	require.Equal(t, 3, g.NumEdges())
+	algoPartitions.Lock()
+	require.Contains(t, algoPartitions.parts, algoKey{attr: "algo.follows", readTs: readTs})
+	algoPartitions.Unlock()
+
+	a, err := graphalgo.New(graphalgo.ConnectedComponents, g.Graph, graphalgo.Options{})
+	require.NoError(t, err)
+	_, err = graphalgo.Run(ctx, a, 0, g.Run, func(int, []int) error { return nil })
+	require.NoError(t, err)
+	labels := make(map[uint64]interface{})
+	for i := 0; i < g.NumNodes(); i++ {
+		labels[g.Uid(i)] = a.Value(i).Value
+	}
+	require.Equal(t, map[uint64]interface{}{
+		1: int64(1), 2: int64(1), 3: int64(1), 5: int64(5), 6: int64(5),
+	}, labels)
+
+	// The supersteps reach the groups the same way over the network.
+	req, err := json.Marshal(&algoRequest{
+		Attr:   "algo.follows",
+		ReadTs: readTs,
+		Step: &graphalgo.Step{
+			Algorithm: graphalgo.PageRank,
+			Ranks:     map[uint64]float64{1: 0.2, 2: 0.2, 3: 0.2, 5: 0.2, 6: 0.2},
+		},
+	})
+	require.NoError(t, err)
+	payload, err := (&grpcWorker{}).AlgorithmStep(ctx, &api.Payload{Data: req})
+	require.NoError(t, err)
+	var resp algoResponse
+	require.NoError(t, json.Unmarshal(payload.Data, &resp))
+	require.Equal(t, map[uint64]float64{2: 0.2, 3: 0.2, 6: 0.2}, resp.Result.Ranks)
+	require.InDelta(t, 0.6, resp.Result.Spread, 1e-9)
+
+	g.Release()
+	algoPartitions.Lock()
+	require.Empty(t, algoPartitions.parts)
+	algoPartitions.Unlock()
+}
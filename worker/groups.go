@@ -52,10 +52,18 @@ type groupi struct {
 	triggerCh chan struct{} // Used to trigger membership sync
 	delPred   chan struct{} // Ensures that predicate move doesn't happen when deletion is ongoing.
 	closer    *y.Closer
+
+	lastZeroUpdate int64 // Unix nanos of the last membership state from Zero, accessed atomically.
+	sums           tabletSums
+	zeros          *conn.Discovery // Where to find the Zeros, from --zero.
+	zeroTries      uint32          // Accessed atomically.
 }
 
 var gr *groupi
 
+// spareRetryInterval is how often a spare Alpha asks Zero for a group to join.
+const spareRetryInterval = 10 * time.Second
+
 func groups() *groupi {
 	return gr
 }
@@ -82,6 +90,9 @@ func StartRaftNodes(walStore *badger.DB, bindall bool) {
 	x.AssertTruef(len(Config.ZeroAddr) > 0, "Providing dgraphzero address is mandatory.")
 	x.AssertTruef(Config.ZeroAddr != Config.MyAddr,
 		"Dgraph Zero address and Dgraph address (IP:Port) can't be the same.")
+	var err error
+	gr.zeros, err = conn.ParseDiscovery(Config.ZeroAddr)
+	x.Checkf(err, "While parsing --zero")
 
 	if Config.RaftId == 0 {
 		id, err := raftwal.RaftId(walStore)
@@ -93,9 +104,9 @@ func StartRaftNodes(walStore *badger.DB, bindall bool) {
 	// Successfully connect with dgraphzero, before doing anything else.
 
 	// Connect with Zero leader and figure out what group we should belong to.
-	m := &pb.Member{Id: Config.RaftId, Addr: Config.MyAddr}
+	m := &pb.Member{Id: Config.RaftId, Addr: Config.MyAddr, Learner: Config.Learner,
+		Spare: Config.Spare, Features: x.Features, Replicas: uint32(Config.GroupReplicas)}
 	var connState *pb.ConnectionState
-	var err error
 	for { // Keep on retrying. See: https://github.com/dgraph-io/dgraph/issues/2289
 		pl := gr.connToZeroLeader()
 		if pl == nil {
@@ -103,6 +114,11 @@ func StartRaftNodes(walStore *badger.DB, bindall bool) {
 		}
 		zc := pb.NewZeroClient(pl.Get())
 		connState, err = zc.Connect(gr.ctx, m)
+		if err == nil && Config.Spare && connState.GetMember().GetGroupId() == 0 {
+			// No group needs this spare yet.
+			time.Sleep(spareRetryInterval)
+			continue
+		}
 		if err == nil || grpc.ErrorDesc(err) == x.ErrReuseRemovedId.Error() {
 			break
 		}
@@ -131,11 +147,13 @@ func StartRaftNodes(walStore *badger.DB, bindall bool) {
 	gr.Node.InitAndStartNode()
 	x.UpdateHealthStatus(true)
 
-	gr.closer = y.NewCloser(4) // Match CLOSER:1 in this file.
+	gr.closer = y.NewCloser(6) // Match CLOSER:1 in this file, invalidate.go and gossip.go.
 	go gr.sendMembershipUpdates()
 	go gr.receiveMembershipUpdates()
 	go gr.cleanupTablets()
 	go gr.processOracleDeltaStream()
+	go invalidations.run(gr.closer)
+	go gossip.run(gr.closer)
 
 	gr.proposeInitialSchema()
 }
@@ -224,6 +242,19 @@ func (g *groupi) calculateTabletSizes() map[string]*pb.Tablet {
 	return tablets
 }
 
+// updateChecksums computes the checksums of the tablets served by this Alpha, as of the last
+// snapshot.
+func (g *groupi) updateChecksums() {
+	snap, err := g.Node.Snapshot()
+	if err != nil || snap.ReadTs == 0 {
+		// No snapshot yet, which is what the replicas compare the checksums at.
+		return
+	}
+	if err := g.sums.update(pstore, snap.ReadTs, g.ServesTablet); err != nil {
+		glog.Errorf("While computing checksums of tablets at %d: %v", snap.ReadTs, err)
+	}
+}
+
 func MaxLeaseId() uint64 {
 	g := groups()
 	g.RLock()
@@ -234,6 +265,33 @@ func MaxLeaseId() uint64 {
 	return g.state.MaxLeaseId
 }
 
+// StrictSchema returns whether the cluster rejects mutations not conforming to the schema.
+func StrictSchema() bool {
+	g := groups()
+	g.RLock()
+	defer g.RUnlock()
+	return g.state.GetStrictSchema()
+}
+
+// ReplicaOf returns the Zero of the primary cluster, if the cluster is a read-only replica of it.
+func ReplicaOf() string {
+	g := groups()
+	g.RLock()
+	defer g.RUnlock()
+	if r := g.state.GetReplication(); r != nil && !r.Promoted {
+		return r.Source
+	}
+	return ""
+}
+
+// ClusterFeatures returns the features of the API every Alpha of the cluster has.
+func ClusterFeatures() []string {
+	g := groups()
+	g.RLock()
+	defer g.RUnlock()
+	return x.ClusterFeatures(g.state)
+}
+
 func UpdateMembershipState(ctx context.Context) error {
 	g := groups()
 	p := g.Leader(0)
@@ -262,6 +320,7 @@ func (g *groupi) applyState(state *pb.MembershipState) {
 	}
 
 	g.state = state
+	applyChangeStreamRoutes(state.ChangeStreamRoutes)
 
 	// While restarting we fill Node information after retrieving initial state.
 	if g.Node != nil {
@@ -277,6 +336,7 @@ func (g *groupi) applyState(state *pb.MembershipState) {
 	}
 
 	// Sometimes this can cause us to lose latest tablet info, but that shouldn't cause any issues.
+	prev := g.tablets
 	g.tablets = make(map[string]*pb.Tablet)
 	for gid, group := range g.state.Groups {
 		for _, member := range group.Members {
@@ -296,6 +356,30 @@ func (g *groupi) applyState(state *pb.MembershipState) {
 			conn.Get().Connect(member.Addr)
 		}
 	}
+	if g.Node != nil && g.Node.AmLeader() {
+		g.cleanSplitTablets(prev)
+	}
+}
+
+// cleanSplitTablets has this group delete the uids it no longer holds, of the tablets whose part
+// held by it has just been split.
+func (g *groupi) cleanSplitTablets(prev map[string]*pb.Tablet) {
+	g.AssertLock()
+	gid := atomic.LoadUint32(&g.gid)
+	for attr, tab := range g.tablets {
+		old, ok := prev[attr]
+		if !ok || len(tab.Shards) == len(old.Shards) {
+			continue
+		}
+		oldRange, held := tabletShard(old, gid)
+		newRange, holds := tabletShard(tab, gid)
+		if !held || !holds || newRange == nil {
+			continue
+		}
+		if oldRange == nil || *oldRange != *newRange {
+			go g.Node.proposeCleanShard(attr, newRange)
+		}
+	}
 }
 
 func (g *groupi) ServesGroup(gid uint32) bool {
@@ -314,15 +398,16 @@ func (g *groupi) BelongsTo(key string) uint32 {
 
 func (g *groupi) ServesTabletRW(key string) bool {
 	tablet := g.Tablet(key)
-	if tablet != nil && !tablet.ReadOnly && tablet.GroupId == groups().groupId() {
+	if tablet != nil && !tablet.ReadOnly && servedBy(tablet, groups().groupId()) {
 		return true
 	}
 	return false
 }
 
+// ServesTablet tells whether this group holds the tablet, or a part of it if it's split.
 func (g *groupi) ServesTablet(key string) bool {
 	tablet := g.Tablet(key)
-	if tablet != nil && tablet.GroupId == groups().groupId() {
+	if tablet != nil && servedBy(tablet, groups().groupId()) {
 		return true
 	}
 	return false
@@ -388,6 +473,10 @@ func (g *groupi) AnyTwoServers(gid uint32) []string {
 	}
 	var res []string
 	for _, m := range group.Members {
+		if m.Learner {
+			// Learners only serve the queries made to them, as they can lag behind.
+			continue
+		}
 		// map iteration gives us members in no particular order.
 		res = append(res, m.Addr)
 		if len(res) >= 2 {
@@ -427,11 +516,12 @@ func (g *groupi) AnyServer(gid uint32) *conn.Pool {
 	return nil
 }
 
+// MyPeer returns another voting member of the group of this Alpha which isn't draining, if any.
 func (g *groupi) MyPeer() (uint64, bool) {
 	members := g.members(g.groupId())
 	if members != nil {
 		for _, m := range members {
-			if m.Id != g.Node.Id {
+			if m.Id != g.Node.Id && !m.Learner && !m.Draining {
 				return m.Id, true
 			}
 		}
@@ -513,7 +603,7 @@ func (g *groupi) connToZeroLeader() *conn.Pool {
 		}
 		pl := g.AnyServer(0)
 		if pl == nil {
-			pl = conn.Get().Connect(Config.ZeroAddr)
+			pl = g.discoverZero()
 		}
 		if pl == nil {
 			glog.V(1).Infof("No healthy Zero server found. Retrying...")
@@ -527,14 +617,37 @@ func (g *groupi) connToZeroLeader() *conn.Pool {
 	}
 }
 
+// discoverZero connects to one of the Zeros found through --zero, another one every call. They're
+// resolved again every call too, as they come and go.
+func (g *groupi) discoverZero() *conn.Pool {
+	ctx, cancel := context.WithTimeout(g.ctx, 10*time.Second)
+	defer cancel()
+	addrs, err := g.zeros.Resolve(ctx)
+	if err != nil {
+		glog.Warningf("While finding the Zeros through %s: %v", g.zeros, err)
+		return nil
+	}
+	if len(addrs) == 0 {
+		glog.Warningf("No Zero found through %s", g.zeros)
+		return nil
+	}
+	try := atomic.AddUint32(&g.zeroTries, 1)
+	return conn.Get().Connect(addrs[int(try)%len(addrs)])
+}
+
 func (g *groupi) doSendMembership(tablets map[string]*pb.Tablet) error {
 	leader := g.Node.AmLeader()
 	member := &pb.Member{
-		Id:         Config.RaftId,
-		GroupId:    g.groupId(),
-		Addr:       Config.MyAddr,
-		Leader:     leader,
-		LastUpdate: uint64(time.Now().Unix()),
+		Id:               Config.RaftId,
+		GroupId:          g.groupId(),
+		Addr:             Config.MyAddr,
+		Leader:           leader,
+		LastUpdate:       uint64(time.Now().Unix()),
+		Checksums:        g.sums.checksums(),
+		Learner:          Config.Learner,
+		SnapshotProgress: snapshotTransfer.progress(),
+		Features:         x.Features,
+		Draining:         IsDraining(),
 	}
 	group := &pb.Group{
 		Members: make(map[uint64]*pb.Member),
@@ -546,6 +659,10 @@ func (g *groupi) doSendMembership(tablets map[string]*pb.Tablet) error {
 		if snap, err := g.Node.Snapshot(); err == nil {
 			group.SnapshotTs = snap.ReadTs
 		}
+		if gossipEnabled() {
+			// The leader speaks for the health of the whole group.
+			group.Health = gossip.digest(gossip.self(false)).Members
+		}
 	}
 
 	pl := g.connToZeroLeader()
@@ -566,7 +683,9 @@ func (g *groupi) doSendMembership(tablets map[string]*pb.Tablet) error {
 }
 
 // sendMembershipUpdates sends the membership update to Zero leader. If this Alpha is the leader, it
-// would also calculate the tablet sizes and send them to Zero.
+// would also calculate the tablet sizes and send them to Zero. With gossip, only the leader sends
+// its membership every 10s, the other members let it speak for them and only send theirs every
+// gossipZeroInterval.
 func (g *groupi) sendMembershipUpdates() {
 	defer g.closer.Done() // CLOSER:1
 
@@ -594,7 +713,11 @@ func (g *groupi) sendMembershipUpdates() {
 		case <-g.closer.HasBeenClosed():
 			return
 		case <-fastTicker.C:
-			if time.Since(lastSent) > 10*time.Second {
+			interval := 10 * time.Second
+			if gossipEnabled() && !g.Node.AmLeader() {
+				interval = gossipZeroInterval
+			}
+			if time.Since(lastSent) > interval {
 				// On start of node if it becomes a leader, we would send tablets size for sure.
 				g.triggerMembershipSync()
 			}
@@ -609,7 +732,14 @@ func (g *groupi) sendMembershipUpdates() {
 				lastSent = time.Now()
 			}
 		case <-slowTicker.C:
+			// Every replica sends the checksums of its tablets, for Zero to compare them.
+			g.updateChecksums()
 			if !g.Node.AmLeader() {
+				if err := g.doSendMembership(nil); err != nil {
+					glog.Errorf("While sending membership update with checksums: %v", err)
+				} else {
+					lastSent = time.Now()
+				}
 				break // breaks select case, not for loop.
 			}
 			tablets := g.calculateTabletSizes()
@@ -637,6 +767,29 @@ func (g *groupi) sendMembershipUpdates() {
 	}
 }
 
+// connToMembershipZero returns the Zero to stream the membership state from. With
+// --zero_follower_reads, the Alphas spread over the healthy voting Zeros by Raft ID, so that they
+// don't all stream from the leader.
+func (g *groupi) connToMembershipZero() *conn.Pool {
+	if !Config.ZeroFollowerReads {
+		return g.connToZeroLeader()
+	}
+	var addrs []string
+	for _, m := range g.members(0) {
+		if !m.Learner {
+			addrs = append(addrs, m.Addr)
+		}
+	}
+	sort.Strings(addrs)
+	for i := range addrs {
+		addr := addrs[(int(Config.RaftId)+i)%len(addrs)]
+		if pl, err := conn.Get().Get(addr); err == nil {
+			return pl
+		}
+	}
+	return g.connToZeroLeader()
+}
+
 // receiveMembershipUpdates receives membership updates from ANY Zero server. This is the main
 // connection which tells Alpha about the state of the cluster, including the latest Zero leader.
 // All the other connections to Zero, are only made only to the leader.
@@ -653,14 +806,14 @@ START:
 	default:
 	}
 
-	pl := g.connToZeroLeader()
+	pl := g.connToMembershipZero()
 	// We should always have some connection to dgraphzero.
 	if pl == nil {
 		glog.Warningln("Membership update: No Zero server known.")
 		time.Sleep(time.Second)
 		goto START
 	}
-	glog.Infof("Got address of a Zero leader: %s", pl.Addr)
+	glog.Infof("Got address of a Zero to stream the membership from: %s", pl.Addr)
 
 	c := pb.NewZeroClient(pl.Get())
 	ctx, cancel := context.WithCancel(context.Background())
@@ -712,6 +865,7 @@ OUTER:
 			break OUTER
 		case state := <-stateCh:
 			lastRecv = time.Now()
+			atomic.StoreInt64(&g.lastZeroUpdate, lastRecv.UnixNano())
 			g.applyState(state)
 		case <-ticker.C:
 			if time.Since(lastRecv) > 10*time.Second {
@@ -785,7 +939,7 @@ func (g *groupi) cleanupTablets() {
 				// request made to group zero fails. We might end up deleting a predicate
 				// on failure of network request even though no one else is serving this
 				// tablet.
-				if tablet := g.Tablet(pk.Attr); tablet != nil && tablet.GroupId != g.groupId() {
+				if tablet := g.Tablet(pk.Attr); tablet != nil && !servedBy(tablet, g.groupId()) {
 					if g.hasReadOnlyTablets() {
 						return
 					}
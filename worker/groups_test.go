@@ -0,0 +1,89 @@
+/*
+ * Copyright 2016-2018 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package worker
+
+import (
+	"sort"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dgraph-io/dgraph/protos/pb"
+)
+
+func TestConnToMembershipZero(t *testing.T) {
+	defer func(followerReads bool, raftId uint64) {
+		Config.ZeroFollowerReads, Config.RaftId = followerReads, raftId
+		gr.Lock()
+		gr.state = nil
+		gr.Unlock()
+	}(Config.ZeroFollowerReads, Config.RaftId)
+
+	var mu sync.Mutex
+	var zeros []*fakeReplica
+	for i := 0; i < 3; i++ {
+		z := startFakeReplica(t, &mu, func() error { return nil })
+		defer z.stop()
+		zeros = append(zeros, z)
+	}
+	leader, follower, learner := zeros[0].addr, zeros[1].addr, zeros[2].addr
+	// A Zero without a connection, as if it were down.
+	const down = "127.0.0.1:1"
+	setZeros := func(addrs ...string) {
+		state := &pb.MembershipState{Zeros: map[uint64]*pb.Member{
+			1: {Id: 1, Addr: leader, Leader: true},
+			3: {Id: 3, Addr: learner, Learner: true},
+		}}
+		for i, addr := range addrs {
+			state.Zeros[uint64(i+4)] = &pb.Member{Id: uint64(i + 4), Addr: addr}
+		}
+		gr.Lock()
+		gr.state = state
+		gr.Unlock()
+	}
+	zeroFor := func(raftId uint64) string {
+		Config.RaftId = raftId
+		return gr.connToMembershipZero().Addr
+	}
+
+	// Without --zero_follower_reads, the Alphas stream from the leader.
+	setZeros(follower)
+	Config.ZeroFollowerReads = false
+	require.Equal(t, leader, zeroFor(1))
+	require.Equal(t, leader, zeroFor(2))
+
+	// With it, they spread over the voters by Raft ID, learners left out.
+	Config.ZeroFollowerReads = true
+	voters := []string{leader, follower}
+	sort.Strings(voters)
+	require.Equal(t, voters[1], zeroFor(1))
+	require.Equal(t, voters[0], zeroFor(2))
+	require.Equal(t, voters[1], zeroFor(3))
+
+	// The Zeros that are down are skipped for the next one.
+	setZeros(follower, down)
+	voters = append(voters, down)
+	sort.Strings(voters)
+	for raftId := uint64(0); raftId < 3; raftId++ {
+		want := voters[raftId]
+		if want == down {
+			want = voters[(raftId+1)%3]
+		}
+		require.Equal(t, want, zeroFor(raftId))
+	}
+}
@@ -0,0 +1,273 @@
+/*
+ * Copyright 2018 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package worker
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"sync/atomic"
+	"time"
+
+	"github.com/dgraph-io/badger"
+	"github.com/dgraph-io/dgo/protos/api"
+	"github.com/dgraph-io/dgraph/x"
+	"golang.org/x/net/context"
+)
+
+// HealthStatus is the status of an Alpha, or of one of the things it depends on. An Alpha which
+// is degraded still serves requests, but needs attention. One which is draining only serves its
+// clients until they're done, for it to be restarted. One which is unhealthy can't serve.
+type HealthStatus string
+
+const (
+	Healthy   HealthStatus = "healthy"
+	Degraded  HealthStatus = "degraded"
+	Draining  HealthStatus = "draining"
+	Unhealthy HealthStatus = "unhealthy"
+)
+
+// worse returns the worse of both statuses.
+func (s HealthStatus) worse(o HealthStatus) HealthStatus {
+	rank := map[HealthStatus]int{Healthy: 0, Degraded: 1, Draining: 2, Unhealthy: 3}
+	if rank[o] > rank[s] {
+		return o
+	}
+	return s
+}
+
+const (
+	zeroDegradedAfter  = 3 * time.Second
+	zeroUnhealthyAfter = 10 * time.Second
+	maxAppliedLag      = 1000
+	// Fractions of the disk that must stay free.
+	minDiskFree         = 0.10
+	minDiskFreeCritical = 0.02
+)
+
+// HealthCheck is the status of one of the things an Alpha depends on.
+type HealthCheck struct {
+	Name    string       `json:"name"`
+	Status  HealthStatus `json:"status"`
+	Message string       `json:"message,omitempty"`
+}
+
+// HealthReport is the health of an Alpha. Its Status tells whether it's ready to serve requests,
+// and Live whether the process itself works, which only a restart can fix if not.
+type HealthReport struct {
+	Status           HealthStatus  `json:"status"`
+	Live             bool          `json:"live"`
+	Version          string        `json:"version"`
+	Addr             string        `json:"addr"`
+	Id               uint64        `json:"id"`
+	Group            uint32        `json:"group"`
+	Leader           bool          `json:"leader"`
+	Uptime           int64         `json:"uptime"`
+	LastZeroUpdate   *time.Time    `json:"last_zero_update,omitempty"`
+	AppliedIndex     uint64        `json:"applied_index"`
+	AppliedLag       uint64        `json:"applied_lag"`
+	PendingProposals int           `json:"pending_proposals"`
+	QueuedProposals  int           `json:"queued_proposals"`
+	DiskFree         uint64        `json:"disk_free_bytes"`
+	DiskTotal        uint64        `json:"disk_total_bytes"`
+	Checks           []HealthCheck `json:"checks"`
+}
+
+var startTime = time.Now()
+
+func (r *HealthReport) add(name string, status HealthStatus, format string, args ...interface{}) {
+	r.Checks = append(r.Checks, HealthCheck{
+		Name:    name,
+		Status:  status,
+		Message: fmt.Sprintf(format, args...),
+	})
+	r.Status = r.Status.worse(status)
+}
+
+// Health checks this Alpha, whether it's draining, and what it depends on: its connection to
+// Zero, the quorum of its group, its peers if they gossip, Badger and the disk holding the
+// postings in dir, how far behind the applied proposals are and how many proposals are pending.
+func Health(dir string) *HealthReport {
+	r := &HealthReport{
+		Status:  Healthy,
+		Live:    true,
+		Version: x.Version(),
+		Addr:    Config.MyAddr,
+		Uptime:  int64(time.Since(startTime).Seconds()),
+	}
+	if err := x.HealthCheck(); err != nil {
+		r.add("server", Unhealthy, "%v", err)
+	} else {
+		r.add("server", Healthy, "Ready")
+	}
+	if since := DrainingSince(); !since.IsZero() {
+		r.add("drain", Draining, "Draining since %s", since.Format(time.RFC3339))
+	}
+
+	g := groups()
+	if g == nil || g.Node == nil || g.Node.Raft() == nil {
+		r.add("raft", Unhealthy, "Not started yet")
+		return r
+	}
+	r.Id = g.Node.Id
+	r.Group = g.groupId()
+	r.checkZero(g)
+	r.checkQuorum(g.Node)
+	if gossipEnabled() {
+		r.checkPeers()
+	}
+	r.checkBadger()
+	r.checkDisk(dir)
+
+	if cap(pendingProposals) > 0 {
+		r.PendingProposals = len(pendingProposals)
+		r.QueuedProposals = int(atomic.LoadInt32(&limiter.waiting))
+		if r.PendingProposals >= cap(pendingProposals) {
+			r.add("proposals", Degraded,
+				"%d proposals pending and %d queued, new ones are being throttled",
+				r.PendingProposals, r.QueuedProposals)
+		} else {
+			r.add("proposals", Healthy, "%d of at most %d proposals pending",
+				r.PendingProposals, cap(pendingProposals))
+		}
+	}
+	return r
+}
+
+// HTTPStatus returns the HTTP status code of r, 503 if the Alpha isn't live or, to tell its
+// readiness, if it can't serve requests. It's 200 otherwise.
+func (r *HealthReport) HTTPStatus(readiness bool) int {
+	if !r.Live || (readiness && (r.Status == Unhealthy || r.Status == Draining)) {
+		return http.StatusServiceUnavailable
+	}
+	return http.StatusOK
+}
+
+func (r *HealthReport) checkZero(g *groupi) {
+	nanos := atomic.LoadInt64(&g.lastZeroUpdate)
+	if nanos == 0 {
+		r.add("zero", Unhealthy, "No membership update received from Zero yet")
+		return
+	}
+	last := time.Unix(0, nanos)
+	r.LastZeroUpdate = &last
+	switch since := time.Since(last).Round(time.Second); {
+	case since > zeroUnhealthyAfter:
+		r.add("zero", Unhealthy, "No membership update received from Zero for %s", since)
+	case since > zeroDegradedAfter:
+		r.add("zero", Degraded, "No membership update received from Zero for %s", since)
+	default:
+		r.add("zero", Healthy, "Connected")
+	}
+}
+
+func (r *HealthReport) checkQuorum(n *node) {
+	st := n.Raft().Status()
+	r.Leader = st.Lead == st.ID
+	r.AppliedIndex = n.Applied.DoneUntil()
+	if st.Commit > r.AppliedIndex {
+		r.AppliedLag = st.Commit - r.AppliedIndex
+	}
+	if r.AppliedLag > maxAppliedLag {
+		r.add("applied", Degraded, "Applied index %d is %d behind the committed index",
+			r.AppliedIndex, r.AppliedLag)
+	} else {
+		r.add("applied", Healthy, "Applied index %d is %d behind the committed index",
+			r.AppliedIndex, r.AppliedLag)
+	}
+
+	switch {
+	case st.Lead == 0:
+		r.add("quorum", Unhealthy, "Group %d has no leader", r.Group)
+	case r.Leader:
+		// Only the leader knows about the other members.
+		active := 1
+		for id, pr := range st.Progress {
+			if id != st.ID && pr.RecentActive {
+				active++
+			}
+		}
+		if 2*active <= len(st.Progress) {
+			r.add("quorum", Degraded, "Only %d of %d members of the group are active", active,
+				len(st.Progress))
+		} else {
+			r.add("quorum", Healthy, "Leader, %d of %d members of the group are active", active,
+				len(st.Progress))
+		}
+	default:
+		r.add("quorum", Healthy, "Following leader %#x", st.Lead)
+	}
+}
+
+func (r *HealthReport) checkPeers() {
+	if ids := gossip.suspectPeers(); len(ids) > 0 {
+		r.add("peers", Degraded, "Members %v of the group not heard of via gossip for %s", ids,
+			gossip.suspectAfter())
+		return
+	}
+	r.add("peers", Healthy, "All members of the group heard of via gossip")
+}
+
+func (r *HealthReport) checkBadger() {
+	txn := pstore.NewTransactionAt(math.MaxUint64, false)
+	defer txn.Discard()
+	if _, err := txn.Get(x.SchemaKey(x.PredicateListAttr)); err != nil &&
+		err != badger.ErrKeyNotFound {
+		r.add("badger", Unhealthy, "Unable to read: %v", err)
+		r.Live = false
+		return
+	}
+	r.add("badger", Healthy, "Readable")
+}
+
+func (r *HealthReport) checkDisk(dir string) {
+	free, total, err := x.DiskUsage(dir)
+	if err == x.ErrNoDiskUsage {
+		return
+	}
+	if err == nil && total == 0 {
+		err = x.Errorf("Disk size unknown")
+	}
+	if err != nil {
+		r.add("disk", Degraded, "Unable to get the usage of %s: %v", dir, err)
+		return
+	}
+	r.DiskFree, r.DiskTotal = free, total
+	frac := float64(free) / float64(total)
+	switch {
+	case frac < minDiskFreeCritical:
+		r.add("disk", Unhealthy, "Only %.1f%% of the disk is free", 100*frac)
+	case frac < minDiskFree:
+		r.add("disk", Degraded, "Only %.1f%% of the disk is free", 100*frac)
+	default:
+		r.add("disk", Healthy, "%.1f%% of the disk is free", 100*frac)
+	}
+}
+
+// Health returns the health report of this Alpha as JSON, for Zero to aggregate those of the
+// cluster.
+func (w *grpcWorker) Health(ctx context.Context, _ *api.Payload) (*api.Payload, error) {
+	if ctx.Err() != nil {
+		return &api.Payload{}, ctx.Err()
+	}
+	js, err := json.Marshal(Health(Config.PostingDir))
+	if err != nil {
+		return &api.Payload{}, err
+	}
+	return &api.Payload{Data: js}, nil
+}
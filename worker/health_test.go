@@ -0,0 +1,77 @@
+/*
+ * Copyright 2018 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package worker
+
+import (
+	"net/http"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestHealthStatusWorse(t *testing.T) {
+	require.Equal(t, Degraded, Healthy.worse(Degraded))
+	require.Equal(t, Unhealthy, Degraded.worse(Unhealthy))
+	require.Equal(t, Draining, Degraded.worse(Draining))
+	require.Equal(t, Unhealthy, Draining.worse(Unhealthy))
+	require.Equal(t, Unhealthy, Unhealthy.worse(Healthy))
+	require.Equal(t, Healthy, Healthy.worse(Healthy))
+}
+
+func TestHealthReportAdd(t *testing.T) {
+	r := &HealthReport{Status: Healthy}
+	r.add("zero", Healthy, "Connected")
+	require.Equal(t, Healthy, r.Status)
+	r.add("disk", Degraded, "Only %.1f%% of the disk is free", 5.0)
+	r.add("badger", Healthy, "Readable")
+	require.Equal(t, Degraded, r.Status)
+	require.Len(t, r.Checks, 3)
+	require.Equal(t, "Only 5.0% of the disk is free", r.Checks[1].Message)
+}
+
+func TestHealthDisk(t *testing.T) {
+	r := &HealthReport{Status: Healthy}
+	r.checkDisk(os.TempDir())
+	if r.DiskTotal == 0 {
+		// Not supported here.
+		return
+	}
+	require.Len(t, r.Checks, 1)
+	require.Equal(t, "disk", r.Checks[0].Name)
+	require.True(t, r.DiskFree <= r.DiskTotal)
+}
+
+func TestHealthHTTPStatus(t *testing.T) {
+	r := &HealthReport{Status: Healthy, Live: true}
+	r.add("disk", Degraded, "Only %.1f%% of the disk is free", 5.0)
+	require.Equal(t, http.StatusOK, r.HTTPStatus(false))
+	require.Equal(t, http.StatusOK, r.HTTPStatus(true))
+
+	// Without Zero or a leader, or while draining, the Alpha is live but not ready.
+	for _, st := range []HealthStatus{Unhealthy, Draining} {
+		r := &HealthReport{Status: Healthy, Live: true}
+		r.add("quorum", st, "Group 1 has no leader")
+		require.Equal(t, http.StatusOK, r.HTTPStatus(false))
+		require.Equal(t, http.StatusServiceUnavailable, r.HTTPStatus(true))
+	}
+
+	r = &HealthReport{Status: Healthy, Live: false}
+	r.add("badger", Unhealthy, "Unable to read")
+	require.Equal(t, http.StatusServiceUnavailable, r.HTTPStatus(false))
+	require.Equal(t, http.StatusServiceUnavailable, r.HTTPStatus(true))
+}
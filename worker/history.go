@@ -0,0 +1,80 @@
+/*
+ * Copyright 2018 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package worker
+
+import (
+	"sync"
+	"time"
+)
+
+// The past versions of the posting lists are kept on disk for Config.HistoryRetention, so that
+// queries can read the data as of a past timestamp. Timestamps don't tell the time, so those of
+// the snapshots are sampled as they're taken, to tell which timestamp was current when.
+
+type tsSample struct {
+	at time.Time
+	ts uint64
+}
+
+type tsHistory struct {
+	sync.Mutex
+	samples []tsSample // In increasing order of time and timestamp.
+}
+
+var history tsHistory
+
+// record samples that ts was current at t, and forgets the samples older than needed.
+func (h *tsHistory) record(t time.Time, ts uint64) {
+	h.Lock()
+	defer h.Unlock()
+	if n := len(h.samples); n > 0 && h.samples[n-1].ts >= ts {
+		return
+	}
+	h.samples = append(h.samples, tsSample{at: t, ts: ts})
+
+	// Only the latest sample before the retention window is needed.
+	cutoff := t.Add(-Config.HistoryRetention)
+	for len(h.samples) > 1 && !h.samples[1].at.After(cutoff) {
+		h.samples = h.samples[1:]
+	}
+}
+
+// tsAt returns the latest timestamp sampled at or before t, or zero if none was.
+func (h *tsHistory) tsAt(t time.Time) uint64 {
+	h.Lock()
+	defer h.Unlock()
+	var ts uint64
+	for _, s := range h.samples {
+		if s.at.After(t) {
+			break
+		}
+		ts = s.ts
+	}
+	return ts
+}
+
+// discardTs returns the timestamp below which a rollup at readTs, done at now, can discard the
+// versions of the posting lists. Those within the retention window are kept.
+func (h *tsHistory) discardTs(readTs uint64, now time.Time) uint64 {
+	if Config.HistoryRetention <= 0 {
+		return readTs
+	}
+	if ts := h.tsAt(now.Add(-Config.HistoryRetention)); ts < readTs {
+		return ts
+	}
+	return readTs
+}
@@ -0,0 +1,47 @@
+/*
+ * Copyright 2018 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package worker
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestHistoryDiscardTs(t *testing.T) {
+	defer func(c Options) { Config = c }(Config)
+	Config.HistoryRetention = time.Hour
+
+	var h tsHistory
+	start := time.Now()
+	// Nothing is discarded until the retention window has passed.
+	h.record(start, 10)
+	require.Zero(t, h.discardTs(10, start))
+
+	h.record(start.Add(30*time.Minute), 20)
+	h.record(start.Add(90*time.Minute), 30)
+	require.Equal(t, uint64(20), h.discardTs(30, start.Add(90*time.Minute)))
+	// The samples before the one current at the start of the window are forgotten.
+	require.Len(t, h.samples, 2)
+
+	// Rollups at older timestamps discard no further than those.
+	require.Equal(t, uint64(15), h.discardTs(15, start.Add(3*time.Hour)))
+
+	Config.HistoryRetention = 0
+	require.Equal(t, uint64(30), h.discardTs(30, start.Add(90*time.Minute)))
+}
@@ -0,0 +1,694 @@
+/*
+ * Copyright 2018 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package worker
+
+import (
+	"bytes"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/dgraph-io/badger"
+	"github.com/dgraph-io/dgraph/posting"
+	"github.com/dgraph-io/dgraph/protos/pb"
+	"github.com/dgraph-io/dgraph/schema"
+	"github.com/dgraph-io/dgraph/tok"
+	"github.com/dgraph-io/dgraph/x"
+	"github.com/golang/glog"
+	"golang.org/x/net/context"
+)
+
+// Indexes added by schema mutations are built by the mutation itself, unless it asks for them to
+// be built in the background (see pb.Mutations.BackgroundIndex) and the predicate has data. Every
+// replica then builds them on its own. Until the build is done, queries are served using the indexes of the predicate from before the
+// mutation (see schema.Served). The build reads the data as of the start ts of the schema mutation,
+// and writes complete index lists at that ts. Mutations of the predicate go on in the meantime, and
+// write their index entries both for the served indexes and the ones being built, as deltas at
+// their commit ts, which are read on top of the lists of the build. Rollups skip these lists until
+// the build is done, so as not to write complete lists lacking what the build adds.
+//
+// The end of a build is decided via Raft, so that a schema mutation of the predicate supersedes
+// the same builds on all replicas: once its own build is done, the leader proposes an IndexBuilt
+// marker. Applying it only records the decision. Every replica then finishes its own build in the
+// background, building again if it failed locally, and only serves the new indexes once done.
+// Builds are cancelled the same way.
+
+const (
+	indexBuildRunning   = "running"
+	indexBuildBuilt     = "built" // Built locally, waiting for the leader to finish it.
+	indexBuildFailed    = "failed"
+	indexBuildDone      = "done"
+	indexBuildCancelled = "cancelled"
+)
+
+// IndexBuildStatus is the progress of a background index build on this Alpha.
+type IndexBuildStatus struct {
+	Attr       string     `json:"attr"`
+	StartTs    uint64     `json:"start_ts"`
+	Status     string     `json:"status"`
+	Building   []string   `json:"building"`
+	Done       int64      `json:"done"`
+	Total      int64      `json:"total"`
+	Percent    float64    `json:"percent"`
+	ETA        string     `json:"eta,omitempty"`
+	StartedAt  time.Time  `json:"started_at"`
+	FinishedAt *time.Time `json:"finished_at,omitempty"`
+	Error      string     `json:"error,omitempty"`
+}
+
+type indexBuild struct {
+	attr       string
+	startTs    uint64
+	index      uint64          // Raft index of the schema mutation.
+	schema     pb.SchemaUpdate // Schema once the build is done.
+	served     pb.SchemaUpdate // Schema served to queries in the meantime.
+	rebuild    struct{ index, reverse, count bool }
+	done       int64 // Number of keys processed, accessed atomically.
+	total      int64
+	last       time.Time // When the last key was processed, used for throttling.
+	background bool      // Built in the background, rather than by the schema mutation.
+	cancel     context.CancelFunc
+	finished   chan struct{} // Closed once the local build returns.
+	applied    chan struct{} // Closed once the end of the build has been applied.
+	completed  chan struct{} // Closed once this replica is done with the build, as decided.
+
+	// Guarded by the mutex of indexBuilds.
+	status     string
+	err        error
+	startedAt  time.Time
+	finishedAt time.Time
+	decision   *pb.IndexBuilt // The end of the build, once applied.
+	stopped    bool           // The build was taken over by an abort or a schema mutation.
+}
+
+type indexBuildRegistry struct {
+	sync.Mutex
+	builds map[string]*indexBuild // Latest build of every predicate.
+	rate   int64                  // Keys processed per second by every build, 0 if unlimited.
+}
+
+var indexBuilds = &indexBuildRegistry{builds: make(map[string]*indexBuild)}
+
+// planIndexBuild returns the build needed to go from the old schema to the current one, or nil if
+// nothing has to be built. The served schema of the build keeps the indexes of the old schema
+// which remain valid.
+func planIndexBuild(old *pb.SchemaUpdate, current pb.SchemaUpdate) *indexBuild {
+	b := &indexBuild{attr: current.Predicate, schema: current, served: current}
+	if old == nil {
+		b.rebuild.index = current.Directive == pb.SchemaUpdate_INDEX
+		b.rebuild.reverse = current.Directive == pb.SchemaUpdate_REVERSE
+		b.rebuild.count = current.Count
+	} else {
+		if needReindexing(*old, current) {
+			b.rebuild.index = current.Directive == pb.SchemaUpdate_INDEX
+		} else if needsRebuildingReverses(*old, current) {
+			b.rebuild.reverse = current.Directive == pb.SchemaUpdate_REVERSE
+		}
+		b.rebuild.count = current.Count && !old.Count
+	}
+	if !b.rebuild.index && !b.rebuild.reverse && !b.rebuild.count {
+		return nil
+	}
+
+	if b.rebuild.index {
+		b.served.Tokenizer = nil
+		if old != nil && old.Directive == pb.SchemaUpdate_INDEX &&
+			old.ValueType == current.ValueType {
+			for _, t := range current.Tokenizer {
+				if x.HasString(old.Tokenizer, t) {
+					b.served.Tokenizer = append(b.served.Tokenizer, t)
+				}
+			}
+		}
+		if len(b.served.Tokenizer) == len(current.Tokenizer) {
+			// Only tokenizers were removed, whose entries can simply be deleted.
+			b.rebuild.index = false
+		} else if len(b.served.Tokenizer) == 0 {
+			b.served.Directive = pb.SchemaUpdate_NONE
+		}
+	}
+	if !b.rebuild.index && !b.rebuild.reverse && !b.rebuild.count {
+		return nil
+	}
+	if b.rebuild.reverse {
+		b.served.Directive = pb.SchemaUpdate_NONE
+	}
+	if b.rebuild.count {
+		b.served.Count = false
+	}
+	return b
+}
+
+// what returns the names of the indexes being built.
+func (b *indexBuild) what() []string {
+	var res []string
+	if b.rebuild.index {
+		for _, t := range b.schema.Tokenizer {
+			if !x.HasString(b.served.Tokenizer, t) {
+				res = append(res, t)
+			}
+		}
+	}
+	if b.rebuild.reverse {
+		res = append(res, "reverse")
+	}
+	if b.rebuild.count {
+		res = append(res, "count")
+	}
+	return res
+}
+
+// building returns whether the indexes of attr are being built.
+func (r *indexBuildRegistry) building(attr string) bool {
+	r.Lock()
+	defer r.Unlock()
+	b, ok := r.builds[attr]
+	return ok && b.active()
+}
+
+// pendingAttrs returns the predicates whose indexes this replica is still building, including
+// after the end of their builds was decided.
+func (r *indexBuildRegistry) pendingAttrs() map[string]bool {
+	r.Lock()
+	defer r.Unlock()
+	attrs := make(map[string]bool)
+	for attr, b := range r.builds {
+		if b.pending() {
+			attrs[attr] = true
+		}
+	}
+	return attrs
+}
+
+// active returns whether the end of the build wasn't applied yet.
+func (b *indexBuild) active() bool {
+	select {
+	case <-b.applied:
+		return false
+	default:
+		return true
+	}
+}
+
+// pending returns whether this replica isn't done with the build yet.
+func (b *indexBuild) pending() bool {
+	select {
+	case <-b.completed:
+		return false
+	default:
+		return true
+	}
+}
+
+// minIndex returns the lowest Raft index of the schema mutations whose builds are pending, or
+// zero. Snapshots must not go past it, so that the builds get started again when the log is
+// replayed.
+func (r *indexBuildRegistry) minIndex() uint64 {
+	r.Lock()
+	defer r.Unlock()
+	var min uint64
+	for _, b := range r.builds {
+		if b.pending() && (min == 0 || b.index < min) {
+			min = b.index
+		}
+	}
+	return min
+}
+
+// startIndexBuild serves queries from the old schema of the predicate and starts building its indexes. It
+// must be called from the apply loop, after the new schema has been set in memory.
+func (n *node) startIndexBuild(b *indexBuild, startTs, index uint64) error {
+	b.startTs = startTs
+	b.index = index
+	b.background = true
+	b.finished = make(chan struct{})
+	b.applied = make(chan struct{})
+	b.completed = make(chan struct{})
+
+	// Until the build is done, the disk keeps the served schema, which is what a restart recovers.
+	if err := writeSchema(b.attr, b.served); err != nil {
+		return err
+	}
+	schema.State().SetServed(b.attr, b.served)
+
+	var ctx context.Context
+	ctx, b.cancel = context.WithCancel(context.Background())
+	indexBuilds.Lock()
+	b.status = indexBuildRunning
+	b.startedAt = time.Now()
+	indexBuilds.builds[b.attr] = b
+	indexBuilds.Unlock()
+
+	glog.Infof("Building %v for predicate %s in the background", b.what(), b.attr)
+	go n.runIndexBuild(ctx, b)
+	return nil
+}
+
+func (n *node) runIndexBuild(ctx context.Context, b *indexBuild) {
+	defer close(b.completed)
+	err := b.run(ctx)
+	indexBuilds.Lock()
+	b.err = err
+	if err != nil {
+		b.status = indexBuildFailed
+	} else {
+		b.status = indexBuildBuilt
+	}
+	indexBuilds.Unlock()
+	close(b.finished)
+
+	switch {
+	case ctx.Err() != nil:
+		// Cancelled, or stopped.
+	case err != nil:
+		glog.Errorf("While building %v for predicate %s: %v", b.what(), b.attr, err)
+	default:
+		glog.Infof("Done building %v for predicate %s", b.what(), b.attr)
+	}
+
+	// The leader decides the end of the build for the whole group. If it failed locally, a
+	// follower waits for it, and builds again if the leader succeeded.
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+	for b.active() && ctx.Err() == nil {
+		if n.AmLeader() {
+			ib := &pb.IndexBuilt{Schema: &b.schema, StartTs: b.startTs}
+			if err != nil {
+				ib.Cancelled = true
+				ib.Reason = err.Error()
+			}
+			pctx, cancel := context.WithTimeout(ctx, time.Minute)
+			perr := n.proposeAndWait(pctx, &pb.Proposal{IndexBuilt: ib})
+			cancel()
+			if perr == nil {
+				break
+			}
+			glog.Warningf("While proposing the end of the build for %s: %v", b.attr, perr)
+		}
+		select {
+		case <-b.applied:
+		case <-ctx.Done():
+		case <-ticker.C:
+		}
+	}
+	<-b.applied
+	b.finish(ctx, err)
+}
+
+// run builds the indexes, writing them at the start ts of the build.
+func (b *indexBuild) run(ctx context.Context) error {
+	pk := x.ParsedKey{Attr: b.attr}
+	data, err := countKeys(pk.DataPrefix(), b.startTs)
+	if err != nil {
+		return err
+	}
+	// Every rebuild iterates over the data keys, and the one of the count index over the reverse
+	// keys as well.
+	var total int64
+	if b.rebuild.index {
+		total += data
+	}
+	if b.rebuild.reverse {
+		total += data
+	}
+	if b.rebuild.count {
+		rev, err := countKeys(pk.ReversePrefix(), b.startTs)
+		if err != nil {
+			return err
+		}
+		total += data + rev
+	}
+	atomic.StoreInt64(&b.total, total)
+	atomic.StoreInt64(&b.done, 0)
+
+	ctx = posting.WithRebuildHook(ctx, b.step)
+	if b.rebuild.index {
+		if err := posting.RebuildIndex(ctx, b.attr, b.startTs); err != nil {
+			return err
+		}
+	}
+	if b.rebuild.reverse {
+		if err := posting.RebuildReverseEdges(ctx, b.attr, b.startTs); err != nil {
+			return err
+		}
+	}
+	if b.rebuild.count {
+		if err := posting.RebuildCountIndex(ctx, b.attr, b.startTs); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// step is called for every key processed by the build, and throttles it.
+func (b *indexBuild) step() error {
+	atomic.AddInt64(&b.done, 1)
+	// Builds run by the schema mutation block the apply loop, so aren't throttled.
+	if rate := atomic.LoadInt64(&indexBuilds.rate); b.background && rate > 0 {
+		if d := time.Second/time.Duration(rate) - time.Since(b.last); d > 0 {
+			time.Sleep(d)
+		}
+		b.last = time.Now()
+	}
+	return nil
+}
+
+// countKeys returns the number of keys with the given prefix, as seen at readTs.
+func countKeys(prefix []byte, readTs uint64) (int64, error) {
+	txn := pstore.NewTransactionAt(readTs, false)
+	defer txn.Discard()
+	opt := badger.DefaultIteratorOptions
+	opt.PrefetchValues = false
+	opt.AllVersions = true
+	itr := txn.NewIterator(opt)
+	defer itr.Close()
+
+	var count int64
+	var prevKey []byte
+	for itr.Seek(prefix); itr.ValidForPrefix(prefix); itr.Next() {
+		key := itr.Item().Key()
+		if bytes.Equal(key, prevKey) {
+			continue
+		}
+		prevKey = append(prevKey[:0], key...)
+		count++
+	}
+	return count, nil
+}
+
+// finishIndexBuild applies the end of a build, as decided by the leader. It only records the
+// decision, which this replica carries out in the background.
+func (n *node) finishIndexBuild(ib *pb.IndexBuilt) error {
+	attr := ib.GetSchema().GetPredicate()
+	indexBuilds.Lock()
+	defer indexBuilds.Unlock()
+	b, ok := indexBuilds.builds[attr]
+	if !ok || b.startTs != ib.StartTs || !b.active() {
+		// Replayed, or the build was aborted in the meantime.
+		return nil
+	}
+	b.decision = ib
+	if ib.Cancelled {
+		b.cancel()
+	}
+	close(b.applied)
+	return nil
+}
+
+// finish carries out the decided end of the build, once the local build returned with err.
+func (b *indexBuild) finish(ctx context.Context, err error) {
+	indexBuilds.Lock()
+	ib, stopped := b.decision, b.stopped
+	indexBuilds.Unlock()
+	if stopped {
+		// Whoever stopped the build takes care of it.
+		return
+	}
+	defer invalidations.changed(b.attr)
+
+	if ib.Cancelled {
+		glog.Infof("Build of %v for predicate %s cancelled: %s", b.what(), b.attr, ib.Reason)
+		if rerr := b.revert(); rerr != nil {
+			glog.Errorf("While reverting schema of predicate %s: %v", b.attr, rerr)
+		}
+		indexBuilds.Lock()
+		b.status = indexBuildCancelled
+		b.err = x.Errorf("%s", ib.Reason)
+		b.finishedAt = time.Now()
+		indexBuilds.Unlock()
+		return
+	}
+
+	if err != nil {
+		// The leader built the indexes, so should we.
+		glog.Infof("Building %v for predicate %s again", b.what(), b.attr)
+		indexBuilds.Lock()
+		b.status = indexBuildRunning
+		indexBuilds.Unlock()
+		err = b.run(ctx)
+	}
+	if ctx.Err() != nil {
+		// Stopped while building again.
+		return
+	}
+	if err == nil {
+		err = b.complete()
+	}
+	if err != nil {
+		glog.Errorf("While finishing the build of %v for predicate %s: %v", b.what(), b.attr, err)
+		if rerr := b.revert(); rerr != nil {
+			glog.Errorf("While reverting schema of predicate %s: %v", b.attr, rerr)
+		}
+	}
+
+	indexBuilds.Lock()
+	defer indexBuilds.Unlock()
+	b.finishedAt = time.Now()
+	b.err = err
+	if err != nil {
+		b.status = indexBuildFailed
+		return
+	}
+	b.status = indexBuildDone
+}
+
+// complete makes the built indexes available to queries.
+func (b *indexBuild) complete() error {
+	// The lists the mutations wrote to in the meantime were cached before the build wrote them.
+	posting.EvictIndexes(b.attr)
+	if err := deleteStaleIndex(b.attr, b.schema); err != nil {
+		return err
+	}
+	if err := updateSchema(b.attr, b.schema); err != nil {
+		return err
+	}
+	schema.State().ClearServed(b.attr)
+	glog.Infof("Predicate %s now uses the indexes: %v", b.attr, b.what())
+	return nil
+}
+
+// revert goes back to the served schema, deleting whatever the build and the mutations wrote to
+// the indexes being built.
+func (b *indexBuild) revert() error {
+	// Stop writing to them first.
+	schema.State().Set(b.attr, b.served)
+	if err := deleteStaleIndex(b.attr, b.served); err != nil {
+		return err
+	}
+	if b.rebuild.reverse {
+		if err := posting.DeleteReverseEdges(b.attr); err != nil {
+			return err
+		}
+	}
+	if b.rebuild.count {
+		if err := posting.DeleteCountIndex(b.attr); err != nil {
+			return err
+		}
+	}
+	if err := updateSchema(b.attr, b.served); err != nil {
+		return err
+	}
+	schema.State().ClearServed(b.attr)
+	return nil
+}
+
+// deleteStaleIndex deletes the index entries of the tokenizers the schema doesn't have.
+func deleteStaleIndex(attr string, s pb.SchemaUpdate) error {
+	if s.Directive != pb.SchemaUpdate_INDEX || len(s.Tokenizer) == 0 {
+		return posting.DeleteIndex(attr)
+	}
+	var keep []byte
+	for _, name := range s.Tokenizer {
+		t, ok := tok.GetTokenizer(name)
+		x.AssertTruef(ok, "Invalid tokenizer %s", name)
+		keep = append(keep, t.Identifier())
+	}
+	return posting.DeleteIndexExcept(attr, keep)
+}
+
+// abort stops the pending builds of the given predicates, or all of them if attrs is empty. The
+// data of the predicates must be deleted afterwards.
+func (r *indexBuildRegistry) abort(attrs ...string) {
+	for _, b := range r.stop(attrs...) {
+		schema.State().ClearServed(b.attr)
+		r.Lock()
+		b.status = indexBuildCancelled
+		b.err = x.Errorf("Predicate was deleted")
+		b.finishedAt = time.Now()
+		r.Unlock()
+	}
+}
+
+// settle gets the build of attr out of the way, before a schema mutation of attr runs. A build
+// whose end was decided is carried out first. One which is still running is superseded: the
+// predicate goes back to the schema it was served with, which the mutation goes on from.
+func (r *indexBuildRegistry) settle(attr string) error {
+	r.Lock()
+	b, ok := r.builds[attr]
+	decided := ok && b.pending() && !b.active() && !b.stopped
+	r.Unlock()
+	if decided {
+		<-b.completed
+	}
+
+	for _, b := range r.stop(attr) {
+		r.Lock()
+		status := b.status
+		r.Unlock()
+		if status == indexBuildDone || status == indexBuildCancelled {
+			// It was over before it stopped.
+			continue
+		}
+		if err := b.revert(); err != nil {
+			return err
+		}
+		r.Lock()
+		b.status = indexBuildCancelled
+		b.err = x.Errorf("Predicate was altered again")
+		b.finishedAt = time.Now()
+		r.Unlock()
+		invalidations.changed(attr)
+	}
+	return nil
+}
+
+// stop stops the pending builds of the given predicates, or all of them if attrs is empty, and
+// returns them once they returned.
+func (r *indexBuildRegistry) stop(attrs ...string) []*indexBuild {
+	r.Lock()
+	var builds []*indexBuild
+	for attr, b := range r.builds {
+		if b.pending() && (len(attrs) == 0 || x.HasString(attrs, attr)) {
+			b.stopped = true
+			if b.active() {
+				close(b.applied)
+			}
+			b.cancel()
+			builds = append(builds, b)
+		}
+	}
+	r.Unlock()
+
+	for _, b := range builds {
+		<-b.completed
+	}
+	return builds
+}
+
+// estimateETA returns how long processing the remaining keys should take, at the average rate so
+// far. It returns zero if the rate isn't known yet.
+func estimateETA(done, total int64, elapsed time.Duration) time.Duration {
+	if done <= 0 || total <= done {
+		return 0
+	}
+	return time.Duration(float64(elapsed) / float64(done) * float64(total-done))
+}
+
+func (b *indexBuild) statusLocked() IndexBuildStatus {
+	st := IndexBuildStatus{
+		Attr:      b.attr,
+		StartTs:   b.startTs,
+		Status:    b.status,
+		Building:  b.what(),
+		Done:      atomic.LoadInt64(&b.done),
+		Total:     atomic.LoadInt64(&b.total),
+		StartedAt: b.startedAt,
+	}
+	if !b.finishedAt.IsZero() {
+		finishedAt := b.finishedAt
+		st.FinishedAt = &finishedAt
+	}
+	if b.err != nil {
+		st.Error = b.err.Error()
+	}
+	switch {
+	case st.Total == 0 && b.status != indexBuildRunning:
+		st.Percent = 100
+	case st.Total > 0:
+		if st.Done > st.Total {
+			st.Done = st.Total
+		}
+		st.Percent = float64(st.Done) * 100 / float64(st.Total)
+	}
+	if b.status == indexBuildRunning {
+		if eta := estimateETA(st.Done, st.Total, time.Since(b.startedAt)); eta > 0 {
+			st.ETA = eta.Round(time.Second).String()
+		}
+	}
+	return st
+}
+
+// IndexBuilds returns the status of the latest index build of every predicate served by this
+// Alpha.
+func IndexBuilds() []IndexBuildStatus {
+	indexBuilds.Lock()
+	defer indexBuilds.Unlock()
+	res := make([]IndexBuildStatus, 0, len(indexBuilds.builds))
+	for _, b := range indexBuilds.builds {
+		res = append(res, b.statusLocked())
+	}
+	sort.Slice(res, func(i, j int) bool { return res[i].Attr < res[j].Attr })
+	return res
+}
+
+// SetIndexBuildRate limits the number of keys processed per second by every index build on this
+// Alpha. Zero removes the limit.
+func SetIndexBuildRate(rate int64) error {
+	if rate < 0 {
+		return x.Errorf("Rate of index builds can't be negative: %d", rate)
+	}
+	atomic.StoreInt64(&indexBuilds.rate, rate)
+	glog.Infof("Rate of index builds set to %d keys/sec", rate)
+	return nil
+}
+
+// wait waits until this replica is done with the build of attr, if any.
+func (r *indexBuildRegistry) wait(ctx context.Context, attr string) error {
+	r.Lock()
+	b, ok := r.builds[attr]
+	r.Unlock()
+	if !ok {
+		return nil
+	}
+	select {
+	case <-b.completed:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// CancelIndexBuild cancels the index build of attr in the whole group, going back to the schema
+// from before it.
+func CancelIndexBuild(ctx context.Context, attr string) error {
+	if !groups().ServesTablet(attr) {
+		return errUnservedTablet
+	}
+	indexBuilds.Lock()
+	b, ok := indexBuilds.builds[attr]
+	indexBuilds.Unlock()
+	if !ok || !b.active() {
+		return x.Errorf("No index is being built for predicate %s", attr)
+	}
+	return groups().Node.proposeAndWait(ctx, &pb.Proposal{IndexBuilt: &pb.IndexBuilt{
+		Schema:    &b.schema,
+		StartTs:   b.startTs,
+		Cancelled: true,
+		Reason:    "Cancelled by user",
+	}})
+}
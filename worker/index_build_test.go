@@ -0,0 +1,194 @@
+/*
+ * Copyright 2018 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package worker
+
+import (
+	"testing"
+	"time"
+
+	"github.com/dgraph-io/dgraph/posting"
+	"github.com/dgraph-io/dgraph/protos/pb"
+	"github.com/dgraph-io/dgraph/schema"
+	"github.com/dgraph-io/dgraph/tok"
+	"github.com/dgraph-io/dgraph/x"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/context"
+)
+
+func TestPlanIndexBuild(t *testing.T) {
+	exact := pb.SchemaUpdate{Predicate: "name", Directive: pb.SchemaUpdate_INDEX,
+		Tokenizer: []string{"exact"}}
+	exactTerm := pb.SchemaUpdate{Predicate: "name", Directive: pb.SchemaUpdate_INDEX,
+		Tokenizer: []string{"exact", "term"}}
+
+	// New predicate.
+	b := planIndexBuild(nil, exact)
+	require.NotNil(t, b)
+	require.True(t, b.rebuild.index)
+	require.Equal(t, pb.SchemaUpdate_NONE, b.served.Directive)
+	require.Empty(t, b.served.Tokenizer)
+	require.Equal(t, []string{"exact"}, b.what())
+
+	// Adding a tokenizer keeps serving the existing one.
+	b = planIndexBuild(&exact, exactTerm)
+	require.NotNil(t, b)
+	require.Equal(t, pb.SchemaUpdate_INDEX, b.served.Directive)
+	require.Equal(t, []string{"exact"}, b.served.Tokenizer)
+	require.Equal(t, []string{"term"}, b.what())
+
+	// Removing a tokenizer doesn't need a build.
+	require.Nil(t, planIndexBuild(&exactTerm, exact))
+
+	// Nor does removing the index.
+	none := pb.SchemaUpdate{Predicate: "name"}
+	require.Nil(t, planIndexBuild(&exact, none))
+
+	// Changing the type makes the old index useless.
+	exactInt := exact
+	exactInt.ValueType = 2
+	b = planIndexBuild(&exact, exactInt)
+	require.NotNil(t, b)
+	require.Empty(t, b.served.Tokenizer)
+
+	// Reverse edges and count index.
+	friend := pb.SchemaUpdate{Predicate: "friend"}
+	reverseCount := pb.SchemaUpdate{Predicate: "friend", Directive: pb.SchemaUpdate_REVERSE,
+		Count: true}
+	b = planIndexBuild(&friend, reverseCount)
+	require.NotNil(t, b)
+	require.Equal(t, pb.SchemaUpdate_NONE, b.served.Directive)
+	require.False(t, b.served.Count)
+	require.Equal(t, []string{"reverse", "count"}, b.what())
+}
+
+func TestEstimateETA(t *testing.T) {
+	require.Equal(t, time.Duration(0), estimateETA(0, 100, time.Minute))
+	require.Equal(t, time.Duration(0), estimateETA(100, 100, time.Minute))
+	require.Equal(t, 3*time.Minute, estimateETA(25, 100, time.Minute))
+}
+
+func TestIndexBuildWithMutations(t *testing.T) {
+	require.NoError(t, schema.ParseBytes([]byte("build.name: string ."), 1))
+	gr.Lock()
+	gr.tablets["build.name"] = &pb.Tablet{GroupId: 1}
+	gr.Unlock()
+	setName := func(uid uint64, name string) {
+		edge := &pb.DirectedEdge{Attr: "build.name", Entity: uid, Value: []byte(name),
+			ValueType: pb.Posting_STRING}
+		addEdge(t, edge, getOrCreate(x.DataKey("build.name", uid)))
+	}
+	setName(1, "alice")
+
+	old, _ := schema.State().Get("build.name")
+	current := old
+	current.Directive = pb.SchemaUpdate_INDEX
+	current.Tokenizer = []string{"exact"}
+	b := planIndexBuild(&old, current)
+	require.NotNil(t, b)
+	b.startTs = timestamp()
+	schema.State().Set("build.name", current)
+	schema.State().SetServed("build.name", b.served)
+	require.False(t, schema.Served().IsIndexed("build.name"))
+
+	// Mutations committed after the start of the build are accepted, and update the index being
+	// built on top of what the build writes.
+	setName(2, "bob")
+	require.NoError(t, b.run(context.Background()))
+	require.NoError(t, b.complete())
+	require.True(t, schema.Served().IsIndexed("build.name"))
+
+	exact, ok := tok.GetTokenizer("exact")
+	require.True(t, ok)
+	readTs := timestamp()
+	for uid, name := range map[uint64]string{1: "alice", 2: "bob"} {
+		tokens, err := tok.BuildTokens(name, exact)
+		require.NoError(t, err)
+		l, err := posting.Get(x.IndexKey("build.name", tokens[0]))
+		require.NoError(t, err)
+		uids, err := l.Uids(posting.ListOptions{ReadTs: readTs})
+		require.NoError(t, err)
+		require.Equal(t, []uint64{uid}, uids.Uids, name)
+	}
+}
+
+func TestFinishIndexBuild(t *testing.T) {
+	attr := "build.finish"
+	var cancelled bool
+	b := &indexBuild{
+		attr:      attr,
+		startTs:   10,
+		index:     7,
+		schema:    pb.SchemaUpdate{Predicate: attr},
+		cancel:    func() { cancelled = true },
+		finished:  make(chan struct{}),
+		applied:   make(chan struct{}),
+		completed: make(chan struct{}),
+	}
+	indexBuilds.Lock()
+	indexBuilds.builds[attr] = b
+	indexBuilds.Unlock()
+	defer func() {
+		indexBuilds.Lock()
+		delete(indexBuilds.builds, attr)
+		indexBuilds.Unlock()
+	}()
+
+	// The end of the build is applied right away, even though the local build isn't done.
+	n := &node{}
+	require.NoError(t, n.finishIndexBuild(&pb.IndexBuilt{Schema: &b.schema, StartTs: 5}))
+	require.True(t, indexBuilds.building(attr))
+	require.NoError(t, n.finishIndexBuild(&pb.IndexBuilt{Schema: &b.schema, StartTs: 10}))
+	require.False(t, indexBuilds.building(attr))
+	require.False(t, cancelled)
+	require.True(t, indexBuilds.pendingAttrs()[attr])
+	require.Equal(t, uint64(7), indexBuilds.minIndex())
+
+	// Until this replica is done with it.
+	close(b.finished)
+	close(b.completed)
+	require.False(t, indexBuilds.pendingAttrs()[attr])
+}
+
+func TestSchemaMutationBuildsIndex(t *testing.T) {
+	require.NoError(t, schema.ParseBytes([]byte("build.sync: string ."), 1))
+	gr.Lock()
+	gr.tablets["build.sync"] = &pb.Tablet{GroupId: 1}
+	gr.Unlock()
+	edge := &pb.DirectedEdge{Attr: "build.sync", Entity: 1, Value: []byte("alice"),
+		ValueType: pb.Posting_STRING}
+	addEdge(t, edge, getOrCreate(x.DataKey("build.sync", 1)))
+
+	// Unless asked to build it in the background, the index is usable once the mutation returns.
+	update := &pb.SchemaUpdate{Predicate: "build.sync", ValueType: pb.Posting_STRING,
+		Directive: pb.SchemaUpdate_INDEX, Tokenizer: []string{"exact"}}
+	require.NoError(t, runSchemaMutation(context.Background(), update, timestamp(), 1, false))
+	require.True(t, schema.Served().IsIndexed("build.sync"))
+	indexBuilds.Lock()
+	_, ok := indexBuilds.builds["build.sync"]
+	indexBuilds.Unlock()
+	require.False(t, ok)
+
+	exact, ok := tok.GetTokenizer("exact")
+	require.True(t, ok)
+	tokens, err := tok.BuildTokens("alice", exact)
+	require.NoError(t, err)
+	l, err := posting.Get(x.IndexKey("build.sync", tokens[0]))
+	require.NoError(t, err)
+	uids, err := l.Uids(posting.ListOptions{ReadTs: timestamp()})
+	require.NoError(t, err)
+	require.Equal(t, []uint64{1}, uids.Uids)
+}
@@ -0,0 +1,297 @@
+/*
+ * Copyright 2018 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package worker
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/dgraph-io/badger/y"
+	"github.com/dgraph-io/dgo/protos/api"
+	"github.com/dgraph-io/dgraph/conn"
+	"github.com/dgraph-io/dgraph/posting"
+	"github.com/dgraph-io/dgraph/protos/pb"
+	"github.com/dgraph-io/dgraph/x"
+	"github.com/golang/glog"
+	"golang.org/x/net/context"
+)
+
+// Caches of query results on an Alpha can hold data of any group. Every Alpha applies the commits
+// of its own group itself, but learns about the commits of other groups only via invalidations:
+// after applying commits, the leader of every group sends the predicates they changed to all the
+// Alphas of the other groups, in small batches.
+//
+// Invalidations are best effort, but never lost silently. Every batch carries a sequence number
+// per sender, and if a receiver notices a gap (due to a failed send, a dropped batch, a restart of
+// the sender or a new leader), it tells its subscribers that anything might have changed.
+//
+// As they are sent asynchronously, every batch also carries the watermark of its group: the
+// timestamp up to which the commits of the group are covered by the batches sent so far. Before
+// serving a result cached at an earlier timestamp at readTs, subscribers check with
+// InvalidatedUpTo that the groups it was read from reached readTs.
+
+// InvalidationFunc is called with the predicates changed by commits up to commitTs. If attrs is
+// nil, any predicate might have changed. The calls are serialized and must not block.
+type InvalidationFunc func(attrs []string, commitTs uint64)
+
+const (
+	invalidationInterval = 10 * time.Millisecond
+	invalidationTimeout  = 5 * time.Second
+)
+
+type invalidationBus struct {
+	subsMu sync.RWMutex
+	subs   []InvalidationFunc
+	notMu  sync.Mutex // Serializes the calls to subscribers.
+
+	pending chan *pb.Invalidation
+	dropped int32 // Set if an invalidation couldn't be queued for broadcasting.
+	seq     uint64
+
+	recvMu     sync.Mutex
+	lastSeq    map[uint64]uint64 // Last sequence number received, by sender.
+	watermarks map[uint32]uint64 // Watermark received, by group.
+}
+
+var invalidations = &invalidationBus{
+	pending:    make(chan *pb.Invalidation, 1000),
+	lastSeq:    make(map[uint64]uint64),
+	watermarks: make(map[uint32]uint64),
+}
+
+// SubscribeInvalidations registers fn to be called for the commits applied by any group.
+func SubscribeInvalidations(fn InvalidationFunc) {
+	invalidations.subsMu.Lock()
+	defer invalidations.subsMu.Unlock()
+	invalidations.subs = append(invalidations.subs, fn)
+}
+
+func (b *invalidationBus) notify(attrs []string, commitTs uint64) {
+	b.subsMu.RLock()
+	subs := b.subs
+	b.subsMu.RUnlock()
+
+	b.notMu.Lock()
+	defer b.notMu.Unlock()
+	for _, fn := range subs {
+		fn(attrs, commitTs)
+	}
+}
+
+// committed is called after this Alpha applied commits changing attrs, and before the Oracle
+// advances past commitTs, which the watermark of the group relies on. If attrs is nil, any
+// predicate might have changed.
+func (b *invalidationBus) committed(attrs []string, commitTs uint64) {
+	b.notify(attrs, commitTs)
+
+	g := groups()
+	if g == nil || g.Node == nil || !g.Node.AmLeader() {
+		return
+	}
+	if attrs == nil {
+		// Skipping a sequence number makes the receivers invalidate everything.
+		atomic.StoreInt32(&b.dropped, 1)
+	}
+	select {
+	case b.pending <- &pb.Invalidation{CommitTs: commitTs, Attrs: attrs}:
+	default:
+		atomic.StoreInt32(&b.dropped, 1)
+	}
+}
+
+// changed is called after this Alpha changed attrs other than by commits, like by schema changes
+// or drops. These changes count as committed right after the latest commit. Without attrs, any
+// predicate might have changed.
+func (b *invalidationBus) changed(attrs ...string) {
+	b.committed(attrs, posting.Oracle().MaxAssigned()+1)
+}
+
+// run batches the pending invalidations and broadcasts them, until closer is signalled.
+func (b *invalidationBus) run(closer *y.Closer) {
+	defer closer.Done() // CLOSER:1
+
+	ticker := time.NewTicker(invalidationInterval)
+	defer ticker.Stop()
+
+	var batch *pb.Invalidation
+	var leader bool
+	var sent uint64 // Last watermark sent.
+	for {
+		select {
+		case inv := <-b.pending:
+			batch = addInvalidation(batch, inv)
+		case <-ticker.C:
+			// The commits up to the watermark were queued before the Oracle advanced past them.
+			watermark := posting.Oracle().MaxAssigned()
+			for drained := false; !drained; {
+				select {
+				case inv := <-b.pending:
+					batch = addInvalidation(batch, inv)
+				default:
+					drained = true
+				}
+			}
+
+			g := groups()
+			wasLeader := leader
+			leader = g.Node.AmLeader()
+			if leader && !wasLeader {
+				// The previous leader might not have sent the commits applied so far.
+				atomic.StoreInt32(&b.dropped, 1)
+			}
+			if batch == nil && (!leader || watermark <= sent) {
+				continue
+			}
+			if batch == nil {
+				batch = &pb.Invalidation{}
+			}
+			if leader {
+				batch.Watermark = watermark
+				sent = watermark
+			}
+			if atomic.CompareAndSwapInt32(&b.dropped, 1, 0) {
+				// Skip a sequence number, so that the receivers reset their caches.
+				b.seq++
+			}
+			b.seq++
+			batch.Seq = b.seq
+			batch.Sender = g.Node.Id
+			batch.GroupId = g.groupId()
+			b.broadcast(batch)
+			batch = nil
+		case <-closer.HasBeenClosed():
+			return
+		}
+	}
+}
+
+// addInvalidation adds inv to the batch, creating it if nil.
+func addInvalidation(batch, inv *pb.Invalidation) *pb.Invalidation {
+	if batch == nil {
+		batch = &pb.Invalidation{}
+	}
+	for _, attr := range inv.Attrs {
+		if !x.HasString(batch.Attrs, attr) {
+			batch.Attrs = append(batch.Attrs, attr)
+		}
+	}
+	batch.CommitTs = x.Max(batch.CommitTs, inv.CommitTs)
+	return batch
+}
+
+// broadcast sends the batch to all Alphas of the other groups, waiting for all sends to finish so
+// that every receiver gets the batches in order.
+func (b *invalidationBus) broadcast(batch *pb.Invalidation) {
+	g := groups()
+	var addrs []string
+	g.RLock()
+	if g.state != nil {
+		for gid, group := range g.state.Groups {
+			if gid == g.gid {
+				continue
+			}
+			for _, m := range group.Members {
+				addrs = append(addrs, m.Addr)
+			}
+		}
+	}
+	g.RUnlock()
+
+	var wg sync.WaitGroup
+	for _, addr := range addrs {
+		pl, err := conn.Get().Get(addr)
+		if err != nil {
+			// The receiver will notice the gap in the sequence numbers.
+			continue
+		}
+		wg.Add(1)
+		go func(pl *conn.Pool) {
+			defer wg.Done()
+			ctx, cancel := context.WithTimeout(context.Background(), invalidationTimeout)
+			defer cancel()
+			if _, err := pb.NewWorkerClient(pl.Get()).Invalidate(ctx, batch); err != nil {
+				glog.V(2).Infof("Unable to send invalidation to %s: %v", pl.Addr, err)
+			}
+		}(pl)
+	}
+	wg.Wait()
+}
+
+// received is called for the invalidations received from other groups.
+func (b *invalidationBus) received(batch *pb.Invalidation) {
+	b.recvMu.Lock()
+	last := b.lastSeq[batch.Sender]
+	b.lastSeq[batch.Sender] = batch.Seq
+	b.recvMu.Unlock()
+
+	if batch.Seq != last+1 {
+		glog.V(2).Infof("Invalidations from %d jumped from %d to %d. Invalidating everything.",
+			batch.Sender, last, batch.Seq)
+		b.notify(nil, batch.CommitTs)
+	} else {
+		b.notify(batch.Attrs, batch.CommitTs)
+	}
+
+	// Only once the subscribers were told about the commits it covers.
+	b.recvMu.Lock()
+	if batch.Watermark > b.watermarks[batch.GroupId] {
+		b.watermarks[batch.GroupId] = batch.Watermark
+	}
+	b.recvMu.Unlock()
+}
+
+// InvalidatedUpTo tells whether this Alpha learnt about the commits up to readTs of all the groups
+// serving attrs, so that the results read from attrs before can be served at readTs. It's false
+// for the groups it didn't hear from yet, or serving predicates it doesn't know about.
+func InvalidatedUpTo(attrs []string, readTs uint64) bool {
+	// The commits of this group are learnt about before the Oracle advances past them.
+	if posting.Oracle().MaxAssigned() < readTs {
+		return false
+	}
+	g := groups()
+	var gids []uint32
+	g.RLock()
+	for _, attr := range attrs {
+		tablet, ok := g.tablets[attr]
+		if !ok {
+			g.RUnlock()
+			return false
+		}
+		gids = append(gids, tabletGroups(tablet)...)
+	}
+	g.RUnlock()
+
+	b := invalidations
+	b.recvMu.Lock()
+	defer b.recvMu.Unlock()
+	for _, gid := range gids {
+		if gid != g.groupId() && b.watermarks[gid] < readTs {
+			return false
+		}
+	}
+	return true
+}
+
+// Invalidate receives the predicates changed by the commits of another group.
+func (w *grpcWorker) Invalidate(ctx context.Context, batch *pb.Invalidation) (*api.Payload, error) {
+	if ctx.Err() != nil {
+		return &api.Payload{}, ctx.Err()
+	}
+	invalidations.received(batch)
+	return &api.Payload{}, nil
+}
@@ -0,0 +1,67 @@
+/*
+ * Copyright 2018 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package worker
+
+import (
+	"testing"
+
+	"github.com/dgraph-io/dgraph/posting"
+	"github.com/dgraph-io/dgraph/protos/pb"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInvalidationGaps(t *testing.T) {
+	b := &invalidationBus{lastSeq: make(map[uint64]uint64), watermarks: make(map[uint32]uint64)}
+	var got [][]string
+	b.subs = append(b.subs, func(attrs []string, commitTs uint64) {
+		got = append(got, attrs)
+	})
+
+	b.received(&pb.Invalidation{Sender: 1, Seq: 1, Attrs: []string{"name"}})
+	b.received(&pb.Invalidation{Sender: 2, Seq: 1, Attrs: []string{"age"}})
+	b.received(&pb.Invalidation{Sender: 1, Seq: 2, Attrs: []string{"friend"}})
+	require.Equal(t, [][]string{{"name"}, {"age"}, {"friend"}}, got)
+
+	// A missed batch invalidates everything.
+	got = nil
+	b.received(&pb.Invalidation{Sender: 1, Seq: 4, Attrs: []string{"name"}})
+	require.Equal(t, [][]string{nil}, got)
+
+	// So does a restarted sender, or a sender whose first batches were missed.
+	got = nil
+	b.received(&pb.Invalidation{Sender: 1, Seq: 1, Attrs: []string{"name"}})
+	b.received(&pb.Invalidation{Sender: 3, Seq: 5, Attrs: []string{"name"}})
+	require.Equal(t, [][]string{nil, nil}, got)
+}
+
+func TestInvalidatedUpTo(t *testing.T) {
+	readTs := posting.Oracle().MaxAssigned() + 10
+	require.False(t, InvalidatedUpTo([]string{"name"}, readTs))
+	posting.Oracle().ProcessDelta(&pb.OracleDelta{MaxAssigned: readTs})
+	require.True(t, InvalidatedUpTo([]string{"name"}, readTs))
+
+	// Until group 2 is heard from.
+	require.False(t, InvalidatedUpTo([]string{"name", "friend_not_served"}, readTs))
+	invalidations.received(&pb.Invalidation{Sender: 100, Seq: 1, GroupId: 2,
+		Watermark: readTs - 1})
+	require.False(t, InvalidatedUpTo([]string{"name", "friend_not_served"}, readTs))
+	invalidations.received(&pb.Invalidation{Sender: 100, Seq: 2, GroupId: 2, Watermark: readTs})
+	require.True(t, InvalidatedUpTo([]string{"name", "friend_not_served"}, readTs))
+
+	// Nor are the groups of unknown predicates.
+	require.False(t, InvalidatedUpTo([]string{"invalidated.unknown"}, readTs))
+}
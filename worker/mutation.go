@@ -38,11 +38,14 @@ import (
 	otrace "go.opencensus.io/trace"
 	"golang.org/x/net/context"
 	"golang.org/x/net/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
 )
 
 var (
-	errUnservedTablet  = x.Errorf("Tablet isn't being served by this instance.")
-	errPredicateMoving = x.Errorf("Predicate is being moved, please retry later")
+	errUnservedTablet = x.Errorf("Tablet isn't being served by this instance.")
 )
 
 func isStarAll(v []byte) bool {
@@ -60,6 +63,11 @@ func runMutation(ctx context.Context, edge *pb.DirectedEdge, txn *posting.Txn) e
 		// after predicate move and before snapshot.
 		return x.Errorf("runMutation: Tablet isn't being served by this group.")
 	}
+	if tablet := groups().Tablet(edge.Attr); tablet != nil &&
+		!servesEdge(tablet, edge, groups().groupId()) {
+		return x.Errorf("runMutation: Uid %#x of split tablet %s isn't held by this group.",
+			edge.Entity, edge.Attr)
+	}
 
 	su, ok := schema.State().Get(edge.Attr)
 	if edge.Op == pb.DirectedEdge_SET {
@@ -96,8 +104,19 @@ func runMutation(ctx context.Context, edge *pb.DirectedEdge, txn *posting.Txn) e
 
 // This is serialized with mutations, called after applied watermarks catch up
 // and further mutations are blocked until this is done.
-func runSchemaMutation(ctx context.Context, update *pb.SchemaUpdate, startTs uint64) error {
-	if err := runSchemaMutationHelper(ctx, update, startTs); err != nil {
+func runSchemaMutation(ctx context.Context, update *pb.SchemaUpdate, startTs, index uint64,
+	background bool) error {
+	build, err := runSchemaMutationHelper(ctx, update, startTs)
+	if err == nil && build != nil {
+		if background && hasEdges(update.Predicate, startTs) {
+			// The schema is persisted once the build is done.
+			return groups().Node.startIndexBuild(build, startTs, index)
+		}
+		// Built right away, before the mutation returns.
+		build.startTs = startTs
+		err = build.run(ctx)
+	}
+	if err != nil {
 		// on error, we restore the memory state to be the same as the disk
 		maxRetries := 10
 		loadErr := x.RetryUntilSuccess(maxRetries, 10*time.Millisecond, func() error {
@@ -109,18 +128,23 @@ func runSchemaMutation(ctx context.Context, update *pb.SchemaUpdate, startTs uin
 		}
 		return err
 	}
-
+	if build != nil {
+		return build.complete()
+	}
 	return updateSchema(update.Predicate, *update)
 }
 
-func runSchemaMutationHelper(ctx context.Context, update *pb.SchemaUpdate, startTs uint64) error {
+// runSchemaMutationHelper applies the schema update, except for the indexes which have to be
+// built. Those are returned, to be built right away or in the background.
+func runSchemaMutationHelper(ctx context.Context, update *pb.SchemaUpdate,
+	startTs uint64) (*indexBuild, error) {
 	n := groups().Node
 	if !groups().ServesTablet(update.Predicate) {
 		tablet := groups().Tablet(update.Predicate)
-		return x.Errorf("Tablet isn't being served by this group. Tablet: %+v", tablet)
+		return nil, x.Errorf("Tablet isn't being served by this group. Tablet: %+v", tablet)
 	}
 	if err := checkSchema(update); err != nil {
-		return err
+		return nil, err
 	}
 	old, ok := schema.State().Get(update.Predicate)
 	current := *update
@@ -135,61 +159,53 @@ func runSchemaMutationHelper(ctx context.Context, update *pb.SchemaUpdate, start
 	// linearizable read requests. Only downside would be on system crash, stale edges
 	// might remain, which is ok.
 
-	// Indexing can't be done in background as it can cause race conditons with new
-	// index mutations (old set and new del)
-	// We need watermark for index/reverse edge addition for linearizable reads.
-	// (both applied and synced watermarks).
+	// Indexes are added afterwards, right away or in the background while queries keep using the
+	// old schema, see index_build.go.
 	defer glog.Infof("Done schema update %+v\n", update)
 	if !ok {
-		if current.Directive == pb.SchemaUpdate_INDEX {
-			if err := n.rebuildOrDelIndex(ctx, update.Predicate, true, startTs); err != nil {
-				return err
-			}
-		} else if current.Directive == pb.SchemaUpdate_REVERSE {
-			if err := n.rebuildOrDelRevEdge(ctx, update.Predicate, true, startTs); err != nil {
-				return err
-			}
-		}
-
-		if current.Count {
-			if err := n.rebuildOrDelCountIndex(ctx, update.Predicate, true, startTs); err != nil {
-				return err
-			}
-		}
-		return nil
+		return planIndexBuild(nil, current), nil
 	}
 
 	// schema was present already
 	if current.List && !old.List {
 		if err := posting.RebuildListType(ctx, update.Predicate, startTs); err != nil {
-			return err
+			return nil, err
 		}
 	} else if old.List && !current.List {
-		return fmt.Errorf("Type can't be changed from list to scalar for attr: [%s]"+
+		return nil, fmt.Errorf("Type can't be changed from list to scalar for attr: [%s]"+
 			" without dropping it first.", current.Predicate)
 	}
 
+	build := planIndexBuild(&old, current)
 	if needReindexing(old, current) {
-		// Reindex if update.Index is true or remove index
-		if err := n.rebuildOrDelIndex(ctx, update.Predicate,
-			current.Directive == pb.SchemaUpdate_INDEX, startTs); err != nil {
-			return err
+		switch {
+		case current.Directive != pb.SchemaUpdate_INDEX:
+			if err := n.rebuildOrDelIndex(ctx, update.Predicate, false, startTs); err != nil {
+				return nil, err
+			}
+		case build == nil || !build.rebuild.index:
+			// Only tokenizers were removed.
+			if err := deleteStaleIndex(update.Predicate, current); err != nil {
+				return nil, err
+			}
+		case len(build.served.Tokenizer) == 0:
+			// None of the old index can be used, as its tokens are of another type.
+			if err := posting.DeleteIndex(update.Predicate); err != nil {
+				return nil, err
+			}
 		}
-	} else if needsRebuildingReverses(old, current) {
-		// Add or remove reverse edge based on update.Reverse
-		if err := n.rebuildOrDelRevEdge(ctx, update.Predicate,
-			current.Directive == pb.SchemaUpdate_REVERSE, startTs); err != nil {
-			return err
+	} else if needsRebuildingReverses(old, current) && current.Directive != pb.SchemaUpdate_REVERSE {
+		if err := n.rebuildOrDelRevEdge(ctx, update.Predicate, false, startTs); err != nil {
+			return nil, err
 		}
 	}
 
-	if current.Count != old.Count {
-		if err := n.rebuildOrDelCountIndex(ctx, update.Predicate, current.Count,
-			startTs); err != nil {
-			return err
+	if old.Count && !current.Count {
+		if err := n.rebuildOrDelCountIndex(ctx, update.Predicate, false, startTs); err != nil {
+			return nil, err
 		}
 	}
-	return nil
+	return build, nil
 }
 
 func needsRebuildingReverses(old pb.SchemaUpdate, current pb.SchemaUpdate) bool {
@@ -223,6 +239,11 @@ func needReindexing(old pb.SchemaUpdate, current pb.SchemaUpdate) bool {
 // only during schema mutations or we see a new predicate.
 func updateSchema(attr string, s pb.SchemaUpdate) error {
 	schema.State().Set(attr, s)
+	return writeSchema(attr, s)
+}
+
+// writeSchema only updates the schema on disk.
+func writeSchema(attr string, s pb.SchemaUpdate) error {
 	txn := pstore.NewTransactionAt(1, true)
 	defer txn.Discard()
 	data, err := s.Marshal()
@@ -242,6 +263,12 @@ func updateSchemaType(attr string, typ types.TypeID, index uint64) {
 	} else {
 		s = pb.SchemaUpdate{ValueType: typ.Enum(), Predicate: attr}
 	}
+	if x.IsScratchAttr(attr) {
+		// Scratch predicates don't outlive the txns using them, nor should their schema, which
+		// is forgotten once they're committed or aborted.
+		schema.State().Set(attr, s)
+		return
+	}
 	updateSchema(attr, s)
 }
 
@@ -291,6 +318,11 @@ func checkSchema(s *pb.SchemaUpdate) error {
 		return x.Errorf("Cannot reverse for non-uid type on predicate %s", s.Predicate)
 	}
 
+	// The count index doesn't know the values expire.
+	if s.Ttl > 0 && s.Count {
+		return x.Errorf("@ttl can't be used together with @count, on predicate %s", s.Predicate)
+	}
+
 	// If schema update has upsert directive, it should have index directive.
 	if s.Upsert && len(s.Tokenizer) == 0 {
 		return x.Errorf("Index tokenizer is mandatory for: [%s] when specifying @upsert directive",
@@ -330,6 +362,27 @@ func checkSchema(s *pb.SchemaUpdate) error {
 	return nil
 }
 
+// checkStrictType verifies that the value of the edge has the type of the predicate, as strict
+// schema mode doesn't convert between types. Values without a type, like the literals of RDF, are
+// still parsed. So are strings for the types which JSON can't represent.
+func checkStrictType(edge *pb.DirectedEdge, su *pb.SchemaUpdate) error {
+	if edge.Op != pb.DirectedEdge_SET {
+		return nil
+	}
+	storageType, schemaType := posting.TypeID(edge), types.TypeID(su.ValueType)
+	switch {
+	case storageType == schemaType, storageType == types.DefaultID, schemaType == types.DefaultID:
+		return nil
+	case storageType == types.IntID && schemaType == types.FloatID:
+		return nil
+	case storageType == types.StringID &&
+		(schemaType == types.DateTimeID || schemaType == types.PasswordID):
+		return nil
+	}
+	return x.Errorf("Input for predicate %s of type %s is of type %s, which isn't allowed in"+
+		" strict schema mode", edge.Attr, schemaType.Name(), storageType.Name())
+}
+
 // If storage type is specified, then check compatibility or convert to schema type
 // if no storage type is specified then convert to schema type.
 func ValidateAndConvert(edge *pb.DirectedEdge, su *pb.SchemaUpdate) error {
@@ -390,6 +443,23 @@ func ValidateAndConvert(edge *pb.DirectedEdge, su *pb.SchemaUpdate) error {
 	return nil
 }
 
+// ttlFrom returns the Unix time the @ttl of the edge counts from. That's the time of its datetime
+// facet named facet, if it has one, or else now.
+func ttlFrom(edge *pb.DirectedEdge, facet string, now time.Time) int64 {
+	for _, f := range edge.Facets {
+		if len(facet) == 0 || f.Key != facet || f.ValType != api.Facet_DATETIME {
+			continue
+		}
+		src := types.Val{Tid: types.BinaryID, Value: f.Value}
+		if val, err := types.Convert(src, types.DateTimeID); err == nil {
+			if t, ok := val.Value.(time.Time); ok {
+				return t.Unix()
+			}
+		}
+	}
+	return now.Unix()
+}
+
 func AssignUidsOverNetwork(ctx context.Context, num *pb.Num) (*pb.AssignedIds, error) {
 	pl := groups().Leader(0)
 	if pl == nil {
@@ -423,6 +493,12 @@ func fillTxnContext(tctx *api.TxnContext, startTs uint64) {
 // proposeOrSend either proposes the mutation if the node serves the group gid or sends it to
 // the leader of the group gid for proposing.
 func proposeOrSend(ctx context.Context, gid uint32, m *pb.Mutations, chr chan res) {
+	ctx, span := otrace.StartSpan(ctx, "worker.proposeOrSend")
+	defer span.End()
+	span.AddAttributes(otrace.Int64Attribute("group", int64(gid)),
+		otrace.Int64Attribute("edges", int64(len(m.Edges))),
+		otrace.BoolAttribute("local", groups().ServesGroup(gid)))
+
 	res := res{}
 	if groups().ServesGroup(gid) {
 		node := groups().Node
@@ -471,31 +547,45 @@ func proposeOrSend(ctx context.Context, gid uint32, m *pb.Mutations, chr chan re
 func populateMutationMap(src *pb.Mutations) map[uint32]*pb.Mutations {
 	mm := make(map[uint32]*pb.Mutations)
 	for _, edge := range src.Edges {
-		gid := groups().BelongsTo(edge.Attr)
-		mu := mm[gid]
-		if mu == nil {
-			mu = &pb.Mutations{GroupId: gid}
-			mm[gid] = mu
+		gids := []uint32{0}
+		if tablet := groups().Tablet(edge.Attr); tablet != nil {
+			gids = edgeGroups(tablet, edge)
+		}
+		for _, gid := range gids {
+			mu := mm[gid]
+			if mu == nil {
+				mu = &pb.Mutations{GroupId: gid}
+				mm[gid] = mu
+			}
+			mu.Edges = append(mu.Edges, edge)
+			mu.Tombstone = src.Tombstone
 		}
-		mu.Edges = append(mu.Edges, edge)
 	}
 	for _, schema := range src.Schema {
-		gid := groups().BelongsTo(schema.Predicate)
-		mu := mm[gid]
-		if mu == nil {
-			mu = &pb.Mutations{GroupId: gid}
-			mm[gid] = mu
+		// All the groups of a split tablet index the uids they hold.
+		gids := []uint32{0}
+		if tablet := groups().Tablet(schema.Predicate); tablet != nil {
+			gids = tabletGroups(tablet)
+		}
+		for _, gid := range gids {
+			mu := mm[gid]
+			if mu == nil {
+				mu = &pb.Mutations{GroupId: gid}
+				mm[gid] = mu
+			}
+			mu.Schema = append(mu.Schema, schema)
+			mu.BackgroundIndex = src.BackgroundIndex
 		}
-		mu.Schema = append(mu.Schema, schema)
 	}
-	if src.DropAll {
+	if src.DropAll || len(src.DropNamespace) > 0 {
 		for _, gid := range groups().KnownGroups() {
 			mu := mm[gid]
 			if mu == nil {
 				mu = &pb.Mutations{GroupId: gid}
 				mm[gid] = mu
 			}
-			mu.DropAll = true
+			mu.DropAll = src.DropAll
+			mu.DropNamespace = src.DropNamespace
 		}
 	}
 	return mm
@@ -522,6 +612,17 @@ func MutateOverNetwork(ctx context.Context, m *pb.Mutations) (*api.TxnContext, e
 	defer span.End()
 
 	tctx := &api.TxnContext{StartTs: m.StartTs}
+	if err := checkClusterAttrs(m); err != nil {
+		return tctx, err
+	}
+	if err := namespaceMutations(ctx, m); err != nil {
+		return tctx, err
+	}
+	// The mutations of a tablet read-only as per this Alpha could be for one writable again with
+	// a new copy, which they wouldn't be sent to.
+	if err := movingTablet(m); err != nil {
+		return tctx, err
+	}
 	mutationMap := populateMutationMap(m)
 
 	resCh := make(chan res, len(mutationMap))
@@ -532,6 +633,7 @@ func MutateOverNetwork(ctx context.Context, m *pb.Mutations) (*api.TxnContext, e
 			return tctx, errUnservedTablet
 		}
 		mu.StartTs = m.StartTs
+		mu.KeepAlive = m.KeepAlive
 		go proposeOrSend(ctx, gid, mu, resCh)
 	}
 
@@ -542,6 +644,13 @@ func MutateOverNetwork(ctx context.Context, m *pb.Mutations) (*api.TxnContext, e
 		res := <-resCh
 		if res.err != nil {
 			e = res.err
+			// Errors from other groups lose their type on the way, so tell the client about
+			// moving tablets based on what we know.
+			if st, ok := status.FromError(res.err); ok && st.Code() == codes.Unavailable {
+				if merr := movingTablet(m); merr != nil {
+					e = merr
+				}
+			}
 			if tr, ok := trace.FromContext(ctx); ok {
 				tr.LazyPrintf("Error while running all mutations: %+v", res.err)
 			}
@@ -564,7 +673,14 @@ func CommitOverNetwork(ctx context.Context, tc *api.TxnContext) (uint64, error)
 		return 0, conn.ErrNoConnection
 	}
 	zc := pb.NewZeroClient(pl.Get())
-	tctx, err := zc.CommitOrAbort(ctx, tc)
+	var opts []grpc.CallOption
+	var trailer metadata.MD
+	if Config.DebugConflicts && !tc.Aborted {
+		// Zero tells what the transaction conflicted on, if it has to abort it.
+		ctx = metadata.AppendToOutgoingContext(ctx, "debug-conflicts", "true")
+		opts = append(opts, grpc.Trailer(&trailer))
+	}
+	tctx, err := zc.CommitOrAbort(ctx, tc, opts...)
 
 	if err != nil {
 		span.Annotatef(nil, "Error=%v", err)
@@ -576,6 +692,9 @@ func CommitOverNetwork(ctx context.Context, tc *api.TxnContext) (uint64, error)
 	span.Annotate(attributes, "")
 
 	if tctx.Aborted {
+		if err := conflictError(tc.StartTs, trailer); err != nil {
+			return 0, err
+		}
 		return 0, y.ErrAborted
 	}
 	return tctx.CommitTs, nil
@@ -592,7 +711,7 @@ func (w *grpcWorker) Mutate(ctx context.Context, m *pb.Mutations) (*api.TxnConte
 	}
 
 	node := groups().Node
-	if rand.Float64() < Config.Tracing {
+	if rand.Float64() < x.TraceRatio() {
 		var tr trace.Trace
 		tr, ctx = x.NewTrace("grpcWorker.Mutate", ctx)
 		defer tr.Finish()
@@ -607,6 +726,6 @@ func tryAbortTransactions(startTimestamps []uint64) {
 	// Aborts if not already committed.
 	req := &pb.TxnTimestamps{Ts: startTimestamps}
 
-	err := groups().Node.blockingAbort(req)
+	_, err := groups().Node.blockingAbort(req)
 	glog.Infof("tryAbortTransactions for %d txns. Error: %+v\n", len(req.Ts), err)
 }
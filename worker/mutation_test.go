@@ -19,13 +19,16 @@ package worker
 import (
 	"reflect"
 	"testing"
+	"time"
 
+	"github.com/dgraph-io/dgo/protos/api"
 	"github.com/stretchr/testify/require"
 
 	"github.com/dgraph-io/dgraph/posting"
 	"github.com/dgraph-io/dgraph/protos/pb"
 	"github.com/dgraph-io/dgraph/schema"
 	"github.com/dgraph-io/dgraph/types"
+	"github.com/dgraph-io/dgraph/types/facets"
 )
 
 func TestConvertEdgeType(t *testing.T) {
@@ -109,6 +112,45 @@ func TestValidateEdgeTypeError(t *testing.T) {
 	require.Error(t, err)
 }
 
+func TestCheckStrictType(t *testing.T) {
+	tests := []struct {
+		valueType types.TypeID
+		schema    types.TypeID
+		ok        bool
+	}{
+		{types.DefaultID, types.IntID, true},
+		{types.IntID, types.IntID, true},
+		{types.IntID, types.FloatID, true},
+		{types.FloatID, types.IntID, false},
+		{types.StringID, types.DateTimeID, true},
+		{types.StringID, types.IntID, false},
+		{types.BoolID, types.StringID, false},
+		{types.IntID, types.DefaultID, true},
+	}
+	for _, tc := range tests {
+		edge := &pb.DirectedEdge{
+			Value:     []byte("1"),
+			ValueType: tc.valueType.Enum(),
+			Attr:      "age",
+		}
+		err := checkStrictType(edge, &pb.SchemaUpdate{ValueType: tc.schema.Enum()})
+		if tc.ok {
+			require.NoError(t, err, "%s into %s", tc.valueType.Name(), tc.schema.Name())
+		} else {
+			require.Error(t, err, "%s into %s", tc.valueType.Name(), tc.schema.Name())
+		}
+	}
+
+	// Deletions aren't checked.
+	edge := &pb.DirectedEdge{
+		Value:     []byte("1"),
+		ValueType: types.BoolID.Enum(),
+		Attr:      "age",
+		Op:        pb.DirectedEdge_DEL,
+	}
+	require.NoError(t, checkStrictType(edge, &pb.SchemaUpdate{ValueType: types.IntID.Enum()}))
+}
+
 func TestPopulateMutationMap(t *testing.T) {
 	edges := []*pb.DirectedEdge{{
 		Value: []byte("set edge"),
@@ -200,6 +242,26 @@ func TestCheckSchema(t *testing.T) {
 	require.NoError(t, err)
 	err = checkSchema(su[1])
 	require.NoError(t, err)
+
+	su, err = schema.Parse(`visits: uid @count @ttl(24h) .`)
+	require.NoError(t, err)
+	require.Error(t, checkSchema(su[0]))
+}
+
+func TestTTLFrom(t *testing.T) {
+	now := time.Now()
+	seen := time.Date(2018, 10, 1, 12, 0, 0, 0, time.UTC)
+	seenFacet, err := facets.FacetFor("seen", seen.Format(time.RFC3339))
+	require.NoError(t, err)
+	countFacet, err := facets.FacetFor("count", "3")
+	require.NoError(t, err)
+
+	edge := &pb.DirectedEdge{Facets: []*api.Facet{countFacet, seenFacet}}
+	require.Equal(t, seen.Unix(), ttlFrom(edge, "seen", now))
+	// The TTL counts from the write, unless the facet is a datetime.
+	require.Equal(t, now.Unix(), ttlFrom(edge, "", now))
+	require.Equal(t, now.Unix(), ttlFrom(edge, "count", now))
+	require.Equal(t, now.Unix(), ttlFrom(edge, "missing", now))
 }
 
 func TestNeedReindexing(t *testing.T) {
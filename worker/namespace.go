@@ -0,0 +1,118 @@
+/*
+ * Copyright 2018 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package worker
+
+import (
+	"strings"
+
+	"github.com/dgraph-io/dgraph/posting"
+	"github.com/dgraph-io/dgraph/protos/pb"
+	"github.com/dgraph-io/dgraph/schema"
+	"github.com/dgraph-io/dgraph/x"
+	"golang.org/x/net/context"
+)
+
+// The query layer only deals with the predicate names seen by the clients. The functions sending
+// queries, sorts, mutations and schema requests over the network qualify them with the namespace
+// of the request context, so that everything below only sees stored predicate names.
+
+// NamespaceTokenHash returns the hash of the token of namespace ns, if there's such a namespace.
+func NamespaceTokenHash(ns string) (string, bool) {
+	g := groups()
+	g.RLock()
+	defer g.RUnlock()
+	for _, n := range g.state.GetNamespaces() {
+		if n.Name == ns {
+			return n.TokenHash, true
+		}
+	}
+	return "", false
+}
+
+// Namespaces returns the names of all namespaces, apart from the default one.
+func Namespaces() []string {
+	g := groups()
+	g.RLock()
+	defer g.RUnlock()
+	var names []string
+	for _, n := range g.state.GetNamespaces() {
+		names = append(names, n.Name)
+	}
+	return names
+}
+
+// namespaceAttr returns the stored name of the predicate attr in the namespace of ctx. Reverse
+// predicates keep their ~ prefix in front.
+func namespaceAttr(ctx context.Context, attr string) (string, error) {
+	if strings.Contains(attr, x.NamespaceSep) {
+		return "", x.Errorf("Predicate name %q can't contain %q", attr, x.NamespaceSep)
+	}
+	ns := x.NamespaceFromContext(ctx)
+	if strings.HasPrefix(attr, "~") {
+		return "~" + x.NamespaceAttr(ns, attr[1:]), nil
+	}
+	return x.NamespaceAttr(ns, attr), nil
+}
+
+// namespaceMutations qualifies the predicates of the mutations with the namespace of ctx. Dropping
+// everything in a namespace only drops the predicates of the namespace.
+func namespaceMutations(ctx context.Context, m *pb.Mutations) error {
+	var err error
+	for _, edge := range m.Edges {
+		if edge.Attr, err = namespaceAttr(ctx, edge.Attr); err != nil {
+			return err
+		}
+	}
+	for _, su := range m.Schema {
+		if su.Predicate, err = namespaceAttr(ctx, su.Predicate); err != nil {
+			return err
+		}
+	}
+	if len(m.Tombstone) > 0 {
+		if m.Tombstone, err = namespaceAttr(ctx, m.Tombstone); err != nil {
+			return err
+		}
+	}
+	if ns := x.NamespaceFromContext(ctx); len(ns) > 0 && m.DropAll {
+		m.DropAll = false
+		m.DropNamespace = ns
+	}
+	return nil
+}
+
+// dropNamespace deletes all predicates of the namespace served by this group.
+func (n *node) dropNamespace(ctx context.Context, ns string) error {
+	var attrs []string
+	for _, attr := range schema.State().Predicates() {
+		if pns, _ := x.ParseNamespaceAttr(attr); pns == ns {
+			attrs = append(attrs, attr)
+		}
+	}
+	// Drop the namespace only if there are no pending transactions on any of its predicates.
+	for _, attr := range attrs {
+		if err := detectPendingTxns(attr); err != nil {
+			return err
+		}
+	}
+	for _, attr := range attrs {
+		indexBuilds.abort(attr)
+		if err := posting.DeletePredicate(ctx, attr); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,438 @@
+/*
+ * Copyright 2018 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package worker
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"encoding/binary"
+	"math"
+	"os"
+
+	"github.com/dgraph-io/dgraph/x"
+)
+
+// This file contains a minimal Parquet writer, just enough for exports. All columns are flat,
+// either required or optional (nullable), values are PLAIN encoded and every column chunk is
+// written as a single GZIP compressed data page. See https://github.com/apache/parquet-format for the format.
+
+const parquetMagic = "PAR1"
+
+// Parquet physical types.
+const (
+	parquetBoolean   int32 = 0
+	parquetInt64     int32 = 2
+	parquetDouble    int32 = 5
+	parquetByteArray int32 = 6
+)
+
+// Parquet converted types, annotating how physical types should be interpreted.
+const (
+	parquetNone            int32 = -1
+	parquetUTF8            int32 = 0
+	parquetTimestampMicros int32 = 10
+	parquetUint64          int32 = 14
+	parquetJSON            int32 = 19
+)
+
+const (
+	parquetEncodingPlain = 0
+	parquetEncodingRLE   = 3
+	parquetCodecGzip     = 2
+	parquetDataPage      = 0
+	parquetRequired      = 0
+	parquetOptional      = 1
+)
+
+// Row groups are flushed once they reach either limit.
+const (
+	parquetMaxRows  = 1 << 20
+	parquetMaxBytes = 64 << 20
+)
+
+// thriftWriter writes the subset of the Thrift compact protocol used by Parquet metadata.
+type thriftWriter struct {
+	bytes.Buffer
+	last  int16   // Id of the last field written in the current struct.
+	stack []int16 // Last field ids of the enclosing structs.
+}
+
+const (
+	thriftI32    = 5
+	thriftI64    = 6
+	thriftBinary = 8
+	thriftList   = 9
+	thriftStruct = 12
+)
+
+func (w *thriftWriter) varint(v uint64) {
+	var b [binary.MaxVarintLen64]byte
+	n := binary.PutUvarint(b[:], v)
+	w.Write(b[:n])
+}
+
+func (w *thriftWriter) zigzag(v int64) {
+	w.varint(uint64((v << 1) ^ (v >> 63)))
+}
+
+func (w *thriftWriter) field(id int16, typ byte) {
+	if delta := id - w.last; delta > 0 && delta <= 15 {
+		w.WriteByte(byte(delta)<<4 | typ)
+	} else {
+		w.WriteByte(typ)
+		w.zigzag(int64(id))
+	}
+	w.last = id
+}
+
+func (w *thriftWriter) i32(id int16, v int32) {
+	w.field(id, thriftI32)
+	w.zigzag(int64(v))
+}
+
+func (w *thriftWriter) i64(id int16, v int64) {
+	w.field(id, thriftI64)
+	w.zigzag(v)
+}
+
+func (w *thriftWriter) str(s string) {
+	w.varint(uint64(len(s)))
+	w.WriteString(s)
+}
+
+func (w *thriftWriter) string(id int16, s string) {
+	w.field(id, thriftBinary)
+	w.str(s)
+}
+
+// list writes the header of a list field. The n elements have to be written right after.
+func (w *thriftWriter) list(id int16, typ byte, n int) {
+	w.field(id, thriftList)
+	if n < 15 {
+		w.WriteByte(byte(n)<<4 | typ)
+		return
+	}
+	w.WriteByte(0xf0 | typ)
+	w.varint(uint64(n))
+}
+
+// begin starts a struct. An id of zero starts a top-level struct or an element of a list.
+func (w *thriftWriter) begin(id int16) {
+	if id > 0 {
+		w.field(id, thriftStruct)
+	}
+	w.stack = append(w.stack, w.last)
+	w.last = 0
+}
+
+func (w *thriftWriter) end() {
+	w.WriteByte(0) // Field stop.
+	w.last = w.stack[len(w.stack)-1]
+	w.stack = w.stack[:len(w.stack)-1]
+}
+
+type parquetChunk struct {
+	offset       int64
+	uncompressed int64
+	compressed   int64
+	numValues    int64
+}
+
+type parquetRowGroup struct {
+	chunks []parquetChunk
+	rows   int64
+	size   int64
+}
+
+type parquetColumn struct {
+	name      string
+	typ       int32
+	converted int32
+	optional  bool
+	values    []byte // PLAIN encoded values of the current row group.
+	n         int64  // Number of values in the current row group, without the nulls.
+	nulls     int64  // Number of nulls in the current row group.
+
+	// The definition levels of an optional column, 0 for nulls and 1 for values, as RLE runs.
+	levels   []byte
+	runLevel byte
+	runLen   uint64
+}
+
+// level records whether the next value of an optional column is defined.
+func (c *parquetColumn) level(defined bool) {
+	if !c.optional {
+		return
+	}
+	var l byte
+	if defined {
+		l = 1
+	}
+	if c.runLen > 0 && l != c.runLevel {
+		c.endRun()
+	}
+	c.runLevel = l
+	c.runLen++
+}
+
+func (c *parquetColumn) endRun() {
+	var b [binary.MaxVarintLen64]byte
+	n := binary.PutUvarint(b[:], c.runLen<<1)
+	c.levels = append(c.levels, b[:n]...)
+	c.levels = append(c.levels, c.runLevel)
+	c.runLen = 0
+}
+
+// page returns the data of the page of the current row group: the definition levels of an
+// optional column, prefixed by their length, then the values.
+func (c *parquetColumn) page() []byte {
+	if !c.optional {
+		return c.values
+	}
+	if c.runLen > 0 {
+		c.endRun()
+	}
+	data := make([]byte, 4, 4+len(c.levels)+len(c.values))
+	binary.LittleEndian.PutUint32(data, uint32(len(c.levels)))
+	data = append(data, c.levels...)
+	return append(data, c.values...)
+}
+
+func (c *parquetColumn) appendNull() {
+	x.AssertTruef(c.optional, "Null appended to required column %s", c.name)
+	c.level(false)
+	c.nulls++
+}
+
+func (c *parquetColumn) appendInt64(v int64) {
+	c.level(true)
+	var b [8]byte
+	binary.LittleEndian.PutUint64(b[:], uint64(v))
+	c.values = append(c.values, b[:]...)
+	c.n++
+}
+
+func (c *parquetColumn) appendDouble(v float64) {
+	c.level(true)
+	var b [8]byte
+	binary.LittleEndian.PutUint64(b[:], math.Float64bits(v))
+	c.values = append(c.values, b[:]...)
+	c.n++
+}
+
+// Booleans are bit-packed, least significant bit first.
+func (c *parquetColumn) appendBool(v bool) {
+	c.level(true)
+	if c.n%8 == 0 {
+		c.values = append(c.values, 0)
+	}
+	if v {
+		c.values[len(c.values)-1] |= 1 << uint(c.n%8)
+	}
+	c.n++
+}
+
+func (c *parquetColumn) appendBytes(v []byte) {
+	c.level(true)
+	var b [4]byte
+	binary.LittleEndian.PutUint32(b[:], uint32(len(v)))
+	c.values = append(c.values, b[:]...)
+	c.values = append(c.values, v...)
+	c.n++
+}
+
+// parquetWriter writes rows into a Parquet file. Values are appended to the columns directly,
+// after which endRow must be called.
+type parquetWriter struct {
+	fd      *os.File
+	bw      *bufio.Writer
+	offset  int64
+	columns []*parquetColumn
+	rows    int64
+	groups  []parquetRowGroup
+}
+
+func newParquetWriter(fpath string, columns []*parquetColumn) (*parquetWriter, error) {
+	fd, err := os.Create(fpath)
+	if err != nil {
+		return nil, err
+	}
+	w := &parquetWriter{fd: fd, bw: bufio.NewWriterSize(fd, 1e6), columns: columns}
+	if err := w.write([]byte(parquetMagic)); err != nil {
+		fd.Close()
+		return nil, err
+	}
+	return w, nil
+}
+
+func (w *parquetWriter) write(b []byte) error {
+	n, err := w.bw.Write(b)
+	w.offset += int64(n)
+	return err
+}
+
+func (w *parquetWriter) endRow() error {
+	w.rows++
+	if w.rows >= parquetMaxRows {
+		return w.flushRowGroup()
+	}
+	var size int
+	for _, c := range w.columns {
+		size += len(c.values)
+	}
+	if size >= parquetMaxBytes {
+		return w.flushRowGroup()
+	}
+	return nil
+}
+
+func (w *parquetWriter) flushRowGroup() error {
+	if w.rows == 0 {
+		return nil
+	}
+	rg := parquetRowGroup{rows: w.rows}
+	for _, c := range w.columns {
+		data := c.page()
+		var page bytes.Buffer
+		gw, err := gzip.NewWriterLevel(&page, gzip.BestSpeed)
+		if err != nil {
+			return err
+		}
+		if _, err := gw.Write(data); err != nil {
+			return err
+		}
+		if err := gw.Close(); err != nil {
+			return err
+		}
+
+		var hdr thriftWriter
+		hdr.begin(0) // PageHeader
+		hdr.i32(1, parquetDataPage)
+		hdr.i32(2, int32(len(data)))
+		hdr.i32(3, int32(page.Len()))
+		hdr.begin(5) // DataPageHeader
+		hdr.i32(1, int32(c.n+c.nulls))
+		hdr.i32(2, parquetEncodingPlain)
+		hdr.i32(3, parquetEncodingRLE)
+		hdr.i32(4, parquetEncodingRLE)
+		hdr.end()
+		hdr.end()
+
+		chunk := parquetChunk{
+			offset:       w.offset,
+			uncompressed: int64(hdr.Len() + len(data)),
+			compressed:   int64(hdr.Len() + page.Len()),
+			numValues:    c.n + c.nulls,
+		}
+		if err := w.write(hdr.Bytes()); err != nil {
+			return err
+		}
+		if err := w.write(page.Bytes()); err != nil {
+			return err
+		}
+		rg.chunks = append(rg.chunks, chunk)
+		rg.size += chunk.uncompressed
+
+		c.values, c.levels = c.values[:0], c.levels[:0]
+		c.n, c.nulls = 0, 0
+	}
+	w.groups = append(w.groups, rg)
+	w.rows = 0
+	return nil
+}
+
+// footer returns the FileMetaData of the file.
+func (w *parquetWriter) footer() []byte {
+	var t thriftWriter
+	t.begin(0)
+	t.i32(1, 1) // Version.
+	t.list(2, thriftStruct, len(w.columns)+1)
+	t.begin(0) // The root of the schema.
+	t.string(4, "schema")
+	t.i32(5, int32(len(w.columns)))
+	t.end()
+	for _, c := range w.columns {
+		t.begin(0)
+		t.i32(1, c.typ)
+		if c.optional {
+			t.i32(3, parquetOptional)
+		} else {
+			t.i32(3, parquetRequired)
+		}
+		t.string(4, c.name)
+		if c.converted != parquetNone {
+			t.i32(6, c.converted)
+		}
+		t.end()
+	}
+	var total int64
+	for _, rg := range w.groups {
+		total += rg.rows
+	}
+	t.i64(3, total)
+	t.list(4, thriftStruct, len(w.groups))
+	for _, rg := range w.groups {
+		t.begin(0)
+		t.list(1, thriftStruct, len(rg.chunks))
+		for i, chunk := range rg.chunks {
+			c := w.columns[i]
+			t.begin(0) // ColumnChunk
+			t.i64(2, chunk.offset)
+			t.begin(3) // ColumnMetaData
+			t.i32(1, c.typ)
+			t.list(2, thriftI32, 1)
+			t.zigzag(parquetEncodingPlain)
+			t.list(3, thriftBinary, 1)
+			t.str(c.name)
+			t.i32(4, parquetCodecGzip)
+			t.i64(5, chunk.numValues)
+			t.i64(6, chunk.uncompressed)
+			t.i64(7, chunk.compressed)
+			t.i64(9, chunk.offset)
+			t.end()
+			t.end()
+		}
+		t.i64(2, rg.size)
+		t.i64(3, rg.rows)
+		t.end()
+	}
+	t.string(6, "dgraph")
+	t.end()
+	return t.Bytes()
+}
+
+func (w *parquetWriter) Close() error {
+	if err := w.flushRowGroup(); err != nil {
+		return err
+	}
+	footer := w.footer()
+	var size [4]byte
+	binary.LittleEndian.PutUint32(size[:], uint32(len(footer)))
+	for _, b := range [][]byte{footer, size[:], []byte(parquetMagic)} {
+		if err := w.write(b); err != nil {
+			return err
+		}
+	}
+	if err := w.bw.Flush(); err != nil {
+		return err
+	}
+	if err := w.fd.Sync(); err != nil {
+		return err
+	}
+	return w.fd.Close()
+}
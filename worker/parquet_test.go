@@ -0,0 +1,111 @@
+/*
+ * Copyright 2018 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package worker
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestThriftCompact(t *testing.T) {
+	var w thriftWriter
+	w.begin(0)
+	w.i32(1, 1)
+	w.string(4, "ab")
+	w.i64(20, -1) // Delta too large for the short form.
+	w.list(21, thriftI32, 2)
+	w.zigzag(3)
+	w.zigzag(4)
+	w.begin(22)
+	w.i32(1, 0)
+	w.end()
+	w.end()
+	require.Equal(t, []byte{
+		0x15, 0x02, // i32 field 1, value 1.
+		0x38, 0x02, 'a', 'b', // binary field 4.
+		0x06, 0x28, 0x01, // i64 field 20, value -1.
+		0x19, 0x25, 0x06, 0x08, // list field 21 of two i32.
+		0x1c, 0x15, 0x00, 0x00, // struct field 22, then stop.
+		0x00,
+	}, w.Bytes())
+}
+
+func TestParquetWriter(t *testing.T) {
+	dir, err := ioutil.TempDir("", "parquet")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	uid := &parquetColumn{name: "uid", typ: parquetInt64, converted: parquetUint64}
+	val := &parquetColumn{name: "value", typ: parquetBoolean, converted: parquetNone}
+	fpath := filepath.Join(dir, "test.parquet")
+	w, err := newParquetWriter(fpath, []*parquetColumn{uid, val})
+	require.NoError(t, err)
+	for i := 0; i < 10; i++ {
+		uid.appendInt64(int64(i))
+		val.appendBool(i%3 == 0)
+		require.NoError(t, w.endRow())
+		if i == 4 {
+			// Values are bit-packed.
+			require.Equal(t, []byte{0x09}, val.values)
+			require.NoError(t, w.flushRowGroup())
+		}
+	}
+	require.NoError(t, w.Close())
+	require.Equal(t, 2, len(w.groups))
+
+	b, err := ioutil.ReadFile(fpath)
+	require.NoError(t, err)
+	require.Equal(t, parquetMagic, string(b[:4]))
+	require.Equal(t, parquetMagic, string(b[len(b)-4:]))
+	size := int(binary.LittleEndian.Uint32(b[len(b)-8:]))
+	footer := b[len(b)-8-size : len(b)-8]
+	require.Equal(t, w.footer(), footer)
+	require.True(t, bytes.Contains(footer, []byte("value")))
+
+	// The first column chunk starts right after the magic bytes.
+	require.Equal(t, int64(4), w.groups[0].chunks[0].offset)
+	last := w.groups[1].chunks[1]
+	require.Equal(t, int64(len(b)-8-size), last.offset+last.compressed)
+}
+
+func TestParquetOptionalColumn(t *testing.T) {
+	c := &parquetColumn{name: "facets", typ: parquetByteArray, converted: parquetJSON,
+		optional: true}
+	c.appendBytes([]byte("a"))
+	c.appendNull()
+	c.appendNull()
+	c.appendBytes([]byte("b"))
+	require.Equal(t, int64(2), c.n)
+	require.Equal(t, int64(2), c.nulls)
+	require.Equal(t, []byte{
+		0x06, 0x00, 0x00, 0x00, // Length of the definition levels.
+		0x02, 0x01, 0x04, 0x00, 0x02, 0x01, // Runs of one value, two nulls and one value.
+		0x01, 0x00, 0x00, 0x00, 'a',
+		0x01, 0x00, 0x00, 0x00, 'b',
+	}, c.page())
+
+	// Required columns have no definition levels.
+	uid := &parquetColumn{name: "uid", typ: parquetInt64, converted: parquetUint64}
+	uid.appendInt64(1)
+	require.Equal(t, uid.values, uid.page())
+}
@@ -17,7 +17,9 @@
 package worker
 
 import (
+	"sync"
 	"sync/atomic"
+	"time"
 
 	"github.com/coreos/etcd/raft"
 	"github.com/dgraph-io/badger"
@@ -33,8 +35,93 @@ import (
 const (
 	// MB represents a megabyte.
 	MB = 1 << 20
+	// How often a follower records how far it got receiving a snapshot, to resume from there.
+	snapshotCheckpointInterval = 10 * time.Second
 )
 
+// transferProgress is the progress of the snapshot this Alpha is receiving from the leader of
+// its group, if any. It's reported to Zero along with the membership.
+type transferProgress struct {
+	sync.Mutex
+	cur *pb.SnapshotProgress
+}
+
+var snapshotTransfer transferProgress
+
+// start tracks the transfer of snap from addr. The progress of the earlier attempts is kept if
+// they're resumed.
+func (t *transferProgress) start(snap pb.Snapshot, addr string, resumed bool) {
+	t.Lock()
+	defer t.Unlock()
+	if resumed && t.cur != nil && t.cur.Index == snap.Index && t.cur.ReadTs == snap.ReadTs {
+		t.cur.Resumes++
+		t.cur.From = addr
+		return
+	}
+	t.cur = &pb.SnapshotProgress{
+		Index:     snap.Index,
+		ReadTs:    snap.ReadTs,
+		From:      addr,
+		StartedAt: uint64(time.Now().Unix()),
+	}
+}
+
+func (t *transferProgress) received(kvs *pb.KVS) {
+	t.Lock()
+	defer t.Unlock()
+	if t.cur != nil {
+		t.cur.Keys += uint64(len(kvs.Kv))
+		t.cur.Bytes += uint64(kvs.Size())
+	}
+}
+
+func (t *transferProgress) done() {
+	t.Lock()
+	defer t.Unlock()
+	t.cur = nil
+}
+
+// progress returns a copy of the progress of the transfer, or nil if there's none.
+func (t *transferProgress) progress() *pb.SnapshotProgress {
+	t.Lock()
+	defer t.Unlock()
+	if t.cur == nil {
+		return nil
+	}
+	p := *t.cur
+	return &p
+}
+
+// resumeKey returns the key from which the transfer of snap can be resumed, if an earlier
+// attempt got part of it. Only the same snapshot can be resumed, as others are at another read
+// ts.
+func (n *node) resumeKey(snap pb.Snapshot) []byte {
+	data, err := n.Store.TransferState()
+	if err != nil || len(data) == 0 {
+		return nil
+	}
+	var prev pb.Snapshot
+	if err := prev.Unmarshal(data); err != nil {
+		glog.Warningf("Unable to read the state of the last snapshot transfer: %v", err)
+		return nil
+	}
+	if prev.Index != snap.Index || prev.ReadTs != snap.ReadTs || prev.SinceTs != snap.SinceTs {
+		return nil
+	}
+	return prev.ResumeFrom
+}
+
+// saveCheckpoint records that all the keys of snap before key were written.
+func (n *node) saveCheckpoint(snap pb.Snapshot, key []byte) error {
+	state := pb.Snapshot{Index: snap.Index, ReadTs: snap.ReadTs, SinceTs: snap.SinceTs,
+		ResumeFrom: key}
+	data, err := state.Marshal()
+	if err != nil {
+		return err
+	}
+	return n.Store.SetTransferState(data)
+}
+
 // populateSnapshot gets data for a shard from the leader and writes it to BadgerDB on the follower.
 func (n *node) populateSnapshot(snap pb.Snapshot, ps *badger.DB, pl *conn.Pool) (int, error) {
 	conn := pl.Get()
@@ -43,6 +130,10 @@ func (n *node) populateSnapshot(snap pb.Snapshot, ps *badger.DB, pl *conn.Pool)
 	// Set my RaftContext on the snapshot, so it's easier to locate me.
 	ctx := n.ctx
 	snap.Context = n.RaftContext
+	// Pick up where an earlier attempt to get the same snapshot left off, if any. The keys it
+	// wrote after its last checkpoint are sent again, and overwritten.
+	snap.ResumeFrom = n.resumeKey(snap)
+	resumed := len(snap.ResumeFrom) > 0
 	stream, err := c.StreamSnapshot(ctx)
 	if err != nil {
 		return 0, err
@@ -50,15 +141,21 @@ func (n *node) populateSnapshot(snap pb.Snapshot, ps *badger.DB, pl *conn.Pool)
 	if err := stream.Send(&snap); err != nil {
 		return 0, err
 	}
-	// Before we write anything, we should drop all the data stored in ps.
-	if err := ps.DropAll(); err != nil {
-		return 0, err
+	snapshotTransfer.start(snap, pl.Addr, resumed)
+	if resumed {
+		glog.Infof("Resuming snapshot transfer from key %x", snap.ResumeFrom)
+	} else {
+		// Before we write anything, we should drop all the data stored in ps.
+		if err := ps.DropAll(); err != nil {
+			return 0, err
+		}
 	}
 
 	// We can use count to check the number of posting lists returned in tests.
 	count := 0
 	writer := x.NewTxnWriter(ps)
 	writer.BlindWrite = true // Do overwrite keys.
+	lastCheckpoint := time.Now()
 	for {
 		kvs, err := stream.Recv()
 		if err != nil {
@@ -79,10 +176,25 @@ func (n *node) populateSnapshot(snap pb.Snapshot, ps *badger.DB, pl *conn.Pool)
 			return 0, err
 		}
 		count += len(kvs.Kv)
+		snapshotTransfer.received(kvs)
+		if len(kvs.Checkpoint) > 0 && time.Since(lastCheckpoint) >= snapshotCheckpointInterval {
+			// The keys before the checkpoint must be written before it's recorded.
+			if err := writer.Flush(); err != nil {
+				return 0, err
+			}
+			if err := n.saveCheckpoint(snap, kvs.Checkpoint); err != nil {
+				return 0, err
+			}
+			lastCheckpoint = time.Now()
+		}
 	}
 	if err := writer.Flush(); err != nil {
 		return 0, err
 	}
+	if err := n.Store.SetTransferState(nil); err != nil {
+		return 0, err
+	}
+	snapshotTransfer.done()
 	glog.Infof("Snapshot writes DONE. Sending ACK")
 	// Send an acknowledgement back to the leader.
 	if err := stream.Send(&pb.Snapshot{Done: true}); err != nil {
@@ -92,27 +204,25 @@ func (n *node) populateSnapshot(snap pb.Snapshot, ps *badger.DB, pl *conn.Pool)
 	return count, nil
 }
 
-func doStreamSnapshot(snap *pb.Snapshot, stream pb.Worker_StreamSnapshotServer) error {
-	// We choose not to try and match the requested snapshot from the latest snapshot at the leader.
-	// This is the job of the Raft library. At the leader end, we service whatever is asked of us.
-	// If this snapshot is old, Raft should cause the follower to request another one, to overwrite
-	// the data from this one.
-	//
-	// Snapshot request contains the txn read timestamp to be used to get a consistent snapshot of
-	// the data. This is what we use in orchestrate.
-	//
-	// Note: This would also pick up schema updates done "after" the snapshot index. Guess that
-	// might be OK. Otherwise, we'd want to version the schemas as well. Currently, they're stored
-	// at timestamp=1.
-
-	var numKeys uint64
-	sl := ws.Lists{Stream: stream, DB: pstore}
-	sl.ChooseKeyFunc = func(_ *badger.Item) bool {
-		// Pick all keys.
-		return true
+// snapshotLists returns the lists streaming the keys of snap, counting them in numKeys. When
+// replicating, they're only the keys changed since snap.SinceTs.
+func snapshotLists(snap *pb.Snapshot, numKeys *uint64) *ws.Lists {
+	sl := &ws.Lists{DB: pstore, Checkpoint: true, Rate: Config.SnapshotRate}
+	// Resume the transfer, if the follower has the keys before ResumeFrom already.
+	sl.StartKey = snap.ResumeFrom
+	sl.ChooseKeyFunc = func(item *badger.Item) bool {
+		// Pick all keys, or only the ones changed since SinceTs when replicating. The schema is
+		// always picked, as it isn't versioned, unless it was deleted. Drops delete the keys at
+		// the versions of their values, which could be older than SinceTs, so the deleted keys
+		// are always picked too, until compactions discard them.
+		pk := x.Parse(item.Key())
+		if pk != nil && pk.IsSchema() {
+			return !item.IsDeletedOrExpired()
+		}
+		return item.Version() > snap.SinceTs || item.IsDeletedOrExpired()
 	}
 	sl.ItemToKVFunc = func(key []byte, itr *badger.Iterator) (*pb.KV, error) {
-		atomic.AddUint64(&numKeys, 1)
+		atomic.AddUint64(numKeys, 1)
 		item := itr.Item()
 		pk := x.Parse(key)
 		if pk.IsSchema() {
@@ -128,6 +238,10 @@ func doStreamSnapshot(snap *pb.Snapshot, stream pb.Worker_StreamSnapshotServer)
 			}
 			return kv, nil
 		}
+		// The list is written at its latest version. Deleting postings doesn't move the version
+		// of the rolled up list, and deleted keys have none, so the deletions would be written
+		// below the versions the replicas have already.
+		version := item.Version()
 		// We should keep reading the posting list instead of copying the key-value pairs directly,
 		// to consolidate the read logic in one place. This is more robust than trying to replicate
 		// a simplified key-value copy logic here, which still understands the BitCompletePosting
@@ -136,9 +250,32 @@ func doStreamSnapshot(snap *pb.Snapshot, stream pb.Worker_StreamSnapshotServer)
 		if err != nil {
 			return nil, err
 		}
-		return l.MarshalToKv()
+		kv, err := l.MarshalToKv()
+		if err != nil {
+			return nil, err
+		}
+		kv.Version = x.Max(kv.Version, version)
+		return kv, nil
 	}
+	return sl
+}
 
+func doStreamSnapshot(snap *pb.Snapshot, stream pb.Worker_StreamSnapshotServer) error {
+	// We choose not to try and match the requested snapshot from the latest snapshot at the leader.
+	// This is the job of the Raft library. At the leader end, we service whatever is asked of us.
+	// If this snapshot is old, Raft should cause the follower to request another one, to overwrite
+	// the data from this one.
+	//
+	// Snapshot request contains the txn read timestamp to be used to get a consistent snapshot of
+	// the data. This is what we use in orchestrate.
+	//
+	// Note: This would also pick up schema updates done "after" the snapshot index. Guess that
+	// might be OK. Otherwise, we'd want to version the schemas as well. Currently, they're stored
+	// at timestamp=1.
+
+	var numKeys uint64
+	sl := snapshotLists(snap, &numKeys)
+	sl.Stream = stream
 	if err := sl.Orchestrate(stream.Context(), "Sending SNAPSHOT", snap.ReadTs); err != nil {
 		return err
 	}
@@ -184,6 +321,11 @@ func (w *grpcWorker) StreamSnapshot(stream pb.Worker_StreamSnapshotServer) error
 		return err
 	}
 	glog.Infof("Got StreamSnapshot request: %+v\n", snap)
+	// Snapshots requested from outside the group, like the ones of dgraph clone, can be at a
+	// read ts which this node hasn't caught up with yet.
+	if err := posting.Oracle().WaitForTs(stream.Context(), snap.ReadTs); err != nil {
+		return err
+	}
 	if err := doStreamSnapshot(snap, stream); err != nil {
 		glog.Errorf("While streaming snapshot: %v. Reporting failure.", err)
 		n.Raft().ReportSnapshot(snap.Context.GetId(), raft.SnapshotFailure)
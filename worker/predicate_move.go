@@ -23,9 +23,12 @@ import (
 	"strconv"
 	"sync"
 	"sync/atomic"
+	"time"
 
 	"github.com/golang/glog"
 	"golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 
 	"github.com/dgraph-io/badger"
 	"github.com/dgraph-io/dgo/protos/api"
@@ -43,6 +46,66 @@ var (
 	emptyPayload      = api.Payload{}
 )
 
+// TabletMovingError is returned for writes to a predicate whose tablet is read-only, because it's
+// being moved to another group or renamed. Such writes can be retried once the move is done.
+type TabletMovingError struct {
+	Predicate string
+	DestGroup uint32    // Zero if the tablet isn't moving to another group.
+	ETA       time.Time // Zero if unknown.
+}
+
+func (e *TabletMovingError) Error() string {
+	msg := fmt.Sprintf("Predicate %s is being moved", e.Predicate)
+	if e.DestGroup > 0 {
+		msg += fmt.Sprintf(" to group %d", e.DestGroup)
+	}
+	if d := e.RetryAfter(); d > 0 {
+		return msg + fmt.Sprintf(", please retry in %v", d)
+	}
+	return msg + ", please retry later"
+}
+
+// RetryAfter returns how long to wait before retrying, or zero if unknown. Moves taking longer
+// than expected are retried every second.
+func (e *TabletMovingError) RetryAfter() time.Duration {
+	if e.ETA.IsZero() {
+		return 0
+	}
+	if d := time.Until(e.ETA).Round(time.Second); d > time.Second {
+		return d
+	}
+	return time.Second
+}
+
+// GRPCStatus makes the error reach gRPC clients as Unavailable, so they know they can retry.
+func (e *TabletMovingError) GRPCStatus() *status.Status {
+	return status.New(codes.Unavailable, e.Error())
+}
+
+func errTabletMoving(tablet *pb.Tablet) error {
+	err := &TabletMovingError{Predicate: tablet.Predicate, DestGroup: tablet.MovingTo}
+	if tablet.MoveEta > 0 {
+		err.ETA = time.Unix(tablet.MoveEta, 0)
+	}
+	return err
+}
+
+// movingTablet returns the error for writes to any of the predicates of the mutations, if one
+// of their tablets is read-only as per the membership state known to this Alpha.
+func movingTablet(m *pb.Mutations) error {
+	for _, edge := range m.Edges {
+		if tablet := groups().Tablet(edge.Attr); tablet != nil && tablet.ReadOnly {
+			return errTabletMoving(tablet)
+		}
+	}
+	for _, su := range m.Schema {
+		if tablet := groups().Tablet(su.Predicate); tablet != nil && tablet.ReadOnly {
+			return errTabletMoving(tablet)
+		}
+	}
+	return nil
+}
+
 // size of kvs won't be too big, we would take care before proposing.
 func populateKeyValues(ctx context.Context, kvs []*pb.KV) error {
 	// No new deletion/background cleanup would start after we start streaming tablet,
@@ -54,14 +117,18 @@ func populateKeyValues(ctx context.Context, kvs []*pb.KV) error {
 	var hasError uint32
 	var wg sync.WaitGroup
 	wg.Add(len(kvs))
-	first := true
-	var predicate string
+	// Moved tablets bring the keys of a single predicate, replicated ones those of any.
+	var predicates []string
+	seen := make(map[string]bool)
+	keys := make([][]byte, 0, len(kvs))
+	var maxVersion uint64
 	for _, kv := range kvs {
-		if first {
-			pk := x.Parse(kv.Key)
-			predicate = pk.Attr
-			first = false
+		if pk := x.Parse(kv.Key); pk != nil && !seen[pk.Attr] {
+			seen[pk.Attr] = true
+			predicates = append(predicates, pk.Attr)
 		}
+		keys = append(keys, kv.Key)
+		maxVersion = x.Max(maxVersion, kv.Version)
 		txn := pstore.NewTransactionAt(math.MaxUint64, true)
 		if err := txn.SetWithMeta(kv.Key, kv.Val, kv.UserMeta[0]); err != nil {
 			return err
@@ -81,10 +148,20 @@ func populateKeyValues(ctx context.Context, kvs []*pb.KV) error {
 		return x.Errorf("Error while writing to badger")
 	}
 	wg.Wait()
-	return schema.Load(predicate)
+	// The lists of the keys might have been read before, if they were replicated.
+	posting.EvictKeys(keys)
+	for _, predicate := range predicates {
+		if err := schema.Load(predicate); err != nil {
+			return err
+		}
+	}
+	invalidations.committed(predicates, maxVersion)
+	return nil
 }
 
-func movePredicateHelper(ctx context.Context, predicate string, gid uint32) error {
+// movePredicateHelper sends the predicate to the group gid. If r isn't nil, only the data of the
+// uids in r is sent, along with their postings in the other lists.
+func movePredicateHelper(ctx context.Context, predicate string, gid uint32, r *shardRange) error {
 	pl := groups().Leader(gid)
 	if pl == nil {
 		return x.Errorf("Unable to find a connection for group: %d\n", gid)
@@ -103,8 +180,22 @@ func movePredicateHelper(ctx context.Context, predicate string, gid uint32) erro
 		if err != nil {
 			return nil, err
 		}
+		if pk := x.Parse(key); r != nil && pk != nil && !pk.IsData() {
+			kv, err := l.MarshalToKvFiltered(r.has)
+			if err != nil || len(kv.Val) == 0 {
+				// No need to send the lists without any of the uids.
+				return nil, err
+			}
+			return kv, nil
+		}
 		return l.MarshalToKv()
 	}
+	if r != nil {
+		sl.ChooseKeyFunc = func(item *badger.Item) bool {
+			pk := x.Parse(item.Key())
+			return pk != nil && (!pk.IsData() || r.has(pk.Uid))
+		}
+	}
 
 	prefix := fmt.Sprintf("Sending predicate: [%s]", predicate)
 	if err := sl.Orchestrate(ctx, prefix, math.MaxUint64); err != nil {
@@ -265,26 +356,12 @@ func (w *grpcWorker) MovePredicate(ctx context.Context,
 	if err := n.proposeAndWait(ctx, &pb.Proposal{State: in.State}); err != nil {
 		return &emptyPayload, err
 	}
-	aborted := false
-	for i := 0; i < 12; i++ {
-		// Try a dozen times, then give up.
-		glog.Infof("Trying to abort pending mutations. Loop: %d", i)
-		tctxs := posting.Oracle().IterateTxns(func(key []byte) bool {
-			pk := x.Parse(key)
-			return pk.Attr == in.Predicate
-		})
-		if len(tctxs) == 0 {
-			aborted = true
-			break
-		}
-		tryAbortTransactions(tctxs)
-	}
-	if !aborted {
-		return &emptyPayload, errUnableToAbort
+	if err := abortPendingTxns(in.Predicate); err != nil {
+		return &emptyPayload, err
 	}
 	// We iterate over badger, so need to flush and wait for sync watermark to catch up.
 	n.applyAllMarks(ctx)
 
-	err := movePredicateHelper(ctx, in.Predicate, in.DestGroupId)
+	err := movePredicateHelper(ctx, in.Predicate, in.DestGroupId, nil)
 	return &emptyPayload, err
 }
@@ -23,11 +23,14 @@ import (
 	"net"
 	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/dgraph-io/badger"
 	"github.com/golang/glog"
 	"github.com/stretchr/testify/require"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 
 	"github.com/dgraph-io/dgraph/posting"
 	"github.com/dgraph-io/dgraph/protos/pb"
@@ -425,3 +428,25 @@ func TestJoinCluster(t *testing.T) {
  *  }
  *}
  */
+
+func TestTabletMovingError(t *testing.T) {
+	err := errTabletMoving(&pb.Tablet{Predicate: "name", ReadOnly: true})
+	require.Equal(t, "Predicate name is being moved, please retry later", err.Error())
+
+	eta := time.Now().Add(time.Minute)
+	err = errTabletMoving(&pb.Tablet{Predicate: "name", ReadOnly: true, MovingTo: 2,
+		MoveEta: eta.Unix()})
+	merr, ok := err.(*TabletMovingError)
+	require.True(t, ok)
+	require.Equal(t, uint32(2), merr.DestGroup)
+	require.InDelta(t, time.Minute.Seconds(), merr.RetryAfter().Seconds(), 2)
+	require.Contains(t, err.Error(), "Predicate name is being moved to group 2, please retry in")
+
+	st, ok := status.FromError(err)
+	require.True(t, ok)
+	require.Equal(t, codes.Unavailable, st.Code())
+
+	// Moves taking longer than expected are retried every second.
+	merr.ETA = time.Now().Add(-time.Minute)
+	require.Equal(t, time.Second, merr.RetryAfter())
+}
@@ -27,8 +27,11 @@ import (
 	"github.com/dgraph-io/dgraph/schema"
 	"github.com/dgraph-io/dgraph/x"
 	otrace "go.opencensus.io/trace"
+	"go.opencensus.io/trace/propagation"
 	"golang.org/x/net/context"
 	"golang.org/x/net/trace"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 const baseTimeout time.Duration = 4 * time.Second
@@ -48,7 +51,8 @@ func init() {
 }
 
 type rateLimiter struct {
-	iou int32
+	iou     int32
+	waiting int32 // Proposals waiting for room in pendingProposals.
 }
 
 // Instead of using the time/rate package, we use this simple one, because that
@@ -77,13 +81,50 @@ func (rl *rateLimiter) incr(ctx context.Context, retry int) error {
 		select {
 		case pendingProposals <- struct{}{}:
 			x.PendingProposals.Add(1)
-		case <-ctx.Done():
-			return ctx.Err()
+			continue
+		default:
+		}
+		if err := rl.wait(ctx); err != nil {
+			// Give back what was taken already.
+			for ; i > 0; i-- {
+				<-pendingProposals
+				x.PendingProposals.Add(-1)
+			}
+			return err
 		}
 	}
 	return nil
 }
 
+// wait waits for room in pendingProposals, as one of at most Config.MutationQueue proposals and
+// for at most Config.MutationQueueTimeout. Beyond those, the proposal is turned away, so that
+// clients back off instead of piling up.
+func (rl *rateLimiter) wait(ctx context.Context) error {
+	if Config.MutationQueue > 0 && int(atomic.AddInt32(&rl.waiting, 1)) > Config.MutationQueue {
+		atomic.AddInt32(&rl.waiting, -1)
+		x.RejectedProposals.Add(1)
+		return errOverloaded
+	}
+	defer atomic.AddInt32(&rl.waiting, -1)
+
+	var timeout <-chan time.Time
+	if Config.MutationQueueTimeout > 0 {
+		timer := time.NewTimer(Config.MutationQueueTimeout)
+		defer timer.Stop()
+		timeout = timer.C
+	}
+	select {
+	case pendingProposals <- struct{}{}:
+		x.PendingProposals.Add(1)
+		return nil
+	case <-timeout:
+		x.RejectedProposals.Add(1)
+		return errOverloaded
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
 // Done would slowly bleed the retries out.
 func (rl *rateLimiter) decr(retry int) {
 	if retry == 0 {
@@ -103,6 +144,20 @@ func uniqueKey() string {
 var errInternalRetry = errors.New("Retry Raft proposal internally")
 var errUnableToServe = errors.New("Server overloaded with pending proposals. Please retry later.")
 
+// OverloadedError is returned for the proposals turned away because too many are pending already.
+type OverloadedError struct{}
+
+func (e *OverloadedError) Error() string {
+	return "Server overloaded. Too many mutations are pending, please retry later."
+}
+
+// GRPCStatus makes the error reach gRPC clients as ResourceExhausted, so they know to back off.
+func (e *OverloadedError) GRPCStatus() *status.Status {
+	return status.New(codes.ResourceExhausted, e.Error())
+}
+
+var errOverloaded = &OverloadedError{}
+
 // proposeAndWait sends a proposal through RAFT. It waits on a channel for the proposal
 // to be applied(written to WAL) to all the nodes in the group.
 func (n *node) proposeAndWait(ctx context.Context, proposal *pb.Proposal) error {
@@ -121,24 +176,40 @@ func (n *node) proposeAndWait(ctx context.Context, proposal *pb.Proposal) error
 	// In very rare cases invalid entries might pass through raft, which would
 	// be persisted, we do best effort schema check while writing
 	if proposal.Mutations != nil {
+		strict := StrictSchema()
 		for _, edge := range proposal.Mutations.Edges {
 			if tablet := groups().Tablet(edge.Attr); tablet != nil && tablet.ReadOnly {
-				return errPredicateMoving
-			} else if tablet.GroupId != groups().groupId() {
-				// Tablet can move by the time request reaches here.
+				return errTabletMoving(tablet)
+			} else if tablet != nil && !servesEdge(tablet, edge, groups().groupId()) {
+				// Tablet can move, or be split, by the time request reaches here.
 				return errUnservedTablet
 			}
 
 			su, ok := schema.State().Get(edge.Attr)
 			if !ok {
+				// Scratch predicates are never stored, so they don't need a schema.
+				if strict && edge.Op == pb.DirectedEdge_SET && !x.IsScratchAttr(edge.Attr) {
+					return x.Errorf("Predicate %s is not in the schema. Strict schema mode doesn't"+
+						" allow creating predicates via mutations.", edge.Attr)
+				}
 				continue
-			} else if err := ValidateAndConvert(edge, &su); err != nil {
+			}
+			if strict {
+				if err := checkStrictType(edge, &su); err != nil {
+					return err
+				}
+			}
+			if err := ValidateAndConvert(edge, &su); err != nil {
 				return err
 			}
+			// Stamped here, so that all the replicas expire the value at the same time.
+			if su.Ttl > 0 && edge.Op == pb.DirectedEdge_SET {
+				edge.TtlFrom = ttlFrom(edge, su.TtlFacet, time.Now())
+			}
 		}
 		for _, schema := range proposal.Mutations.Schema {
 			if tablet := groups().Tablet(schema.Predicate); tablet != nil && tablet.ReadOnly {
-				return errPredicateMoving
+				return errTabletMoving(tablet)
 			}
 			if err := checkSchema(schema); err != nil {
 				return err
@@ -172,12 +243,10 @@ func (n *node) proposeAndWait(ctx context.Context, proposal *pb.Proposal) error
 			tr.LazyPrintf("Proposing data with key: %s. Timeout: %v", key, timeout)
 		}
 
-		data, err := proposal.Marshal()
+		// Lets the other nodes trace applying the proposal as part of the same trace.
+		proposal.TraceContext = propagation.Binary(span.SpanContext())
+		err := n.propose(cctx, proposal)
 		if err != nil {
-			return err
-		}
-
-		if err = n.Raft().Propose(cctx, data); err != nil {
 			return x.Wrapf(err, "While proposing")
 		}
 		if tr, ok := trace.FromContext(ctx); ok {
@@ -0,0 +1,135 @@
+/*
+ * Copyright 2018 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package worker
+
+import (
+	"golang.org/x/net/context"
+
+	"github.com/dgraph-io/dgraph/protos/pb"
+)
+
+// Small mutations proposed while others are being proposed are batched together into a single
+// Raft proposal, up to --mutation_batch of them. Every mutation of a batch keeps its own key,
+// and is applied and reported done on its own, so that batching is invisible to the proposers.
+// Batches only form under load, so batching adds no latency when there's none.
+const (
+	// maxBatchedSize is the size of the largest proposal batched with others.
+	maxBatchedSize = 16 << 10
+	// maxBatchSize bounds the size of a batch.
+	maxBatchSize = 1 << 20
+)
+
+// batchedProposal is a proposal waiting to be proposed in a batch.
+type batchedProposal struct {
+	ctx      context.Context
+	proposal *pb.Proposal
+	errCh    chan error
+}
+
+// batchable tells whether proposal can be batched with others: only the small mutations without
+// schema updates or drops are.
+func batchable(proposal *pb.Proposal) bool {
+	m := proposal.Mutations
+	return m != nil && len(m.Schema) == 0 && !m.DropAll && len(m.DropNamespace) == 0 &&
+		len(m.Tombstone) == 0 && proposal.Size() <= maxBatchedSize
+}
+
+// batched returns the proposals in proposal, which is either a batch or a single proposal.
+func batched(proposal *pb.Proposal) []*pb.Proposal {
+	if len(proposal.Batch) > 0 {
+		return proposal.Batch
+	}
+	return []*pb.Proposal{proposal}
+}
+
+// propose proposes proposal to Raft, in a batch if it's batchable.
+func (n *node) propose(ctx context.Context, proposal *pb.Proposal) error {
+	if n.batchCh == nil || !batchable(proposal) {
+		data, err := proposal.Marshal()
+		if err != nil {
+			return err
+		}
+		return n.Raft().Propose(ctx, data)
+	}
+	// The proposer can change its proposal to retry it, once it gives up on this try.
+	p := *proposal
+	bp := &batchedProposal{ctx: ctx, proposal: &p, errCh: make(chan error, 1)}
+	select {
+	case n.batchCh <- bp:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	select {
+	case err := <-bp.errCh:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// batchProposals proposes the proposals sent to batchCh, batching those sent while others are
+// proposed.
+func (n *node) batchProposals() {
+	for bp := range n.batchCh {
+		batch := []*batchedProposal{bp}
+		size := bp.proposal.Size()
+	gather:
+		for len(batch) < Config.MutationBatch && size < maxBatchSize {
+			select {
+			case bp := <-n.batchCh:
+				batch = append(batch, bp)
+				size += bp.proposal.Size()
+			default:
+				break gather
+			}
+		}
+		n.proposeBatch(batch)
+	}
+}
+
+func (n *node) proposeBatch(batch []*batchedProposal) {
+	// The proposers which gave up already are left out.
+	var live []*batchedProposal
+	for _, bp := range batch {
+		if err := bp.ctx.Err(); err != nil {
+			bp.errCh <- err
+			continue
+		}
+		live = append(live, bp)
+	}
+	if len(live) == 0 {
+		return
+	}
+
+	proposal, ctx := live[0].proposal, live[0].ctx
+	if len(live) > 1 {
+		proposal = &pb.Proposal{}
+		for _, bp := range live {
+			proposal.Batch = append(proposal.Batch, bp.proposal)
+		}
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(n.ctx, baseTimeout)
+		defer cancel()
+	}
+	data, err := proposal.Marshal()
+	if err == nil {
+		err = n.Raft().Propose(ctx, data)
+	}
+	for _, bp := range live {
+		bp.errCh <- err
+	}
+}
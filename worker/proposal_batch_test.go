@@ -0,0 +1,93 @@
+/*
+ * Copyright 2018 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package worker
+
+import (
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/context"
+
+	"github.com/dgraph-io/dgraph/protos/pb"
+)
+
+func TestBatchable(t *testing.T) {
+	edge := &pb.DirectedEdge{Attr: "name", Value: []byte("Alice")}
+	require.True(t, batchable(&pb.Proposal{Mutations: &pb.Mutations{Edges: []*pb.DirectedEdge{edge}}}))
+	require.False(t, batchable(&pb.Proposal{Kv: []*pb.KV{{Key: []byte("k")}}}))
+	require.False(t, batchable(&pb.Proposal{Mutations: &pb.Mutations{DropAll: true}}))
+	require.False(t, batchable(&pb.Proposal{Mutations: &pb.Mutations{
+		Schema: []*pb.SchemaUpdate{{Predicate: "name"}}}}))
+
+	large := &pb.DirectedEdge{Attr: "name", Value: []byte(strings.Repeat("a", maxBatchedSize))}
+	require.False(t, batchable(&pb.Proposal{Mutations: &pb.Mutations{
+		Edges: []*pb.DirectedEdge{large}}}))
+}
+
+func TestBatchedProposals(t *testing.T) {
+	single := &pb.Proposal{Key: "a", Mutations: &pb.Mutations{StartTs: 1}}
+	require.Equal(t, []*pb.Proposal{single}, batched(single))
+
+	batch := &pb.Proposal{Batch: []*pb.Proposal{single, {Key: "b", Mutations: &pb.Mutations{}}}}
+	data, err := batch.Marshal()
+	require.NoError(t, err)
+	var got pb.Proposal
+	require.NoError(t, got.Unmarshal(data))
+	require.Len(t, batched(&got), 2)
+	require.Equal(t, "a", batched(&got)[0].Key)
+	require.Equal(t, uint64(1), batched(&got)[0].Mutations.StartTs)
+	require.Equal(t, "b", batched(&got)[1].Key)
+}
+
+func TestRateLimiterQueue(t *testing.T) {
+	defer func(ch chan struct{}, c Options) {
+		pendingProposals, Config = ch, c
+	}(pendingProposals, Config)
+	pendingProposals = make(chan struct{}, 1)
+	Config.MutationQueue = 1
+	Config.MutationQueueTimeout = 100 * time.Millisecond
+
+	var rl rateLimiter
+	ctx := context.Background()
+	require.NoError(t, rl.incr(ctx, 0))
+
+	// The first proposal over the pending ones waits, and the next one is turned away at once.
+	errCh := make(chan error, 1)
+	go func() { errCh <- rl.incr(ctx, 0) }()
+	for atomic.LoadInt32(&rl.waiting) == 0 {
+		time.Sleep(time.Millisecond)
+	}
+	require.Equal(t, errOverloaded, rl.incr(ctx, 0))
+
+	// The waiting one gets room once there's some.
+	<-pendingProposals
+	require.NoError(t, <-errCh)
+
+	// Or is turned away after the timeout.
+	start := time.Now()
+	require.Equal(t, errOverloaded, rl.incr(ctx, 0))
+	require.True(t, time.Since(start) >= Config.MutationQueueTimeout)
+
+	// Retries weigh more, and give back what they took if turned away.
+	<-pendingProposals
+	pendingProposals = make(chan struct{}, 2)
+	require.Equal(t, errOverloaded, rl.incr(ctx, 2))
+	require.Len(t, pendingProposals, 0)
+}
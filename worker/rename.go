@@ -0,0 +1,191 @@
+/*
+ * Copyright 2018 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package worker
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dgraph-io/dgo/protos/api"
+	"github.com/dgraph-io/dgraph/posting"
+	"github.com/dgraph-io/dgraph/protos/pb"
+	"github.com/dgraph-io/dgraph/schema"
+	"github.com/dgraph-io/dgraph/x"
+	"github.com/golang/glog"
+	"golang.org/x/net/context"
+)
+
+// Dropping a predicate doesn't delete it right away, but renames it to a tombstone, named after
+// the time of the drop and the original name. Tombstones are hidden from schema queries and
+// exports, and can be renamed back via Zero to undo the drop. Once Config.DropGrace has passed,
+// the leader of the group deletes them for good.
+const (
+	tombstonePrefix = "dgraph.dropped."
+	purgeInterval   = time.Minute
+)
+
+// TombstoneName returns the name a predicate dropped at the given time is renamed to.
+func TombstoneName(attr string, droppedAt time.Time) string {
+	return tombstonePrefix + strconv.FormatInt(droppedAt.Unix(), 10) + "." + attr
+}
+
+// IsTombstone tells whether attr is the tombstone of a dropped predicate.
+func IsTombstone(attr string) bool {
+	_, _, ok := parseTombstone(attr)
+	return ok
+}
+
+// parseTombstone returns the original name of the dropped predicate and the time of the drop.
+func parseTombstone(attr string) (string, time.Time, bool) {
+	_, attr = x.ParseNamespaceAttr(attr)
+	if !strings.HasPrefix(attr, tombstonePrefix) {
+		return "", time.Time{}, false
+	}
+	rest := attr[len(tombstonePrefix):]
+	idx := strings.IndexByte(rest, '.')
+	if idx <= 0 || idx == len(rest)-1 {
+		return "", time.Time{}, false
+	}
+	secs, err := strconv.ParseInt(rest[:idx], 10, 64)
+	if err != nil {
+		return "", time.Time{}, false
+	}
+	return rest[idx+1:], time.Unix(secs, 0), true
+}
+
+// abortPendingTxns aborts the pending transactions touching any of the given predicates.
+func abortPendingTxns(attrs ...string) error {
+	for i := 0; i < 12; i++ {
+		// Try a dozen times, then give up.
+		glog.Infof("Trying to abort pending mutations. Loop: %d", i)
+		tctxs := posting.Oracle().IterateTxns(func(key []byte) bool {
+			pk := x.Parse(key)
+			return x.HasString(attrs, pk.Attr)
+		})
+		if len(tctxs) == 0 {
+			return nil
+		}
+		tryAbortTransactions(tctxs)
+	}
+	return errUnableToAbort
+}
+
+// RenamePredicate is called by Zero, once it has made the tablets of both names read-only, to
+// rename the predicate in this group.
+func (w *grpcWorker) RenamePredicate(ctx context.Context,
+	in *pb.RenamePredicatePayload) (*api.Payload, error) {
+	if groups().gid != in.GroupId {
+		return &emptyPayload,
+			x.Errorf("Group id doesn't match, received request for %d, my gid: %d",
+				in.GroupId, groups().gid)
+	}
+	if len(in.From) == 0 || len(in.To) == 0 {
+		return &emptyPayload, errEmptyPredicate
+	}
+	if !groups().ServesTablet(in.From) {
+		return &emptyPayload, errUnservedTablet
+	}
+	n := groups().Node
+	if !n.AmLeader() {
+		return &emptyPayload, errNotLeader
+	}
+	// The data is renamed as it is stored, which the indexes must be done with.
+	if err := indexBuilds.wait(ctx, in.From); err != nil {
+		return &emptyPayload, err
+	}
+
+	glog.Infof("Rename predicate request for pred: [%v] to [%v]\n", in.From, in.To)
+
+	// Ensures that all future mutations beyond this point are rejected.
+	if err := n.proposeAndWait(ctx, &pb.Proposal{State: in.State}); err != nil {
+		return &emptyPayload, err
+	}
+	if err := abortPendingTxns(in.From, in.To); err != nil {
+		return &emptyPayload, err
+	}
+	// We iterate over badger, so need to flush and wait for sync watermark to catch up.
+	n.applyAllMarks(ctx)
+
+	rename := &pb.RenamePredicatePayload{From: in.From, To: in.To, GroupId: in.GroupId}
+	return &emptyPayload, n.proposeAndWait(ctx, &pb.Proposal{Rename: rename})
+}
+
+// applyRename renames the predicate on this node.
+func (n *node) applyRename(ctx context.Context, rename *pb.RenamePredicatePayload) error {
+	for _, attr := range []string{rename.From, rename.To} {
+		if err := detectPendingTxns(attr); err != nil {
+			return err
+		}
+	}
+	indexBuilds.abort(rename.From)
+	defer invalidations.changed(rename.From, rename.To)
+	return posting.RenamePredicate(ctx, rename.From, rename.To)
+}
+
+// purgeTombstones deletes the tombstones whose grace period has passed, while this node is the
+// leader of the group.
+func (n *node) purgeTombstones() {
+	defer n.closer.Done() // CLOSER:1
+
+	ticker := time.NewTicker(purgeInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-n.closer.HasBeenClosed():
+			return
+		case <-ticker.C:
+			if !n.AmLeader() {
+				break // Break out of the select case.
+			}
+			for _, attr := range schema.State().Predicates() {
+				_, droppedAt, ok := parseTombstone(attr)
+				if !ok {
+					continue
+				}
+				// This also registers the tombstone with Zero, should that have failed on drop.
+				if !groups().ServesTablet(attr) {
+					continue
+				}
+				if time.Since(droppedAt) < Config.DropGrace {
+					continue
+				}
+				if err := n.purgeTombstone(attr); err != nil {
+					glog.Errorf("While purging dropped predicate %s: %v", attr, err)
+				}
+			}
+		}
+	}
+}
+
+func (n *node) purgeTombstone(attr string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	glog.Infof("Purging dropped predicate: %s", attr)
+	edge := &pb.DirectedEdge{
+		Attr:  attr,
+		Value: []byte(x.Star),
+		Op:    pb.DirectedEdge_DEL,
+	}
+	m := &pb.Mutations{
+		GroupId: n.gid,
+		StartTs: x.Max(posting.Oracle().MaxAssigned(), 1),
+		Edges:   []*pb.DirectedEdge{edge},
+	}
+	return n.proposeAndWait(ctx, &pb.Proposal{Mutations: m})
+}
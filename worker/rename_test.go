@@ -0,0 +1,41 @@
+/*
+ * Copyright 2018 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package worker
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTombstoneName(t *testing.T) {
+	at := time.Unix(1540000000, 0)
+	name := TombstoneName("friend.of", at)
+	require.Equal(t, "dgraph.dropped.1540000000.friend.of", name)
+
+	attr, droppedAt, ok := parseTombstone(name)
+	require.True(t, ok)
+	require.Equal(t, "friend.of", attr)
+	require.True(t, at.Equal(droppedAt))
+	require.True(t, IsTombstone(name))
+
+	for _, attr := range []string{"friend", "dgraph.dropped.", "dgraph.dropped.123",
+		"dgraph.dropped.123.", "dgraph.dropped..friend", "dgraph.dropped.abc.friend"} {
+		require.False(t, IsTombstone(attr), attr)
+	}
+}
@@ -0,0 +1,59 @@
+/*
+ * Copyright 2018 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package worker
+
+import (
+	"golang.org/x/net/context"
+
+	"github.com/dgraph-io/dgo/protos/api"
+	"github.com/dgraph-io/dgraph/protos/pb"
+	"github.com/dgraph-io/dgraph/x"
+)
+
+// A secondary cluster replicates a primary one asynchronously. The leader of the Zero of the
+// secondary streams the keys the groups of the primary changed since the last round, as of a
+// single read timestamp, and sends them to the leaders of the groups of the secondary serving
+// their predicates. These write them at the versions they were committed at in the primary, so
+// the secondary ends up with the same data at the same timestamps. Only the primary is written
+// to, so replicating can't conflict with anything.
+
+// CheckWritable returns an error if the cluster is a replica, and so can't be written to.
+func CheckWritable() error {
+	if src := ReplicaOf(); len(src) > 0 {
+		return x.Errorf("Cluster is a read-only replica of %s", src)
+	}
+	return nil
+}
+
+// ApplyReplicated is called by the Zero of the secondary cluster, with keys replicated from the
+// primary cluster, for the predicates served by this group.
+func (w *grpcWorker) ApplyReplicated(ctx context.Context, kvs *pb.KVS) (*api.Payload, error) {
+	if ctx.Err() != nil {
+		return &emptyPayload, ctx.Err()
+	}
+	if len(ReplicaOf()) == 0 {
+		return &emptyPayload, x.Errorf("Cluster isn't replicating another one")
+	}
+	n := groups().Node
+	if !n.AmLeader() {
+		return &emptyPayload, errNotLeader
+	}
+	if len(kvs.Kv) == 0 {
+		return &emptyPayload, nil
+	}
+	return &emptyPayload, n.proposeAndWait(ctx, &pb.Proposal{Kv: kvs.Kv})
+}
@@ -0,0 +1,107 @@
+/*
+ * Copyright 2016-2018 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package worker
+
+import (
+	"context"
+	"io/ioutil"
+	"math"
+	"os"
+	"sync/atomic"
+	"testing"
+
+	"github.com/dgraph-io/badger"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dgraph-io/dgraph/posting"
+	"github.com/dgraph-io/dgraph/protos/pb"
+	"github.com/dgraph-io/dgraph/schema"
+	"github.com/dgraph-io/dgraph/x"
+)
+
+type kvCollector struct {
+	kvs []*pb.KV
+}
+
+func (c *kvCollector) Send(kvs *pb.KVS) error {
+	c.kvs = append(c.kvs, kvs.Kv...)
+	return nil
+}
+
+// replicate streams the keys changed since sinceTs, as the rounds of the replication do, and
+// writes them to the replica at their versions.
+func replicate(t *testing.T, replica *badger.DB, sinceTs uint64) {
+	var numKeys uint64
+	sl := snapshotLists(&pb.Snapshot{SinceTs: sinceTs}, &numKeys)
+	c := &kvCollector{}
+	sl.Stream = c
+	require.NoError(t, sl.Orchestrate(context.Background(), "Testing", atomic.LoadUint64(&ts)))
+	for _, kv := range c.kvs {
+		txn := replica.NewTransactionAt(math.MaxUint64, true)
+		require.NoError(t, txn.SetWithMeta(kv.Key, kv.Val, kv.UserMeta[0]))
+		require.NoError(t, txn.CommitAt(kv.Version, nil))
+	}
+}
+
+func replicaUids(t *testing.T, replica *badger.DB, key []byte) []uint64 {
+	txn := replica.NewTransactionAt(math.MaxUint64, false)
+	defer txn.Discard()
+	opt := badger.DefaultIteratorOptions
+	opt.AllVersions = true
+	itr := txn.NewIterator(opt)
+	defer itr.Close()
+	itr.Seek(key)
+	l, err := posting.ReadPostingList(key, itr)
+	require.NoError(t, err)
+	uids, err := l.Uids(posting.ListOptions{ReadTs: math.MaxUint64})
+	require.NoError(t, err)
+	return uids.Uids
+}
+
+func TestReplicateDeletes(t *testing.T) {
+	require.NoError(t, schema.ParseBytes([]byte("repl.friend: uid .\nrepl.owner: uid ."), 1))
+	dir, err := ioutil.TempDir("", "replica_")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	opt := badger.DefaultOptions
+	opt.Dir = dir
+	opt.ValueDir = dir
+	replica, err := badger.OpenManaged(opt)
+	require.NoError(t, err)
+	defer replica.Close()
+
+	friend := func(from, to uint64) *pb.DirectedEdge {
+		return &pb.DirectedEdge{Attr: "repl.friend", Entity: from, ValueId: to}
+	}
+	addEdge(t, friend(1, 2), getOrCreate(x.DataKey("repl.friend", 1)))
+	addEdge(t, friend(1, 3), getOrCreate(x.DataKey("repl.friend", 1)))
+	addEdge(t, friend(4, 5), getOrCreate(x.DataKey("repl.friend", 4)))
+	owner := &pb.DirectedEdge{Attr: "repl.owner", Entity: 6, ValueId: 7}
+	addEdge(t, owner, getOrCreate(x.DataKey("repl.owner", 6)))
+	replicate(t, replica, 0)
+	require.Equal(t, []uint64{2, 3}, replicaUids(t, replica, x.DataKey("repl.friend", 1)))
+	require.Equal(t, []uint64{7}, replicaUids(t, replica, x.DataKey("repl.owner", 6)))
+
+	sinceTs := atomic.LoadUint64(&ts)
+	delEdge(t, friend(1, 2), getOrCreate(x.DataKey("repl.friend", 1)))
+	// Dropping deletes the keys at the versions they had.
+	require.NoError(t, posting.DeletePredicate(context.Background(), "repl.owner"))
+	replicate(t, replica, sinceTs)
+	require.Equal(t, []uint64{3}, replicaUids(t, replica, x.DataKey("repl.friend", 1)))
+	require.Empty(t, replicaUids(t, replica, x.DataKey("repl.owner", 6)))
+	require.Equal(t, []uint64{5}, replicaUids(t, replica, x.DataKey("repl.friend", 4)))
+}
@@ -0,0 +1,328 @@
+/*
+ * Copyright 2018 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package worker
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang/glog"
+
+	"github.com/dgraph-io/dgraph/posting"
+	"github.com/dgraph-io/dgraph/x"
+)
+
+// The rollups of the posting lists, and the value log GC which follows them, rewrite much of the
+// data. They can be confined to daily windows of low load, paused and triggered. The predicates of
+// high priority are rolled up first, and at every rollup even outside of the windows, those of low
+// priority last.
+
+// timeWindow is a daily window of time, from start to end minutes after midnight in local time. It
+// wraps around midnight if end is before start.
+type timeWindow struct {
+	start, end int
+}
+
+func (w timeWindow) String() string {
+	return fmt.Sprintf("%02d:%02d-%02d:%02d", w.start/60, w.start%60, w.end/60, w.end%60)
+}
+
+func (w timeWindow) contains(t time.Time) bool {
+	min := t.Hour()*60 + t.Minute()
+	if w.start <= w.end {
+		return min >= w.start && min < w.end
+	}
+	return min >= w.start || min < w.end
+}
+
+func parseClock(s string) (int, error) {
+	t, err := time.Parse("15:04", strings.TrimSpace(s))
+	if err != nil {
+		return 0, x.Errorf("Invalid time of day %q, must be like 22:30", s)
+	}
+	return t.Hour()*60 + t.Minute(), nil
+}
+
+// parseWindows parses comma separated windows, like 01:00-05:00,22:00-23:30.
+func parseWindows(s string) ([]timeWindow, error) {
+	var windows []timeWindow
+	for _, entry := range strings.Split(s, ",") {
+		if len(strings.TrimSpace(entry)) == 0 {
+			continue
+		}
+		parts := strings.Split(entry, "-")
+		if len(parts) != 2 {
+			return nil, x.Errorf("Invalid window %q, must be like 22:00-06:00", entry)
+		}
+		var w timeWindow
+		var err error
+		if w.start, err = parseClock(parts[0]); err != nil {
+			return nil, err
+		}
+		if w.end, err = parseClock(parts[1]); err != nil {
+			return nil, err
+		}
+		if w.start == w.end {
+			return nil, x.Errorf("Window %q is empty", entry)
+		}
+		windows = append(windows, w)
+	}
+	return windows, nil
+}
+
+// Priorities of the predicates in rollups.
+const (
+	lowPriority = iota - 1
+	normalPriority
+	highPriority
+)
+
+// parsePriorities parses comma separated priorities of predicates, like name:high,logs:low.
+func parsePriorities(s string) (map[string]int, error) {
+	prios := make(map[string]int)
+	for _, entry := range strings.Split(s, ",") {
+		entry = strings.TrimSpace(entry)
+		if len(entry) == 0 {
+			continue
+		}
+		idx := strings.LastIndexByte(entry, ':')
+		if idx <= 0 {
+			return nil, x.Errorf("Invalid priority %q, must be like name:high", entry)
+		}
+		switch attr, prio := entry[:idx], entry[idx+1:]; prio {
+		case "high":
+			prios[attr] = highPriority
+		case "low":
+			prios[attr] = lowPriority
+		default:
+			return nil, x.Errorf("Invalid priority %q of predicate %s, must be high or low",
+				prio, attr)
+		}
+	}
+	return prios, nil
+}
+
+// RollupStatus is the status of the rollups of this Alpha.
+type RollupStatus struct {
+	Paused bool `json:"paused"`
+	// Windows are the daily windows rollups run in, all day if none.
+	Windows  []string `json:"windows,omitempty"`
+	InWindow bool     `json:"in_window"`
+	High     []string `json:"high_priority,omitempty"`
+	Low      []string `json:"low_priority,omitempty"`
+	Running  bool     `json:"running"`
+	// LastTs is the read timestamp of the last full rollup, done at LastAt in LastDuration.
+	LastTs       uint64 `json:"last_ts,omitempty"`
+	LastAt       string `json:"last_at,omitempty"`
+	LastDuration string `json:"last_duration,omitempty"`
+	LastError    string `json:"last_error,omitempty"`
+	// HistoryTs is the oldest timestamp the data can be queried as of.
+	HistoryTs uint64 `json:"history_ts"`
+}
+
+// rollupSchedule tells when to roll up the posting lists.
+type rollupSchedule struct {
+	sync.Mutex
+	windows []timeWindow
+	prios   map[string]int
+	paused  bool
+	runCh   chan struct{} // Rollups triggered via TriggerRollup.
+
+	running  bool
+	lastTs   uint64
+	lastAt   time.Time
+	lastTook time.Duration
+	lastErr  error
+}
+
+var rollups = &rollupSchedule{runCh: make(chan struct{}, 1)}
+
+// SetRollupSchedule confines the rollups to the given comma separated daily windows, like
+// 22:00-06:00, all day if empty, and sets the priority of predicates, like name:high,logs:low.
+func SetRollupSchedule(windows, priorities string) error {
+	ws, err := parseWindows(windows)
+	if err != nil {
+		return err
+	}
+	prios, err := parsePriorities(priorities)
+	if err != nil {
+		return err
+	}
+	rollups.Lock()
+	defer rollups.Unlock()
+	rollups.windows, rollups.prios = ws, prios
+	return nil
+}
+
+// inWindowLocked tells whether t is in the windows of the schedule.
+func (s *rollupSchedule) inWindowLocked(t time.Time) bool {
+	if len(s.windows) == 0 {
+		return true
+	}
+	for _, w := range s.windows {
+		if w.contains(t) {
+			return true
+		}
+	}
+	return false
+}
+
+// plan tells whether to roll up all the posting lists at t, or only those of high priority.
+// Triggered rollups are full ones, even outside of the windows or when paused.
+func (s *rollupSchedule) plan(t time.Time, triggered bool) (full, high bool) {
+	s.Lock()
+	defer s.Unlock()
+	switch {
+	case triggered:
+		return true, false
+	case s.paused:
+		return false, false
+	case s.inWindowLocked(t):
+		return true, false
+	}
+	for _, prio := range s.prios {
+		if prio == highPriority {
+			return false, true
+		}
+	}
+	return false, false
+}
+
+// order returns the predicates to roll up on their own, in order, around all the others. The
+// others go where the empty predicate is. With onlyHigh, only those of high priority are returned.
+func (s *rollupSchedule) order(onlyHigh bool) []string {
+	s.Lock()
+	defer s.Unlock()
+	var high, low []string
+	for attr, prio := range s.prios {
+		if prio == highPriority {
+			high = append(high, attr)
+		} else {
+			low = append(low, attr)
+		}
+	}
+	sort.Strings(high)
+	sort.Strings(low)
+	if onlyHigh {
+		return high
+	}
+	return append(append(high, ""), low...)
+}
+
+func (s *rollupSchedule) start() {
+	s.Lock()
+	defer s.Unlock()
+	s.running = true
+}
+
+// done records the end of a rollup at readTs, which is full unless only the predicates of high
+// priority were rolled up.
+func (s *rollupSchedule) done(readTs uint64, full bool, start time.Time, err error) {
+	s.Lock()
+	defer s.Unlock()
+	s.running = false
+	if !full {
+		return
+	}
+	s.lastErr = err
+	if err == nil {
+		s.lastTs, s.lastAt, s.lastTook = readTs, start, time.Since(start)
+	}
+}
+
+// CompactionAllowed tells whether the rollups and value log GC can run now: they aren't paused,
+// and it's within the rollup windows.
+func CompactionAllowed() bool {
+	rollups.Lock()
+	defer rollups.Unlock()
+	return !rollups.paused && rollups.inWindowLocked(time.Now())
+}
+
+// PauseRollups pauses or resumes the rollups and value log GC of this Alpha. A rollup running
+// already isn't stopped.
+func PauseRollups(pause bool) {
+	rollups.Lock()
+	defer rollups.Unlock()
+	rollups.paused = pause
+	glog.Infof("Rollups paused: %v", pause)
+}
+
+// TriggerRollup makes this Alpha roll up its posting lists as soon as possible, even outside of
+// the rollup windows or when paused.
+func TriggerRollup() {
+	select {
+	case rollups.runCh <- struct{}{}:
+	default: // One is triggered already.
+	}
+}
+
+// Rollups returns the status of the rollups of this Alpha.
+func Rollups() RollupStatus {
+	rollups.Lock()
+	defer rollups.Unlock()
+	st := RollupStatus{
+		Paused:   rollups.paused,
+		InWindow: rollups.inWindowLocked(time.Now()),
+		Running:  rollups.running,
+		LastTs:   rollups.lastTs,
+
+		HistoryTs: posting.HistoryTs(),
+	}
+	for _, w := range rollups.windows {
+		st.Windows = append(st.Windows, w.String())
+	}
+	for attr, prio := range rollups.prios {
+		if prio == highPriority {
+			st.High = append(st.High, attr)
+		} else {
+			st.Low = append(st.Low, attr)
+		}
+	}
+	sort.Strings(st.High)
+	sort.Strings(st.Low)
+	if rollups.lastTs > 0 {
+		st.LastAt = rollups.lastAt.Format(time.RFC3339)
+		st.LastDuration = rollups.lastTook.Round(time.Millisecond).String()
+	}
+	if rollups.lastErr != nil {
+		st.LastError = rollups.lastErr.Error()
+	}
+	return st
+}
+
+// scheduleStrings returns the windows and priorities of the schedule, as SetRollupSchedule takes
+// them.
+func (s *rollupSchedule) scheduleStrings() (windows, priorities string) {
+	s.Lock()
+	defer s.Unlock()
+	var ws, ps []string
+	for _, w := range s.windows {
+		ws = append(ws, w.String())
+	}
+	for attr, prio := range s.prios {
+		if prio == highPriority {
+			ps = append(ps, attr+":high")
+		} else {
+			ps = append(ps, attr+":low")
+		}
+	}
+	sort.Strings(ps)
+	return strings.Join(ws, ","), strings.Join(ps, ",")
+}
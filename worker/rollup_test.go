@@ -0,0 +1,98 @@
+/*
+ * Copyright 2018 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package worker
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRollupWindows(t *testing.T) {
+	ws, err := parseWindows("01:00-05:00, 22:30-02:00")
+	require.NoError(t, err)
+	require.Equal(t, []timeWindow{{60, 300}, {1350, 120}}, ws)
+	require.Equal(t, "22:30-02:00", ws[1].String())
+
+	at := func(clock string) time.Time {
+		ts, err := time.Parse("15:04", clock)
+		require.NoError(t, err)
+		return ts
+	}
+	require.True(t, ws[0].contains(at("01:00")))
+	require.True(t, ws[0].contains(at("04:59")))
+	require.False(t, ws[0].contains(at("05:00")))
+	// Wrapping around midnight.
+	require.True(t, ws[1].contains(at("23:00")))
+	require.True(t, ws[1].contains(at("00:10")))
+	require.False(t, ws[1].contains(at("12:00")))
+
+	for _, s := range []string{"01:00", "1am-5am", "25:00-01:00", "03:00-03:00"} {
+		_, err := parseWindows(s)
+		require.Error(t, err, s)
+	}
+	ws, err = parseWindows("")
+	require.NoError(t, err)
+	require.Empty(t, ws)
+}
+
+func TestRollupPriorities(t *testing.T) {
+	prios, err := parsePriorities("name:high, logs:low,friend:high")
+	require.NoError(t, err)
+	require.Equal(t, map[string]int{"name": highPriority, "logs": lowPriority,
+		"friend": highPriority}, prios)
+	for _, s := range []string{"name", ":high", "name:urgent"} {
+		_, err := parsePriorities(s)
+		require.Error(t, err, s)
+	}
+
+	s := &rollupSchedule{prios: prios}
+	require.Equal(t, []string{"friend", "name", "", "logs"}, s.order(false))
+	require.Equal(t, []string{"friend", "name"}, s.order(true))
+	require.Equal(t, []string{""}, (&rollupSchedule{}).order(false))
+
+	windows, err := parseWindows("22:00-06:00")
+	require.NoError(t, err)
+	s.windows = windows
+	w, p := s.scheduleStrings()
+	require.Equal(t, "22:00-06:00", w)
+	require.Equal(t, "friend:high,logs:low,name:high", p)
+}
+
+func TestRollupPlan(t *testing.T) {
+	noon, err := time.Parse("15:04", "12:00")
+	require.NoError(t, err)
+	s := &rollupSchedule{}
+
+	plan := func(triggered bool) []bool {
+		full, high := s.plan(noon, triggered)
+		return []bool{full, high}
+	}
+	require.Equal(t, []bool{true, false}, plan(false))
+
+	// Outside of the windows, only the predicates of high priority are rolled up, if any.
+	s.windows = []timeWindow{{60, 300}}
+	require.Equal(t, []bool{false, false}, plan(false))
+	s.prios = map[string]int{"name": highPriority}
+	require.Equal(t, []bool{false, true}, plan(false))
+
+	// Triggered rollups run anyway.
+	s.paused = true
+	require.Equal(t, []bool{false, false}, plan(false))
+	require.Equal(t, []bool{true, false}, plan(true))
+}
@@ -61,6 +61,13 @@ func getSchema(ctx context.Context, s *pb.SchemaRequest) (*pb.SchemaResult, erro
 	}
 
 	for _, attr := range predicates {
+		// Dropped predicates are only listed if asked for by name.
+		if len(s.Predicates) == 0 && IsTombstone(attr) {
+			continue
+		}
+		if ns, _ := x.ParseNamespaceAttr(attr); len(s.Predicates) == 0 && ns != s.Namespace {
+			continue
+		}
 		// This can happen after a predicate is moved. We don't delete predicate from schema state
 		// immediately. So lets ignore this predicate.
 		if !groups().ServesTablet(attr) {
@@ -88,15 +95,15 @@ func populateSchema(attr string, fields []string) *api.SchemaNode {
 		case "type":
 			schemaNode.Type = typ.Name()
 		case "index":
-			schemaNode.Index = schema.State().IsIndexed(attr)
+			schemaNode.Index = schema.Served().IsIndexed(attr)
 		case "tokenizer":
-			if schema.State().IsIndexed(attr) {
-				schemaNode.Tokenizer = schema.State().TokenizerNames(attr)
+			if schema.Served().IsIndexed(attr) {
+				schemaNode.Tokenizer = schema.Served().TokenizerNames(attr)
 			}
 		case "reverse":
-			schemaNode.Reverse = schema.State().IsReversed(attr)
+			schemaNode.Reverse = schema.Served().IsReversed(attr)
 		case "count":
-			schemaNode.Count = schema.State().HasCount(attr)
+			schemaNode.Count = schema.Served().HasCount(attr)
 		case "list":
 			schemaNode.List = schema.State().IsList(attr)
 		case "upsert":
@@ -117,7 +124,7 @@ func addToSchemaMap(schemaMap map[uint32]*pb.SchemaRequest, schema *pb.SchemaReq
 		gid := groups().BelongsTo(attr)
 		s := schemaMap[gid]
 		if s == nil {
-			s = &pb.SchemaRequest{GroupId: gid}
+			s = &pb.SchemaRequest{GroupId: gid, Namespace: schema.Namespace}
 			s.Fields = schema.Fields
 			schemaMap[gid] = s
 		}
@@ -135,7 +142,7 @@ func addToSchemaMap(schemaMap map[uint32]*pb.SchemaRequest, schema *pb.SchemaReq
 		}
 		s := schemaMap[gid]
 		if s == nil {
-			s = &pb.SchemaRequest{GroupId: gid}
+			s = &pb.SchemaRequest{GroupId: gid, Namespace: schema.Namespace}
 			s.Fields = schema.Fields
 			schemaMap[gid] = s
 		}
@@ -176,9 +183,19 @@ func GetSchemaOverNetwork(ctx context.Context, schema *pb.SchemaRequest) ([]*api
 		return nil, err
 	}
 
+	ns := x.NamespaceFromContext(ctx)
+	nschema := &pb.SchemaRequest{Fields: schema.Fields, Namespace: ns}
+	for _, attr := range schema.Predicates {
+		nattr, err := namespaceAttr(ctx, attr)
+		if err != nil {
+			return nil, err
+		}
+		nschema.Predicates = append(nschema.Predicates, nattr)
+	}
+
 	// Map of groupd id => Predicates for that group.
 	schemaMap := make(map[uint32]*pb.SchemaRequest)
-	addToSchemaMap(schemaMap, schema)
+	addToSchemaMap(schemaMap, nschema)
 
 	results := make(chan resultErr, len(schemaMap))
 	var schemaNodes []*api.SchemaNode
@@ -198,7 +215,10 @@ func GetSchemaOverNetwork(ctx context.Context, schema *pb.SchemaRequest) ([]*api
 			if r.err != nil {
 				return nil, r.err
 			}
-			schemaNodes = append(schemaNodes, r.result.Schema...)
+			for _, node := range r.result.Schema {
+				_, node.Predicate = x.ParseNamespaceAttr(node.Predicate)
+				schemaNodes = append(schemaNodes, node)
+			}
 		case <-ctx.Done():
 			return nil, ctx.Err()
 		}
@@ -45,17 +45,30 @@ type sortresult struct {
 
 // SortOverNetwork sends sort query over the network.
 func SortOverNetwork(ctx context.Context, q *pb.SortMessage) (*pb.SortResult, error) {
+	nq := *q
+	nq.Order = make([]*pb.Order, 0, len(q.Order))
+	for _, o := range q.Order {
+		attr, err := namespaceAttr(ctx, o.Attr)
+		if err != nil {
+			return &emptySortResult, err
+		}
+		no := *o
+		no.Attr = attr
+		nq.Order = append(nq.Order, &no)
+	}
+	q = &nq
+
 	gid := groups().BelongsTo(q.Order[0].Attr)
 	if tr, ok := trace.FromContext(ctx); ok {
 		tr.LazyPrintf("worker.Sort attr: %v groupId: %v", q.Order[0].Attr, gid)
 	}
 
-	if groups().ServesGroup(gid) {
+	if groups().ServesGroup(gid) || groups().HoldsTabletCopy(q.Order[0].Attr) {
 		// No need for a network call, as this should be run from within this instance.
 		return processSort(ctx, q)
 	}
 
-	result, err := processWithBackupRequest(ctx, gid, func(ctx context.Context, c pb.WorkerClient) (interface{}, error) {
+	result, err := processWithRetry(ctx, gid, func(ctx context.Context, c pb.WorkerClient) (interface{}, error) {
 		return c.Sort(ctx, q)
 	})
 	if err != nil {
@@ -113,6 +126,17 @@ func sortWithoutIndex(ctx context.Context, ts *pb.SortMessage) *sortresult {
 		return &sortresult{&emptySortResult, nil,
 			x.Errorf("Cannot sort attribute %s of type object.", ts.Order[0].Attr)}
 	}
+	cur, err := newSortCursor(ts, sType)
+	if err != nil {
+		return &sortresult{&emptySortResult, nil, err}
+	}
+	fetch := localValues(ts, sType)
+	if tab := groups().knownTablet(ts.Order[0].Attr); tab != nil && len(tab.Shards) > 0 {
+		if fetch, err = shardValues(ctx, ts.Order[0], destUids(ts.UidMatrix), ts.ReadTs,
+			sType); err != nil {
+			return &sortresult{&emptySortResult, nil, err}
+		}
+	}
 
 	for i := 0; i < n; i++ {
 		select {
@@ -122,9 +146,12 @@ func sortWithoutIndex(ctx context.Context, ts *pb.SortMessage) *sortresult {
 			// Copy, otherwise it'd affect the destUids and hence the srcUids of Next level.
 			tempList := &pb.List{Uids: ts.UidMatrix[i].Uids}
 			var vals []types.Val
-			if vals, err = sortByValue(ctx, ts, tempList, sType); err != nil {
+			if vals, err = sortByValue(ctx, ts, tempList, fetch); err != nil {
 				return &sortresult{&emptySortResult, nil, err}
 			}
+			if cur != nil {
+				tempList.Uids, vals = cur.filter(tempList.Uids, vals)
+			}
 			start, end, err := paginate(ts, tempList, vals)
 			if err != nil {
 				return &sortresult{&emptySortResult, nil, err}
@@ -166,19 +193,24 @@ func sortWithIndex(ctx context.Context, ts *pb.SortMessage) *sortresult {
 	}
 
 	// Get the tokenizers and choose the corresponding one.
-	if !schema.State().IsIndexed(order.Attr) {
+	if !schema.Served().IsIndexed(order.Attr) {
 		return &sortresult{&emptySortResult, nil, x.Errorf("Attribute %s is not indexed.", order.Attr)}
 	}
 
-	tokenizers := schema.State().Tokenizer(order.Attr)
+	tokenizers := schema.Served().Tokenizer(order.Attr)
 	var tokenizer tok.Tokenizer
+	useIndex := ts.GetHints().GetUseIndex()
 	for _, t := range tokenizers {
-		// Get the first sortable index.
-		if t.IsSortable() {
+		// Get the first sortable index, or the one asked for.
+		if t.IsSortable() && (len(useIndex) == 0 || t.Name() == useIndex) {
 			tokenizer = t
 			break
 		}
 	}
+	if tokenizer == nil && len(useIndex) > 0 {
+		return &sortresult{&emptySortResult, nil,
+			x.Errorf("Attribute:%s has no sortable index %s.", order.Attr, useIndex)}
+	}
 
 	if tokenizer == nil {
 		// String type can have multiple tokenizers, only one of which is
@@ -192,12 +224,33 @@ func sortWithIndex(ctx context.Context, ts *pb.SortMessage) *sortresult {
 		return &sortresult{&emptySortResult, nil, x.Errorf("Attribute:%s is not sortable.", order.Attr)}
 	}
 
+	cur, err := newSortCursor(ts, typ)
+	if err != nil {
+		return &sortresult{&emptySortResult, nil, err}
+	}
+
 	indexPrefix := x.IndexKey(order.Attr, string(tokenizer.Identifier()))
 	var seekKey []byte
-	if !order.Desc {
+	switch {
+	case cur != nil && cur.val.Value != nil:
+		// We need to seek to the bucket of the value the results are after.
+		tokens, err := tok.BuildTokens(cur.val.Value, tokenizer)
+		if err != nil || len(tokens) == 0 {
+			return &sortresult{&emptySortResult, nil,
+				x.Errorf("Cannot find the index bucket of the cursor for attribute %s.", order.Attr)}
+		}
+		cur.token = tokens[0]
+		seekKey = x.IndexKey(order.Attr, cur.token)
+	case cur != nil && !order.Desc:
+		// The cursor is past all values, uids without a value aren't in the index.
+		for range out {
+			r.UidMatrix = append(r.UidMatrix, &pb.List{})
+		}
+		return &sortresult{r, make([][]types.Val, n), nil}
+	case !order.Desc:
 		// We need to seek to the first key of this index type.
 		seekKey = indexPrefix
-	} else {
+	default:
 		// We need to reach the last key of this index type.
 		seekKey = x.IndexKey(order.Attr, string(tokenizer.Identifier()+1))
 	}
@@ -226,7 +279,7 @@ BUCKETS:
 			}
 			// Intersect every UID list with the index bucket, and update their
 			// results (in out).
-			err := intersectBucket(ctx, ts, token, out)
+			err := intersectBucket(ctx, ts, token, out, cur)
 			switch err {
 			case errDone:
 				break BUCKETS
@@ -257,7 +310,9 @@ BUCKETS:
 type orderResult struct {
 	idx int
 	r   *pb.Result
-	err error
+	// fetch returns the values of a split attribute, instead of r.
+	fetch valueFunc
+	err   error
 }
 
 func multiSort(ctx context.Context, r *sortresult, ts *pb.SortMessage) error {
@@ -292,6 +347,20 @@ func multiSort(ctx context.Context, r *sortresult, ts *pb.SortMessage) error {
 	// Execute rest of the sorts concurrently.
 	och := make(chan orderResult, len(ts.Order)-1)
 	for i := 1; i < len(ts.Order); i++ {
+		if order := ts.Order[i]; isSplit(groups().knownTablet(order.Attr)) {
+			// The values are fetched and converted as those of the first attribute are.
+			go func(i int) {
+				or := orderResult{idx: i}
+				typ, err := schema.State().TypeOf(order.Attr)
+				if err != nil || !typ.IsScalar() {
+					or.err = x.Errorf("Cannot sort attribute %s of type object.", order.Attr)
+				} else {
+					or.fetch, or.err = shardValues(ctx, order, dest, ts.ReadTs, typ)
+				}
+				och <- or
+			}(i)
+			continue
+		}
 		in := &pb.Query{
 			Attr:    ts.Order[i].Attr,
 			UidList: dest,
@@ -312,6 +381,18 @@ func multiSort(ctx context.Context, r *sortresult, ts *pb.SortMessage) error {
 			continue
 		}
 
+		if or.fetch != nil {
+			for i, uid := range dest.Uids {
+				// Uids without a value get a nil one, sorted as greater than all others.
+				sv, err := or.fetch(uid)
+				if err != nil {
+					sv = types.Val{}
+				}
+				sortVals[i][or.idx] = sv
+			}
+			continue
+		}
+
 		result := or.r
 		x.AssertTrue(len(result.ValueMatrix) == len(dest.Uids))
 		for i, _ := range dest.Uids {
@@ -382,6 +463,34 @@ func processSort(ctx context.Context, ts *pb.SortMessage) (*pb.SortResult, error
 		return nil, x.Errorf("Sorting not supported on attr: %s of type: [scalar]", ts.Order[0].Attr)
 	}
 
+	var r *sortresult
+	tab := groups().knownTablet(ts.Order[0].Attr)
+	switch {
+	case tab != nil && len(tab.Shards) > 0:
+		// The index of a split tablet is in several groups.
+		r = sortWithoutIndex(ctx, ts)
+	case ts.GetHints().GetSort() == "index":
+		r = sortWithIndex(ctx, ts)
+	case ts.GetHints().GetSort() == "values":
+		r = sortWithoutIndex(ctx, ts)
+	default:
+		r = raceSort(ctx, ts)
+	}
+
+	if r.err != nil {
+		return nil, r.err
+	}
+	// If request didn't have multiple attributes we return.
+	if len(ts.Order) <= 1 {
+		return r.reply, nil
+	}
+
+	err := multiSort(ctx, r, ts)
+	return r.reply, err
+}
+
+// raceSort sorts both with and without the index, returning the result of the first to succeed.
+func raceSort(ctx context.Context, ts *pb.SortMessage) *sortresult {
 	cctx, cancel := context.WithCancel(ctx)
 	resCh := make(chan *sortresult, 2)
 	go func() {
@@ -412,17 +521,7 @@ func processSort(ctx context.Context, ts *pb.SortMessage) (*pb.SortResult, error
 		}
 		r = <-resCh
 	}
-
-	if r.err != nil {
-		return nil, r.err
-	}
-	// If request didn't have multiple attributes we return.
-	if len(ts.Order) <= 1 {
-		return r.reply, nil
-	}
-
-	err := multiSort(ctx, r, ts)
-	return r.reply, err
+	return r
 }
 
 func destUids(uidMatrix []*pb.List) *pb.List {
@@ -447,7 +546,7 @@ func fetchValues(ctx context.Context, in *pb.Query, idx int, or chan orderResult
 	if in.Reverse {
 		in.Attr = strings.TrimPrefix(in.Attr, "~")
 	}
-	r, err := ProcessTaskOverNetwork(ctx, in)
+	r, err := processTaskOverNetwork(ctx, in)
 	or <- orderResult{
 		idx: idx,
 		err: err,
@@ -463,9 +562,10 @@ type intersectedList struct {
 }
 
 // intersectBucket intersects every UID list in the UID matrix with the
-// indexed bucket.
+// indexed bucket. If the bucket is the one of the cursor, the uids sorted up to
+// the cursor are left out.
 func intersectBucket(ctx context.Context, ts *pb.SortMessage, token string,
-	out []intersectedList) error {
+	out []intersectedList, cur *sortCursor) error {
 	count := int(ts.Count)
 	order := ts.Order[0]
 	sType, err := schema.State().TypeOf(order.Attr)
@@ -503,6 +603,16 @@ func intersectBucket(ctx context.Context, ts *pb.SortMessage, token string,
 		// variants of a predicate.
 		result.Uids = removeDuplicates(result.Uids, il.uset)
 
+		// The offset applies after the cursor, so its bucket must be sorted first.
+		sorted := false
+		if cur != nil && token == cur.token {
+			if vals, err = sortByValue(ctx, ts, result, localValues(ts, scalar)); err != nil {
+				return err
+			}
+			result.Uids, vals = cur.filter(result.Uids, vals)
+			sorted = true
+		}
+
 		// Check offsets[i].
 		n := len(result.Uids)
 		if il.offset >= n {
@@ -514,8 +624,10 @@ func intersectBucket(ctx context.Context, ts *pb.SortMessage, token string,
 
 		// We are within the page. We need to apply sorting.
 		// Sort results by value before applying offset.
-		if vals, err = sortByValue(ctx, ts, result, scalar); err != nil {
-			return err
+		if !sorted {
+			if vals, err = sortByValue(ctx, ts, result, localValues(ts, scalar)); err != nil {
+				return err
+			}
 		}
 
 		// Result set might have reduced after sorting. As some uids might not have a
@@ -599,9 +711,107 @@ func paginate(ts *pb.SortMessage, dest *pb.List, vals []types.Val) (int, int, er
 	return start, end, nil
 }
 
+// sortCursor is the position of a sort result, as a value and uid. Uids of equal values are sorted
+// by uid.
+type sortCursor struct {
+	uid  uint64
+	val  types.Val // The value is nil if the uid doesn't have any.
+	desc bool
+	// token is the index token of val, set when sorting with the index.
+	token string
+}
+
+// newSortCursor returns the cursor results of ts are after, or nil if there is none.
+func newSortCursor(ts *pb.SortMessage, typ types.TypeID) (*sortCursor, error) {
+	if ts.AfterUid == 0 {
+		return nil, nil
+	}
+	cur := &sortCursor{uid: ts.AfterUid, desc: ts.Order[0].Desc}
+	cur.val.Tid = typ
+	if tv := ts.AfterValue; tv != nil {
+		src := types.ValueForType(types.TypeID(tv.ValType))
+		src.Value = tv.Val
+		val, err := types.Convert(src, typ)
+		if err != nil {
+			return nil, x.Errorf("Invalid cursor value for attribute %s: %v", ts.Order[0].Attr, err)
+		}
+		cur.val = val
+	}
+	return cur, nil
+}
+
+// after tells whether the uid with the value val is sorted after the cursor.
+func (cur *sortCursor) after(val types.Val, uid uint64) bool {
+	cmp := types.Compare(val, cur.val, cur.desc)
+	if cmp == 0 && val.Value == nil && cur.desc {
+		// A descending sort puts the uids without a value in the reverse order of the uids.
+		return uid < cur.uid
+	}
+	return cmp > 0 || (cmp == 0 && uid > cur.uid)
+}
+
+// filter removes the uids sorted up to the cursor, along with their values, from the sorted
+// uids and values.
+func (cur *sortCursor) filter(uids []uint64, vals []types.Val) ([]uint64, []types.Val) {
+	x.AssertTrue(len(uids) == len(vals))
+	outUids, outVals := uids[:0], vals[:0]
+	for i, uid := range uids {
+		if cur.after(vals[i], uid) {
+			outUids = append(outUids, uid)
+			outVals = append(outVals, vals[i])
+		}
+	}
+	return outUids, outVals
+}
+
+// valueFunc returns the value to sort the given uid by.
+type valueFunc func(uid uint64) (types.Val, error)
+
+// localValues returns the values of the attribute sorted by, as held by this group.
+func localValues(ts *pb.SortMessage, typ types.TypeID) valueFunc {
+	order := ts.Order[0]
+	return func(uid uint64) (types.Val, error) {
+		return fetchValue(uid, order.Attr, order.Langs, typ, ts.ReadTs)
+	}
+}
+
+// shardValues fetches the values of the attribute of order from all the groups holding a part of
+// its split tablet, for the given uids.
+func shardValues(ctx context.Context, order *pb.Order, uids *pb.List, readTs uint64,
+	typ types.TypeID) (valueFunc, error) {
+	in := &pb.Query{
+		Attr:    order.Attr,
+		UidList: uids,
+		Langs:   order.Langs,
+		ReadTs:  readTs,
+	}
+	result, err := processTaskOverNetwork(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+	vals := make(map[uint64]types.Val, len(in.UidList.Uids))
+	for i, uid := range in.UidList.Uids {
+		if i >= len(result.ValueMatrix) || len(result.ValueMatrix[i].Values) == 0 {
+			continue
+		}
+		v := result.ValueMatrix[i].Values[0]
+		val := types.ValueForType(types.TypeID(v.ValType))
+		val.Value = v.Val
+		if sv, err := types.Convert(val, typ); err == nil {
+			vals[uid] = sv
+		}
+	}
+	return func(uid uint64) (types.Val, error) {
+		if val, ok := vals[uid]; ok {
+			return val, nil
+		}
+		return types.Val{}, posting.ErrNoValue
+	}, nil
+}
+
 // sortByValue fetches values and sort UIDList.
 func sortByValue(ctx context.Context, ts *pb.SortMessage, ul *pb.List,
-	typ types.TypeID) ([]types.Val, error) {
+	fetch valueFunc) ([]types.Val, error) {
 	lenList := len(ul.Uids)
 	uids := make([]uint64, 0, lenList)
 	values := make([][]types.Val, 0, lenList)
@@ -614,7 +824,7 @@ func sortByValue(ctx context.Context, ts *pb.SortMessage, ul *pb.List,
 		default:
 			uid := ul.Uids[i]
 			uids = append(uids, uid)
-			val, err := fetchValue(uid, order.Attr, order.Langs, typ, ts.ReadTs)
+			val, err := fetch(uid)
 			if err != nil {
 				// Value couldn't be found or couldn't be converted to the sort
 				// type.  By using a nil Value, it will appear at the
@@ -626,7 +836,7 @@ func sortByValue(ctx context.Context, ts *pb.SortMessage, ul *pb.List,
 	}
 	err := types.Sort(values, &pb.List{Uids: uids}, []bool{order.Desc})
 	ul.Uids = uids
-	if len(ts.Order) > 1 {
+	if len(ts.Order) > 1 || ts.AfterUid > 0 {
 		for _, v := range values {
 			multiSortVals = append(multiSortVals, v[0])
 		}
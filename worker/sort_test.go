@@ -17,9 +17,15 @@
 package worker
 
 import (
+	"context"
+	"sync"
 	"testing"
 
 	"github.com/stretchr/testify/require"
+
+	"github.com/dgraph-io/dgraph/protos/pb"
+	"github.com/dgraph-io/dgraph/schema"
+	"github.com/dgraph-io/dgraph/types"
 )
 
 func TestRemoveDuplicates(t *testing.T) {
@@ -54,3 +60,62 @@ func TestRemoveDuplicates(t *testing.T) {
 		require.Equal(t, set, toSet(test.setOut))
 	}
 }
+
+func shardAges(t *testing.T, ages ...int64) *pb.Result {
+	r := &pb.Result{}
+	for _, age := range ages {
+		vl := &pb.ValueList{}
+		if age > 0 {
+			val := types.ValueForType(types.BinaryID)
+			require.NoError(t, types.Marshal(types.Val{Tid: types.IntID, Value: age}, &val))
+			vl.Values = []*pb.TaskValue{{Val: val.Value.([]byte), ValType: pb.Posting_INT}}
+		}
+		r.ValueMatrix = append(r.ValueMatrix, vl)
+	}
+	return r
+}
+
+func TestMultiSortSplit(t *testing.T) {
+	require.NoError(t, schema.ParseBytes([]byte("sort.age: int ."), 1))
+	var mu sync.Mutex
+	g2 := startFakeReplica(t, &mu, func() error { return nil })
+	defer g2.stop()
+	g3 := startFakeReplica(t, &mu, func() error { return nil })
+	defer g3.stop()
+	// The ages of uids 1 and 2 are in group 2, and those of 3 and 4 in group 3.
+	mu.Lock()
+	g2.reply = shardAges(t, 40, 10, 0, 0)
+	g3.reply = shardAges(t, 0, 0, 30, 20)
+	mu.Unlock()
+	gr.Lock()
+	gr.state = &pb.MembershipState{Groups: map[uint32]*pb.Group{
+		2: {Members: map[uint64]*pb.Member{1: {Id: 1, GroupId: 2, Addr: g2.addr}}},
+		3: {Members: map[uint64]*pb.Member{2: {Id: 2, GroupId: 3, Addr: g3.addr}}},
+	}}
+	gr.tablets["sort.age"] = &pb.Tablet{GroupId: 2, Predicate: "sort.age",
+		Shards: []*pb.TabletShard{{StartUid: 3, GroupId: 3}}}
+	gr.Unlock()
+	defer func() {
+		gr.Lock()
+		gr.state = nil
+		delete(gr.tablets, "sort.age")
+		gr.Unlock()
+	}()
+
+	// All the uids have the same name, so they're sorted by their ages.
+	name := types.Val{Tid: types.StringID, Value: "alice"}
+	r := &sortresult{
+		reply: &pb.SortResult{UidMatrix: []*pb.List{{Uids: []uint64{1, 2, 3, 4}}}},
+		vals:  [][]types.Val{{name, name, name, name}},
+	}
+	ts := &pb.SortMessage{
+		Order: []*pb.Order{{Attr: "sort.name"}, {Attr: "sort.age"}},
+		Count: 4,
+	}
+	require.NoError(t, multiSort(context.Background(), r, ts))
+	require.Equal(t, []uint64{2, 4, 3, 1}, r.reply.UidMatrix[0].Uids)
+	mu.Lock()
+	require.Equal(t, 1, g2.calls)
+	require.Equal(t, 1, g3.calls)
+	mu.Unlock()
+}
@@ -0,0 +1,435 @@
+/*
+ * Copyright 2018 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package worker
+
+import (
+	"math"
+	"sort"
+
+	"github.com/dgraph-io/badger"
+	"github.com/dgraph-io/dgraph/posting"
+	"github.com/dgraph-io/dgraph/protos/pb"
+	"github.com/dgraph-io/dgraph/schema"
+	"github.com/dgraph-io/dgraph/x"
+	"github.com/golang/glog"
+	"golang.org/x/net/context"
+)
+
+// A tablet split by Zero is held by several groups, each holding the data of a range of uids,
+// along with the postings of these uids in the index, reverse and count lists. Mutations go to the
+// group holding their subject, and queries to all the groups, which only answer for the uids they
+// hold. The ranges are in the shards of the tablet, in the membership state.
+
+// shardRange is the range of uids of a split tablet held by a group, from start to end. An end of
+// zero means up to the last uid. A nil range holds all the uids, as for the tablets not split.
+type shardRange struct {
+	start uint64
+	end   uint64
+}
+
+func (r *shardRange) has(uid uint64) bool {
+	return r == nil || (uid >= r.start && (r.end == 0 || uid < r.end))
+}
+
+// tabletShard returns the range of uids of tab held by gid, which is nil if tab isn't split. ok is
+// false if gid holds no part of tab, nor a copy of it.
+func tabletShard(tab *pb.Tablet, gid uint32) (r *shardRange, ok bool) {
+	if len(tab.Shards) == 0 {
+		return nil, tab.GroupId == gid || holdsCopy(tab, gid)
+	}
+	r = &shardRange{}
+	owner := tab.GroupId
+	for _, sh := range tab.Shards {
+		if owner == gid {
+			r.end = sh.StartUid
+			return r, true
+		}
+		r.start, owner = sh.StartUid, sh.GroupId
+	}
+	return r, owner == gid
+}
+
+// TabletUids returns whether gid holds the data of a uid of tab. ok is false if gid holds no part
+// of tab, nor a copy of it.
+func TabletUids(tab *pb.Tablet, gid uint32) (holds func(uid uint64) bool, ok bool) {
+	r, ok := tabletShard(tab, gid)
+	return r.has, ok
+}
+
+// knownTablet returns the tablet of key, or nil if this group doesn't know of it yet. Unlike
+// Tablet, it doesn't ask Zero, for the code paths which only run once the tablet is known.
+func (g *groupi) knownTablet(key string) *pb.Tablet {
+	g.RLock()
+	defer g.RUnlock()
+	return g.tablets[key]
+}
+
+// isSplit tells whether tab is split across groups.
+func isSplit(tab *pb.Tablet) bool {
+	return tab != nil && len(tab.Shards) > 0
+}
+
+// servedBy tells whether gid holds tab, or a part of it.
+func servedBy(tab *pb.Tablet, gid uint32) bool {
+	_, ok := tabletShard(tab, gid)
+	return ok
+}
+
+// uidGroup returns the group holding the given uid of tab.
+func uidGroup(tab *pb.Tablet, uid uint64) uint32 {
+	gid := tab.GroupId
+	for _, sh := range tab.Shards {
+		if uid < sh.StartUid {
+			break
+		}
+		gid = sh.GroupId
+	}
+	return gid
+}
+
+// shardGroups returns the groups holding a part of tab, the one of the tablet first.
+func shardGroups(tab *pb.Tablet) []uint32 {
+	gids := []uint32{tab.GroupId}
+	for _, sh := range tab.Shards {
+		gids = append(gids, sh.GroupId)
+	}
+	return gids
+}
+
+// tabletGroups returns the groups holding a part of tab, or a copy of it.
+func tabletGroups(tab *pb.Tablet) []uint32 {
+	return append(shardGroups(tab), copyGroups(tab)...)
+}
+
+// edgeGroups returns the groups the edge has to be applied by. The edges deleting a whole
+// predicate are applied by all the groups holding a part of it, and all edges by the copies.
+func edgeGroups(tab *pb.Tablet, edge *pb.DirectedEdge) []uint32 {
+	if isDeletePredicateEdge(edge) {
+		return tabletGroups(tab)
+	}
+	return append([]uint32{uidGroup(tab, edge.Entity)}, copyGroups(tab)...)
+}
+
+// servesEdge tells whether gid is one of the groups the edge has to be applied by.
+func servesEdge(tab *pb.Tablet, edge *pb.DirectedEdge, gid uint32) bool {
+	for _, g := range edgeGroups(tab, edge) {
+		if g == gid {
+			return true
+		}
+	}
+	return false
+}
+
+// shardLength returns the length of pl, only counting the postings of the uids in r.
+func shardLength(pl *posting.List, readTs uint64, r *shardRange) int {
+	if r == nil {
+		return pl.Length(readTs, 0)
+	}
+	var n int
+	err := pl.Iterate(readTs, 0, func(p *pb.Posting) error {
+		if r.has(p.Uid) {
+			n++
+		}
+		return nil
+	})
+	if err != nil {
+		return -1
+	}
+	return n
+}
+
+// shardUids keeps the uids of l in r.
+func shardUids(l *pb.List, r *shardRange) {
+	if r == nil {
+		return
+	}
+	uids := l.Uids[:0]
+	for _, uid := range l.Uids {
+		if r.has(uid) {
+			uids = append(uids, uid)
+		}
+	}
+	l.Uids = uids
+}
+
+// processTaskOverShards runs q in all the groups holding a part of the split tablet, and merges
+// their results.
+func processTaskOverShards(ctx context.Context, q *pb.Query, tab *pb.Tablet) (*pb.Result, error) {
+	gids := tabletGroups(tab)
+	results := make([]*pb.Result, len(gids))
+	errCh := make(chan error, len(gids))
+	for i, gid := range gids {
+		go func(i int, gid uint32) {
+			var err error
+			results[i], err = processTaskInGroup(ctx, q, gid)
+			errCh <- err
+		}(i, gid)
+	}
+	var rerr error
+	for range gids {
+		if err := <-errCh; err != nil && rerr == nil {
+			rerr = err
+		}
+	}
+	if rerr != nil {
+		return &pb.Result{}, rerr
+	}
+	return mergeShardResults(results), nil
+}
+
+// mergeShardResults merges the results of a query from the groups of a split tablet. The groups
+// return their lists in the same order, by uid or by token, so these are merged position by
+// position. The one exception is for the functions returning a list per uid they match, which
+// are all merged by the query in the end.
+func mergeShardResults(results []*pb.Result) *pb.Result {
+	out := &pb.Result{}
+	aligned := true
+	for _, r := range results {
+		out.IntersectDest = out.IntersectDest || r.IntersectDest
+		out.List = out.List || r.List
+		aligned = aligned && len(r.UidMatrix) == len(results[0].UidMatrix)
+	}
+	if !aligned {
+		for _, r := range results {
+			out.UidMatrix = append(out.UidMatrix, r.UidMatrix...)
+			out.FacetMatrix = append(out.FacetMatrix, r.FacetMatrix...)
+		}
+		return out
+	}
+
+	for i := range results[0].UidMatrix {
+		var from []*pb.Result
+		for _, r := range results {
+			if len(r.UidMatrix[i].Uids) > 0 {
+				from = append(from, r)
+			}
+		}
+		switch len(from) {
+		case 0:
+			// Lists of values are empty, the facets are those of the group holding the uid.
+			src := results[0]
+			for _, r := range results {
+				if i < len(r.FacetMatrix) && len(r.FacetMatrix[i].FacetsList) > 0 {
+					src = r
+					break
+				}
+			}
+			out.UidMatrix = append(out.UidMatrix, src.UidMatrix[i])
+			if i < len(src.FacetMatrix) {
+				out.FacetMatrix = append(out.FacetMatrix, src.FacetMatrix[i])
+			}
+		case 1:
+			out.UidMatrix = append(out.UidMatrix, from[0].UidMatrix[i])
+			if i < len(from[0].FacetMatrix) {
+				out.FacetMatrix = append(out.FacetMatrix, from[0].FacetMatrix[i])
+			}
+		default:
+			uids, fcs := mergeShardLists(from, i)
+			out.UidMatrix = append(out.UidMatrix, uids)
+			if fcs != nil {
+				out.FacetMatrix = append(out.FacetMatrix, fcs)
+			}
+		}
+	}
+
+	for _, r := range results {
+		for i, vl := range r.ValueMatrix {
+			if i == len(out.ValueMatrix) {
+				out.ValueMatrix = append(out.ValueMatrix, vl)
+			} else if len(out.ValueMatrix[i].Values) == 0 {
+				out.ValueMatrix[i] = vl
+			}
+		}
+		for i, ll := range r.LangMatrix {
+			if i == len(out.LangMatrix) {
+				out.LangMatrix = append(out.LangMatrix, ll)
+			} else if len(out.LangMatrix[i].Lang) == 0 {
+				out.LangMatrix[i] = ll
+			}
+		}
+		for i, c := range r.Counts {
+			if i == len(out.Counts) {
+				out.Counts = append(out.Counts, c)
+			} else {
+				out.Counts[i] += c
+			}
+		}
+	}
+	return out
+}
+
+// mergeShardLists merges the lists at position i of the results, along with their facets if
+// all of them have some.
+func mergeShardLists(results []*pb.Result, i int) (*pb.List, *pb.FacetsList) {
+	type entry struct {
+		uid    uint64
+		facets *pb.Facets
+	}
+	withFacets := true
+	var entries []entry
+	for _, r := range results {
+		ul := r.UidMatrix[i]
+		var fl []*pb.Facets
+		if i < len(r.FacetMatrix) && len(r.FacetMatrix[i].FacetsList) == len(ul.Uids) {
+			fl = r.FacetMatrix[i].FacetsList
+		} else {
+			withFacets = false
+		}
+		for j, uid := range ul.Uids {
+			e := entry{uid: uid}
+			if fl != nil {
+				e.facets = fl[j]
+			}
+			entries = append(entries, e)
+		}
+	}
+	sort.SliceStable(entries, func(a, b int) bool { return entries[a].uid < entries[b].uid })
+
+	uids := &pb.List{}
+	var fcs *pb.FacetsList
+	if withFacets {
+		fcs = &pb.FacetsList{}
+	}
+	for j, e := range entries {
+		if j > 0 && e.uid == entries[j-1].uid {
+			continue
+		}
+		uids.Uids = append(uids.Uids, e.uid)
+		if fcs != nil {
+			fcs.FacetsList = append(fcs.FacetsList, e.facets)
+		}
+	}
+	return uids, fcs
+}
+
+// splitPoint returns the uid in the middle of the data of attr for the uids in r, so that
+// splitting r at it halves the data.
+func splitPoint(attr string, r *shardRange) (uint64, error) {
+	txn := pstore.NewTransactionAt(math.MaxUint64, false)
+	defer txn.Discard()
+	opt := badger.DefaultIteratorOptions
+	opt.PrefetchValues = false
+
+	prefix := x.ParsedKey{Attr: attr}.DataPrefix()
+	iterate := func(f func(uid uint64) bool) {
+		itr := txn.NewIterator(opt)
+		defer itr.Close()
+		for itr.Seek(x.DataKey(attr, r.start)); itr.ValidForPrefix(prefix); itr.Next() {
+			pk := x.Parse(itr.Item().Key())
+			if pk == nil || !r.has(pk.Uid) || !f(pk.Uid) {
+				return
+			}
+		}
+	}
+
+	var total int
+	iterate(func(uid uint64) bool {
+		total++
+		return true
+	})
+	if total < 2 {
+		return 0, x.Errorf("Predicate %s has %d uids from %#x to %#x, too few to be split",
+			attr, total, r.start, r.end)
+	}
+	var n int
+	var split uint64
+	iterate(func(uid uint64) bool {
+		if n == total/2 {
+			split = uid
+			return false
+		}
+		n++
+		return true
+	})
+	if split == 0 {
+		return 0, x.Errorf("Predicate %s changed while picking the uid to split it at", attr)
+	}
+	return split, nil
+}
+
+// SplitTablet is called by Zero, once it has made the tablet read-only, to split the part of it
+// held by this group. It sends the data of the uids from the split one on to the destination
+// group, and returns the uid it picked.
+func (w *grpcWorker) SplitTablet(ctx context.Context,
+	in *pb.SplitTabletPayload) (*pb.SplitTabletPayload, error) {
+	if groups().groupId() != in.SourceGroupId {
+		return nil, x.Errorf("Group id doesn't match, received request for %d, my gid: %d",
+			in.SourceGroupId, groups().groupId())
+	}
+	if len(in.Predicate) == 0 {
+		return nil, errEmptyPredicate
+	}
+	if !groups().ServesTablet(in.Predicate) {
+		return nil, errUnservedTablet
+	}
+	// The count index of reverse edges is by the count over all the groups, which none has.
+	if schema.State().IsReversed(in.Predicate) && schema.State().HasCount(in.Predicate) {
+		return nil, x.Errorf("Predicate %s has a count index of reverse edges, so it can't be split",
+			in.Predicate)
+	}
+	n := groups().Node
+	if !n.AmLeader() {
+		return nil, errNotLeader
+	}
+
+	glog.Infof("Split tablet request for pred: [%v], src: [%v], dst: [%v]\n", in.Predicate,
+		in.SourceGroupId, in.DestGroupId)
+
+	// Ensures that all future mutations beyond this point are rejected.
+	if err := n.proposeAndWait(ctx, &pb.Proposal{State: in.State}); err != nil {
+		return nil, err
+	}
+	if err := abortPendingTxns(in.Predicate); err != nil {
+		return nil, err
+	}
+	// We iterate over badger, so need to flush and wait for sync watermark to catch up.
+	n.applyAllMarks(ctx)
+
+	out := &pb.SplitTabletPayload{
+		Predicate:     in.Predicate,
+		SourceGroupId: in.SourceGroupId,
+		DestGroupId:   in.DestGroupId,
+		StartUid:      in.StartUid,
+		EndUid:        in.EndUid,
+		SplitUid:      in.SplitUid,
+	}
+	if out.SplitUid == 0 {
+		var err error
+		out.SplitUid, err = splitPoint(in.Predicate, &shardRange{start: in.StartUid, end: in.EndUid})
+		if err != nil {
+			return nil, err
+		}
+	}
+	r := &shardRange{start: out.SplitUid, end: in.EndUid}
+	return out, movePredicateHelper(ctx, in.Predicate, in.DestGroupId, r)
+}
+
+// proposeCleanShard has this group delete the data of the uids of attr it no longer holds, once
+// the part it held has been split.
+func (n *node) proposeCleanShard(attr string, r *shardRange) {
+	ctx := context.Background()
+	clean := &pb.SplitTabletPayload{Predicate: attr, StartUid: r.start, EndUid: r.end}
+	if err := n.proposeAndWait(ctx, &pb.Proposal{CleanShard: clean}); err != nil {
+		glog.Errorf("While cleaning the uids of split predicate %s: %v", attr, err)
+	}
+}
+
+// applyCleanShard deletes the data of the uids of the predicate out of the range of the proposal.
+func (n *node) applyCleanShard(ctx context.Context, clean *pb.SplitTabletPayload) error {
+	r := &shardRange{start: clean.StartUid, end: clean.EndUid}
+	return posting.TrimPredicate(ctx, clean.Predicate, r.has)
+}
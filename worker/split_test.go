@@ -0,0 +1,105 @@
+/*
+ * Copyright 2018 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package worker
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dgraph-io/dgraph/protos/pb"
+)
+
+func TestTabletShard(t *testing.T) {
+	tab := &pb.Tablet{GroupId: 1, Shards: []*pb.TabletShard{
+		{StartUid: 100, GroupId: 2},
+		{StartUid: 200, GroupId: 3},
+	}}
+	require.True(t, isSplit(tab))
+	r, ok := tabletShard(tab, 1)
+	require.True(t, ok)
+	require.Equal(t, shardRange{start: 0, end: 100}, *r)
+	r, ok = tabletShard(tab, 2)
+	require.True(t, ok)
+	require.Equal(t, shardRange{start: 100, end: 200}, *r)
+	r, ok = tabletShard(tab, 3)
+	require.True(t, ok)
+	require.Equal(t, shardRange{start: 200, end: 0}, *r)
+	_, ok = tabletShard(tab, 4)
+	require.False(t, ok)
+
+	for uid, gid := range map[uint64]uint32{1: 1, 99: 1, 100: 2, 199: 2, 200: 3, 1 << 60: 3} {
+		require.Equal(t, gid, uidGroup(tab, uid), "uid %d", uid)
+		r, _ := tabletShard(tab, gid)
+		require.True(t, r.has(uid), "uid %d", uid)
+	}
+
+	holds, ok := TabletUids(tab, 2)
+	require.True(t, ok)
+	require.True(t, holds(150))
+	require.False(t, holds(250))
+
+	// A tablet which isn't split is served whole by its group.
+	tab = &pb.Tablet{GroupId: 1}
+	require.False(t, isSplit(tab))
+	r, ok = tabletShard(tab, 1)
+	require.True(t, ok)
+	require.Nil(t, r)
+	require.True(t, r.has(12345))
+	holds, ok = TabletUids(tab, 1)
+	require.True(t, ok)
+	require.True(t, holds(12345))
+}
+
+func TestShardUids(t *testing.T) {
+	l := &pb.List{Uids: []uint64{1, 50, 150, 200, 250}}
+	shardUids(l, &shardRange{start: 50, end: 200})
+	require.Equal(t, []uint64{50, 150}, l.Uids)
+}
+
+func TestMergeShardResults(t *testing.T) {
+	a := &pb.Result{
+		UidMatrix: []*pb.List{{Uids: []uint64{1, 5}}, {}, {Uids: []uint64{2}}},
+		ValueMatrix: []*pb.ValueList{
+			{Values: []*pb.TaskValue{{Val: []byte("a")}}}, {}, {},
+		},
+		Counts: []uint32{1, 2, 3},
+	}
+	b := &pb.Result{
+		UidMatrix: []*pb.List{{Uids: []uint64{3}}, {Uids: []uint64{7}}, {}},
+		ValueMatrix: []*pb.ValueList{
+			{}, {Values: []*pb.TaskValue{{Val: []byte("b")}}}, {},
+		},
+		Counts: []uint32{1, 0, 1},
+	}
+	out := mergeShardResults([]*pb.Result{a, b})
+	var uids [][]uint64
+	for _, l := range out.UidMatrix {
+		uids = append(uids, l.Uids)
+	}
+	require.Equal(t, [][]uint64{{1, 3, 5}, {7}, {2}}, uids)
+	require.Equal(t, []uint32{2, 2, 4}, out.Counts)
+	require.Equal(t, "a", string(out.ValueMatrix[0].Values[0].Val))
+	require.Equal(t, "b", string(out.ValueMatrix[1].Values[0].Val))
+	require.Empty(t, out.ValueMatrix[2].Values)
+	// The results of the shards are left as they were.
+	require.Equal(t, []uint32{1, 2, 3}, a.Counts)
+
+	// Results not aligned by the uids of the query, as for functions, are concatenated.
+	c := &pb.Result{UidMatrix: []*pb.List{{Uids: []uint64{9}}}}
+	out = mergeShardResults([]*pb.Result{a, c})
+	require.Len(t, out.UidMatrix, 4)
+}
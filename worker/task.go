@@ -44,7 +44,9 @@ import (
 	cregexp "github.com/google/codesearch/regexp"
 	"golang.org/x/net/context"
 	"golang.org/x/net/trace"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
 )
 
 var (
@@ -70,6 +72,8 @@ func invokeNetworkRequest(
 
 const backupRequestGracePeriod = time.Second
 
+var errNoNetworkConnection = errors.New("no network connection")
+
 // TODO: Cross-server cancellation as described in Jeff Dean's talk.
 func processWithBackupRequest(
 	ctx context.Context,
@@ -77,7 +81,7 @@ func processWithBackupRequest(
 	f func(context.Context, pb.WorkerClient) (interface{}, error)) (interface{}, error) {
 	addrs := groups().AnyTwoServers(gid)
 	if len(addrs) == 0 {
-		return nil, errors.New("no network connection")
+		return nil, errNoNetworkConnection
 	}
 	if len(addrs) == 1 {
 		reply, err := invokeNetworkRequest(ctx, addrs[0], f)
@@ -130,34 +134,147 @@ func processWithBackupRequest(
 	}
 }
 
+const (
+	// Number of times a read is retried, as long as the deadline of the request allows.
+	readRetries      = 3
+	readRetryBackoff = 100 * time.Millisecond
+)
+
+// isTransientReadError returns whether a read failed because the replica it was sent to was
+// unreachable or not serving the group at the time, like while the group changes its leader or
+// members. Errors of remote replicas are only known by their messages.
+func isTransientReadError(err error) bool {
+	if err == nil {
+		return false
+	}
+	if s, ok := status.FromError(err); ok && s.Code() == codes.Unavailable {
+		return true
+	}
+	msg := err.Error()
+	for _, transient := range []string{
+		errNoNetworkConnection.Error(),
+		conn.ErrNoConnection.Error(),
+		conn.ErrUnhealthyConnection.Error(),
+		errUnservedTablet.Error(),
+		"Request sent to wrong server",
+	} {
+		if strings.Contains(msg, transient) {
+			return true
+		}
+	}
+	return false
+}
+
+// processWithRetry runs f like processWithBackupRequest, retrying it against the replicas of the
+// group if it fails for a transient reason. So f must be idempotent, as reads are.
+func processWithRetry(
+	ctx context.Context,
+	gid uint32,
+	f func(context.Context, pb.WorkerClient) (interface{}, error)) (interface{}, error) {
+	backoff := readRetryBackoff
+	for i := 0; ; i++ {
+		reply, err := processWithBackupRequest(ctx, gid, f)
+		if i == readRetries || !isTransientReadError(err) || ctx.Err() != nil {
+			return reply, err
+		}
+		if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < backoff {
+			return reply, err
+		}
+		x.ReadRetries.Add(1)
+		if tr, ok := trace.FromContext(ctx); ok {
+			tr.LazyPrintf("Retrying read from group %d in %v. Error: %v", gid, backoff, err)
+		}
+		select {
+		case <-time.After(backoff):
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+		backoff *= 2
+	}
+}
+
 // ProcessTaskOverNetwork is used to process the query and get the result from
 // the instance which stores posting list corresponding to the predicate in the
 // query.
 func ProcessTaskOverNetwork(ctx context.Context, q *pb.Query) (*pb.Result, error) {
+	if isClusterAttr(q.Attr) {
+		// Served locally, from the membership state.
+		return processClusterTask(ctx, q)
+	}
+	attr, err := namespaceAttr(ctx, q.Attr)
+	if err != nil {
+		return &pb.Result{}, err
+	}
+	nq := *q
+	nq.Attr = attr
+	return processTaskOverNetwork(ctx, &nq)
+}
+
+// processTaskOverNetwork is like ProcessTaskOverNetwork, for queries of stored predicate names.
+func processTaskOverNetwork(ctx context.Context, q *pb.Query) (*pb.Result, error) {
 	attr := q.Attr
-	gid := groups().BelongsTo(attr)
-	if gid == 0 {
+	tab := groups().Tablet(attr)
+	if tab == nil {
 		return &pb.Result{}, errUnservedTablet
 	}
+	if len(tab.Shards) > 0 {
+		return processTaskOverShards(ctx, q, tab)
+	}
+	gid := tab.GroupId
+	if holdsCopy(tab, groups().groupId()) {
+		gid = groups().groupId()
+	}
 	if tr, ok := trace.FromContext(ctx); ok {
 		tr.LazyPrintf("attr: %v groupId: %v, readTs: %d", attr, gid, q.ReadTs)
 	}
 
+	reply, err := processTaskInGroup(ctx, q, gid)
+	// Reads of a moving tablet are served by the source group until the destination takes
+	// over. If that happened in the meantime, read from the destination instead.
+	if err != nil && strings.Contains(err.Error(), errUnservedTablet.Error()) {
+		if dst := groups().BelongsTo(attr); dst != 0 && dst != gid {
+			if tr, ok := trace.FromContext(ctx); ok {
+				tr.LazyPrintf("Tablet %s moved from group %d to %d. Reading again.", attr, gid, dst)
+			}
+			return processTaskInGroup(ctx, q, dst)
+		}
+	}
+	// The groups holding a copy serve the reads the group of the tablet can't.
+	for _, cg := range append([]uint32{tab.GroupId}, copyGroups(tab)...) {
+		if !isTransientReadError(err) || ctx.Err() != nil {
+			break
+		}
+		if cg == gid {
+			continue
+		}
+		if tr, ok := trace.FromContext(ctx); ok {
+			tr.LazyPrintf("Reading %s from group %d, holding a copy. Error: %v", attr, cg, err)
+		}
+		reply, err = processTaskInGroup(ctx, q, cg)
+	}
+	return reply, err
+}
+
+func processTaskInGroup(ctx context.Context, q *pb.Query, gid uint32) (*pb.Result, error) {
+	ctx, span := otrace.StartSpan(ctx, "worker.processTaskInGroup")
+	defer span.End()
+	span.AddAttributes(otrace.StringAttribute("attr", q.Attr),
+		otrace.Int64Attribute("group", int64(gid)),
+		otrace.BoolAttribute("local", groups().ServesGroup(gid)))
+
 	if groups().ServesGroup(gid) {
 		// No need for a network call, as this should be run from within this instance.
 		return processTask(ctx, q, gid)
 	}
 
-	result, err := processWithBackupRequest(ctx, gid, func(ctx context.Context, c pb.WorkerClient) (interface{}, error) {
+	result, err := processWithRetry(ctx, gid, func(ctx context.Context, c pb.WorkerClient) (interface{}, error) {
 		return c.ServeTask(ctx, q)
 	})
 	if err != nil {
 		return nil, err
 	}
 	reply := result.(*pb.Result)
-	if span := otrace.FromContext(ctx); span != nil {
-		span.Annotatef(nil, "Reply from server. length: %v Group: %v Attr: %v", len(reply.UidMatrix), gid, attr)
-	}
+	span.Annotatef(nil, "Reply from server. length: %v Group: %v Attr: %v", len(reply.UidMatrix), gid, q.Attr)
 	return reply, nil
 }
 
@@ -280,6 +397,7 @@ type funcArgs struct {
 	gid   uint32
 	srcFn *functionContext
 	out   *pb.Result
+	shard *shardRange // The uids held by this group, if the tablet is split.
 }
 
 // The function tells us whether we want to fetch value posting lists or uid posting lists.
@@ -332,12 +450,30 @@ func handleValuePostings(ctx context.Context, args funcArgs) error {
 
 	var key []byte
 	listType := schema.State().IsList(attr)
+	noValue := func() {
+		out.UidMatrix = append(out.UidMatrix, &emptyUIDList)
+		if q.DoCount {
+			out.Counts = append(out.Counts, 0)
+		} else {
+			out.ValueMatrix = append(out.ValueMatrix, &emptyValueList)
+			out.FacetMatrix = append(out.FacetMatrix, &pb.FacetsList{})
+			if q.ExpandAll {
+				// To keep the cardinality same as that of ValueMatrix.
+				out.LangMatrix = append(out.LangMatrix, &pb.LangList{})
+			}
+		}
+	}
 	for i := 0; i < srcFn.n; i++ {
 		select {
 		case <-ctx.Done():
 			return ctx.Err()
 		default:
 		}
+		if !args.shard.has(q.UidList.Uids[i]) {
+			// Another group of the split tablet holds the value.
+			noValue()
+			continue
+		}
 		key = x.DataKey(attr, q.UidList.Uids[i])
 
 		// Get or create the posting list for an entity, attribute combination.
@@ -357,17 +493,7 @@ func handleValuePostings(ctx context.Context, args funcArgs) error {
 		}
 
 		if err == posting.ErrNoValue || len(vals) == 0 {
-			out.UidMatrix = append(out.UidMatrix, &emptyUIDList)
-			if q.DoCount {
-				out.Counts = append(out.Counts, 0)
-			} else {
-				out.ValueMatrix = append(out.ValueMatrix, &emptyValueList)
-				out.FacetMatrix = append(out.FacetMatrix, &pb.FacetsList{})
-				if q.ExpandAll {
-					// To keep the cardinality same as that of ValueMatrix.
-					out.LangMatrix = append(out.LangMatrix, &pb.LangList{})
-				}
-			}
+			noValue()
 			continue
 		} else if err != nil {
 			return err
@@ -472,6 +598,13 @@ func handleUidPostings(ctx context.Context, args funcArgs, opts posting.ListOpti
 	}
 
 	span := otrace.FromContext(ctx)
+	if needsIndex(srcFn.fnType) {
+		ctx, span = otrace.StartSpan(ctx, "worker.indexLookup")
+		defer span.End()
+		span.AddAttributes(otrace.StringAttribute("attr", q.Attr),
+			otrace.StringAttribute("func", srcFn.fname),
+			otrace.Int64Attribute("tokens", int64(len(srcFn.tokens))))
+	}
 	if span != nil {
 		span.Annotatef(nil, "Number of uids: %d. args.srcFn: %+v", srcFn.n, args.srcFn)
 		defer func() {
@@ -510,12 +643,29 @@ func handleUidPostings(ctx context.Context, args funcArgs, opts posting.ListOpti
 				}
 			}
 			var key []byte
+			// The uids in the lists of a split tablet only held by another group are left out.
+			// Those of the data keys are held by a single group, so the others skip them.
+			shard := args.shard
 			switch srcFn.fnType {
 			case NotAFunction, CompareScalarFn, HasFn, UidInFn:
 				if q.Reverse {
 					key = x.ReverseKey(q.Attr, q.UidList.Uids[i])
 				} else {
 					key = x.DataKey(q.Attr, q.UidList.Uids[i])
+					if !shard.has(q.UidList.Uids[i]) {
+						if q.FacetParam != nil {
+							out.FacetMatrix = append(out.FacetMatrix, &pb.FacetsList{})
+						}
+						switch {
+						case q.DoCount:
+							out.Counts = append(out.Counts, 0)
+							out.UidMatrix = append(out.UidMatrix, &emptyUIDList)
+						case srcFn.fnType == NotAFunction:
+							out.UidMatrix = append(out.UidMatrix, &emptyUIDList)
+						}
+						continue
+					}
+					shard = nil
 				}
 			case GeoFn, RegexFn, FullTextSearchFn, StandardFn, CustomIndexFn:
 				key = x.IndexKey(q.Attr, srcFn.tokens[i])
@@ -537,6 +687,9 @@ func handleUidPostings(ctx context.Context, args funcArgs, opts posting.ListOpti
 			var perr error
 			filteredRes = make([]*result, 0)
 			err = pl.Postings(opts, func(p *pb.Posting) error {
+				if !shard.has(p.Uid) {
+					return nil
+				}
 				res := true
 				res, perr = applyFacetsTree(p.Facets, facetsTree)
 				if perr != nil {
@@ -569,7 +722,7 @@ func handleUidPostings(ctx context.Context, args funcArgs, opts posting.ListOpti
 				if i == 0 {
 					span.Annotate(nil, "DoCount")
 				}
-				len := pl.Length(args.q.ReadTs, 0)
+				len := shardLength(pl, args.q.ReadTs, shard)
 				if len == -1 {
 					return posting.ErrTsTooOld
 				}
@@ -597,6 +750,13 @@ func handleUidPostings(ctx context.Context, args funcArgs, opts posting.ListOpti
 				if err != nil {
 					return err
 				}
+				if shard != nil && !empty {
+					n := shardLength(pl, args.q.ReadTs, shard)
+					if n == -1 {
+						return posting.ErrTsTooOld
+					}
+					empty = n == 0
+				}
 				if !empty {
 					tlist := &pb.List{Uids: []uint64{q.UidList.Uids[i]}}
 					out.UidMatrix = append(out.UidMatrix, tlist)
@@ -615,7 +775,7 @@ func handleUidPostings(ctx context.Context, args funcArgs, opts posting.ListOpti
 				if err != nil {
 					return err
 				}
-				if len(plist.Uids) > 0 {
+				if len(plist.Uids) > 0 && shard.has(srcFn.uidPresent) {
 					tlist := &pb.List{Uids: []uint64{q.UidList.Uids[i]}}
 					out.UidMatrix = append(out.UidMatrix, tlist)
 				}
@@ -682,6 +842,9 @@ func processTask(ctx context.Context, q *pb.Query, gid uint32) (*pb.Result, erro
 	if !groups().ServesTablet(q.Attr) {
 		return &emptyResult, errUnservedTablet
 	}
+	if q.Hints != nil {
+		span.Annotatef(nil, "Hints for %q: %+v", q.Attr, *q.Hints)
+	}
 	out, err := helpProcessTask(ctx, q, gid)
 	if err != nil {
 		return &emptyResult, err
@@ -699,11 +862,21 @@ func helpProcessTask(ctx context.Context, q *pb.Query, gid uint32) (*pb.Result,
 		return nil, err
 	}
 
-	if q.Reverse && !schema.State().IsReversed(attr) {
+	if q.Reverse && !schema.Served().IsReversed(attr) {
 		return nil, x.Errorf("Predicate %s doesn't have reverse edge", attr)
 	}
 
-	if needsIndex(srcFn.fnType) && !schema.State().IsIndexed(q.Attr) {
+	var shard *shardRange
+	if tab := groups().knownTablet(attr); tab != nil {
+		shard, _ = tabletShard(tab, gid)
+	}
+	if shard != nil && q.Reverse && srcFn.fnType == CompareScalarFn {
+		// Each group only has the count of the reverse edges from the uids it holds.
+		return nil, x.Errorf("Counts of reverse edges of predicate %s can't be compared, as its"+
+			" tablet is split across groups", attr)
+	}
+
+	if needsIndex(srcFn.fnType) && !schema.Served().IsIndexed(q.Attr) {
 		return nil, x.Errorf("Predicate %s is not indexed", q.Attr)
 	}
 
@@ -732,7 +905,7 @@ func helpProcessTask(ctx context.Context, q *pb.Query, gid uint32) (*pb.Result,
 		opts.Intersect = q.UidList
 	}
 
-	args := funcArgs{q, gid, srcFn, out}
+	args := funcArgs{q, gid, srcFn, out, shard}
 	needsValPostings, err := srcFn.needsValuePostings(typ)
 	if err != nil {
 		return nil, err
@@ -751,14 +924,14 @@ func helpProcessTask(ctx context.Context, q *pb.Query, gid uint32) (*pb.Result,
 
 	if srcFn.fnType == HasFn && srcFn.isFuncAtRoot {
 		span.Annotate(nil, "handleHasFunction")
-		if err := handleHasFunction(ctx, q, out); err != nil {
+		if err := handleHasFunction(ctx, q, out, shard); err != nil {
 			return nil, err
 		}
 	}
 
 	if srcFn.fnType == CompareScalarFn && srcFn.isFuncAtRoot {
 		span.Annotate(nil, "handleCompareScalarFunction")
-		if err := handleCompareScalarFunction(funcArgs{q, gid, srcFn, out}); err != nil {
+		if err := handleCompareScalarFunction(funcArgs{q, gid, srcFn, out, shard}); err != nil {
 			return nil, err
 		}
 	}
@@ -767,7 +940,7 @@ func helpProcessTask(ctx context.Context, q *pb.Query, gid uint32) (*pb.Result,
 		// Go through the indexkeys for the predicate and match them with
 		// the regex matcher.
 		span.Annotate(nil, "handleRegexFunction")
-		if err := handleRegexFunction(ctx, funcArgs{q, gid, srcFn, out}); err != nil {
+		if err := handleRegexFunction(ctx, funcArgs{q, gid, srcFn, out, shard}); err != nil {
 			return nil, err
 		}
 	}
@@ -776,7 +949,7 @@ func helpProcessTask(ctx context.Context, q *pb.Query, gid uint32) (*pb.Result,
 	// request and filter the uids only if the tokenizer IsLossy.
 	if srcFn.fnType == CompareAttrFn && len(srcFn.tokens) > 0 {
 		span.Annotate(nil, "handleCompareFunction")
-		if err := handleCompareFunction(ctx, funcArgs{q, gid, srcFn, out}); err != nil {
+		if err := handleCompareFunction(ctx, funcArgs{q, gid, srcFn, out, shard}); err != nil {
 			return nil, err
 		}
 	}
@@ -784,13 +957,13 @@ func helpProcessTask(ctx context.Context, q *pb.Query, gid uint32) (*pb.Result,
 	// If geo filter, do value check for correctness.
 	if srcFn.geoQuery != nil {
 		span.Annotate(nil, "handleGeoFunction")
-		filterGeoFunction(funcArgs{q, gid, srcFn, out})
+		filterGeoFunction(funcArgs{q, gid, srcFn, out, shard})
 	}
 
 	// For string matching functions, check the language.
 	if needsStringFiltering(srcFn, q.Langs, attr) {
 		span.Annotate(nil, "filterStringFunction")
-		filterStringFunction(funcArgs{q, gid, srcFn, out})
+		filterStringFunction(funcArgs{q, gid, srcFn, out, shard})
 	}
 
 	out.IntersectDest = srcFn.intersectDest
@@ -815,7 +988,7 @@ func needsStringFiltering(srcFn *functionContext, langs []string, attr string) b
 
 func handleCompareScalarFunction(arg funcArgs) error {
 	attr := arg.q.Attr
-	if ok := schema.State().HasCount(attr); !ok {
+	if ok := schema.Served().HasCount(attr); !ok {
 		return x.Errorf("Need @count directive in schema for attr: %s for fn: %s at root",
 			attr, arg.srcFn.fname)
 	}
@@ -827,6 +1000,7 @@ func handleCompareScalarFunction(arg funcArgs) error {
 		gid:     arg.gid,
 		readTs:  arg.q.ReadTs,
 		reverse: arg.q.Reverse,
+		shard:   arg.shard,
 	}
 	return cp.evaluate(arg.out)
 }
@@ -840,7 +1014,7 @@ func handleRegexFunction(ctx context.Context, arg funcArgs) error {
 	if typ != types.StringID {
 		return x.Errorf("Got non-string type. Regex match is allowed only on string type.")
 	}
-	tokenizers := schema.State().TokenizerNames(attr)
+	tokenizers := schema.Served().TokenizerNames(attr)
 	var found bool
 	for _, t := range tokenizers {
 		if t == "trigram" { // TODO(tzdybal) - maybe just rename to 'regex' tokenizer?
@@ -866,6 +1040,9 @@ func handleRegexFunction(ctx context.Context, arg funcArgs) error {
 				return ctx.Err()
 			default:
 			}
+			if !arg.shard.has(uid) {
+				continue
+			}
 			pl, err := posting.Get(x.DataKey(attr, uid))
 			if err != nil {
 				return err
@@ -919,7 +1096,7 @@ func handleRegexFunction(ctx context.Context, arg funcArgs) error {
 
 func handleCompareFunction(ctx context.Context, arg funcArgs) error {
 	attr := arg.q.Attr
-	tokenizer, err := pickTokenizer(attr, arg.srcFn.fname)
+	tokenizer, err := pickTokenizer(attr, arg.srcFn.fname, arg.q.GetHints().GetUseIndex())
 	// We should already have checked this in getInequalityTokens.
 	x.Check(err)
 	// Only if the tokenizer that we used IsLossy, then we need to fetch
@@ -1269,7 +1446,7 @@ func parseSrcFn(q *pb.Query) (*functionContext, error) {
 			}
 			// Get tokens ge / le ineqValueToken.
 			if tokens, fc.ineqValueToken, err = getInequalityTokens(q.ReadTs, attr, f,
-				fc.ineqValue); err != nil {
+				q.GetHints().GetUseIndex(), fc.ineqValue); err != nil {
 				return nil, err
 			}
 			if len(tokens) == 0 {
@@ -1283,8 +1460,9 @@ func parseSrcFn(q *pb.Query) (*functionContext, error) {
 		// directly and compare. Lets make tokens empty.
 		// We don't do this for eq because eq could have multiple arguments and we would have to
 		// compare the value with all of them. Also eq would usually have less arguments, hence we
-		// won't be fetching many index keys.
-		if q.UidList != nil && len(fc.tokens) > len(q.UidList.Uids) && fc.fname != eq {
+		// won't be fetching many index keys. The noValueScan hint forces the index.
+		if q.UidList != nil && len(fc.tokens) > len(q.UidList.Uids) && fc.fname != eq &&
+			!q.GetHints().GetNoValueScan() {
 			fc.tokens = fc.tokens[:0]
 			fc.n = len(q.UidList.Uids)
 		} else {
@@ -1404,6 +1582,10 @@ func (w *grpcWorker) ServeTask(ctx context.Context, q *pb.Query) (*pb.Result, er
 	}
 
 	gid := groups().BelongsTo(q.Attr)
+	if groups().ServesTablet(q.Attr) {
+		// Split tablets are served by all the groups holding a part of them.
+		gid = groups().groupId()
+	}
 	var numUids int
 	if q.UidList != nil {
 		numUids = len(q.UidList.Uids)
@@ -1628,8 +1810,9 @@ type countParams struct {
 	count   int64
 	attr    string
 	gid     uint32
-	reverse bool   // If query is asking for ~pred
-	fn      string // function name
+	reverse bool        // If query is asking for ~pred
+	fn      string      // function name
+	shard   *shardRange // The uids held by this group, if the tablet is split.
 }
 
 func (cp *countParams) evaluate(out *pb.Result) error {
@@ -1664,6 +1847,7 @@ func (cp *countParams) evaluate(out *pb.Result) error {
 		if err != nil {
 			return err
 		}
+		shardUids(uids, cp.shard)
 		out.UidMatrix = append(out.UidMatrix, uids)
 		return nil
 	}
@@ -1701,12 +1885,14 @@ func (cp *countParams) evaluate(out *pb.Result) error {
 		if err != nil {
 			return err
 		}
+		shardUids(uids, cp.shard)
 		out.UidMatrix = append(out.UidMatrix, uids)
 	}
 	return nil
 }
 
-func handleHasFunction(ctx context.Context, q *pb.Query, out *pb.Result) error {
+func handleHasFunction(ctx context.Context, q *pb.Query, out *pb.Result,
+	shard *shardRange) error {
 	span := otrace.FromContext(ctx)
 	if glog.V(3) {
 		glog.Infof("handleHasFunction query: %+v\n", q)
@@ -1719,6 +1905,9 @@ func handleHasFunction(ctx context.Context, q *pb.Query, out *pb.Result) error {
 		Attr: q.Attr,
 	}
 	startKey := x.DataKey(q.Attr, q.AfterUid+1)
+	if shard != nil && shard.start > q.AfterUid+1 {
+		startKey = x.DataKey(q.Attr, shard.start)
+	}
 	prefix := initKey.DataPrefix()
 	if q.Reverse {
 		// Reverse does not mean reverse iteration. It means we're looking for
@@ -1749,10 +1938,15 @@ func handleHasFunction(ctx context.Context, q *pb.Query, out *pb.Result) error {
 		// Parse the key upfront, otherwise ReadPostingList would advance the
 		// iterator.
 		pk := x.Parse(item.Key())
+		if !q.Reverse && !shard.has(pk.Uid) {
+			// Past the uids held by this group of the split tablet.
+			break
+		}
 
 		// The following optimization speeds up this iteration considerably, because it avoids
-		// the need to run ReadPostingList.
-		if item.UserMeta()&posting.BitCompletePosting > 0 {
+		// the need to run ReadPostingList. Reverse lists of split tablets have to be read, to
+		// find if they have postings of the uids held by this group.
+		if item.UserMeta()&posting.BitCompletePosting > 0 && (!q.Reverse || shard == nil) {
 			// This bit would only be set if there are valid uids in UidPack.
 			result.Uids = append(result.Uids, pk.Uid)
 			continue
@@ -1763,9 +1957,15 @@ func handleHasFunction(ctx context.Context, q *pb.Query, out *pb.Result) error {
 		if err != nil {
 			return err
 		}
-		if empty, err := l.IsEmpty(q.ReadTs, 0); err != nil {
-			return err
-		} else if !empty {
+		if !q.Reverse || shard == nil {
+			if empty, err := l.IsEmpty(q.ReadTs, 0); err != nil {
+				return err
+			} else if !empty {
+				result.Uids = append(result.Uids, pk.Uid)
+			}
+		} else if n := shardLength(l, q.ReadTs, shard); n == -1 {
+			return posting.ErrTsTooOld
+		} else if n > 0 {
 			result.Uids = append(result.Uids, pk.Uid)
 		}
 
@@ -0,0 +1,167 @@
+/*
+ * Copyright 2016-2018 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package worker
+
+import (
+	"errors"
+	"net"
+	"sync"
+	"testing"
+
+	"github.com/dgraph-io/dgo/protos/api"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/dgraph-io/dgraph/conn"
+	"github.com/dgraph-io/dgraph/protos/pb"
+	"github.com/dgraph-io/dgraph/x"
+)
+
+func TestIsTransientReadError(t *testing.T) {
+	for _, tc := range []struct {
+		err       error
+		transient bool
+	}{
+		{nil, false},
+		{status.Error(codes.Unavailable, "connection refused"), true},
+		{status.Error(codes.Internal, "boom"), false},
+		{errNoNetworkConnection, true},
+		{x.Wrapf(conn.ErrNoConnection, "dispatchTaskOverNetwork"), true},
+		{conn.ErrUnhealthyConnection, true},
+		// Errors of remote replicas only keep their messages.
+		{status.Error(codes.Unknown, errUnservedTablet.Error()), true},
+		{status.Error(codes.Unknown, "Request sent to wrong server."), true},
+		{status.Error(codes.Unknown, "Predicate name has no index"), false},
+		{context.DeadlineExceeded, false},
+		{errors.New("invalid query"), false},
+	} {
+		require.Equal(t, tc.transient, isTransientReadError(tc.err), "%v", tc.err)
+	}
+}
+
+// fakeReplica answers the tasks with the error returned by serve, if any, or else with reply, or
+// an empty result if it's nil.
+type fakeReplica struct {
+	addr  string
+	srv   *grpc.Server
+	calls int
+	serve func() error
+	reply *pb.Result
+}
+
+func (r *fakeReplica) stop() {
+	conn.Get().Remove(r.addr)
+	r.srv.Stop()
+}
+
+func startFakeReplica(t *testing.T, mu *sync.Mutex, serve func() error) *fakeReplica {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	r := &fakeReplica{addr: ln.Addr().String(), serve: serve}
+	srv := grpc.NewServer(grpc.UnknownServiceHandler(func(_ interface{},
+		stream grpc.ServerStream) error {
+		switch method, _ := grpc.MethodFromServerStream(stream); method {
+		case "/pb.Raft/Echo":
+			// The health checks of the connection.
+			var p api.Payload
+			if err := stream.RecvMsg(&p); err != nil {
+				return err
+			}
+			return stream.SendMsg(&p)
+		case "/pb.Worker/ServeTask":
+		default:
+			return status.Error(codes.Unimplemented, method)
+		}
+		var q pb.Query
+		if err := stream.RecvMsg(&q); err != nil {
+			return err
+		}
+		mu.Lock()
+		r.calls++
+		reply := r.reply
+		mu.Unlock()
+		if err := r.serve(); err != nil {
+			return err
+		}
+		if reply == nil {
+			reply = &pb.Result{}
+		}
+		return stream.SendMsg(reply)
+	}))
+	r.srv = srv
+	go srv.Serve(ln)
+	conn.Get().Connect(r.addr)
+	return r
+}
+
+// setGroupMembers makes addrs the members of group gid.
+func setGroupMembers(gid uint32, addrs ...string) {
+	group := &pb.Group{Members: make(map[uint64]*pb.Member)}
+	for i, addr := range addrs {
+		group.Members[uint64(i+1)] = &pb.Member{Id: uint64(i + 1), GroupId: gid, Addr: addr}
+	}
+	gr.Lock()
+	gr.state = &pb.MembershipState{Groups: map[uint32]*pb.Group{gid: group}}
+	gr.Unlock()
+}
+
+func serveTask(ctx context.Context, c pb.WorkerClient) (interface{}, error) {
+	return c.ServeTask(ctx, &pb.Query{})
+}
+
+func TestProcessWithRetry(t *testing.T) {
+	defer func() {
+		gr.Lock()
+		gr.state = nil
+		gr.Unlock()
+	}()
+	var mu sync.Mutex
+	healthy := startFakeReplica(t, &mu, func() error { return nil })
+	defer healthy.stop()
+	// The first replica fails while the group changes its members, which leaves it out.
+	failing := startFakeReplica(t, &mu, func() error {
+		setGroupMembers(7, healthy.addr)
+		return status.Error(codes.Unknown, errUnservedTablet.Error())
+	})
+	defer failing.stop()
+	setGroupMembers(7, failing.addr)
+
+	retries := x.ReadRetries.Value()
+	_, err := processWithRetry(context.Background(), 7, serveTask)
+	require.NoError(t, err)
+	mu.Lock()
+	require.Equal(t, 1, failing.calls)
+	require.Equal(t, 1, healthy.calls)
+	mu.Unlock()
+	require.Equal(t, retries+1, x.ReadRetries.Value())
+
+	// Other errors aren't retried.
+	broken := startFakeReplica(t, &mu, func() error {
+		return status.Error(codes.Internal, "boom")
+	})
+	defer broken.stop()
+	setGroupMembers(7, broken.addr)
+	_, err = processWithRetry(context.Background(), 7, serveTask)
+	require.Error(t, err)
+	mu.Lock()
+	require.Equal(t, 1, broken.calls)
+	mu.Unlock()
+	require.Equal(t, retries+1, x.ReadRetries.Value())
+}
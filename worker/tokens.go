@@ -38,11 +38,11 @@ func verifyStringIndex(attr string, funcType FuncType) (string, bool) {
 		requiredTokenizer = tok.TermTokenizer{}.Name()
 	}
 
-	if !schema.State().IsIndexed(attr) {
+	if !schema.Served().IsIndexed(attr) {
 		return requiredTokenizer, false
 	}
 
-	tokenizers := schema.State().Tokenizer(attr)
+	tokenizers := schema.Served().Tokenizer(attr)
 	for _, tokenizer := range tokenizers {
 		// check for prefix, in case of explicit usage of language specific full text tokenizer
 		if strings.HasPrefix(tokenizer.Name(), requiredTokenizer) {
@@ -54,10 +54,10 @@ func verifyStringIndex(attr string, funcType FuncType) (string, bool) {
 }
 
 func verifyCustomIndex(attr string, tokenizerName string) bool {
-	if !schema.State().IsIndexed(attr) {
+	if !schema.Served().IsIndexed(attr) {
 		return false
 	}
-	for _, tn := range schema.State().TokenizerNames(attr) {
+	for _, tn := range schema.Served().TokenizerNames(attr) {
 		if tn == tokenizerName {
 			return true
 		}
@@ -79,13 +79,28 @@ func getStringTokens(funcArgs []string, lang string, funcType FuncType) ([]strin
 	}
 }
 
-func pickTokenizer(attr string, f string) (tok.Tokenizer, error) {
+// pickTokenizer picks the tokenizer of attr to use for function f. If useIndex is set, the
+// tokenizer with that name is used instead.
+func pickTokenizer(attr, f, useIndex string) (tok.Tokenizer, error) {
 	// Get the tokenizers and choose the corresponding one.
-	if !schema.State().IsIndexed(attr) {
+	if !schema.Served().IsIndexed(attr) {
 		return nil, x.Errorf("Attribute %s is not indexed.", attr)
 	}
 
-	tokenizers := schema.State().Tokenizer(attr)
+	tokenizers := schema.Served().Tokenizer(attr)
+	if len(useIndex) > 0 {
+		for _, t := range tokenizers {
+			if t.Name() != useIndex {
+				continue
+			}
+			if f != "eq" && !t.IsSortable() {
+				return nil, x.Errorf("Tokenizer %s of attribute %s can't be used for comparison",
+					useIndex, attr)
+			}
+			return t, nil
+		}
+		return nil, x.Errorf("Attribute %s is not indexed with tokenizer %s", attr, useIndex)
+	}
 
 	var tokenizer tok.Tokenizer
 	for _, t := range tokenizers {
@@ -120,9 +135,9 @@ func pickTokenizer(attr string, f string) (tok.Tokenizer, error) {
 
 // getInequalityTokens gets tokens ge / le compared to given token using the first sortable
 // index that is found for the predicate.
-func getInequalityTokens(readTs uint64, attr, f string,
+func getInequalityTokens(readTs uint64, attr, f, useIndex string,
 	ineqValue types.Val) ([]string, string, error) {
-	tokenizer, err := pickTokenizer(attr, f)
+	tokenizer, err := pickTokenizer(attr, f, useIndex)
 	if err != nil {
 		return nil, "", err
 	}
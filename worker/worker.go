@@ -31,6 +31,7 @@ import (
 	"github.com/dgraph-io/dgraph/x"
 
 	"github.com/golang/glog"
+	"go.opencensus.io/plugin/ocgrpc"
 	"google.golang.org/grpc"
 )
 
@@ -53,10 +54,13 @@ func Init(ps *badger.DB) {
 	pstore = ps
 	// needs to be initialized after group config
 	pendingProposals = make(chan struct{}, Config.NumPendingProposals)
-	workerServer = grpc.NewServer(
+	opts := []grpc.ServerOption{
 		grpc.MaxRecvMsgSize(x.GrpcMaxSize),
 		grpc.MaxSendMsgSize(x.GrpcMaxSize),
-		grpc.MaxConcurrentStreams(math.MaxInt32))
+		grpc.MaxConcurrentStreams(math.MaxInt32),
+		grpc.StatsHandler(&ocgrpc.ServerHandler{}),
+	}
+	workerServer = grpc.NewServer(append(opts, conn.ServerOptions()...)...)
 }
 
 // grpcWorker struct implements the gRPC server interface.
@@ -621,6 +621,7 @@ func TestMain(m *testing.M) {
 	x.Check(err)
 	pstore = ps
 	posting.Init(ps)
+	schema.Init(ps)
 	Init(ps)
 	os.Exit(m.Run())
 }
@@ -0,0 +1,112 @@
+/*
+ * Copyright 2018 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package x
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"net/http"
+	"strings"
+
+	"github.com/spf13/pflag"
+	"github.com/spf13/viper"
+)
+
+// AuthTokenHeader is the header of the HTTP requests carrying their auth token.
+const AuthTokenHeader = "X-Dgraph-AuthToken"
+
+// RegisterAdminFlags registers the flags securing the admin endpoints, for Alphas and Zeros alike.
+func RegisterAdminFlags(flag *pflag.FlagSet) {
+	flag.String("admin_token", "", "If set, the requests to the admin endpoints must have this"+
+		" token in the "+AuthTokenHeader+" header.")
+	flag.String("admin_tokens", "", "Comma separated tokens of single admin endpoints, like"+
+		" /admin/export=<token>,/moveTablet=<token>, taking precedence over --admin_token.")
+	flag.String("admin_addr", "", "If set, the admin endpoints are served at this host:port"+
+		" only, instead of along with the other HTTP endpoints.")
+}
+
+// AdminAuth checks the auth tokens of the requests to admin endpoints. Every endpoint takes its
+// own token, if it has one, or the shared token. Endpoints without a token are open.
+type AdminAuth struct {
+	token  string
+	tokens map[string]string
+}
+
+// NewAdminAuth returns the AdminAuth with the shared token, and the tokens of single endpoints as
+// a comma separated list of <path>=<token>.
+func NewAdminAuth(token, tokens string) (*AdminAuth, error) {
+	a := &AdminAuth{token: token, tokens: make(map[string]string)}
+	for _, kv := range strings.Split(tokens, ",") {
+		kv = strings.TrimSpace(kv)
+		if len(kv) == 0 {
+			continue
+		}
+		parts := strings.SplitN(kv, "=", 2)
+		if len(parts) != 2 || !strings.HasPrefix(parts[0], "/") || len(parts[1]) == 0 {
+			return nil, Errorf("Invalid admin token %q. Use <path>=<token>.", kv)
+		}
+		a.tokens[parts[0]] = parts[1]
+	}
+	return a, nil
+}
+
+// AdminAuthFromConfig returns the AdminAuth set up by the flags registered with
+// RegisterAdminFlags.
+func AdminAuthFromConfig(v *viper.Viper) (*AdminAuth, error) {
+	return NewAdminAuth(v.GetString("admin_token"), v.GetString("admin_tokens"))
+}
+
+// Token returns the token of the endpoint at path, or the empty string if it's open.
+func (a *AdminAuth) Token(path string) string {
+	if a == nil {
+		return ""
+	}
+	if token, ok := a.tokens[path]; ok {
+		return token
+	}
+	return a.token
+}
+
+// Handler returns h, only passing it the requests with the token of their endpoint.
+func (a *AdminAuth) Handler(h http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		want := a.Token(r.URL.Path)
+		if len(want) == 0 {
+			h(w, r)
+			return
+		}
+		got := r.Header.Get(AuthTokenHeader)
+		if len(got) == 0 || !TokenMatches(got, want) {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusUnauthorized)
+			msg := "Invalid auth token."
+			if len(got) == 0 {
+				msg = "No auth token found in the " + AuthTokenHeader + " header."
+			}
+			SetStatus(w, ErrorUnauthorized, msg)
+			return
+		}
+		h(w, r)
+	}
+}
+
+// TokenMatches tells whether got is the token want, in constant time. Comparing the hashes keeps
+// the length of want from leaking too.
+func TokenMatches(got, want string) bool {
+	g, w := sha256.Sum256([]byte(got)), sha256.Sum256([]byte(want))
+	return subtle.ConstantTimeCompare(g[:], w[:]) == 1
+}
@@ -0,0 +1,80 @@
+/*
+ * Copyright 2018 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package x
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAdminAuth(t *testing.T) {
+	a, err := NewAdminAuth("shared", "/admin/export=export, /moveTablet=move")
+	require.NoError(t, err)
+	require.Equal(t, "export", a.Token("/admin/export"))
+	require.Equal(t, "shared", a.Token("/admin/shutdown"))
+
+	h := a.Handler(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("OK"))
+	})
+	tests := []struct {
+		path, token string
+		status      int
+	}{
+		{"/admin/export", "export", http.StatusOK},
+		{"/admin/export", "shared", http.StatusUnauthorized},
+		{"/admin/shutdown", "shared", http.StatusOK},
+		{"/admin/shutdown", "", http.StatusUnauthorized},
+		{"/moveTablet", "mov", http.StatusUnauthorized},
+		{"/moveTablet", "move", http.StatusOK},
+	}
+	for _, tc := range tests {
+		req := httptest.NewRequest("GET", tc.path, nil)
+		if len(tc.token) > 0 {
+			req.Header.Set(AuthTokenHeader, tc.token)
+		}
+		rec := httptest.NewRecorder()
+		h(rec, req)
+		require.Equal(t, tc.status, rec.Code, "%+v", tc)
+		if tc.status == http.StatusUnauthorized {
+			require.Contains(t, rec.Body.String(), ErrorUnauthorized)
+		}
+	}
+
+	// Without tokens, the endpoints are open.
+	a, err = NewAdminAuth("", "")
+	require.NoError(t, err)
+	rec := httptest.NewRecorder()
+	a.Handler(func(w http.ResponseWriter, r *http.Request) {})(rec,
+		httptest.NewRequest("GET", "/admin/export", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+}
+
+func TestNewAdminAuthErrors(t *testing.T) {
+	for _, tokens := range []string{"/admin/export", "admin/export=x", "/admin/export="} {
+		_, err := NewAdminAuth("", tokens)
+		require.Error(t, err, tokens)
+	}
+}
+
+func TestTokenMatches(t *testing.T) {
+	require.True(t, TokenMatches("secret", "secret"))
+	require.False(t, TokenMatches("secret", "secre"))
+	require.False(t, TokenMatches("", "secret"))
+}
@@ -0,0 +1,339 @@
+/*
+ * Copyright 2018 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package x
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/binary"
+	"expvar"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Encodings the HTTP responses and the gRPC messages can be compressed with. Snappy is cheaper to
+// compress with than gzip, at the cost of a lower ratio. It uses the snappy block format.
+const (
+	GzipEncoding   = "gzip"
+	SnappyEncoding = "snappy"
+)
+
+// ValidEncoding tells whether enc can be used as the compressor of RPCs. Empty means none.
+func ValidEncoding(enc string) bool {
+	return enc == "" || enc == GzipEncoding || enc == SnappyEncoding
+}
+
+// Compress compresses data with the given encoding.
+func Compress(enc string, data []byte) ([]byte, error) {
+	switch enc {
+	case GzipEncoding:
+		var buf bytes.Buffer
+		gw, err := gzip.NewWriterLevel(&buf, gzip.BestSpeed)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := gw.Write(data); err != nil {
+			return nil, err
+		}
+		if err := gw.Close(); err != nil {
+			return nil, err
+		}
+		return buf.Bytes(), nil
+	case SnappyEncoding:
+		return SnappyEncode(data), nil
+	}
+	return nil, Errorf("Unknown encoding: %q", enc)
+}
+
+// Decompress returns a reader of the data read from r, compressed with the given encoding.
+func Decompress(enc string, r io.Reader) (io.Reader, error) {
+	switch enc {
+	case GzipEncoding:
+		return gzip.NewReader(r)
+	case SnappyEncoding:
+		data, err := ioutil.ReadAll(r)
+		if err != nil {
+			return nil, err
+		}
+		data, err = SnappyDecode(data)
+		if err != nil {
+			return nil, err
+		}
+		return bytes.NewReader(data), nil
+	}
+	return nil, Errorf("Unknown encoding: %q", enc)
+}
+
+var compressionStats sync.Mutex
+
+// RecordCompression adds the sizes of data before and after compression to the metrics of the
+// given transport, like http or grpc, and encoding.
+func RecordCompression(transport, enc string, in, out int) {
+	compressionStats.Lock()
+	defer compressionStats.Unlock()
+	for _, m := range []struct {
+		stats *expvar.Map
+		value int
+	}{{CompressionInput, in}, {CompressionSaved, in - out}} {
+		byEnc, ok := m.stats.Get(transport).(*expvar.Map)
+		if !ok {
+			byEnc = new(expvar.Map).Init()
+			m.stats.Set(transport, byEnc)
+		}
+		byEnc.Add(enc, int64(m.value))
+	}
+}
+
+// AcceptedEncoding returns the encoding to compress a response with, given the Accept-Encoding
+// header of the request. Empty means none. gzip is preferred, as all HTTP clients support it.
+func AcceptedEncoding(header string) string {
+	accepted := make(map[string]bool)
+	for _, part := range strings.Split(header, ",") {
+		fields := strings.Split(part, ";")
+		enc := strings.ToLower(strings.TrimSpace(fields[0]))
+		ok := true
+		for _, param := range fields[1:] {
+			param = strings.TrimSpace(param)
+			if strings.HasPrefix(param, "q=") {
+				q, err := strconv.ParseFloat(param[2:], 64)
+				ok = err == nil && q > 0
+			}
+		}
+		accepted[enc] = ok
+	}
+	for _, enc := range []string{GzipEncoding, SnappyEncoding} {
+		if accepted[enc] {
+			return enc
+		}
+	}
+	return ""
+}
+
+type bufferedResponse struct {
+	http.ResponseWriter
+	buf     bytes.Buffer
+	status  int
+	flushed bool
+}
+
+func (b *bufferedResponse) WriteHeader(status int) {
+	if b.status == 0 {
+		b.status = status
+	}
+}
+
+func (b *bufferedResponse) Write(data []byte) (int, error) {
+	if b.flushed {
+		return b.ResponseWriter.Write(data)
+	}
+	return b.buf.Write(data)
+}
+
+// Flush sends what was written so far uncompressed, and the rest of the response as it's written,
+// for handlers streaming their responses.
+func (b *bufferedResponse) Flush() {
+	if !b.flushed {
+		b.flushed = true
+		if b.status == 0 {
+			b.status = http.StatusOK
+		}
+		b.ResponseWriter.WriteHeader(b.status)
+		b.ResponseWriter.Write(b.buf.Bytes())
+		b.buf = bytes.Buffer{}
+	}
+	if f, ok := b.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+// CompressHandler compresses the responses of h of at least minSize bytes, with the encoding
+// asked for by the client. The responses are held in memory until h is done, unless it flushes
+// them, which sends them uncompressed. A negative minSize disables compression.
+func CompressHandler(minSize int, h http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		enc := AcceptedEncoding(r.Header.Get("Accept-Encoding"))
+		if minSize < 0 || enc == "" {
+			h(w, r)
+			return
+		}
+		b := &bufferedResponse{ResponseWriter: w}
+		h(b, r)
+		if b.flushed {
+			return
+		}
+		if b.status == 0 {
+			b.status = http.StatusOK
+		}
+		w.Header().Add("Vary", "Accept-Encoding")
+
+		body := b.buf.Bytes()
+		if len(body) >= minSize && w.Header().Get("Content-Encoding") == "" {
+			if out, err := Compress(enc, body); err == nil && len(out) < len(body) {
+				RecordCompression("http", enc, len(body), len(out))
+				w.Header().Set("Content-Encoding", enc)
+				w.Header().Del("Content-Length")
+				body = out
+			}
+		}
+		w.WriteHeader(b.status)
+		w.Write(body)
+	}
+}
+
+const snappyTableBits = 14
+
+// SnappyEncode compresses src in the snappy block format.
+func SnappyEncode(src []byte) []byte {
+	dst := appendVarint(nil, uint64(len(src)))
+	load := func(i int) uint32 { return binary.LittleEndian.Uint32(src[i:]) }
+	hash := func(u uint32) uint32 { return (u * 0x1e35a7bd) >> (32 - snappyTableBits) }
+
+	// table holds the last position, plus one, of every hash of four bytes.
+	table := make([]int, 1<<snappyTableBits)
+	lit := 0
+	for i := 0; i+4 <= len(src); {
+		h := hash(load(i))
+		cand := table[h] - 1
+		table[h] = i + 1
+		if cand < 0 || i-cand > 1<<16-1 || load(cand) != load(i) {
+			// Skip faster over data which doesn't compress.
+			i += 1 + (i-lit)>>5
+			continue
+		}
+		n := 4
+		for i+n < len(src) && src[cand+n] == src[i+n] {
+			n++
+		}
+		dst = appendSnappyLiteral(dst, src[lit:i])
+		dst = appendSnappyCopy(dst, i-cand, n)
+		i += n
+		lit = i
+	}
+	return appendSnappyLiteral(dst, src[lit:])
+}
+
+func appendSnappyLiteral(dst, lit []byte) []byte {
+	if len(lit) == 0 {
+		return dst
+	}
+	n := uint64(len(lit) - 1)
+	switch {
+	case n < 60:
+		dst = append(dst, byte(n)<<2)
+	case n < 1<<8:
+		dst = append(dst, 60<<2, byte(n))
+	case n < 1<<16:
+		dst = append(dst, 61<<2, byte(n), byte(n>>8))
+	case n < 1<<24:
+		dst = append(dst, 62<<2, byte(n), byte(n>>8), byte(n>>16))
+	default:
+		dst = append(dst, 63<<2, byte(n), byte(n>>8), byte(n>>16), byte(n>>24))
+	}
+	return append(dst, lit...)
+}
+
+// appendSnappyCopy appends copies of n bytes from offset bytes back, using copies with a two
+// byte offset, which can copy up to 64 bytes at a time.
+func appendSnappyCopy(dst []byte, offset, n int) []byte {
+	for n > 0 {
+		l := n
+		if l > 64 {
+			l = 64
+		}
+		dst = append(dst, byte(l-1)<<2|2, byte(offset), byte(offset>>8))
+		n -= l
+	}
+	return dst
+}
+
+// SnappyDecode decompresses src, in the snappy block format.
+func SnappyDecode(src []byte) ([]byte, error) {
+	errCorrupt := Errorf("Corrupt snappy data")
+	size, k := binary.Uvarint(src)
+	if k <= 0 || size > 1<<32-1 {
+		return nil, errCorrupt
+	}
+	// Don't trust the size to allocate, it could be corrupt.
+	capacity := size
+	if max := 8 * uint64(len(src)); capacity > max {
+		capacity = max
+	}
+	dst := make([]byte, 0, capacity)
+	for s := k; s < len(src); {
+		tag := src[s]
+		var length, offset int
+		switch tag & 3 {
+		case 0:
+			length = int(tag >> 2)
+			s++
+			if length >= 60 {
+				nb := length - 59
+				if s+nb > len(src) {
+					return nil, errCorrupt
+				}
+				length = 0
+				for j := 0; j < nb; j++ {
+					length |= int(src[s+j]) << (8 * uint(j))
+				}
+				s += nb
+			}
+			length++
+			if length > len(src)-s || uint64(len(dst)+length) > size {
+				return nil, errCorrupt
+			}
+			dst = append(dst, src[s:s+length]...)
+			s += length
+			continue
+		case 1:
+			if s+2 > len(src) {
+				return nil, errCorrupt
+			}
+			length = 4 + int(tag>>2&7)
+			offset = int(tag>>5)<<8 | int(src[s+1])
+			s += 2
+		case 2:
+			if s+3 > len(src) {
+				return nil, errCorrupt
+			}
+			length = 1 + int(tag>>2)
+			offset = int(binary.LittleEndian.Uint16(src[s+1:]))
+			s += 3
+		case 3:
+			if s+5 > len(src) {
+				return nil, errCorrupt
+			}
+			length = 1 + int(tag>>2)
+			offset = int(binary.LittleEndian.Uint32(src[s+1:]))
+			s += 5
+		}
+		if offset <= 0 || offset > len(dst) || uint64(len(dst)+length) > size {
+			return nil, errCorrupt
+		}
+		// Copies can overlap the bytes they produce, so copy one byte at a time.
+		for j := 0; j < length; j++ {
+			dst = append(dst, dst[len(dst)-offset])
+		}
+	}
+	if uint64(len(dst)) != size {
+		return nil, errCorrupt
+	}
+	return dst, nil
+}
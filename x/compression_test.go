@@ -0,0 +1,146 @@
+/*
+ * Copyright 2018 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package x
+
+import (
+	"bytes"
+	"expvar"
+	"io/ioutil"
+	"math/rand"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSnappy(t *testing.T) {
+	random := make([]byte, 100000)
+	rand.Read(random)
+	for _, data := range [][]byte{
+		nil,
+		[]byte("abc"),
+		[]byte(strings.Repeat(`{"name": "Alice", "age": 26}`, 1000)),
+		random,
+		append(random[:1000:1000], bytes.Repeat([]byte{'a'}, 70000)...),
+	} {
+		enc := SnappyEncode(data)
+		dec, err := SnappyDecode(enc)
+		require.NoError(t, err)
+		require.Equal(t, len(data), len(dec))
+		require.True(t, bytes.Equal(data, dec))
+	}
+	enc := SnappyEncode([]byte(strings.Repeat("abcd", 1000)))
+	require.True(t, len(enc) < 500, "%d", len(enc))
+
+	// Copies with one and two byte offsets, and out of bounds ones.
+	dec, err := SnappyDecode([]byte{9, 2 << 2, 'a', 'b', 'c', 2<<2 | 1, 3})
+	require.NoError(t, err)
+	require.Equal(t, "abcabcabc", string(dec))
+	dec, err = SnappyDecode([]byte{9, 2 << 2, 'a', 'b', 'c', 5<<2 | 2, 3, 0})
+	require.NoError(t, err)
+	require.Equal(t, "abcabcabc", string(dec))
+	_, err = SnappyDecode([]byte{9, 2 << 2, 'a', 'b', 'c', 5<<2 | 2, 4, 0})
+	require.Error(t, err)
+	_, err = SnappyDecode([]byte{4, 2 << 2, 'a', 'b', 'c'})
+	require.Error(t, err)
+}
+
+func TestCompress(t *testing.T) {
+	data := []byte(strings.Repeat("dgraph ", 100))
+	for _, enc := range []string{GzipEncoding, SnappyEncoding} {
+		out, err := Compress(enc, data)
+		require.NoError(t, err)
+		require.True(t, len(out) < len(data))
+		r, err := Decompress(enc, bytes.NewReader(out))
+		require.NoError(t, err)
+		dec, err := ioutil.ReadAll(r)
+		require.NoError(t, err)
+		require.Equal(t, data, dec)
+	}
+	_, err := Compress("lz4", data)
+	require.Error(t, err)
+}
+
+func TestAcceptedEncoding(t *testing.T) {
+	require.Equal(t, "", AcceptedEncoding(""))
+	require.Equal(t, "gzip", AcceptedEncoding("gzip, deflate, br"))
+	require.Equal(t, "gzip", AcceptedEncoding("snappy, GZIP"))
+	require.Equal(t, "snappy", AcceptedEncoding("gzip;q=0, snappy;q=0.5"))
+	require.Equal(t, "", AcceptedEncoding("deflate"))
+}
+
+func TestCompressHandler(t *testing.T) {
+	body := strings.Repeat(`{"uid": "0x1"}`, 100)
+	h := CompressHandler(100, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusAccepted)
+		w.Write([]byte(body[:len(body)/2]))
+		w.Write([]byte(body[len(body)/2:]))
+	})
+	saved := func() int64 {
+		v, ok := CompressionSaved.Get("http").(*expvar.Map)
+		if !ok || v.Get(GzipEncoding) == nil {
+			return 0
+		}
+		return v.Get(GzipEncoding).(*expvar.Int).Value()
+	}
+	before := saved()
+
+	req := httptest.NewRequest(http.MethodPost, "/query", nil)
+	req.Header.Set("Accept-Encoding", "gzip")
+	rec := httptest.NewRecorder()
+	h(rec, req)
+	require.Equal(t, http.StatusAccepted, rec.Code)
+	require.Equal(t, "gzip", rec.Header().Get("Content-Encoding"))
+	r, err := Decompress(GzipEncoding, rec.Body)
+	require.NoError(t, err)
+	dec, err := ioutil.ReadAll(r)
+	require.NoError(t, err)
+	require.Equal(t, body, string(dec))
+	require.True(t, saved() > before)
+
+	// Not asked for.
+	rec = httptest.NewRecorder()
+	h(rec, httptest.NewRequest(http.MethodPost, "/query", nil))
+	require.Equal(t, "", rec.Header().Get("Content-Encoding"))
+	require.Equal(t, body, rec.Body.String())
+
+	// Too small.
+	h = CompressHandler(1<<20, func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(body))
+	})
+	rec = httptest.NewRecorder()
+	h(rec, req)
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Equal(t, "", rec.Header().Get("Content-Encoding"))
+	require.Equal(t, body, rec.Body.String())
+
+	// Flushed, as streamed.
+	h = CompressHandler(100, func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(body[:len(body)/2]))
+		w.(http.Flusher).Flush()
+		w.Write([]byte(body[len(body)/2:]))
+	})
+	rec = httptest.NewRecorder()
+	h(rec, req)
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.True(t, rec.Flushed)
+	require.Equal(t, "", rec.Header().Get("Content-Encoding"))
+	require.Equal(t, body, rec.Body.String())
+}
@@ -20,6 +20,8 @@ type Options struct {
 	DebugMode      bool
 	PortOffset     int
 	QueryEdgeLimit uint64
+	// Compression is the encoding the RPCs to other nodes are compressed with, if any.
+	Compression string
 }
 
 var Config Options
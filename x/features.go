@@ -0,0 +1,81 @@
+/*
+ * Copyright 2018 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package x
+
+import "github.com/dgraph-io/dgraph/protos/pb"
+
+// APIVersion is the version of the API served to clients. It's only raised for changes clients
+// can't find out about from the features.
+const APIVersion = 1
+
+// The features of the API clients can negotiate, as they're missing from older versions.
+const (
+	FeatureUpsert     = "upsert"      // Upsert templates, and Batch.RunTxn.
+	FeatureBestEffort = "best-effort" // Best-effort queries.
+	FeatureAsOf       = "as-of"       // Queries as of a past timestamp.
+	FeatureNamespaces = "namespaces"  // Requests against the namespaces of tenants.
+	FeatureCursors    = "cursors"     // Pagination with after: <cursor>.
+	FeatureTTL        = "ttl"         // The @ttl directive of the schema.
+	FeatureStream     = "stream"      // Streamed query responses, and pb.Stream.
+)
+
+// Features are those of this version, which Alphas advertise to Zero as members.
+var Features = []string{FeatureUpsert, FeatureBestEffort, FeatureAsOf, FeatureNamespaces,
+	FeatureCursors, FeatureTTL, FeatureStream}
+
+func hasFeature(features []string, f string) bool {
+	for _, ff := range features {
+		if ff == f {
+			return true
+		}
+	}
+	return false
+}
+
+// ClusterFeatures returns the features of this version that every Alpha of the cluster has. While
+// a cluster is upgraded, Alphas of older versions don't have the features added since, so they
+// are only used by clients once all Alphas have them.
+func ClusterFeatures(state *pb.MembershipState) []string {
+	var res []string
+	for _, f := range Features {
+		has := true
+		for _, group := range state.GetGroups() {
+			for _, m := range group.GetMembers() {
+				has = has && hasFeature(m.GetFeatures(), f)
+			}
+		}
+		if has {
+			res = append(res, f)
+		}
+	}
+	return res
+}
+
+// Negotiate returns the version and features of the API the client can use, given the features
+// of the cluster.
+func Negotiate(req *pb.NegotiateRequest, features []string) *pb.NegotiateResponse {
+	resp := &pb.NegotiateResponse{ApiVersion: APIVersion, ServerVersion: Version()}
+	if req.ApiVersion > 0 && req.ApiVersion < APIVersion {
+		resp.ApiVersion = req.ApiVersion
+	}
+	for _, f := range features {
+		if len(req.Features) == 0 || hasFeature(req.Features, f) {
+			resp.Features = append(resp.Features, f)
+		}
+	}
+	return resp
+}
@@ -0,0 +1,57 @@
+/*
+ * Copyright 2018 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package x
+
+import (
+	"testing"
+
+	"github.com/dgraph-io/dgraph/protos/pb"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClusterFeatures(t *testing.T) {
+	state := &pb.MembershipState{Groups: map[uint32]*pb.Group{
+		1: {Members: map[uint64]*pb.Member{
+			1: {Id: 1, Features: Features},
+			2: {Id: 2, Features: []string{FeatureUpsert, FeatureTTL, "future"}},
+		}},
+		2: {Members: map[uint64]*pb.Member{
+			3: {Id: 3, Features: []string{FeatureTTL, FeatureUpsert, FeatureCursors}},
+		}},
+	}}
+	require.Equal(t, []string{FeatureUpsert, FeatureTTL}, ClusterFeatures(state))
+
+	// An Alpha of a version before features were negotiated has none of them.
+	state.Groups[2].Members[4] = &pb.Member{Id: 4}
+	require.Empty(t, ClusterFeatures(state))
+
+	require.Equal(t, Features, ClusterFeatures(&pb.MembershipState{}))
+}
+
+func TestNegotiate(t *testing.T) {
+	features := []string{FeatureUpsert, FeatureBestEffort}
+
+	resp := Negotiate(&pb.NegotiateRequest{}, features)
+	require.Equal(t, uint32(APIVersion), resp.ApiVersion)
+	require.Equal(t, Version(), resp.ServerVersion)
+	require.Equal(t, features, resp.Features)
+
+	resp = Negotiate(&pb.NegotiateRequest{ApiVersion: APIVersion + 1,
+		Features: []string{FeatureBestEffort, FeatureAsOf, "unknown"}}, features)
+	require.Equal(t, uint32(APIVersion), resp.ApiVersion)
+	require.Equal(t, []string{FeatureBestEffort}, resp.Features)
+}
@@ -17,6 +17,7 @@
 package x
 
 import (
+	"io/ioutil"
 	"os"
 )
 
@@ -37,3 +38,18 @@ func WriteFileSync(filename string, data []byte, perm os.FileMode) error {
 	}
 	return nil
 }
+
+// shmDir is the directory of the shared memory of Linux, whose files are kept in memory.
+var shmDir = "/dev/shm"
+
+// MemoryDir creates a temporary directory whose files are kept in memory, in /dev/shm. Without
+// /dev/shm, it's created in the default directory for temporary files, on disk, and inMemory is
+// false.
+func MemoryDir(prefix string) (dir string, inMemory bool, err error) {
+	if fi, err := os.Stat(shmDir); err == nil && fi.IsDir() {
+		dir, err = ioutil.TempDir(shmDir, prefix)
+		return dir, err == nil, err
+	}
+	dir, err = ioutil.TempDir("", prefix)
+	return dir, false, err
+}
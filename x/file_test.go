@@ -0,0 +1,47 @@
+/*
+ * Copyright 2016-2018 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package x
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemoryDir(t *testing.T) {
+	shm, err := ioutil.TempDir("", "shm")
+	require.NoError(t, err)
+	defer os.RemoveAll(shm)
+	defer func(dir string) { shmDir = dir }(shmDir)
+
+	shmDir = shm
+	dir, inMemory, err := MemoryDir("mem")
+	require.NoError(t, err)
+	require.True(t, inMemory)
+	require.Equal(t, shm, filepath.Dir(dir))
+
+	// Without /dev/shm, the directory is on disk, and said so.
+	shmDir = filepath.Join(shm, "missing")
+	dir, inMemory, err = MemoryDir("mem")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	require.False(t, inMemory)
+	require.Equal(t, filepath.Clean(os.TempDir()), filepath.Dir(dir))
+}
@@ -0,0 +1,90 @@
+/*
+ * Copyright 2017-2018 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package x
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// The versions of the on-disk formats of the directories this release writes. A release that
+// changes one bumps it, so that the older data has to be exported and reloaded instead of being
+// misread. Directories written before the versions were recorded have version 1.
+const (
+	// PostingFormat is the version of the format of the posting directories of Alpha.
+	PostingFormat = 1
+	// WALFormat is the version of the format of the WAL directories of Alpha and Zero.
+	WALFormat = 1
+)
+
+// FormatFile is the file recording the version of the format of a directory, in it.
+const FormatFile = "DGRAPH_FORMAT"
+
+// ReadFormat returns the version of the format of the data in dir, and whether it's recorded in
+// dir. It returns zero if dir holds no data.
+func ReadFormat(dir string) (int, bool, error) {
+	data, err := ioutil.ReadFile(filepath.Join(dir, FormatFile))
+	switch {
+	case err == nil:
+		version, err := strconv.Atoi(strings.TrimSpace(string(data)))
+		if err != nil || version <= 0 {
+			return 0, false, Errorf("Invalid format %q in %s", data, filepath.Join(dir, FormatFile))
+		}
+		return version, true, nil
+	case !os.IsNotExist(err):
+		return 0, false, err
+	}
+
+	files, err := ioutil.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return 0, false, nil
+	}
+	if err != nil {
+		return 0, false, err
+	}
+	if len(files) == 0 {
+		return 0, false, nil
+	}
+	return 1, false, nil
+}
+
+// WriteFormat records the version of the format of the data in dir.
+func WriteFormat(dir string, version int) error {
+	return WriteFileSync(filepath.Join(dir, FormatFile), []byte(strconv.Itoa(version)+"\n"), 0600)
+}
+
+// CheckFormat checks that the data in dir has the given version of its format, as written by this
+// release, and records it if it isn't yet.
+func CheckFormat(dir string, version int) error {
+	cur, recorded, err := ReadFormat(dir)
+	switch {
+	case err != nil:
+		return err
+	case cur > version:
+		return Errorf("The data in %s has format %d, written by a newer release than this one,"+
+			" which reads format %d", dir, cur, version)
+	case cur > 0 && cur < version:
+		return Errorf("The data in %s has format %d, older than format %d of this release."+
+			" Export it with the release which wrote it, and reload the export", dir, cur, version)
+	case !recorded:
+		return WriteFormat(dir, version)
+	}
+	return nil
+}
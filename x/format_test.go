@@ -0,0 +1,63 @@
+/*
+ * Copyright 2017-2018 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package x
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckFormat(t *testing.T) {
+	dir, err := ioutil.TempDir("", "format")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	// A new directory gets the version of this release.
+	version, recorded, err := ReadFormat(dir)
+	require.NoError(t, err)
+	require.Equal(t, 0, version)
+	require.False(t, recorded)
+	require.NoError(t, CheckFormat(dir, 2))
+	version, recorded, err = ReadFormat(dir)
+	require.NoError(t, err)
+	require.Equal(t, 2, version)
+	require.True(t, recorded)
+	require.NoError(t, CheckFormat(dir, 2))
+	require.Error(t, CheckFormat(dir, 1))
+	require.Error(t, CheckFormat(dir, 3))
+
+	// Data written before the versions were recorded has version 1.
+	require.NoError(t, os.Remove(filepath.Join(dir, FormatFile)))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "000001.vlog"), nil, 0600))
+	version, recorded, err = ReadFormat(dir)
+	require.NoError(t, err)
+	require.Equal(t, 1, version)
+	require.False(t, recorded)
+	require.Error(t, CheckFormat(dir, 2))
+	require.NoError(t, CheckFormat(dir, 1))
+	_, recorded, err = ReadFormat(dir)
+	require.NoError(t, err)
+	require.True(t, recorded)
+
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, FormatFile), []byte("x\n"), 0600))
+	_, _, err = ReadFormat(dir)
+	require.Error(t, err)
+}
@@ -250,6 +250,17 @@ func PredicatePrefix(predicate string) []byte {
 	return buf
 }
 
+// RenameAttr returns a copy of the key, for the given attribute instead of its own.
+func RenameAttr(key []byte, attr string) []byte {
+	sz := int(binary.BigEndian.Uint16(key[1:3]))
+	rest := key[3+sz:]
+	buf := make([]byte, 1+2+len(attr)+len(rest))
+	buf[0] = key[0]
+	k := writeAttr(buf[1:], attr)
+	AssertTrue(len(rest) == copy(k, rest))
+	return buf
+}
+
 // Parse would parse the key. ParsedKey does not reuse the key slice, so the key slice can change
 // without affecting the contents of ParsedKey.
 func Parse(key []byte) *ParsedKey {
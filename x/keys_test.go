@@ -91,3 +91,11 @@ func TestSchemaKey(t *testing.T) {
 		require.Equal(t, sattr, pk.Attr)
 	}
 }
+
+func TestRenameAttr(t *testing.T) {
+	require.Equal(t, DataKey("new.attr", 7), RenameAttr(DataKey("attr", 7), "new.attr"))
+	require.Equal(t, ReverseKey("a", 7), RenameAttr(ReverseKey("attr", 7), "a"))
+	require.Equal(t, IndexKey("new", "\x01term"), RenameAttr(IndexKey("attr", "\x01term"), "new"))
+	require.Equal(t, CountKey("new", 3, true), RenameAttr(CountKey("attr", 3, true), "new"))
+	require.Equal(t, SchemaKey("new"), RenameAttr(SchemaKey("attr"), "new"))
+}
@@ -35,6 +35,14 @@ var (
 	LcacheMiss    *expvar.Int
 	LcacheRace    *expvar.Int
 	LcacheEvicts  *expvar.Int
+	ReadRetries   *expvar.Int
+	QcacheHit     *expvar.Int
+	QcacheMiss    *expvar.Int
+	QcacheEvicts  *expvar.Int
+	// Proposals turned away, because too many were pending
+	RejectedProposals *expvar.Int
+	// Transactions aborted, because they were idle for over --txn_ttl
+	ReapedTxns *expvar.Int
 
 	// value at particular point of time
 	PendingQueries   *expvar.Int
@@ -42,6 +50,7 @@ var (
 	LcacheSize       *expvar.Int
 	LcacheLen        *expvar.Int
 	LcacheCapacity   *expvar.Int
+	QcacheSize       *expvar.Int
 	DirtyMapSize     *expvar.Int
 	NumGoRoutines    *expvar.Int
 	MemoryInUse      *expvar.Int
@@ -51,13 +60,54 @@ var (
 	AlphaHealth      *expvar.Int
 	MaxPlSize        *expvar.Int
 	MaxPlLength      *expvar.Int
+	RaftLogBytes     *expvar.Int
+	SnapshotInterval *expvar.Int
 
 	PredicateStats *expvar.Map
 	Conf           *expvar.Map
 
+	// Keyed by cache, and then by predicate
+	LcachePredicateHits *expvar.Map
+	LcachePredicateMiss *expvar.Map
+	// Keyed by cache
+	LcacheSizes      *expvar.Map
+	LcacheCapacities *expvar.Map
+	LcacheEvictions  *expvar.Map
+	// Keyed by transport, and then by encoding
+	CompressionInput *expvar.Map
+	CompressionSaved *expvar.Map
+	// Keyed by group of the primary cluster
+	ReplicationLag  *expvar.Map
+	ReplicationKeys *expvar.Map
+	// Keyed by the reason why a snapshot was taken or not
+	SnapshotDecisions *expvar.Map
+
+	// Metrics of Zero
+	ZeroPendingTxns       *expvar.Int
+	ZeroProposals         *expvar.Int
+	ZeroProposalSeconds   *expvar.Float
+	ZeroTabletMoveSeconds *expvar.Float
+	ZeroMembershipStreams *expvar.Int
+	ZeroRaftTerm          *expvar.Int
+	ZeroRaftCommitIndex   *expvar.Int
+	ZeroRaftAppliedIndex  *expvar.Int
+	// Keyed by what is leased: uids or timestamps
+	ZeroLeased *expvar.Map
+	// Keyed by the result of the move: ok or error
+	ZeroTabletMoves *expvar.Map
+	// Keyed by the result of the split: ok or error
+	ZeroTabletSplits *expvar.Map
+	// Keyed by the result of the copy: ok or error
+	ZeroTabletCopies *expvar.Map
+
+	// Keyed by what became of the events of the change stream: published or dropped
+	ChangeEvents *expvar.Map
+
 	MaxPlSz int64
 	// TODO: Request statistics, latencies, 500, timeouts
 
+	// expvarDescs describes the expvar metrics exported to Prometheus, by name.
+	expvarDescs map[string]*prometheus.Desc
 )
 
 func init() {
@@ -81,11 +131,43 @@ func init() {
 	LcacheMiss = expvar.NewInt("dgraph_lru_miss_total")
 	LcacheRace = expvar.NewInt("dgraph_lru_race_total")
 	LcacheEvicts = expvar.NewInt("dgraph_lru_evicted_total")
+	ReadRetries = expvar.NewInt("dgraph_read_retries_total")
+	RejectedProposals = expvar.NewInt("dgraph_rejected_proposals_total")
+	ReapedTxns = expvar.NewInt("dgraph_reaped_txns_total")
+	QcacheHit = expvar.NewInt("dgraph_query_cache_hits_total")
+	QcacheMiss = expvar.NewInt("dgraph_query_cache_miss_total")
+	QcacheEvicts = expvar.NewInt("dgraph_query_cache_evicted_total")
+	QcacheSize = expvar.NewInt("dgraph_query_cache_size_bytes")
 	LcacheSize = expvar.NewInt("dgraph_lru_size_bytes")
 	LcacheLen = expvar.NewInt("dgraph_lru_keys_total")
 	LcacheCapacity = expvar.NewInt("dgraph_lru_capacity_bytes")
+	LcachePredicateHits = expvar.NewMap("dgraph_lru_predicate_hits_total")
+	LcachePredicateMiss = expvar.NewMap("dgraph_lru_predicate_miss_total")
+	LcacheSizes = expvar.NewMap("dgraph_lru_cache_size_bytes")
+	LcacheCapacities = expvar.NewMap("dgraph_lru_cache_capacity_bytes")
+	LcacheEvictions = expvar.NewMap("dgraph_lru_cache_evicted_total")
+	CompressionInput = expvar.NewMap("dgraph_compression_input_bytes_total")
+	CompressionSaved = expvar.NewMap("dgraph_compression_saved_bytes_total")
+	ReplicationLag = expvar.NewMap("dgraph_replication_lag_seconds")
+	ReplicationKeys = expvar.NewMap("dgraph_replication_keys_total")
 	MaxPlSize = expvar.NewInt("dgraph_max_list_bytes")
 	MaxPlLength = expvar.NewInt("dgraph_max_list_length")
+	RaftLogBytes = expvar.NewInt("dgraph_raft_log_bytes")
+	SnapshotInterval = expvar.NewInt("dgraph_snapshot_interval_seconds")
+	SnapshotDecisions = expvar.NewMap("dgraph_snapshot_decisions_total")
+	ZeroPendingTxns = expvar.NewInt("dgraph_zero_pending_txns")
+	ZeroProposals = expvar.NewInt("dgraph_zero_proposals_total")
+	ZeroProposalSeconds = expvar.NewFloat("dgraph_zero_proposal_seconds_total")
+	ZeroTabletMoveSeconds = expvar.NewFloat("dgraph_zero_tablet_move_seconds_total")
+	ZeroMembershipStreams = expvar.NewInt("dgraph_zero_membership_streams")
+	ZeroRaftTerm = expvar.NewInt("dgraph_zero_raft_term")
+	ZeroRaftCommitIndex = expvar.NewInt("dgraph_zero_raft_commit_index")
+	ZeroRaftAppliedIndex = expvar.NewInt("dgraph_zero_raft_applied_index")
+	ZeroLeased = expvar.NewMap("dgraph_zero_leased_total")
+	ZeroTabletMoves = expvar.NewMap("dgraph_zero_tablet_moves_total")
+	ZeroTabletSplits = expvar.NewMap("dgraph_zero_tablet_splits_total")
+	ZeroTabletCopies = expvar.NewMap("dgraph_zero_tablet_copies_total")
+	ChangeEvents = expvar.NewMap("dgraph_change_events_total")
 
 	go func() {
 		ticker := time.NewTicker(5 * time.Second)
@@ -104,7 +186,7 @@ func init() {
 
 	// TODO: prometheus.NewExpvarCollector is not production worthy (see godocs). Use a better
 	// way for exporting Prometheus metrics (like an OpenCensus metrics exporter).
-	expvarCollector := prometheus.NewExpvarCollector(map[string]*prometheus.Desc{
+	expvarDescs = map[string]*prometheus.Desc{
 		"dgraph_lru_hits_total": prometheus.NewDesc(
 			"dgraph_lru_hits_total",
 			"dgraph_lru_hits_total",
@@ -140,6 +222,81 @@ func init() {
 			"dgraph_lru_capacity_bytes",
 			nil, nil,
 		),
+		"dgraph_lru_predicate_hits_total": prometheus.NewDesc(
+			"dgraph_lru_predicate_hits_total",
+			"dgraph_lru_predicate_hits_total",
+			[]string{"cache", "predicate"}, nil,
+		),
+		"dgraph_lru_predicate_miss_total": prometheus.NewDesc(
+			"dgraph_lru_predicate_miss_total",
+			"dgraph_lru_predicate_miss_total",
+			[]string{"cache", "predicate"}, nil,
+		),
+		"dgraph_compression_input_bytes_total": prometheus.NewDesc(
+			"dgraph_compression_input_bytes_total",
+			"dgraph_compression_input_bytes_total",
+			[]string{"transport", "encoding"}, nil,
+		),
+		"dgraph_compression_saved_bytes_total": prometheus.NewDesc(
+			"dgraph_compression_saved_bytes_total",
+			"dgraph_compression_saved_bytes_total",
+			[]string{"transport", "encoding"}, nil,
+		),
+		"dgraph_replication_lag_seconds": prometheus.NewDesc(
+			"dgraph_replication_lag_seconds",
+			"dgraph_replication_lag_seconds",
+			[]string{"group"}, nil,
+		),
+		"dgraph_replication_keys_total": prometheus.NewDesc(
+			"dgraph_replication_keys_total",
+			"dgraph_replication_keys_total",
+			[]string{"group"}, nil,
+		),
+		"dgraph_snapshot_decisions_total": prometheus.NewDesc(
+			"dgraph_snapshot_decisions_total",
+			"dgraph_snapshot_decisions_total",
+			[]string{"reason"}, nil,
+		),
+		"dgraph_lru_cache_size_bytes": prometheus.NewDesc(
+			"dgraph_lru_cache_size_bytes",
+			"dgraph_lru_cache_size_bytes",
+			[]string{"cache"}, nil,
+		),
+		"dgraph_lru_cache_capacity_bytes": prometheus.NewDesc(
+			"dgraph_lru_cache_capacity_bytes",
+			"dgraph_lru_cache_capacity_bytes",
+			[]string{"cache"}, nil,
+		),
+		"dgraph_lru_cache_evicted_total": prometheus.NewDesc(
+			"dgraph_lru_cache_evicted_total",
+			"dgraph_lru_cache_evicted_total",
+			[]string{"cache"}, nil,
+		),
+		"dgraph_read_retries_total": prometheus.NewDesc(
+			"dgraph_read_retries_total",
+			"dgraph_read_retries_total",
+			nil, nil,
+		),
+		"dgraph_query_cache_hits_total": prometheus.NewDesc(
+			"dgraph_query_cache_hits_total",
+			"dgraph_query_cache_hits_total",
+			nil, nil,
+		),
+		"dgraph_query_cache_miss_total": prometheus.NewDesc(
+			"dgraph_query_cache_miss_total",
+			"dgraph_query_cache_miss_total",
+			nil, nil,
+		),
+		"dgraph_query_cache_evicted_total": prometheus.NewDesc(
+			"dgraph_query_cache_evicted_total",
+			"dgraph_query_cache_evicted_total",
+			nil, nil,
+		),
+		"dgraph_query_cache_size_bytes": prometheus.NewDesc(
+			"dgraph_query_cache_size_bytes",
+			"dgraph_query_cache_size_bytes",
+			nil, nil,
+		),
 		"dgraph_posting_reads_total": prometheus.NewDesc(
 			"dgraph_posting_reads_total",
 			"dgraph_posting_reads_total",
@@ -160,11 +317,96 @@ func init() {
 			"dgraph_max_list_length",
 			nil, nil,
 		),
+		"dgraph_raft_log_bytes": prometheus.NewDesc(
+			"dgraph_raft_log_bytes",
+			"dgraph_raft_log_bytes",
+			nil, nil,
+		),
+		"dgraph_snapshot_interval_seconds": prometheus.NewDesc(
+			"dgraph_snapshot_interval_seconds",
+			"dgraph_snapshot_interval_seconds",
+			nil, nil,
+		),
+		"dgraph_zero_pending_txns": prometheus.NewDesc(
+			"dgraph_zero_pending_txns",
+			"dgraph_zero_pending_txns",
+			nil, nil,
+		),
+		"dgraph_zero_proposals_total": prometheus.NewDesc(
+			"dgraph_zero_proposals_total",
+			"dgraph_zero_proposals_total",
+			nil, nil,
+		),
+		"dgraph_zero_proposal_seconds_total": prometheus.NewDesc(
+			"dgraph_zero_proposal_seconds_total",
+			"dgraph_zero_proposal_seconds_total",
+			nil, nil,
+		),
+		"dgraph_zero_tablet_move_seconds_total": prometheus.NewDesc(
+			"dgraph_zero_tablet_move_seconds_total",
+			"dgraph_zero_tablet_move_seconds_total",
+			nil, nil,
+		),
+		"dgraph_zero_membership_streams": prometheus.NewDesc(
+			"dgraph_zero_membership_streams",
+			"dgraph_zero_membership_streams",
+			nil, nil,
+		),
+		"dgraph_zero_raft_term": prometheus.NewDesc(
+			"dgraph_zero_raft_term",
+			"dgraph_zero_raft_term",
+			nil, nil,
+		),
+		"dgraph_zero_raft_commit_index": prometheus.NewDesc(
+			"dgraph_zero_raft_commit_index",
+			"dgraph_zero_raft_commit_index",
+			nil, nil,
+		),
+		"dgraph_zero_raft_applied_index": prometheus.NewDesc(
+			"dgraph_zero_raft_applied_index",
+			"dgraph_zero_raft_applied_index",
+			nil, nil,
+		),
+		"dgraph_zero_leased_total": prometheus.NewDesc(
+			"dgraph_zero_leased_total",
+			"dgraph_zero_leased_total",
+			[]string{"kind"}, nil,
+		),
+		"dgraph_zero_tablet_moves_total": prometheus.NewDesc(
+			"dgraph_zero_tablet_moves_total",
+			"dgraph_zero_tablet_moves_total",
+			[]string{"result"}, nil,
+		),
+		"dgraph_zero_tablet_splits_total": prometheus.NewDesc(
+			"dgraph_zero_tablet_splits_total",
+			"dgraph_zero_tablet_splits_total",
+			[]string{"result"}, nil,
+		),
+		"dgraph_zero_tablet_copies_total": prometheus.NewDesc(
+			"dgraph_zero_tablet_copies_total",
+			"dgraph_zero_tablet_copies_total",
+			[]string{"result"}, nil,
+		),
+		"dgraph_change_events_total": prometheus.NewDesc(
+			"dgraph_change_events_total",
+			"dgraph_change_events_total",
+			[]string{"result"}, nil,
+		),
 		"dgraph_pending_proposals_total": prometheus.NewDesc(
 			"dgraph_pending_proposals_total",
 			"dgraph_pending_proposals_total",
 			nil, nil,
 		),
+		"dgraph_rejected_proposals_total": prometheus.NewDesc(
+			"dgraph_rejected_proposals_total",
+			"dgraph_rejected_proposals_total",
+			nil, nil,
+		),
+		"dgraph_reaped_txns_total": prometheus.NewDesc(
+			"dgraph_reaped_txns_total",
+			"dgraph_reaped_txns_total",
+			nil, nil,
+		),
 		"dgraph_read_bytes_total": prometheus.NewDesc(
 			"dgraph_read_bytes_total",
 			"dgraph_read_bytes_total",
@@ -280,7 +522,8 @@ func init() {
 			"badger_vlog_size",
 			[]string{"dir"}, nil,
 		),
-	})
+	}
+	expvarCollector := prometheus.NewExpvarCollector(expvarDescs)
 	prometheus.MustRegister(expvarCollector)
 	http.Handle("/debug/prometheus_metrics", prometheus.Handler())
 }
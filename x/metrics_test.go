@@ -0,0 +1,39 @@
+/*
+ * Copyright 2016-2018 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package x
+
+import (
+	"expvar"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestZeroMetricsRegistered(t *testing.T) {
+	var names []string
+	expvar.Do(func(kv expvar.KeyValue) {
+		if strings.HasPrefix(kv.Key, "dgraph_zero_") {
+			names = append(names, kv.Key)
+		}
+	})
+	require.Len(t, names, 12)
+	// Every metric of Zero is exported to Prometheus, not only to /debug/vars.
+	for _, name := range names {
+		require.Contains(t, expvarDescs, name)
+	}
+}
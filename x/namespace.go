@@ -0,0 +1,74 @@
+/*
+ * Copyright 2018 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package x
+
+import (
+	"context"
+	"strings"
+)
+
+// Namespaces let tenants share a cluster. The predicates of a namespace are stored, and served by
+// Zero, under their name prefixed with the name of the namespace and NamespaceSep, which can't
+// be part of predicate names. So all keys, tablets and schema entries of a namespace are separate
+// from the ones of other namespaces. The predicates of the default namespace, whose name is
+// empty, aren't prefixed.
+const NamespaceSep = "|"
+
+type namespaceKey struct{}
+
+// WithNamespace returns a context for requests against the given namespace.
+func WithNamespace(ctx context.Context, ns string) context.Context {
+	return context.WithValue(ctx, namespaceKey{}, ns)
+}
+
+// NamespaceFromContext returns the namespace requests made with ctx are run against.
+func NamespaceFromContext(ctx context.Context) string {
+	ns, _ := ctx.Value(namespaceKey{}).(string)
+	return ns
+}
+
+// NamespaceAttr returns the name under which the predicate attr of namespace ns is stored.
+func NamespaceAttr(ns, attr string) string {
+	if len(ns) == 0 {
+		return attr
+	}
+	return ns + NamespaceSep + attr
+}
+
+// ParseNamespaceAttr splits a stored predicate name into its namespace and predicate.
+func ParseNamespaceAttr(attr string) (string, string) {
+	idx := strings.Index(attr, NamespaceSep)
+	if idx < 0 {
+		return "", attr
+	}
+	return attr[:idx], attr[idx+len(NamespaceSep):]
+}
+
+// ValidNamespaceName tells whether name can be used for a new namespace.
+func ValidNamespaceName(name string) bool {
+	if len(name) == 0 || len(name) > 64 {
+		return false
+	}
+	for _, r := range name {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '_', r == '-':
+		default:
+			return false
+		}
+	}
+	return true
+}
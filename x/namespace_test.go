@@ -0,0 +1,57 @@
+/*
+ * Copyright 2018 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package x
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNamespaceAttr(t *testing.T) {
+	require.Equal(t, "name", NamespaceAttr("", "name"))
+	require.Equal(t, "acme|name", NamespaceAttr("acme", "name"))
+
+	ns, attr := ParseNamespaceAttr("acme|dgraph.template.name")
+	require.Equal(t, "acme", ns)
+	require.Equal(t, "dgraph.template.name", attr)
+
+	ns, attr = ParseNamespaceAttr("name")
+	require.Equal(t, "", ns)
+	require.Equal(t, "name", attr)
+
+	ctx := context.Background()
+	require.Equal(t, "", NamespaceFromContext(ctx))
+	require.Equal(t, "acme", NamespaceFromContext(WithNamespace(ctx, "acme")))
+}
+
+func TestValidNamespaceName(t *testing.T) {
+	for _, name := range []string{"acme", "Acme_2", "tenant-1"} {
+		require.True(t, ValidNamespaceName(name), name)
+	}
+	for _, name := range []string{"", "ac|me", "a.b", "a b", string(make([]byte, 65))} {
+		require.False(t, ValidNamespaceName(name), name)
+	}
+}
+
+func TestIsScratchAttr(t *testing.T) {
+	require.True(t, IsScratchAttr("dgraph.scratch.seen"))
+	require.True(t, IsScratchAttr("acme|dgraph.scratch.seen"))
+	require.False(t, IsScratchAttr("seen"))
+	require.False(t, IsScratchAttr("dgraph.scratchpad"))
+}
@@ -0,0 +1,459 @@
+/*
+ * Copyright 2018 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package x
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"math"
+	"net"
+	"net/http"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/golang/glog"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/spf13/pflag"
+	"github.com/spf13/viper"
+)
+
+// Metrics are usually scraped from /debug/prometheus_metrics. Where that isn't possible, they can
+// also be pushed at an interval to any number of sinks, given as a comma separated list of
+// kind=address pairs:
+//
+//   prometheus=http://host:9090/api/v1/write  Prometheus remote write.
+//   statsd=host:8125                          StatsD gauges over UDP.
+//   otlp=http://host:4318/v1/metrics          OpenTelemetry metrics, via OTLP/HTTP with JSON.
+//
+// Every push sends the same metrics as the scrape endpoint, split into batches of a bounded size.
+
+const (
+	pushTimeout        = 10 * time.Second
+	pushBatchSize      = 500  // Series per remote write or OTLP request.
+	statsdPacketSize   = 1432 // Fits into the MTU of most networks.
+	pushedServiceLabel = "dgraph"
+)
+
+type metricSample struct {
+	name    string
+	labels  [][2]string // Sorted by name.
+	value   float64
+	counter bool
+}
+
+type metricsSink interface {
+	push(samples []metricSample, ts time.Time) error
+}
+
+func RegisterMetricsPushFlags(flag *pflag.FlagSet) {
+	flag.String("metrics_push", "", "Comma separated list of sinks to push metrics to, like"+
+		" prometheus=<remote write URL>, statsd=<host:port> or otlp=<OTLP/HTTP metrics URL>.")
+	flag.Duration("metrics_push_interval", 15*time.Second, "Interval at which metrics are pushed.")
+}
+
+// StartMetricsPushFromConfig starts pushing metrics as configured by the flags registered with
+// RegisterMetricsPushFlags.
+func StartMetricsPushFromConfig(v *viper.Viper, instance string, stop <-chan struct{}) error {
+	return StartMetricsPush(v.GetString("metrics_push"), instance,
+		v.GetDuration("metrics_push_interval"), stop)
+}
+
+// StartMetricsPush pushes the metrics to the sinks in targets every interval, until stop is
+// closed. The instance label of the metrics is set to instance.
+func StartMetricsPush(targets, instance string, interval time.Duration,
+	stop <-chan struct{}) error {
+	sinks, err := parseMetricsSinks(targets)
+	if err != nil || len(sinks) == 0 {
+		return err
+	}
+	if interval <= 0 {
+		return Errorf("Metrics push interval must be positive, got: %v", interval)
+	}
+	if len(instance) == 0 {
+		instance, _ = os.Hostname()
+	}
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-stop:
+				return
+			case <-ticker.C:
+				mfs, err := prometheus.DefaultGatherer.Gather()
+				if err != nil {
+					glog.Warningf("While gathering metrics to push: %v", err)
+				}
+				samples := metricSamples(mfs, instance)
+				now := time.Now()
+				for kind, sink := range sinks {
+					if err := sink.push(samples, now); err != nil {
+						glog.Warningf("While pushing metrics to %s: %v", kind, err)
+					}
+				}
+			}
+		}
+	}()
+	return nil
+}
+
+func parseMetricsSinks(targets string) (map[string]metricsSink, error) {
+	sinks := make(map[string]metricsSink)
+	for _, target := range strings.Split(targets, ",") {
+		target = strings.TrimSpace(target)
+		if len(target) == 0 {
+			continue
+		}
+		kv := strings.SplitN(target, "=", 2)
+		if len(kv) != 2 || len(kv[1]) == 0 {
+			return nil, Errorf("Invalid metrics push target %q. Use kind=address.", target)
+		}
+		switch kv[0] {
+		case "prometheus":
+			sinks[target] = &remoteWriteSink{url: kv[1]}
+		case "statsd":
+			sinks[target] = &statsdSink{addr: kv[1]}
+		case "otlp":
+			sinks[target] = &otlpSink{url: kv[1]}
+		default:
+			return nil, Errorf("Unknown metrics push target %q. Use prometheus, statsd or otlp.",
+				kv[0])
+		}
+	}
+	return sinks, nil
+}
+
+// metricSamples flattens the metric families into samples. Summaries and histograms are pushed
+// as their quantiles or buckets, along with their _sum and _count.
+func metricSamples(mfs []*dto.MetricFamily, instance string) []metricSample {
+	var samples []metricSample
+	for _, mf := range mfs {
+		name := mf.GetName()
+		for _, m := range mf.GetMetric() {
+			labels := [][2]string{{"instance", instance}}
+			for _, lp := range m.GetLabel() {
+				labels = append(labels, [2]string{lp.GetName(), lp.GetValue()})
+			}
+			add := func(suffix string, value float64, counter bool, extra ...[2]string) {
+				ls := append(append([][2]string{}, labels...), extra...)
+				sort.Slice(ls, func(i, j int) bool { return ls[i][0] < ls[j][0] })
+				samples = append(samples, metricSample{
+					name:    name + suffix,
+					labels:  ls,
+					value:   value,
+					counter: counter,
+				})
+			}
+			switch mf.GetType() {
+			case dto.MetricType_COUNTER:
+				add("", m.GetCounter().GetValue(), true)
+			case dto.MetricType_GAUGE:
+				add("", m.GetGauge().GetValue(), false)
+			case dto.MetricType_SUMMARY:
+				s := m.GetSummary()
+				for _, q := range s.GetQuantile() {
+					add("", q.GetValue(), false,
+						[2]string{"quantile", formatFloat(q.GetQuantile())})
+				}
+				add("_sum", s.GetSampleSum(), true)
+				add("_count", float64(s.GetSampleCount()), true)
+			case dto.MetricType_HISTOGRAM:
+				h := m.GetHistogram()
+				for _, b := range h.GetBucket() {
+					add("_bucket", float64(b.GetCumulativeCount()), true,
+						[2]string{"le", formatFloat(b.GetUpperBound())})
+				}
+				add("_bucket", float64(h.GetSampleCount()), true, [2]string{"le", "+Inf"})
+				add("_sum", h.GetSampleSum(), true)
+				add("_count", float64(h.GetSampleCount()), true)
+			default:
+				// The metrics exported from expvar are untyped.
+				add("", m.GetUntyped().GetValue(), strings.HasSuffix(name, "_total"))
+			}
+		}
+	}
+	return samples
+}
+
+func formatFloat(f float64) string {
+	if math.IsInf(f, 1) {
+		return "+Inf"
+	}
+	return strconv.FormatFloat(f, 'g', -1, 64)
+}
+
+func postRequest(url, contentType string, body []byte, headers map[string]string) error {
+	req, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", contentType)
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+	client := &http.Client{Timeout: pushTimeout}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		msg, _ := ioutil.ReadAll(io.LimitReader(resp.Body, 512))
+		return Errorf("Got status %s: %s", resp.Status, bytes.TrimSpace(msg))
+	}
+	return nil
+}
+
+// remoteWriteSink pushes to the Prometheus remote write API, which takes a snappy compressed
+// WriteRequest protobuf.
+type remoteWriteSink struct {
+	url string
+}
+
+func (s *remoteWriteSink) push(samples []metricSample, ts time.Time) error {
+	for start := 0; start < len(samples); start += pushBatchSize {
+		end := start + pushBatchSize
+		if end > len(samples) {
+			end = len(samples)
+		}
+		body := SnappyEncode(encodeWriteRequest(samples[start:end], ts))
+		if err := postRequest(s.url, "application/x-protobuf", body, map[string]string{
+			"Content-Encoding":                  "snappy",
+			"X-Prometheus-Remote-Write-Version": "0.1.0",
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// encodeWriteRequest encodes the samples as a WriteRequest of the remote write protocol:
+//
+//	message WriteRequest { repeated TimeSeries timeseries = 1; }
+//	message TimeSeries { repeated Label labels = 1; repeated Sample samples = 2; }
+//	message Label { string name = 1; string value = 2; }
+//	message Sample { double value = 1; int64 timestamp = 2; }
+func encodeWriteRequest(samples []metricSample, ts time.Time) []byte {
+	var req []byte
+	for _, sample := range samples {
+		var series []byte
+		series = appendLabel(series, "__name__", sample.name)
+		for _, l := range sample.labels {
+			series = appendLabel(series, l[0], l[1])
+		}
+		var sm []byte
+		sm = append(sm, 1<<3|1) // Field 1, fixed64.
+		var buf [8]byte
+		binary.LittleEndian.PutUint64(buf[:], math.Float64bits(sample.value))
+		sm = append(sm, buf[:]...)
+		sm = append(sm, 2<<3|0) // Field 2, varint.
+		sm = appendVarint(sm, uint64(ts.UnixNano()/int64(time.Millisecond)))
+		series = appendBytesField(series, 2, sm)
+		req = appendBytesField(req, 1, series)
+	}
+	return req
+}
+
+func appendLabel(b []byte, name, value string) []byte {
+	var l []byte
+	l = appendBytesField(l, 1, []byte(name))
+	l = appendBytesField(l, 2, []byte(value))
+	return appendBytesField(b, 1, l)
+}
+
+func appendBytesField(b []byte, field uint64, data []byte) []byte {
+	b = appendVarint(b, field<<3|2) // Length delimited.
+	b = appendVarint(b, uint64(len(data)))
+	return append(b, data...)
+}
+
+func appendVarint(b []byte, v uint64) []byte {
+	var buf [binary.MaxVarintLen64]byte
+	n := binary.PutUvarint(buf[:], v)
+	return append(b, buf[:n]...)
+}
+
+// statsdSink pushes the samples as StatsD gauges over UDP. StatsD has no labels, so their values
+// are appended to the name of the metrics.
+type statsdSink struct {
+	addr string
+}
+
+func (s *statsdSink) push(samples []metricSample, ts time.Time) error {
+	conn, err := net.DialTimeout("udp", s.addr, pushTimeout)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	for _, packet := range statsdPackets(samples) {
+		if _, err := conn.Write(packet); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func statsdPackets(samples []metricSample) [][]byte {
+	var packets [][]byte
+	var buf bytes.Buffer
+	for _, sample := range samples {
+		line := statsdLine(sample)
+		if buf.Len() > 0 && buf.Len()+1+len(line) > statsdPacketSize {
+			packets = append(packets, append([]byte{}, buf.Bytes()...))
+			buf.Reset()
+		}
+		if buf.Len() > 0 {
+			buf.WriteByte('\n')
+		}
+		buf.WriteString(line)
+	}
+	if buf.Len() > 0 {
+		packets = append(packets, buf.Bytes())
+	}
+	return packets
+}
+
+func statsdLine(sample metricSample) string {
+	name := sample.name
+	for _, l := range sample.labels {
+		if l[0] == "instance" {
+			continue
+		}
+		name += "." + statsdName(l[1])
+	}
+	return fmt.Sprintf("%s:%s|g", name, strconv.FormatFloat(sample.value, 'f', -1, 64))
+}
+
+func statsdName(s string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '_', r == '-':
+			return r
+		}
+		return '_'
+	}, s)
+}
+
+// otlpSink pushes the samples as OpenTelemetry metrics, via OTLP/HTTP with JSON encoding.
+// Counters are sent as cumulative monotonic sums, everything else as gauges.
+type otlpSink struct {
+	url string
+}
+
+type otlpAttr struct {
+	Key   string `json:"key"`
+	Value struct {
+		StringValue string `json:"stringValue"`
+	} `json:"value"`
+}
+
+type otlpPoint struct {
+	Attributes   []otlpAttr `json:"attributes"`
+	TimeUnixNano string     `json:"timeUnixNano"`
+	AsDouble     float64    `json:"asDouble"`
+}
+
+type otlpGauge struct {
+	DataPoints []otlpPoint `json:"dataPoints"`
+}
+
+type otlpSum struct {
+	DataPoints             []otlpPoint `json:"dataPoints"`
+	AggregationTemporality int         `json:"aggregationTemporality"`
+	IsMonotonic            bool        `json:"isMonotonic"`
+}
+
+type otlpMetric struct {
+	Name  string     `json:"name"`
+	Gauge *otlpGauge `json:"gauge,omitempty"`
+	Sum   *otlpSum   `json:"sum,omitempty"`
+}
+
+func newOtlpAttr(key, value string) otlpAttr {
+	var a otlpAttr
+	a.Key = key
+	a.Value.StringValue = value
+	return a
+}
+
+func (s *otlpSink) push(samples []metricSample, ts time.Time) error {
+	for start := 0; start < len(samples); start += pushBatchSize {
+		end := start + pushBatchSize
+		if end > len(samples) {
+			end = len(samples)
+		}
+		body, err := encodeOtlp(samples[start:end], ts)
+		if err != nil {
+			return err
+		}
+		if err := postRequest(s.url, "application/json", body, nil); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func encodeOtlp(samples []metricSample, ts time.Time) ([]byte, error) {
+	var metrics []*otlpMetric
+	byName := make(map[string]*otlpMetric)
+	for _, sample := range samples {
+		point := otlpPoint{
+			TimeUnixNano: strconv.FormatInt(ts.UnixNano(), 10),
+			AsDouble:     sample.value,
+		}
+		for _, l := range sample.labels {
+			point.Attributes = append(point.Attributes, newOtlpAttr(l[0], l[1]))
+		}
+		m, ok := byName[sample.name]
+		if !ok {
+			m = &otlpMetric{Name: sample.name}
+			if sample.counter {
+				// Aggregation temporality 2 is cumulative.
+				m.Sum = &otlpSum{AggregationTemporality: 2, IsMonotonic: true}
+			} else {
+				m.Gauge = &otlpGauge{}
+			}
+			byName[sample.name] = m
+			metrics = append(metrics, m)
+		}
+		if m.Sum != nil {
+			m.Sum.DataPoints = append(m.Sum.DataPoints, point)
+		} else {
+			m.Gauge.DataPoints = append(m.Gauge.DataPoints, point)
+		}
+	}
+	req := map[string]interface{}{
+		"resourceMetrics": []interface{}{map[string]interface{}{
+			"resource": map[string]interface{}{
+				"attributes": []otlpAttr{newOtlpAttr("service.name", pushedServiceLabel)},
+			},
+			"scopeMetrics": []interface{}{map[string]interface{}{
+				"scope":   map[string]string{"name": pushedServiceLabel},
+				"metrics": metrics,
+			}},
+		}},
+	}
+	return json.Marshal(req)
+}
@@ -0,0 +1,119 @@
+/*
+ * Copyright 2018 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package x
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseMetricsSinks(t *testing.T) {
+	sinks, err := parseMetricsSinks("prometheus=http://p:9090/api/v1/write, statsd=s:8125,")
+	require.NoError(t, err)
+	require.Len(t, sinks, 2)
+
+	sinks, err = parseMetricsSinks("")
+	require.NoError(t, err)
+	require.Empty(t, sinks)
+
+	_, err = parseMetricsSinks("graphite=g:2003")
+	require.Error(t, err)
+	_, err = parseMetricsSinks("statsd")
+	require.Error(t, err)
+}
+
+func TestRemoteWriteSink(t *testing.T) {
+	samples := []metricSample{{name: "m", labels: [][2]string{{"a", "b"}}, value: 1}}
+	ts := time.Unix(0, 2*int64(time.Millisecond))
+	var got []byte
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "snappy", r.Header.Get("Content-Encoding"))
+		body, err := ioutil.ReadAll(r.Body)
+		require.NoError(t, err)
+		got, err = SnappyDecode(body)
+		require.NoError(t, err)
+	}))
+	defer srv.Close()
+
+	require.NoError(t, (&remoteWriteSink{url: srv.URL}).push(samples, ts))
+	require.Equal(t, encodeWriteRequest(samples, ts), got)
+}
+
+func TestEncodeWriteRequest(t *testing.T) {
+	samples := []metricSample{{name: "m", labels: [][2]string{{"a", "b"}}, value: 1}}
+	b := encodeWriteRequest(samples, time.Unix(0, 2*int64(time.Millisecond)))
+	require.Equal(t, []byte{
+		0x0a, 0x24, // timeseries
+		0x0a, 0x0d, 0x0a, 0x08, '_', '_', 'n', 'a', 'm', 'e', '_', '_', 0x12, 0x01, 'm',
+		0x0a, 0x06, 0x0a, 0x01, 'a', 0x12, 0x01, 'b',
+		0x12, 0x0b, 0x09, 0, 0, 0, 0, 0, 0, 0xf0, 0x3f, 0x10, 0x02, // sample
+	}, b)
+}
+
+func TestStatsdPackets(t *testing.T) {
+	samples := []metricSample{
+		{name: "a", labels: [][2]string{{"instance", "i"}, {"level", "1"}}, value: 1.5},
+		{name: "b", labels: [][2]string{{"dir", "/data/p"}}, value: 2},
+	}
+	require.Equal(t, [][]byte{[]byte("a.1:1.5|g\nb._data_p:2|g")}, statsdPackets(samples))
+
+	samples = nil
+	for i := 0; i < 200; i++ {
+		samples = append(samples, metricSample{name: "dgraph_num_queries_total", value: 1})
+	}
+	packets := statsdPackets(samples)
+	require.True(t, len(packets) > 1)
+	var lines int
+	for _, p := range packets {
+		require.True(t, len(p) <= statsdPacketSize)
+		lines += strings.Count(string(p), "\n") + 1
+	}
+	require.Equal(t, 200, lines)
+}
+
+func TestEncodeOtlp(t *testing.T) {
+	samples := []metricSample{
+		{name: "c_total", labels: [][2]string{{"instance", "i"}}, value: 3, counter: true},
+		{name: "g", value: 1},
+		{name: "g", labels: [][2]string{{"dir", "p"}}, value: 2},
+	}
+	b, err := encodeOtlp(samples, time.Unix(1, 0))
+	require.NoError(t, err)
+
+	var req struct {
+		ResourceMetrics []struct {
+			ScopeMetrics []struct {
+				Metrics []otlpMetric `json:"metrics"`
+			} `json:"scopeMetrics"`
+		} `json:"resourceMetrics"`
+	}
+	require.NoError(t, json.Unmarshal(b, &req))
+	metrics := req.ResourceMetrics[0].ScopeMetrics[0].Metrics
+	require.Len(t, metrics, 2)
+	require.Equal(t, "c_total", metrics[0].Name)
+	require.True(t, metrics[0].Sum.IsMonotonic)
+	require.Equal(t, "1000000000", metrics[0].Sum.DataPoints[0].TimeUnixNano)
+	require.Equal(t, "g", metrics[1].Name)
+	require.Len(t, metrics[1].Gauge.DataPoints, 2)
+}
@@ -0,0 +1,31 @@
+/*
+ * Copyright 2018 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package x
+
+import "strings"
+
+// Scratch predicates hold facts which only live as long as the transaction writing them. They
+// can be read back by the queries of that transaction, like any other predicate, but their
+// writes are neither checked for conflicts nor committed: they're discarded once the
+// transaction is committed or aborted, and their schema is only kept in memory.
+const ScratchPrefix = "dgraph.scratch."
+
+// IsScratchAttr tells whether the stored predicate attr is a scratch predicate.
+func IsScratchAttr(attr string) bool {
+	_, attr = ParseNamespaceAttr(attr)
+	return strings.HasPrefix(attr, ScratchPrefix)
+}
@@ -0,0 +1,268 @@
+/*
+ * Copyright 2018 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package x
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"sync/atomic"
+	"time"
+
+	"github.com/golang/glog"
+	"github.com/spf13/pflag"
+	"github.com/spf13/viper"
+	"go.opencensus.io/exporter/jaeger"
+	otrace "go.opencensus.io/trace"
+)
+
+// Traces are propagated along with the gRPC requests between clients, Alphas and Zeros, and along
+// with the Raft proposals of the Alphas, so that a request is traced end to end. The sampled
+// traces are exported to Jaeger, or to any OpenTelemetry collector via OTLP/HTTP with JSON.
+
+const (
+	traceQueueSize     = 4096
+	traceFlushInterval = 5 * time.Second
+)
+
+// RegisterTracingFlags registers the flags setting up tracing, for Alphas and Zeros alike.
+func RegisterTracingFlags(flag *pflag.FlagSet) {
+	flag.Float64("trace", 1.0, "The ratio of queries to trace.")
+	flag.String("jaeger.collector", "", "Send opencensus traces to Jaeger.")
+	flag.String("otlp.traces", "", "Send opencensus traces to this OTLP/HTTP traces URL, like"+
+		" http://localhost:4318/v1/traces.")
+}
+
+// traceRatio holds the bits of the float64 ratio of the requests traced, accessed atomically.
+var traceRatio uint64
+
+// SetTraceRatio sets the ratio of the requests traced, between 0 and 1.
+func SetTraceRatio(ratio float64) error {
+	if err := checkTraceRatio(ratio); err != nil {
+		return err
+	}
+	atomic.StoreUint64(&traceRatio, math.Float64bits(ratio))
+	otrace.ApplyConfig(otrace.Config{DefaultSampler: otrace.ProbabilitySampler(ratio)})
+	return nil
+}
+
+// TraceRatio returns the ratio of the requests traced.
+func TraceRatio() float64 {
+	return math.Float64frombits(atomic.LoadUint64(&traceRatio))
+}
+
+func checkTraceRatio(ratio float64) error {
+	if ratio < 0 || ratio > 1 {
+		return Errorf("The ratio of traces must be between 0 and 1, not %v", ratio)
+	}
+	return nil
+}
+
+// TraceTunable is the Tunable of the ratio of the requests traced, set by --trace.
+func TraceTunable() Tunable {
+	return FloatTunable("trace", TraceRatio, checkTraceRatio,
+		func(ratio float64) { Check(SetTraceRatio(ratio)) })
+}
+
+// RegisterTraceExportersFromConfig registers the trace exporters set up by the flags registered
+// with RegisterTracingFlags. The spans are exported with service as their service name.
+func RegisterTraceExportersFromConfig(v *viper.Viper, service string) error {
+	if collector := v.GetString("jaeger.collector"); len(collector) > 0 {
+		// Port details: https://www.jaegertracing.io/docs/getting-started/
+		// Default collectorEndpointURI := "http://localhost:14268"
+		je, err := jaeger.NewExporter(jaeger.Options{
+			Endpoint:    collector,
+			ServiceName: service,
+		})
+		if err != nil {
+			return Wrapf(err, "While creating the Jaeger exporter")
+		}
+		otrace.RegisterExporter(je)
+	}
+	if url := v.GetString("otlp.traces"); len(url) > 0 {
+		otrace.RegisterExporter(newOtlpTraceExporter(url, service))
+	}
+	return nil
+}
+
+// otlpTraceExporter sends the spans in batches as OpenTelemetry traces, via OTLP/HTTP with JSON
+// encoding.
+type otlpTraceExporter struct {
+	url     string
+	service string
+	spans   chan *otrace.SpanData
+	dropped uint64 // Accessed atomically.
+}
+
+func newOtlpTraceExporter(url, service string) *otlpTraceExporter {
+	e := &otlpTraceExporter{
+		url:     url,
+		service: service,
+		spans:   make(chan *otrace.SpanData, traceQueueSize),
+	}
+	go e.run()
+	return e
+}
+
+// ExportSpan queues sd for the next batch. Spans are dropped while the queue is full, rather than
+// slowing down the requests they trace.
+func (e *otlpTraceExporter) ExportSpan(sd *otrace.SpanData) {
+	select {
+	case e.spans <- sd:
+	default:
+		atomic.AddUint64(&e.dropped, 1)
+	}
+}
+
+func (e *otlpTraceExporter) run() {
+	ticker := time.NewTicker(traceFlushInterval)
+	defer ticker.Stop()
+
+	var batch []*otrace.SpanData
+	flush := func() {
+		if dropped := atomic.SwapUint64(&e.dropped, 0); dropped > 0 {
+			glog.Warningf("Dropped %d spans, as the OTLP trace exporter couldn't keep up", dropped)
+		}
+		if len(batch) == 0 {
+			return
+		}
+		body, err := encodeOtlpSpans(batch, e.service)
+		if err == nil {
+			err = postRequest(e.url, "application/json", body, nil)
+		}
+		if err != nil {
+			glog.Warningf("While exporting %d spans to %s: %v", len(batch), e.url, err)
+		}
+		batch = batch[:0]
+	}
+	for {
+		select {
+		case sd := <-e.spans:
+			batch = append(batch, sd)
+			if len(batch) >= pushBatchSize {
+				flush()
+			}
+		case <-ticker.C:
+			flush()
+		}
+	}
+}
+
+type otlpKeyValue struct {
+	Key   string                 `json:"key"`
+	Value map[string]interface{} `json:"value"`
+}
+
+type otlpEvent struct {
+	TimeUnixNano string         `json:"timeUnixNano"`
+	Name         string         `json:"name"`
+	Attributes   []otlpKeyValue `json:"attributes,omitempty"`
+}
+
+type otlpStatus struct {
+	Code    int    `json:"code,omitempty"`
+	Message string `json:"message,omitempty"`
+}
+
+type otlpSpan struct {
+	TraceId           string         `json:"traceId"`
+	SpanId            string         `json:"spanId"`
+	ParentSpanId      string         `json:"parentSpanId,omitempty"`
+	Name              string         `json:"name"`
+	Kind              int            `json:"kind"`
+	StartTimeUnixNano string         `json:"startTimeUnixNano"`
+	EndTimeUnixNano   string         `json:"endTimeUnixNano"`
+	Attributes        []otlpKeyValue `json:"attributes,omitempty"`
+	Events            []otlpEvent    `json:"events,omitempty"`
+	Status            otlpStatus     `json:"status"`
+}
+
+// otlpAttributes converts the attributes of OpenCensus, which are strings, bools, int64s or
+// float64s, sorted by key.
+func otlpAttributes(attrs map[string]interface{}) []otlpKeyValue {
+	var out []otlpKeyValue
+	for k, v := range attrs {
+		kv := otlpKeyValue{Key: k}
+		switch v := v.(type) {
+		case bool:
+			kv.Value = map[string]interface{}{"boolValue": v}
+		case int64:
+			// 64 bit integers are strings in the JSON encoding of protobuf.
+			kv.Value = map[string]interface{}{"intValue": strconv.FormatInt(v, 10)}
+		case float64:
+			kv.Value = map[string]interface{}{"doubleValue": v}
+		default:
+			kv.Value = map[string]interface{}{"stringValue": fmt.Sprint(v)}
+		}
+		out = append(out, kv)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Key < out[j].Key })
+	return out
+}
+
+func encodeOtlpSpans(spans []*otrace.SpanData, service string) ([]byte, error) {
+	out := make([]otlpSpan, 0, len(spans))
+	for _, sd := range spans {
+		span := otlpSpan{
+			TraceId:           hex.EncodeToString(sd.TraceID[:]),
+			SpanId:            hex.EncodeToString(sd.SpanID[:]),
+			Name:              sd.Name,
+			StartTimeUnixNano: strconv.FormatInt(sd.StartTime.UnixNano(), 10),
+			EndTimeUnixNano:   strconv.FormatInt(sd.EndTime.UnixNano(), 10),
+			Attributes:        otlpAttributes(sd.Attributes),
+		}
+		if sd.ParentSpanID != (otrace.SpanID{}) {
+			span.ParentSpanId = hex.EncodeToString(sd.ParentSpanID[:])
+		}
+		// The kinds of OTLP are internal = 1, server = 2 and client = 3.
+		switch sd.SpanKind {
+		case otrace.SpanKindServer:
+			span.Kind = 2
+		case otrace.SpanKindClient:
+			span.Kind = 3
+		default:
+			span.Kind = 1
+		}
+		for _, a := range sd.Annotations {
+			span.Events = append(span.Events, otlpEvent{
+				TimeUnixNano: strconv.FormatInt(a.Time.UnixNano(), 10),
+				Name:         a.Message,
+				Attributes:   otlpAttributes(a.Attributes),
+			})
+		}
+		// OpenCensus has the codes of gRPC, of which only OK isn't an error.
+		if sd.Code != 0 {
+			span.Status = otlpStatus{Code: 2, Message: sd.Message}
+		}
+		out = append(out, span)
+	}
+	req := map[string]interface{}{
+		"resourceSpans": []interface{}{map[string]interface{}{
+			"resource": map[string]interface{}{
+				"attributes": []otlpAttr{newOtlpAttr("service.name", service)},
+			},
+			"scopeSpans": []interface{}{map[string]interface{}{
+				"scope": map[string]string{"name": pushedServiceLabel},
+				"spans": out,
+			}},
+		}},
+	}
+	return json.Marshal(req)
+}
@@ -0,0 +1,75 @@
+/*
+ * Copyright 2018 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package x
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	otrace "go.opencensus.io/trace"
+)
+
+func TestEncodeOtlpSpans(t *testing.T) {
+	parent := &otrace.SpanData{
+		SpanContext: otrace.SpanContext{TraceID: otrace.TraceID{1}, SpanID: otrace.SpanID{2}},
+		Name:        "Server.Query",
+		SpanKind:    otrace.SpanKindServer,
+		StartTime:   time.Unix(1, 0),
+		EndTime:     time.Unix(2, 0),
+		Attributes:  map[string]interface{}{"local": true, "group": int64(1), "attr": "name"},
+		Annotations: []otrace.Annotation{{Time: time.Unix(1, 5), Message: "Done"}},
+	}
+	child := &otrace.SpanData{
+		SpanContext:  otrace.SpanContext{TraceID: otrace.TraceID{1}, SpanID: otrace.SpanID{3}},
+		ParentSpanID: otrace.SpanID{2},
+		Name:         "worker.ServeTask",
+		Status:       otrace.Status{Code: 14, Message: "Unavailable"},
+	}
+	b, err := encodeOtlpSpans([]*otrace.SpanData{parent, child}, "dgraph.alpha")
+	require.NoError(t, err)
+
+	var req struct {
+		ResourceSpans []struct {
+			ScopeSpans []struct {
+				Spans []otlpSpan `json:"spans"`
+			} `json:"scopeSpans"`
+		} `json:"resourceSpans"`
+	}
+	require.NoError(t, json.Unmarshal(b, &req))
+	spans := req.ResourceSpans[0].ScopeSpans[0].Spans
+	require.Len(t, spans, 2)
+
+	require.Equal(t, "01000000000000000000000000000000", spans[0].TraceId)
+	require.Equal(t, "0200000000000000", spans[0].SpanId)
+	require.Empty(t, spans[0].ParentSpanId)
+	require.Equal(t, 2, spans[0].Kind)
+	require.Equal(t, "1000000000", spans[0].StartTimeUnixNano)
+	require.Equal(t, "2000000000", spans[0].EndTimeUnixNano)
+	require.Equal(t, []otlpKeyValue{
+		{Key: "attr", Value: map[string]interface{}{"stringValue": "name"}},
+		{Key: "group", Value: map[string]interface{}{"intValue": "1"}},
+		{Key: "local", Value: map[string]interface{}{"boolValue": true}},
+	}, spans[0].Attributes)
+	require.Equal(t, "Done", spans[0].Events[0].Name)
+	require.Equal(t, 0, spans[0].Status.Code)
+
+	require.Equal(t, "0200000000000000", spans[1].ParentSpanId)
+	require.Equal(t, 1, spans[1].Kind)
+	require.Equal(t, otlpStatus{Code: 2, Message: "Unavailable"}, spans[1].Status)
+}
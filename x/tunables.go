@@ -0,0 +1,188 @@
+/*
+ * Copyright 2017-2018 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package x
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang/glog"
+)
+
+// Some of the options of Alpha and Zero can be changed while they run, without restarting them,
+// through /admin/config. A GET reports the effective value of every one of them, and a POST of a
+// JSON object sets some, like {"trace": 0.1, "slow_query": "2s"}. The new values are all checked
+// before any of them is applied, so that none is if one is invalid.
+
+// Tunable is an option which can be changed at runtime.
+type Tunable struct {
+	Name string
+	// Get returns the effective value.
+	Get func() interface{}
+	// Check parses and checks a new value, and returns the function applying it.
+	Check func(val string) (apply func(), err error)
+}
+
+// Tunables are the options of a server which can be changed at runtime.
+type Tunables struct {
+	sync.Mutex
+	all map[string]Tunable
+}
+
+func NewTunables(ts ...Tunable) *Tunables {
+	t := &Tunables{all: make(map[string]Tunable)}
+	for _, tun := range ts {
+		AssertTruef(t.all[tun.Name].Get == nil, "Tunable %s registered twice", tun.Name)
+		t.all[tun.Name] = tun
+	}
+	return t
+}
+
+// Values returns the effective value of every option.
+func (t *Tunables) Values() map[string]interface{} {
+	t.Lock()
+	defer t.Unlock()
+	vals := make(map[string]interface{}, len(t.all))
+	for name, tun := range t.all {
+		vals[name] = tun.Get()
+	}
+	return vals
+}
+
+func (t *Tunables) names() string {
+	var names []string
+	for name := range t.all {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return strings.Join(names, ", ")
+}
+
+// Set checks the new values of the options, and applies them if they're all valid.
+func (t *Tunables) Set(vals map[string]string) error {
+	t.Lock()
+	defer t.Unlock()
+	var applies []func()
+	for name, val := range vals {
+		tun, ok := t.all[name]
+		if !ok {
+			return Errorf("Option %s can't be changed at runtime. Those which can are: %s",
+				name, t.names())
+		}
+		apply, err := tun.Check(val)
+		if err != nil {
+			return Wrapf(err, "Invalid %s: %q", name, val)
+		}
+		applies = append(applies, apply)
+	}
+	for _, apply := range applies {
+		apply()
+	}
+	for name, val := range vals {
+		glog.Infof("Option %s set to %s at runtime", name, val)
+	}
+	return nil
+}
+
+// Handler reports the effective options (GET), and sets those of the JSON object posted (POST).
+func (t *Tunables) Handler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	switch r.Method {
+	case http.MethodGet:
+	case http.MethodPost:
+		var body map[string]interface{}
+		if !ParseRequest(w, r, &body) {
+			return
+		}
+		vals := make(map[string]string, len(body))
+		for name, val := range body {
+			switch val := val.(type) {
+			case string:
+				vals[name] = val
+			case float64:
+				vals[name] = strconv.FormatFloat(val, 'f', -1, 64)
+			case bool:
+				vals[name] = strconv.FormatBool(val)
+			default:
+				SetStatus(w, ErrorInvalidRequest, fmt.Sprintf("Invalid value of %s: %v", name, val))
+				return
+			}
+		}
+		if err := t.Set(vals); err != nil {
+			SetStatus(w, ErrorInvalidRequest, err.Error())
+			return
+		}
+	default:
+		SetStatus(w, ErrorInvalidMethod, "Invalid method")
+		return
+	}
+	Reply(w, t.Values())
+}
+
+// FloatTunable is a Tunable of a float64, checked by check if not nil, then set by set.
+func FloatTunable(name string, get func() float64, check func(float64) error,
+	set func(float64)) Tunable {
+	return Tunable{
+		Name: name,
+		Get:  func() interface{} { return get() },
+		Check: func(val string) (func(), error) {
+			f, err := strconv.ParseFloat(val, 64)
+			if err == nil && check != nil {
+				err = check(f)
+			}
+			return func() { set(f) }, err
+		},
+	}
+}
+
+// IntTunable is a Tunable of an int64, checked by check if not nil, then set by set.
+func IntTunable(name string, get func() int64, check func(int64) error,
+	set func(int64)) Tunable {
+	return Tunable{
+		Name: name,
+		Get:  func() interface{} { return get() },
+		Check: func(val string) (func(), error) {
+			i, err := strconv.ParseInt(val, 10, 64)
+			if err == nil && check != nil {
+				err = check(i)
+			}
+			return func() { set(i) }, err
+		},
+	}
+}
+
+// DurationTunable is a Tunable of a time.Duration, like 10m, checked by check if not nil, then
+// set by set. Its value is reported as a string.
+func DurationTunable(name string, get func() time.Duration, check func(time.Duration) error,
+	set func(time.Duration)) Tunable {
+	return Tunable{
+		Name: name,
+		Get:  func() interface{} { return get().String() },
+		Check: func(val string) (func(), error) {
+			d, err := time.ParseDuration(val)
+			if err == nil && check != nil {
+				err = check(d)
+			}
+			return func() { set(d) }, err
+		},
+	}
+}
@@ -0,0 +1,77 @@
+/*
+ * Copyright 2017-2018 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package x
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTunablesSet(t *testing.T) {
+	ratio, ttl := 0.5, time.Minute
+	tuns := NewTunables(
+		FloatTunable("ratio", func() float64 { return ratio },
+			func(f float64) error {
+				if f > 1 {
+					return Errorf("too high")
+				}
+				return nil
+			},
+			func(f float64) { ratio = f }),
+		DurationTunable("ttl", func() time.Duration { return ttl }, nil,
+			func(d time.Duration) { ttl = d }))
+	require.Equal(t, map[string]interface{}{"ratio": 0.5, "ttl": "1m0s"}, tuns.Values())
+
+	require.NoError(t, tuns.Set(map[string]string{"ratio": "0.25", "ttl": "10s"}))
+	require.Equal(t, 0.25, ratio)
+	require.Equal(t, 10*time.Second, ttl)
+
+	// None is applied if one is invalid.
+	require.Error(t, tuns.Set(map[string]string{"ratio": "2", "ttl": "1s"}))
+	require.Error(t, tuns.Set(map[string]string{"ratio": "0.1", "ttl": "soon"}))
+	require.Error(t, tuns.Set(map[string]string{"ratio": "0.1", "other": "1"}))
+	require.Equal(t, 0.25, ratio)
+	require.Equal(t, 10*time.Second, ttl)
+}
+
+func TestTunablesHandler(t *testing.T) {
+	rate := int64(10)
+	tuns := NewTunables(IntTunable("rate", func() int64 { return rate }, nil,
+		func(i int64) { rate = i }))
+
+	serve := func(method, body string) map[string]interface{} {
+		w := httptest.NewRecorder()
+		tuns.Handler(w, httptest.NewRequest(method, "/admin/config", strings.NewReader(body)))
+		var res map[string]interface{}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &res))
+		return res
+	}
+	require.Equal(t, map[string]interface{}{"rate": 10.0}, serve(http.MethodGet, ""))
+	require.Equal(t, map[string]interface{}{"rate": 20.0},
+		serve(http.MethodPost, `{"rate": 20}`))
+	require.Equal(t, int64(20), rate)
+
+	res := serve(http.MethodPost, `{"rate": "fast"}`)
+	require.Contains(t, res, "errors")
+	require.Equal(t, int64(20), rate)
+}
@@ -47,6 +47,7 @@ const (
 	ErrorNoPermission       = "ErrorNoPermission"
 	ErrorInvalidMutation    = "ErrorInvalidMutation"
 	ErrorServiceUnavailable = "ErrorServiceUnavailable"
+	ErrorTooManyRequests    = "ErrorTooManyRequests"
 	ValidHostnameRegex      = "^(([a-zA-Z0-9]|[a-zA-Z0-9][a-zA-Z0-9\\-]*[a-zA-Z0-9])\\.)*([A-Za-z0-9]|[A-Za-z0-9][A-Za-z0-9\\-]*[A-Za-z0-9])$"
 	// When changing this value also remember to change in in client/client.go:DeleteEdges.
 	Star = "_STAR_ALL"
@@ -73,6 +74,8 @@ var (
 	// Useful for running multiple servers on the same machine.
 	regExpHostName    = regexp.MustCompile(ValidHostnameRegex)
 	ErrReuseRemovedId = errors.New("Reusing RAFT index of a removed node.")
+	// ErrNoDiskUsage is returned by DiskUsage on systems it doesn't support.
+	ErrNoDiskUsage = errors.New("Disk usage isn't supported")
 )
 
 // WhiteSpace Replacer removes spaces and tabs from a string.